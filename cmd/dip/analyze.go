@@ -0,0 +1,331 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Johanneslueke/dip-client/internal/analyze"
+	"github.com/Johanneslueke/dip-client/internal/config"
+)
+
+// analyzeFormats are the values of -o of "dip analyze durations"
+var analyzeFormats = []string{"text", "csv", "json"}
+
+// reportFormats are the values of -o of "dip analyze <report>"
+var reportFormats = []string{"markdown", "csv", "json"}
+
+// runAnalyze implements "dip analyze": analyses over the local database
+func runAnalyze(args []string) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		analyzeUsage(os.Stderr)
+		os.Exit(2)
+	}
+	switch args[0] {
+	case "durations":
+		runDurations(args[1:])
+	case "list":
+		listReports()
+	default:
+		r, err := analyze.FindReport(args[0])
+		if errors.Is(err, analyze.ErrUnknownReport) {
+			fmt.Fprintf(os.Stderr, "dip: %v\n\n", err)
+			analyzeUsage(os.Stderr)
+			os.Exit(2)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		runReport(r, args[1:])
+	}
+}
+
+// runDurations implements "dip analyze durations": the phase durations of the
+// legislative process, aggregated by a dimension
+func runDurations(args []string) {
+	fs := flag.NewFlagSet("analyze durations", flag.ExitOnError)
+	var (
+		dbPath       = fs.String(config.DB, config.Default(config.DB), "SQLite database written by sync-all")
+		profile      = fs.String("profile", defaultProfile, config.ProfileUsage)
+		format       = fs.String("o", "text", "Output format: "+strings.Join(analyzeFormats, ", "))
+		by           = fs.String("by", analyze.ByWahlperiode, "Group by "+strings.Join(analyze.Dimensions, ", "))
+		rows         = fs.String("rows", analyze.RowsGroups, "Rows to write: "+strings.Join(analyze.DurationRows, ", "))
+		outliers     = fs.Int("outliers", analyze.DefaultOutliers, "Maximum number of outliers per phase, 0 for none")
+		wahlperioden wahlperiodeList
+	)
+	fs.Var(&wahlperioden, "wp", "Wahlperioden, e.g. 20, 19,20 or 18-20 (default all)")
+	fs.Var(&wahlperioden, "wahlperiode", "Alias for -wp")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: dip analyze durations [flags]\n\n")
+		fmt.Fprintf(out, "Derives the phase durations in days of each Gesetzgebung Vorgang from its\n")
+		fmt.Fprintf(out, "Vorgangspositionen (Einbringung, 1./2./3. Beratung, 2. Durchgang im Bundesrat),\n")
+		fmt.Fprintf(out, "Verkündung and Inkrafttreten. Prints the median per phase and group and lists\n")
+		fmt.Fprintf(out, "the outliers beyond 1.5 times the interquartile range. A Gesetz counts for each\n")
+		fmt.Fprintf(out, "of its Initiativen and Sachgebiete. -rows gesetze writes the durations per Gesetz.\n\n")
+		fs.PrintDefaults()
+	}
+	if positional := parseInterspersed(fs, args); len(positional) > 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	useGlobalLocal(fs)
+	applyConfig(fs, *profile)
+	for _, check := range []struct {
+		name, value string
+		valid       []string
+	}{
+		{"o", *format, analyzeFormats},
+		{"by", *by, analyze.Dimensions},
+		{"rows", *rows, analyze.DurationRows},
+	} {
+		if !slices.Contains(check.valid, check.value) {
+			fmt.Fprintf(fs.Output(), "invalid -%s %q: must be one of %s\n\n", check.name, check.value, strings.Join(check.valid, ", "))
+			fs.Usage()
+			os.Exit(2)
+		}
+	}
+	if *outliers == 0 {
+		*outliers = -1
+	}
+
+	s := openLocalDB(*dbPath)
+	defer s.Close()
+
+	report, err := analyze.Durations(context.Background(), s.DB(), analyze.DurationOptions{
+		Wahlperioden: wahlperioden,
+		By:           *by,
+		Outliers:     *outliers,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(report.Gesetze) == 0 && *format == "text" {
+		fmt.Println("No Gesetze found")
+		return
+	}
+
+	switch *format {
+	case "json":
+		var v any = report
+		switch *rows {
+		case analyze.RowsOutliers:
+			v = report.Outliers
+		case analyze.RowsGesetze:
+			v = report.Gesetze
+			if report.Gesetze == nil {
+				v = []analyze.Gesetz{}
+			}
+		}
+		output, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			log.Fatalf("Marshal error: %v", err)
+		}
+		fmt.Println(string(output))
+		return
+	case "csv":
+		err = analyze.WriteDurationsCSV(os.Stdout, report, *rows)
+	default:
+		if *rows == analyze.RowsGesetze {
+			err = analyze.WriteGesetzeText(os.Stdout, report.Gesetze)
+		} else {
+			err = analyze.WriteDurationsText(os.Stdout, report)
+		}
+	}
+	if err != nil {
+		exitOnBrokenPipe(err)
+		log.Fatal(err)
+	}
+}
+
+// runReport implements "dip analyze <report>": runs an embedded report and
+// prints it or writes the versioned files of the run
+func runReport(r analyze.Report, args []string) {
+	fs := flag.NewFlagSet("analyze "+r.Name, flag.ExitOnError)
+	var (
+		dbPath       = fs.String(config.DB, config.Default(config.DB), "SQLite database written by sync-all")
+		profile      = fs.String("profile", defaultProfile, config.ProfileUsage)
+		format       = fs.String("o", "markdown", "Output format: "+strings.Join(reportFormats, ", "))
+		section      = fs.Int("section", 0, "Section to write with -o csv, starting at 1")
+		outDir       = fs.String("out", "", "Write the run as Markdown, JSON and CSV files to this directory instead of stdout")
+		fraktionen   = new(string)
+		wahlperioden wahlperiodeList
+	)
+	fs.Var(&wahlperioden, "wp", "Wahlperioden, e.g. 20 or 18-20 (default all)")
+	fs.Var(&wahlperioden, "wahlperiode", "Alias for -wp")
+	if slices.Contains(r.Params, analyze.ParamFraktion) {
+		fs.StringVar(fraktionen, "fraktion", "", "Fraktionen, separated by commas, e.g. SPD,FDP (default all)")
+	}
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: dip analyze %s [flags]\n\n", r.Name)
+		fmt.Fprintf(out, "%s (version %s, derived from %s)\n\n", r.Title, r.Version, r.Source)
+		fmt.Fprintf(out, "%s\n\nSections:\n", wrap(r.Description, 80))
+		for i, s := range r.Sections {
+			fmt.Fprintf(out, "  %d. %s\n", i+1, s.Title)
+		}
+		fmt.Fprintf(out, "\nWith -out, each run is written to <dir>/%s/ as <time>.md, <time>.json and one\n", r.Name)
+		fmt.Fprintf(out, "<time>-<nn>-<section>.csv per section, and copied to latest.*.\n\n")
+		fs.PrintDefaults()
+	}
+	if positional := parseInterspersed(fs, args); len(positional) > 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	useGlobalLocal(fs)
+	applyConfig(fs, *profile)
+	if !slices.Contains(reportFormats, *format) {
+		fmt.Fprintf(fs.Output(), "invalid -o %q: must be one of %s\n\n", *format, strings.Join(reportFormats, ", "))
+		fs.Usage()
+		os.Exit(2)
+	}
+	params := analyze.ReportParams{}
+	if len(wahlperioden) > 0 {
+		params.WahlperiodeFrom, params.WahlperiodeTo = slices.Min(wahlperioden), slices.Max(wahlperioden)
+		if len(wahlperioden) != params.WahlperiodeTo-params.WahlperiodeFrom+1 {
+			fmt.Fprintf(fs.Output(), "invalid -wp %s: reports take a range, e.g. 18-20\n\n", wahlperioden.String())
+			fs.Usage()
+			os.Exit(2)
+		}
+	}
+	for _, f := range strings.Split(*fraktionen, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			params.Fraktionen = append(params.Fraktionen, f)
+		}
+	}
+	if *outDir == "" && *format == "csv" && (*section < 1 || *section > len(r.Sections)) {
+		fmt.Fprintf(fs.Output(), "-o csv writes one section: select it with -section 1 to %d, or write all with -out\n\n", len(r.Sections))
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	s := openLocalDB(*dbPath)
+	defer s.Close()
+
+	result, err := analyze.RunReport(context.Background(), s.DB(), r, params)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *outDir != "" {
+		written, err := analyze.WriteReportFiles(*outDir, result)
+		if err != nil {
+			log.Fatalf("Failed to write the report: %v", err)
+		}
+		for _, path := range written {
+			fmt.Println(path)
+		}
+		return
+	}
+	switch *format {
+	case "json":
+		output, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			log.Fatalf("Marshal error: %v", err)
+		}
+		fmt.Println(string(output))
+		return
+	case "csv":
+		err = analyze.WriteTableCSV(os.Stdout, result.Sections[*section-1])
+	default:
+		err = analyze.WriteReportMarkdown(os.Stdout, result)
+	}
+	if err != nil {
+		exitOnBrokenPipe(err)
+		log.Fatal(err)
+	}
+}
+
+// listReports implements "dip analyze list"
+func listReports() {
+	reports, err := analyze.Reports()
+	if err != nil {
+		log.Fatal(err)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "REPORT\tVERSION\tPARAMS\tTITLE")
+	fmt.Fprintf(tw, "durations\t\twahlperiode\tDuration of the phases of the legislative process\n")
+	for _, r := range reports {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Name, r.Version, strings.Join(r.Params, ", "), r.Title)
+	}
+	if err := tw.Flush(); err != nil {
+		exitOnBrokenPipe(err)
+		log.Fatal(err)
+	}
+}
+
+// wrap breaks text into lines of at most width characters
+func wrap(text string, width int) string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len([]rune(line))+1+len([]rune(word)) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	return strings.Join(append(lines, line), "\n")
+}
+
+// wahlperiodeList is a flag of Wahlperioden as list and ranges, e.g. 19,20 or 18-20
+type wahlperiodeList []int
+
+func (l *wahlperiodeList) String() string {
+	if l == nil {
+		return ""
+	}
+	var parts []string
+	for _, wp := range *l {
+		parts = append(parts, strconv.Itoa(wp))
+	}
+	return strings.Join(parts, ",")
+}
+
+func (l *wahlperiodeList) Set(value string) error {
+	for _, part := range strings.Split(value, ",") {
+		from, to, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		first, err := strconv.Atoi(from)
+		if err != nil {
+			return fmt.Errorf("invalid Wahlperiode %q", part)
+		}
+		last := first
+		if isRange {
+			if last, err = strconv.Atoi(to); err != nil || last < first {
+				return fmt.Errorf("invalid range %q", part)
+			}
+		}
+		for wp := first; wp <= last; wp++ {
+			if !slices.Contains(*l, wp) {
+				*l = append(*l, wp)
+			}
+		}
+	}
+	return nil
+}
+
+func analyzeUsage(out *os.File) {
+	fmt.Fprintf(out, "Usage: dip analyze <analysis> [flags]\n\n")
+	fmt.Fprintf(out, "Analyses over the local database:\n\n")
+	fmt.Fprintf(out, "  %-22s %s\n", "durations", "Duration of the phases of the legislative process")
+	if reports, err := analyze.Reports(); err == nil {
+		for _, r := range reports {
+			fmt.Fprintf(out, "  %-22s %s\n", r.Name, r.Title)
+		}
+	}
+	fmt.Fprintf(out, "  %-22s %s\n\n", "list", "The analyses with their versions and parameters")
+	fmt.Fprintf(out, "e.g. dip analyze durations -wp 19-20 -by initiative -o csv, or\n")
+	fmt.Fprintf(out, "dip analyze fraktion-collaboration -wp 19-20 -fraktion SPD -out findings.\n")
+	fmt.Fprintf(out, "Run \"dip help analyze <analysis>\" for the flags.\n")
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/Johanneslueke/dip-client/internal/analyze"
+)
+
+// runCompletion implements "dip completion bash|zsh|fish". The scripts are
+// generated from the resources and their flags, so they match the binary.
+func runCompletion(args []string) {
+	if len(args) != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: dip completion <bash|zsh|fish>\n\n")
+		fmt.Fprintf(os.Stderr, "  bash: source <(dip completion bash)\n")
+		fmt.Fprintf(os.Stderr, "  zsh:  source <(dip completion zsh)\n")
+		fmt.Fprintf(os.Stderr, "  fish: dip completion fish > ~/.config/fish/completions/dip.fish\n")
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "bash":
+		writeBashCompletion(os.Stdout)
+	case "zsh":
+		// zsh runs the bash completion via bashcompinit
+		fmt.Fprintln(os.Stdout, "autoload -U +X compinit && compinit")
+		fmt.Fprintln(os.Stdout, "autoload -U +X bashcompinit && bashcompinit")
+		writeBashCompletion(os.Stdout)
+	case "fish":
+		writeFishCompletion(os.Stdout)
+	default:
+		fmt.Fprintf(os.Stderr, "dip: unsupported shell %q: must be bash, zsh or fish\n", args[0])
+		os.Exit(2)
+	}
+}
+
+// completionFlag is a flag of a subcommand, with its allowed values if any
+type completionFlag struct {
+	name   string
+	values []string
+}
+
+// verbFlags returns the flags of "dip <resource> <verb>"
+func verbFlags(r resource, verb string) []completionFlag {
+	var flags []completionFlag
+	newCommand(r, verb).fs.VisitAll(func(f *flag.Flag) {
+		flags = append(flags, completionFlag{name: f.Name, values: flagValues()[f.Name]})
+	})
+	return flags
+}
+
+// flagValues returns the allowed values of the flags that have a fixed set
+func flagValues() map[string][]string {
+	values := map[string][]string{"o": outputFormats}
+	for name, info := range params {
+		if len(info.values) > 0 {
+			values[name] = info.values
+		}
+	}
+	return values
+}
+
+func resourceNames() []string {
+	var names []string
+	for _, r := range resources {
+		names = append(names, r.name)
+	}
+	return names
+}
+
+func commandNames() []string {
+	return append(resourceNames(), "search", "gesetz", "analyze", "export", "diff", "config", "completion", "help")
+}
+
+// analysisNames are the subcommands of "dip analyze"
+func analysisNames() []string {
+	names := []string{"durations", "list"}
+	reports, _ := analyze.Reports()
+	for _, r := range reports {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func verbNames() []string {
+	var names []string
+	for _, v := range verbs {
+		names = append(names, v.name)
+	}
+	return names
+}
+
+func writeBashCompletion(w io.Writer) {
+	var b strings.Builder
+	b.WriteString("# bash completion for dip, generated by \"dip completion bash\"\n")
+	b.WriteString("_dip() {\n")
+	b.WriteString("    local cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	b.WriteString("    if [[ $COMP_CWORD -eq 1 ]]; then\n")
+	fmt.Fprintf(&b, "        COMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(commandNames(), " "))
+	b.WriteString("        return\n")
+	b.WriteString("    fi\n")
+	b.WriteString("    if [[ $COMP_CWORD -eq 2 ]]; then\n")
+	b.WriteString("        case \"${COMP_WORDS[1]}\" in\n")
+	fmt.Fprintf(&b, "        completion) COMPREPLY=($(compgen -W \"bash zsh fish\" -- \"$cur\")) ;;\n")
+	fmt.Fprintf(&b, "        help) COMPREPLY=($(compgen -W %q -- \"$cur\")) ;;\n", strings.Join(commandNames(), " "))
+	b.WriteString("        search) COMPREPLY=($(compgen -W \"-db -profile -type -wahlperiode -limit -stem -json\" -- \"$cur\")) ;;\n")
+	b.WriteString("        config) COMPREPLY=($(compgen -W \"show\" -- \"$cur\")) ;;\n")
+	b.WriteString("        gesetz) COMPREPLY=($(compgen -W \"trace list\" -- \"$cur\")) ;;\n")
+	fmt.Fprintf(&b, "        analyze) COMPREPLY=($(compgen -W %q -- \"$cur\")) ;;\n", strings.Join(analysisNames(), " "))
+	b.WriteString("        export) COMPREPLY=($(compgen -W \"network\" -- \"$cur\")) ;;\n")
+	fmt.Fprintf(&b, "        diff) COMPREPLY=($(compgen -W %q -- \"$cur\")) ;;\n", strings.Join(resourceNames(), " "))
+	for _, r := range resources {
+		fmt.Fprintf(&b, "        %s) COMPREPLY=($(compgen -W %q -- \"$cur\")) ;;\n", r.name, strings.Join(verbNames(), " "))
+	}
+	b.WriteString("        esac\n")
+	b.WriteString("        return\n")
+	b.WriteString("    fi\n")
+	b.WriteString("    case \"$prev\" in\n")
+	values := flagValues()
+	for _, name := range sortedKeys(values) {
+		fmt.Fprintf(&b, "    -%s) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", name, strings.Join(values[name], " "))
+	}
+	b.WriteString("    esac\n")
+	b.WriteString("    case \"${COMP_WORDS[1]} ${COMP_WORDS[2]}\" in\n")
+	for _, r := range resources {
+		for _, v := range verbs {
+			var names []string
+			for _, f := range verbFlags(r, v.name) {
+				names = append(names, "-"+f.name)
+			}
+			fmt.Fprintf(&b, "    \"%s %s\") COMPREPLY=($(compgen -W %q -- \"$cur\")) ;;\n", r.name, v.name, strings.Join(names, " "))
+		}
+	}
+	b.WriteString("    esac\n")
+	b.WriteString("}\n")
+	b.WriteString("complete -F _dip dip\n")
+	io.WriteString(w, b.String())
+}
+
+func writeFishCompletion(w io.Writer) {
+	var b strings.Builder
+	b.WriteString("# fish completion for dip, generated by \"dip completion fish\"\n")
+	b.WriteString("complete -c dip -f\n")
+	for _, r := range resources {
+		fmt.Fprintf(&b, "complete -c dip -n __fish_use_subcommand -a %s -d %q\n", r.name, r.summary)
+	}
+	b.WriteString("complete -c dip -n __fish_use_subcommand -a search -d \"Full-text search over the local database\"\n")
+	b.WriteString("complete -c dip -n __fish_use_subcommand -a config -d \"Show the settings and where they come from\"\n")
+	b.WriteString("complete -c dip -n \"__fish_seen_subcommand_from config\" -a show\n")
+	b.WriteString("complete -c dip -n __fish_use_subcommand -a gesetz -d \"Legislative process of Gesetze in the local database\"\n")
+	b.WriteString("complete -c dip -n \"__fish_seen_subcommand_from gesetz\" -a \"trace list\"\n")
+	b.WriteString("complete -c dip -n __fish_use_subcommand -a analyze -d \"Analyses over the local database\"\n")
+	fmt.Fprintf(&b, "complete -c dip -n \"__fish_seen_subcommand_from analyze\" -a %q\n", strings.Join(analysisNames(), " "))
+	b.WriteString("complete -c dip -n __fish_use_subcommand -a export -d \"Exports of the local database\"\n")
+	b.WriteString("complete -c dip -n \"__fish_seen_subcommand_from export\" -a network\n")
+	b.WriteString("complete -c dip -n __fish_use_subcommand -a diff -d \"Compare entities of the API with the local database\"\n")
+	fmt.Fprintf(&b, "complete -c dip -n \"__fish_seen_subcommand_from diff\" -a %q\n", strings.Join(resourceNames(), " "))
+	b.WriteString("complete -c dip -n __fish_use_subcommand -a completion -d \"Print the shell completion script\"\n")
+	b.WriteString("complete -c dip -n __fish_use_subcommand -a help -d \"Show help\"\n")
+	b.WriteString("complete -c dip -n \"__fish_seen_subcommand_from completion\" -a \"bash zsh fish\"\n")
+	for _, r := range resources {
+		for _, v := range verbs {
+			fmt.Fprintf(&b, "complete -c dip -n \"__fish_seen_subcommand_from %s; and not __fish_seen_subcommand_from %s\" -a %s -d %q\n",
+				r.name, strings.Join(verbNames(), " "), v.name, v.summary)
+		}
+	}
+	for _, r := range resources {
+		for _, v := range verbs {
+			condition := fmt.Sprintf("__fish_seen_subcommand_from %s; and __fish_seen_subcommand_from %s", r.name, v.name)
+			for _, f := range verbFlags(r, v.name) {
+				fmt.Fprintf(&b, "complete -c dip -n %q -o %s -r", condition, f.name)
+				if len(f.values) > 0 {
+					fmt.Fprintf(&b, " -a %q", strings.Join(f.values, " "))
+				}
+				b.WriteString("\n")
+			}
+		}
+	}
+	io.WriteString(w, b.String())
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Johanneslueke/dip-client/internal/config"
+)
+
+// runConfig implements "dip config show": the resolved settings and their sources
+func runConfig(args []string) {
+	if len(args) == 0 || args[0] != "show" {
+		fmt.Fprintf(os.Stderr, "Usage: dip config show [flags]\n")
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("config show", flag.ExitOnError)
+	profile := fs.String("profile", defaultProfile, config.ProfileUsage)
+	showKey := fs.Bool("show-key", false, "Show the API key instead of masking it")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: dip config show [flags]\n\n")
+		fmt.Fprintf(fs.Output(), "Shows the settings the commands use and where each comes from. Precedence:\n")
+		fmt.Fprintf(fs.Output(), "flags, environment, the profile, the project file %s, the user file.\n\n", config.ProjectFile)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args[1:])
+
+	cfg := applyConfig(fs, *profile)
+
+	fmt.Println("Config files:")
+	files := config.Files()
+	for _, path := range files {
+		state := "not found"
+		for _, read := range cfg.Files {
+			if read == path {
+				state = "read"
+			}
+		}
+		fmt.Printf("  %s (%s)\n", path, state)
+	}
+	if cfg.Profile != "" {
+		fmt.Printf("Profile: %s (from %s)\n", cfg.Profile, cfg.ProfileSource)
+	} else {
+		fmt.Println("Profile: none")
+	}
+	if len(cfg.Profiles) > 0 {
+		fmt.Printf("Profiles: %s\n", strings.Join(cfg.Profiles, ", "))
+	}
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SETTING\tVALUE\tSOURCE")
+	for _, name := range config.Names() {
+		v := cfg.Lookup(name)
+		value := v.Value
+		if (name == config.Key || name == config.DSN) && !*showKey {
+			value = mask(value)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", name, value, v.Source)
+	}
+	w.Flush()
+}
+
+// mask hides a secret but its last characters, so it can still be told apart
+func mask(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
@@ -0,0 +1,410 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Johanneslueke/dip-client/internal/config"
+	"github.com/Johanneslueke/dip-client/internal/drift"
+	"github.com/Johanneslueke/dip-client/internal/offline"
+	"github.com/Johanneslueke/dip-client/internal/store"
+	dipclient "github.com/Johanneslueke/dip-client/pkg/dip-client"
+)
+
+// diffTables are the tables "dip diff -sample" draws the IDs from. The text
+// resources share the table of their metadata.
+var diffTables = map[string]store.Table{
+	"aktivitaet":           store.AktivitaetTable,
+	"drucksache":           store.DrucksacheTable,
+	"drucksache-text":      store.DrucksacheTable,
+	"person":               store.PersonTable,
+	"plenarprotokoll":      store.PlenarprotokollTable,
+	"plenarprotokoll-text": store.PlenarprotokollTable,
+	"vorgang":              store.VorgangTable,
+	"vorgangsposition":     store.VorgangspositionTable,
+}
+
+// idBatch is the number of IDs fetched with one f.id filter
+const idBatch = 100
+
+// Status of an entity in "dip diff"
+const (
+	statusIdentical = "identical"
+	statusChanged   = "changed"
+	statusOnlyLive  = "only-live"  // not in the local database
+	statusOnlyLocal = "only-local" // the API no longer returns it
+	statusNotFound  = "not-found"  // neither in the API nor in the database
+)
+
+// diffResult is the comparison of one entity
+type diffResult struct {
+	ID      string         `json:"id"`
+	Status  string         `json:"status"`
+	Changes []drift.Change `json:"changes,omitempty"`
+}
+
+// differ compares live entities of a resource with the local database
+type differ struct {
+	r      resource
+	live   backend
+	local  backend
+	normal *drift.Normalizer
+}
+
+// runDiff implements "dip diff <resource>": the field-level differences between
+// the API and the local database
+func runDiff(args []string) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		diffUsage(os.Stderr)
+		os.Exit(2)
+	}
+	r, ok := findResource(args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "dip: unknown resource %q\n\n", args[0])
+		diffUsage(os.Stderr)
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("diff "+r.name, flag.ExitOnError)
+	var (
+		baseURL = fs.String(config.URL, config.Default(config.URL), "API base URL")
+		apiKey  = fs.String(config.Key, "", "API key (default: $DIP_API_KEY or the config file)")
+		dbPath  = fs.String(config.DB, config.Default(config.DB), "SQLite database written by sync-all")
+		profile = fs.String("profile", defaultProfile, config.ProfileUsage)
+		max     = fs.Int("max", 100, "Compare at most this many entities matching the filters")
+		sample  = fs.Int("sample", 0, "Compare this many random local entities per Wahlperiode and print the drift rates")
+		seed    = fs.Int64("seed", 0, "Seed of the -sample selection, to repeat a run (default: random)")
+		asJSON  = fs.Bool("json", false, "Print the result as JSON")
+	)
+	params := r.listParams()
+	bindParams(fs, params)
+	if f := fs.Lookup("f.wahlperiode"); f != nil {
+		fs.Var(f.Value, "wahlperiode", "Alias for -f.wahlperiode")
+	}
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: dip diff %s [flags] [<id>...]\n\n", r.name)
+		fmt.Fprintf(out, "Compares %s entities of the API with the local database field by field. The\n", r.name)
+		fmt.Fprintf(out, "entities are the given IDs, else up to -max entities matching the filters. With\n")
+		fmt.Fprintf(out, "-sample N, N random local entities of each -wahlperiode are compared and the\n")
+		fmt.Fprintf(out, "share that drifted is reported. The API entity is passed through the store mapping\n")
+		fmt.Fprintf(out, "first, so only differences a new sync would write are shown.\n\n")
+		fs.PrintDefaults()
+	}
+	positional := parseInterspersed(fs, args[1:])
+	useGlobalLocal(fs)
+	applyConfig(fs, *profile)
+
+	var ids []string
+	for _, arg := range positional {
+		if _, err := strconv.Atoi(arg); err != nil {
+			log.Fatalf("Invalid ID %q: must be an integer", arg)
+		}
+		ids = append(ids, arg)
+	}
+	var wahlperioden []int
+	if f := paramField(params, "f.wahlperiode"); f.IsValid() && !f.IsNil() {
+		for i := 0; i < f.Elem().Len(); i++ {
+			wahlperioden = append(wahlperioden, int(f.Elem().Index(i).Int()))
+		}
+	}
+	if *sample > 0 && (len(ids) > 0 || len(wahlperioden) == 0) {
+		fmt.Fprintf(fs.Output(), "-sample needs -wahlperiode and no IDs\n\n")
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	s := openLocalDB(*dbPath)
+	defer s.Close()
+	normal, err := drift.NewNormalizer()
+	if err != nil {
+		log.Fatalf("Failed to create scratch database: %v", err)
+	}
+	defer normal.Close()
+
+	d := &differ{
+		r:      r,
+		live:   backend{client: newClient(*baseURL, *apiKey)},
+		local:  backend{local: offline.New(s.DB())},
+		normal: normal,
+	}
+	ctx := context.Background()
+
+	if *sample > 0 {
+		if *seed == 0 {
+			*seed = time.Now().UnixNano()
+		}
+		summaries, err := d.sample(ctx, s, wahlperioden, *sample, *seed)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if *asJSON {
+			writeDiffJSON(summaries)
+			return
+		}
+		printSummaries(r, summaries, *seed)
+		return
+	}
+
+	var results []diffResult
+	if len(ids) > 0 {
+		results, err = d.byIDs(ctx, ids)
+	} else {
+		results, err = d.matching(ctx, params, *max)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *asJSON {
+		writeDiffJSON(results)
+		return
+	}
+	printResults(r, results)
+}
+
+// byIDs compares the entities with the given IDs
+func (d *differ) byIDs(ctx context.Context, ids []string) ([]diffResult, error) {
+	live, err := d.fetchIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	results, err := d.compare(ctx, live)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]bool)
+	for _, e := range live {
+		found[entityID(e)] = true
+	}
+	for _, id := range ids {
+		if found[id] {
+			continue
+		}
+		status := statusNotFound
+		if _, err := d.getLocal(ctx, id); err == nil {
+			status = statusOnlyLocal
+		} else if !errors.Is(err, offline.ErrNotFound) {
+			return nil, fmt.Errorf("%s error: %w", d.local.name(), err)
+		}
+		results = append(results, diffResult{ID: id, Status: status})
+	}
+	return results, nil
+}
+
+// matching compares up to max entities of the API matching the filters of params
+func (d *differ) matching(ctx context.Context, params any, max int) ([]diffResult, error) {
+	live, err := d.fetch(ctx, params, max)
+	if err != nil {
+		return nil, err
+	}
+	return d.compare(ctx, live)
+}
+
+// sample compares n random local entities of each Wahlperiode
+func (d *differ) sample(ctx context.Context, s store.Store, wahlperioden []int, n int, seed int64) ([]drift.Summary, error) {
+	rng := rand.New(rand.NewSource(seed))
+	var summaries []drift.Summary
+	for _, wp := range wahlperioden {
+		ids, err := s.IDsByWahlperiode(ctx, diffTables[d.r.name], wp)
+		if err != nil {
+			return nil, fmt.Errorf("%s error: %w", d.local.name(), err)
+		}
+		rng.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+		if len(ids) > n {
+			ids = ids[:n]
+		}
+		fmt.Fprintf(os.Stderr, "WP %d: comparing %d entities\n", wp, len(ids))
+
+		summary := drift.Summary{Wahlperiode: wp}
+		results, err := d.byIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			switch result.Status {
+			case statusIdentical, statusChanged:
+				summary.Add(result.Changes)
+			case statusOnlyLocal:
+				summary.Missing++
+			}
+			// only-live and not-found are entities of the text resources
+			// without a local text, which are not counted
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// compare compares live entities with the local database
+func (d *differ) compare(ctx context.Context, live []any) ([]diffResult, error) {
+	var results []diffResult
+	for _, e := range live {
+		id := entityID(e)
+		local, err := d.getLocal(ctx, id)
+		if errors.Is(err, offline.ErrNotFound) {
+			results = append(results, diffResult{ID: id, Status: statusOnlyLive})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s error: %w", d.local.name(), err)
+		}
+		normalized, err := d.normal.Normalize(ctx, e)
+		if err != nil {
+			return nil, fmt.Errorf("normalize %s %s: %w", d.r.name, id, err)
+		}
+		changes, err := drift.Compare(local, normalized)
+		if err != nil {
+			return nil, err
+		}
+		status := statusIdentical
+		if len(changes) > 0 {
+			status = statusChanged
+		}
+		results = append(results, diffResult{ID: id, Status: status, Changes: changes})
+	}
+	return results, nil
+}
+
+func (d *differ) getLocal(ctx context.Context, id string) (any, error) {
+	n, _ := strconv.Atoi(id)
+	return d.r.get(ctx, d.local, dipclient.ID(n), d.r.getParams())
+}
+
+// fetchIDs gets the entities with the given IDs from the API, in batches of
+// one list request with an f.id filter each. IDs the API does not know are missing.
+func (d *differ) fetchIDs(ctx context.Context, ids []string) ([]any, error) {
+	var entities []any
+	for start := 0; start < len(ids); start += idBatch {
+		end := min(start+idBatch, len(ids))
+		params := d.r.listParams()
+		f := &paramFlag{name: "f.id", field: paramField(params, "f.id")}
+		if err := f.Set(strings.Join(ids[start:end], ",")); err != nil {
+			return nil, err
+		}
+		batch, err := d.fetch(ctx, params, 0)
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, batch...)
+	}
+	return entities, nil
+}
+
+// fetch lists up to max (0 = all) entities of the API matching params,
+// following the cursor
+func (d *differ) fetch(ctx context.Context, params any, max int) ([]any, error) {
+	var entities []any
+	cursor := getCursor(params)
+	for {
+		result, err := d.r.list(ctx, d.live, params)
+		if err != nil {
+			return nil, fmt.Errorf("%s error: %w", d.live.name(), err)
+		}
+		docs := reflect.Indirect(reflect.ValueOf(result)).FieldByName("Documents")
+		for i := 0; i < docs.Len(); i++ {
+			if max > 0 && len(entities) >= max {
+				return entities, nil
+			}
+			entities = append(entities, docs.Index(i).Addr().Interface())
+		}
+		numFound, next := pageInfo(result)
+		// The API returns the same cursor again after the last page
+		if docs.Len() == 0 || len(entities) >= numFound || next == cursor {
+			return entities, nil
+		}
+		cursor = next
+		setCursor(params, cursor)
+	}
+}
+
+// entityID returns the ID of a pointer to an entity type of the API
+func entityID(entity any) string {
+	return reflect.ValueOf(entity).Elem().FieldByName("Id").String()
+}
+
+func writeDiffJSON(v any) {
+	output, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Fatalf("Marshal error: %v", err)
+	}
+	fmt.Println(string(output))
+}
+
+// printResults prints the changes of each entity and a count per status
+func printResults(r resource, results []diffResult) {
+	counts := make(map[string]int)
+	for _, result := range results {
+		counts[result.Status]++
+		switch result.Status {
+		case statusIdentical:
+			fmt.Printf("%s %s: identical\n", r.name, result.ID)
+		case statusChanged:
+			fmt.Printf("%s %s: %d changed fields\n", r.name, result.ID, len(result.Changes))
+			for _, c := range result.Changes {
+				fmt.Printf("  %s\n", c.Path)
+				fmt.Printf("    local: %s\n", formatDiffValue(c.Local))
+				fmt.Printf("    live:  %s\n", formatDiffValue(c.Live))
+			}
+		case statusOnlyLive:
+			fmt.Printf("%s %s: not in the local database\n", r.name, result.ID)
+		case statusOnlyLocal:
+			fmt.Printf("%s %s: no longer returned by the API\n", r.name, result.ID)
+		case statusNotFound:
+			fmt.Printf("%s %s: neither in the API nor in the local database\n", r.name, result.ID)
+		}
+	}
+	if len(results) == 0 {
+		fmt.Println("No entities found")
+		return
+	}
+	fmt.Printf("\n%d compared: %d identical, %d changed, %d only live, %d only local, %d not found\n",
+		len(results), counts[statusIdentical], counts[statusChanged], counts[statusOnlyLive], counts[statusOnlyLocal], counts[statusNotFound])
+}
+
+// formatDiffValue prints a value as JSON, shortened to one line
+func formatDiffValue(v any) string {
+	if v == nil {
+		return "(missing)"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return truncate(string(data), 100)
+}
+
+// printSummaries prints the drift rate of each Wahlperiode
+func printSummaries(r resource, summaries []drift.Summary, seed int64) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "WP\tCHECKED\tDRIFTED\tRATE\tMISSING\tTOP FIELDS")
+	for _, s := range summaries {
+		var fields []string
+		for _, f := range s.TopFields(3) {
+			fields = append(fields, fmt.Sprintf("%s (%d)", f, s.Fields[f]))
+		}
+		fmt.Fprintf(w, "%d\t%d\t%d\t%.1f%%\t%d\t%s\n",
+			s.Wahlperiode, s.Checked, s.Drifted, 100*s.Rate(), s.Missing, strings.Join(fields, ", "))
+	}
+	w.Flush()
+	fmt.Printf("\n%s sample, -seed %d to repeat. MISSING entities are no longer returned by the API.\n", r.name, seed)
+}
+
+func diffUsage(out *os.File) {
+	fmt.Fprintf(out, "Usage: dip diff <resource> [flags] [<id>...]\n\n")
+	fmt.Fprintf(out, "Compares entities of the API with the local database, e.g.\n\n")
+	fmt.Fprintf(out, "  dip diff vorgang 303271\n")
+	fmt.Fprintf(out, "  dip diff drucksache -f.drucksachetyp Gesetzentwurf -wahlperiode 20 -max 50\n")
+	fmt.Fprintf(out, "  dip diff vorgang -sample 100 -wahlperiode 19,20\n\n")
+	fmt.Fprintf(out, "Run \"dip help diff <resource>\" for the flags.\n")
+}
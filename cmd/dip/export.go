@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Johanneslueke/dip-client/internal/config"
+	"github.com/Johanneslueke/dip-client/internal/network"
+)
+
+// networkFormats are the values of -o of "dip export network"; csv is the
+// edge list, nodes the node list with the attributes
+var networkFormats = []string{"graphml", "gexf", "csv", "nodes"}
+
+// runExport implements "dip export": exports of the local database for other tools
+func runExport(args []string) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		exportUsage(os.Stderr)
+		os.Exit(2)
+	}
+	switch args[0] {
+	case "network":
+		runExportNetwork(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "dip: unknown export %q\n\n", args[0])
+		exportUsage(os.Stderr)
+		os.Exit(2)
+	}
+}
+
+// runExportNetwork implements "dip export network": the co-authorship graph of
+// persons or Fraktionen
+func runExportNetwork(args []string) {
+	fs := flag.NewFlagSet("export network", flag.ExitOnError)
+	var (
+		dbPath       = fs.String(config.DB, config.Default(config.DB), "SQLite database written by sync-all")
+		profile      = fs.String("profile", defaultProfile, config.ProfileUsage)
+		graph        = fs.String("graph", network.Person, "Graph: "+strings.Join(network.Kinds, ", "))
+		format       = fs.String("o", "graphml", "Output format: "+strings.Join(networkFormats, ", "))
+		outPath      = fs.String("out", "", "Write to this file instead of stdout")
+		from         = fs.String("from", "", "Only Drucksachen dated on or after YYYY-MM-DD")
+		to           = fs.String("to", "", "Only Drucksachen dated on or before YYYY-MM-DD")
+		minWeight    = fs.Int("min-weight", 1, "Leave out edges with fewer shared Drucksachen")
+		wahlperioden wahlperiodeList
+	)
+	fs.Var(&wahlperioden, "wp", "Wahlperioden, e.g. 20, 19,20 or 18-20 (default all)")
+	fs.Var(&wahlperioden, "wahlperiode", "Alias for -wp")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: dip export network [flags]\n\n")
+		fmt.Fprintf(out, "Exports the undirected co-authorship graph of the Drucksachen in the local\n")
+		fmt.Fprintf(out, "database. The edge weight is the number of shared Drucksachen.\n\n")
+		fmt.Fprintf(out, "  person    persons sharing a Drucksache as displayed author or by an Aktivität\n")
+		fmt.Fprintf(out, "            citing it; nodes carry partei, geschlecht and bundesland from the\n")
+		fmt.Fprintf(out, "            MdB Stammdaten (import-mdb-stammdaten, link-person-mdb)\n")
+		fmt.Fprintf(out, "  fraktion  Fraktionen introducing a Drucksache together (Urheber)\n\n")
+		fmt.Fprintf(out, "GraphML and GEXF open in Gephi, Cytoscape or networkx; -o csv writes the edge\n")
+		fmt.Fprintf(out, "list and -o nodes the node list with the attributes.\n\n")
+		fs.PrintDefaults()
+	}
+	if positional := parseInterspersed(fs, args); len(positional) > 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	useGlobalLocal(fs)
+	applyConfig(fs, *profile)
+	for _, check := range []struct {
+		name, value string
+		valid       []string
+	}{
+		{"graph", *graph, network.Kinds},
+		{"o", *format, networkFormats},
+	} {
+		if !slices.Contains(check.valid, check.value) {
+			fmt.Fprintf(fs.Output(), "invalid -%s %q: must be one of %s\n\n", check.name, check.value, strings.Join(check.valid, ", "))
+			fs.Usage()
+			os.Exit(2)
+		}
+	}
+	for _, check := range []struct{ name, value string }{{"from", *from}, {"to", *to}} {
+		if _, err := time.Parse(time.DateOnly, check.value); check.value != "" && err != nil {
+			fmt.Fprintf(fs.Output(), "invalid -%s %q: expected YYYY-MM-DD\n\n", check.name, check.value)
+			fs.Usage()
+			os.Exit(2)
+		}
+	}
+
+	s := openLocalDB(*dbPath)
+	defer s.Close()
+
+	g, err := network.Build(context.Background(), s.DB(), network.Options{
+		Kind:         *graph,
+		Wahlperioden: wahlperioden,
+		From:         *from,
+		To:           *to,
+		MinWeight:    *minWeight,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	write := map[string]func(io.Writer, *network.Graph) error{
+		"graphml": network.WriteGraphML,
+		"gexf":    network.WriteGEXF,
+		"csv":     network.WriteEdgesCSV,
+		"nodes":   network.WriteNodesCSV,
+	}[*format]
+	if *outPath == "" {
+		if err := write(os.Stdout, g); err != nil {
+			exitOnBrokenPipe(err)
+			log.Fatal(err)
+		}
+		return
+	}
+	f, err := os.Create(*outPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := write(f, g); err != nil {
+		f.Close()
+		log.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %d nodes and %d edges to %s\n", len(g.Nodes), len(g.Edges), *outPath)
+}
+
+func exportUsage(out *os.File) {
+	fmt.Fprintf(out, "Usage: dip export <export> [flags]\n\n")
+	fmt.Fprintf(out, "Exports of the local database:\n\n")
+	fmt.Fprintf(out, "  %-22s %s\n\n", "network", "Co-authorship graph of persons or Fraktionen (GraphML, GEXF, CSV)")
+	fmt.Fprintf(out, "e.g. dip export network -graph fraktion -wp 20 -o gexf -out fraktionen.gexf.\n")
+	fmt.Fprintf(out, "Run \"dip help export network\" for the flags.\n")
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/Johanneslueke/dip-client/internal/config"
+	"github.com/Johanneslueke/dip-client/internal/gesetz"
+)
+
+// gesetzFormats are the values of -o of "dip gesetz"
+var gesetzFormats = []string{"text", "json", "markdown", "html"}
+
+// runGesetz implements "dip gesetz trace|list": Gesetze and their legislative
+// process from the gesetz_trace and gesetz_timeline views of the local database
+func runGesetz(args []string) {
+	if len(args) == 0 || (args[0] != "trace" && args[0] != "list") {
+		gesetzUsage(os.Stderr)
+		os.Exit(2)
+	}
+	verb := args[0]
+
+	fs := flag.NewFlagSet("gesetz "+verb, flag.ExitOnError)
+	var (
+		dbPath      = fs.String(config.DB, config.Default(config.DB), "SQLite database written by sync-all")
+		profile     = fs.String("profile", defaultProfile, config.ProfileUsage)
+		format      = fs.String("o", "text", "Output format: "+strings.Join(gesetzFormats, ", "))
+		wahlperiode = fs.Int("wp", 0, "Wahlperiode")
+		status      = new(string)
+		limit       = new(int)
+	)
+	fs.IntVar(wahlperiode, "wahlperiode", 0, "Alias for -wp")
+	if verb == "list" {
+		fs.StringVar(status, "status", "", "Beratungsstand, e.g. Verkündet or \"Noch nicht beraten\"")
+		fs.IntVar(limit, "limit", gesetz.DefaultLimit, "Maximum number of Gesetze")
+	}
+	fs.Usage = func() {
+		out := fs.Output()
+		if verb == "trace" {
+			fmt.Fprintf(out, "Usage: dip gesetz trace [flags] <vorgang-id|gesta>\n\n")
+			fmt.Fprintf(out, "Shows the legislative process of a Gesetz in chronological order: Drucksachen,\n")
+			fmt.Fprintf(out, "Beratungen, Beschlussfassungen, Verkündung and Inkrafttreten, with PDF links.\n")
+			fmt.Fprintf(out, "A GESTA-Ordnungsnummer, e.g. N001, may need -wp to select the Wahlperiode.\n\n")
+		} else {
+			fmt.Fprintf(out, "Usage: dip gesetz list [flags]\n\n")
+			fmt.Fprintf(out, "Lists the Gesetzgebung Vorgänge of the local database, newest first.\n\n")
+		}
+		fs.PrintDefaults()
+	}
+	positional := parseInterspersed(fs, args[1:])
+	useGlobalLocal(fs)
+	applyConfig(fs, *profile)
+	if !slices.Contains(gesetzFormats, *format) {
+		fmt.Fprintf(fs.Output(), "invalid -o %q: must be one of %s\n\n", *format, strings.Join(gesetzFormats, ", "))
+		fs.Usage()
+		os.Exit(2)
+	}
+	if (verb == "trace") != (len(positional) == 1) {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	s := openLocalDB(*dbPath)
+	defer s.Close()
+	ctx := context.Background()
+
+	if verb == "trace" {
+		trace, err := gesetz.Find(ctx, s.DB(), positional[0], *wahlperiode)
+		if errors.Is(err, gesetz.ErrNotFound) {
+			log.Fatalf("%v in %s", err, *dbPath)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		writeGesetz(*format, trace, func(w io.Writer) error {
+			switch *format {
+			case "markdown":
+				return gesetz.WriteMarkdown(w, trace)
+			case "html":
+				return gesetz.WriteHTML(w, trace)
+			}
+			return gesetz.WriteText(w, trace)
+		})
+		return
+	}
+
+	gesetze, err := gesetz.List(ctx, s.DB(), gesetz.Options{
+		Wahlperiode:    *wahlperiode,
+		Beratungsstand: *status,
+		Limit:          *limit,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if gesetze == nil {
+		gesetze = []gesetz.Gesetz{}
+	}
+	if len(gesetze) == 0 && *format == "text" {
+		fmt.Println("No Gesetze found")
+		return
+	}
+	writeGesetz(*format, gesetze, func(w io.Writer) error {
+		switch *format {
+		case "markdown":
+			return gesetz.WriteListMarkdown(w, gesetze)
+		case "html":
+			return gesetz.WriteListHTML(w, gesetze)
+		}
+		return gesetz.WriteListText(w, gesetze)
+	})
+}
+
+// writeGesetz writes v as JSON or through the report writer of the format
+func writeGesetz(format string, v any, write func(io.Writer) error) {
+	if format == "json" {
+		output, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			log.Fatalf("Marshal error: %v", err)
+		}
+		fmt.Println(string(output))
+		return
+	}
+	if err := write(os.Stdout); err != nil {
+		exitOnBrokenPipe(err)
+		log.Fatal(err)
+	}
+}
+
+func gesetzUsage(out *os.File) {
+	fmt.Fprintf(out, "Usage: dip gesetz <trace|list> [flags]\n\n")
+	fmt.Fprintf(out, "Gesetze and their legislative process from the local database:\n\n")
+	fmt.Fprintf(out, "  trace  Timeline of one Gesetz, e.g. dip gesetz trace 303271 -o markdown\n")
+	fmt.Fprintf(out, "  list   Gesetze matching the filters, e.g. dip gesetz list -wp 20 -status Verkündet\n\n")
+	fmt.Fprintf(out, "Run \"dip help gesetz trace\" for the flags.\n")
+}
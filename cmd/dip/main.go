@@ -2,360 +2,417 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"syscall"
 
+	"github.com/Johanneslueke/dip-client/internal/config"
+	"github.com/Johanneslueke/dip-client/internal/offline"
+	"github.com/Johanneslueke/dip-client/internal/store"
 	dipclient "github.com/Johanneslueke/dip-client/pkg/dip-client"
 )
 
-type filterParams struct {
-	cursor          *dipclient.Cursor
-	wahlperiode     *dipclient.WahlperiodeFilter
-	id              *dipclient.IDFilter
-	drucksache      *dipclient.DrucksacheFilter
-	plenarprotokoll *dipclient.PlenarprotokollFilter
-	dokumentnummer  *dipclient.DokumentnummerFilter
-	dokumentart     string
-	drucksachetyp   *dipclient.DrucksachtypFilter
-	frageNummer     *dipclient.FrageNummerFilter
-	zuordnung       *dipclient.ZuordnungFilter
-	gesta           *dipclient.GestaFilter
-	format          string
+// command is "dip <resource> <verb>" with its flags
+type command struct {
+	fs      *flag.FlagSet
+	params  any // *Get*ListParams or *Get*Params bound to the API flags
+	baseURL *string
+	apiKey  *string
+	local   *string
+	profile *string
+	output  *outputOptions
+	all     *bool // list only
+	max     *int  // list only
 }
 
 func main() {
-	var (
-		baseURL  = flag.String("url", "https://search.dip.bundestag.de/api/v1", "API base URL")
-		apiKey   = flag.String("key", "", "API key")
-		endpoint = flag.String("endpoint", "", "Endpoint to call")
-		id       = flag.Int("id", 0, "Resource ID")
-		list     = flag.Bool("list", false, "List resources")
-
-		// Common parameters
-		format           = flag.String("format", "", "Response format: json or xml")
-		cursor           = flag.String("cursor", "", "Cursor for pagination")
-		wahlperiode      = flag.Int("wahlperiode", 0, "Wahlperiode filter")
-		fID              = flag.Int("f.id", 0, "Filter by ID")
-		fDrucksache      = flag.Int("f.drucksache", 0, "Filter by Drucksache ID")
-		fPlenarprotokoll = flag.Int("f.plenarprotokoll", 0, "Filter by Plenarprotokoll ID")
-		fDokumentnummer  = flag.String("f.dokumentnummer", "", "Filter by Dokumentnummer")
-		fDokumentart     = flag.String("f.dokumentart", "", "Filter by Dokumentart (Drucksache or Plenarprotokoll)")
-		fDrucksachetyp   = flag.String("f.drucksachetyp", "", "Filter by Drucksachetyp")
-		fFrageNummer     = flag.String("f.frage_nummer", "", "Filter by Fragenummer")
-		fZuordnung       = flag.String("f.zuordnung", "", "Filter by Zuordnung")
-		fGesta           = flag.String("f.gesta", "", "Filter by GESTA-Ordnungsnummer (Vorgang only)")
-	)
-	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("dip: ")
 
-	if *apiKey == "" {
-		*apiKey = os.Getenv("DIP_API_KEY")
+	args := os.Args[1:]
+	args = globalFlags(args)
+	if len(args) == 0 {
+		usage(os.Stderr)
+		os.Exit(2)
 	}
 
-	if *apiKey == "" {
-		log.Fatal("API key required")
+	switch args[0] {
+	case "search":
+		runSearch(args[1:])
+	case "diff":
+		runDiff(args[1:])
+	case "gesetz":
+		runGesetz(args[1:])
+	case "analyze":
+		runAnalyze(args[1:])
+	case "export":
+		runExport(args[1:])
+	case "config":
+		runConfig(args[1:])
+	case "completion":
+		runCompletion(args[1:])
+	case "help", "-h", "-help", "--help":
+		runHelp(args[1:])
+	default:
+		if strings.HasPrefix(args[0], "-") {
+			fmt.Fprintf(os.Stderr, "dip: flags come after the subcommand, e.g. \"dip vorgang list -f.wahlperiode 20\" or \"dip drucksache get 283847\"\n\n")
+			usage(os.Stderr)
+			os.Exit(2)
+		}
+		r, ok := findResource(args[0])
+		if !ok {
+			fmt.Fprintf(os.Stderr, "dip: unknown command %q\n\n", args[0])
+			usage(os.Stderr)
+			os.Exit(2)
+		}
+		if len(args) < 2 || (args[1] != "list" && args[1] != "get") {
+			resourceUsage(os.Stderr, r)
+			os.Exit(2)
+		}
+		runVerb(r, args[1], args[2:])
 	}
+}
 
-	if *endpoint == "" {
-		log.Fatal("Endpoint required: aktivitaet, drucksache, drucksache-text, person, plenarprotokoll, plenarprotokoll-text, vorgang, vorgangsposition")
+// newCommand returns "dip <resource> <verb>" with its flags
+func newCommand(r resource, verb string) *command {
+	fs := flag.NewFlagSet(r.name+" "+verb, flag.ExitOnError)
+	cmd := &command{
+		fs:      fs,
+		baseURL: fs.String(config.URL, config.Default(config.URL), "API base URL"),
+		apiKey:  fs.String(config.Key, "", "API key (default: $DIP_API_KEY or the config file)"),
+		local:   fs.String("local", defaultLocal, "Answer from this SQLite database written by sync-all instead of the API"),
+		profile: fs.String("profile", defaultProfile, config.ProfileUsage),
+		output:  addOutputFlags(fs),
 	}
 
-	client, err := dipclient.New(dipclient.Config{
-		BaseURL: *baseURL,
-		APIKey:  *apiKey,
-	})
-	if err != nil {
-		log.Fatalf("Failed to create client: %v", err)
+	if verb == "list" {
+		cmd.params = r.listParams()
+		cmd.all = fs.Bool("all", false, "Fetch all pages, following the cursor")
+		cmd.max = fs.Int("max", 0, "Fetch pages until this many entities (implies -all)")
+	} else {
+		cmd.params = r.getParams()
 	}
-
-	ctx := context.Background()
-	var result interface{}
-
-	// Helper functions to convert flags to filter types
-	cursorPtr := func() *dipclient.Cursor {
-		if *cursor == "" {
-			return nil
-		}
-		c := dipclient.Cursor(*cursor)
-		return &c
+	bindParams(fs, cmd.params)
+	if f := fs.Lookup("f.wahlperiode"); f != nil {
+		fs.Var(f.Value, "wahlperiode", "Alias for -f.wahlperiode")
 	}
 
-	wahlperiodePtr := func() *dipclient.WahlperiodeFilter {
-		if *wahlperiode == 0 {
-			return nil
+	fs.Usage = func() {
+		out := fs.Output()
+		if verb == "list" {
+			fmt.Fprintf(out, "Usage: dip %s list [flags]\n\n", r.name)
+			fmt.Fprintf(out, "%s. Lists the entities matching all given filters, one page per call;\n", r.summary)
+			fmt.Fprintf(out, "pass the cursor of the response to -cursor for the next page, or use -all or -max\n")
+			fmt.Fprintf(out, "to stream all pages.\n\n")
+		} else {
+			fmt.Fprintf(out, "Usage: dip %s get [flags] <id>\n\n", r.name)
+			fmt.Fprintf(out, "%s. Gets one entity by its ID.\n\n", r.summary)
 		}
-		filter := make(dipclient.WahlperiodeFilter, 1)
-		filter[0] = *wahlperiode
-		return &filter
+		fs.PrintDefaults()
 	}
+	return cmd
+}
 
-	idFilterPtr := func() *dipclient.IDFilter {
-		if *fID == 0 {
-			return nil
-		}
-		filter := make(dipclient.IDFilter, 1)
-		filter[0] = *fID
-		return &filter
+// runVerb implements "dip <resource> list|get"
+func runVerb(r resource, verb string, args []string) {
+	cmd := newCommand(r, verb)
+	fs := cmd.fs
+	positional := parseInterspersed(fs, args)
+	applyConfig(fs, *cmd.profile)
+	if err := cmd.output.validate(); err != nil {
+		fmt.Fprintf(fs.Output(), "%v\n\n", err)
+		fs.Usage()
+		os.Exit(2)
 	}
 
-	drucksacheFilterPtr := func() *dipclient.DrucksacheFilter {
-		if *fDrucksache == 0 {
-			return nil
+	var id dipclient.ID
+	switch verb {
+	case "list":
+		if len(positional) > 0 {
+			fmt.Fprintf(fs.Output(), "dip %s list takes no arguments, got %q\n\n", r.name, positional)
+			fs.Usage()
+			os.Exit(2)
+		}
+	case "get":
+		if len(positional) != 1 {
+			fs.Usage()
+			os.Exit(2)
 		}
-		f := dipclient.DrucksacheFilter(*fDrucksache)
-		return &f
+		n, err := strconv.Atoi(positional[0])
+		if err != nil {
+			log.Fatalf("Invalid ID %q: must be an integer", positional[0])
+		}
+		id = dipclient.ID(n)
 	}
 
-	plenarprotokollFilterPtr := func() *dipclient.PlenarprotokollFilter {
-		if *fPlenarprotokoll == 0 {
-			return nil
+	b, closeBackend := openBackend(cmd)
+	defer closeBackend()
+
+	// A closed pipe, e.g. "dip vorgang list -all | head", ends the output
+	// without an error instead of killing the process mid-write
+	signal.Ignore(syscall.SIGPIPE)
+
+	if verb == "list" && (*cmd.all || *cmd.max > 0) {
+		// Ctrl-C stops after the current page and still ends the output properly
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := streamList(ctx, os.Stdout, newProgress(isTerminal(os.Stderr)), r, b, cmd, *cmd.max); err != nil {
+			exitOnBrokenPipe(err)
+			log.Fatal(err)
 		}
-		f := dipclient.PlenarprotokollFilter(*fPlenarprotokoll)
-		return &f
+		return
 	}
 
-	dokumentnummerFilterPtr := func() *dipclient.DokumentnummerFilter {
-		if *fDokumentnummer == "" {
-			return nil
-		}
-		filter := make(dipclient.DokumentnummerFilter, 1)
-		filter[0] = *fDokumentnummer
-		return &filter
+	ctx := context.Background()
+
+	var (
+		result any
+		err    error
+	)
+	if verb == "list" {
+		result, err = r.list(ctx, b, cmd.params)
+	} else {
+		result, err = r.get(ctx, b, id, cmd.params)
+	}
+	if err != nil {
+		log.Fatalf("%s error: %v", b.name(), err)
 	}
 
-	drucksachtypFilterPtr := func() *dipclient.DrucksachtypFilter {
-		if *fDrucksachetyp == "" {
-			return nil
-		}
-		f := dipclient.DrucksachtypFilter(*fDrucksachetyp)
-		return &f
+	if err := writeOutput(os.Stdout, r, result, verb == "list", cmd.output); err != nil {
+		exitOnBrokenPipe(err)
+		log.Fatal(err)
+	}
+	// Only json and yaml contain the cursor, table output is read by a person
+	if verb == "list" && cmd.output.format == "table" {
+		numFound, cursor := pageInfo(result)
+		fmt.Fprintf(os.Stderr, "\n%d found, next page: -cursor %q\n", numFound, cursor)
 	}
+}
 
-	frageNummerFilterPtr := func() *dipclient.FrageNummerFilter {
-		if *fFrageNummer == "" {
-			return nil
-		}
-		filter := make(dipclient.FrageNummerFilter, 1)
-		filter[0] = *fFrageNummer
-		return &filter
+// exitOnBrokenPipe exits quietly if the reader of stdout went away
+func exitOnBrokenPipe(err error) {
+	if errors.Is(err, syscall.EPIPE) {
+		os.Exit(0)
 	}
+}
 
-	zuordnungFilterPtr := func() *dipclient.ZuordnungFilter {
-		if *fZuordnung == "" {
-			return nil
+// defaultLocal and defaultProfile are the values of "dip -local <path>" and
+// "dip -profile <name>" in front of the subcommand, see globalFlags
+var defaultLocal, defaultProfile string
+
+// globalFlags removes "-local <path>" and "-profile <name>" in front of the
+// subcommand, so both "dip -local dip.db vorgang get 1" and
+// "dip vorgang get -local dip.db 1" work
+func globalFlags(args []string) []string {
+	for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(args[0], "-"), "=")
+		var target *string
+		switch name {
+		case "local":
+			target = &defaultLocal
+		case "profile":
+			target = &defaultProfile
+		default:
+			return args
+		}
+		if hasValue {
+			*target = value
+			args = args[1:]
+			continue
 		}
-		f := dipclient.ZuordnungFilter(*fZuordnung)
-		return &f
+		if len(args) < 2 {
+			fmt.Fprintf(os.Stderr, "dip: -%s requires a value\n", name)
+			os.Exit(2)
+		}
+		*target = args[1]
+		args = args[2:]
 	}
+	return args
+}
 
-	gestaFilterPtr := func() *dipclient.GestaFilter {
-		if *fGesta == "" {
-			return nil
-		}
-		filter := make(dipclient.GestaFilter, 1)
-		filter[0] = *fGesta
-		return &filter
-	} // Struct to hold all filter parameters
+// useGlobalLocal sets -db of the commands that only read the local database to
+// the path of "dip -local <path>", unless -db was given. Call it before applyConfig.
+func useGlobalLocal(fs *flag.FlagSet) {
+	given := false
+	fs.Visit(func(f *flag.Flag) { given = given || f.Name == config.DB })
+	if defaultLocal != "" && !given {
+		fs.Set(config.DB, defaultLocal)
+	}
+}
 
-	filters := filterParams{
-		cursor:          cursorPtr(),
-		wahlperiode:     wahlperiodePtr(),
-		id:              idFilterPtr(),
-		drucksache:      drucksacheFilterPtr(),
-		plenarprotokoll: plenarprotokollFilterPtr(),
-		dokumentnummer:  dokumentnummerFilterPtr(),
-		dokumentart:     *fDokumentart,
-		drucksachetyp:   drucksachtypFilterPtr(),
-		frageNummer:     frageNummerFilterPtr(),
-		zuordnung:       zuordnungFilterPtr(),
-		gesta:           gestaFilterPtr(),
-		format:          *format,
+// applyConfig sets the flags of fs that were not given from the config files
+// and environment, see package config
+func applyConfig(fs *flag.FlagSet, profile string) *config.Config {
+	cfg, err := config.Load(profile)
+	if err == nil {
+		err = cfg.Apply(fs)
 	}
+	if err != nil {
+		log.Fatalf("Config error: %v", err)
+	}
+	return cfg
+}
 
-	// Dispatch table for endpoints
-	type endpointHandler func(ctx context.Context, listMode bool, resourceId dipclient.ID, f filterParams) (interface{}, error)
+// openBackend returns the local database if -local is set and the API client otherwise
+func openBackend(cmd *command) (backend, func()) {
+	if *cmd.local == "" {
+		return backend{client: newClient(*cmd.baseURL, *cmd.apiKey)}, func() {}
+	}
+	s := openLocalDB(*cmd.local)
+	return backend{local: offline.New(s.DB())}, func() { s.Close() }
+}
 
-	handlers := map[string]endpointHandler{
-		"aktivitaet": func(ctx context.Context, listMode bool, resourceId dipclient.ID, f filterParams) (interface{}, error) {
-			if listMode {
-				params := &dipclient.GetAktivitaetListParams{
-					Cursor:           f.cursor,
-					FWahlperiode:     f.wahlperiode,
-					FId:              f.id,
-					FDrucksache:      f.drucksache,
-					FPlenarprotokoll: f.plenarprotokoll,
-					FDokumentnummer:  f.dokumentnummer,
-					FDrucksachetyp:   f.drucksachetyp,
-					FFrageNummer:     f.frageNummer,
-					FZuordnung:       f.zuordnung,
-				}
-				if f.format != "" {
-					fmt := dipclient.GetAktivitaetListParamsFormat(f.format)
-					params.Format = &fmt
-				}
-				if f.dokumentart != "" {
-					da := dipclient.GetAktivitaetListParamsFDokumentart(f.dokumentart)
-					params.FDokumentart = &da
-				}
-				return client.GetAktivitaetList(ctx, params)
-			}
-			return client.GetAktivitaet(ctx, resourceId, nil)
-		},
-		"drucksache": func(ctx context.Context, listMode bool, resourceId dipclient.ID, f filterParams) (interface{}, error) {
-			if listMode {
-				params := &dipclient.GetDrucksacheListParams{
-					Cursor:          f.cursor,
-					FWahlperiode:    f.wahlperiode,
-					FId:             f.id,
-					FDokumentnummer: f.dokumentnummer,
-					FDrucksachetyp:  f.drucksachetyp,
-					FZuordnung:      f.zuordnung,
-				}
-				if f.format != "" {
-					fmt := dipclient.GetDrucksacheListParamsFormat(f.format)
-					params.Format = &fmt
-				}
-				return client.GetDrucksacheList(ctx, params)
-			}
-			return client.GetDrucksache(ctx, resourceId, nil)
-		},
-		"drucksache-text": func(ctx context.Context, listMode bool, resourceId dipclient.ID, f filterParams) (interface{}, error) {
-			if listMode {
-				params := &dipclient.GetDrucksacheTextListParams{
-					Cursor:          f.cursor,
-					FWahlperiode:    f.wahlperiode,
-					FId:             f.id,
-					FDokumentnummer: f.dokumentnummer,
-					FDrucksachetyp:  f.drucksachetyp,
-					FZuordnung:      f.zuordnung,
-				}
-				if f.format != "" {
-					fmt := dipclient.GetDrucksacheTextListParamsFormat(f.format)
-					params.Format = &fmt
-				}
-				return client.GetDrucksacheTextList(ctx, params)
-			}
-			return client.GetDrucksacheText(ctx, resourceId, nil)
-		},
-		"person": func(ctx context.Context, listMode bool, resourceId dipclient.ID, f filterParams) (interface{}, error) {
-			if listMode {
-				params := &dipclient.GetPersonListParams{
-					Cursor:       f.cursor,
-					FWahlperiode: f.wahlperiode,
-					FId:          f.id,
-				}
-				if f.format != "" {
-					fmt := dipclient.GetPersonListParamsFormat(f.format)
-					params.Format = &fmt
-				}
-				return client.GetPersonList(ctx, params)
-			}
-			return client.GetPerson(ctx, resourceId, nil)
-		},
-		"plenarprotokoll": func(ctx context.Context, listMode bool, resourceId dipclient.ID, f filterParams) (interface{}, error) {
-			if listMode {
-				params := &dipclient.GetPlenarprotokollListParams{
-					Cursor:          f.cursor,
-					FWahlperiode:    f.wahlperiode,
-					FId:             f.id,
-					FDokumentnummer: f.dokumentnummer,
-					FZuordnung:      f.zuordnung,
-				}
-				if f.format != "" {
-					fmt := dipclient.GetPlenarprotokollListParamsFormat(f.format)
-					params.Format = &fmt
-				}
-				return client.GetPlenarprotokollList(ctx, params)
-			}
-			return client.GetPlenarprotokoll(ctx, resourceId, nil)
-		},
-		"plenarprotokoll-text": func(ctx context.Context, listMode bool, resourceId dipclient.ID, f filterParams) (interface{}, error) {
-			if listMode {
-				params := &dipclient.GetPlenarprotokollTextListParams{
-					Cursor:          f.cursor,
-					FWahlperiode:    f.wahlperiode,
-					FId:             f.id,
-					FDokumentnummer: f.dokumentnummer,
-					FZuordnung:      f.zuordnung,
-				}
-				if f.format != "" {
-					fmt := dipclient.GetPlenarprotokollTextListParamsFormat(f.format)
-					params.Format = &fmt
-				}
-				return client.GetPlenarprotokollTextList(ctx, params)
-			}
-			return client.GetPlenarprotokollText(ctx, resourceId, nil)
-		},
-		"vorgang": func(ctx context.Context, listMode bool, resourceId dipclient.ID, f filterParams) (interface{}, error) {
-			if listMode {
-				params := &dipclient.GetVorgangListParams{
-					Cursor:           f.cursor,
-					FWahlperiode:     f.wahlperiode,
-					FId:              f.id,
-					FDrucksache:      f.drucksache,
-					FPlenarprotokoll: f.plenarprotokoll,
-					FDokumentnummer:  f.dokumentnummer,
-					FDrucksachetyp:   f.drucksachetyp,
-					FFrageNummer:     f.frageNummer,
-					FGesta:           f.gesta,
-				}
-				if f.format != "" {
-					fmt := dipclient.GetVorgangListParamsFormat(f.format)
-					params.Format = &fmt
-				}
-				if f.dokumentart != "" {
-					da := dipclient.GetVorgangListParamsFDokumentart(f.dokumentart)
-					params.FDokumentart = &da
-				}
-				return client.GetVorgangList(ctx, params)
-			}
-			return client.GetVorgang(ctx, resourceId, nil)
-		},
-		"vorgangsposition": func(ctx context.Context, listMode bool, resourceId dipclient.ID, f filterParams) (interface{}, error) {
-			if listMode {
-				params := &dipclient.GetVorgangspositionListParams{
-					Cursor:           f.cursor,
-					FWahlperiode:     f.wahlperiode,
-					FId:              f.id,
-					FDrucksache:      f.drucksache,
-					FPlenarprotokoll: f.plenarprotokoll,
-					FDokumentnummer:  f.dokumentnummer,
-					FDrucksachetyp:   f.drucksachetyp,
-					FFrageNummer:     f.frageNummer,
-					FZuordnung:       f.zuordnung,
-				}
-				if f.format != "" {
-					fmt := dipclient.GetVorgangspositionListParamsFormat(f.format)
-					params.Format = &fmt
-				}
-				if f.dokumentart != "" {
-					da := dipclient.GetVorgangspositionListParamsFDokumentart(f.dokumentart)
-					params.FDokumentart = &da
-				}
-				return client.GetVorgangspositionList(ctx, params)
-			}
-			return client.GetVorgangsposition(ctx, resourceId, nil)
-		},
+// openLocalDB opens the SQLite database at path and exits if it fails. Unlike
+// store.Open, it does not create a missing database.
+func openLocalDB(path string) store.Store {
+	// Opening a missing path would create an empty database
+	if _, err := os.Stat(path); err != nil {
+		log.Fatalf("Local database: %v", err)
 	}
+	s, err := store.Open(store.SQLite, path)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	return s
+}
 
-	handler, ok := handlers[strings.ToLower(*endpoint)]
-	if !ok {
-		log.Fatalf("Unknown endpoint: %s", *endpoint)
+// name is the source of the data in error messages
+func (b backend) name() string {
+	if b.local != nil {
+		return "Local database"
 	}
+	return "API"
+}
 
-	result, err = handler(ctx, *list, dipclient.ID(*id), filters)
+func newClient(baseURL, apiKey string) *dipclient.Client {
+	if apiKey == "" {
+		log.Fatal("API key required: set -key, DIP_API_KEY or key in the config file (see dip config show)")
+	}
 
+	client, err := dipclient.New(dipclient.Config{
+		BaseURL: baseURL,
+		APIKey:  apiKey,
+	})
 	if err != nil {
-		log.Fatalf("API error: %v", err)
+		log.Fatalf("Failed to create client: %v", err)
 	}
+	return client
+}
 
-	output, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		log.Fatalf("Marshal error: %v", err)
+// parseInterspersed parses flags before and after positional arguments, so
+// "dip drucksache get 283847 -key ..." works, and returns the positional arguments
+func parseInterspersed(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		fs.Parse(args)
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional
+		}
+		if args[0] == "--" {
+			return append(positional, args[1:]...)
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
 	}
+}
 
-	fmt.Println(string(output))
+// runHelp implements "dip help [resource [verb]]"
+func runHelp(args []string) {
+	if len(args) == 0 {
+		usage(os.Stdout)
+		return
+	}
+	if args[0] == "search" {
+		runSearch([]string{"-h"})
+		return
+	}
+	if args[0] == "gesetz" {
+		if len(args) == 1 {
+			gesetzUsage(os.Stdout)
+			return
+		}
+		runGesetz([]string{args[1], "-h"})
+		return
+	}
+	if args[0] == "analyze" {
+		if len(args) == 1 {
+			analyzeUsage(os.Stdout)
+			return
+		}
+		runAnalyze([]string{args[1], "-h"})
+		return
+	}
+	if args[0] == "export" {
+		if len(args) == 1 {
+			exportUsage(os.Stdout)
+			return
+		}
+		runExport([]string{args[1], "-h"})
+		return
+	}
+	if args[0] == "diff" {
+		if len(args) == 1 {
+			diffUsage(os.Stdout)
+			return
+		}
+		runDiff([]string{args[1], "-h"})
+		return
+	}
+	r, ok := findResource(args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "dip: unknown command %q\n\n", args[0])
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+	if len(args) == 1 {
+		resourceUsage(os.Stdout, r)
+		return
+	}
+	if args[1] != "list" && args[1] != "get" {
+		resourceUsage(os.Stderr, r)
+		os.Exit(2)
+	}
+	cmd := newCommand(r, args[1])
+	cmd.fs.SetOutput(os.Stdout)
+	cmd.fs.Usage()
+}
+
+func usage(out *os.File) {
+	fmt.Fprintf(out, "Usage: dip <command> [flags]\n\n")
+	fmt.Fprintf(out, "Query the DIP API of the Bundestag:\n\n")
+	fmt.Fprintf(out, "  dip <resource> list [flags]      List entities, e.g. dip vorgang list -f.wahlperiode 20\n")
+	fmt.Fprintf(out, "  dip <resource> get [flags] <id>  Get one entity, e.g. dip drucksache get 283847\n\n")
+	fmt.Fprintf(out, "Resources:\n")
+	for _, r := range resources {
+		fmt.Fprintf(out, "  %-22s %s\n", r.name, r.summary)
+	}
+	fmt.Fprintf(out, "\nOther commands:\n")
+	fmt.Fprintf(out, "  %-22s %s\n", "search", "Full-text search over the texts in the local database")
+	fmt.Fprintf(out, "  %-22s %s\n", "gesetz trace|list", "Legislative process of Gesetze in the local database")
+	fmt.Fprintf(out, "  %-22s %s\n", "analyze <analysis>", "Legislative durations and reports over the local database")
+	fmt.Fprintf(out, "  %-22s %s\n", "export network", "Co-authorship graphs as GraphML, GEXF or CSV")
+	fmt.Fprintf(out, "  %-22s %s\n", "diff <resource>", "Compare entities of the API with the local database")
+	fmt.Fprintf(out, "  %-22s %s\n", "config show", "Show the settings and where they come from")
+	fmt.Fprintf(out, "  %-22s %s\n", "completion", "Print the shell completion script (bash, zsh, fish)")
+	fmt.Fprintf(out, "  %-22s %s\n", "help", "Show help, e.g. dip help vorgang list")
+	fmt.Fprintf(out, "\nThe API key is read from -key, DIP_API_KEY or the config file; -profile <name>\n")
+	fmt.Fprintf(out, "selects a profile of the config file. With -local <path>, e.g.\n")
+	fmt.Fprintf(out, "dip -local dip.db vorgang list -f.wahlperiode 20, list and get are answered from\n")
+	fmt.Fprintf(out, "the SQLite database written by sync-all instead of the API.\n")
+}
+
+func resourceUsage(out *os.File, r resource) {
+	fmt.Fprintf(out, "Usage: dip %s <list|get> [flags]\n\n", r.name)
+	fmt.Fprintf(out, "%s\n\n", r.summary)
+	for _, v := range verbs {
+		fmt.Fprintf(out, "  %-6s %s\n", v.name, v.summary)
+	}
+	fmt.Fprintf(out, "\nRun \"dip help %s list\" for the filters.\n", r.name)
 }
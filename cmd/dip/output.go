@@ -0,0 +1,413 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// outputFormats are the values of -o
+var outputFormats = []string{"table", "json", "ndjson", "csv", "yaml", "template"}
+
+// maxCellWidth truncates table cells, long titles would break the layout
+const maxCellWidth = 80
+
+// defaultColumns are the columns of table and csv output per resource
+var defaultColumns = map[string][]string{
+	"aktivitaet":           {"id", "fundstelle.dokumentnummer", "datum", "aktivitaetsart", "titel"},
+	"drucksache":           {"id", "dokumentnummer", "datum", "drucksachetyp", "titel"},
+	"drucksache-text":      {"id", "dokumentnummer", "datum", "drucksachetyp", "titel"},
+	"person":               {"id", "nachname", "vorname", "wahlperiode", "titel"},
+	"plenarprotokoll":      {"id", "dokumentnummer", "datum", "titel"},
+	"plenarprotokoll-text": {"id", "dokumentnummer", "datum", "titel"},
+	"vorgang":              {"id", "vorgangstyp", "datum", "beratungsstand", "titel"},
+	"vorgangsposition":     {"id", "fundstelle.dokumentnummer", "datum", "vorgangsposition", "titel"},
+}
+
+// outputOptions are the output flags of the API subcommands
+type outputOptions struct {
+	format   string
+	columns  string
+	template string
+}
+
+func addOutputFlags(fs *flag.FlagSet) *outputOptions {
+	o := &outputOptions{}
+	fs.StringVar(&o.format, "o", "", "Output format: "+strings.Join(outputFormats, ", ")+" (default: table on a terminal, json otherwise)")
+	fs.StringVar(&o.columns, "columns", "", "Comma-separated columns of table and csv output, as paths into the JSON, e.g. id,titel,fundstelle.pdf_url")
+	fs.StringVar(&o.template, "template", "", "Go template applied to each entity for -o template, e.g. '{{.dokumentnummer}} {{.titel}}'")
+	return o
+}
+
+// validate checks the flags before the request is sent. Without -o, output
+// to a terminal is a table and output to a pipe or file stays JSON for scripts.
+func (o *outputOptions) validate() error {
+	if o.format == "" {
+		o.format = "json"
+		if isTerminal(os.Stdout) {
+			o.format = "table"
+		}
+	}
+	if !slices.Contains(outputFormats, o.format) {
+		return fmt.Errorf("invalid -o %q: must be one of %s", o.format, strings.Join(outputFormats, ", "))
+	}
+	if o.format == "template" && o.template == "" {
+		return fmt.Errorf("-o template requires -template")
+	}
+	if o.template != "" && o.format != "template" {
+		return fmt.Errorf("-template requires -o template")
+	}
+	if o.format == "template" {
+		if _, err := parseTemplate(o.template); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// columnPaths returns the selected columns or the defaults of the resource
+func (o *outputOptions) columnPaths(r resource) []string {
+	if o.columns == "" {
+		if columns, ok := defaultColumns[r.name]; ok {
+			return columns
+		}
+		return []string{"id", "titel"}
+	}
+	var columns []string
+	for _, c := range strings.Split(o.columns, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			columns = append(columns, c)
+		}
+	}
+	return columns
+}
+
+// writeOutput writes the response of a list or get request. json and yaml
+// write the whole response, the other formats one record per entity.
+func writeOutput(w io.Writer, r resource, result any, list bool, o *outputOptions) error {
+	if o.format == "json" {
+		output, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal error: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(output))
+		return err
+	}
+
+	generic, err := toGeneric(result)
+	if err != nil {
+		return err
+	}
+	if o.format == "yaml" {
+		return writeYAML(w, generic)
+	}
+
+	entities := []any{generic}
+	if list {
+		entities = documents(generic)
+	}
+	ew, err := newEntityWriter(w, r, o)
+	if err != nil {
+		return err
+	}
+	if err := ew.write(entities); err != nil {
+		return err
+	}
+	return ew.close()
+}
+
+// toGeneric converts a response to its JSON representation, so columns and
+// templates use the field names of the API
+func toGeneric(result any) (any, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, fmt.Errorf("unmarshal error: %w", err)
+	}
+	return generic, nil
+}
+
+// documents returns the entities of a list response
+func documents(generic any) []any {
+	m, _ := generic.(map[string]any)
+	entities, _ := m["documents"].([]any)
+	return entities
+}
+
+func writeYAML(w io.Writer, v any) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("yaml error: %w", err)
+	}
+	return enc.Close()
+}
+
+// entityWriter writes entities as they arrive, so pages are written while
+// the next one is fetched. json and yaml write a list of entities.
+type entityWriter struct {
+	w       io.Writer
+	format  string
+	columns []string
+	tmpl    *template.Template
+	count   int
+	started bool // the first batch was written, with the table or csv header
+}
+
+func newEntityWriter(w io.Writer, r resource, o *outputOptions) (*entityWriter, error) {
+	ew := &entityWriter{w: w, format: o.format, columns: o.columnPaths(r)}
+	if o.format == "template" {
+		tmpl, err := parseTemplate(o.template)
+		if err != nil {
+			return nil, err
+		}
+		ew.tmpl = tmpl
+	}
+	return ew, nil
+}
+
+// write writes a batch of entities, e.g. one page. Table columns are aligned per batch.
+func (ew *entityWriter) write(entities []any) error {
+	first := ew.count == 0
+	header := !ew.started
+	ew.count += len(entities)
+	ew.started = true
+
+	switch ew.format {
+	case "json":
+		for i, e := range entities {
+			data, err := json.MarshalIndent(e, "  ", "  ")
+			if err != nil {
+				return fmt.Errorf("marshal error: %w", err)
+			}
+			sep := ",\n  "
+			if first && i == 0 {
+				sep = "[\n  "
+			}
+			if _, err := fmt.Fprintf(ew.w, "%s%s", sep, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "yaml":
+		for _, e := range entities {
+			if err := writeYAML(ew.w, []any{e}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "ndjson":
+		enc := json.NewEncoder(ew.w)
+		for _, e := range entities {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "csv":
+		cw := csv.NewWriter(ew.w)
+		if header {
+			cw.Write(ew.columns)
+		}
+		for _, e := range entities {
+			row := make([]string, len(ew.columns))
+			for i, c := range ew.columns {
+				row[i] = formatValue(lookupPath(e, c))
+			}
+			cw.Write(row)
+		}
+		cw.Flush()
+		return cw.Error()
+	case "table":
+		tw := tabwriter.NewWriter(ew.w, 0, 0, 2, ' ', 0)
+		if header {
+			header := make([]string, len(ew.columns))
+			for i, c := range ew.columns {
+				header[i] = strings.ToUpper(c)
+			}
+			fmt.Fprintln(tw, strings.Join(header, "\t"))
+		}
+		for _, e := range entities {
+			row := make([]string, len(ew.columns))
+			for i, c := range ew.columns {
+				row[i] = truncate(strings.Join(strings.Fields(formatValue(lookupPath(e, c))), " "), maxCellWidth)
+			}
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	case "template":
+		for _, e := range entities {
+			var b strings.Builder
+			if err := ew.tmpl.Execute(&b, e); err != nil {
+				return fmt.Errorf("template error: %w", err)
+			}
+			out := b.String()
+			if !strings.HasSuffix(out, "\n") {
+				out += "\n"
+			}
+			if _, err := io.WriteString(ew.w, out); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported output format %q", ew.format)
+}
+
+// close ends the output, e.g. the JSON list
+func (ew *entityWriter) close() error {
+	switch {
+	case ew.format == "json" && ew.count == 0:
+		_, err := fmt.Fprintln(ew.w, "[]")
+		return err
+	case ew.format == "json":
+		_, err := fmt.Fprintln(ew.w, "\n]")
+		return err
+	case ew.format == "yaml" && ew.count == 0:
+		_, err := fmt.Fprintln(ew.w, "[]")
+		return err
+	}
+	return nil
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("output").Option("missingkey=zero").Funcs(template.FuncMap{
+		"get": lookupPath,
+		"json": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+		"join": func(v any, sep string) string {
+			list, ok := v.([]any)
+			if !ok {
+				return formatValue(v)
+			}
+			parts := make([]string, len(list))
+			for i, item := range list {
+				parts[i] = formatValue(item)
+			}
+			return strings.Join(parts, sep)
+		},
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// lookupPath returns the value at a dot-separated path like "fundstelle.pdf_url".
+// A numeric segment selects an element of a list, e.g. "urheber.0.titel"; other
+// segments are applied to every element, so "urheber.titel" returns all titles.
+func lookupPath(v any, path string) any {
+	if path == "" || path == "." {
+		return v
+	}
+	return lookup(v, strings.Split(path, "."))
+}
+
+func lookup(v any, path []string) any {
+	if len(path) == 0 {
+		return v
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		return lookup(v[path[0]], path[1:])
+	case []any:
+		if i, err := strconv.Atoi(path[0]); err == nil {
+			if i < 0 || i >= len(v) {
+				return nil
+			}
+			return lookup(v[i], path[1:])
+		}
+		var values []any
+		for _, item := range v {
+			switch found := lookup(item, path).(type) {
+			case nil:
+			case []any:
+				values = append(values, found...)
+			default:
+				values = append(values, found)
+			}
+		}
+		if values == nil {
+			return nil
+		}
+		return values
+	}
+	return nil
+}
+
+// formatValue formats a JSON value for a table or csv cell. Lists are joined
+// with "; ", objects are written as JSON.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = formatValue(item)
+		}
+		return strings.Join(parts, "; ")
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
+
+// pageInfo returns the number of matching entities and the cursor of the next
+// page of a *ListResponse
+func pageInfo(result any) (int, string) {
+	v := reflect.Indirect(reflect.ValueOf(result))
+	if v.Kind() != reflect.Struct {
+		return 0, ""
+	}
+	var (
+		numFound int
+		cursor   string
+	)
+	if f := v.FieldByName("NumFound"); f.IsValid() && f.CanInt() {
+		numFound = int(f.Int())
+	}
+	if f := v.FieldByName("Cursor"); f.IsValid() && f.Kind() == reflect.String {
+		cursor = f.String()
+	}
+	return numFound, cursor
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const vorgangJSON = `{
+	"id": "303271",
+	"titel": "Wärmeplanungsgesetz",
+	"wahlperiode": 20,
+	"verkuendung": [{"fundstelle": "BGBl I 2023 Nr. 394", "pdf_url": "https://www.recht.bund.de/bgbl/1/2023/394/VO.html"}],
+	"urheber": [{"titel": "Bundesregierung", "einbringer": true}, {"titel": "Bundesrat"}],
+	"deskriptor": [{"name": "Wärmeplanung", "fundstelle": true}, {"name": "Kommune", "fundstelle": false}],
+	"fundstelle": {"dokumentnummer": "20/8654", "urheber": ["BRg", "SPD"]}
+}`
+
+func generic(t *testing.T, data string) any {
+	t.Helper()
+	var v any
+	require.NoError(t, json.Unmarshal([]byte(data), &v))
+	return v
+}
+
+func TestLookupPath(t *testing.T) {
+	vorgang := generic(t, vorgangJSON)
+	tests := []struct {
+		path string
+		want any
+	}{
+		{path: "id", want: "303271"},
+		{path: "wahlperiode", want: 20.0},
+		{path: "fundstelle.dokumentnummer", want: "20/8654"},
+		{path: "fundstelle.urheber.1", want: "SPD"},
+		{path: "urheber.0.titel", want: "Bundesregierung"},
+		{path: "urheber.2.titel", want: nil},
+		{path: "urheber.-1.titel", want: nil},
+		{path: "urheber.titel", want: []any{"Bundesregierung", "Bundesrat"}},
+		{path: "urheber.einbringer", want: []any{true}},
+		{path: "deskriptor.name", want: []any{"Wärmeplanung", "Kommune"}},
+		{path: "verkuendung.0", want: map[string]any{"fundstelle": "BGBl I 2023 Nr. 394", "pdf_url": "https://www.recht.bund.de/bgbl/1/2023/394/VO.html"}},
+		{path: "abstract", want: nil},
+		{path: "titel.name", want: nil},
+		{path: "ressort.titel", want: nil},
+		{path: ".", want: vorgang},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, lookupPath(vorgang, tt.path), tt.path)
+	}
+
+	// Fan-out over nested lists flattens the values
+	nested := generic(t, `{"positionen": [{"urheber": [{"titel": "A"}, {"titel": "B"}]}, {"urheber": [{"titel": "C"}]}, {}]}`)
+	assert.Equal(t, []any{"A", "B", "C"}, lookupPath(nested, "positionen.urheber.titel"))
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{value: nil, want: ""},
+		{value: "Wärmeplanungsgesetz", want: "Wärmeplanungsgesetz"},
+		{value: 20.0, want: "20"},
+		{value: 0.5, want: "0.5"},
+		{value: 1e21, want: "1000000000000000000000"},
+		{value: true, want: "true"},
+		{value: []any{"BRg", 20.0, nil}, want: "BRg; 20; "},
+		{value: []any{}, want: ""},
+		{value: map[string]any{"titel": "Bundesrat", "einbringer": false}, want: `{"einbringer":false,"titel":"Bundesrat"}`},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatValue(tt.value), "%v", tt.value)
+	}
+}
+
+func TestEntityWriter(t *testing.T) {
+	a := generic(t, `{"id": "1", "titel": "Erster"}`)
+	b := generic(t, `{"id": "2", "titel": "Zweiter\nTeil"}`)
+	c := generic(t, `{"id": "3", "titel": "Dritter", "urheber": [{"titel": "BRg"}]}`)
+	tests := []struct {
+		format  string
+		batches [][]any // pages, an empty first page writes the header once
+		want    string
+	}{
+		{format: "json", batches: [][]any{{}, {a}, {b, c}}, want: "[\n" +
+			"  {\n    \"id\": \"1\",\n    \"titel\": \"Erster\"\n  },\n" +
+			"  {\n    \"id\": \"2\",\n    \"titel\": \"Zweiter\\nTeil\"\n  },\n" +
+			"  {\n    \"id\": \"3\",\n    \"titel\": \"Dritter\",\n    \"urheber\": [\n      {\n        \"titel\": \"BRg\"\n      }\n    ]\n  }\n]\n"},
+		{format: "json", batches: [][]any{{}, {}}, want: "[]\n"},
+		{format: "ndjson", batches: [][]any{{a}, {}, {c}}, want: "{\"id\":\"1\",\"titel\":\"Erster\"}\n{\"id\":\"3\",\"titel\":\"Dritter\",\"urheber\":[{\"titel\":\"BRg\"}]}\n"},
+		{format: "csv", batches: [][]any{{}, {a, b}, {c}}, want: "id,titel,urheber.titel\n1,Erster,\n2,\"Zweiter\nTeil\",\n3,Dritter,BRg\n"},
+		{format: "csv", batches: [][]any{{}}, want: "id,titel,urheber.titel\n"},
+		{format: "table", batches: [][]any{{}, {a}, {b}}, want: "ID  TITEL  URHEBER.TITEL\n1  Erster  \n2  Zweiter Teil  \n"},
+		{format: "yaml", batches: [][]any{{}, {}}, want: "[]\n"},
+		{format: "yaml", batches: [][]any{{}, {a}}, want: "- id: \"1\"\n  titel: Erster\n"},
+		{format: "template", batches: [][]any{{a}, {}, {c}}, want: "1: Erster\n3: Dritter\n"},
+	}
+	for _, tt := range tests {
+		var buf strings.Builder
+		o := &outputOptions{format: tt.format, columns: "id,titel,urheber.titel"}
+		if tt.format == "template" {
+			o.template = "{{.id}}: {{.titel}}"
+		}
+		ew, err := newEntityWriter(&buf, resource{name: "vorgang"}, o)
+		require.NoError(t, err)
+		for _, batch := range tt.batches {
+			require.NoError(t, ew.write(batch))
+		}
+		require.NoError(t, ew.close())
+		assert.Equal(t, tt.want, buf.String(), tt.format)
+	}
+}
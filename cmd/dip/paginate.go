@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// streamList implements "dip <resource> list -all/-max": it follows the cursor
+// and writes every page to w through the output format as it arrives. It stops
+// after max entities (0 = all), when the cursor no longer changes or ctx is
+// cancelled.
+func streamList(ctx context.Context, w io.Writer, p *progress, r resource, b backend, cmd *command, max int) error {
+	ew, err := newEntityWriter(w, r, cmd.output)
+	if err != nil {
+		return err
+	}
+	defer p.done()
+
+	cursor := getCursor(cmd.params)
+	for {
+		result, err := r.list(ctx, b, cmd.params)
+		if err != nil {
+			if ctx.Err() != nil {
+				p.next = cursor
+				break
+			}
+			return fmt.Errorf("%s error after %d entities: %w", b.name(), ew.count, err)
+		}
+		generic, err := toGeneric(result)
+		if err != nil {
+			return err
+		}
+		entities := documents(generic)
+		numFound, next := pageInfo(result)
+		truncated := max > 0 && ew.count+len(entities) > max
+		if truncated {
+			entities = entities[:max-ew.count]
+		}
+		if err := ew.write(entities); err != nil {
+			return err
+		}
+		p.update(ew.count, numFound)
+
+		// The API returns the same cursor again after the last page
+		if len(entities) == 0 || next == cursor {
+			break
+		}
+		if (max > 0 && ew.count >= max) || ctx.Err() != nil {
+			if !truncated {
+				p.next = next
+			}
+			break
+		}
+		cursor = next
+		setCursor(cmd.params, cursor)
+	}
+	return ew.close()
+}
+
+// getCursor returns the cursor of a *Get*ListParams
+func getCursor(params any) string {
+	if f := cursorField(params); f.IsValid() && !f.IsNil() {
+		return f.Elem().String()
+	}
+	return ""
+}
+
+// setCursor sets the cursor of a *Get*ListParams
+func setCursor(params any, cursor string) {
+	if f := cursorField(params); f.IsValid() {
+		v := reflect.New(f.Type().Elem())
+		v.Elem().SetString(cursor)
+		f.Set(v)
+	}
+}
+
+func cursorField(params any) reflect.Value {
+	return paramField(params, "cursor")
+}
+
+// paramField returns the field of a *Get*Params struct for a query parameter
+func paramField(params any, param string) reflect.Value {
+	v := reflect.ValueOf(params).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("form"), ",")
+		if name == param {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// progress is the progress line of streamList on stderr, shown on a terminal only
+type progress struct {
+	enabled bool
+	start   time.Time
+	pages   int
+	count   int
+	next    string // cursor to continue with after -max or an interrupt
+}
+
+func newProgress(enabled bool) *progress {
+	return &progress{enabled: enabled, start: time.Now()}
+}
+
+func (p *progress) update(count, numFound int) {
+	p.pages++
+	p.count = count
+	if !p.enabled {
+		return
+	}
+	rate := float64(count) / time.Since(p.start).Seconds()
+	fmt.Fprintf(os.Stderr, "\rFetched %d of %d (%d pages, %.1f/sec)    ", count, numFound, p.pages, rate)
+}
+
+func (p *progress) done() {
+	if !p.enabled {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\rFetched %d in %d pages (%s)                    \n", p.count, p.pages, time.Since(p.start).Round(time.Second))
+	if p.next != "" {
+		fmt.Fprintf(os.Stderr, "Next page: -cursor %q\n", p.next)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// page is a list response of the fake backend
+type page struct {
+	NumFound  int32            `json:"numFound"`
+	Cursor    string           `json:"cursor"`
+	Documents []map[string]any `json:"documents"`
+}
+
+// fakeList returns a resource answering list requests from pages by cursor,
+// recording the requested cursors
+func fakeList(pages map[string]page, requested *[]string) resource {
+	return resource{
+		name: "vorgang",
+		list: func(ctx context.Context, b backend, params any) (any, error) {
+			cursor := getCursor(params)
+			*requested = append(*requested, cursor)
+			p, ok := pages[cursor]
+			if !ok {
+				return nil, errors.New("unknown cursor " + cursor)
+			}
+			return p, nil
+		},
+	}
+}
+
+func docs(ids ...string) []map[string]any {
+	var d []map[string]any
+	for _, id := range ids {
+		d = append(d, map[string]any{"id": id})
+	}
+	return d
+}
+
+func TestStreamList(t *testing.T) {
+	// The API returns the cursor of the last page again
+	repeating := map[string]page{
+		"":   {NumFound: 5, Cursor: "c1", Documents: docs("1", "2")},
+		"c1": {NumFound: 5, Cursor: "c2", Documents: docs("3", "4")},
+		"c2": {NumFound: 5, Cursor: "c2", Documents: docs("5")},
+	}
+	// The last page has a new cursor, the page after it is empty
+	empty := map[string]page{
+		"":   {NumFound: 2, Cursor: "c1", Documents: docs("1", "2")},
+		"c1": {NumFound: 2, Cursor: "c2"},
+	}
+	tests := []struct {
+		name      string
+		pages     map[string]page
+		cursor    string // -cursor to start with
+		max       int
+		want      []string
+		requested []string
+		next      string
+	}{
+		{name: "all", pages: repeating, want: []string{"1", "2", "3", "4", "5"}, requested: []string{"", "c1", "c2"}},
+		{name: "empty last page", pages: empty, want: []string{"1", "2"}, requested: []string{"", "c1"}},
+		{name: "max at page end", pages: repeating, max: 4, want: []string{"1", "2", "3", "4"}, requested: []string{"", "c1"}, next: "c2"},
+		// Continuing from c2 would skip 4, so there is no next cursor
+		{name: "max within page", pages: repeating, max: 3, want: []string{"1", "2", "3"}, requested: []string{"", "c1"}},
+		{name: "max beyond total", pages: repeating, max: 10, want: []string{"1", "2", "3", "4", "5"}, requested: []string{"", "c1", "c2"}},
+		{name: "cursor", pages: repeating, cursor: "c1", want: []string{"3", "4", "5"}, requested: []string{"c1", "c2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested []string
+			params := &client.GetVorgangListParams{}
+			if tt.cursor != "" {
+				setCursor(params, tt.cursor)
+			}
+			cmd := &command{params: params, output: &outputOptions{format: "ndjson"}}
+			p := newProgress(false)
+			var out strings.Builder
+			require.NoError(t, streamList(context.Background(), &out, p, fakeList(tt.pages, &requested), backend{}, cmd, tt.max))
+
+			var ids []string
+			dec := json.NewDecoder(strings.NewReader(out.String()))
+			for dec.More() {
+				var doc struct{ ID string }
+				require.NoError(t, dec.Decode(&doc))
+				ids = append(ids, doc.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+			assert.Equal(t, tt.requested, requested)
+			assert.Equal(t, tt.next, p.next)
+			assert.Equal(t, len(tt.want), p.count)
+		})
+	}
+}
+
+func TestStreamListError(t *testing.T) {
+	var requested []string
+	pages := map[string]page{"": {NumFound: 3, Cursor: "c1", Documents: docs("1", "2")}}
+	cmd := &command{params: &client.GetVorgangListParams{}, output: &outputOptions{format: "json"}}
+	var out strings.Builder
+	err := streamList(context.Background(), &out, newProgress(false), fakeList(pages, &requested), backend{}, cmd, 0)
+	assert.EqualError(t, err, "API error after 2 entities: unknown cursor c1")
+
+	// An interrupt ends the output properly and keeps the cursor to continue with
+	ctx, cancel := context.WithCancel(context.Background())
+	r := fakeList(pages, &requested)
+	list := r.list
+	r.list = func(ctx context.Context, b backend, params any) (any, error) {
+		if getCursor(params) == "c1" {
+			cancel()
+			return nil, ctx.Err()
+		}
+		return list(ctx, b, params)
+	}
+	out.Reset()
+	cmd.params = &client.GetVorgangListParams{}
+	p := newProgress(false)
+	require.NoError(t, streamList(ctx, &out, p, r, backend{}, cmd, 0))
+	var ids []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out.String()), &ids))
+	assert.Len(t, ids, 2)
+	assert.Equal(t, "c1", p.next)
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// paramInfo describes an API query parameter for the help of its flag
+type paramInfo struct {
+	usage  string
+	values []string // allowed values, empty for free text
+	and    bool     // repeated values select the intersection instead of the union
+}
+
+// params documents the query parameters of the Get*ListParams structs. Flags are
+// named like the parameters, so the API documentation applies as is.
+var params = map[string]paramInfo{
+	"cursor":                   {usage: "Cursor of the next page, from the previous response"},
+	"f.aktivitaet":             {usage: "ID of a linked Aktivität"},
+	"f.aktualisiert.start":     {usage: "Updated at or after"},
+	"f.aktualisiert.end":       {usage: "Updated at or before"},
+	"f.beratungsstand":         {usage: "Beratungsstand, e.g. \"Noch nicht beraten\""},
+	"f.datum.start":            {usage: "Document date from"},
+	"f.datum.end":              {usage: "Document date until"},
+	"f.deskriptor":             {usage: "Deskriptor", and: true},
+	"f.dokumentart":            {usage: "Dokumentart of linked documents", values: []string{"Drucksache", "Plenarprotokoll"}},
+	"f.dokumentnummer":         {usage: "Dokumentnummer, e.g. 20/1234"},
+	"f.drucksache":             {usage: "ID of a linked Drucksache"},
+	"f.drucksachetyp":          {usage: "Drucksachetyp, e.g. Antrag"},
+	"f.frage_nummer":           {usage: "Fragenummer/Listenziffer"},
+	"f.gesta":                  {usage: "GESTA-Ordnungsnummer, e.g. N001"},
+	"f.id":                     {usage: "ID of the entity"},
+	"f.initiative":             {usage: "Initiative, e.g. Bundesregierung", and: true},
+	"f.person":                 {usage: "Name of a person"},
+	"f.person_id":              {usage: "ID of a person"},
+	"f.plenarprotokoll":        {usage: "ID of a linked Plenarprotokoll"},
+	"f.ressort_fdf":            {usage: "Federführendes Ressort", and: true},
+	"f.sachgebiet":             {usage: "Sachgebiet, e.g. Umwelt", and: true},
+	"f.titel":                  {usage: "Words of the title", and: true},
+	"f.urheber":                {usage: "Urheber, e.g. \"Fraktion der SPD\"", and: true},
+	"f.verkuendung_fundstelle": {usage: "Fundstelle of the Verkündung, e.g. \"BGBl I 2023 Nr. 88\""},
+	"f.vorgang":                {usage: "ID of a linked Vorgang"},
+	"f.vorgangsposition_id":    {usage: "ID of a Vorgangsposition"},
+	"f.vorgangstyp":            {usage: "Vorgangstyp, e.g. Gesetzgebung"},
+	"f.vorgangstyp_notation":   {usage: "Notation of the Vorgangstyp"},
+	"f.wahlperiode":            {usage: "Wahlperiode"},
+	"f.zuordnung":              {usage: "Zuordnung", values: []string{"BT", "BR", "BV", "EK"}},
+}
+
+// skippedParams are not exposed as flags. The client decodes JSON responses only,
+// so the API's format=xml cannot be used.
+var skippedParams = []string{"format"}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	dateType = reflect.TypeOf(openapi_types.Date{})
+)
+
+// paramFlag binds a pointer field of a Get*Params struct to a flag
+type paramFlag struct {
+	name  string
+	field reflect.Value
+	info  paramInfo
+}
+
+// bindParams defines one flag per query parameter of params, a pointer to a
+// Get*Params struct
+func bindParams(fs *flag.FlagSet, params any) {
+	v := reflect.ValueOf(params).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("form"), ",")
+		if name == "" || slices.Contains(skippedParams, name) || t.Field(i).Type.Kind() != reflect.Pointer {
+			continue
+		}
+		p := &paramFlag{name: name, field: v.Field(i), info: paramInfoFor(name)}
+		fs.Var(p, name, p.usage())
+	}
+}
+
+func paramInfoFor(name string) paramInfo {
+	if info, ok := params[name]; ok {
+		return info
+	}
+	return paramInfo{usage: "API parameter " + name}
+}
+
+// usage returns the help of the flag, with the accepted format
+func (p *paramFlag) usage() string {
+	elem := p.field.Type().Elem()
+	usage := p.info.usage
+	switch {
+	case elem == timeType:
+		usage += " (YYYY-MM-DD or RFC 3339)"
+	case elem == dateType:
+		usage += " (YYYY-MM-DD)"
+	case len(p.info.values) > 0:
+		usage += " (" + strings.Join(p.info.values, ", ") + ")"
+	}
+	if elem.Kind() == reflect.Slice {
+		if p.info.and {
+			usage += "; repeat to require all"
+		} else {
+			usage += "; repeat for any of several"
+		}
+	}
+	return usage
+}
+
+// String implements flag.Value
+func (p *paramFlag) String() string {
+	if p == nil || !p.field.IsValid() || p.field.IsNil() {
+		return ""
+	}
+	v := p.field.Elem()
+	switch {
+	case v.Type() == timeType:
+		return v.Interface().(time.Time).Format(time.RFC3339)
+	case v.Type() == dateType:
+		return v.Interface().(openapi_types.Date).String()
+	case v.Kind() == reflect.Slice:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = fmt.Sprint(v.Index(i).Interface())
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// Set implements flag.Value. Repeated flags add to list parameters; integer
+// lists also accept comma-separated values.
+func (p *paramFlag) Set(s string) error {
+	elem := p.field.Type().Elem()
+	switch {
+	case elem == timeType:
+		t, err := parseTime(s, strings.HasSuffix(p.name, ".end"))
+		if err != nil {
+			return err
+		}
+		p.field.Set(reflect.ValueOf(&t))
+	case elem == dateType:
+		t, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			return fmt.Errorf("expected YYYY-MM-DD")
+		}
+		p.field.Set(reflect.ValueOf(&openapi_types.Date{Time: t}))
+	case elem.Kind() == reflect.String:
+		if len(p.info.values) > 0 && !slices.Contains(p.info.values, s) {
+			return fmt.Errorf("must be one of %s", strings.Join(p.info.values, ", "))
+		}
+		v := reflect.New(elem)
+		v.Elem().SetString(s)
+		p.field.Set(v)
+	case elem.Kind() == reflect.Int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("expected an integer")
+		}
+		v := reflect.New(elem)
+		v.Elem().SetInt(int64(n))
+		p.field.Set(v)
+	case elem.Kind() == reflect.Slice:
+		if p.field.IsNil() {
+			p.field.Set(reflect.New(elem))
+		}
+		list := p.field.Elem()
+		values := []string{s}
+		if elem.Elem().Kind() == reflect.Int {
+			values = strings.Split(s, ",")
+		}
+		for _, value := range values {
+			item := reflect.New(elem.Elem()).Elem()
+			switch item.Kind() {
+			case reflect.Int:
+				n, err := strconv.Atoi(strings.TrimSpace(value))
+				if err != nil {
+					return fmt.Errorf("expected integers")
+				}
+				item.SetInt(int64(n))
+			case reflect.String:
+				item.SetString(value)
+			default:
+				return fmt.Errorf("unsupported parameter type %s", elem)
+			}
+			list.Set(reflect.Append(list, item))
+		}
+	default:
+		return fmt.Errorf("unsupported parameter type %s", elem)
+	}
+	return nil
+}
+
+// parseTime parses a date or an RFC 3339 timestamp. A date is the start of the
+// day in local time, or its last second if end is set, so that an end date
+// includes the whole day.
+func parseTime(s string, end bool) (time.Time, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		if end {
+			t = t.AddDate(0, 0, 1).Add(-time.Second)
+		}
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected YYYY-MM-DD or RFC 3339, e.g. 2024-01-31T12:00:00+01:00")
+	}
+	return t, nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamFlagSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string // String() of the flag
+		wantErr string
+	}{
+		{name: "f.id", args: []string{"-f.id", "1,2", "-f.id", " 3"}, want: "1,2,3"},
+		{name: "f.id", args: []string{"-f.id", "1,x"}, wantErr: "expected integers"},
+		{name: "f.wahlperiode", args: []string{"-f.wahlperiode", "19,20"}, want: "19,20"},
+		{name: "f.titel", args: []string{"-f.titel", "Wärme, Planung", "-f.titel", "Gesetz"}, want: "Wärme, Planung,Gesetz"},
+		{name: "f.zuordnung", args: []string{"-f.zuordnung", "BT"}, want: "BT"},
+		{name: "f.zuordnung", args: []string{"-f.zuordnung", "BX"}, wantErr: "must be one of BT, BR, BV, EK"},
+		{name: "f.dokumentart", args: []string{"-f.dokumentart", "Drucksache"}, want: "Drucksache"},
+		{name: "f.dokumentart", args: []string{"-f.dokumentart", "drucksache"}, wantErr: "must be one of Drucksache, Plenarprotokoll"},
+		{name: "f.datum.start", args: []string{"-f.datum.start", "2024-01-31"}, want: "2024-01-31"},
+		{name: "f.datum.start", args: []string{"-f.datum.start", "2024-01-31T12:00:00+01:00"}, wantErr: "expected YYYY-MM-DD"},
+		{name: "f.aktualisiert.start", args: []string{"-f.aktualisiert.start", "2024-01-31T12:00:00+01:00"}, want: "2024-01-31T12:00:00+01:00"},
+		{name: "f.aktualisiert.start", args: []string{"-f.aktualisiert.start", "31.01.2024"}, wantErr: "expected YYYY-MM-DD or RFC 3339"},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			bindParams(fs, &client.GetVorgangspositionListParams{})
+			err := fs.Parse(tt.args)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, fs.Lookup(tt.name).Value.String())
+		})
+	}
+}
+
+func TestParamFlagSetTime(t *testing.T) {
+	params := &client.GetVorgangspositionListParams{}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	bindParams(fs, params)
+
+	// A date is the start of the day in local time, a timestamp keeps its zone
+	require.NoError(t, fs.Parse([]string{"-f.aktualisiert.start", "2024-01-31", "-f.aktualisiert.end", "2024-01-31T12:00:00Z"}))
+	assert.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, time.Local), *params.FAktualisiertStart)
+	assert.Equal(t, time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC), *params.FAktualisiertEnd)
+	assert.Nil(t, params.FId)
+
+	// An end date includes the whole day
+	require.NoError(t, fs.Parse([]string{"-f.aktualisiert.end", "2024-01-31"}))
+	assert.Equal(t, time.Date(2024, 1, 31, 23, 59, 59, 0, time.Local), *params.FAktualisiertEnd)
+}
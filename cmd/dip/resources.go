@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+
+	"github.com/Johanneslueke/dip-client/internal/offline"
+	dipclient "github.com/Johanneslueke/dip-client/pkg/dip-client"
+)
+
+// backend answers the requests: the API, or the local database with -local
+type backend struct {
+	client *dipclient.Client
+	local  *offline.DB
+}
+
+// resource is an API resource with its list and get endpoints, e.g. "dip vorgang list"
+type resource struct {
+	name       string
+	summary    string
+	listParams func() any // returns a new *Get*ListParams
+	getParams  func() any // returns a new *Get*Params
+	list       func(ctx context.Context, b backend, params any) (any, error)
+	get        func(ctx context.Context, b backend, id dipclient.ID, params any) (any, error)
+}
+
+// resourceOf builds a resource from the list and get methods of the client and
+// of the local database, which take the same parameters
+func resourceOf[LP, L, GP, G any](
+	name, summary string,
+	list func(*dipclient.Client, context.Context, *LP) (L, error),
+	get func(*dipclient.Client, context.Context, dipclient.ID, *GP) (G, error),
+	localList func(*offline.DB, context.Context, *LP) (L, error),
+	localGet func(*offline.DB, context.Context, dipclient.ID, *GP) (G, error),
+) resource {
+	return resource{
+		name:       name,
+		summary:    summary,
+		listParams: func() any { return new(LP) },
+		getParams:  func() any { return new(GP) },
+		list: func(ctx context.Context, b backend, params any) (any, error) {
+			if b.local != nil {
+				return localList(b.local, ctx, params.(*LP))
+			}
+			return list(b.client, ctx, params.(*LP))
+		},
+		get: func(ctx context.Context, b backend, id dipclient.ID, params any) (any, error) {
+			if b.local != nil {
+				return localGet(b.local, ctx, id, params.(*GP))
+			}
+			return get(b.client, ctx, id, params.(*GP))
+		},
+	}
+}
+
+// resources are the API resources in the order of the API documentation
+var resources = []resource{
+	resourceOf("aktivitaet", "Aktivitäten of persons, e.g. Reden and Fragen",
+		(*dipclient.Client).GetAktivitaetList, (*dipclient.Client).GetAktivitaet,
+		(*offline.DB).GetAktivitaetList, (*offline.DB).GetAktivitaet),
+	resourceOf("drucksache", "Drucksachen (metadata)",
+		(*dipclient.Client).GetDrucksacheList, (*dipclient.Client).GetDrucksache,
+		(*offline.DB).GetDrucksacheList, (*offline.DB).GetDrucksache),
+	resourceOf("drucksache-text", "Drucksachen with full text",
+		(*dipclient.Client).GetDrucksacheTextList, (*dipclient.Client).GetDrucksacheText,
+		(*offline.DB).GetDrucksacheTextList, (*offline.DB).GetDrucksacheText),
+	resourceOf("person", "Persons",
+		(*dipclient.Client).GetPersonList, (*dipclient.Client).GetPerson,
+		(*offline.DB).GetPersonList, (*offline.DB).GetPerson),
+	resourceOf("plenarprotokoll", "Plenarprotokolle (metadata)",
+		(*dipclient.Client).GetPlenarprotokollList, (*dipclient.Client).GetPlenarprotokoll,
+		(*offline.DB).GetPlenarprotokollList, (*offline.DB).GetPlenarprotokoll),
+	resourceOf("plenarprotokoll-text", "Plenarprotokolle with full text",
+		(*dipclient.Client).GetPlenarprotokollTextList, (*dipclient.Client).GetPlenarprotokollText,
+		(*offline.DB).GetPlenarprotokollTextList, (*offline.DB).GetPlenarprotokollText),
+	resourceOf("vorgang", "Vorgänge, e.g. Gesetzgebungsverfahren",
+		(*dipclient.Client).GetVorgangList, (*dipclient.Client).GetVorgang,
+		(*offline.DB).GetVorgangList, (*offline.DB).GetVorgang),
+	resourceOf("vorgangsposition", "Vorgangspositionen, the steps of a Vorgang",
+		(*dipclient.Client).GetVorgangspositionList, (*dipclient.Client).GetVorgangsposition,
+		(*offline.DB).GetVorgangspositionList, (*offline.DB).GetVorgangsposition),
+}
+
+// verbs are the subcommands of every resource
+var verbs = []struct{ name, summary string }{
+	{"list", "List entities matching the filters, one page per call"},
+	{"get", "Get one entity by ID"},
+}
+
+func findResource(name string) (resource, bool) {
+	for _, r := range resources {
+		if r.name == name {
+			return r, true
+		}
+	}
+	return resource{}, false
+}
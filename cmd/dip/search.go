@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/Johanneslueke/dip-client/internal/config"
+	"github.com/Johanneslueke/dip-client/internal/search"
+	"github.com/Johanneslueke/dip-client/internal/store"
+)
+
+// runSearch implements "dip search": full-text search over the texts in the local database
+func runSearch(args []string) {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	var (
+		dbPath      = fs.String(config.DB, config.Default(config.DB), "SQLite database path")
+		profile     = fs.String("profile", defaultProfile, config.ProfileUsage)
+		dokumentart = fs.String("type", "", "Only search Drucksache or Plenarprotokoll texts")
+		wahlperiode = fs.Int("wahlperiode", 0, "Wahlperiode filter")
+		limit       = fs.Int("limit", search.DefaultLimit, "Maximum number of hits")
+		stemming    = fs.Bool("stem", false, "Also match other inflections of the words")
+		asJSON      = fs.Bool("json", false, "Print hits as JSON")
+	)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: dip search [flags] <query>\n\n")
+		fmt.Fprintf(fs.Output(), "All words must match. Use \"quoted phrases\" for exact phrases and word* for prefixes.\n\n")
+		fmt.Fprintf(fs.Output(), "Scores of Drucksachen and Plenarprotokolle are not strictly comparable; use -type for an exact ranking.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	applyConfig(fs, *profile)
+
+	query := strings.Join(fs.Args(), " ")
+	if strings.TrimSpace(query) == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	s, err := store.Open(store.SQLite, *dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer s.Close()
+
+	hits, err := search.Search(context.Background(), s.DB(), search.Options{
+		Query:       query,
+		Dokumentart: *dokumentart,
+		Wahlperiode: *wahlperiode,
+		Limit:       *limit,
+		Stemming:    *stemming,
+	})
+	if err != nil {
+		log.Fatalf("Search failed: %v", err)
+	}
+
+	if *asJSON {
+		output, err := json.MarshalIndent(hits, "", "  ")
+		if err != nil {
+			log.Fatalf("Marshal error: %v", err)
+		}
+		fmt.Println(string(output))
+		return
+	}
+
+	if len(hits) == 0 {
+		fmt.Println("No matches")
+		return
+	}
+	for i, hit := range hits {
+		fmt.Printf("%d. %s %s (%s", i+1, hit.Dokumentart, hit.Dokumentnummer, hit.Datum)
+		if hit.Drucksachetyp != "" {
+			fmt.Printf(", %s", hit.Drucksachetyp)
+		}
+		fmt.Printf(", score %.2f)\n", hit.Score)
+		fmt.Printf("   %s\n", hit.Titel)
+		fmt.Printf("   %s\n", strings.Join(strings.Fields(hit.Snippet), " "))
+		for _, vorgang := range hit.Vorgaenge {
+			fmt.Printf("   ↳ Vorgang %s: %s (%s)\n", vorgang.ID, vorgang.Titel, vorgang.Vorgangstyp)
+		}
+		if hit.PdfURL != "" {
+			fmt.Printf("   %s\n", hit.PdfURL)
+		}
+		fmt.Println()
+	}
+}
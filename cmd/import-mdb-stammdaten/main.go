@@ -10,6 +10,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/Johanneslueke/dip-client/internal/config"
 	db "github.com/Johanneslueke/dip-client/internal/database/gen/sqlite"
 	_ "modernc.org/sqlite"
 )
@@ -89,11 +90,13 @@ type ImportStats struct {
 
 func main() {
 	// Parse command-line flags
-	dbPath := flag.String("db", "dip.clean.db", "Path to SQLite database")
+	dbPath := flag.String(config.DB, config.Default(config.DB), "Path to SQLite database")
 	xmlPath := flag.String("xml", "MdB-Stammdaten/MDB_STAMMDATEN.XML", "Path to MDB_STAMMDATEN.XML file")
 	dryRun := flag.Bool("dry-run", false, "Parse XML without importing to database")
 	verbose := flag.Bool("verbose", false, "Show detailed progress information")
-	flag.Parse()
+	if _, err := config.Parse(flag.CommandLine, os.Args[1:]); err != nil {
+		log.Fatalf("Config error: %v", err)
+	}
 
 	// Open and parse XML file
 	log.Printf("Opening XML file: %s", *xmlPath)
@@ -6,9 +6,11 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/Johanneslueke/dip-client/internal/config"
 	db "github.com/Johanneslueke/dip-client/internal/database/gen/sqlite"
 	_ "modernc.org/sqlite"
 )
@@ -36,11 +38,13 @@ type MdBPerson struct {
 
 func main() {
 	// Parse command-line flags
-	dbPath := flag.String("db", "dip.clean.db", "Path to SQLite database")
+	dbPath := flag.String(config.DB, config.Default(config.DB), "Path to SQLite database")
 	dryRun := flag.Bool("dry-run", false, "Show matches without creating links")
 	minConfidence := flag.String("min-confidence", "high", "Minimum confidence level to create links (exact, high, medium)")
 	verbose := flag.Bool("verbose", false, "Show detailed matching information")
-	flag.Parse()
+	if _, err := config.Parse(flag.CommandLine, os.Args[1:]); err != nil {
+		log.Fatalf("Config error: %v", err)
+	}
 
 	log.Printf("Opening database: %s", *dbPath)
 	sqlDB, err := sql.Open("sqlite", *dbPath)
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Johanneslueke/dip-client/internal/config"
+	db "github.com/Johanneslueke/dip-client/internal/database/gen/sqlite"
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+	"github.com/Johanneslueke/dip-client/internal/zitat"
+)
+
+// ProcessStats tracks processing statistics
+type ProcessStats struct {
+	Texte      int
+	Zitate     int // cited documents, one per citing and cited document
+	Verweise   int // references in the texts
+	Eigene     int // references of a document to itself, skipped
+	Failed     int
+	Resolved   int64 // cited documents resolved to a synced document in this run
+	Unresolved int64 // cited documents that are not synced
+}
+
+// edge is a cited document with the number of references to it
+type edge struct {
+	zitat  zitat.Zitat // first reference
+	anzahl int
+}
+
+func main() {
+	dbPath := flag.String(config.DB, config.Default(config.DB), "Path to SQLite database")
+	dokumentart := flag.String("dokumentart", "", "Only process texts of this Dokumentart: Drucksache or Plenarprotokoll (default: both)")
+	all := flag.Bool("all", false, "Process all texts again, not only new or changed ones")
+	limit := flag.Int("limit", 0, "Maximum number of texts to process (0 = all)")
+	verbose := flag.Bool("verbose", false, "Log the number of cited documents per text")
+	// The tables of this command only exist in the SQLite schema
+	cfg, err := config.Parse(flag.CommandLine, os.Args[1:])
+	if err == nil {
+		err = cfg.SQLiteOnly()
+	}
+	if err != nil {
+		log.Fatalf("Config error: %v", err)
+	}
+
+	if *dokumentart != "" && *dokumentart != zitat.Drucksache && *dokumentart != zitat.Plenarprotokoll {
+		log.Fatalf("Invalid -dokumentart %q: must be Drucksache or Plenarprotokoll", *dokumentart)
+	}
+
+	log.Printf("Opening database: %s", *dbPath)
+	s, err := store.Open(store.SQLite, *dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer s.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signalHandler := utility.NewSignalHandler(func() { cancel() }, nil)
+	defer signalHandler.Stop()
+
+	queries := db.New(s.DB())
+
+	var allTexte int64
+	if *all {
+		allTexte = 1
+	}
+	pending, err := queries.ListPendingZitatTexte(ctx, db.ListPendingZitatTexteParams{
+		Dokumentart:   *dokumentart,
+		AllTexte:      allTexte,
+		ParserVersion: zitat.ParserVersion,
+	})
+	if err != nil {
+		log.Fatalf("Failed to list texts: %v", err)
+	}
+	if *limit > 0 && len(pending) > *limit {
+		pending = pending[:*limit]
+	}
+	log.Printf("Extracting citations from %d texts (parser version %d)", len(pending), zitat.ParserVersion)
+
+	stats := ProcessStats{}
+	start := time.Now()
+	for i, row := range pending {
+		if ctx.Err() != nil {
+			log.Printf("Interrupted after %d texts", i)
+			break
+		}
+
+		count, err := processText(ctx, s.DB(), queries, row, &stats)
+		if err != nil {
+			log.Printf("❌ %s %s: %v", row.Dokumentart, row.ID, err)
+			stats.Failed++
+			continue
+		}
+		stats.Texte++
+		stats.Zitate += count
+		if *verbose {
+			log.Printf("%s %s: %d cited documents", row.Dokumentart, row.Dokumentnummer, count)
+		}
+		if (i+1)%1000 == 0 {
+			log.Printf("Progress: %d/%d texts, %d cited documents", i+1, len(pending), stats.Zitate)
+		}
+	}
+
+	// Also resolves citations of earlier runs whose documents were synced since
+	stats.Resolved, err = resolveZitate(context.Background(), queries)
+	if err != nil {
+		log.Fatalf("Failed to resolve cited documents: %v", err)
+	}
+	stats.Unresolved, err = queries.CountUnresolvedDokumentZitate(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to count unresolved cited documents: %v", err)
+	}
+
+	printStats(stats, time.Since(start))
+}
+
+// processText extracts the citations of one text and replaces its edges in one transaction
+func processText(ctx context.Context, sqlDB *sql.DB, queries *db.Queries, row db.ListPendingZitatTexteRow, stats *ProcessStats) (int, error) {
+	var (
+		text sql.NullString
+		err  error
+	)
+	if row.Dokumentart == zitat.Drucksache {
+		text, err = queries.GetDrucksacheTextContent(ctx, row.ID)
+	} else {
+		text, err = queries.GetPlenarprotokollTextContent(ctx, row.ID)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to load text: %w", err)
+	}
+
+	zitate := zitat.Parse(text.String)
+	stats.Verweise += len(zitate)
+	edges := groupZitate(row, zitate, stats)
+
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := queries.WithTx(tx)
+
+	if err := qtx.DeleteDokumentZitate(ctx, db.DeleteDokumentZitateParams{
+		QuelleDokumentart: row.Dokumentart,
+		QuelleID:          row.ID,
+	}); err != nil {
+		return 0, fmt.Errorf("DeleteDokumentZitate: %w", err)
+	}
+	for _, e := range edges {
+		if err := qtx.CreateDokumentZitat(ctx, db.CreateDokumentZitatParams{
+			QuelleDokumentart:  row.Dokumentart,
+			QuelleID:           row.ID,
+			ZielDokumentart:    e.zitat.Dokumentart,
+			ZielHerausgeber:    e.zitat.Herausgeber,
+			ZielDokumentnummer: e.zitat.Dokumentnummer,
+			Anzahl:             int64(e.anzahl),
+			ErsterOffset:       int64(e.zitat.Offset),
+			Roh:                e.zitat.Raw,
+		}); err != nil {
+			return 0, fmt.Errorf("CreateDokumentZitat %s %s: %w", e.zitat.Dokumentart, e.zitat.Dokumentnummer, err)
+		}
+	}
+	if err := qtx.UpsertDokumentZitatVerarbeitung(ctx, db.UpsertDokumentZitatVerarbeitungParams{
+		Dokumentart:   row.Dokumentart,
+		DokumentID:    row.ID,
+		ParserVersion: zitat.ParserVersion,
+		TextUpdatedAt: row.UpdatedAt,
+		ZitateAnzahl:  int64(len(edges)),
+	}); err != nil {
+		return 0, fmt.Errorf("UpsertDokumentZitatVerarbeitung: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return len(edges), nil
+}
+
+// resolveZitate resolves cited documents via their dokumentnummer and returns the number of resolved ones
+func resolveZitate(ctx context.Context, queries *db.Queries) (int64, error) {
+	drucksachen, err := queries.ResolveDrucksacheZitate(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("ResolveDrucksacheZitate: %w", err)
+	}
+	plenarprotokolle, err := queries.ResolvePlenarprotokollZitate(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("ResolvePlenarprotokollZitate: %w", err)
+	}
+	return drucksachen + plenarprotokolle, nil
+}
+
+// groupZitate returns one edge per cited document in order of the first
+// reference, skipping references of the document to itself (e.g. on the title page)
+func groupZitate(row db.ListPendingZitatTexteRow, zitate []zitat.Zitat, stats *ProcessStats) []*edge {
+	var (
+		edges []*edge
+		seen  = make(map[zitat.Zitat]*edge)
+	)
+	for _, z := range zitate {
+		if z.Dokumentart == row.Dokumentart && z.Herausgeber == row.Herausgeber && z.Dokumentnummer == row.Dokumentnummer {
+			stats.Eigene++
+			continue
+		}
+		key := zitat.Zitat{Dokumentart: z.Dokumentart, Herausgeber: z.Herausgeber, Dokumentnummer: z.Dokumentnummer}
+		if e, ok := seen[key]; ok {
+			e.anzahl++
+			continue
+		}
+		e := &edge{zitat: z, anzahl: 1}
+		seen[key] = e
+		edges = append(edges, e)
+	}
+	return edges
+}
+
+func printStats(stats ProcessStats, elapsed time.Duration) {
+	fmt.Println("\n=== Citation Statistics ===")
+	fmt.Printf("  Texts processed:         %6d\n", stats.Texte)
+	fmt.Printf("  Failed:                  %6d\n", stats.Failed)
+	fmt.Printf("  References found:        %6d\n", stats.Verweise)
+	fmt.Printf("  Self-references skipped: %6d\n", stats.Eigene)
+	fmt.Printf("  Cited documents stored:  %6d\n", stats.Zitate)
+	fmt.Printf("  Newly resolved:          %6d\n", stats.Resolved)
+	fmt.Printf("  Unresolved (not synced): %6d\n", stats.Unresolved)
+	fmt.Printf("  Duration:                %6s\n", elapsed.Round(time.Second))
+}
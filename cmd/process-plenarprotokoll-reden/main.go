@@ -0,0 +1,370 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Johanneslueke/dip-client/internal/config"
+	db "github.com/Johanneslueke/dip-client/internal/database/gen/sqlite"
+	"github.com/Johanneslueke/dip-client/internal/protokoll"
+	"github.com/Johanneslueke/dip-client/internal/sprecher"
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+)
+
+// ProcessStats tracks processing statistics
+type ProcessStats struct {
+	Protokolle int
+	Reden      int
+	Reaktionen int // Beifall, Zurufe etc. parsed from stage directions
+	Empty      int // Plenarprotokolle without recognized speaker lines
+	Failed     int
+	Resolved   int // Reden whose speaker was assigned to a person
+	Unresolved int // Reden whose speaker was queued for review
+}
+
+func main() {
+	dbPath := flag.String(config.DB, config.Default(config.DB), "Path to SQLite database")
+	all := flag.Bool("all", false, "Parse all Plenarprotokolle again, not only new or changed texts")
+	limit := flag.Int("limit", 0, "Maximum number of Plenarprotokolle to process (0 = all)")
+	verbose := flag.Bool("verbose", false, "Log the number of Reden per Plenarprotokoll")
+	resolve := flag.Bool("resolve", true, "Resolve speakers to DIP persons and MdB Stammdaten")
+	resolveOnly := flag.Bool("resolve-only", false, "Only resolve the speakers of stored Reden without person or MdB (with -all: of all Reden)")
+	minConfidence := flag.Float64("min-confidence", sprecher.DefaultMinConfidence, "Minimum confidence to assign a speaker to a person")
+	// The tables of this command only exist in the SQLite schema
+	cfg, err := config.Parse(flag.CommandLine, os.Args[1:])
+	if err == nil {
+		err = cfg.SQLiteOnly()
+	}
+	if err != nil {
+		log.Fatalf("Config error: %v", err)
+	}
+
+	log.Printf("Opening database: %s", *dbPath)
+	s, err := store.Open(store.SQLite, *dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer s.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signalHandler := utility.NewSignalHandler(func() { cancel() }, nil)
+	defer signalHandler.Stop()
+
+	queries := db.New(s.DB())
+
+	var resolver *sprecher.Resolver
+	if *resolve || *resolveOnly {
+		resolver, err = sprecher.NewResolver(ctx, queries, *minConfidence)
+		if err != nil {
+			log.Fatalf("Failed to load speaker data: %v", err)
+		}
+	}
+
+	if *resolveOnly {
+		start := time.Now()
+		stats, err := resolveStoredReden(ctx, queries, resolver, *all)
+		if err != nil {
+			log.Fatalf("Failed to resolve speakers: %v", err)
+		}
+		printStats(stats, time.Since(start))
+		return
+	}
+
+	var pending []db.ListPendingRedenProtokolleRow
+	if *all {
+		rows, err := queries.ListAllRedenProtokolle(ctx)
+		if err != nil {
+			log.Fatalf("Failed to list Plenarprotokolle: %v", err)
+		}
+		for _, row := range rows {
+			pending = append(pending, db.ListPendingRedenProtokolleRow(row))
+		}
+	} else {
+		pending, err = queries.ListPendingRedenProtokolle(ctx, protokoll.ParserVersion)
+		if err != nil {
+			log.Fatalf("Failed to list Plenarprotokolle: %v", err)
+		}
+	}
+	if *limit > 0 && len(pending) > *limit {
+		pending = pending[:*limit]
+	}
+	log.Printf("Parsing %d Plenarprotokoll texts (parser version %d)", len(pending), protokoll.ParserVersion)
+
+	stats := ProcessStats{}
+	start := time.Now()
+	for i, row := range pending {
+		if ctx.Err() != nil {
+			log.Printf("Interrupted after %d Plenarprotokolle", i)
+			break
+		}
+
+		count, err := processProtokoll(ctx, s.DB(), queries, resolver, row, &stats)
+		if err != nil {
+			log.Printf("❌ Plenarprotokoll %s: %v", row.ID, err)
+			stats.Failed++
+			continue
+		}
+		stats.Protokolle++
+		stats.Reden += count
+		if count == 0 {
+			stats.Empty++
+		}
+		if *verbose {
+			log.Printf("Plenarprotokoll %s: %d Reden", row.ID, count)
+		}
+		if (i+1)%100 == 0 {
+			log.Printf("Progress: %d/%d Plenarprotokolle, %d Reden", i+1, len(pending), stats.Reden)
+		}
+	}
+
+	printStats(stats, time.Since(start))
+}
+
+// processProtokoll parses one Plenarprotokoll text and replaces its Reden in one transaction.
+// If resolver is not nil, the speakers are resolved before the Reden are stored.
+func processProtokoll(ctx context.Context, sqlDB *sql.DB, queries *db.Queries, resolver *sprecher.Resolver, row db.ListPendingRedenProtokolleRow, stats *ProcessStats) (int, error) {
+	text, err := queries.GetPlenarprotokollTextContent(ctx, row.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load text: %w", err)
+	}
+	reden := protokoll.ParseReden(text.String)
+
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := queries.WithTx(tx)
+
+	if err := qtx.DeleteRedeReaktionen(ctx, row.ID); err != nil {
+		return 0, fmt.Errorf("DeleteRedeReaktionen: %w", err)
+	}
+	if err := qtx.DeleteReden(ctx, row.ID); err != nil {
+		return 0, fmt.Errorf("DeleteReden: %w", err)
+	}
+	var resolved, unresolved, reaktionen int
+	for _, rede := range reden {
+		params := db.CreateRedeParams{
+			PlenarprotokollID:  row.ID,
+			Position:           int64(rede.Position),
+			Sprecher:           rede.Sprecher,
+			Rolle:              store.NullString(rede.Rolle),
+			Fraktion:           store.NullString(rede.Fraktion),
+			Tagesordnungspunkt: store.NullString(rede.Tagesordnungspunkt),
+			StartOffset:        int64(rede.Start),
+			EndOffset:          int64(rede.End),
+			Text:               rede.Text,
+		}
+		var result sprecher.Result
+		if resolver != nil {
+			result = resolver.Resolve(sprecherInput(rede.Sprecher, params.Rolle, params.Fraktion, row.Datum, row.Wahlperiode))
+			params.PersonID = store.NullString(result.PersonID)
+			params.MdbID = store.NullString(result.MdbID)
+			params.SprecherConfidence = sql.NullFloat64{Float64: result.Confidence, Valid: true}
+			params.SprecherMethode = store.NullString(result.Methode)
+		}
+
+		redeID, err := qtx.CreateRede(ctx, params)
+		if err != nil {
+			return 0, fmt.Errorf("CreateRede %d: %w", rede.Position, err)
+		}
+		count, err := storeReaktionen(ctx, qtx, resolver, row, redeID, rede.Text)
+		if err != nil {
+			return 0, fmt.Errorf("Rede %d: %w", rede.Position, err)
+		}
+		reaktionen += count
+		if resolver == nil {
+			continue
+		}
+		if err := recordReview(ctx, qtx, rede.Sprecher, params.Rolle, params.Fraktion, redeID, result); err != nil {
+			return 0, err
+		}
+		if result.Resolved {
+			resolved++
+		} else {
+			unresolved++
+		}
+	}
+	if err := qtx.UpsertRedeVerarbeitung(ctx, db.UpsertRedeVerarbeitungParams{
+		PlenarprotokollID: row.ID,
+		ParserVersion:     protokoll.ParserVersion,
+		TextUpdatedAt:     row.UpdatedAt,
+		RedenAnzahl:       int64(len(reden)),
+	}); err != nil {
+		return 0, fmt.Errorf("UpsertRedeVerarbeitung: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	stats.Resolved += resolved
+	stats.Unresolved += unresolved
+	stats.Reaktionen += reaktionen
+	return len(reden), nil
+}
+
+// storeReaktionen parses the stage directions of a Rede and stores its Reaktionen.
+// Members calling out are resolved like speakers, but not queued for review.
+func storeReaktionen(ctx context.Context, q *db.Queries, resolver *sprecher.Resolver, row db.ListPendingRedenProtokolleRow, redeID int64, text string) (int, error) {
+	reaktionen := protokoll.ParseReaktionen(text)
+	for _, reaktion := range reaktionen {
+		params := db.CreateRedeReaktionParams{
+			RedeID:            redeID,
+			PlenarprotokollID: row.ID,
+			Position:          int64(reaktion.Position),
+			Kommentar:         int64(reaktion.Kommentar),
+			Art:               reaktion.Art,
+			Fraktion:          store.NullString(reaktion.Fraktion),
+			Sprecher:          store.NullString(reaktion.Person),
+			Text:              store.NullString(reaktion.Text),
+			KommentarOffset:   int64(reaktion.Offset),
+			Roh:               reaktion.Raw,
+		}
+		if reaktion.Abgeordnete {
+			params.Abgeordnete = 1
+		}
+		if resolver != nil && reaktion.Person != "" {
+			result := resolver.Resolve(sprecherInput(reaktion.Person, sql.NullString{}, params.Fraktion, row.Datum, row.Wahlperiode))
+			params.PersonID = store.NullString(result.PersonID)
+			params.MdbID = store.NullString(result.MdbID)
+		}
+		if err := q.CreateRedeReaktion(ctx, params); err != nil {
+			return 0, fmt.Errorf("CreateRedeReaktion %d: %w", reaktion.Position, err)
+		}
+	}
+	return len(reaktionen), nil
+}
+
+// resolveStoredReden resolves the speakers of stored Reden again, e.g. after
+// import-mdb, link-person-mdb or decisions in rede_sprecher_review
+func resolveStoredReden(ctx context.Context, queries *db.Queries, resolver *sprecher.Resolver, all bool) (ProcessStats, error) {
+	stats := ProcessStats{}
+	var allReden int64
+	if all {
+		allReden = 1
+	}
+	reden, err := queries.ListRedenForSprecher(ctx, allReden)
+	if err != nil {
+		return stats, fmt.Errorf("failed to list Reden: %w", err)
+	}
+	log.Printf("Resolving speakers of %d Reden", len(reden))
+
+	for i, rede := range reden {
+		if ctx.Err() != nil {
+			log.Printf("Interrupted after %d Reden", i)
+			break
+		}
+		result := resolver.Resolve(sprecherInput(rede.Sprecher, rede.Rolle, rede.Fraktion, rede.Datum, rede.Wahlperiode))
+		if err := queries.UpdateRedeSprecher(ctx, db.UpdateRedeSprecherParams{
+			PersonID:           store.NullString(result.PersonID),
+			MdbID:              store.NullString(result.MdbID),
+			SprecherConfidence: sql.NullFloat64{Float64: result.Confidence, Valid: true},
+			SprecherMethode:    store.NullString(result.Methode),
+			ID:                 rede.ID,
+		}); err != nil {
+			log.Printf("❌ Rede %d: %v", rede.ID, err)
+			stats.Failed++
+			continue
+		}
+		if err := recordReview(ctx, queries, rede.Sprecher, rede.Rolle, rede.Fraktion, rede.ID, result); err != nil {
+			log.Printf("❌ Rede %d: %v", rede.ID, err)
+			stats.Failed++
+			continue
+		}
+		stats.Reden++
+		if result.Resolved {
+			stats.Resolved++
+		} else {
+			stats.Unresolved++
+		}
+		if (i+1)%10000 == 0 {
+			log.Printf("Progress: %d/%d Reden, %d resolved", i+1, len(reden), stats.Resolved)
+		}
+	}
+
+	reaktionen, err := queries.ListRedeReaktionenForSprecher(ctx, allReden)
+	if err != nil {
+		return stats, fmt.Errorf("failed to list Reaktionen: %w", err)
+	}
+	log.Printf("Resolving members of %d Zurufe", len(reaktionen))
+	for _, reaktion := range reaktionen {
+		if ctx.Err() != nil {
+			break
+		}
+		result := resolver.Resolve(sprecherInput(reaktion.Sprecher.String, sql.NullString{}, reaktion.Fraktion, reaktion.Datum, reaktion.Wahlperiode))
+		if err := queries.UpdateRedeReaktionSprecher(ctx, db.UpdateRedeReaktionSprecherParams{
+			PersonID: store.NullString(result.PersonID),
+			MdbID:    store.NullString(result.MdbID),
+			ID:       reaktion.ID,
+		}); err != nil {
+			log.Printf("❌ Reaktion %d: %v", reaktion.ID, err)
+			stats.Failed++
+			continue
+		}
+		stats.Reaktionen++
+	}
+	return stats, nil
+}
+
+// recordReview queues an unresolved speaker for review and removes the open
+// review entry of a speaker that was resolved automatically
+func recordReview(ctx context.Context, q *db.Queries, name string, rolle, fraktion sql.NullString, redeID int64, result sprecher.Result) error {
+	if result.Resolved {
+		if result.Methode == sprecher.MethodManual {
+			return nil
+		}
+		if err := q.DeleteOpenSprecherReview(ctx, db.DeleteOpenSprecherReviewParams{Sprecher: name, Fraktion: fraktion.String}); err != nil {
+			return fmt.Errorf("DeleteOpenSprecherReview: %w", err)
+		}
+		return nil
+	}
+
+	kandidaten, err := json.Marshal(result.Kandidaten)
+	if err != nil {
+		return fmt.Errorf("failed to encode candidates: %w", err)
+	}
+	if err := q.UpsertSprecherReview(ctx, db.UpsertSprecherReviewParams{
+		Sprecher:        name,
+		Fraktion:        fraktion.String,
+		Rolle:           rolle,
+		Kandidaten:      string(kandidaten),
+		BesteConfidence: sql.NullFloat64{Float64: result.Confidence, Valid: len(result.Kandidaten) > 0},
+		BeispielRedeID:  sql.NullInt64{Int64: redeID, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("UpsertSprecherReview: %w", err)
+	}
+	return nil
+}
+
+func sprecherInput(name string, rolle, fraktion, datum sql.NullString, wahlperiode sql.NullInt64) sprecher.Input {
+	in := sprecher.Input{
+		Sprecher:    name,
+		Rolle:       rolle.String,
+		Fraktion:    fraktion.String,
+		Wahlperiode: int(wahlperiode.Int64),
+	}
+	if t, err := time.Parse("2006-01-02", datum.String); err == nil {
+		in.Datum = t
+	}
+	return in
+}
+
+func printStats(stats ProcessStats, elapsed time.Duration) {
+	fmt.Println("\n=== Reden Statistics ===")
+	fmt.Printf("  Plenarprotokolle parsed: %6d\n", stats.Protokolle)
+	fmt.Printf("  Reden stored:            %6d\n", stats.Reden)
+	fmt.Printf("  Reaktionen stored:       %6d\n", stats.Reaktionen)
+	fmt.Printf("  Without speaker lines:   %6d\n", stats.Empty)
+	fmt.Printf("  Speakers resolved:       %6d\n", stats.Resolved)
+	fmt.Printf("  Speakers unresolved:     %6d\n", stats.Unresolved)
+	fmt.Printf("  Failed:                  %6d\n", stats.Failed)
+	fmt.Printf("  Duration:                %6s\n", elapsed.Round(time.Second))
+}
@@ -1,65 +1,59 @@
 package main
 
 import (
-	"database/sql"
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"os"
-	"os/exec"
 	"strings"
 	"time"
 
+	"github.com/Johanneslueke/dip-client/internal/syncer"
 	"github.com/Johanneslueke/dip-client/internal/utility"
-	_ "modernc.org/sqlite"
 )
 
-type SyncCommand struct {
-	Name        string
-	Description string
-	BinaryPath  string
-	Enabled     bool
+// summaryEntry is the JSON representation of a single sync result
+type summaryEntry struct {
+	Entity      string  `json:"entity"`
+	Stored      int     `json:"stored"`
+	Failed      int     `json:"failed"`
+	Seconds     float64 `json:"duration_seconds"`
+	Interrupted bool    `json:"interrupted"`
+	Skipped     bool    `json:"skipped,omitempty"`
+	Error       string  `json:"error,omitempty"`
 }
 
-var syncCommands = []SyncCommand{
-	{Name: "personen", Description: "Sync persons (Personen)", BinaryPath: "./bin/sync-personen", Enabled: true},
-	{Name: "vorgaenge", Description: "Sync procedures (Vorgänge)", BinaryPath: "./bin/sync-vorgaenge", Enabled: true},
-	{Name: "vorgangspositionen", Description: "Sync procedure positions (Vorgangspositionen)", BinaryPath: "./bin/sync-vorgangspositionen", Enabled: true},
-	{Name: "aktivitaeten", Description: "Sync activities (Aktivitäten)", BinaryPath: "./bin/sync-aktivitaeten", Enabled: true},
-	{Name: "drucksachen", Description: "Sync printed documents (Drucksachen)", BinaryPath: "./bin/sync-drucksachen", Enabled: true},
-	{Name: "drucksache-texte", Description: "Sync printed document texts (Drucksache-Texte)", BinaryPath: "./bin/sync-drucksache-texte", Enabled: true},
-	{Name: "plenarprotokolle", Description: "Sync plenary protocols (Plenarprotokolle)", BinaryPath: "./bin/sync-plenarprotokolle", Enabled: true},
-	{Name: "plenarprotokoll-texte", Description: "Sync plenary protocol texts (Plenarprotokoll-Texte)", BinaryPath: "./bin/sync-plenarprotokoll-texte", Enabled: true},
+// backfillEntry is the JSON representation of a backfill result
+type backfillEntry struct {
+	syncer.BackfillResult
+	Error string `json:"error,omitempty"`
 }
 
 func main() {
+	config := &utility.SyncConfig{Resume: true}
+	utility.AddSettingFlags(flag.CommandLine, config)
+
 	var (
-		apiKey          = flag.String("key", "", "API key")
-		dbPath          = flag.String("db", "dip.db", "SQLite database path")
 		limit           = flag.Int("limit", 0, "Maximum number of records per sync (0 = all)")
+		rate            = flag.Int("rate", 240, "Maximum API requests per minute shared by all syncs")
 		skipList        = flag.String("skip", "", "Comma-separated list of syncs to skip")
 		onlyList        = flag.String("only", "", "Comma-separated list of syncs to run")
 		dryRun          = flag.Bool("dry-run", false, "Show plan without running")
-		continueOnError = flag.Bool("continue", false, "Continue if sync fails")
+		continueOnError = flag.Bool("continue", false, "Continue with the next stage if a sync fails")
+		sequential      = flag.Bool("sequential", false, "Run the syncs of a stage one after another instead of in parallel")
+		backfill        = flag.Bool("backfill", true, "Backfill dangling references after each stage")
+		history         = flag.Bool("history", false, "Record previous versions of changed rows in history tables")
+		summaryFile     = flag.String("summary", "", "Write the sync summary as JSON to this file")
 	)
-	flag.Parse()
-
-	if *apiKey == "" {
-		*apiKey = os.Getenv("DIP_API_KEY")
-	}
-	if *apiKey == "" {
-		log.Fatal("API key required")
-	}
-
-	sqlDB, err := sql.Open("sqlite", *dbPath)
-	if err != nil {
-		log.Fatalf("Failed to open database: %v", err)
-	}
-	defer sqlDB.Close()
+	utility.ParseSettings(flag.CommandLine, os.Args[1:], config)
 
-	if err := utility.RunMigrations(sqlDB); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
+	if config.APIKey == "" {
+		log.Fatal("API key required (use -key flag, DIP_API_KEY environment variable or key in the config file)")
 	}
+	config.Limit = *limit
+	config.History = *history
 
 	skipMap := make(map[string]bool)
 	if *skipList != "" {
@@ -75,29 +69,43 @@ func main() {
 		}
 	}
 
-	var commandsToRun []SyncCommand
-	for _, cmd := range syncCommands {
-		if !cmd.Enabled || skipMap[cmd.Name] {
+	var entities []syncer.Entity
+	for _, def := range syncer.Definitions() {
+		name := string(def.Entity)
+		if skipMap[name] {
 			continue
 		}
-		if len(onlyMap) > 0 && !onlyMap[cmd.Name] {
+		if len(onlyMap) > 0 && !onlyMap[name] {
 			continue
 		}
-		commandsToRun = append(commandsToRun, cmd)
+		entities = append(entities, def.Entity)
 	}
 
-	if len(commandsToRun) == 0 {
+	if len(entities) == 0 {
 		log.Fatal("No sync commands to run")
 	}
 
+	stages, err := syncer.Stages(entities)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	fmt.Println("═══════════════════════════════════════════════════════════════════════")
 	fmt.Println("DIP Sync All - Comprehensive Data Synchronization")
 	fmt.Println("═══════════════════════════════════════════════════════════════════════")
-	fmt.Printf("Database: %s\n", *dbPath)
+	if config.Profile != "" {
+		fmt.Printf("Profile: %s\n", config.Profile)
+	}
+	fmt.Printf("Database: %s\n", config.DatabaseName())
 	fmt.Printf("Limit per sync: %d (0 = all)\n", *limit)
-	fmt.Println("\nPlanned sync operations:")
-	for i, cmd := range commandsToRun {
-		fmt.Printf("  [%d] %s - %s\n", i+1, cmd.Name, cmd.Description)
+	fmt.Printf("Rate limit: %d requests/minute (shared)\n", *rate)
+	fmt.Println("\nPlanned sync stages:")
+	for i, stage := range stages {
+		fmt.Printf("  Stage %d:\n", i+1)
+		for _, entity := range stage {
+			def, _ := syncer.Lookup(entity)
+			fmt.Printf("    - %s - %s\n", entity, def.Description)
+		}
 	}
 	fmt.Println("═══════════════════════════════════════════════════════════════════════")
 
@@ -108,79 +116,158 @@ func main() {
 
 	fmt.Println()
 
-	// Setup signal handling for Ctrl-C
-	signalHandler := utility.NewSignalHandler(nil, nil)
+	// Database pool, API client and rate limiter are shared by all syncs
+	shared, err := utility.NewSharedResources(config, *rate)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer shared.Close()
+
+	// Setup signal handling for Ctrl-C: cancelling the context stops the running sync gracefully
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signalHandler := utility.NewSignalHandler(func() { cancel() }, nil)
 	defer signalHandler.Stop()
 
 	startTime := time.Now()
-	var successCount, failCount int
-	var failedCommands []string
-
-	for i, cmd := range commandsToRun {
-		// Check if interrupted before starting next sync
-		if signalHandler.IsInterrupted() {
-			fmt.Println("\n⚠️  Skipping remaining syncs due to interrupt")
-			failCount = len(commandsToRun) - i
-			for j := i; j < len(commandsToRun); j++ {
-				failedCommands = append(failedCommands, commandsToRun[j].Name)
+
+	report, err := syncer.RunAll(ctx, entities, config, shared, syncer.Options{
+		Sequential:      *sequential,
+		ContinueOnError: *continueOnError,
+		Backfill:        *backfill,
+		OnStageStart: func(stage int, entities []syncer.Entity) {
+			fmt.Printf("\n[Stage %d/%d] Running: %v\n", stage+1, len(stages), entities)
+			fmt.Println("───────────────────────────────────────────────────────────────────────")
+		},
+		OnResult: func(result syncer.Result) {
+			switch {
+			case result.Err != nil:
+				fmt.Printf("\n❌ FAILED: %s (took %s)\n", result.Entity, result.Duration.Round(time.Second))
+				fmt.Printf("   Error: %v\n", result.Err)
+			case result.Interrupted:
+				fmt.Printf("\n⚠️  INTERRUPTED: %s (took %s)\n", result.Entity, result.Duration.Round(time.Second))
+			default:
+				fmt.Printf("\n✅ COMPLETED: %s (took %s)\n", result.Entity, result.Duration.Round(time.Second))
 			}
-			break
-		}
-		fmt.Printf("\n[%d/%d] Running: %s\n", i+1, len(commandsToRun), cmd.Description)
-		fmt.Println("───────────────────────────────────────────────────────────────────────")
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
 
-		cmdStart := time.Now()
+	if len(report.Skipped) > 0 && ctx.Err() == nil && !*continueOnError {
+		fmt.Println("\n❌ Stopped due to error. Use --continue to continue on errors.")
+	}
 
-		args := []string{"--key", *apiKey, "--db", *dbPath, "--resume", "true"}
-		if *limit > 0 {
-			args = append(args, "--limit", fmt.Sprintf("%d", *limit))
+	totalDuration := time.Since(startTime)
+	failCount := printSummary(report, totalDuration, len(entities))
+
+	if *summaryFile != "" {
+		if err := writeSummary(*summaryFile, report); err != nil {
+			log.Printf("Warning: Failed to write summary: %v", err)
 		}
+	}
 
-		execCmd := exec.Command(cmd.BinaryPath, args...)
-		execCmd.Stdout = os.Stdout
-		execCmd.Stderr = os.Stderr
+	if failCount > 0 {
+		os.Exit(1)
+	}
+}
 
-		// Register command with signal handler
-		signalHandler.SetCurrentCommand(execCmd)
+// printSummary prints a combined per-entity summary and returns the number of unsuccessful syncs
+func printSummary(report syncer.Report, totalDuration time.Duration, planned int) int {
+	fmt.Println("\n═══════════════════════════════════════════════════════════════════════")
+	fmt.Println("Sync All - Summary")
+	fmt.Println("═══════════════════════════════════════════════════════════════════════")
+	fmt.Printf("%-24s %10s %8s %12s  %s\n", "Entity", "Stored", "Failed", "Duration", "Status")
+	fmt.Println("───────────────────────────────────────────────────────────────────────")
 
-		err := execCmd.Run()
-		signalHandler.ClearCurrentCommand()
-		cmdDuration := time.Since(cmdStart)
+	var totalStored, totalFailed, successCount int
+	var errors []string
+	for _, r := range report.Results {
+		status := "ok"
+		switch {
+		case r.Err != nil:
+			status = "error"
+			errors = append(errors, fmt.Sprintf("%s: %v", r.Entity, r.Err))
+		case r.Interrupted:
+			status = "interrupted"
+		default:
+			successCount++
+		}
+		totalStored += r.Stored
+		totalFailed += r.Failed
+		fmt.Printf("%-24s %10d %8d %12s  %s\n", r.Entity, r.Stored, r.Failed, r.Duration.Round(time.Second), status)
+	}
+	for _, entity := range report.Skipped {
+		fmt.Printf("%-24s %10s %8s %12s  %s\n", entity, "-", "-", "-", "skipped")
+	}
 
-		if err != nil {
-			failCount++
-			failedCommands = append(failedCommands, cmd.Name)
-			fmt.Printf("❌ FAILED: %s (took %s)\n", cmd.Name, cmdDuration)
-			fmt.Printf("   Error: %v\n", err)
+	fmt.Println("───────────────────────────────────────────────────────────────────────")
+	fmt.Printf("%-24s %10d %8d %12s\n", "Total", totalStored, totalFailed, totalDuration.Round(time.Second))
+	fmt.Printf("\nSuccessful: %d/%d\n", successCount, planned)
+	fmt.Printf("Failed: %d/%d\n", planned-successCount, planned)
 
-			if !*continueOnError {
-				fmt.Println("\n❌ Stopping due to error. Use --continue to continue on errors.")
-				os.Exit(1)
+	if len(report.Backfills) > 0 {
+		fmt.Println("\nBackfilled references:")
+		for _, b := range report.Backfills {
+			status := ""
+			if b.Err != nil {
+				status = fmt.Sprintf(" (error: %v)", b.Err)
 			}
-		} else {
-			successCount++
-			fmt.Printf("✅ COMPLETED: %s (took %s)\n", cmd.Name, cmdDuration)
+			fmt.Printf("  - %s: %d/%d stored from %v%s\n", b.Target, b.Stored, b.Missing, b.Sources, status)
 		}
 	}
 
-	totalDuration := time.Since(startTime)
+	if len(errors) > 0 {
+		fmt.Println("\nErrors:")
+		for _, e := range errors {
+			fmt.Printf("  - %s\n", e)
+		}
+	}
 
-	fmt.Println("\n═══════════════════════════════════════════════════════════════════════")
-	fmt.Println("Sync All - Summary")
+	if successCount == planned {
+		fmt.Println("\n✅ All syncs completed successfully!")
+	}
 	fmt.Println("═══════════════════════════════════════════════════════════════════════")
-	fmt.Printf("Total time: %s\n", totalDuration)
-	fmt.Printf("Successful: %d/%d\n", successCount, len(commandsToRun))
-	fmt.Printf("Failed: %d/%d\n", failCount, len(commandsToRun))
-
-	if len(failedCommands) > 0 {
-		fmt.Println("\nFailed commands:")
-		for _, name := range failedCommands {
-			fmt.Printf("  - %s\n", name)
+
+	return planned - successCount
+}
+
+// writeSummary writes the sync report as JSON
+func writeSummary(path string, report syncer.Report) error {
+	summary := struct {
+		Stages    [][]syncer.Entity `json:"stages"`
+		Results   []summaryEntry    `json:"results"`
+		Backfills []backfillEntry   `json:"backfills,omitempty"`
+	}{Stages: report.Stages}
+
+	for _, r := range report.Results {
+		entry := summaryEntry{
+			Entity:      string(r.Entity),
+			Stored:      r.Stored,
+			Failed:      r.Failed,
+			Seconds:     r.Duration.Seconds(),
+			Interrupted: r.Interrupted,
 		}
-		fmt.Println("═══════════════════════════════════════════════════════════════════════")
-		os.Exit(1)
+		if r.Err != nil {
+			entry.Error = r.Err.Error()
+		}
+		summary.Results = append(summary.Results, entry)
+	}
+	for _, entity := range report.Skipped {
+		summary.Results = append(summary.Results, summaryEntry{Entity: string(entity), Skipped: true})
+	}
+	for _, b := range report.Backfills {
+		entry := backfillEntry{BackfillResult: b}
+		if b.Err != nil {
+			entry.Error = b.Err.Error()
+		}
+		summary.Backfills = append(summary.Backfills, entry)
 	}
 
-	fmt.Println("\n✅ All syncs completed successfully!")
-	fmt.Println("═══════════════════════════════════════════════════════════════════════")
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
 }
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Johanneslueke/dip-client/internal/config"
+	db "github.com/Johanneslueke/dip-client/internal/database/gen/sqlite"
+	"github.com/Johanneslueke/dip-client/internal/pdfstore"
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+)
+
+// SyncStats tracks download statistics
+type SyncStats struct {
+	Downloaded int
+	Resumed    int
+	Existing   int // already in the store, only recorded
+	Failed     int
+	Bytes      int64
+	Verified   int // -verify: stored PDFs that are intact
+	Removed    int // -verify: records of missing or corrupt PDFs
+}
+
+func main() {
+	dbPath := flag.String(config.DB, config.Default(config.DB), "Path to SQLite database")
+	storeDir := flag.String("store", "pdfs", "Root directory of the PDF store")
+	wahlperiode := flag.Int("wahlperiode", 0, "Only PDFs of this Wahlperiode (0 = all)")
+	dokumentart := flag.String("dokumentart", "", "Only PDFs of this Dokumentart: Drucksache or Plenarprotokoll (default: both)")
+	drucksachetyp := flag.String("drucksachetyp", "", "Only Drucksachen of this Drucksachetyp, e.g. Gesetzentwurf")
+	limit := flag.Int("limit", 0, "Maximum number of PDFs to download (0 = all)")
+	rate := flag.Int("rate", 60, "Maximum downloads per minute")
+	retryFailed := flag.Bool("retry-failed", false, "Retry PDFs whose download failed before")
+	verify := flag.Bool("verify", false, "Verify the recorded PDFs against their pdf_hash before downloading")
+	verbose := flag.Bool("verbose", false, "Log every stored PDF")
+	// The tables of this command only exist in the SQLite schema
+	cfg, err := config.Parse(flag.CommandLine, os.Args[1:])
+	if err == nil {
+		err = cfg.SQLiteOnly()
+	}
+	if err != nil {
+		log.Fatalf("Config error: %v", err)
+	}
+
+	if *dokumentart != "" && *dokumentart != "Drucksache" && *dokumentart != "Plenarprotokoll" {
+		log.Fatalf("Invalid -dokumentart %q: must be Drucksache or Plenarprotokoll", *dokumentart)
+	}
+	if *drucksachetyp != "" && *dokumentart == "Plenarprotokoll" {
+		log.Fatalf("-drucksachetyp cannot be combined with -dokumentart Plenarprotokoll")
+	}
+
+	log.Printf("Opening database: %s", *dbPath)
+	s, err := store.Open(store.SQLite, *dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer s.Close()
+	queries := db.New(s.DB())
+
+	pdfs, err := pdfstore.New(*storeDir)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signalHandler := utility.NewSignalHandler(func() { cancel() }, nil)
+	defer signalHandler.Stop()
+
+	start := time.Now()
+	var stats SyncStats
+
+	if *verify {
+		if err := verifyStored(ctx, queries, pdfs, &stats, *verbose); err != nil {
+			log.Fatalf("Failed to verify PDFs: %v", err)
+		}
+	}
+
+	var retry int64
+	if *retryFailed {
+		retry = 1
+	}
+	pending, err := queries.ListPendingPdfs(ctx, db.ListPendingPdfsParams{
+		Wahlperiode:   int64(*wahlperiode),
+		Dokumentart:   *dokumentart,
+		Drucksachetyp: *drucksachetyp,
+		RetryFailed:   retry,
+	})
+	if err != nil {
+		log.Fatalf("Failed to list PDFs: %v", err)
+	}
+	if *limit > 0 && len(pending) > *limit {
+		pending = pending[:*limit]
+	}
+	log.Printf("Storing %d PDFs in %s", len(pending), pdfs.Root())
+
+	client := &http.Client{Timeout: 10 * time.Minute}
+	limiter := utility.NewRateLimiter(*rate, time.Minute)
+	for i, row := range pending {
+		if ctx.Err() != nil {
+			break
+		}
+		// PDFs already in the store, e.g. after the database was rebuilt, are only recorded
+		result, stored, err := pdfs.Stored(row.PdfHash)
+		if err == nil && !stored {
+			if err := limiter.Wait(ctx); err != nil {
+				break
+			}
+			result, err = pdfs.Download(ctx, client, row.Url, row.PdfHash)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Printf("Interrupted, the partial download of %s %s is resumed next time", row.Dokumentart, row.Dokumentnummer)
+				break
+			}
+			log.Printf("❌ %s %s (%s): %v", row.Dokumentart, row.Dokumentnummer, row.Url, err)
+			stats.Failed++
+			if err := queries.RecordDokumentPdfFehler(ctx, db.RecordDokumentPdfFehlerParams{
+				PdfHash: row.PdfHash,
+				Url:     row.Url,
+				Fehler:  err.Error(),
+			}); err != nil {
+				log.Printf("⚠️  Failed to record error: %v", err)
+			}
+			continue
+		}
+
+		if err := queries.UpsertDokumentPdf(ctx, db.UpsertDokumentPdfParams{
+			PdfHash: row.PdfHash,
+			Pfad:    filepath.ToSlash(result.Path),
+			Groesse: result.Size,
+			Url:     row.Url,
+		}); err != nil {
+			log.Fatalf("Failed to record PDF %s: %v", row.PdfHash, err)
+		}
+		if err := queries.DeleteDokumentPdfFehler(ctx, row.PdfHash); err != nil {
+			log.Printf("⚠️  Failed to clear error of %s: %v", row.PdfHash, err)
+		}
+
+		switch {
+		case result.Existed:
+			stats.Existing++
+		case result.Resumed:
+			stats.Resumed++
+			stats.Downloaded++
+			stats.Bytes += result.Size
+		default:
+			stats.Downloaded++
+			stats.Bytes += result.Size
+		}
+		if *verbose {
+			log.Printf("%s %s -> %s (%d bytes)", row.Dokumentart, row.Dokumentnummer, result.Path, result.Size)
+		}
+		if (i+1)%100 == 0 {
+			log.Printf("Progress: %d/%d PDFs", i+1, len(pending))
+		}
+	}
+
+	printStats(stats, time.Since(start))
+}
+
+// verifyStored checks the recorded PDFs and removes the records of missing or
+// corrupt files, so they are downloaded again
+func verifyStored(ctx context.Context, queries *db.Queries, pdfs *pdfstore.Store, stats *SyncStats, verbose bool) error {
+	stored, err := queries.ListDokumentPdfs(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("Verifying %d stored PDFs", len(stored))
+	for _, row := range stored {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		_, err := pdfs.Verify(row.PdfHash)
+		if err == nil {
+			stats.Verified++
+			continue
+		}
+		if !errors.Is(err, os.ErrNotExist) && !errors.Is(err, pdfstore.ErrHashMismatch) {
+			return err
+		}
+		if verbose {
+			log.Printf("⚠️  %s: %v", row.Pfad, err)
+		}
+		if err := queries.DeleteDokumentPdf(ctx, row.PdfHash); err != nil {
+			return err
+		}
+		stats.Removed++
+	}
+	return nil
+}
+
+func printStats(stats SyncStats, elapsed time.Duration) {
+	fmt.Println("\n=== PDF Statistics ===")
+	fmt.Printf("  Downloaded:              %6d\n", stats.Downloaded)
+	fmt.Printf("  Resumed:                 %6d\n", stats.Resumed)
+	fmt.Printf("  Already stored:          %6d\n", stats.Existing)
+	fmt.Printf("  Failed:                  %6d\n", stats.Failed)
+	fmt.Printf("  Stored MB:               %9.2f\n", float64(stats.Bytes)/(1<<20))
+	if stats.Verified > 0 || stats.Removed > 0 {
+		fmt.Printf("  Verified:                %6d\n", stats.Verified)
+		fmt.Printf("  Missing or corrupt:      %6d\n", stats.Removed)
+	}
+	fmt.Printf("  Duration:                %6s\n", elapsed.Round(time.Second))
+}
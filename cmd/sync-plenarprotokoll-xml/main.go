@@ -0,0 +1,497 @@
+package main
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Johanneslueke/dip-client/internal/config"
+	db "github.com/Johanneslueke/dip-client/internal/database/gen/sqlite"
+	"github.com/Johanneslueke/dip-client/internal/plenarxml"
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+	"github.com/Johanneslueke/dip-client/internal/xmlvalidate"
+)
+
+// maxDocumentBytes limits the size of a downloaded Plenarprotokoll XML
+const maxDocumentBytes = 64 << 20
+
+// SyncStats tracks loading statistics
+type SyncStats struct {
+	Loaded    int
+	Unchanged int // same document as loaded before
+	Invalid   int // not valid according to the DTD
+	Failed    int
+	Reden     int
+	Absaetze  int
+}
+
+// errNoDTD is returned for downloads and zip archives without a DTD: their
+// documents have no location to resolve the DOCTYPE against, so every one of
+// them would fail validation
+var errNoDTD = errors.New("no DTD to validate against, pass -dtd or -validate=false")
+
+// document is a Plenarprotokoll XML from one of the sources
+type document struct {
+	quelle  string // URL, file or archive entry
+	baseURL string // resolves a relative DOCTYPE, empty for downloads and zip entries (see errNoDTD)
+	data    []byte
+}
+
+type loader struct {
+	sqlDB    *sql.DB
+	queries  *db.Queries
+	dtdPath  string
+	validate bool
+	all      bool
+	verbose  bool
+	stats    SyncStats
+}
+
+func main() {
+	dbPath := flag.String(config.DB, config.Default(config.DB), "Path to SQLite database")
+	archive := flag.String("archive", "", "Load the XML files of an offline archive (zip file or directory) instead of downloading")
+	saveDir := flag.String("save", "", "Directory to keep downloaded XML files in, for later use with -archive")
+	dtdPath := flag.String("dtd", "", "Path to dbtplenarprotokoll.dtd (default: the DTD declared by the document, relative to the file)")
+	validate := flag.Bool("validate", true, "Validate documents against the DTD and skip invalid ones")
+	wahlperiode := flag.Int("wahlperiode", 0, "Only download Plenarprotokolle of this Wahlperiode (0 = all)")
+	all := flag.Bool("all", false, "Load all documents again, not only new or changed ones")
+	limit := flag.Int("limit", 0, "Maximum number of documents to load (0 = all)")
+	rate := flag.Int("rate", 60, "Maximum downloads per minute")
+	verbose := flag.Bool("verbose", false, "Log every loaded document")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: sync-plenarprotokoll-xml [flags] [file.xml ...]\n\n")
+		fmt.Fprintf(os.Stderr, "Without files or -archive, the XML of all synced Plenarprotokolle with fundstelle.xml_url is downloaded.\n\n")
+		flag.PrintDefaults()
+	}
+	// The tables of this command only exist in the SQLite schema
+	cfg, err := config.Parse(flag.CommandLine, os.Args[1:])
+	if err == nil {
+		err = cfg.SQLiteOnly()
+	}
+	if err != nil {
+		log.Fatalf("Config error: %v", err)
+	}
+
+	log.Printf("Opening database: %s", *dbPath)
+	s, err := store.Open(store.SQLite, *dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer s.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signalHandler := utility.NewSignalHandler(func() { cancel() }, nil)
+	defer signalHandler.Stop()
+
+	l := &loader{
+		sqlDB:    s.DB(),
+		queries:  db.New(s.DB()),
+		dtdPath:  *dtdPath,
+		validate: *validate,
+		all:      *all,
+		verbose:  *verbose,
+	}
+	if l.dtdPath != "" {
+		if _, err := os.Stat(l.dtdPath); err != nil {
+			log.Fatalf("DTD not found: %v", err)
+		}
+	}
+
+	start := time.Now()
+	switch {
+	case flag.NArg() > 0:
+		err = l.loadFiles(ctx, flag.Args(), *limit)
+	case *archive != "":
+		err = l.loadArchive(ctx, *archive, *limit)
+	default:
+		err = l.download(ctx, *wahlperiode, *limit, *rate, *saveDir)
+	}
+	if err != nil {
+		log.Fatalf("Failed to sync Plenarprotokoll XML: %v", err)
+	}
+
+	printStats(l.stats, time.Since(start))
+}
+
+// loadFiles loads local XML files
+func (l *loader) loadFiles(ctx context.Context, files []string, limit int) error {
+	if limit > 0 && len(files) > limit {
+		files = files[:limit]
+	}
+	for _, file := range files {
+		if ctx.Err() != nil {
+			log.Printf("Interrupted")
+			break
+		}
+		doc, err := readFile(file)
+		if err != nil {
+			log.Printf("❌ %s: %v", file, err)
+			l.stats.Failed++
+			continue
+		}
+		l.load(ctx, doc)
+	}
+	return nil
+}
+
+// loadArchive loads the XML files of a zip file or a directory
+func (l *loader) loadArchive(ctx context.Context, archive string, limit int) error {
+	info, err := os.Stat(archive)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		files, err := filepath.Glob(filepath.Join(archive, "*.xml"))
+		if err != nil {
+			return err
+		}
+		sort.Strings(files)
+		log.Printf("Loading %d XML files from %s", len(files), archive)
+		return l.loadFiles(ctx, files, limit)
+	}
+
+	r, err := zip.OpenReader(archive)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer r.Close()
+
+	var entries []*zip.File
+	for _, f := range r.File {
+		switch strings.ToLower(path.Ext(f.Name)) {
+		case ".xml":
+			entries = append(entries, f)
+		case ".dtd":
+			// A DTD shipped with the archive is used unless -dtd is given
+			if l.dtdPath == "" && l.validate {
+				if l.dtdPath, err = extractDTD(f); err != nil {
+					return err
+				}
+				defer os.RemoveAll(filepath.Dir(l.dtdPath))
+			}
+		}
+	}
+	if l.validate && l.dtdPath == "" {
+		return fmt.Errorf("archive %s: %w", archive, errNoDTD)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	log.Printf("Loading %d XML files from %s", len(entries), archive)
+
+	for _, f := range entries {
+		if ctx.Err() != nil {
+			log.Printf("Interrupted")
+			break
+		}
+		quelle := archive + ":" + f.Name
+		data, err := readZipEntry(f)
+		if err != nil {
+			log.Printf("❌ %s: %v", quelle, err)
+			l.stats.Failed++
+			continue
+		}
+		l.load(ctx, document{quelle: quelle, data: data})
+	}
+	return nil
+}
+
+// download loads the XML of the synced Plenarprotokolle from fundstelle.xml_url
+func (l *loader) download(ctx context.Context, wahlperiode, limit, rate int, saveDir string) error {
+	if l.validate && l.dtdPath == "" {
+		return fmt.Errorf("downloaded documents: %w", errNoDTD)
+	}
+	var allProtokolle int64
+	if l.all {
+		allProtokolle = 1
+	}
+	pending, err := l.queries.ListPendingPlenarprotokollXML(ctx, db.ListPendingPlenarprotokollXMLParams{
+		Wahlperiode:   int64(wahlperiode),
+		AllProtokolle: allProtokolle,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list Plenarprotokolle: %w", err)
+	}
+	if limit > 0 && len(pending) > limit {
+		pending = pending[:limit]
+	}
+	if saveDir != "" {
+		if err := os.MkdirAll(saveDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", saveDir, err)
+		}
+	}
+	log.Printf("Downloading %d Plenarprotokoll XML documents", len(pending))
+
+	client := &http.Client{Timeout: 2 * time.Minute}
+	limiter := utility.NewRateLimiter(rate, time.Minute)
+	for i, row := range pending {
+		if err := limiter.Wait(ctx); err != nil {
+			log.Printf("Interrupted after %d documents", i)
+			break
+		}
+		url := row.FundstelleXmlUrl.String
+		data, err := fetch(ctx, client, url)
+		if err != nil {
+			log.Printf("❌ Plenarprotokoll %s (%s): %v", row.Dokumentnummer, url, err)
+			l.stats.Failed++
+			continue
+		}
+		if saveDir != "" {
+			if err := os.WriteFile(filepath.Join(saveDir, path.Base(url)), data, 0o644); err != nil {
+				log.Printf("⚠️  Failed to save %s: %v", url, err)
+			}
+		}
+		l.load(ctx, document{quelle: url, data: data})
+		if (i+1)%50 == 0 {
+			log.Printf("Progress: %d/%d documents", i+1, len(pending))
+		}
+	}
+	return nil
+}
+
+// load validates, parses and stores one document
+func (l *loader) load(ctx context.Context, doc document) {
+	sum := sha256.Sum256(doc.data)
+	hash := hex.EncodeToString(sum[:])
+
+	if l.validate {
+		if err := xmlvalidate.Validate(doc.data, doc.baseURL, l.dtdPath); err != nil {
+			log.Printf("❌ %s: %v", doc.quelle, err)
+			l.stats.Invalid++
+			return
+		}
+	}
+	p, err := plenarxml.Parse(doc.data)
+	if err != nil {
+		log.Printf("❌ %s: %v", doc.quelle, err)
+		l.stats.Failed++
+		return
+	}
+
+	if !l.all {
+		existing, err := l.queries.GetPlenarprotokollXMLHash(ctx, p.Dokumentnummer())
+		if err == nil && existing == hash {
+			l.stats.Unchanged++
+			return
+		}
+	}
+
+	absaetze, err := l.store(ctx, p, doc.quelle, hash)
+	if err != nil {
+		log.Printf("❌ %s: %v", doc.quelle, err)
+		l.stats.Failed++
+		return
+	}
+	l.stats.Loaded++
+	for _, top := range p.Tagesordnungspunkte {
+		l.stats.Reden += len(top.Reden)
+	}
+	l.stats.Absaetze += absaetze
+	if l.verbose {
+		log.Printf("Plenarprotokoll %s: %d Tagesordnungspunkte, %d Redner", p.Dokumentnummer(), len(p.Tagesordnungspunkte), len(p.Redner))
+	}
+}
+
+// store replaces a Plenarprotokoll and its Tagesordnungspunkte, Reden and Redner in one transaction
+func (l *loader) store(ctx context.Context, p *plenarxml.Protokoll, quelle, hash string) (int, error) {
+	dokumentnummer := p.Dokumentnummer()
+	plenarprotokollID, err := l.queries.GetPlenarprotokollIDByDokumentnummer(ctx, dokumentnummer)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("GetPlenarprotokollIDByDokumentnummer: %w", err)
+	}
+
+	tx, err := l.sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := l.queries.WithTx(tx)
+
+	deletes := []struct {
+		name string
+		fn   func(context.Context, string) error
+	}{
+		{"DeletePlenarprotokollXMLAbsaetze", qtx.DeletePlenarprotokollXMLAbsaetze},
+		{"DeletePlenarprotokollXMLReden", qtx.DeletePlenarprotokollXMLReden},
+		{"DeletePlenarprotokollXMLTagesordnungspunkte", qtx.DeletePlenarprotokollXMLTagesordnungspunkte},
+		{"DeletePlenarprotokollXMLRedner", qtx.DeletePlenarprotokollXMLRedner},
+		{"DeletePlenarprotokollXML", qtx.DeletePlenarprotokollXML},
+	}
+	for _, d := range deletes {
+		if err := d.fn(ctx, dokumentnummer); err != nil {
+			return 0, fmt.Errorf("%s: %w", d.name, err)
+		}
+	}
+
+	protokollID, err := qtx.CreatePlenarprotokollXML(ctx, db.CreatePlenarprotokollXMLParams{
+		Dokumentnummer:    dokumentnummer,
+		PlenarprotokollID: store.NullString(plenarprotokollID),
+		Wahlperiode:       int64(p.Wahlperiode),
+		SitzungNr:         int64(p.SitzungNr),
+		Datum:             store.NullString(p.Datum),
+		StartUhrzeit:      store.NullString(p.StartUhrzeit),
+		EndeUhrzeit:       store.NullString(p.EndeUhrzeit),
+		Ort:               store.NullString(p.Ort),
+		Version:           store.NullString(p.Version),
+		Quelle:            quelle,
+		Sha256:            hash,
+		DtdValidiert:      boolToInt(l.validate),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("CreatePlenarprotokollXML: %w", err)
+	}
+
+	for _, r := range p.Redner {
+		if err := qtx.CreatePlenarprotokollXMLRedner(ctx, db.CreatePlenarprotokollXMLRednerParams{
+			ProtokollXmlID: protokollID,
+			RednerID:       r.ID,
+			Titel:          store.NullString(r.Titel),
+			Vorname:        store.NullString(r.Vorname),
+			Namenszusatz:   store.NullString(r.Namenszusatz),
+			Nachname:       store.NullString(r.Nachname),
+			Ortszusatz:     store.NullString(r.Ortszusatz),
+			Fraktion:       store.NullString(r.Fraktion),
+			RolleLang:      store.NullString(r.RolleLang),
+			RolleKurz:      store.NullString(r.RolleKurz),
+			Bundesland:     store.NullString(r.Bundesland),
+		}); err != nil {
+			return 0, fmt.Errorf("CreatePlenarprotokollXMLRedner %s: %w", r.ID, err)
+		}
+	}
+
+	absaetze := 0
+	for _, top := range p.Tagesordnungspunkte {
+		topID, err := qtx.CreatePlenarprotokollXMLTagesordnungspunkt(ctx, db.CreatePlenarprotokollXMLTagesordnungspunktParams{
+			ProtokollXmlID: protokollID,
+			Position:       int64(top.Position),
+			TopID:          store.NullString(top.TopID),
+			Titel:          store.NullString(top.Titel),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("CreatePlenarprotokollXMLTagesordnungspunkt %d: %w", top.Position, err)
+		}
+
+		for _, rede := range top.Reden {
+			redeID, err := qtx.CreatePlenarprotokollXMLRede(ctx, db.CreatePlenarprotokollXMLRedeParams{
+				ProtokollXmlID:       protokollID,
+				TagesordnungspunktID: topID,
+				Position:             int64(rede.Position),
+				RedeID:               store.NullString(rede.ID),
+				RednerID:             store.NullString(rede.RednerID),
+			})
+			if err != nil {
+				return 0, fmt.Errorf("CreatePlenarprotokollXMLRede %d: %w", rede.Position, err)
+			}
+
+			for _, absatz := range rede.Absaetze {
+				if err := qtx.CreatePlenarprotokollXMLAbsatz(ctx, db.CreatePlenarprotokollXMLAbsatzParams{
+					RedeID:   redeID,
+					Position: int64(absatz.Position),
+					Typ:      absatz.Typ,
+					Klasse:   store.NullString(absatz.Klasse),
+					RednerID: store.NullString(absatz.RednerID),
+					Sprecher: store.NullString(absatz.Sprecher),
+					Text:     absatz.Text,
+				}); err != nil {
+					return 0, fmt.Errorf("CreatePlenarprotokollXMLAbsatz %d/%d: %w", rede.Position, absatz.Position, err)
+				}
+				absaetze++
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return absaetze, nil
+}
+
+func fetch(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxDocumentBytes))
+}
+
+func readFile(file string) (document, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return document{}, err
+	}
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return document{}, err
+	}
+	return document{quelle: file, baseURL: abs, data: data}, nil
+}
+
+func readZipEntry(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, maxDocumentBytes))
+}
+
+// extractDTD writes a DTD of an archive to a temporary directory
+func extractDTD(f *zip.File) (string, error) {
+	data, err := readZipEntry(f)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", f.Name, err)
+	}
+	dir, err := os.MkdirTemp("", "plenarprotokoll-dtd")
+	if err != nil {
+		return "", err
+	}
+	dtdPath := filepath.Join(dir, path.Base(f.Name))
+	if err := os.WriteFile(dtdPath, data, 0o644); err != nil {
+		return "", err
+	}
+	log.Printf("Using DTD %s of the archive", f.Name)
+	return dtdPath, nil
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func printStats(stats SyncStats, elapsed time.Duration) {
+	fmt.Println("\n=== Plenarprotokoll XML Statistics ===")
+	fmt.Printf("  Documents loaded:        %6d\n", stats.Loaded)
+	fmt.Printf("  Unchanged:               %6d\n", stats.Unchanged)
+	fmt.Printf("  Invalid (DTD):           %6d\n", stats.Invalid)
+	fmt.Printf("  Failed:                  %6d\n", stats.Failed)
+	fmt.Printf("  Reden stored:            %6d\n", stats.Reden)
+	fmt.Printf("  Absätze stored:          %6d\n", stats.Absaetze)
+	fmt.Printf("  Duration:                %6s\n", elapsed.Round(time.Second))
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	db "github.com/Johanneslueke/dip-client/internal/database/gen/sqlite"
+	"github.com/Johanneslueke/dip-client/internal/store/storetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownload(t *testing.T) {
+	ctx := context.Background()
+	data, err := os.ReadFile("../../internal/plenarxml/testdata/20123-data.xml")
+	require.NoError(t, err)
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write(data)
+	}))
+	defer srv.Close()
+
+	s := storetest.Open(t)
+	require.NoError(t, s.UpsertPlenarprotokoll(ctx, storetest.Plenarprotokoll(t, fmt.Sprintf(
+		`{"id": "5655", "dokumentnummer": "20/123", "fundstelle": {"xml_url": %q}}`, srv.URL+"/btp/20/20123.xml"))))
+	l := &loader{sqlDB: s.DB(), queries: db.New(s.DB()), validate: true}
+
+	// The DOCTYPE of a download cannot be resolved, so validating needs -dtd
+	err = l.download(ctx, 0, 0, 6000, "")
+	assert.ErrorIs(t, err, errNoDTD)
+	assert.Zero(t, requests)
+
+	saveDir := t.TempDir()
+	l.dtdPath = "../../internal/plenarxml/testdata/dbtplenarprotokoll.dtd"
+	require.NoError(t, l.download(ctx, 20, 0, 6000, saveDir))
+	assert.Equal(t, 1, l.stats.Loaded)
+	assert.Zero(t, l.stats.Invalid+l.stats.Failed)
+	assert.NotZero(t, l.stats.Reden)
+	saved, err := os.ReadFile(filepath.Join(saveDir, "20123.xml"))
+	require.NoError(t, err)
+	assert.Equal(t, data, saved)
+
+	var plenarprotokollID, quelle string
+	var validiert int
+	require.NoError(t, s.DB().QueryRowContext(ctx,
+		`SELECT plenarprotokoll_id, quelle, dtd_validiert FROM plenarprotokoll_xml WHERE dokumentnummer = '20/123'`,
+	).Scan(&plenarprotokollID, &quelle, &validiert))
+	assert.Equal(t, "5655", plenarprotokollID)
+	assert.Equal(t, srv.URL+"/btp/20/20123.xml", quelle)
+	assert.Equal(t, 1, validiert)
+
+	// Loaded documents are not downloaded again
+	require.NoError(t, l.download(ctx, 0, 0, 6000, ""))
+	assert.Equal(t, 1, requests)
+}
+
+func TestLoadArchiveWithoutDTD(t *testing.T) {
+	s := storetest.Open(t)
+	l := &loader{sqlDB: s.DB(), queries: db.New(s.DB()), validate: true}
+
+	archive := filepath.Join(t.TempDir(), "pp20-data.zip")
+	require.NoError(t, os.WriteFile(archive, emptyZip, 0o644))
+	assert.ErrorIs(t, l.loadArchive(context.Background(), archive, 0), errNoDTD)
+
+	l.validate = false
+	assert.NoError(t, l.loadArchive(context.Background(), archive, 0))
+}
+
+// emptyZip is a zip archive without entries
+var emptyZip = []byte{'P', 'K', 5, 6, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Johanneslueke/dip-client/internal/syncer"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+)
+
+func main() {
+	// Reconcile only compares IDs per Wahlperiode, so the sync flags such as
+	// -limit, -resume or -history are not registered
+	config := &utility.SyncConfig{ResourceName: "reconcile"}
+	utility.AddSettingFlags(flag.CommandLine, config)
+
+	var (
+		entityList = flag.String("entities", "vorgaenge,vorgangspositionen,drucksachen,plenarprotokolle,aktivitaeten,personen", "Comma-separated list of entities to reconcile")
+		rate       = flag.Int("rate", 240, "Maximum API requests per minute")
+		dryRun     = flag.Bool("dry-run", false, "Only report records missing from the API without marking them as deleted")
+		reportFile = flag.String("report", "", "Write the result as JSON to this file")
+	)
+	flag.StringVar(&config.Wahlperiode, "wahlperiode", "", "Wahlperiode numbers to reconcile (comma-separated, e.g. '19,20')")
+	utility.ParseSettings(flag.CommandLine, os.Args[1:], config)
+	if err := config.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	if config.Wahlperiode == "" {
+		log.Fatal("Wahlperiode required (use -wahlperiode, e.g. '19,20')")
+	}
+	var wahlperioden []int
+	for _, wpStr := range strings.Split(config.Wahlperiode, ",") {
+		wp, err := strconv.Atoi(strings.TrimSpace(wpStr))
+		if err != nil {
+			log.Fatalf("Invalid wahlperiode value: %v", err)
+		}
+		wahlperioden = append(wahlperioden, wp)
+	}
+
+	var entities []syncer.Entity
+	for _, name := range strings.Split(*entityList, ",") {
+		entity := syncer.Entity(strings.TrimSpace(name))
+		if !syncer.CanReconcile(entity) {
+			log.Fatalf("Reconciliation is not supported for %q", entity)
+		}
+		entities = append(entities, entity)
+	}
+
+	shared, err := utility.NewSharedResources(config, *rate)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer shared.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signalHandler := utility.NewSignalHandler(func() { cancel() }, nil)
+	defer signalHandler.Stop()
+
+	var results []syncer.ReconcileResult
+	failed := false
+	for _, entity := range entities {
+		for _, wp := range wahlperioden {
+			if ctx.Err() != nil {
+				break
+			}
+			result, err := syncer.Reconcile(ctx, entity, wp, shared, *dryRun)
+			if err != nil {
+				log.Printf("❌ %s WP %d: %v", entity, wp, err)
+				failed = true
+				continue
+			}
+			results = append(results, result)
+		}
+	}
+
+	printResults(results, *dryRun)
+
+	if *reportFile != "" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err == nil {
+			err = os.WriteFile(*reportFile, data, 0644)
+		}
+		if err != nil {
+			log.Printf("Warning: Failed to write report: %v", err)
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// printResults prints the reconciliation summary including all disappeared IDs
+func printResults(results []syncer.ReconcileResult, dryRun bool) {
+	fmt.Println("\n═══════════════════════════════════════════════════════════════════════")
+	fmt.Println("Sync Reconcile - Summary")
+	fmt.Println("═══════════════════════════════════════════════════════════════════════")
+	fmt.Printf("%-20s %4s %10s %10s %10s\n", "Entity", "WP", "API", "Local", "Missing")
+	fmt.Println("───────────────────────────────────────────────────────────────────────")
+
+	total := 0
+	for _, r := range results {
+		fmt.Printf("%-20s %4d %10d %10d %10d\n", r.Entity, r.Wahlperiode, r.Remote, r.Local, len(r.Deleted))
+		total += len(r.Deleted)
+	}
+
+	for _, r := range results {
+		if len(r.Deleted) > 0 {
+			fmt.Printf("\n%s WP %d no longer in the API:\n  %s\n", r.Entity, r.Wahlperiode, strings.Join(r.Deleted, ", "))
+		}
+	}
+
+	fmt.Println()
+	if dryRun {
+		fmt.Printf("Dry run: %d records would be marked as deleted\n", total)
+	} else {
+		fmt.Printf("%d records marked as deleted (deleted_at)\n", total)
+	}
+	fmt.Println("═══════════════════════════════════════════════════════════════════════")
+}
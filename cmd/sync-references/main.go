@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/Johanneslueke/dip-client/internal/syncer"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+)
+
+func main() {
+	var (
+		depth      = flag.Int("depth", 5, "Maximum number of backfill rounds (0 = until closed)")
+		rate       = flag.Int("rate", 240, "Maximum API requests per minute")
+		dryRun     = flag.Bool("dry-run", false, "Only report dangling references without fetching them")
+		targets    = flag.String("targets", "", "Comma-separated list of referenced entities to backfill (default: all)")
+		reportFile = flag.String("report", "", "Write the result as JSON to this file")
+	)
+	config := utility.ParseSyncFlags("references")
+	if err := config.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	refs := syncer.References()
+	if *targets != "" {
+		wanted := make(map[syncer.Entity]bool)
+		for _, name := range strings.Split(*targets, ",") {
+			entity := syncer.Entity(strings.TrimSpace(name))
+			if _, ok := syncer.Lookup(entity); !ok {
+				log.Fatalf("Unknown entity %q", entity)
+			}
+			wanted[entity] = true
+		}
+		var filtered []syncer.Reference
+		for _, ref := range refs {
+			if wanted[ref.Target] {
+				filtered = append(filtered, ref)
+			}
+		}
+		refs = filtered
+	}
+
+	shared, err := utility.NewSharedResources(config, *rate)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer shared.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signalHandler := utility.NewSignalHandler(func() { cancel() }, nil)
+	defer signalHandler.Stop()
+
+	if *dryRun {
+		printDangling(ctx, shared, refs)
+		return
+	}
+
+	result, err := syncer.CloseReferences(ctx, config, shared, refs, *depth)
+	printResult(result)
+
+	if *reportFile != "" {
+		data, jsonErr := json.MarshalIndent(result, "", "  ")
+		if jsonErr == nil {
+			jsonErr = os.WriteFile(*reportFile, data, 0644)
+		}
+		if jsonErr != nil {
+			log.Printf("Warning: Failed to write report: %v", jsonErr)
+		}
+	}
+
+	if err != nil && ctx.Err() == nil {
+		log.Fatal(err)
+	}
+}
+
+// printDangling prints the number of dangling IDs per reference
+func printDangling(ctx context.Context, shared *utility.SharedResources, refs []syncer.Reference) {
+	fmt.Println("Dangling references:")
+	total := 0
+	for _, ref := range refs {
+		missing, _, err := syncer.FindDangling(ctx, shared.Store, []syncer.Reference{ref})
+		if err != nil {
+			log.Fatal(err)
+		}
+		count := len(missing[ref.Target])
+		total += count
+		fmt.Printf("  %-55s -> %-18s %8d\n", ref.Name, ref.Target, count)
+	}
+	fmt.Printf("Total: %d\n", total)
+}
+
+// printResult prints a summary of all backfill rounds
+func printResult(result syncer.ClosureResult) {
+	fmt.Println("\n═══════════════════════════════════════════════════════════════════════")
+	fmt.Println("Sync References - Summary")
+	fmt.Println("═══════════════════════════════════════════════════════════════════════")
+	for _, round := range result.Rounds {
+		fmt.Printf("Round %d:\n", round.Depth)
+		for _, b := range round.Backfills {
+			status := ""
+			if b.Err != nil {
+				status = fmt.Sprintf(" (error: %v)", b.Err)
+			}
+			fmt.Printf("  - %s: %d/%d stored from %v%s\n", b.Target, b.Stored, b.Missing, b.Sources, status)
+		}
+	}
+
+	for entity, ids := range result.Unresolved {
+		shown := ids
+		if len(shown) > 20 {
+			shown = shown[:20]
+		}
+		fmt.Printf("⚠️  %d %s not returned by the API: %s", len(ids), entity, strings.Join(shown, ", "))
+		if len(ids) > len(shown) {
+			fmt.Print(", ...")
+		}
+		fmt.Println()
+	}
+
+	if result.Closed {
+		fmt.Println("\n✅ All references resolved (apart from IDs unknown to the API)")
+	} else {
+		fmt.Println("\n⚠️  Dangling references remain, run again or increase -depth")
+	}
+	fmt.Println("═══════════════════════════════════════════════════════════════════════")
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/Johanneslueke/dip-client/internal/xmlvalidate"
 	"github.com/lestrrat-go/libxml2/parser"
 	"github.com/lestrrat-go/libxml2/types"
 )
@@ -54,6 +55,23 @@ func main() {
 }
 
 func validateXML(xmlPath, dtdPath string, verbose, showSummary bool) error {
+	// If DTD path provided, resolve relative to XML file
+	if dtdPath != "" {
+		if !filepath.IsAbs(dtdPath) {
+			xmlDir := filepath.Dir(xmlPath)
+			dtdPath = filepath.Join(xmlDir, dtdPath)
+		}
+		if verbose {
+			fmt.Printf("Using DTD file: %s\n", dtdPath)
+		}
+	}
+
+	// Validate against the DTD; libxml2 only reports validity errors, the parser below
+	// accepts any well-formed document
+	if err := xmlvalidate.ValidateFile(xmlPath, dtdPath); err != nil {
+		return err
+	}
+
 	// Read XML file
 	xmlFile, err := os.Open(xmlPath)
 	if err != nil {
@@ -67,17 +85,6 @@ func validateXML(xmlPath, dtdPath string, verbose, showSummary bool) error {
 		parser.XMLParseDTDLoad,  // Load external DTD
 	)
 
-	// If DTD path provided, resolve relative to XML file
-	if dtdPath != "" {
-		if !filepath.IsAbs(dtdPath) {
-			xmlDir := filepath.Dir(xmlPath)
-			dtdPath = filepath.Join(xmlDir, dtdPath)
-		}
-		if verbose {
-			fmt.Printf("Using DTD file: %s\n", dtdPath)
-		}
-	}
-
 	// Parse XML with validation
 	doc, err := p.ParseReader(xmlFile)
 	if err != nil {
@@ -234,3 +234,38 @@ UNION ALL
 SELECT 
     'Most common vorgang type',
     'Antrag (38.15%) and Gesetzgebung (32.45%)';
+
+-- PART 10: Text Citations vs. Vorgangsbezug
+-- Drucksachen citing each other in their texts ("Drucksache 20/1234"), extracted by
+-- process-dokument-zitate into dokument_zitat. Citations without a common Vorgang are
+-- relationships the API's vorgangsbezug does not show.
+
+SELECT
+    q.drucksachetyp AS zitierende_drucksachetyp,
+    z.drucksachetyp AS zitierte_drucksachetyp,
+    COUNT(*) AS zitate,
+    SUM(dz.gemeinsamer_vorgang) AS mit_gemeinsamem_vorgang,
+    ROUND(100.0 * SUM(dz.gemeinsamer_vorgang) / COUNT(*), 2) AS pct_gemeinsamer_vorgang
+FROM drucksache_zitat dz
+JOIN drucksache q ON q.id = dz.drucksache_id
+JOIN drucksache z ON z.id = dz.zitiert_id
+GROUP BY q.drucksachetyp, z.drucksachetyp
+HAVING COUNT(*) >= 50
+ORDER BY zitate DESC
+LIMIT 25;
+
+-- PART 11: Most Cited Drucksachen
+-- Drucksachen cited by the most Drucksachen and Plenarprotokolle
+
+SELECT
+    d.dokumentnummer,
+    d.drucksachetyp,
+    d.titel,
+    COUNT(DISTINCT CASE WHEN dz.quelle_dokumentart = 'Drucksache' THEN dz.quelle_id END) AS zitiert_von_drucksachen,
+    COUNT(DISTINCT CASE WHEN dz.quelle_dokumentart = 'Plenarprotokoll' THEN dz.quelle_id END) AS zitiert_in_plenarprotokollen
+FROM dokument_zitat dz
+JOIN drucksache d ON d.id = dz.ziel_id
+WHERE dz.ziel_dokumentart = 'Drucksache'
+GROUP BY d.id
+ORDER BY zitiert_von_drucksachen + zitiert_in_plenarprotokollen DESC
+LIMIT 20;
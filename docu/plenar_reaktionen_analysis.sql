@@ -0,0 +1,80 @@
+-- ========================================
+-- PLENARY INTERACTION ANALYSIS
+-- Beifall, Zurufe, Widerspruch across Fraktionen
+-- ========================================
+-- The collaboration analyses (fraktion_collaboration_50years_*) look at joint
+-- initiatives. This analysis looks at the plenary: which Fraktionen applaud,
+-- heckle or contradict the speakers of which Fraktionen.
+--
+-- Requires the Plenarprotokoll texts and the parsed Reden and Reaktionen:
+--   sync-plenarprotokoll-texte, then process-plenarprotokoll-reden
+-- Tables: rede, rede_reaktion, view rede_reaktion_fraktion
+-- ========================================
+
+-- PART 1: COVERAGE
+-- Parsed Reden and Reaktionen per Wahlperiode
+
+SELECT
+    p.wahlperiode,
+    COUNT(DISTINCT r.plenarprotokoll_id) AS plenarprotokolle,
+    COUNT(DISTINCT r.id) AS reden,
+    COUNT(rr.id) AS reaktionen,
+    ROUND(1.0 * COUNT(rr.id) / COUNT(DISTINCT r.id), 2) AS reaktionen_pro_rede
+FROM rede r
+JOIN plenarprotokoll p ON p.id = r.plenarprotokoll_id
+LEFT JOIN rede_reaktion rr ON rr.rede_id = r.id
+GROUP BY p.wahlperiode
+ORDER BY p.wahlperiode;
+
+-- PART 2: CROSS-FRAKTION APPLAUSE
+-- Share of the Reden of a Fraktion that another Fraktion applauded at least once
+
+WITH reden_pro_fraktion AS (
+    SELECT p.wahlperiode, r.fraktion, COUNT(*) AS reden
+    FROM rede r
+    JOIN plenarprotokoll p ON p.id = r.plenarprotokoll_id
+    WHERE r.fraktion IS NOT NULL
+    GROUP BY p.wahlperiode, r.fraktion
+)
+SELECT
+    rf.wahlperiode,
+    rf.redner_fraktion,
+    rf.reagierende_fraktion,
+    rf.reden AS applaudierte_reden,
+    rp.reden AS reden_gesamt,
+    ROUND(100.0 * rf.reden / rp.reden, 1) AS anteil_prozent
+FROM rede_reaktion_fraktion rf
+JOIN reden_pro_fraktion rp ON rp.wahlperiode = rf.wahlperiode AND rp.fraktion = rf.redner_fraktion
+WHERE rf.art = 'Beifall'
+    AND rf.redner_fraktion <> rf.reagierende_fraktion
+ORDER BY rf.wahlperiode, rf.redner_fraktion, anteil_prozent DESC;
+
+-- PART 3: CONFRONTATION
+-- Zurufe and Widerspruch between Fraktionen per Wahlperiode
+
+SELECT
+    wahlperiode,
+    reagierende_fraktion,
+    redner_fraktion,
+    SUM(CASE WHEN art = 'Zuruf' THEN anzahl ELSE 0 END) AS zurufe,
+    SUM(CASE WHEN art = 'Widerspruch' THEN anzahl ELSE 0 END) AS widerspruch,
+    SUM(CASE WHEN art = 'Heiterkeit' THEN anzahl ELSE 0 END) AS heiterkeit
+FROM rede_reaktion_fraktion
+WHERE redner_fraktion <> reagierende_fraktion
+GROUP BY wahlperiode, reagierende_fraktion, redner_fraktion
+ORDER BY wahlperiode, zurufe + widerspruch DESC;
+
+-- PART 4: MOST ACTIVE HECKLERS
+-- Members with the most Zurufe, by resolved MdB where available
+
+SELECT
+    COALESCE(rr.mdb_id, rr.sprecher) AS mitglied,
+    MAX(rr.sprecher) AS name,
+    rr.fraktion,
+    COUNT(*) AS zurufe,
+    COUNT(DISTINCT rr.plenarprotokoll_id) AS sitzungen
+FROM rede_reaktion rr
+WHERE rr.art = 'Zuruf' AND rr.sprecher IS NOT NULL
+GROUP BY COALESCE(rr.mdb_id, rr.sprecher), rr.fraktion
+ORDER BY zurufe DESC
+LIMIT 30;
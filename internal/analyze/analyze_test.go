@@ -0,0 +1,212 @@
+package analyze
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/store/storetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openTestDB(t *testing.T) store.Store {
+	t.Helper()
+	ctx := context.Background()
+	s := storetest.Open(t)
+	require.NoError(t, s.UpsertVorgang(ctx, storetest.Vorgang(t, `{"sachgebiet": ["Energie"]}`)))
+	for _, p := range [][4]string{
+		{"555001", "Gesetzentwurf", "2023-08-18", "BR"},
+		{"555002", "1. Beratung", "2023-10-12", "BT"},
+		{"555003", "2. Beratung", "2023-11-17", "BT"},
+		{"555004", "3. Beratung", "2023-11-17", "BT"},
+		{"555005", "2. Durchgang", "2023-12-15", "BR"},
+	} {
+		position := storetest.Beratung(t, fmt.Sprintf(`{"id": %q, "vorgangsposition": %q, "datum": %q, "zuordnung": %q}`, p[0], p[1], p[2], p[3]))
+		require.NoError(t, s.UpsertVorgangsposition(ctx, position))
+	}
+
+	// A Gesetz of the Fraktionen without Beratungen, and one of the Bundesrat
+	// in the previous Wahlperiode
+	require.NoError(t, s.UpsertVorgang(ctx, storetest.Vorgang(t, `{
+		"id": "303300", "sachgebiet": ["Energie"], "verkuendung": null, "inkrafttreten": null, "zustimmungsbeduerftigkeit": null,
+		"initiative": ["Fraktion der SPD", "Fraktion BÜNDNIS 90/DIE GRÜNEN"]
+	}`)))
+	require.NoError(t, s.UpsertVorgang(ctx, storetest.Vorgang(t, `{
+		"id": "250001", "wahlperiode": 19, "sachgebiet": ["Energie"], "verkuendung": null, "inkrafttreten": null,
+		"zustimmungsbeduerftigkeit": null, "initiative": ["Bundesrat"]
+	}`)))
+	return s
+}
+
+func TestDurations(t *testing.T) {
+	ctx := context.Background()
+	s := openTestDB(t)
+
+	report, err := Durations(ctx, s.DB(), DurationOptions{Wahlperioden: []int{20}, Outliers: -1})
+	require.NoError(t, err)
+	require.Len(t, report.Gesetze, 2)
+	g := report.Gesetze[0]
+	assert.Equal(t, "303271", g.VorgangID)
+	assert.Equal(t, "Nein", g.Zustimmung)
+	assert.Equal(t, map[string]string{
+		Einbringung: "2023-08-18", ErsteBeratung: "2023-10-12", ZweiteBeratung: "2023-11-17",
+		DritteBeratung: "2023-11-17", Bundesrat: "2023-12-15", Verkuendung: "2023-12-22", Inkrafttreten: "2024-01-01",
+	}, g.Dates)
+	assert.Equal(t, map[string]int{
+		"einbringung": 55, "ausschuss": 36, "schlussberatung": 0, "bundesrat": 28,
+		"verkuendung": 7, "inkrafttreten": 10, "gesamt": 71,
+	}, g.Durations)
+	assert.Empty(t, report.Gesetze[1].Durations)
+
+	require.Len(t, report.Groups, 1)
+	assert.Equal(t, "20", report.Groups[0].Value)
+	assert.Equal(t, 2, report.Groups[0].Gesetze)
+	assert.Equal(t, Stats{N: 1, Min: 71, P25: 71, Median: 71, Mean: 71, P75: 71, Max: 71}, report.Groups[0].Phases["gesamt"])
+
+	report, err = Durations(ctx, s.DB(), DurationOptions{By: ByInitiative})
+	require.NoError(t, err)
+	var values []string
+	for _, g := range report.Groups {
+		values = append(values, fmt.Sprintf("%s=%d", g.Value, g.Gesetze))
+	}
+	assert.Equal(t, []string{"Bundesrat=1", "Bundesregierung=1", "Fraktion BÜNDNIS 90/DIE GRÜNEN=1", "Fraktion der SPD=1"}, values)
+
+	_, err = Durations(ctx, s.DB(), DurationOptions{By: "fraktion"})
+	assert.ErrorContains(t, err, "unknown dimension")
+
+	// Deleted Vorgangspositionen are left out
+	require.NoError(t, s.MarkDeleted(ctx, store.VorgangspositionTable, "555002", time.Now()))
+	report, err = Durations(ctx, s.DB(), DurationOptions{Wahlperioden: []int{20}})
+	require.NoError(t, err)
+	assert.NotContains(t, report.Gesetze[0].Dates, ErsteBeratung)
+	assert.NotContains(t, report.Gesetze[0].Durations, "gesamt")
+}
+
+func TestOutliers(t *testing.T) {
+	var gesetze []Gesetz
+	for i, days := range []int{10, 12, 11, 13, 12, 95, -3} {
+		gesetze = append(gesetze, Gesetz{VorgangID: fmt.Sprint(i), Durations: map[string]int{"gesamt": days}})
+	}
+	found := outliers(gesetze, 10)
+	require.Len(t, found, 2)
+	assert.Equal(t, "5", found[0].VorgangID)
+	assert.Equal(t, 95, found[0].Days)
+	assert.Equal(t, 12.0, found[0].Median)
+	assert.Equal(t, -3, found[1].Days)
+	assert.Len(t, outliers(gesetze, 1), 1)
+
+	assert.Equal(t, 2.5, percentile([]int{1, 2, 3, 4}, 0.5))
+}
+
+func TestWriteDurations(t *testing.T) {
+	report, err := Durations(context.Background(), openTestDB(t).DB(), DurationOptions{})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteDurationsCSV(&buf, report, RowsGroups))
+	assert.Contains(t, buf.String(), "dimension,value,gesetze,phase,n,min,p25,median,mean,p75,max\n")
+	assert.Contains(t, buf.String(), "wahlperiode,20,2,gesamt,1,71,71,71,71,71,71\n")
+
+	buf.Reset()
+	require.NoError(t, WriteDurationsCSV(&buf, report, RowsGesetze))
+	assert.Contains(t, buf.String(), "303271,20,Bundesregierung,Energie,Nein,2023-08-18,2023-10-12,")
+
+	buf.Reset()
+	require.NoError(t, WriteDurationsText(&buf, report))
+	assert.Contains(t, buf.String(), "71 (1)")
+	assert.Error(t, WriteDurationsCSV(&buf, report, "phases"))
+}
+
+func TestReports(t *testing.T) {
+	reports, err := Reports()
+	require.NoError(t, err)
+	var names []string
+	for _, r := range reports {
+		names = append(names, r.Name)
+		assert.NotEmpty(t, r.Title, r.Name)
+		assert.Contains(t, r.Params, ParamWahlperiode, r.Name)
+	}
+	assert.Equal(t, []string{
+		"activity-categorization", "collaboration-champions", "collaboration-continuity", "drucksache-vorgang", "eu-influence",
+		"fraktion-collaboration", "fraktion-content", "oversight", "person-participation", "plenar-reaktionen",
+	}, names)
+
+	_, err = FindReport("coalitions")
+	assert.ErrorIs(t, err, ErrUnknownReport)
+
+	// Every section runs against the schema
+	ctx := context.Background()
+	s := openTestDB(t)
+	for _, r := range reports {
+		var params ReportParams
+		if slices.Contains(r.Params, ParamFraktion) {
+			params.Fraktionen = []string{"SPD"}
+		}
+		result, err := RunReport(ctx, s.DB(), r, params)
+		require.NoError(t, err, r.Name)
+		assert.Len(t, result.Sections, len(r.Sections))
+		assert.Equal(t, ReportParams{WahlperiodeFrom: 19, WahlperiodeTo: 20, Fraktionen: params.Fraktionen}, result.Params)
+	}
+
+	eu, err := FindReport("eu-influence")
+	require.NoError(t, err)
+	_, err = RunReport(ctx, s.DB(), eu, ReportParams{Fraktionen: []string{"SPD"}})
+	assert.ErrorContains(t, err, "does not take Fraktionen")
+	_, err = RunReport(ctx, s.DB(), eu, ReportParams{WahlperiodeFrom: 21, WahlperiodeTo: 20})
+	assert.ErrorContains(t, err, "invalid Wahlperiode range")
+
+	collaboration, err := FindReport("fraktion-collaboration")
+	require.NoError(t, err)
+	result, err := RunReport(ctx, s.DB(), collaboration, ReportParams{Fraktionen: []string{"GRÜNEN"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"wahlperiode", "joint_vorgaenge", "fraktion_vorgaenge", "pct_joint"}, result.Sections[0].Columns)
+	assert.Equal(t, [][]any{{int64(20), int64(1), int64(1), 100.0}}, result.Sections[0].Rows)
+	assert.Equal(t, [][]any{{"Fraktion BÜNDNIS 90/DIE GRÜNEN", "Fraktion der SPD", int64(1), 100.0}}, result.Sections[1].Rows)
+
+	result, err = RunReport(ctx, s.DB(), collaboration, ReportParams{Fraktionen: []string{"FDP"}})
+	require.NoError(t, err)
+	assert.Empty(t, result.Sections[0].Rows)
+
+	result, err = RunReport(ctx, s.DB(), collaboration, ReportParams{WahlperiodeFrom: 19, WahlperiodeTo: 19})
+	require.NoError(t, err)
+	assert.Empty(t, result.Sections[0].Rows)
+}
+
+func TestWriteReportFiles(t *testing.T) {
+	collaboration, err := FindReport("fraktion-collaboration")
+	require.NoError(t, err)
+	result, err := RunReport(context.Background(), openTestDB(t).DB(), collaboration, ReportParams{Fraktionen: []string{"SPD"}})
+	require.NoError(t, err)
+	result.GeneratedAt = time.Date(2024, 2, 1, 8, 30, 0, 0, time.UTC)
+
+	dir := t.TempDir()
+	written, err := WriteReportFiles(dir, result)
+	require.NoError(t, err)
+	assert.Len(t, written, 2+len(result.Sections))
+	assert.Contains(t, written, filepath.Join(dir, "fraktion-collaboration", "20240201T083000Z-01-joint-vorgaenge-per-wahlperiode.csv"))
+
+	md, err := os.ReadFile(filepath.Join(dir, "fraktion-collaboration", "latest.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(md), "# Fraktion collaboration\n")
+	assert.Contains(t, string(md), "- **Wahlperioden:** 19-20\n- **Fraktionen:** SPD\n- **Generated:** 2024-02-01T08:30:00Z\n")
+	assert.Contains(t, string(md), "| 20 | 1 | 1 | 100 |\n")
+
+	csv, err := os.ReadFile(filepath.Join(dir, "fraktion-collaboration", "latest-01-joint-vorgaenge-per-wahlperiode.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "wahlperiode,joint_vorgaenge,fraktion_vorgaenge,pct_joint\n20,1,1,100\n", string(csv))
+
+	var decoded ReportResult
+	data, err := os.ReadFile(filepath.Join(dir, "fraktion-collaboration", "20240201T083000Z.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, result.Checksum, decoded.Checksum)
+	assert.Equal(t, "2024-01-10T09:00:00+01:00", decoded.DataAsOf)
+}
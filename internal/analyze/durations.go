@@ -0,0 +1,397 @@
+// Package analyze runs analyses over the local SQLite database written by
+// sync-all. Rows marked as deleted by sync-reconcile are left out.
+package analyze
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Milestones of the legislative process, see Gesetz.Dates
+const (
+	Einbringung    = "einbringung"
+	ErsteBeratung  = "1_beratung"
+	ZweiteBeratung = "2_beratung"
+	DritteBeratung = "3_beratung"
+	Bundesrat      = "bundesrat"
+	Verkuendung    = "verkuendung"
+	Inkrafttreten  = "inkrafttreten"
+)
+
+// milestones are the dates read per Gesetzgebung Vorgang. Vorgangspositionen
+// are named like the step; "2. und 3. Beratung" counts for both Beratungen.
+// The Bundesrat milestone is the 2. Durchgang, the decision on the Gesetzesbeschluss.
+var milestones = []struct{ name, query string }{
+	{Einbringung, `SELECT MIN(datum) FROM vorgangsposition
+		WHERE vorgang_id = v.id AND deleted_at IS NULL AND vorgangsposition = 'Gesetzentwurf'`},
+	{ErsteBeratung, `SELECT MIN(datum) FROM vorgangsposition
+		WHERE vorgang_id = v.id AND deleted_at IS NULL AND zuordnung = 'BT' AND vorgangsposition LIKE '1. Beratung%'`},
+	{ZweiteBeratung, `SELECT MIN(datum) FROM vorgangsposition
+		WHERE vorgang_id = v.id AND deleted_at IS NULL AND zuordnung = 'BT'
+		  AND (vorgangsposition LIKE '2. Beratung%' OR vorgangsposition LIKE '2. und 3. Beratung%')`},
+	{DritteBeratung, `SELECT MIN(datum) FROM vorgangsposition
+		WHERE vorgang_id = v.id AND deleted_at IS NULL AND zuordnung = 'BT'
+		  AND (vorgangsposition LIKE '3. Beratung%' OR vorgangsposition LIKE '2. und 3. Beratung%')`},
+	{Bundesrat, `SELECT MIN(datum) FROM vorgangsposition
+		WHERE vorgang_id = v.id AND deleted_at IS NULL AND zuordnung = 'BR' AND vorgangsposition LIKE '2. Durchgang%'`},
+	{Verkuendung, `SELECT MIN(verkuendungsdatum) FROM verkuendung WHERE vorgang_id = v.id`},
+	{Inkrafttreten, `SELECT MIN(datum) FROM inkrafttreten WHERE vorgang_id = v.id`},
+}
+
+// Phase is the time between two milestones
+type Phase struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// Phases are the durations computed per Gesetz, in the order of the process.
+// Gesamt spans the parliamentary process from the 1. Beratung to the Verkündung.
+var Phases = []Phase{
+	{"einbringung", "Einbringung → 1. Beratung", Einbringung, ErsteBeratung},
+	{"ausschuss", "1. → 2. Beratung", ErsteBeratung, ZweiteBeratung},
+	{"schlussberatung", "2. → 3. Beratung", ZweiteBeratung, DritteBeratung},
+	{"bundesrat", "3. Beratung → Bundesrat", DritteBeratung, Bundesrat},
+	{"verkuendung", "Bundesrat → Verkündung", Bundesrat, Verkuendung},
+	{"inkrafttreten", "Verkündung → Inkrafttreten", Verkuendung, Inkrafttreten},
+	{"gesamt", "1. Beratung → Verkündung", ErsteBeratung, Verkuendung},
+}
+
+// Dimensions Gesetze can be grouped by, see DurationOptions.By
+const (
+	ByWahlperiode = "wahlperiode"
+	ByInitiative  = "initiative"
+	BySachgebiet  = "sachgebiet"
+	ByZustimmung  = "zustimmung"
+)
+
+// Dimensions are the valid values of DurationOptions.By
+var Dimensions = []string{ByWahlperiode, ByInitiative, BySachgebiet, ByZustimmung}
+
+// noValue is the group of Gesetze without a value of the dimension
+const noValue = "(ohne)"
+
+// DefaultOutliers is the number of outliers listed per phase if DurationOptions.Outliers is not set
+const DefaultOutliers = 10
+
+// Gesetz is a Gesetzgebung Vorgang with its milestone dates and phase durations
+type Gesetz struct {
+	VorgangID   string            `json:"vorgang_id"`
+	Titel       string            `json:"titel"`
+	Wahlperiode int               `json:"wahlperiode"`
+	Initiativen []string          `json:"initiativen,omitempty"`
+	Sachgebiete []string          `json:"sachgebiete,omitempty"`
+	Zustimmung  string            `json:"zustimmungsbeduerftig,omitempty"` // Ja or Nein
+	Dates       map[string]string `json:"dates"`                           // milestone -> YYYY-MM-DD
+	Durations   map[string]int    `json:"durations"`                       // phase -> days
+}
+
+// Stats summarizes the durations of a phase in days
+type Stats struct {
+	N      int     `json:"n"`
+	Min    int     `json:"min"`
+	P25    float64 `json:"p25"`
+	Median float64 `json:"median"`
+	Mean   float64 `json:"mean"`
+	P75    float64 `json:"p75"`
+	Max    int     `json:"max"`
+}
+
+// Group are the Gesetze sharing a value of the dimension
+type Group struct {
+	Value   string           `json:"value"`
+	Gesetze int              `json:"gesetze"`
+	Phases  map[string]Stats `json:"phases"` // phase -> stats, phases without durations are left out
+}
+
+// Outlier is a Gesetz whose phase took unusually long or short, or has a
+// negative duration because of inconsistent dates
+type Outlier struct {
+	Phase       string  `json:"phase"`
+	VorgangID   string  `json:"vorgang_id"`
+	Titel       string  `json:"titel"`
+	Wahlperiode int     `json:"wahlperiode"`
+	Days        int     `json:"days"`
+	Median      float64 `json:"median"`
+	Fence       float64 `json:"fence"` // the Tukey fence that was crossed
+}
+
+// DurationReport is the result of Durations
+type DurationReport struct {
+	By       string    `json:"by"`
+	Phases   []Phase   `json:"phases"`
+	Groups   []Group   `json:"groups"`
+	Outliers []Outlier `json:"outliers"`
+	Gesetze  []Gesetz  `json:"-"`
+}
+
+// DurationOptions filters and groups Durations
+type DurationOptions struct {
+	Wahlperioden []int  // empty = all
+	By           string // one of Dimensions, empty = ByWahlperiode
+	Outliers     int    // per phase, 0 = DefaultOutliers, < 0 = none
+}
+
+// Durations derives the phase durations of every Gesetzgebung Vorgang and
+// aggregates them by the dimension of opts. A Gesetz counts for every
+// Initiative and Sachgebiet it has. Outliers lie beyond 1.5 times the
+// interquartile range of a phase over all Gesetze.
+func Durations(ctx context.Context, sqlDB *sql.DB, opts DurationOptions) (*DurationReport, error) {
+	if opts.By == "" {
+		opts.By = ByWahlperiode
+	}
+	if !slices.Contains(Dimensions, opts.By) {
+		return nil, fmt.Errorf("unknown dimension %q, must be one of %s", opts.By, strings.Join(Dimensions, ", "))
+	}
+	if opts.Outliers == 0 {
+		opts.Outliers = DefaultOutliers
+	}
+
+	gesetze, err := loadGesetze(ctx, sqlDB, opts.Wahlperioden)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Gesetze: %w", err)
+	}
+	report := &DurationReport{By: opts.By, Phases: Phases, Groups: []Group{}, Outliers: []Outlier{}, Gesetze: gesetze}
+
+	members := map[string][]*Gesetz{}
+	for i := range gesetze {
+		for _, value := range groupValues(&gesetze[i], opts.By) {
+			members[value] = append(members[value], &gesetze[i])
+		}
+	}
+	for value, group := range members {
+		report.Groups = append(report.Groups, Group{Value: value, Gesetze: len(group), Phases: phaseStats(group)})
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if opts.By == ByWahlperiode {
+			return len(a.Value) < len(b.Value) || len(a.Value) == len(b.Value) && a.Value < b.Value
+		}
+		if a.Gesetze != b.Gesetze {
+			return a.Gesetze > b.Gesetze
+		}
+		return a.Value < b.Value
+	})
+
+	if opts.Outliers > 0 {
+		report.Outliers = outliers(gesetze, opts.Outliers)
+	}
+	return report, nil
+}
+
+func loadGesetze(ctx context.Context, sqlDB *sql.DB, wahlperioden []int) ([]Gesetz, error) {
+	var columns []string
+	for _, m := range milestones {
+		columns = append(columns, "("+m.query+")")
+	}
+	query := `
+		SELECT v.id, v.titel, v.wahlperiode,
+			(SELECT json_group_array(initiative) FROM (SELECT initiative FROM vorgang_initiative WHERE vorgang_id = v.id ORDER BY id)),
+			(SELECT json_group_array(sachgebiet) FROM (SELECT sachgebiet FROM vorgang_sachgebiet WHERE vorgang_id = v.id ORDER BY id)),
+			(SELECT json_group_array(zustimmungsbeduerftigkeit) FROM (SELECT zustimmungsbeduerftigkeit FROM vorgang_zustimmungsbeduerftigkeit WHERE vorgang_id = v.id ORDER BY id)),
+			` + strings.Join(columns, ",\n\t\t\t") + `
+		FROM vorgang v
+		WHERE v.vorgangstyp = 'Gesetzgebung' AND v.deleted_at IS NULL`
+	var args []any
+	if len(wahlperioden) > 0 {
+		query += ` AND v.wahlperiode IN (?` + strings.Repeat(", ?", len(wahlperioden)-1) + `)`
+		for _, wp := range wahlperioden {
+			args = append(args, wp)
+		}
+	}
+	query += ` ORDER BY v.wahlperiode, v.id`
+
+	rows, err := sqlDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var gesetze []Gesetz
+	for rows.Next() {
+		var (
+			g                                    Gesetz
+			titel                                sql.NullString
+			wahlperiode                          sql.NullInt64
+			initiativen, sachgebiete, zustimmung string
+			zustimmungen                         []string
+			dates                                = make([]sql.NullString, len(milestones))
+		)
+		dest := []any{&g.VorgangID, &titel, &wahlperiode, &initiativen, &sachgebiete, &zustimmung}
+		for i := range dates {
+			dest = append(dest, &dates[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		g.Titel = titel.String
+		g.Wahlperiode = int(wahlperiode.Int64)
+		if err := json.Unmarshal([]byte(initiativen), &g.Initiativen); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(sachgebiete), &g.Sachgebiete); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(zustimmung), &zustimmungen); err != nil {
+			return nil, err
+		}
+		g.Zustimmung = zustimmungsbeduerftig(zustimmungen)
+
+		g.Dates = map[string]string{}
+		for i, m := range milestones {
+			if dates[i].Valid && len(dates[i].String) >= len(time.DateOnly) {
+				g.Dates[m.name] = dates[i].String[:len(time.DateOnly)]
+			}
+		}
+		g.Durations = durations(g.Dates)
+		gesetze = append(gesetze, g)
+	}
+	return gesetze, rows.Err()
+}
+
+// zustimmungsbeduerftig reduces the statements of a Vorgang, e.g. "Ja, laut
+// Gesetzentwurf" and "Nein, laut Verkündung", to Ja or Nein. The statement
+// of the Verkündung is final, otherwise the latest one counts.
+func zustimmungsbeduerftig(statements []string) string {
+	if len(statements) == 0 {
+		return ""
+	}
+	final := statements[len(statements)-1]
+	for _, s := range statements {
+		if strings.Contains(s, "Verkündung") {
+			final = s
+		}
+	}
+	verdict, _, _ := strings.Cut(final, ",")
+	return strings.TrimSpace(verdict)
+}
+
+// durations returns the days of each phase whose milestones are both known
+func durations(dates map[string]string) map[string]int {
+	days := map[string]int{}
+	for _, p := range Phases {
+		from, err := time.Parse(time.DateOnly, dates[p.From])
+		if err != nil {
+			continue
+		}
+		to, err := time.Parse(time.DateOnly, dates[p.To])
+		if err != nil {
+			continue
+		}
+		days[p.Name] = int(math.Round(to.Sub(from).Hours() / 24))
+	}
+	return days
+}
+
+func groupValues(g *Gesetz, by string) []string {
+	var values []string
+	switch by {
+	case ByWahlperiode:
+		values = []string{fmt.Sprint(g.Wahlperiode)}
+	case ByInitiative:
+		values = g.Initiativen
+	case BySachgebiet:
+		values = g.Sachgebiete
+	case ByZustimmung:
+		if g.Zustimmung != "" {
+			values = []string{g.Zustimmung}
+		}
+	}
+	if len(values) == 0 {
+		return []string{noValue}
+	}
+	return values
+}
+
+func phaseStats(gesetze []*Gesetz) map[string]Stats {
+	stats := map[string]Stats{}
+	for _, p := range Phases {
+		var days []int
+		for _, g := range gesetze {
+			if d, ok := g.Durations[p.Name]; ok {
+				days = append(days, d)
+			}
+		}
+		if len(days) > 0 {
+			stats[p.Name] = summarize(days)
+		}
+	}
+	return stats
+}
+
+func summarize(days []int) Stats {
+	sorted := slices.Clone(days)
+	slices.Sort(sorted)
+	sum := 0
+	for _, d := range sorted {
+		sum += d
+	}
+	return Stats{
+		N:      len(sorted),
+		Min:    sorted[0],
+		P25:    percentile(sorted, 0.25),
+		Median: percentile(sorted, 0.5),
+		Mean:   math.Round(float64(sum)/float64(len(sorted))*10) / 10,
+		P75:    percentile(sorted, 0.75),
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+// percentile interpolates linearly between the closest ranks of sorted
+func percentile(sorted []int, p float64) float64 {
+	pos := p * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	return float64(sorted[lower]) + (pos-float64(lower))*float64(sorted[upper]-sorted[lower])
+}
+
+// outliers returns up to limit Gesetze per phase outside the Tukey fences,
+// farthest from the median first. Negative durations are always outliers.
+func outliers(gesetze []Gesetz, limit int) []Outlier {
+	result := []Outlier{}
+	for _, p := range Phases {
+		var days []int
+		for _, g := range gesetze {
+			if d, ok := g.Durations[p.Name]; ok {
+				days = append(days, d)
+			}
+		}
+		if len(days) == 0 {
+			continue
+		}
+		s := summarize(days)
+		iqr := s.P75 - s.P25
+		lower, upper := s.P25-1.5*iqr, s.P75+1.5*iqr
+
+		var found []Outlier
+		for _, g := range gesetze {
+			d, ok := g.Durations[p.Name]
+			if !ok {
+				continue
+			}
+			o := Outlier{Phase: p.Name, VorgangID: g.VorgangID, Titel: g.Titel, Wahlperiode: g.Wahlperiode, Days: d, Median: s.Median}
+			switch {
+			case float64(d) > upper:
+				o.Fence = upper
+			case float64(d) < lower || d < 0:
+				o.Fence = max(lower, 0)
+			default:
+				continue
+			}
+			found = append(found, o)
+		}
+		sort.SliceStable(found, func(i, j int) bool {
+			return math.Abs(float64(found[i].Days)-s.Median) > math.Abs(float64(found[j].Days)-s.Median)
+		})
+		result = append(result, found[:min(limit, len(found))]...)
+	}
+	return result
+}
@@ -0,0 +1,297 @@
+package analyze
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Row sets of a DurationReport, see WriteDurationsCSV
+const (
+	RowsGroups   = "groups"
+	RowsOutliers = "outliers"
+	RowsGesetze  = "gesetze"
+)
+
+// DurationRows are the valid row sets of a DurationReport
+var DurationRows = []string{RowsGroups, RowsOutliers, RowsGesetze}
+
+// WriteDurationsText writes the median days per phase and group, followed by
+// the outliers
+func WriteDurationsText(w io.Writer, r *DurationReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := []string{strings.ToUpper(r.By), "GESETZE"}
+	for _, p := range r.Phases {
+		header = append(header, strings.ToUpper(p.Name))
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, g := range r.Groups {
+		cells := []string{shorten(g.Value, 40), strconv.Itoa(g.Gesetze)}
+		for _, p := range r.Phases {
+			cells = append(cells, medianCell(g.Phases[p.Name]))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\nCells are the median days (number of Gesetze) of the phases:\n")
+	for _, p := range r.Phases {
+		fmt.Fprintf(&b, "  %-16s %s\n", p.Name, p.Label)
+	}
+	if len(r.Outliers) > 0 {
+		fmt.Fprintf(&b, "\nOutliers:\n")
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return err
+	}
+	if len(r.Outliers) == 0 {
+		return nil
+	}
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PHASE\tDAYS\tMEDIAN\tVORGANG\tWP\tTITEL")
+	for _, o := range r.Outliers {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%d\t%s\n",
+			o.Phase, o.Days, formatDays(o.Median), o.VorgangID, o.Wahlperiode, shorten(o.Titel, 70))
+	}
+	return tw.Flush()
+}
+
+// WriteGesetzeText writes the milestone dates and durations of each Gesetz
+func WriteGesetzeText(w io.Writer, gesetze []Gesetz) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := []string{"VORGANG", "WP"}
+	for _, m := range milestones {
+		header = append(header, strings.ToUpper(m.name))
+	}
+	fmt.Fprintln(tw, strings.Join(append(header, "GESAMT", "TITEL"), "\t"))
+	for _, g := range gesetze {
+		cells := []string{g.VorgangID, strconv.Itoa(g.Wahlperiode)}
+		for _, m := range milestones {
+			cells = append(cells, g.Dates[m.name])
+		}
+		gesamt := ""
+		if d, ok := g.Durations["gesamt"]; ok {
+			gesamt = strconv.Itoa(d)
+		}
+		fmt.Fprintln(tw, strings.Join(append(cells, gesamt, shorten(g.Titel, 60)), "\t"))
+	}
+	return tw.Flush()
+}
+
+// WriteDurationsCSV writes one of the row sets of the report as CSV: groups
+// with one line per group and phase, outliers, or gesetze with the dates and
+// durations of each Gesetz
+func WriteDurationsCSV(w io.Writer, r *DurationReport, rows string) error {
+	cw := csv.NewWriter(w)
+	switch rows {
+	case RowsGroups:
+		cw.Write([]string{"dimension", "value", "gesetze", "phase", "n", "min", "p25", "median", "mean", "p75", "max"})
+		for _, g := range r.Groups {
+			for _, p := range r.Phases {
+				s, ok := g.Phases[p.Name]
+				if !ok {
+					continue
+				}
+				cw.Write([]string{r.By, g.Value, strconv.Itoa(g.Gesetze), p.Name, strconv.Itoa(s.N),
+					strconv.Itoa(s.Min), formatDays(s.P25), formatDays(s.Median), formatDays(s.Mean),
+					formatDays(s.P75), strconv.Itoa(s.Max)})
+			}
+		}
+	case RowsOutliers:
+		cw.Write([]string{"phase", "vorgang_id", "wahlperiode", "days", "median", "fence", "titel"})
+		for _, o := range r.Outliers {
+			cw.Write([]string{o.Phase, o.VorgangID, strconv.Itoa(o.Wahlperiode), strconv.Itoa(o.Days),
+				formatDays(o.Median), formatDays(o.Fence), o.Titel})
+		}
+	case RowsGesetze:
+		header := []string{"vorgang_id", "wahlperiode", "initiativen", "sachgebiete", "zustimmungsbeduerftig"}
+		for _, m := range milestones {
+			header = append(header, m.name)
+		}
+		for _, p := range r.Phases {
+			header = append(header, p.Name+"_days")
+		}
+		cw.Write(append(header, "titel"))
+		for _, g := range r.Gesetze {
+			record := []string{g.VorgangID, strconv.Itoa(g.Wahlperiode),
+				strings.Join(g.Initiativen, "; "), strings.Join(g.Sachgebiete, "; "), g.Zustimmung}
+			for _, m := range milestones {
+				record = append(record, g.Dates[m.name])
+			}
+			for _, p := range r.Phases {
+				days := ""
+				if d, ok := g.Durations[p.Name]; ok {
+					days = strconv.Itoa(d)
+				}
+				record = append(record, days)
+			}
+			cw.Write(append(record, g.Titel))
+		}
+	default:
+		return fmt.Errorf("unknown rows %q, must be one of %s", rows, strings.Join(DurationRows, ", "))
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func medianCell(s Stats) string {
+	if s.N == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%d)", formatDays(s.Median), s.N)
+}
+
+func formatDays(days float64) string {
+	return strconv.FormatFloat(days, 'f', -1, 64)
+}
+
+func shorten(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// WriteReportMarkdown writes a report run as a Markdown document with the
+// parameters and versions in the header, so findings can cite it
+func WriteReportMarkdown(w io.Writer, r *ReportResult) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", r.Title)
+	fmt.Fprintf(&b, "- **Report:** %s, version %s (%s)\n", r.Report, r.Version, r.Checksum)
+	fmt.Fprintf(&b, "- **Wahlperioden:** %s\n", wahlperiodeRange(r.Params))
+	if len(r.Params.Fraktionen) > 0 {
+		fmt.Fprintf(&b, "- **Fraktionen:** %s\n", markdownCell(strings.Join(r.Params.Fraktionen, ", ")))
+	}
+	fmt.Fprintf(&b, "- **Generated:** %s\n", r.GeneratedAt.Format(time.RFC3339))
+	if r.DataAsOf != "" {
+		fmt.Fprintf(&b, "- **Data as of:** %s\n", r.DataAsOf)
+	}
+	if r.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", r.Description)
+	}
+	for _, t := range r.Sections {
+		fmt.Fprintf(&b, "\n## %s\n\n", t.Title)
+		if len(t.Rows) == 0 {
+			fmt.Fprintf(&b, "No rows.\n")
+			continue
+		}
+		fmt.Fprintf(&b, "| %s |\n", strings.Join(t.Columns, " | "))
+		fmt.Fprintf(&b, "|%s\n", strings.Repeat("---|", len(t.Columns)))
+		for _, row := range t.Rows {
+			cells := make([]string, len(row))
+			for i, v := range row {
+				cells[i] = markdownCell(formatValue(v))
+			}
+			fmt.Fprintf(&b, "| %s |\n", strings.Join(cells, " | "))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteTableCSV writes the rows of a report section as CSV
+func WriteTableCSV(w io.Writer, t Table) error {
+	cw := csv.NewWriter(w)
+	cw.Write(t.Columns)
+	for _, row := range t.Rows {
+		record := make([]string, len(row))
+		for i, v := range row {
+			record[i] = formatValue(v)
+		}
+		cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteReportFiles writes a report run to dir/<report>/ as <stamp>.md,
+// <stamp>.json and one <stamp>-<nn>-<section>.csv per section, stamped with
+// the time of the run, and copies them to latest.md, latest.json and
+// latest-<nn>-<section>.csv. It returns the paths of the stamped files.
+func WriteReportFiles(dir string, r *ReportResult) ([]string, error) {
+	dir = filepath.Join(dir, r.Report)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	stamp := r.GeneratedAt.UTC().Format("20060102T150405Z")
+
+	files := map[string]func(io.Writer) error{
+		".md": func(w io.Writer) error { return WriteReportMarkdown(w, r) },
+		".json": func(w io.Writer) error {
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(r)
+		},
+	}
+	for i, t := range r.Sections {
+		files[fmt.Sprintf("-%02d-%s.csv", i+1, slug(t.Title))] = func(w io.Writer) error { return WriteTableCSV(w, t) }
+	}
+	suffixes := make([]string, 0, len(files))
+	for suffix := range files {
+		suffixes = append(suffixes, suffix)
+	}
+	slices.Sort(suffixes)
+
+	var written []string
+	for _, suffix := range suffixes {
+		var buf bytes.Buffer
+		if err := files[suffix](&buf); err != nil {
+			return written, err
+		}
+		stamped := filepath.Join(dir, stamp+suffix)
+		if err := os.WriteFile(stamped, buf.Bytes(), 0o644); err != nil {
+			return written, err
+		}
+		written = append(written, stamped)
+		if err := os.WriteFile(filepath.Join(dir, "latest"+suffix), buf.Bytes(), 0o644); err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+func wahlperiodeRange(p ReportParams) string {
+	if p.WahlperiodeFrom == p.WahlperiodeTo {
+		return strconv.Itoa(p.WahlperiodeFrom)
+	}
+	return fmt.Sprintf("%d-%d", p.WahlperiodeFrom, p.WahlperiodeTo)
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+var umlauts = strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss")
+
+// slug turns a section title into a file name part, e.g. "Joint Vorgänge per
+// Wahlperiode" into "joint-vorgaenge-per-wahlperiode"
+func slug(title string) string {
+	s := umlauts.Replace(strings.ToLower(title))
+	return strings.Trim(strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	}), "-"), "-")
+}
@@ -0,0 +1,273 @@
+package analyze
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// reportFiles are the named reports. Each file starts with a header of
+// "-- key: value" lines (title, version, params, source) and description
+// comments, followed by "-- section: <title>" lines each introducing one
+// SELECT. Queries use the parameters :wp_from, :wp_to and :fraktionen, a
+// JSON array matched as substring of the Fraktion names ('[]' = all).
+//
+//go:embed reports/*.sql
+var reportFiles embed.FS
+
+// Report parameters, see Report.Params
+const (
+	ParamWahlperiode = "wahlperiode"
+	ParamFraktion    = "fraktion"
+)
+
+// ErrUnknownReport is returned by FindReport for names without a report
+var ErrUnknownReport = errors.New("unknown report")
+
+// Report is a named analysis embedded in the binary
+type Report struct {
+	Name        string
+	Title       string
+	Version     string
+	Source      string // the docu/*.sql analysis the report is derived from
+	Description string
+	Params      []string // ParamWahlperiode, ParamFraktion
+	Checksum    string   // of the SQL, changes with every edit of the queries
+	Sections    []Section
+}
+
+// Section is a query of a report
+type Section struct {
+	Title string
+	Query string
+}
+
+// ReportParams are the arguments of a report run
+type ReportParams struct {
+	WahlperiodeFrom int      `json:"wahlperiode_from"` // 0 = first in the database
+	WahlperiodeTo   int      `json:"wahlperiode_to"`   // 0 = last in the database
+	Fraktionen      []string `json:"fraktionen,omitempty"`
+}
+
+// ReportResult is the outcome of a report run
+type ReportResult struct {
+	Report      string       `json:"report"`
+	Title       string       `json:"title"`
+	Version     string       `json:"version"`
+	Checksum    string       `json:"checksum"`
+	Description string       `json:"description,omitempty"`
+	GeneratedAt time.Time    `json:"generated_at"`
+	DataAsOf    string       `json:"data_as_of,omitempty"` // latest aktualisiert of the Vorgänge
+	Params      ReportParams `json:"params"`
+	Sections    []Table      `json:"sections"`
+}
+
+// Table is the result of a section
+type Table struct {
+	Title   string   `json:"title"`
+	Columns []string `json:"columns"`
+	Rows    [][]any  `json:"rows"`
+}
+
+// Reports returns the embedded reports sorted by name
+func Reports() ([]Report, error) {
+	names, err := fs.Glob(reportFiles, "reports/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	var reports []Report
+	for _, name := range names {
+		data, err := reportFiles.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		r, err := parseReport(strings.TrimSuffix(path.Base(name), ".sql"), string(data))
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, r)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Name < reports[j].Name })
+	return reports, nil
+}
+
+// FindReport returns the embedded report of the name
+func FindReport(name string) (Report, error) {
+	reports, err := Reports()
+	if err != nil {
+		return Report{}, err
+	}
+	var names []string
+	for _, r := range reports {
+		if r.Name == name {
+			return r, nil
+		}
+		names = append(names, r.Name)
+	}
+	return Report{}, fmt.Errorf("%w %q, must be one of %s", ErrUnknownReport, name, strings.Join(names, ", "))
+}
+
+func parseReport(name, data string) (Report, error) {
+	sum := sha256.Sum256([]byte(data))
+	r := Report{Name: name, Checksum: hex.EncodeToString(sum[:])[:12]}
+	var (
+		description []string
+		query       strings.Builder
+		inSections  bool
+	)
+	flush := func() {
+		if inSections {
+			r.Sections[len(r.Sections)-1].Query = strings.TrimSuffix(strings.TrimSpace(query.String()), ";")
+		}
+		query.Reset()
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if title, ok := strings.CutPrefix(line, "-- section:"); ok {
+			flush()
+			r.Sections = append(r.Sections, Section{Title: strings.TrimSpace(title)})
+			inSections = true
+			continue
+		}
+		if inSections {
+			query.WriteString(line + "\n")
+			continue
+		}
+		comment, ok := strings.CutPrefix(line, "--")
+		if !ok {
+			continue
+		}
+		comment = strings.TrimSpace(comment)
+		key, value, _ := strings.Cut(comment, ":")
+		switch key {
+		case "title":
+			r.Title = strings.TrimSpace(value)
+		case "version":
+			r.Version = strings.TrimSpace(value)
+		case "source":
+			r.Source = strings.TrimSpace(value)
+		case "params":
+			for _, p := range strings.Split(value, ",") {
+				r.Params = append(r.Params, strings.TrimSpace(p))
+			}
+		default:
+			description = append(description, comment)
+		}
+	}
+	flush()
+	r.Description = strings.TrimSpace(strings.Join(description, " "))
+
+	if r.Title == "" || r.Version == "" || len(r.Sections) == 0 {
+		return r, fmt.Errorf("report %s: title, version and at least one section are required", name)
+	}
+	for _, s := range r.Sections {
+		if s.Query == "" {
+			return r, fmt.Errorf("report %s: section %q has no query", name, s.Title)
+		}
+	}
+	return r, scanner.Err()
+}
+
+// RunReport runs the sections of the report. Without a Wahlperiode range, the
+// Wahlperioden of the Vorgänge in the database are used.
+func RunReport(ctx context.Context, sqlDB *sql.DB, r Report, params ReportParams) (*ReportResult, error) {
+	if len(params.Fraktionen) > 0 && !slices.Contains(r.Params, ParamFraktion) {
+		return nil, fmt.Errorf("report %s does not take Fraktionen", r.Name)
+	}
+
+	var first, last sql.NullInt64
+	var dataAsOf sql.NullString
+	if err := sqlDB.QueryRowContext(ctx, `
+		SELECT MIN(wahlperiode), MAX(wahlperiode), MAX(aktualisiert)
+		FROM vorgang WHERE deleted_at IS NULL`).Scan(&first, &last, &dataAsOf); err != nil {
+		return nil, fmt.Errorf("failed to read the Wahlperioden: %w", err)
+	}
+	if params.WahlperiodeFrom == 0 {
+		params.WahlperiodeFrom = int(first.Int64)
+	}
+	if params.WahlperiodeTo == 0 {
+		params.WahlperiodeTo = int(last.Int64)
+	}
+	if params.WahlperiodeFrom > params.WahlperiodeTo {
+		return nil, fmt.Errorf("invalid Wahlperiode range %d-%d", params.WahlperiodeFrom, params.WahlperiodeTo)
+	}
+	fraktionen, err := json.Marshal(append([]string{}, params.Fraktionen...))
+	if err != nil {
+		return nil, err
+	}
+	args := []any{
+		sql.Named("wp_from", params.WahlperiodeFrom),
+		sql.Named("wp_to", params.WahlperiodeTo),
+		sql.Named("fraktionen", string(fraktionen)),
+	}
+
+	result := &ReportResult{
+		Report:      r.Name,
+		Title:       r.Title,
+		Version:     r.Version,
+		Checksum:    r.Checksum,
+		Description: r.Description,
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
+		DataAsOf:    dataAsOf.String,
+		Params:      params,
+	}
+	for _, s := range r.Sections {
+		table, err := runSection(ctx, sqlDB, s, args)
+		if err != nil {
+			return nil, fmt.Errorf("report %s, section %q: %w", r.Name, s.Title, err)
+		}
+		result.Sections = append(result.Sections, table)
+	}
+	return result, nil
+}
+
+func runSection(ctx context.Context, sqlDB *sql.DB, s Section, args []any) (Table, error) {
+	// Only the parameters of the query may be bound
+	var used []any
+	for _, arg := range args {
+		if strings.Contains(s.Query, ":"+arg.(sql.NamedArg).Name) {
+			used = append(used, arg)
+		}
+	}
+	rows, err := sqlDB.QueryContext(ctx, s.Query, used...)
+	if err != nil {
+		return Table{}, err
+	}
+	defer rows.Close()
+
+	table := Table{Title: s.Title, Rows: [][]any{}}
+	if table.Columns, err = rows.Columns(); err != nil {
+		return Table{}, err
+	}
+	for rows.Next() {
+		values := make([]any, len(table.Columns))
+		dest := make([]any, len(values))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return Table{}, err
+		}
+		for i, v := range values {
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		table.Rows = append(table.Rows, values)
+	}
+	return table, rows.Err()
+}
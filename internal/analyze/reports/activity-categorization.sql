@@ -0,0 +1,350 @@
+-- title: Activity categorization
+-- version: 1
+-- params: wahlperiode, fraktion
+-- source: docu/activity_categorization_analysis.sql
+-- Aktivitäten grouped into functional categories (written and oral questions,
+-- speeches, legislative proposals, ...) over time, by Fraktion and person, and
+-- activity profiles of persons with at least 100 Aktivitäten.
+-- Aktivitäten are titled "Name, MdB, Fraktion"; with a Fraktion set, only the
+-- Aktivitäten of those Fraktionen are counted.
+
+-- section: Aktivitätsarten
+SELECT
+    aktivitaetsart,
+    COUNT(*) AS aktivitaeten,
+    ROUND(100.0 * COUNT(*) / SUM(COUNT(*)) OVER (), 2) AS pct
+FROM aktivitaet
+WHERE deleted_at IS NULL
+      AND wahlperiode BETWEEN :wp_from AND :wp_to
+      AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE titel LIKE '%' || f.value || '%'))
+GROUP BY aktivitaetsart
+ORDER BY aktivitaeten DESC, aktivitaetsart;
+
+-- section: Functional categories
+WITH kategorie(aktivitaetsart, kategorie) AS (
+    VALUES
+        ('Kleine Anfrage', 'Written Questions'),
+        ('Große Anfrage', 'Written Questions'),
+        ('Antwort', 'Government Responses'),
+        ('Beantwortung', 'Government Responses'),
+        ('Berichterstattung und Beantwortung', 'Government Responses'),
+        ('Einleitende Ausführungen und Beantwortung', 'Government Responses'),
+        ('Frage', 'Oral Questions'),
+        ('Zusatzfrage', 'Oral Questions'),
+        ('Zwischenfrage', 'Oral Questions'),
+        ('Gesetzentwurf', 'Legislative Proposals'),
+        ('Antrag', 'Legislative Proposals'),
+        ('Entschließungsantrag', 'Legislative Proposals'),
+        ('Änderungsantrag', 'Legislative Proposals'),
+        ('Rede', 'Parliamentary Speeches'),
+        ('Rede (zu Protokoll gegeben)', 'Parliamentary Speeches'),
+        ('Wortbeitrag', 'Parliamentary Speeches'),
+        ('Erwiderung', 'Parliamentary Speeches'),
+        ('Kurzintervention', 'Parliamentary Speeches'),
+        ('Erklärung zum Plenarprotokoll', 'Formal Declarations'),
+        ('Erklärung zur Aussprache gem. § 30 Geschäftsordnung BT', 'Formal Declarations'),
+        ('Erklärung zum Vermittlungsverfahren (§91 GO-BT, §10 GO-VermA)', 'Formal Declarations'),
+        ('Mündliche Erklärung gem. § 31 Geschäftsordnung BT', 'Formal Declarations'),
+        ('Persönliche Erklärung gem. § 32 Geschäftsordnung BT', 'Formal Declarations'),
+        ('Schriftliche Erklärung gem. § 31 Geschäftsordnung BT', 'Formal Declarations'),
+        ('Zur Geschäftsordnung BT', 'Procedural Interventions'),
+        ('Zur Geschäftsordnung BR', 'Procedural Interventions'),
+        ('Berichterstattung', 'Committee Reports'),
+        ('Berichterstattung (zu Protokoll gegeben)', 'Committee Reports'),
+        ('Unterrichtung', 'Committee Reports'),
+        ('Wahlvorschläge', 'Administrative Actions')
+),
+aktivitaeten AS (
+    SELECT a.*, COALESCE(k.kategorie, 'Other') AS kategorie
+    FROM aktivitaet a
+    LEFT JOIN kategorie k ON k.aktivitaetsart = a.aktivitaetsart
+    WHERE a.deleted_at IS NULL
+      AND a.wahlperiode BETWEEN :wp_from AND :wp_to
+      AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE a.titel LIKE '%' || f.value || '%'))
+)
+SELECT
+    kategorie,
+    COUNT(*) AS aktivitaeten,
+    ROUND(100.0 * COUNT(*) / SUM(COUNT(*)) OVER (), 2) AS pct,
+    GROUP_CONCAT(DISTINCT aktivitaetsart) AS aktivitaetsarten
+FROM aktivitaeten
+GROUP BY kategorie
+ORDER BY aktivitaeten DESC, kategorie;
+
+-- section: Categories per Wahlperiode
+WITH kategorie(aktivitaetsart, kategorie) AS (
+    VALUES
+        ('Kleine Anfrage', 'Written Questions'),
+        ('Große Anfrage', 'Written Questions'),
+        ('Antwort', 'Government Responses'),
+        ('Beantwortung', 'Government Responses'),
+        ('Berichterstattung und Beantwortung', 'Government Responses'),
+        ('Einleitende Ausführungen und Beantwortung', 'Government Responses'),
+        ('Frage', 'Oral Questions'),
+        ('Zusatzfrage', 'Oral Questions'),
+        ('Zwischenfrage', 'Oral Questions'),
+        ('Gesetzentwurf', 'Legislative Proposals'),
+        ('Antrag', 'Legislative Proposals'),
+        ('Entschließungsantrag', 'Legislative Proposals'),
+        ('Änderungsantrag', 'Legislative Proposals'),
+        ('Rede', 'Parliamentary Speeches'),
+        ('Rede (zu Protokoll gegeben)', 'Parliamentary Speeches'),
+        ('Wortbeitrag', 'Parliamentary Speeches'),
+        ('Erwiderung', 'Parliamentary Speeches'),
+        ('Kurzintervention', 'Parliamentary Speeches'),
+        ('Erklärung zum Plenarprotokoll', 'Formal Declarations'),
+        ('Erklärung zur Aussprache gem. § 30 Geschäftsordnung BT', 'Formal Declarations'),
+        ('Erklärung zum Vermittlungsverfahren (§91 GO-BT, §10 GO-VermA)', 'Formal Declarations'),
+        ('Mündliche Erklärung gem. § 31 Geschäftsordnung BT', 'Formal Declarations'),
+        ('Persönliche Erklärung gem. § 32 Geschäftsordnung BT', 'Formal Declarations'),
+        ('Schriftliche Erklärung gem. § 31 Geschäftsordnung BT', 'Formal Declarations'),
+        ('Zur Geschäftsordnung BT', 'Procedural Interventions'),
+        ('Zur Geschäftsordnung BR', 'Procedural Interventions'),
+        ('Berichterstattung', 'Committee Reports'),
+        ('Berichterstattung (zu Protokoll gegeben)', 'Committee Reports'),
+        ('Unterrichtung', 'Committee Reports'),
+        ('Wahlvorschläge', 'Administrative Actions')
+),
+aktivitaeten AS (
+    SELECT a.*, COALESCE(k.kategorie, 'Other') AS kategorie
+    FROM aktivitaet a
+    LEFT JOIN kategorie k ON k.aktivitaetsart = a.aktivitaetsart
+    WHERE a.deleted_at IS NULL
+      AND a.wahlperiode BETWEEN :wp_from AND :wp_to
+      AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE a.titel LIKE '%' || f.value || '%'))
+)
+SELECT
+    wahlperiode,
+    kategorie,
+    COUNT(*) AS aktivitaeten,
+    ROUND(100.0 * COUNT(*) / SUM(COUNT(*)) OVER (PARTITION BY wahlperiode), 2) AS pct_of_wahlperiode
+FROM aktivitaeten
+GROUP BY wahlperiode, kategorie
+ORDER BY wahlperiode, aktivitaeten DESC, kategorie;
+
+-- section: Categories by Fraktion
+WITH kategorie(aktivitaetsart, kategorie) AS (
+    VALUES
+        ('Kleine Anfrage', 'Written Questions'),
+        ('Große Anfrage', 'Written Questions'),
+        ('Antwort', 'Government Responses'),
+        ('Beantwortung', 'Government Responses'),
+        ('Berichterstattung und Beantwortung', 'Government Responses'),
+        ('Einleitende Ausführungen und Beantwortung', 'Government Responses'),
+        ('Frage', 'Oral Questions'),
+        ('Zusatzfrage', 'Oral Questions'),
+        ('Zwischenfrage', 'Oral Questions'),
+        ('Gesetzentwurf', 'Legislative Proposals'),
+        ('Antrag', 'Legislative Proposals'),
+        ('Entschließungsantrag', 'Legislative Proposals'),
+        ('Änderungsantrag', 'Legislative Proposals'),
+        ('Rede', 'Parliamentary Speeches'),
+        ('Rede (zu Protokoll gegeben)', 'Parliamentary Speeches'),
+        ('Wortbeitrag', 'Parliamentary Speeches'),
+        ('Erwiderung', 'Parliamentary Speeches'),
+        ('Kurzintervention', 'Parliamentary Speeches'),
+        ('Erklärung zum Plenarprotokoll', 'Formal Declarations'),
+        ('Erklärung zur Aussprache gem. § 30 Geschäftsordnung BT', 'Formal Declarations'),
+        ('Erklärung zum Vermittlungsverfahren (§91 GO-BT, §10 GO-VermA)', 'Formal Declarations'),
+        ('Mündliche Erklärung gem. § 31 Geschäftsordnung BT', 'Formal Declarations'),
+        ('Persönliche Erklärung gem. § 32 Geschäftsordnung BT', 'Formal Declarations'),
+        ('Schriftliche Erklärung gem. § 31 Geschäftsordnung BT', 'Formal Declarations'),
+        ('Zur Geschäftsordnung BT', 'Procedural Interventions'),
+        ('Zur Geschäftsordnung BR', 'Procedural Interventions'),
+        ('Berichterstattung', 'Committee Reports'),
+        ('Berichterstattung (zu Protokoll gegeben)', 'Committee Reports'),
+        ('Unterrichtung', 'Committee Reports'),
+        ('Wahlvorschläge', 'Administrative Actions')
+),
+aktivitaeten AS (
+    SELECT a.*, COALESCE(k.kategorie, 'Other') AS kategorie
+    FROM aktivitaet a
+    LEFT JOIN kategorie k ON k.aktivitaetsart = a.aktivitaetsart
+    WHERE a.deleted_at IS NULL
+      AND a.wahlperiode BETWEEN :wp_from AND :wp_to
+      AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE a.titel LIKE '%' || f.value || '%'))
+      AND a.titel LIKE '%MdB%'
+),
+counts AS (
+    SELECT
+        CASE
+            WHEN titel LIKE '%AfD%' THEN 'AfD'
+            WHEN titel LIKE '%DIE LINKE%' OR titel LIKE '%Die Linke%' OR titel LIKE '%PDS%' THEN 'DIE LINKE'
+            WHEN titel LIKE '%GRÜNE%' THEN 'GRÜNE'
+            WHEN titel LIKE '%SPD%' THEN 'SPD'
+            WHEN titel LIKE '%CDU%' OR titel LIKE '%CSU%' THEN 'CDU/CSU'
+            WHEN titel LIKE '%FDP%' THEN 'FDP'
+            WHEN titel LIKE '%BSW%' THEN 'BSW'
+            ELSE 'Sonstige'
+        END AS fraktion,
+        kategorie,
+        COUNT(*) AS aktivitaeten
+    FROM aktivitaeten
+    GROUP BY fraktion, kategorie
+)
+SELECT
+    fraktion,
+    kategorie,
+    aktivitaeten,
+    ROUND(100.0 * aktivitaeten / SUM(aktivitaeten) OVER (PARTITION BY fraktion), 2) AS pct_of_fraktion
+FROM counts
+ORDER BY fraktion, aktivitaeten DESC, kategorie;
+
+-- section: Top persons per category
+WITH kategorie(aktivitaetsart, kategorie) AS (
+    VALUES
+        ('Kleine Anfrage', 'Written Questions'),
+        ('Große Anfrage', 'Written Questions'),
+        ('Antwort', 'Government Responses'),
+        ('Beantwortung', 'Government Responses'),
+        ('Berichterstattung und Beantwortung', 'Government Responses'),
+        ('Einleitende Ausführungen und Beantwortung', 'Government Responses'),
+        ('Frage', 'Oral Questions'),
+        ('Zusatzfrage', 'Oral Questions'),
+        ('Zwischenfrage', 'Oral Questions'),
+        ('Gesetzentwurf', 'Legislative Proposals'),
+        ('Antrag', 'Legislative Proposals'),
+        ('Entschließungsantrag', 'Legislative Proposals'),
+        ('Änderungsantrag', 'Legislative Proposals'),
+        ('Rede', 'Parliamentary Speeches'),
+        ('Rede (zu Protokoll gegeben)', 'Parliamentary Speeches'),
+        ('Wortbeitrag', 'Parliamentary Speeches'),
+        ('Erwiderung', 'Parliamentary Speeches'),
+        ('Kurzintervention', 'Parliamentary Speeches'),
+        ('Erklärung zum Plenarprotokoll', 'Formal Declarations'),
+        ('Erklärung zur Aussprache gem. § 30 Geschäftsordnung BT', 'Formal Declarations'),
+        ('Erklärung zum Vermittlungsverfahren (§91 GO-BT, §10 GO-VermA)', 'Formal Declarations'),
+        ('Mündliche Erklärung gem. § 31 Geschäftsordnung BT', 'Formal Declarations'),
+        ('Persönliche Erklärung gem. § 32 Geschäftsordnung BT', 'Formal Declarations'),
+        ('Schriftliche Erklärung gem. § 31 Geschäftsordnung BT', 'Formal Declarations'),
+        ('Zur Geschäftsordnung BT', 'Procedural Interventions'),
+        ('Zur Geschäftsordnung BR', 'Procedural Interventions'),
+        ('Berichterstattung', 'Committee Reports'),
+        ('Berichterstattung (zu Protokoll gegeben)', 'Committee Reports'),
+        ('Unterrichtung', 'Committee Reports'),
+        ('Wahlvorschläge', 'Administrative Actions')
+),
+aktivitaeten AS (
+    SELECT a.*, COALESCE(k.kategorie, 'Other') AS kategorie
+    FROM aktivitaet a
+    LEFT JOIN kategorie k ON k.aktivitaetsart = a.aktivitaetsart
+    WHERE a.deleted_at IS NULL
+      AND a.wahlperiode BETWEEN :wp_from AND :wp_to
+      AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE a.titel LIKE '%' || f.value || '%'))
+      AND a.titel LIKE '%MdB%'
+),
+ranked AS (
+    SELECT
+        kategorie,
+        titel AS person,
+        COUNT(*) AS aktivitaeten,
+        COUNT(DISTINCT wahlperiode) AS wahlperioden,
+        MIN(datum) AS first_activity,
+        MAX(datum) AS last_activity,
+        ROW_NUMBER() OVER (PARTITION BY kategorie ORDER BY COUNT(*) DESC, titel) AS rang
+    FROM aktivitaeten
+    WHERE kategorie IN ('Written Questions', 'Oral Questions', 'Parliamentary Speeches', 'Legislative Proposals')
+    GROUP BY kategorie, titel
+)
+SELECT kategorie, rang, person, aktivitaeten, wahlperioden, first_activity, last_activity
+FROM ranked
+WHERE rang <= 20
+ORDER BY kategorie, rang;
+
+-- section: Activity profiles
+WITH person_profiles AS (
+    SELECT
+        titel AS person,
+        COUNT(*) AS aktivitaeten,
+        ROUND(100.0 * SUM(aktivitaetsart IN ('Kleine Anfrage', 'Große Anfrage')) / COUNT(*), 1) AS pct_written_questions,
+        ROUND(100.0 * SUM(aktivitaetsart IN ('Frage', 'Zusatzfrage', 'Zwischenfrage')) / COUNT(*), 1) AS pct_oral_questions,
+        ROUND(100.0 * SUM(aktivitaetsart IN ('Rede', 'Rede (zu Protokoll gegeben)', 'Wortbeitrag', 'Erwiderung', 'Kurzintervention')) / COUNT(*), 1) AS pct_speeches,
+        ROUND(100.0 * SUM(aktivitaetsart IN ('Gesetzentwurf', 'Antrag', 'Entschließungsantrag', 'Änderungsantrag')) / COUNT(*), 1) AS pct_legislative,
+        CASE
+            WHEN 100.0 * SUM(aktivitaetsart IN ('Kleine Anfrage', 'Große Anfrage')) / COUNT(*) > 70 THEN 'Question Specialist'
+            WHEN 100.0 * SUM(aktivitaetsart IN ('Antwort', 'Beantwortung')) / COUNT(*) > 70 THEN 'Government Responder'
+            WHEN 100.0 * SUM(aktivitaetsart IN ('Rede', 'Rede (zu Protokoll gegeben)', 'Wortbeitrag')) / COUNT(*) > 50 THEN 'Speech Specialist'
+            WHEN 100.0 * SUM(aktivitaetsart IN ('Frage', 'Zusatzfrage', 'Zwischenfrage')) / COUNT(*) > 40 THEN 'Oral Questioner'
+            WHEN 100.0 * SUM(aktivitaetsart IN ('Gesetzentwurf', 'Antrag', 'Entschließungsantrag', 'Änderungsantrag')) / COUNT(*) > 40 THEN 'Legislative Drafter'
+            ELSE 'Generalist'
+        END AS profile
+    FROM aktivitaet
+    WHERE deleted_at IS NULL
+      AND wahlperiode BETWEEN :wp_from AND :wp_to
+      AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE titel LIKE '%' || f.value || '%'))
+      AND titel LIKE '%MdB%'
+    GROUP BY titel
+    HAVING COUNT(*) >= 100
+)
+SELECT
+    profile,
+    COUNT(*) AS persons,
+    ROUND(AVG(aktivitaeten), 1) AS avg_aktivitaeten,
+    ROUND(AVG(pct_written_questions), 1) AS avg_pct_written_questions,
+    ROUND(AVG(pct_oral_questions), 1) AS avg_pct_oral_questions,
+    ROUND(AVG(pct_speeches), 1) AS avg_pct_speeches,
+    ROUND(AVG(pct_legislative), 1) AS avg_pct_legislative
+FROM person_profiles
+GROUP BY profile
+ORDER BY persons DESC, profile;
+
+-- section: Examples per activity profile
+WITH person_profiles AS (
+    SELECT
+        titel AS person,
+        COUNT(*) AS aktivitaeten,
+        ROUND(100.0 * SUM(aktivitaetsart IN ('Kleine Anfrage', 'Große Anfrage')) / COUNT(*), 1) AS pct_written_questions,
+        ROUND(100.0 * SUM(aktivitaetsart IN ('Frage', 'Zusatzfrage', 'Zwischenfrage')) / COUNT(*), 1) AS pct_oral_questions,
+        ROUND(100.0 * SUM(aktivitaetsart IN ('Rede', 'Rede (zu Protokoll gegeben)', 'Wortbeitrag', 'Erwiderung', 'Kurzintervention')) / COUNT(*), 1) AS pct_speeches,
+        ROUND(100.0 * SUM(aktivitaetsart IN ('Gesetzentwurf', 'Antrag', 'Entschließungsantrag', 'Änderungsantrag')) / COUNT(*), 1) AS pct_legislative,
+        CASE
+            WHEN 100.0 * SUM(aktivitaetsart IN ('Kleine Anfrage', 'Große Anfrage')) / COUNT(*) > 70 THEN 'Question Specialist'
+            WHEN 100.0 * SUM(aktivitaetsart IN ('Antwort', 'Beantwortung')) / COUNT(*) > 70 THEN 'Government Responder'
+            WHEN 100.0 * SUM(aktivitaetsart IN ('Rede', 'Rede (zu Protokoll gegeben)', 'Wortbeitrag')) / COUNT(*) > 50 THEN 'Speech Specialist'
+            WHEN 100.0 * SUM(aktivitaetsart IN ('Frage', 'Zusatzfrage', 'Zwischenfrage')) / COUNT(*) > 40 THEN 'Oral Questioner'
+            WHEN 100.0 * SUM(aktivitaetsart IN ('Gesetzentwurf', 'Antrag', 'Entschließungsantrag', 'Änderungsantrag')) / COUNT(*) > 40 THEN 'Legislative Drafter'
+            ELSE 'Generalist'
+        END AS profile
+    FROM aktivitaet
+    WHERE deleted_at IS NULL
+      AND wahlperiode BETWEEN :wp_from AND :wp_to
+      AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE titel LIKE '%' || f.value || '%'))
+      AND titel LIKE '%MdB%'
+    GROUP BY titel
+    HAVING COUNT(*) >= 100
+),
+ranked AS (
+    SELECT profile, person, aktivitaeten,
+        ROW_NUMBER() OVER (PARTITION BY profile ORDER BY aktivitaeten DESC, person) AS rang
+    FROM person_profiles
+)
+SELECT profile, rang, person, aktivitaeten
+FROM ranked
+WHERE rang <= 3
+ORDER BY profile, rang;
+
+-- section: Specialization
+WITH person_types AS (
+    SELECT titel, COUNT(*) AS aktivitaeten, COUNT(DISTINCT aktivitaetsart) AS aktivitaetsarten
+    FROM aktivitaet
+    WHERE deleted_at IS NULL
+      AND wahlperiode BETWEEN :wp_from AND :wp_to
+      AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE titel LIKE '%' || f.value || '%'))
+      AND titel LIKE '%MdB%'
+    GROUP BY titel
+    HAVING COUNT(*) >= 100
+)
+SELECT
+    CASE
+        WHEN aktivitaetsarten <= 2 THEN '1. Extreme Specialist (1-2 types)'
+        WHEN aktivitaetsarten <= 4 THEN '2. Specialist (3-4 types)'
+        WHEN aktivitaetsarten <= 7 THEN '3. Moderate (5-7 types)'
+        WHEN aktivitaetsarten <= 10 THEN '4. Generalist (8-10 types)'
+        ELSE '5. Extreme Generalist (11+ types)'
+    END AS specialization,
+    COUNT(*) AS persons,
+    ROUND(AVG(aktivitaeten), 1) AS avg_aktivitaeten,
+    ROUND(AVG(aktivitaetsarten), 1) AS avg_aktivitaetsarten
+FROM person_types
+GROUP BY specialization
+ORDER BY specialization;
@@ -0,0 +1,108 @@
+-- title: Collaboration champions
+-- version: 1
+-- params: wahlperiode, fraktion
+-- source: docu/individual_collaboration_champions_analysis.sql
+-- The most active Abgeordnete in Kleine Anfragen, Reden and Anträge, and who
+-- takes part in Vorgänge initiated jointly by several Fraktionen.
+-- Aktivitäten are titled "Name, MdB, Fraktion"; with a Fraktion set, only the
+-- Aktivitäten and Funktionen of those Fraktionen are counted.
+
+-- section: Most active persons
+SELECT
+    a.titel AS person,
+    a.wahlperiode,
+    COUNT(*) AS aktivitaeten,
+    (SELECT COUNT(DISTINCT av.vorgang_id)
+     FROM aktivitaet_vorgangsbezug av
+     JOIN aktivitaet a2 ON a2.id = av.aktivitaet_id
+     WHERE a2.titel = a.titel AND a2.wahlperiode = a.wahlperiode AND a2.deleted_at IS NULL) AS vorgaenge,
+    SUM(a.aktivitaetsart = 'Kleine Anfrage') AS kleine_anfragen,
+    SUM(a.aktivitaetsart = 'Rede') AS reden,
+    SUM(a.aktivitaetsart = 'Antrag') AS antraege
+FROM aktivitaet a
+WHERE a.deleted_at IS NULL
+  AND a.titel LIKE '%, MdB, %'
+  AND a.wahlperiode BETWEEN :wp_from AND :wp_to
+  AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE a.titel LIKE '%' || f.value || '%'))
+GROUP BY a.titel, a.wahlperiode
+ORDER BY a.wahlperiode DESC, aktivitaeten DESC, person
+LIMIT 40;
+
+-- section: Top Kleine Anfragen per Wahlperiode
+WITH ranked AS (
+    SELECT
+        wahlperiode,
+        titel AS person,
+        COUNT(*) AS kleine_anfragen,
+        ROW_NUMBER() OVER (PARTITION BY wahlperiode ORDER BY COUNT(*) DESC, titel) AS rang
+    FROM aktivitaet
+    WHERE deleted_at IS NULL
+      AND aktivitaetsart = 'Kleine Anfrage'
+      AND titel LIKE '%, MdB, %'
+      AND wahlperiode BETWEEN :wp_from AND :wp_to
+      AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE titel LIKE '%' || f.value || '%'))
+    GROUP BY wahlperiode, titel
+)
+SELECT wahlperiode, rang, person, kleine_anfragen
+FROM ranked
+WHERE rang <= 10
+ORDER BY wahlperiode DESC, rang;
+
+-- section: Top Reden per Wahlperiode
+WITH ranked AS (
+    SELECT
+        wahlperiode,
+        titel AS person,
+        COUNT(*) AS reden,
+        ROW_NUMBER() OVER (PARTITION BY wahlperiode ORDER BY COUNT(*) DESC, titel) AS rang
+    FROM aktivitaet
+    WHERE deleted_at IS NULL
+      AND aktivitaetsart = 'Rede'
+      AND titel LIKE '%, MdB, %'
+      AND wahlperiode BETWEEN :wp_from AND :wp_to
+      AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE titel LIKE '%' || f.value || '%'))
+    GROUP BY wahlperiode, titel
+)
+SELECT wahlperiode, rang, person, reden
+FROM ranked
+WHERE rang <= 10
+ORDER BY wahlperiode DESC, rang;
+
+-- section: Persons in joint Fraktion Vorgänge
+WITH joint_vorgaenge AS (
+    SELECT vorgang_id
+    FROM vorgang_initiative
+    WHERE initiative LIKE 'Fraktion%'
+    GROUP BY vorgang_id
+    HAVING COUNT(DISTINCT initiative) > 1
+)
+SELECT
+    a.titel AS person,
+    a.wahlperiode,
+    COUNT(DISTINCT av.vorgang_id) AS joint_vorgaenge,
+    COUNT(DISTINCT a.id) AS aktivitaeten
+FROM aktivitaet a
+JOIN aktivitaet_vorgangsbezug av ON av.aktivitaet_id = a.id
+JOIN joint_vorgaenge jv ON jv.vorgang_id = av.vorgang_id
+WHERE a.deleted_at IS NULL
+  AND a.titel LIKE '%, MdB, %'
+  AND a.wahlperiode BETWEEN :wp_from AND :wp_to
+  AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE a.titel LIKE '%' || f.value || '%'))
+GROUP BY a.titel, a.wahlperiode
+ORDER BY joint_vorgaenge DESC, a.wahlperiode DESC, person
+LIMIT 30;
+
+-- section: Funktionen of persons
+SELECT
+    pr.funktion,
+    COUNT(DISTINCT pr.person_id) AS persons
+FROM person_role pr
+JOIN person p ON p.id = pr.person_id
+WHERE p.deleted_at IS NULL
+  AND EXISTS (
+      SELECT 1 FROM person_wahlperiode pw
+      WHERE pw.person_id = p.id AND pw.wahlperiode_nummer BETWEEN :wp_from AND :wp_to)
+  AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE pr.fraktion LIKE '%' || f.value || '%'))
+GROUP BY pr.funktion
+ORDER BY persons DESC, pr.funktion
+LIMIT 20;
@@ -0,0 +1,103 @@
+-- title: Collaboration continuity
+-- version: 1
+-- params: wahlperiode, fraktion
+-- source: docu/collaboration_continuity_analysis.sql
+-- How often the Drucksachen of Vorgänge with several Initiativen are also
+-- joint Drucksachen of several Fraktionen. With a Fraktion set, only Vorgänge
+-- initiated by one of the Fraktionen are counted.
+
+-- section: Continuity per Wahlperiode
+WITH joint_vorgaenge AS (
+    SELECT v.id AS vorgang_id, v.wahlperiode
+    FROM vorgang v
+    JOIN vorgang_initiative vi ON vi.vorgang_id = v.id
+    WHERE v.deleted_at IS NULL
+      AND v.wahlperiode BETWEEN :wp_from AND :wp_to
+    GROUP BY v.id, v.wahlperiode
+    HAVING COUNT(DISTINCT vi.initiative) > 1
+       AND (:fraktionen = '[]' OR SUM(EXISTS (
+           SELECT 1 FROM json_each(:fraktionen) f WHERE vi.initiative LIKE '%' || f.value || '%')) > 0)
+),
+joint_fraktion_drucksachen AS (
+    SELECT du.drucksache_id AS id
+    FROM drucksache_urheber du
+    JOIN urheber u ON u.id = du.urheber_id
+    WHERE u.titel LIKE 'Fraktion%'
+    GROUP BY du.drucksache_id
+    HAVING COUNT(DISTINCT u.bezeichnung) > 1
+)
+SELECT
+    jv.wahlperiode,
+    COUNT(DISTINCT jv.vorgang_id) AS joint_vorgaenge,
+    COUNT(DISTINCT d.id) AS drucksachen,
+    COUNT(DISTINCT jfd.id) AS joint_fraktion_drucksachen,
+    ROUND(100.0 * COUNT(DISTINCT jfd.id) / NULLIF(COUNT(DISTINCT d.id), 0), 2) AS continuity_pct
+FROM joint_vorgaenge jv
+LEFT JOIN drucksache_vorgangsbezug dv ON dv.vorgang_id = jv.vorgang_id
+LEFT JOIN drucksache d ON d.id = dv.drucksache_id AND d.deleted_at IS NULL
+LEFT JOIN joint_fraktion_drucksachen jfd ON jfd.id = d.id
+GROUP BY jv.wahlperiode
+ORDER BY jv.wahlperiode;
+
+-- section: Continuity by Vorgangstyp
+WITH joint_vorgaenge AS (
+    SELECT v.id AS vorgang_id, v.vorgangstyp
+    FROM vorgang v
+    JOIN vorgang_initiative vi ON vi.vorgang_id = v.id
+    WHERE v.deleted_at IS NULL
+      AND v.wahlperiode BETWEEN :wp_from AND :wp_to
+    GROUP BY v.id, v.vorgangstyp
+    HAVING COUNT(DISTINCT vi.initiative) > 1
+       AND (:fraktionen = '[]' OR SUM(EXISTS (
+           SELECT 1 FROM json_each(:fraktionen) f WHERE vi.initiative LIKE '%' || f.value || '%')) > 0)
+),
+joint_fraktion_drucksachen AS (
+    SELECT du.drucksache_id AS id
+    FROM drucksache_urheber du
+    JOIN urheber u ON u.id = du.urheber_id
+    WHERE u.titel LIKE 'Fraktion%'
+    GROUP BY du.drucksache_id
+    HAVING COUNT(DISTINCT u.bezeichnung) > 1
+)
+SELECT
+    jv.vorgangstyp,
+    COUNT(DISTINCT d.id) AS drucksachen,
+    COUNT(DISTINCT jfd.id) AS joint_fraktion_drucksachen,
+    ROUND(100.0 * COUNT(DISTINCT jfd.id) / COUNT(DISTINCT d.id), 2) AS continuity_pct
+FROM joint_vorgaenge jv
+JOIN drucksache_vorgangsbezug dv ON dv.vorgang_id = jv.vorgang_id
+JOIN drucksache d ON d.id = dv.drucksache_id AND d.deleted_at IS NULL
+LEFT JOIN joint_fraktion_drucksachen jfd ON jfd.id = d.id
+GROUP BY jv.vorgangstyp
+HAVING COUNT(DISTINCT d.id) >= 10
+ORDER BY continuity_pct DESC, jv.vorgangstyp;
+
+-- section: Overall continuity
+WITH joint_vorgaenge AS (
+    SELECT v.id AS vorgang_id
+    FROM vorgang v
+    JOIN vorgang_initiative vi ON vi.vorgang_id = v.id
+    WHERE v.deleted_at IS NULL
+      AND v.wahlperiode BETWEEN :wp_from AND :wp_to
+    GROUP BY v.id
+    HAVING COUNT(DISTINCT vi.initiative) > 1
+       AND (:fraktionen = '[]' OR SUM(EXISTS (
+           SELECT 1 FROM json_each(:fraktionen) f WHERE vi.initiative LIKE '%' || f.value || '%')) > 0)
+),
+joint_fraktion_drucksachen AS (
+    SELECT du.drucksache_id AS id
+    FROM drucksache_urheber du
+    JOIN urheber u ON u.id = du.urheber_id
+    WHERE u.titel LIKE 'Fraktion%'
+    GROUP BY du.drucksache_id
+    HAVING COUNT(DISTINCT u.bezeichnung) > 1
+)
+SELECT
+    COUNT(DISTINCT jv.vorgang_id) AS joint_vorgaenge,
+    COUNT(DISTINCT d.id) AS drucksachen,
+    COUNT(DISTINCT jfd.id) AS joint_fraktion_drucksachen,
+    ROUND(100.0 * COUNT(DISTINCT jfd.id) / NULLIF(COUNT(DISTINCT d.id), 0), 2) AS continuity_pct
+FROM joint_vorgaenge jv
+LEFT JOIN drucksache_vorgangsbezug dv ON dv.vorgang_id = jv.vorgang_id
+LEFT JOIN drucksache d ON d.id = dv.drucksache_id AND d.deleted_at IS NULL
+LEFT JOIN joint_fraktion_drucksachen jfd ON jfd.id = d.id;
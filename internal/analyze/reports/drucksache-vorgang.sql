@@ -0,0 +1,110 @@
+-- title: Drucksache-Vorgang relationship
+-- version: 1
+-- params: wahlperiode
+-- source: docu/drucksache_vorgang_relationship_analysis.sql
+-- How Drucksachen are linked to Vorgänge through their Vorgangsbezüge, and
+-- which Drucksachen cite each other in their texts (process-dokument-zitate)
+-- without a common Vorgang.
+
+-- section: Coverage per Wahlperiode
+SELECT
+    d.wahlperiode,
+    COUNT(*) AS drucksachen,
+    SUM(EXISTS (SELECT 1 FROM drucksache_vorgangsbezug dv WHERE dv.drucksache_id = d.id)) AS with_vorgang,
+    ROUND(100.0 * SUM(EXISTS (SELECT 1 FROM drucksache_vorgangsbezug dv WHERE dv.drucksache_id = d.id)) / COUNT(*), 2) AS pct_with_vorgang,
+    (SELECT COUNT(*) FROM vorgang v
+     WHERE v.deleted_at IS NULL AND v.wahlperiode = d.wahlperiode) AS vorgaenge,
+    (SELECT COUNT(*) FROM vorgang v
+     WHERE v.deleted_at IS NULL AND v.wahlperiode = d.wahlperiode
+       AND EXISTS (SELECT 1 FROM drucksache_vorgangsbezug dv WHERE dv.vorgang_id = v.id)) AS vorgaenge_with_drucksache
+FROM drucksache d
+WHERE d.deleted_at IS NULL
+  AND d.wahlperiode BETWEEN :wp_from AND :wp_to
+GROUP BY d.wahlperiode
+ORDER BY d.wahlperiode;
+
+-- section: Vorgänge per Drucksache
+SELECT
+    vorgaenge,
+    COUNT(*) AS drucksachen,
+    ROUND(100.0 * COUNT(*) / SUM(COUNT(*)) OVER (), 2) AS pct
+FROM (
+    SELECT dv.drucksache_id, COUNT(*) AS vorgaenge
+    FROM drucksache_vorgangsbezug dv
+    JOIN drucksache d ON d.id = dv.drucksache_id
+    WHERE d.deleted_at IS NULL
+      AND d.wahlperiode BETWEEN :wp_from AND :wp_to
+    GROUP BY dv.drucksache_id
+)
+GROUP BY vorgaenge
+ORDER BY vorgaenge
+LIMIT 15;
+
+-- section: Linkage by Drucksachetyp
+SELECT
+    d.drucksachetyp,
+    COUNT(*) AS drucksachen,
+    SUM(EXISTS (SELECT 1 FROM drucksache_vorgangsbezug dv WHERE dv.drucksache_id = d.id)) AS with_vorgang,
+    ROUND(100.0 * SUM(EXISTS (SELECT 1 FROM drucksache_vorgangsbezug dv WHERE dv.drucksache_id = d.id)) / COUNT(*), 2) AS pct_with_vorgang
+FROM drucksache d
+WHERE d.deleted_at IS NULL
+  AND d.wahlperiode BETWEEN :wp_from AND :wp_to
+GROUP BY d.drucksachetyp
+ORDER BY drucksachen DESC, d.drucksachetyp
+LIMIT 15;
+
+-- section: Vorgangstypen of joint Fraktion Drucksachen
+WITH joint_drucksachen AS (
+    SELECT du.drucksache_id
+    FROM drucksache_urheber du
+    JOIN urheber u ON u.id = du.urheber_id
+    JOIN drucksache d ON d.id = du.drucksache_id
+    WHERE d.deleted_at IS NULL
+      AND d.wahlperiode BETWEEN :wp_from AND :wp_to
+      AND u.titel LIKE 'Fraktion%'
+    GROUP BY du.drucksache_id
+    HAVING COUNT(DISTINCT du.urheber_id) > 1
+)
+SELECT
+    COALESCE(dv.vorgangstyp, '(kein Vorgang)') AS vorgangstyp,
+    COUNT(DISTINCT jd.drucksache_id) AS drucksachen,
+    ROUND(100.0 * COUNT(DISTINCT jd.drucksache_id) / (SELECT COUNT(*) FROM joint_drucksachen), 2) AS pct_of_joint
+FROM joint_drucksachen jd
+LEFT JOIN drucksache_vorgangsbezug dv ON dv.drucksache_id = jd.drucksache_id
+GROUP BY dv.vorgangstyp
+ORDER BY drucksachen DESC, vorgangstyp
+LIMIT 15;
+
+-- section: Citations with and without a common Vorgang
+SELECT
+    q.drucksachetyp AS zitierende_drucksachetyp,
+    z.drucksachetyp AS zitierte_drucksachetyp,
+    COUNT(*) AS zitate,
+    SUM(dz.gemeinsamer_vorgang) AS mit_gemeinsamem_vorgang,
+    ROUND(100.0 * SUM(dz.gemeinsamer_vorgang) / COUNT(*), 2) AS pct_gemeinsamer_vorgang
+FROM drucksache_zitat dz
+JOIN drucksache q ON q.id = dz.drucksache_id
+JOIN drucksache z ON z.id = dz.zitiert_id
+WHERE q.deleted_at IS NULL
+  AND z.deleted_at IS NULL
+  AND dz.wahlperiode BETWEEN :wp_from AND :wp_to
+GROUP BY q.drucksachetyp, z.drucksachetyp
+HAVING COUNT(*) >= 50
+ORDER BY zitate DESC, zitierende_drucksachetyp, zitierte_drucksachetyp
+LIMIT 25;
+
+-- section: Most cited Drucksachen
+SELECT
+    d.dokumentnummer,
+    d.drucksachetyp,
+    d.titel,
+    COUNT(DISTINCT CASE WHEN dz.quelle_dokumentart = 'Drucksache' THEN dz.quelle_id END) AS zitiert_von_drucksachen,
+    COUNT(DISTINCT CASE WHEN dz.quelle_dokumentart = 'Plenarprotokoll' THEN dz.quelle_id END) AS zitiert_in_plenarprotokollen
+FROM dokument_zitat dz
+JOIN drucksache d ON d.id = dz.ziel_id
+WHERE dz.ziel_dokumentart = 'Drucksache'
+  AND d.deleted_at IS NULL
+  AND d.wahlperiode BETWEEN :wp_from AND :wp_to
+GROUP BY d.id
+ORDER BY zitiert_von_drucksachen + zitiert_in_plenarprotokollen DESC, d.dokumentnummer
+LIMIT 20;
@@ -0,0 +1,69 @@
+-- title: EU influence growth
+-- version: 1
+-- params: wahlperiode
+-- source: docu/eu_influence_growth_analysis.sql
+-- EU-Vorlagen as share of all Vorgänge, their growth between Wahlperioden and
+-- the annual rate, which accounts for Wahlperioden of different length.
+
+-- section: EU-Vorlagen per Wahlperiode
+SELECT
+    wahlperiode,
+    SUM(vorgangstyp = 'EU-Vorlage') AS eu_vorlagen,
+    COUNT(*) AS vorgaenge,
+    ROUND(100.0 * SUM(vorgangstyp = 'EU-Vorlage') / COUNT(*), 2) AS pct_eu
+FROM vorgang
+WHERE deleted_at IS NULL
+  AND wahlperiode BETWEEN :wp_from AND :wp_to
+GROUP BY wahlperiode
+ORDER BY wahlperiode;
+
+-- section: Growth between Wahlperioden
+WITH wp_counts AS (
+    SELECT wahlperiode, COUNT(*) AS eu_vorlagen
+    FROM vorgang
+    WHERE deleted_at IS NULL
+      AND vorgangstyp = 'EU-Vorlage'
+      AND wahlperiode BETWEEN :wp_from AND :wp_to
+    GROUP BY wahlperiode
+)
+SELECT
+    wahlperiode,
+    eu_vorlagen,
+    LAG(eu_vorlagen) OVER (ORDER BY wahlperiode) AS previous,
+    eu_vorlagen - LAG(eu_vorlagen) OVER (ORDER BY wahlperiode) AS change,
+    ROUND(100.0 * (eu_vorlagen - LAG(eu_vorlagen) OVER (ORDER BY wahlperiode))
+        / NULLIF(LAG(eu_vorlagen) OVER (ORDER BY wahlperiode), 0), 1) AS pct_change
+FROM wp_counts
+ORDER BY wahlperiode;
+
+-- section: Annual rate per Wahlperiode
+SELECT
+    wahlperiode,
+    MIN(SUBSTR(datum, 1, 4)) AS start_year,
+    MAX(SUBSTR(datum, 1, 4)) AS end_year,
+    COUNT(DISTINCT SUBSTR(datum, 1, 4)) AS years,
+    SUM(vorgangstyp = 'EU-Vorlage') AS eu_vorlagen,
+    ROUND(1.0 * SUM(vorgangstyp = 'EU-Vorlage') / NULLIF(COUNT(DISTINCT SUBSTR(datum, 1, 4)), 0), 1) AS eu_vorlagen_per_year
+FROM vorgang
+WHERE deleted_at IS NULL
+  AND wahlperiode BETWEEN :wp_from AND :wp_to
+  AND datum IS NOT NULL AND datum != ''
+GROUP BY wahlperiode
+ORDER BY wahlperiode;
+
+-- section: EU integration milestones
+SELECT
+    CASE
+        WHEN wahlperiode <= 10 THEN 'Pre-Maastricht (WP7-10)'
+        WHEN wahlperiode <= 14 THEN 'Post-Maastricht/Euro (WP11-14)'
+        WHEN wahlperiode <= 17 THEN 'Post-Lisbon (WP15-17)'
+        ELSE 'Recent (WP18+)'
+    END AS period,
+    SUM(vorgangstyp = 'EU-Vorlage') AS eu_vorlagen,
+    COUNT(*) AS vorgaenge,
+    ROUND(100.0 * SUM(vorgangstyp = 'EU-Vorlage') / COUNT(*), 2) AS pct_eu
+FROM vorgang
+WHERE deleted_at IS NULL
+  AND wahlperiode BETWEEN :wp_from AND :wp_to
+GROUP BY period
+ORDER BY MIN(wahlperiode);
@@ -0,0 +1,124 @@
+-- title: Fraktion collaboration
+-- version: 1
+-- params: wahlperiode, fraktion
+-- source: docu/fraktion_collaboration_50years_analysis.sql, docu/fraktion_collaboration_50years_drucksachen_analysis.sql
+-- Joint initiatives of several Fraktionen, from the Initiativen of Vorgänge
+-- and the Urheber of Drucksachen. With a Fraktion set, only Vorgänge and
+-- Drucksachen involving one of the Fraktionen are counted.
+
+-- section: Joint Vorgänge per Wahlperiode
+WITH vorgang_fraktionen AS (
+    SELECT v.wahlperiode, v.id, COUNT(DISTINCT vi.initiative) AS fraktionen
+    FROM vorgang v
+    JOIN vorgang_initiative vi ON vi.vorgang_id = v.id
+    WHERE v.deleted_at IS NULL
+      AND v.wahlperiode BETWEEN :wp_from AND :wp_to
+      AND vi.initiative LIKE 'Fraktion%'
+    GROUP BY v.wahlperiode, v.id
+    HAVING :fraktionen = '[]' OR SUM(EXISTS (
+        SELECT 1 FROM json_each(:fraktionen) f WHERE vi.initiative LIKE '%' || f.value || '%')) > 0
+)
+SELECT
+    wahlperiode,
+    SUM(fraktionen >= 2) AS joint_vorgaenge,
+    COUNT(*) AS fraktion_vorgaenge,
+    ROUND(100.0 * SUM(fraktionen >= 2) / COUNT(*), 2) AS pct_joint
+FROM vorgang_fraktionen
+GROUP BY wahlperiode
+ORDER BY wahlperiode;
+
+-- section: Top Fraktion pairs in Vorgängen
+WITH pairs AS (
+    SELECT vi1.initiative AS fraktion1, vi2.initiative AS fraktion2
+    FROM vorgang_initiative vi1
+    JOIN vorgang_initiative vi2 ON vi2.vorgang_id = vi1.vorgang_id AND vi1.initiative < vi2.initiative
+    JOIN vorgang v ON v.id = vi1.vorgang_id
+    WHERE v.deleted_at IS NULL
+      AND v.wahlperiode BETWEEN :wp_from AND :wp_to
+      AND vi1.initiative LIKE 'Fraktion%'
+      AND vi2.initiative LIKE 'Fraktion%'
+      AND (:fraktionen = '[]' OR EXISTS (
+          SELECT 1 FROM json_each(:fraktionen) f
+          WHERE vi1.initiative LIKE '%' || f.value || '%' OR vi2.initiative LIKE '%' || f.value || '%'))
+)
+SELECT
+    fraktion1,
+    fraktion2,
+    COUNT(*) AS joint_vorgaenge,
+    ROUND(100.0 * COUNT(*) / (SELECT COUNT(*) FROM pairs), 2) AS pct
+FROM pairs
+GROUP BY fraktion1, fraktion2
+ORDER BY joint_vorgaenge DESC, fraktion1, fraktion2
+LIMIT 15;
+
+-- section: Joint Drucksachen per Wahlperiode
+WITH drucksache_fraktionen AS (
+    SELECT d.wahlperiode, d.id, COUNT(DISTINCT du.urheber_id) AS fraktionen
+    FROM drucksache d
+    JOIN drucksache_urheber du ON du.drucksache_id = d.id
+    JOIN urheber u ON u.id = du.urheber_id
+    WHERE d.deleted_at IS NULL
+      AND d.wahlperiode BETWEEN :wp_from AND :wp_to
+      AND u.titel LIKE 'Fraktion%'
+    GROUP BY d.wahlperiode, d.id
+    HAVING :fraktionen = '[]' OR SUM(EXISTS (
+        SELECT 1 FROM json_each(:fraktionen) f WHERE u.titel LIKE '%' || f.value || '%')) > 0
+)
+SELECT
+    wahlperiode,
+    SUM(fraktionen >= 2) AS joint_drucksachen,
+    COUNT(*) AS fraktion_drucksachen,
+    ROUND(100.0 * SUM(fraktionen >= 2) / COUNT(*), 2) AS pct_joint
+FROM drucksache_fraktionen
+GROUP BY wahlperiode
+ORDER BY wahlperiode;
+
+-- section: Top Fraktion pairs in Drucksachen
+WITH pairs AS (
+    SELECT u1.titel AS fraktion1, u2.titel AS fraktion2
+    FROM drucksache_urheber du1
+    JOIN drucksache_urheber du2 ON du2.drucksache_id = du1.drucksache_id AND du1.urheber_id < du2.urheber_id
+    JOIN urheber u1 ON u1.id = du1.urheber_id
+    JOIN urheber u2 ON u2.id = du2.urheber_id
+    JOIN drucksache d ON d.id = du1.drucksache_id
+    WHERE d.deleted_at IS NULL
+      AND d.wahlperiode BETWEEN :wp_from AND :wp_to
+      AND u1.titel LIKE 'Fraktion%'
+      AND u2.titel LIKE 'Fraktion%'
+      AND (:fraktionen = '[]' OR EXISTS (
+          SELECT 1 FROM json_each(:fraktionen) f
+          WHERE u1.titel LIKE '%' || f.value || '%' OR u2.titel LIKE '%' || f.value || '%'))
+)
+SELECT
+    fraktion1,
+    fraktion2,
+    COUNT(*) AS joint_drucksachen,
+    ROUND(100.0 * COUNT(*) / (SELECT COUNT(*) FROM pairs), 2) AS pct
+FROM pairs
+GROUP BY fraktion1, fraktion2
+ORDER BY joint_drucksachen DESC, fraktion1, fraktion2
+LIMIT 15;
+
+-- section: Joint Drucksachen by Drucksachetyp
+WITH joint AS (
+    SELECT DISTINCT d.id, d.drucksachetyp
+    FROM drucksache_urheber du1
+    JOIN drucksache_urheber du2 ON du2.drucksache_id = du1.drucksache_id AND du1.urheber_id < du2.urheber_id
+    JOIN urheber u1 ON u1.id = du1.urheber_id
+    JOIN urheber u2 ON u2.id = du2.urheber_id
+    JOIN drucksache d ON d.id = du1.drucksache_id
+    WHERE d.deleted_at IS NULL
+      AND d.wahlperiode BETWEEN :wp_from AND :wp_to
+      AND u1.titel LIKE 'Fraktion%'
+      AND u2.titel LIKE 'Fraktion%'
+      AND (:fraktionen = '[]' OR EXISTS (
+          SELECT 1 FROM json_each(:fraktionen) f
+          WHERE u1.titel LIKE '%' || f.value || '%' OR u2.titel LIKE '%' || f.value || '%'))
+)
+SELECT
+    drucksachetyp,
+    COUNT(*) AS joint_drucksachen,
+    ROUND(100.0 * COUNT(*) / (SELECT COUNT(*) FROM joint), 2) AS pct
+FROM joint
+GROUP BY drucksachetyp
+ORDER BY joint_drucksachen DESC, drucksachetyp;
@@ -0,0 +1,130 @@
+-- title: Fraktion content
+-- version: 1
+-- params: wahlperiode, fraktion
+-- source: docu/fdp_content_analysis.sql
+-- What the Kleine Anfragen of Abgeordneten are about, from the Deskriptoren of
+-- the Vorgänge they belong to. The source analysis looked at the FDP in WP 19;
+-- set a Fraktion and Wahlperiode to repeat it for any Fraktion.
+-- Aktivitäten are titled "Name, MdB, Fraktion"; without a Fraktion, the
+-- Aktivitäten of all Fraktionen are counted.
+
+-- section: Aktivitätsarten
+SELECT
+    aktivitaetsart,
+    COUNT(*) AS aktivitaeten,
+    ROUND(100.0 * COUNT(*) / SUM(COUNT(*)) OVER (), 2) AS pct
+FROM aktivitaet
+WHERE deleted_at IS NULL
+  AND titel LIKE '%MdB%'
+  AND wahlperiode BETWEEN :wp_from AND :wp_to
+  AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE titel LIKE '%' || f.value || '%'))
+GROUP BY aktivitaetsart
+ORDER BY aktivitaeten DESC, aktivitaetsart
+LIMIT 15;
+
+-- section: Top Sachbegriffe of Kleine Anfragen
+WITH anfragen AS (
+    SELECT id
+    FROM aktivitaet
+    WHERE deleted_at IS NULL
+      AND aktivitaetsart = 'Kleine Anfrage'
+      AND titel LIKE '%MdB%'
+      AND wahlperiode BETWEEN :wp_from AND :wp_to
+      AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE titel LIKE '%' || f.value || '%'))
+)
+SELECT
+    vd.name AS sachbegriff,
+    COUNT(DISTINCT a.id) AS anfragen,
+    ROUND(100.0 * COUNT(DISTINCT a.id) / (SELECT COUNT(*) FROM anfragen), 2) AS pct
+FROM anfragen a
+JOIN aktivitaet_vorgangsbezug av ON av.aktivitaet_id = a.id
+JOIN vorgang_deskriptor vd ON vd.vorgang_id = av.vorgang_id
+WHERE vd.typ = 'Sachbegriffe'
+GROUP BY vd.name
+ORDER BY anfragen DESC, sachbegriff
+LIMIT 50;
+
+-- section: Top geographic Deskriptoren of Kleine Anfragen
+WITH anfragen AS (
+    SELECT id
+    FROM aktivitaet
+    WHERE deleted_at IS NULL
+      AND aktivitaetsart = 'Kleine Anfrage'
+      AND titel LIKE '%MdB%'
+      AND wahlperiode BETWEEN :wp_from AND :wp_to
+      AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE titel LIKE '%' || f.value || '%'))
+)
+SELECT
+    vd.name AS region,
+    COUNT(DISTINCT a.id) AS anfragen,
+    ROUND(100.0 * COUNT(DISTINCT a.id) / (SELECT COUNT(*) FROM anfragen), 2) AS pct
+FROM anfragen a
+JOIN aktivitaet_vorgangsbezug av ON av.aktivitaet_id = a.id
+JOIN vorgang_deskriptor vd ON vd.vorgang_id = av.vorgang_id
+WHERE vd.typ = 'Geograph. Begriffe'
+GROUP BY vd.name
+ORDER BY anfragen DESC, region
+LIMIT 40;
+
+-- section: Top Institutionen of Kleine Anfragen
+WITH anfragen AS (
+    SELECT id
+    FROM aktivitaet
+    WHERE deleted_at IS NULL
+      AND aktivitaetsart = 'Kleine Anfrage'
+      AND titel LIKE '%MdB%'
+      AND wahlperiode BETWEEN :wp_from AND :wp_to
+      AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE titel LIKE '%' || f.value || '%'))
+)
+SELECT
+    vd.name AS institution,
+    COUNT(DISTINCT a.id) AS anfragen,
+    ROUND(100.0 * COUNT(DISTINCT a.id) / (SELECT COUNT(*) FROM anfragen), 2) AS pct
+FROM anfragen a
+JOIN aktivitaet_vorgangsbezug av ON av.aktivitaet_id = a.id
+JOIN vorgang_deskriptor vd ON vd.vorgang_id = av.vorgang_id
+WHERE vd.typ = 'Institutionen'
+GROUP BY vd.name
+ORDER BY anfragen DESC, institution
+LIMIT 40;
+
+-- section: Top Sachbegriffe per Wahlperiode
+WITH counts AS (
+    SELECT
+        a.wahlperiode,
+        vd.name AS sachbegriff,
+        COUNT(DISTINCT a.id) AS anfragen
+    FROM aktivitaet a
+    JOIN aktivitaet_vorgangsbezug av ON av.aktivitaet_id = a.id
+    JOIN vorgang_deskriptor vd ON vd.vorgang_id = av.vorgang_id
+    WHERE a.deleted_at IS NULL
+      AND a.aktivitaetsart = 'Kleine Anfrage'
+      AND a.titel LIKE '%MdB%'
+      AND a.wahlperiode BETWEEN :wp_from AND :wp_to
+      AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE a.titel LIKE '%' || f.value || '%'))
+      AND vd.typ = 'Sachbegriffe'
+    GROUP BY a.wahlperiode, vd.name
+),
+ranked AS (
+    SELECT *, ROW_NUMBER() OVER (PARTITION BY wahlperiode ORDER BY anfragen DESC, sachbegriff) AS rang
+    FROM counts
+)
+SELECT wahlperiode, rang, sachbegriff, anfragen
+FROM ranked
+WHERE rang <= 20
+ORDER BY wahlperiode, rang;
+
+-- section: Top questioners
+SELECT
+    titel AS person,
+    COUNT(*) AS anfragen,
+    COUNT(DISTINCT wahlperiode) AS wahlperioden
+FROM aktivitaet
+WHERE deleted_at IS NULL
+  AND aktivitaetsart = 'Kleine Anfrage'
+  AND titel LIKE '%MdB%'
+  AND wahlperiode BETWEEN :wp_from AND :wp_to
+  AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE titel LIKE '%' || f.value || '%'))
+GROUP BY titel
+ORDER BY anfragen DESC, person
+LIMIT 15;
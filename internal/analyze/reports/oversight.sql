@@ -0,0 +1,94 @@
+-- title: Parliamentary oversight evolution
+-- version: 1
+-- params: wahlperiode, fraktion
+-- source: docu/parliamentary_oversight_evolution_analysis.sql
+-- Kleine and Große Anfragen and Fragen over the Wahlperioden, who asks them and
+-- how many are answered. Aktivitäten are titled "Name, MdB, Fraktion"; with a
+-- Fraktion set, only the Aktivitäten of those Fraktionen are counted.
+
+-- section: Questions per Wahlperiode
+SELECT
+    wahlperiode,
+    SUM(aktivitaetsart = 'Kleine Anfrage') AS kleine_anfragen,
+    SUM(aktivitaetsart = 'Große Anfrage') AS grosse_anfragen,
+    SUM(aktivitaetsart = 'Frage') AS fragen,
+    COUNT(*) AS aktivitaeten,
+    ROUND(100.0 * COUNT(*) / SUM(COUNT(*)) OVER (), 2) AS pct_of_total
+FROM aktivitaet
+WHERE deleted_at IS NULL
+  AND aktivitaetsart IN ('Kleine Anfrage', 'Große Anfrage', 'Frage')
+  AND wahlperiode BETWEEN :wp_from AND :wp_to
+  AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE titel LIKE '%' || f.value || '%'))
+GROUP BY wahlperiode
+ORDER BY wahlperiode;
+
+-- section: Anfragen by Fraktion
+SELECT
+    a.wahlperiode,
+    CASE
+        WHEN a.titel LIKE '%AfD%' THEN 'AfD'
+        WHEN a.titel LIKE '%DIE LINKE%' OR a.titel LIKE '%Die Linke%' OR a.titel LIKE '%PDS%' THEN 'DIE LINKE'
+        WHEN a.titel LIKE '%GRÜNE%' THEN 'GRÜNE'
+        WHEN a.titel LIKE '%SPD%' THEN 'SPD'
+        WHEN a.titel LIKE '%CDU%' OR a.titel LIKE '%CSU%' THEN 'CDU/CSU'
+        WHEN a.titel LIKE '%FDP%' THEN 'FDP'
+        WHEN a.titel LIKE '%BSW%' THEN 'BSW'
+        WHEN a.titel LIKE '%fraktionslos%' THEN 'fraktionslos'
+        ELSE 'Sonstige'
+    END AS fraktion,
+    COUNT(*) AS aktivitaeten,
+    COUNT(DISTINCT av.vorgang_id) AS anfragen,
+    ROUND(1.0 * COUNT(*) / COUNT(DISTINCT av.vorgang_id), 2) AS signers_per_anfrage
+FROM aktivitaet a
+JOIN aktivitaet_vorgangsbezug av ON av.aktivitaet_id = a.id
+WHERE a.deleted_at IS NULL
+  AND a.aktivitaetsart IN ('Kleine Anfrage', 'Große Anfrage')
+  AND a.wahlperiode BETWEEN :wp_from AND :wp_to
+  AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE a.titel LIKE '%' || f.value || '%'))
+GROUP BY a.wahlperiode, fraktion
+ORDER BY a.wahlperiode, anfragen DESC, fraktion;
+
+-- section: Answered Kleine Anfragen per Wahlperiode
+WITH anfragen AS (
+    SELECT DISTINCT a.wahlperiode, av.vorgang_id
+    FROM aktivitaet a
+    JOIN aktivitaet_vorgangsbezug av ON av.aktivitaet_id = a.id
+    WHERE a.deleted_at IS NULL
+      AND a.aktivitaetsart = 'Kleine Anfrage'
+      AND a.wahlperiode BETWEEN :wp_from AND :wp_to
+      AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE a.titel LIKE '%' || f.value || '%'))
+),
+beantwortet AS (
+    SELECT DISTINCT av.vorgang_id
+    FROM aktivitaet a
+    JOIN aktivitaet_vorgangsbezug av ON av.aktivitaet_id = a.id
+    WHERE a.deleted_at IS NULL AND a.aktivitaetsart = 'Antwort'
+)
+SELECT
+    q.wahlperiode,
+    COUNT(*) AS kleine_anfragen,
+    SUM(b.vorgang_id IS NOT NULL) AS beantwortet,
+    ROUND(100.0 * SUM(b.vorgang_id IS NOT NULL) / COUNT(*), 2) AS pct_beantwortet
+FROM anfragen q
+LEFT JOIN beantwortet b ON b.vorgang_id = q.vorgang_id
+GROUP BY q.wahlperiode
+ORDER BY q.wahlperiode;
+
+-- section: Signers per Anfrage-Drucksache
+SELECT
+    d.wahlperiode,
+    d.drucksachetyp,
+    COUNT(DISTINCT d.id) AS drucksachen,
+    COUNT(DISTINCT a.id) AS aktivitaeten,
+    ROUND(1.0 * COUNT(DISTINCT a.id) / COUNT(DISTINCT d.id), 2) AS signers_per_drucksache
+FROM drucksache d
+JOIN drucksache_vorgangsbezug dv ON dv.drucksache_id = d.id
+JOIN aktivitaet_vorgangsbezug av ON av.vorgang_id = dv.vorgang_id
+JOIN aktivitaet a ON a.id = av.aktivitaet_id
+WHERE d.deleted_at IS NULL AND a.deleted_at IS NULL
+  AND d.drucksachetyp IN ('Kleine Anfrage', 'Große Anfrage')
+  AND a.aktivitaetsart = d.drucksachetyp
+  AND d.wahlperiode BETWEEN :wp_from AND :wp_to
+  AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE a.titel LIKE '%' || f.value || '%'))
+GROUP BY d.wahlperiode, d.drucksachetyp
+ORDER BY d.wahlperiode, d.drucksachetyp;
@@ -0,0 +1,101 @@
+-- title: Person participation
+-- version: 1
+-- params: wahlperiode, fraktion
+-- source: docu/person_participation_tracking_analysis.sql
+-- Aktivitäten of individual Abgeordnete: the most active persons, how activity
+-- is spread within each Fraktion and who is active across Wahlperioden.
+-- Aktivitäten are titled "Name, MdB, Fraktion"; with a Fraktion set, only the
+-- Aktivitäten of those Fraktionen are counted.
+
+-- section: Most active persons
+SELECT
+    titel AS person,
+    wahlperiode,
+    COUNT(*) AS aktivitaeten,
+    COUNT(DISTINCT aktivitaetsart) AS aktivitaetsarten,
+    MIN(datum) AS first_activity,
+    MAX(datum) AS last_activity
+FROM aktivitaet
+WHERE deleted_at IS NULL
+  AND titel LIKE '%MdB%'
+  AND wahlperiode BETWEEN :wp_from AND :wp_to
+  AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE titel LIKE '%' || f.value || '%'))
+GROUP BY titel, wahlperiode
+ORDER BY aktivitaeten DESC, person
+LIMIT 50;
+
+-- section: Activity per member by Fraktion
+WITH person_counts AS (
+    SELECT
+        CASE
+            WHEN titel LIKE '%AfD%' THEN 'AfD'
+            WHEN titel LIKE '%DIE LINKE%' OR titel LIKE '%Die Linke%' OR titel LIKE '%PDS%' THEN 'DIE LINKE'
+            WHEN titel LIKE '%GRÜNE%' THEN 'GRÜNE'
+            WHEN titel LIKE '%SPD%' THEN 'SPD'
+            WHEN titel LIKE '%CDU%' OR titel LIKE '%CSU%' THEN 'CDU/CSU'
+            WHEN titel LIKE '%FDP%' THEN 'FDP'
+            WHEN titel LIKE '%BSW%' THEN 'BSW'
+            ELSE 'Sonstige'
+        END AS fraktion,
+        titel,
+        COUNT(*) AS aktivitaeten
+    FROM aktivitaet
+    WHERE deleted_at IS NULL
+      AND titel LIKE '%MdB%'
+      AND wahlperiode BETWEEN :wp_from AND :wp_to
+      AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE titel LIKE '%' || f.value || '%'))
+    GROUP BY fraktion, titel
+)
+SELECT
+    fraktion,
+    COUNT(*) AS members,
+    SUM(aktivitaeten) AS aktivitaeten,
+    ROUND(AVG(aktivitaeten), 2) AS avg_per_member,
+    MAX(aktivitaeten) AS max_per_member,
+    MIN(aktivitaeten) AS min_per_member,
+    ROUND(AVG(aktivitaeten) / MAX(aktivitaeten), 3) AS equality_ratio
+FROM person_counts
+GROUP BY fraktion
+ORDER BY avg_per_member DESC;
+
+-- section: Aktivitätsarten by Fraktion
+SELECT
+    CASE
+        WHEN titel LIKE '%AfD%' THEN 'AfD'
+        WHEN titel LIKE '%DIE LINKE%' OR titel LIKE '%Die Linke%' OR titel LIKE '%PDS%' THEN 'DIE LINKE'
+        WHEN titel LIKE '%GRÜNE%' THEN 'GRÜNE'
+        WHEN titel LIKE '%SPD%' THEN 'SPD'
+        WHEN titel LIKE '%CDU%' OR titel LIKE '%CSU%' THEN 'CDU/CSU'
+        WHEN titel LIKE '%FDP%' THEN 'FDP'
+        WHEN titel LIKE '%BSW%' THEN 'BSW'
+        ELSE 'Sonstige'
+    END AS fraktion,
+    aktivitaetsart,
+    COUNT(*) AS aktivitaeten,
+    COUNT(DISTINCT titel) AS persons,
+    ROUND(1.0 * COUNT(*) / COUNT(DISTINCT titel), 2) AS avg_per_person
+FROM aktivitaet
+WHERE deleted_at IS NULL
+  AND titel LIKE '%MdB%'
+  AND wahlperiode BETWEEN :wp_from AND :wp_to
+  AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE titel LIKE '%' || f.value || '%'))
+GROUP BY fraktion, aktivitaetsart
+ORDER BY fraktion, aktivitaeten DESC, aktivitaetsart;
+
+-- section: Persons active in several Wahlperioden
+SELECT
+    titel AS person,
+    COUNT(DISTINCT wahlperiode) AS wahlperioden,
+    MIN(wahlperiode) AS first_wahlperiode,
+    MAX(wahlperiode) AS last_wahlperiode,
+    COUNT(*) AS aktivitaeten,
+    ROUND((JULIANDAY(MAX(datum)) - JULIANDAY(MIN(datum))) / 365.25, 1) AS years_active
+FROM aktivitaet
+WHERE deleted_at IS NULL
+  AND titel LIKE '%MdB%'
+  AND wahlperiode BETWEEN :wp_from AND :wp_to
+  AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE titel LIKE '%' || f.value || '%'))
+GROUP BY titel
+HAVING COUNT(DISTINCT wahlperiode) >= 2
+ORDER BY wahlperioden DESC, aktivitaeten DESC, person
+LIMIT 50;
@@ -0,0 +1,84 @@
+-- title: Plenar-Reaktionen
+-- version: 1
+-- params: wahlperiode, fraktion
+-- source: docu/plenar_reaktionen_analysis.sql
+-- Beifall, Zurufe and Widerspruch between the Fraktionen in the plenary, from
+-- the Reden and Reaktionen parsed by process-plenarprotokoll-reden. With a
+-- Fraktion set, only the Reden and Reaktionen of those Fraktionen are counted.
+
+-- section: Parsed Reden and Reaktionen per Wahlperiode
+SELECT
+    p.wahlperiode,
+    COUNT(DISTINCT r.plenarprotokoll_id) AS plenarprotokolle,
+    COUNT(DISTINCT r.id) AS reden,
+    COUNT(rr.id) AS reaktionen,
+    ROUND(1.0 * COUNT(rr.id) / COUNT(DISTINCT r.id), 2) AS reaktionen_pro_rede
+FROM rede r
+JOIN plenarprotokoll p ON p.id = r.plenarprotokoll_id
+LEFT JOIN rede_reaktion rr ON rr.rede_id = r.id
+WHERE p.deleted_at IS NULL
+  AND p.wahlperiode BETWEEN :wp_from AND :wp_to
+  AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE r.fraktion LIKE '%' || f.value || '%'))
+GROUP BY p.wahlperiode
+ORDER BY p.wahlperiode;
+
+-- section: Cross-Fraktion Beifall
+WITH reden_pro_fraktion AS (
+    SELECT p.wahlperiode, r.fraktion, COUNT(*) AS reden
+    FROM rede r
+    JOIN plenarprotokoll p ON p.id = r.plenarprotokoll_id
+    WHERE p.deleted_at IS NULL
+      AND r.fraktion IS NOT NULL
+    GROUP BY p.wahlperiode, r.fraktion
+)
+SELECT
+    rf.wahlperiode,
+    rf.redner_fraktion,
+    rf.reagierende_fraktion,
+    rf.reden AS applaudierte_reden,
+    rp.reden AS reden_gesamt,
+    ROUND(100.0 * rf.reden / rp.reden, 1) AS anteil_prozent
+FROM rede_reaktion_fraktion rf
+JOIN reden_pro_fraktion rp ON rp.wahlperiode = rf.wahlperiode AND rp.fraktion = rf.redner_fraktion
+WHERE rf.art = 'Beifall'
+  AND rf.redner_fraktion <> rf.reagierende_fraktion
+  AND rf.wahlperiode BETWEEN :wp_from AND :wp_to
+  AND (:fraktionen = '[]' OR EXISTS (
+      SELECT 1 FROM json_each(:fraktionen) f
+      WHERE rf.redner_fraktion LIKE '%' || f.value || '%' OR rf.reagierende_fraktion LIKE '%' || f.value || '%'))
+ORDER BY rf.wahlperiode, rf.redner_fraktion, anteil_prozent DESC;
+
+-- section: Zurufe and Widerspruch between Fraktionen
+SELECT
+    wahlperiode,
+    reagierende_fraktion,
+    redner_fraktion,
+    SUM(CASE WHEN art = 'Zuruf' THEN anzahl ELSE 0 END) AS zurufe,
+    SUM(CASE WHEN art = 'Widerspruch' THEN anzahl ELSE 0 END) AS widerspruch,
+    SUM(CASE WHEN art = 'Heiterkeit' THEN anzahl ELSE 0 END) AS heiterkeit
+FROM rede_reaktion_fraktion
+WHERE redner_fraktion <> reagierende_fraktion
+  AND wahlperiode BETWEEN :wp_from AND :wp_to
+  AND (:fraktionen = '[]' OR EXISTS (
+      SELECT 1 FROM json_each(:fraktionen) f
+      WHERE redner_fraktion LIKE '%' || f.value || '%' OR reagierende_fraktion LIKE '%' || f.value || '%'))
+GROUP BY wahlperiode, reagierende_fraktion, redner_fraktion
+ORDER BY wahlperiode, zurufe + widerspruch DESC, reagierende_fraktion, redner_fraktion;
+
+-- section: Most active Zwischenrufer
+SELECT
+    COALESCE(rr.mdb_id, rr.sprecher) AS mitglied,
+    MAX(rr.sprecher) AS name,
+    rr.fraktion,
+    COUNT(*) AS zurufe,
+    COUNT(DISTINCT rr.plenarprotokoll_id) AS sitzungen
+FROM rede_reaktion rr
+JOIN plenarprotokoll p ON p.id = rr.plenarprotokoll_id
+WHERE rr.art = 'Zuruf'
+  AND rr.sprecher IS NOT NULL
+  AND p.deleted_at IS NULL
+  AND p.wahlperiode BETWEEN :wp_from AND :wp_to
+  AND (:fraktionen = '[]' OR EXISTS (SELECT 1 FROM json_each(:fraktionen) f WHERE rr.fraktion LIKE '%' || f.value || '%'))
+GROUP BY COALESCE(rr.mdb_id, rr.sprecher), rr.fraktion
+ORDER BY zurufe DESC, name
+LIMIT 30;
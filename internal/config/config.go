@@ -0,0 +1,288 @@
+// Package config resolves the settings shared by all commands: the API URL and
+// key, the database and the sync directories. Settings come from, in order of
+// precedence:
+//
+//  1. command-line flags
+//  2. environment variables, e.g. DIP_API_KEY
+//  3. the selected profile of the project file, then of the user file
+//  4. the project file dip-client.yaml in the working directory
+//  5. the user file ~/.config/dip-client/config.yaml ($DIP_CONFIG if set)
+//  6. the built-in defaults
+//
+// The profile is chosen by -profile, DIP_PROFILE or the profile key of the
+// files. A file looks like this:
+//
+//	profile: prod
+//	db: dip.db
+//	profiles:
+//	  prod:
+//	    key: XXXX
+//	  mock:
+//	    url: http://localhost:8080/api/v1
+//	    key: test
+//	    db: mock.db
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Names of the settings, the same as the flags of the commands
+const (
+	URL           = "url"
+	Key           = "key"
+	DB            = "db"
+	DBDriver      = "db-driver"
+	DSN           = "dsn"
+	CheckpointDir = "checkpoint-dir"
+	FailedDir     = "failed-dir"
+)
+
+// ProjectFile is the name of the project-local config file in the working directory
+const ProjectFile = "dip-client.yaml"
+
+// ProfileUsage is the usage of the -profile flag
+const ProfileUsage = "Config profile (default: $DIP_PROFILE or the profile of the config file)"
+
+// setting is a known setting with its environment variable and default
+type setting struct {
+	name string
+	env  string
+	def  string
+}
+
+var settings = []setting{
+	{URL, "DIP_URL", "https://search.dip.bundestag.de/api/v1"},
+	{Key, "DIP_API_KEY", ""},
+	{DB, "DIP_DB", "dip.db"},
+	{DBDriver, "DIP_DB_DRIVER", "sqlite"},
+	{DSN, "DIP_DSN", ""},
+	{CheckpointDir, "DIP_CHECKPOINT_DIR", ".checkpoints"},
+	{FailedDir, "DIP_FAILED_DIR", ".failed"},
+}
+
+func lookup(name string) (setting, bool) {
+	for _, s := range settings {
+		if s.name == name {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+// Names returns the names of all settings
+func Names() []string {
+	names := make([]string, len(settings))
+	for i, s := range settings {
+		names[i] = s.name
+	}
+	return names
+}
+
+// Default returns the built-in default of a setting, for the defaults of flags
+func Default(name string) string {
+	s, _ := lookup(name)
+	return s.def
+}
+
+// Value is a resolved setting and where it came from
+type Value struct {
+	Value  string
+	Source string // e.g. "default", "$DIP_API_KEY", "flag -db" or the file and profile
+}
+
+// Config is the resolved configuration of a command
+type Config struct {
+	Profile       string
+	ProfileSource string
+	Profiles      []string // all profiles defined in the files
+	Files         []string // files that were read, user file first
+	values        map[string]Value
+}
+
+// file is the content of a config file
+type file struct {
+	Profile  string                       `yaml:"profile"`
+	Settings map[string]string            `yaml:",inline"`
+	Profiles map[string]map[string]string `yaml:"profiles"`
+
+	path string
+}
+
+// Load resolves the settings from the files and the environment for profile,
+// which may be empty to use DIP_PROFILE or the profile key of the files
+func Load(profile string) (*Config, error) {
+	return load(Files(), os.Getenv, profile)
+}
+
+// Files returns the paths of the user and the project file. They need not exist.
+func Files() []string {
+	var files []string
+	if path := os.Getenv("DIP_CONFIG"); path != "" {
+		files = append(files, path)
+	} else if dir, err := os.UserConfigDir(); err == nil {
+		files = append(files, filepath.Join(dir, "dip-client", "config.yaml"))
+	}
+	return append(files, ProjectFile)
+}
+
+func load(paths []string, getenv func(string) string, profile string) (*Config, error) {
+	c := &Config{values: make(map[string]Value)}
+	for _, s := range settings {
+		c.values[s.name] = Value{Value: s.def, Source: "default"}
+	}
+
+	var files []file
+	for _, path := range paths {
+		f, err := readFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+		c.Files = append(c.Files, path)
+	}
+
+	// The later file wins, the project file overrides the user file
+	c.Profile, c.ProfileSource = profile, "flag -profile"
+	if c.Profile == "" {
+		c.Profile, c.ProfileSource = getenv("DIP_PROFILE"), "$DIP_PROFILE"
+	}
+	for i := len(files) - 1; i >= 0 && c.Profile == ""; i-- {
+		c.Profile, c.ProfileSource = files[i].Profile, files[i].path
+	}
+	if c.Profile == "" {
+		c.ProfileSource = ""
+	}
+
+	defined := make(map[string]bool)
+	for _, f := range files {
+		for name := range f.Profiles {
+			defined[name] = true
+		}
+	}
+	for name := range defined {
+		c.Profiles = append(c.Profiles, name)
+	}
+	sort.Strings(c.Profiles)
+	if c.Profile != "" && !defined[c.Profile] {
+		return nil, fmt.Errorf("profile %q (from %s) is not defined in %s", c.Profile, c.ProfileSource, strings.Join(paths, " or "))
+	}
+
+	for _, f := range files {
+		c.set(f.Settings, f.path)
+	}
+	if c.Profile != "" {
+		for _, f := range files {
+			c.set(f.Profiles[c.Profile], fmt.Sprintf("%s (profile %s)", f.path, c.Profile))
+		}
+	}
+	for _, s := range settings {
+		if v := getenv(s.env); v != "" {
+			c.values[s.name] = Value{Value: v, Source: "$" + s.env}
+		}
+	}
+	return c, nil
+}
+
+func (c *Config) set(values map[string]string, source string) {
+	for name, v := range values {
+		c.values[name] = Value{Value: v, Source: source}
+	}
+}
+
+// readFile reads and checks a config file
+func readFile(path string) (file, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return file{}, err
+	}
+	f := file{path: path}
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&f); err != nil && err != io.EOF {
+		return file{}, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	check := func(values map[string]string, where string) error {
+		for name := range values {
+			if _, ok := lookup(name); !ok {
+				return fmt.Errorf("invalid config file %s: unknown setting %q%s", path, name, where)
+			}
+		}
+		return nil
+	}
+	if err := check(f.Settings, ""); err != nil {
+		return file{}, err
+	}
+	for name, values := range f.Profiles {
+		if err := check(values, " in profile "+name); err != nil {
+			return file{}, err
+		}
+	}
+	return f, nil
+}
+
+// Get returns the value of a setting
+func (c *Config) Get(name string) string {
+	return c.values[name].Value
+}
+
+// Lookup returns the value of a setting and where it came from
+func (c *Config) Lookup(name string) Value {
+	return c.values[name]
+}
+
+// SQLiteOnly returns an error if db-driver selects another database than
+// SQLite, for the commands whose tables only exist in the SQLite schema
+func (c *Config) SQLiteOnly() error {
+	if driver := c.Lookup(DBDriver); driver.Value != Default(DBDriver) {
+		return fmt.Errorf("only SQLite is supported, but db-driver is %q (from %s)", driver.Value, driver.Source)
+	}
+	return nil
+}
+
+// Apply sets the flags of fs that are named after a setting and were not given
+// on the command line. Flags given on the command line win over the config and
+// are recorded as the source of the setting.
+func (c *Config) Apply(fs *flag.FlagSet) error {
+	given := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		given[f.Name] = true
+		if _, ok := lookup(f.Name); ok {
+			c.values[f.Name] = Value{Value: f.Value.String(), Source: "flag -" + f.Name}
+		}
+	})
+	for _, s := range settings {
+		if fs.Lookup(s.name) == nil || given[s.name] {
+			continue
+		}
+		if err := fs.Set(s.name, c.Get(s.name)); err != nil {
+			return fmt.Errorf("invalid %s %q from %s: %w", s.name, c.Get(s.name), c.values[s.name].Source, err)
+		}
+	}
+	return nil
+}
+
+// Parse adds -profile to fs, parses args and applies the config to the flags
+// of fs. It is flag.Parse for commands that use the shared settings.
+func Parse(fs *flag.FlagSet, args []string) (*Config, error) {
+	profile := fs.String("profile", "", ProfileUsage)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	c, err := Load(*profile)
+	if err != nil {
+		return nil, err
+	}
+	return c, c.Apply(fs)
+}
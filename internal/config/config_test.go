@@ -0,0 +1,129 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const userFile = `
+profile: prod
+db: user.db
+checkpoint-dir: /var/dip/checkpoints
+profiles:
+  prod:
+    key: prod-key
+  mock:
+    url: http://localhost:8080/api/v1
+    key: test
+`
+
+const projectFile = `
+db: project.db
+profiles:
+  mock:
+    db: mock.db
+`
+
+func writeFiles(t *testing.T, contents ...string) []string {
+	t.Helper()
+	dir := t.TempDir()
+	var paths []string
+	for i, content := range contents {
+		path := filepath.Join(dir, string(rune('a'+i))+".yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func env(vars map[string]string) func(string) string {
+	return func(name string) string { return vars[name] }
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	paths := writeFiles(t, userFile, projectFile)
+
+	// The profile of the user file applies, the project file overrides the user file
+	c, err := load(paths, env(nil), "")
+	require.NoError(t, err)
+	assert.Equal(t, "prod", c.Profile)
+	assert.Equal(t, paths[0], c.ProfileSource)
+	assert.Equal(t, []string{"mock", "prod"}, c.Profiles)
+	assert.Equal(t, "https://search.dip.bundestag.de/api/v1", c.Get(URL))
+	assert.Equal(t, "default", c.Lookup(URL).Source)
+	assert.Equal(t, "prod-key", c.Get(Key))
+	assert.Equal(t, "project.db", c.Get(DB))
+	assert.Equal(t, paths[1], c.Lookup(DB).Source)
+	assert.Equal(t, "/var/dip/checkpoints", c.Get(CheckpointDir))
+
+	// A profile overrides the top level of both files
+	c, err = load(paths, env(map[string]string{"DIP_PROFILE": "mock"}), "")
+	require.NoError(t, err)
+	assert.Equal(t, "$DIP_PROFILE", c.ProfileSource)
+	assert.Equal(t, "http://localhost:8080/api/v1", c.Get(URL))
+	assert.Equal(t, "test", c.Get(Key))
+	assert.Equal(t, "mock.db", c.Get(DB))
+
+	// The environment overrides the files, the flag overrides DIP_PROFILE
+	c, err = load(paths, env(map[string]string{"DIP_PROFILE": "mock", "DIP_API_KEY": "env-key"}), "prod")
+	require.NoError(t, err)
+	assert.Equal(t, "prod", c.Profile)
+	assert.Equal(t, "env-key", c.Get(Key))
+	assert.Equal(t, "$DIP_API_KEY", c.Lookup(Key).Source)
+
+	// Missing files are skipped
+	c, err = load([]string{filepath.Join(t.TempDir(), "missing.yaml")}, env(nil), "")
+	require.NoError(t, err)
+	assert.Empty(t, c.Files)
+	assert.Equal(t, "dip.db", c.Get(DB))
+}
+
+func TestLoadErrors(t *testing.T) {
+	paths := writeFiles(t, userFile)
+	_, err := load(paths, env(nil), "staging")
+	assert.ErrorContains(t, err, `profile "staging" (from flag -profile) is not defined`)
+
+	paths = writeFiles(t, "database: dip.db\n")
+	_, err = load(paths, env(nil), "")
+	assert.ErrorContains(t, err, `unknown setting "database"`)
+
+	paths = writeFiles(t, "profiles:\n  mock:\n    api-key: test\n")
+	_, err = load(paths, env(nil), "")
+	assert.ErrorContains(t, err, `unknown setting "api-key" in profile mock`)
+
+	paths = writeFiles(t, "db: [dip.db\n")
+	_, err = load(paths, env(nil), "")
+	assert.ErrorContains(t, err, "invalid config file")
+}
+
+func TestApply(t *testing.T) {
+	c, err := load(writeFiles(t, userFile), env(nil), "")
+	require.NoError(t, err)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	db := fs.String("db", Default(DB), "")
+	key := fs.String("key", "", "")
+	url := fs.String("url", Default(URL), "")
+	require.NoError(t, fs.Parse([]string{"-db", "flag.db"}))
+	require.NoError(t, c.Apply(fs))
+
+	assert.Equal(t, "flag.db", *db)
+	assert.Equal(t, "flag -db", c.Lookup(DB).Source)
+	assert.Equal(t, "prod-key", *key)
+	assert.Equal(t, Default(URL), *url)
+}
+
+func TestSQLiteOnly(t *testing.T) {
+	c, err := load(writeFiles(t, userFile), env(nil), "")
+	require.NoError(t, err)
+	assert.NoError(t, c.SQLiteOnly())
+
+	c, err = load(writeFiles(t, userFile), env(map[string]string{"DIP_DB_DRIVER": "postgres"}), "")
+	require.NoError(t, err)
+	assert.EqualError(t, c.SQLiteOnly(), `only SQLite is supported, but db-driver is "postgres" (from $DIP_DB_DRIVER)`)
+}
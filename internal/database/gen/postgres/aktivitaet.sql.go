@@ -56,13 +56,13 @@ INSERT INTO aktivitaet (
     fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
     fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
     fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
-    fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
     fundstelle_frage_nummer, fundstelle_verteildatum
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
     $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
-    $21, $22, $23, $24, $25, $26, $27
-) RETURNING id, titel, aktivitaetsart, typ, dokumentart, datum, aktualisiert, abstract, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at
+    $21, $22, $23, $24, $25, $26, $27, $28
+) RETURNING id, titel, aktivitaetsart, typ, dokumentart, datum, aktualisiert, abstract, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url, deleted_at
 `
 
 type CreateAktivitaetParams struct {
@@ -89,6 +89,7 @@ type CreateAktivitaetParams struct {
 	FundstelleEndquadrant     sql.NullString `json:"fundstelle_endquadrant"`
 	FundstelleSeite           sql.NullString `json:"fundstelle_seite"`
 	FundstellePdfUrl          sql.NullString `json:"fundstelle_pdf_url"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
 	FundstelleTop             sql.NullInt32  `json:"fundstelle_top"`
 	FundstelleTopZusatz       sql.NullString `json:"fundstelle_top_zusatz"`
 	FundstelleFrageNummer     sql.NullString `json:"fundstelle_frage_nummer"`
@@ -120,6 +121,7 @@ func (q *Queries) CreateAktivitaet(ctx context.Context, arg CreateAktivitaetPara
 		arg.FundstelleEndquadrant,
 		arg.FundstelleSeite,
 		arg.FundstellePdfUrl,
+		arg.FundstelleXmlUrl,
 		arg.FundstelleTop,
 		arg.FundstelleTopZusatz,
 		arg.FundstelleFrageNummer,
@@ -156,6 +158,8 @@ func (q *Queries) CreateAktivitaet(ctx context.Context, arg CreateAktivitaetPara
 		&i.FundstelleVerteildatum,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -223,9 +227,27 @@ func (q *Queries) DeleteAktivitaet(ctx context.Context, id string) error {
 	return err
 }
 
+const deleteAktivitaetDeskriptoren = `-- name: DeleteAktivitaetDeskriptoren :exec
+DELETE FROM aktivitaet_deskriptor WHERE aktivitaet_id = $1
+`
+
+func (q *Queries) DeleteAktivitaetDeskriptoren(ctx context.Context, aktivitaetID string) error {
+	_, err := q.db.ExecContext(ctx, deleteAktivitaetDeskriptoren, aktivitaetID)
+	return err
+}
+
+const deleteAktivitaetVorgangsbezuege = `-- name: DeleteAktivitaetVorgangsbezuege :exec
+DELETE FROM aktivitaet_vorgangsbezug WHERE aktivitaet_id = $1
+`
+
+func (q *Queries) DeleteAktivitaetVorgangsbezuege(ctx context.Context, aktivitaetID string) error {
+	_, err := q.db.ExecContext(ctx, deleteAktivitaetVorgangsbezuege, aktivitaetID)
+	return err
+}
+
 const getAktivitaet = `-- name: GetAktivitaet :one
 SELECT 
-    a.id, a.titel, a.aktivitaetsart, a.typ, a.dokumentart, a.datum, a.aktualisiert, a.abstract, a.vorgangsbezug_anzahl, a.wahlperiode, a.fundstelle_dokumentnummer, a.fundstelle_datum, a.fundstelle_dokumentart, a.fundstelle_herausgeber, a.fundstelle_id, a.fundstelle_drucksachetyp, a.fundstelle_anlagen, a.fundstelle_anfangsseite, a.fundstelle_endseite, a.fundstelle_anfangsquadrant, a.fundstelle_endquadrant, a.fundstelle_seite, a.fundstelle_pdf_url, a.fundstelle_top, a.fundstelle_top_zusatz, a.fundstelle_frage_nummer, a.fundstelle_verteildatum, a.created_at, a.updated_at,
+    a.id, a.titel, a.aktivitaetsart, a.typ, a.dokumentart, a.datum, a.aktualisiert, a.abstract, a.vorgangsbezug_anzahl, a.wahlperiode, a.fundstelle_dokumentnummer, a.fundstelle_datum, a.fundstelle_dokumentart, a.fundstelle_herausgeber, a.fundstelle_id, a.fundstelle_drucksachetyp, a.fundstelle_anlagen, a.fundstelle_anfangsseite, a.fundstelle_endseite, a.fundstelle_anfangsquadrant, a.fundstelle_endquadrant, a.fundstelle_seite, a.fundstelle_pdf_url, a.fundstelle_top, a.fundstelle_top_zusatz, a.fundstelle_frage_nummer, a.fundstelle_verteildatum, a.created_at, a.updated_at, a.fundstelle_xml_url, a.deleted_at,
     COALESCE(
         json_agg(
             DISTINCT jsonb_build_object(
@@ -282,6 +304,8 @@ type GetAktivitaetRow struct {
 	FundstelleVerteildatum    sql.NullTime   `json:"fundstelle_verteildatum"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
 	Deskriptor                interface{}    `json:"deskriptor"`
 	Vorgangsbezug             interface{}    `json:"vorgangsbezug"`
 }
@@ -319,15 +343,28 @@ func (q *Queries) GetAktivitaet(ctx context.Context, id string) (GetAktivitaetRo
 		&i.FundstelleVerteildatum,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 		&i.Deskriptor,
 		&i.Vorgangsbezug,
 	)
 	return i, err
 }
 
+const getLatestAktivitaetDatum = `-- name: GetLatestAktivitaetDatum :one
+SELECT MIN(datum) AS datum FROM aktivitaet
+`
+
+func (q *Queries) GetLatestAktivitaetDatum(ctx context.Context) (interface{}, error) {
+	row := q.db.QueryRowContext(ctx, getLatestAktivitaetDatum)
+	var datum interface{}
+	err := row.Scan(&datum)
+	return datum, err
+}
+
 const listAktivitaeten = `-- name: ListAktivitaeten :many
 SELECT 
-    a.id, a.titel, a.aktivitaetsart, a.typ, a.dokumentart, a.datum, a.aktualisiert, a.abstract, a.vorgangsbezug_anzahl, a.wahlperiode, a.fundstelle_dokumentnummer, a.fundstelle_datum, a.fundstelle_dokumentart, a.fundstelle_herausgeber, a.fundstelle_id, a.fundstelle_drucksachetyp, a.fundstelle_anlagen, a.fundstelle_anfangsseite, a.fundstelle_endseite, a.fundstelle_anfangsquadrant, a.fundstelle_endquadrant, a.fundstelle_seite, a.fundstelle_pdf_url, a.fundstelle_top, a.fundstelle_top_zusatz, a.fundstelle_frage_nummer, a.fundstelle_verteildatum, a.created_at, a.updated_at,
+    a.id, a.titel, a.aktivitaetsart, a.typ, a.dokumentart, a.datum, a.aktualisiert, a.abstract, a.vorgangsbezug_anzahl, a.wahlperiode, a.fundstelle_dokumentnummer, a.fundstelle_datum, a.fundstelle_dokumentart, a.fundstelle_herausgeber, a.fundstelle_id, a.fundstelle_drucksachetyp, a.fundstelle_anlagen, a.fundstelle_anfangsseite, a.fundstelle_endseite, a.fundstelle_anfangsquadrant, a.fundstelle_endquadrant, a.fundstelle_seite, a.fundstelle_pdf_url, a.fundstelle_top, a.fundstelle_top_zusatz, a.fundstelle_frage_nummer, a.fundstelle_verteildatum, a.created_at, a.updated_at, a.fundstelle_xml_url, a.deleted_at,
     COALESCE(
         json_agg(
             DISTINCT jsonb_build_object(
@@ -405,6 +442,8 @@ type ListAktivitaetenRow struct {
 	FundstelleVerteildatum    sql.NullTime   `json:"fundstelle_verteildatum"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
 	Deskriptor                interface{}    `json:"deskriptor"`
 	Vorgangsbezug             interface{}    `json:"vorgangsbezug"`
 }
@@ -458,6 +497,8 @@ func (q *Queries) ListAktivitaeten(ctx context.Context, arg ListAktivitaetenPara
 			&i.FundstelleVerteildatum,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 			&i.Deskriptor,
 			&i.Vorgangsbezug,
 		); err != nil {
@@ -482,9 +523,10 @@ SET
     aktualisiert = $4,
     abstract = $5,
     vorgangsbezug_anzahl = $6,
+    deleted_at = NULL,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, titel, aktivitaetsart, typ, dokumentart, datum, aktualisiert, abstract, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at
+RETURNING id, titel, aktivitaetsart, typ, dokumentart, datum, aktualisiert, abstract, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url, deleted_at
 `
 
 type UpdateAktivitaetParams struct {
@@ -536,6 +578,120 @@ func (q *Queries) UpdateAktivitaet(ctx context.Context, arg UpdateAktivitaetPara
 		&i.FundstelleVerteildatum,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
+
+const upsertAktivitaet = `-- name: UpsertAktivitaet :exec
+INSERT INTO aktivitaet (
+    id, titel, aktivitaetsart, typ, dokumentart, datum, aktualisiert,
+    abstract, vorgangsbezug_anzahl, wahlperiode,
+    fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+    fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
+    fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
+    fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_frage_nummer, fundstelle_verteildatum
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+    $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+    $21, $22, $23, $24, $25, $26, $27, $28
+) ON CONFLICT (id) DO UPDATE
+SET
+    titel = excluded.titel,
+    aktivitaetsart = excluded.aktivitaetsart,
+    typ = excluded.typ,
+    dokumentart = excluded.dokumentart,
+    datum = excluded.datum,
+    aktualisiert = excluded.aktualisiert,
+    abstract = excluded.abstract,
+    vorgangsbezug_anzahl = excluded.vorgangsbezug_anzahl,
+    wahlperiode = excluded.wahlperiode,
+    fundstelle_dokumentnummer = excluded.fundstelle_dokumentnummer,
+    fundstelle_datum = excluded.fundstelle_datum,
+    fundstelle_dokumentart = excluded.fundstelle_dokumentart,
+    fundstelle_herausgeber = excluded.fundstelle_herausgeber,
+    fundstelle_id = excluded.fundstelle_id,
+    fundstelle_drucksachetyp = excluded.fundstelle_drucksachetyp,
+    fundstelle_anlagen = excluded.fundstelle_anlagen,
+    fundstelle_anfangsseite = excluded.fundstelle_anfangsseite,
+    fundstelle_endseite = excluded.fundstelle_endseite,
+    fundstelle_anfangsquadrant = excluded.fundstelle_anfangsquadrant,
+    fundstelle_endquadrant = excluded.fundstelle_endquadrant,
+    fundstelle_seite = excluded.fundstelle_seite,
+    fundstelle_pdf_url = excluded.fundstelle_pdf_url,
+    fundstelle_xml_url = excluded.fundstelle_xml_url,
+    fundstelle_top = excluded.fundstelle_top,
+    fundstelle_top_zusatz = excluded.fundstelle_top_zusatz,
+    fundstelle_frage_nummer = excluded.fundstelle_frage_nummer,
+    fundstelle_verteildatum = excluded.fundstelle_verteildatum,
+    deleted_at = NULL,
+    updated_at = NOW()
+`
+
+type UpsertAktivitaetParams struct {
+	ID                        string         `json:"id"`
+	Titel                     string         `json:"titel"`
+	Aktivitaetsart            string         `json:"aktivitaetsart"`
+	Typ                       string         `json:"typ"`
+	Dokumentart               string         `json:"dokumentart"`
+	Datum                     time.Time      `json:"datum"`
+	Aktualisiert              time.Time      `json:"aktualisiert"`
+	Abstract                  sql.NullString `json:"abstract"`
+	VorgangsbezugAnzahl       int32          `json:"vorgangsbezug_anzahl"`
+	Wahlperiode               int32          `json:"wahlperiode"`
+	FundstelleDokumentnummer  string         `json:"fundstelle_dokumentnummer"`
+	FundstelleDatum           time.Time      `json:"fundstelle_datum"`
+	FundstelleDokumentart     string         `json:"fundstelle_dokumentart"`
+	FundstelleHerausgeber     string         `json:"fundstelle_herausgeber"`
+	FundstelleID              string         `json:"fundstelle_id"`
+	FundstelleDrucksachetyp   sql.NullString `json:"fundstelle_drucksachetyp"`
+	FundstelleAnlagen         sql.NullString `json:"fundstelle_anlagen"`
+	FundstelleAnfangsseite    sql.NullInt32  `json:"fundstelle_anfangsseite"`
+	FundstelleEndseite        sql.NullInt32  `json:"fundstelle_endseite"`
+	FundstelleAnfangsquadrant sql.NullString `json:"fundstelle_anfangsquadrant"`
+	FundstelleEndquadrant     sql.NullString `json:"fundstelle_endquadrant"`
+	FundstelleSeite           sql.NullString `json:"fundstelle_seite"`
+	FundstellePdfUrl          sql.NullString `json:"fundstelle_pdf_url"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	FundstelleTop             sql.NullInt32  `json:"fundstelle_top"`
+	FundstelleTopZusatz       sql.NullString `json:"fundstelle_top_zusatz"`
+	FundstelleFrageNummer     sql.NullString `json:"fundstelle_frage_nummer"`
+	FundstelleVerteildatum    sql.NullTime   `json:"fundstelle_verteildatum"`
+}
+
+func (q *Queries) UpsertAktivitaet(ctx context.Context, arg UpsertAktivitaetParams) error {
+	_, err := q.db.ExecContext(ctx, upsertAktivitaet,
+		arg.ID,
+		arg.Titel,
+		arg.Aktivitaetsart,
+		arg.Typ,
+		arg.Dokumentart,
+		arg.Datum,
+		arg.Aktualisiert,
+		arg.Abstract,
+		arg.VorgangsbezugAnzahl,
+		arg.Wahlperiode,
+		arg.FundstelleDokumentnummer,
+		arg.FundstelleDatum,
+		arg.FundstelleDokumentart,
+		arg.FundstelleHerausgeber,
+		arg.FundstelleID,
+		arg.FundstelleDrucksachetyp,
+		arg.FundstelleAnlagen,
+		arg.FundstelleAnfangsseite,
+		arg.FundstelleEndseite,
+		arg.FundstelleAnfangsquadrant,
+		arg.FundstelleEndquadrant,
+		arg.FundstelleSeite,
+		arg.FundstellePdfUrl,
+		arg.FundstelleXmlUrl,
+		arg.FundstelleTop,
+		arg.FundstelleTopZusatz,
+		arg.FundstelleFrageNummer,
+		arg.FundstelleVerteildatum,
+	)
+	return err
+}
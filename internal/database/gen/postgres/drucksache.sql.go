@@ -57,13 +57,13 @@ INSERT INTO drucksache (
     fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
     fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
     fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
-    fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
     fundstelle_frage_nummer, fundstelle_verteildatum
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
     $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
-    $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31
-) RETURNING id, titel, dokumentnummer, dokumentart, typ, drucksachetyp, herausgeber, datum, aktualisiert, anlagen, autoren_anzahl, vorgangsbezug_anzahl, pdf_hash, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at
+    $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32
+) RETURNING id, titel, dokumentnummer, dokumentart, typ, drucksachetyp, herausgeber, datum, aktualisiert, anlagen, autoren_anzahl, vorgangsbezug_anzahl, pdf_hash, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url, deleted_at
 `
 
 type CreateDrucksacheParams struct {
@@ -94,6 +94,7 @@ type CreateDrucksacheParams struct {
 	FundstelleEndquadrant     sql.NullString `json:"fundstelle_endquadrant"`
 	FundstelleSeite           sql.NullString `json:"fundstelle_seite"`
 	FundstellePdfUrl          sql.NullString `json:"fundstelle_pdf_url"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
 	FundstelleTop             sql.NullInt32  `json:"fundstelle_top"`
 	FundstelleTopZusatz       sql.NullString `json:"fundstelle_top_zusatz"`
 	FundstelleFrageNummer     sql.NullString `json:"fundstelle_frage_nummer"`
@@ -129,6 +130,7 @@ func (q *Queries) CreateDrucksache(ctx context.Context, arg CreateDrucksachePara
 		arg.FundstelleEndquadrant,
 		arg.FundstelleSeite,
 		arg.FundstellePdfUrl,
+		arg.FundstelleXmlUrl,
 		arg.FundstelleTop,
 		arg.FundstelleTopZusatz,
 		arg.FundstelleFrageNummer,
@@ -169,6 +171,8 @@ func (q *Queries) CreateDrucksache(ctx context.Context, arg CreateDrucksachePara
 		&i.FundstelleVerteildatum,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -291,9 +295,54 @@ func (q *Queries) DeleteDrucksache(ctx context.Context, id string) error {
 	return err
 }
 
+const deleteDrucksacheAutorAnzeigen = `-- name: DeleteDrucksacheAutorAnzeigen :exec
+DELETE FROM drucksache_autor_anzeige WHERE drucksache_id = $1
+`
+
+func (q *Queries) DeleteDrucksacheAutorAnzeigen(ctx context.Context, drucksacheID string) error {
+	_, err := q.db.ExecContext(ctx, deleteDrucksacheAutorAnzeigen, drucksacheID)
+	return err
+}
+
+const deleteDrucksacheFundstelleUrheber = `-- name: DeleteDrucksacheFundstelleUrheber :exec
+DELETE FROM fundstelle_urheber WHERE drucksache_id = $1
+`
+
+func (q *Queries) DeleteDrucksacheFundstelleUrheber(ctx context.Context, drucksacheID sql.NullString) error {
+	_, err := q.db.ExecContext(ctx, deleteDrucksacheFundstelleUrheber, drucksacheID)
+	return err
+}
+
+const deleteDrucksacheRessorts = `-- name: DeleteDrucksacheRessorts :exec
+DELETE FROM drucksache_ressort WHERE drucksache_id = $1
+`
+
+func (q *Queries) DeleteDrucksacheRessorts(ctx context.Context, drucksacheID string) error {
+	_, err := q.db.ExecContext(ctx, deleteDrucksacheRessorts, drucksacheID)
+	return err
+}
+
+const deleteDrucksacheUrheber = `-- name: DeleteDrucksacheUrheber :exec
+DELETE FROM drucksache_urheber WHERE drucksache_id = $1
+`
+
+func (q *Queries) DeleteDrucksacheUrheber(ctx context.Context, drucksacheID string) error {
+	_, err := q.db.ExecContext(ctx, deleteDrucksacheUrheber, drucksacheID)
+	return err
+}
+
+const deleteDrucksacheVorgangsbezuege = `-- name: DeleteDrucksacheVorgangsbezuege :exec
+DELETE FROM drucksache_vorgangsbezug WHERE drucksache_id = $1
+`
+
+func (q *Queries) DeleteDrucksacheVorgangsbezuege(ctx context.Context, drucksacheID string) error {
+	_, err := q.db.ExecContext(ctx, deleteDrucksacheVorgangsbezuege, drucksacheID)
+	return err
+}
+
 const getDrucksache = `-- name: GetDrucksache :one
 SELECT 
-    d.id, d.titel, d.dokumentnummer, d.dokumentart, d.typ, d.drucksachetyp, d.herausgeber, d.datum, d.aktualisiert, d.anlagen, d.autoren_anzahl, d.vorgangsbezug_anzahl, d.pdf_hash, d.wahlperiode, d.fundstelle_dokumentnummer, d.fundstelle_datum, d.fundstelle_dokumentart, d.fundstelle_herausgeber, d.fundstelle_id, d.fundstelle_drucksachetyp, d.fundstelle_anlagen, d.fundstelle_anfangsseite, d.fundstelle_endseite, d.fundstelle_anfangsquadrant, d.fundstelle_endquadrant, d.fundstelle_seite, d.fundstelle_pdf_url, d.fundstelle_top, d.fundstelle_top_zusatz, d.fundstelle_frage_nummer, d.fundstelle_verteildatum, d.created_at, d.updated_at,
+    d.id, d.titel, d.dokumentnummer, d.dokumentart, d.typ, d.drucksachetyp, d.herausgeber, d.datum, d.aktualisiert, d.anlagen, d.autoren_anzahl, d.vorgangsbezug_anzahl, d.pdf_hash, d.wahlperiode, d.fundstelle_dokumentnummer, d.fundstelle_datum, d.fundstelle_dokumentart, d.fundstelle_herausgeber, d.fundstelle_id, d.fundstelle_drucksachetyp, d.fundstelle_anlagen, d.fundstelle_anfangsseite, d.fundstelle_endseite, d.fundstelle_anfangsquadrant, d.fundstelle_endquadrant, d.fundstelle_seite, d.fundstelle_pdf_url, d.fundstelle_top, d.fundstelle_top_zusatz, d.fundstelle_frage_nummer, d.fundstelle_verteildatum, d.created_at, d.updated_at, d.fundstelle_xml_url, d.deleted_at,
     COALESCE(
         json_agg(
             DISTINCT jsonb_build_object(
@@ -379,6 +428,8 @@ type GetDrucksacheRow struct {
 	FundstelleVerteildatum    sql.NullTime   `json:"fundstelle_verteildatum"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
 	AutorenAnzeige            interface{}    `json:"autoren_anzeige"`
 	Ressort                   interface{}    `json:"ressort"`
 	Urheber                   interface{}    `json:"urheber"`
@@ -422,6 +473,8 @@ func (q *Queries) GetDrucksache(ctx context.Context, id string) (GetDrucksacheRo
 		&i.FundstelleVerteildatum,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 		&i.AutorenAnzeige,
 		&i.Ressort,
 		&i.Urheber,
@@ -430,9 +483,20 @@ func (q *Queries) GetDrucksache(ctx context.Context, id string) (GetDrucksacheRo
 	return i, err
 }
 
+const getLatestDrucksacheDatum = `-- name: GetLatestDrucksacheDatum :one
+SELECT MIN(datum) AS datum FROM drucksache
+`
+
+func (q *Queries) GetLatestDrucksacheDatum(ctx context.Context) (interface{}, error) {
+	row := q.db.QueryRowContext(ctx, getLatestDrucksacheDatum)
+	var datum interface{}
+	err := row.Scan(&datum)
+	return datum, err
+}
+
 const listDrucksachen = `-- name: ListDrucksachen :many
 SELECT 
-    d.id, d.titel, d.dokumentnummer, d.dokumentart, d.typ, d.drucksachetyp, d.herausgeber, d.datum, d.aktualisiert, d.anlagen, d.autoren_anzahl, d.vorgangsbezug_anzahl, d.pdf_hash, d.wahlperiode, d.fundstelle_dokumentnummer, d.fundstelle_datum, d.fundstelle_dokumentart, d.fundstelle_herausgeber, d.fundstelle_id, d.fundstelle_drucksachetyp, d.fundstelle_anlagen, d.fundstelle_anfangsseite, d.fundstelle_endseite, d.fundstelle_anfangsquadrant, d.fundstelle_endquadrant, d.fundstelle_seite, d.fundstelle_pdf_url, d.fundstelle_top, d.fundstelle_top_zusatz, d.fundstelle_frage_nummer, d.fundstelle_verteildatum, d.created_at, d.updated_at,
+    d.id, d.titel, d.dokumentnummer, d.dokumentart, d.typ, d.drucksachetyp, d.herausgeber, d.datum, d.aktualisiert, d.anlagen, d.autoren_anzahl, d.vorgangsbezug_anzahl, d.pdf_hash, d.wahlperiode, d.fundstelle_dokumentnummer, d.fundstelle_datum, d.fundstelle_dokumentart, d.fundstelle_herausgeber, d.fundstelle_id, d.fundstelle_drucksachetyp, d.fundstelle_anlagen, d.fundstelle_anfangsseite, d.fundstelle_endseite, d.fundstelle_anfangsquadrant, d.fundstelle_endquadrant, d.fundstelle_seite, d.fundstelle_pdf_url, d.fundstelle_top, d.fundstelle_top_zusatz, d.fundstelle_frage_nummer, d.fundstelle_verteildatum, d.created_at, d.updated_at, d.fundstelle_xml_url, d.deleted_at,
     COALESCE(
         json_agg(
             DISTINCT jsonb_build_object(
@@ -539,6 +603,8 @@ type ListDrucksachenRow struct {
 	FundstelleVerteildatum    sql.NullTime   `json:"fundstelle_verteildatum"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
 	AutorenAnzeige            interface{}    `json:"autoren_anzeige"`
 	Ressort                   interface{}    `json:"ressort"`
 	Urheber                   interface{}    `json:"urheber"`
@@ -598,6 +664,8 @@ func (q *Queries) ListDrucksachen(ctx context.Context, arg ListDrucksachenParams
 			&i.FundstelleVerteildatum,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 			&i.AutorenAnzeige,
 			&i.Ressort,
 			&i.Urheber,
@@ -625,9 +693,10 @@ SET
     autoren_anzahl = $5,
     vorgangsbezug_anzahl = $6,
     pdf_hash = $7,
+    deleted_at = NULL,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, titel, dokumentnummer, dokumentart, typ, drucksachetyp, herausgeber, datum, aktualisiert, anlagen, autoren_anzahl, vorgangsbezug_anzahl, pdf_hash, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at
+RETURNING id, titel, dokumentnummer, dokumentart, typ, drucksachetyp, herausgeber, datum, aktualisiert, anlagen, autoren_anzahl, vorgangsbezug_anzahl, pdf_hash, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url, deleted_at
 `
 
 type UpdateDrucksacheParams struct {
@@ -685,6 +754,133 @@ func (q *Queries) UpdateDrucksache(ctx context.Context, arg UpdateDrucksachePara
 		&i.FundstelleVerteildatum,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
+
+const upsertDrucksache = `-- name: UpsertDrucksache :exec
+INSERT INTO drucksache (
+    id, titel, dokumentnummer, dokumentart, typ, drucksachetyp, herausgeber,
+    datum, aktualisiert, anlagen, autoren_anzahl, vorgangsbezug_anzahl,
+    pdf_hash, wahlperiode,
+    fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+    fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
+    fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
+    fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_frage_nummer, fundstelle_verteildatum
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+    $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+    $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32
+) ON CONFLICT (id) DO UPDATE
+SET
+    titel = excluded.titel,
+    dokumentnummer = excluded.dokumentnummer,
+    dokumentart = excluded.dokumentart,
+    typ = excluded.typ,
+    drucksachetyp = excluded.drucksachetyp,
+    herausgeber = excluded.herausgeber,
+    datum = excluded.datum,
+    aktualisiert = excluded.aktualisiert,
+    anlagen = excluded.anlagen,
+    autoren_anzahl = excluded.autoren_anzahl,
+    vorgangsbezug_anzahl = excluded.vorgangsbezug_anzahl,
+    pdf_hash = excluded.pdf_hash,
+    wahlperiode = excluded.wahlperiode,
+    fundstelle_dokumentnummer = excluded.fundstelle_dokumentnummer,
+    fundstelle_datum = excluded.fundstelle_datum,
+    fundstelle_dokumentart = excluded.fundstelle_dokumentart,
+    fundstelle_herausgeber = excluded.fundstelle_herausgeber,
+    fundstelle_id = excluded.fundstelle_id,
+    fundstelle_drucksachetyp = excluded.fundstelle_drucksachetyp,
+    fundstelle_anlagen = excluded.fundstelle_anlagen,
+    fundstelle_anfangsseite = excluded.fundstelle_anfangsseite,
+    fundstelle_endseite = excluded.fundstelle_endseite,
+    fundstelle_anfangsquadrant = excluded.fundstelle_anfangsquadrant,
+    fundstelle_endquadrant = excluded.fundstelle_endquadrant,
+    fundstelle_seite = excluded.fundstelle_seite,
+    fundstelle_pdf_url = excluded.fundstelle_pdf_url,
+    fundstelle_xml_url = excluded.fundstelle_xml_url,
+    fundstelle_top = excluded.fundstelle_top,
+    fundstelle_top_zusatz = excluded.fundstelle_top_zusatz,
+    fundstelle_frage_nummer = excluded.fundstelle_frage_nummer,
+    fundstelle_verteildatum = excluded.fundstelle_verteildatum,
+    deleted_at = NULL,
+    updated_at = NOW()
+`
+
+type UpsertDrucksacheParams struct {
+	ID                        string         `json:"id"`
+	Titel                     string         `json:"titel"`
+	Dokumentnummer            string         `json:"dokumentnummer"`
+	Dokumentart               string         `json:"dokumentart"`
+	Typ                       string         `json:"typ"`
+	Drucksachetyp             string         `json:"drucksachetyp"`
+	Herausgeber               string         `json:"herausgeber"`
+	Datum                     time.Time      `json:"datum"`
+	Aktualisiert              time.Time      `json:"aktualisiert"`
+	Anlagen                   sql.NullString `json:"anlagen"`
+	AutorenAnzahl             int32          `json:"autoren_anzahl"`
+	VorgangsbezugAnzahl       int32          `json:"vorgangsbezug_anzahl"`
+	PdfHash                   sql.NullString `json:"pdf_hash"`
+	Wahlperiode               sql.NullInt32  `json:"wahlperiode"`
+	FundstelleDokumentnummer  string         `json:"fundstelle_dokumentnummer"`
+	FundstelleDatum           time.Time      `json:"fundstelle_datum"`
+	FundstelleDokumentart     string         `json:"fundstelle_dokumentart"`
+	FundstelleHerausgeber     string         `json:"fundstelle_herausgeber"`
+	FundstelleID              string         `json:"fundstelle_id"`
+	FundstelleDrucksachetyp   sql.NullString `json:"fundstelle_drucksachetyp"`
+	FundstelleAnlagen         sql.NullString `json:"fundstelle_anlagen"`
+	FundstelleAnfangsseite    sql.NullInt32  `json:"fundstelle_anfangsseite"`
+	FundstelleEndseite        sql.NullInt32  `json:"fundstelle_endseite"`
+	FundstelleAnfangsquadrant sql.NullString `json:"fundstelle_anfangsquadrant"`
+	FundstelleEndquadrant     sql.NullString `json:"fundstelle_endquadrant"`
+	FundstelleSeite           sql.NullString `json:"fundstelle_seite"`
+	FundstellePdfUrl          sql.NullString `json:"fundstelle_pdf_url"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	FundstelleTop             sql.NullInt32  `json:"fundstelle_top"`
+	FundstelleTopZusatz       sql.NullString `json:"fundstelle_top_zusatz"`
+	FundstelleFrageNummer     sql.NullString `json:"fundstelle_frage_nummer"`
+	FundstelleVerteildatum    sql.NullTime   `json:"fundstelle_verteildatum"`
+}
+
+func (q *Queries) UpsertDrucksache(ctx context.Context, arg UpsertDrucksacheParams) error {
+	_, err := q.db.ExecContext(ctx, upsertDrucksache,
+		arg.ID,
+		arg.Titel,
+		arg.Dokumentnummer,
+		arg.Dokumentart,
+		arg.Typ,
+		arg.Drucksachetyp,
+		arg.Herausgeber,
+		arg.Datum,
+		arg.Aktualisiert,
+		arg.Anlagen,
+		arg.AutorenAnzahl,
+		arg.VorgangsbezugAnzahl,
+		arg.PdfHash,
+		arg.Wahlperiode,
+		arg.FundstelleDokumentnummer,
+		arg.FundstelleDatum,
+		arg.FundstelleDokumentart,
+		arg.FundstelleHerausgeber,
+		arg.FundstelleID,
+		arg.FundstelleDrucksachetyp,
+		arg.FundstelleAnlagen,
+		arg.FundstelleAnfangsseite,
+		arg.FundstelleEndseite,
+		arg.FundstelleAnfangsquadrant,
+		arg.FundstelleEndquadrant,
+		arg.FundstelleSeite,
+		arg.FundstellePdfUrl,
+		arg.FundstelleXmlUrl,
+		arg.FundstelleTop,
+		arg.FundstelleTopZusatz,
+		arg.FundstelleFrageNummer,
+		arg.FundstelleVerteildatum,
+	)
+	return err
+}
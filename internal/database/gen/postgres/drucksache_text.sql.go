@@ -86,7 +86,7 @@ func (q *Queries) DeleteDrucksacheText(ctx context.Context, id string) error {
 
 const getDrucksacheText = `-- name: GetDrucksacheText :one
 SELECT 
-    d.id, d.titel, d.dokumentnummer, d.dokumentart, d.typ, d.drucksachetyp, d.herausgeber, d.datum, d.aktualisiert, d.anlagen, d.autoren_anzahl, d.vorgangsbezug_anzahl, d.pdf_hash, d.wahlperiode, d.fundstelle_dokumentnummer, d.fundstelle_datum, d.fundstelle_dokumentart, d.fundstelle_herausgeber, d.fundstelle_id, d.fundstelle_drucksachetyp, d.fundstelle_anlagen, d.fundstelle_anfangsseite, d.fundstelle_endseite, d.fundstelle_anfangsquadrant, d.fundstelle_endquadrant, d.fundstelle_seite, d.fundstelle_pdf_url, d.fundstelle_top, d.fundstelle_top_zusatz, d.fundstelle_frage_nummer, d.fundstelle_verteildatum, d.created_at, d.updated_at,
+    d.id, d.titel, d.dokumentnummer, d.dokumentart, d.typ, d.drucksachetyp, d.herausgeber, d.datum, d.aktualisiert, d.anlagen, d.autoren_anzahl, d.vorgangsbezug_anzahl, d.pdf_hash, d.wahlperiode, d.fundstelle_dokumentnummer, d.fundstelle_datum, d.fundstelle_dokumentart, d.fundstelle_herausgeber, d.fundstelle_id, d.fundstelle_drucksachetyp, d.fundstelle_anlagen, d.fundstelle_anfangsseite, d.fundstelle_endseite, d.fundstelle_anfangsquadrant, d.fundstelle_endquadrant, d.fundstelle_seite, d.fundstelle_pdf_url, d.fundstelle_top, d.fundstelle_top_zusatz, d.fundstelle_frage_nummer, d.fundstelle_verteildatum, d.created_at, d.updated_at, d.fundstelle_xml_url, d.deleted_at,
     dt.text,
     COALESCE(
         json_agg(
@@ -174,6 +174,8 @@ type GetDrucksacheTextRow struct {
 	FundstelleVerteildatum    sql.NullTime   `json:"fundstelle_verteildatum"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
 	Text                      sql.NullString `json:"text"`
 	AutorenAnzeige            interface{}    `json:"autoren_anzeige"`
 	Ressort                   interface{}    `json:"ressort"`
@@ -218,6 +220,8 @@ func (q *Queries) GetDrucksacheText(ctx context.Context, id string) (GetDrucksac
 		&i.FundstelleVerteildatum,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 		&i.Text,
 		&i.AutorenAnzeige,
 		&i.Ressort,
@@ -229,7 +233,7 @@ func (q *Queries) GetDrucksacheText(ctx context.Context, id string) (GetDrucksac
 
 const listDrucksacheTexte = `-- name: ListDrucksacheTexte :many
 SELECT 
-    d.id, d.titel, d.dokumentnummer, d.dokumentart, d.typ, d.drucksachetyp, d.herausgeber, d.datum, d.aktualisiert, d.anlagen, d.autoren_anzahl, d.vorgangsbezug_anzahl, d.pdf_hash, d.wahlperiode, d.fundstelle_dokumentnummer, d.fundstelle_datum, d.fundstelle_dokumentart, d.fundstelle_herausgeber, d.fundstelle_id, d.fundstelle_drucksachetyp, d.fundstelle_anlagen, d.fundstelle_anfangsseite, d.fundstelle_endseite, d.fundstelle_anfangsquadrant, d.fundstelle_endquadrant, d.fundstelle_seite, d.fundstelle_pdf_url, d.fundstelle_top, d.fundstelle_top_zusatz, d.fundstelle_frage_nummer, d.fundstelle_verteildatum, d.created_at, d.updated_at,
+    d.id, d.titel, d.dokumentnummer, d.dokumentart, d.typ, d.drucksachetyp, d.herausgeber, d.datum, d.aktualisiert, d.anlagen, d.autoren_anzahl, d.vorgangsbezug_anzahl, d.pdf_hash, d.wahlperiode, d.fundstelle_dokumentnummer, d.fundstelle_datum, d.fundstelle_dokumentart, d.fundstelle_herausgeber, d.fundstelle_id, d.fundstelle_drucksachetyp, d.fundstelle_anlagen, d.fundstelle_anfangsseite, d.fundstelle_endseite, d.fundstelle_anfangsquadrant, d.fundstelle_endquadrant, d.fundstelle_seite, d.fundstelle_pdf_url, d.fundstelle_top, d.fundstelle_top_zusatz, d.fundstelle_frage_nummer, d.fundstelle_verteildatum, d.created_at, d.updated_at, d.fundstelle_xml_url, d.deleted_at,
     dt.text,
     COALESCE(
         json_agg(
@@ -338,6 +342,8 @@ type ListDrucksacheTexteRow struct {
 	FundstelleVerteildatum    sql.NullTime   `json:"fundstelle_verteildatum"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
 	Text                      sql.NullString `json:"text"`
 	AutorenAnzeige            interface{}    `json:"autoren_anzeige"`
 	Ressort                   interface{}    `json:"ressort"`
@@ -398,6 +404,8 @@ func (q *Queries) ListDrucksacheTexte(ctx context.Context, arg ListDrucksacheTex
 			&i.FundstelleVerteildatum,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 			&i.Text,
 			&i.AutorenAnzeige,
 			&i.Ressort,
@@ -0,0 +1,564 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: history.sql
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const createAktivitaetHistory = `-- name: CreateAktivitaetHistory :exec
+INSERT INTO aktivitaet_history (aktivitaet_id, aktualisiert, sync_run_id, snapshot, diff)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateAktivitaetHistoryParams struct {
+	AktivitaetID string          `json:"aktivitaet_id"`
+	Aktualisiert time.Time       `json:"aktualisiert"`
+	SyncRunID    string          `json:"sync_run_id"`
+	Snapshot     json.RawMessage `json:"snapshot"`
+	Diff         json.RawMessage `json:"diff"`
+}
+
+func (q *Queries) CreateAktivitaetHistory(ctx context.Context, arg CreateAktivitaetHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, createAktivitaetHistory,
+		arg.AktivitaetID,
+		arg.Aktualisiert,
+		arg.SyncRunID,
+		arg.Snapshot,
+		arg.Diff,
+	)
+	return err
+}
+
+const createDrucksacheHistory = `-- name: CreateDrucksacheHistory :exec
+INSERT INTO drucksache_history (drucksache_id, aktualisiert, sync_run_id, snapshot, diff)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateDrucksacheHistoryParams struct {
+	DrucksacheID string          `json:"drucksache_id"`
+	Aktualisiert time.Time       `json:"aktualisiert"`
+	SyncRunID    string          `json:"sync_run_id"`
+	Snapshot     json.RawMessage `json:"snapshot"`
+	Diff         json.RawMessage `json:"diff"`
+}
+
+func (q *Queries) CreateDrucksacheHistory(ctx context.Context, arg CreateDrucksacheHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, createDrucksacheHistory,
+		arg.DrucksacheID,
+		arg.Aktualisiert,
+		arg.SyncRunID,
+		arg.Snapshot,
+		arg.Diff,
+	)
+	return err
+}
+
+const createPersonHistory = `-- name: CreatePersonHistory :exec
+INSERT INTO person_history (person_id, aktualisiert, sync_run_id, snapshot, diff)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreatePersonHistoryParams struct {
+	PersonID     string          `json:"person_id"`
+	Aktualisiert time.Time       `json:"aktualisiert"`
+	SyncRunID    string          `json:"sync_run_id"`
+	Snapshot     json.RawMessage `json:"snapshot"`
+	Diff         json.RawMessage `json:"diff"`
+}
+
+func (q *Queries) CreatePersonHistory(ctx context.Context, arg CreatePersonHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, createPersonHistory,
+		arg.PersonID,
+		arg.Aktualisiert,
+		arg.SyncRunID,
+		arg.Snapshot,
+		arg.Diff,
+	)
+	return err
+}
+
+const createPlenarprotokollHistory = `-- name: CreatePlenarprotokollHistory :exec
+INSERT INTO plenarprotokoll_history (plenarprotokoll_id, aktualisiert, sync_run_id, snapshot, diff)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreatePlenarprotokollHistoryParams struct {
+	PlenarprotokollID string          `json:"plenarprotokoll_id"`
+	Aktualisiert      time.Time       `json:"aktualisiert"`
+	SyncRunID         string          `json:"sync_run_id"`
+	Snapshot          json.RawMessage `json:"snapshot"`
+	Diff              json.RawMessage `json:"diff"`
+}
+
+func (q *Queries) CreatePlenarprotokollHistory(ctx context.Context, arg CreatePlenarprotokollHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, createPlenarprotokollHistory,
+		arg.PlenarprotokollID,
+		arg.Aktualisiert,
+		arg.SyncRunID,
+		arg.Snapshot,
+		arg.Diff,
+	)
+	return err
+}
+
+const createSyncRun = `-- name: CreateSyncRun :exec
+
+INSERT INTO sync_run (id, resource_name)
+VALUES ($1, $2)
+ON CONFLICT (id) DO NOTHING
+`
+
+type CreateSyncRunParams struct {
+	ID           string `json:"id"`
+	ResourceName string `json:"resource_name"`
+}
+
+// Queries for the optional change history of synced entities.
+func (q *Queries) CreateSyncRun(ctx context.Context, arg CreateSyncRunParams) error {
+	_, err := q.db.ExecContext(ctx, createSyncRun, arg.ID, arg.ResourceName)
+	return err
+}
+
+const createVorgangHistory = `-- name: CreateVorgangHistory :exec
+INSERT INTO vorgang_history (vorgang_id, aktualisiert, sync_run_id, snapshot, diff)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateVorgangHistoryParams struct {
+	VorgangID    string          `json:"vorgang_id"`
+	Aktualisiert time.Time       `json:"aktualisiert"`
+	SyncRunID    string          `json:"sync_run_id"`
+	Snapshot     json.RawMessage `json:"snapshot"`
+	Diff         json.RawMessage `json:"diff"`
+}
+
+func (q *Queries) CreateVorgangHistory(ctx context.Context, arg CreateVorgangHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, createVorgangHistory,
+		arg.VorgangID,
+		arg.Aktualisiert,
+		arg.SyncRunID,
+		arg.Snapshot,
+		arg.Diff,
+	)
+	return err
+}
+
+const createVorgangspositionHistory = `-- name: CreateVorgangspositionHistory :exec
+INSERT INTO vorgangsposition_history (vorgangsposition_id, aktualisiert, sync_run_id, snapshot, diff)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateVorgangspositionHistoryParams struct {
+	VorgangspositionID string          `json:"vorgangsposition_id"`
+	Aktualisiert       time.Time       `json:"aktualisiert"`
+	SyncRunID          string          `json:"sync_run_id"`
+	Snapshot           json.RawMessage `json:"snapshot"`
+	Diff               json.RawMessage `json:"diff"`
+}
+
+func (q *Queries) CreateVorgangspositionHistory(ctx context.Context, arg CreateVorgangspositionHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, createVorgangspositionHistory,
+		arg.VorgangspositionID,
+		arg.Aktualisiert,
+		arg.SyncRunID,
+		arg.Snapshot,
+		arg.Diff,
+	)
+	return err
+}
+
+const getAktivitaetHistoryAsOf = `-- name: GetAktivitaetHistoryAsOf :one
+SELECT id, aktivitaet_id, aktualisiert, sync_run_id, snapshot, diff, recorded_at FROM aktivitaet_history
+WHERE aktivitaet_id = $1 AND aktualisiert <= $2
+ORDER BY aktualisiert DESC, id DESC
+LIMIT 1
+`
+
+type GetAktivitaetHistoryAsOfParams struct {
+	AktivitaetID string    `json:"aktivitaet_id"`
+	AsOf         time.Time `json:"as_of"`
+}
+
+// Returns the latest previous version that was current at the given time
+func (q *Queries) GetAktivitaetHistoryAsOf(ctx context.Context, arg GetAktivitaetHistoryAsOfParams) (AktivitaetHistory, error) {
+	row := q.db.QueryRowContext(ctx, getAktivitaetHistoryAsOf, arg.AktivitaetID, arg.AsOf)
+	var i AktivitaetHistory
+	err := row.Scan(
+		&i.ID,
+		&i.AktivitaetID,
+		&i.Aktualisiert,
+		&i.SyncRunID,
+		&i.Snapshot,
+		&i.Diff,
+		&i.RecordedAt,
+	)
+	return i, err
+}
+
+const getDrucksacheHistoryAsOf = `-- name: GetDrucksacheHistoryAsOf :one
+SELECT id, drucksache_id, aktualisiert, sync_run_id, snapshot, diff, recorded_at FROM drucksache_history
+WHERE drucksache_id = $1 AND aktualisiert <= $2
+ORDER BY aktualisiert DESC, id DESC
+LIMIT 1
+`
+
+type GetDrucksacheHistoryAsOfParams struct {
+	DrucksacheID string    `json:"drucksache_id"`
+	AsOf         time.Time `json:"as_of"`
+}
+
+// Returns the latest previous version that was current at the given time
+func (q *Queries) GetDrucksacheHistoryAsOf(ctx context.Context, arg GetDrucksacheHistoryAsOfParams) (DrucksacheHistory, error) {
+	row := q.db.QueryRowContext(ctx, getDrucksacheHistoryAsOf, arg.DrucksacheID, arg.AsOf)
+	var i DrucksacheHistory
+	err := row.Scan(
+		&i.ID,
+		&i.DrucksacheID,
+		&i.Aktualisiert,
+		&i.SyncRunID,
+		&i.Snapshot,
+		&i.Diff,
+		&i.RecordedAt,
+	)
+	return i, err
+}
+
+const getPersonHistoryAsOf = `-- name: GetPersonHistoryAsOf :one
+SELECT id, person_id, aktualisiert, sync_run_id, snapshot, diff, recorded_at FROM person_history
+WHERE person_id = $1 AND aktualisiert <= $2
+ORDER BY aktualisiert DESC, id DESC
+LIMIT 1
+`
+
+type GetPersonHistoryAsOfParams struct {
+	PersonID string    `json:"person_id"`
+	AsOf     time.Time `json:"as_of"`
+}
+
+// Returns the latest previous version that was current at the given time
+func (q *Queries) GetPersonHistoryAsOf(ctx context.Context, arg GetPersonHistoryAsOfParams) (PersonHistory, error) {
+	row := q.db.QueryRowContext(ctx, getPersonHistoryAsOf, arg.PersonID, arg.AsOf)
+	var i PersonHistory
+	err := row.Scan(
+		&i.ID,
+		&i.PersonID,
+		&i.Aktualisiert,
+		&i.SyncRunID,
+		&i.Snapshot,
+		&i.Diff,
+		&i.RecordedAt,
+	)
+	return i, err
+}
+
+const getPlenarprotokollHistoryAsOf = `-- name: GetPlenarprotokollHistoryAsOf :one
+SELECT id, plenarprotokoll_id, aktualisiert, sync_run_id, snapshot, diff, recorded_at FROM plenarprotokoll_history
+WHERE plenarprotokoll_id = $1 AND aktualisiert <= $2
+ORDER BY aktualisiert DESC, id DESC
+LIMIT 1
+`
+
+type GetPlenarprotokollHistoryAsOfParams struct {
+	PlenarprotokollID string    `json:"plenarprotokoll_id"`
+	AsOf              time.Time `json:"as_of"`
+}
+
+// Returns the latest previous version that was current at the given time
+func (q *Queries) GetPlenarprotokollHistoryAsOf(ctx context.Context, arg GetPlenarprotokollHistoryAsOfParams) (PlenarprotokollHistory, error) {
+	row := q.db.QueryRowContext(ctx, getPlenarprotokollHistoryAsOf, arg.PlenarprotokollID, arg.AsOf)
+	var i PlenarprotokollHistory
+	err := row.Scan(
+		&i.ID,
+		&i.PlenarprotokollID,
+		&i.Aktualisiert,
+		&i.SyncRunID,
+		&i.Snapshot,
+		&i.Diff,
+		&i.RecordedAt,
+	)
+	return i, err
+}
+
+const getVorgangHistoryAsOf = `-- name: GetVorgangHistoryAsOf :one
+SELECT id, vorgang_id, aktualisiert, sync_run_id, snapshot, diff, recorded_at FROM vorgang_history
+WHERE vorgang_id = $1 AND aktualisiert <= $2
+ORDER BY aktualisiert DESC, id DESC
+LIMIT 1
+`
+
+type GetVorgangHistoryAsOfParams struct {
+	VorgangID string    `json:"vorgang_id"`
+	AsOf      time.Time `json:"as_of"`
+}
+
+// Returns the latest previous version that was current at the given time
+func (q *Queries) GetVorgangHistoryAsOf(ctx context.Context, arg GetVorgangHistoryAsOfParams) (VorgangHistory, error) {
+	row := q.db.QueryRowContext(ctx, getVorgangHistoryAsOf, arg.VorgangID, arg.AsOf)
+	var i VorgangHistory
+	err := row.Scan(
+		&i.ID,
+		&i.VorgangID,
+		&i.Aktualisiert,
+		&i.SyncRunID,
+		&i.Snapshot,
+		&i.Diff,
+		&i.RecordedAt,
+	)
+	return i, err
+}
+
+const getVorgangspositionHistoryAsOf = `-- name: GetVorgangspositionHistoryAsOf :one
+SELECT id, vorgangsposition_id, aktualisiert, sync_run_id, snapshot, diff, recorded_at FROM vorgangsposition_history
+WHERE vorgangsposition_id = $1 AND aktualisiert <= $2
+ORDER BY aktualisiert DESC, id DESC
+LIMIT 1
+`
+
+type GetVorgangspositionHistoryAsOfParams struct {
+	VorgangspositionID string    `json:"vorgangsposition_id"`
+	AsOf               time.Time `json:"as_of"`
+}
+
+// Returns the latest previous version that was current at the given time
+func (q *Queries) GetVorgangspositionHistoryAsOf(ctx context.Context, arg GetVorgangspositionHistoryAsOfParams) (VorgangspositionHistory, error) {
+	row := q.db.QueryRowContext(ctx, getVorgangspositionHistoryAsOf, arg.VorgangspositionID, arg.AsOf)
+	var i VorgangspositionHistory
+	err := row.Scan(
+		&i.ID,
+		&i.VorgangspositionID,
+		&i.Aktualisiert,
+		&i.SyncRunID,
+		&i.Snapshot,
+		&i.Diff,
+		&i.RecordedAt,
+	)
+	return i, err
+}
+
+const listAktivitaetHistory = `-- name: ListAktivitaetHistory :many
+SELECT id, aktivitaet_id, aktualisiert, sync_run_id, snapshot, diff, recorded_at FROM aktivitaet_history
+WHERE aktivitaet_id = $1
+ORDER BY aktualisiert DESC, id DESC
+`
+
+func (q *Queries) ListAktivitaetHistory(ctx context.Context, aktivitaetID string) ([]AktivitaetHistory, error) {
+	rows, err := q.db.QueryContext(ctx, listAktivitaetHistory, aktivitaetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AktivitaetHistory
+	for rows.Next() {
+		var i AktivitaetHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.AktivitaetID,
+			&i.Aktualisiert,
+			&i.SyncRunID,
+			&i.Snapshot,
+			&i.Diff,
+			&i.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDrucksacheHistory = `-- name: ListDrucksacheHistory :many
+SELECT id, drucksache_id, aktualisiert, sync_run_id, snapshot, diff, recorded_at FROM drucksache_history
+WHERE drucksache_id = $1
+ORDER BY aktualisiert DESC, id DESC
+`
+
+func (q *Queries) ListDrucksacheHistory(ctx context.Context, drucksacheID string) ([]DrucksacheHistory, error) {
+	rows, err := q.db.QueryContext(ctx, listDrucksacheHistory, drucksacheID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DrucksacheHistory
+	for rows.Next() {
+		var i DrucksacheHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.DrucksacheID,
+			&i.Aktualisiert,
+			&i.SyncRunID,
+			&i.Snapshot,
+			&i.Diff,
+			&i.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPersonHistory = `-- name: ListPersonHistory :many
+SELECT id, person_id, aktualisiert, sync_run_id, snapshot, diff, recorded_at FROM person_history
+WHERE person_id = $1
+ORDER BY aktualisiert DESC, id DESC
+`
+
+func (q *Queries) ListPersonHistory(ctx context.Context, personID string) ([]PersonHistory, error) {
+	rows, err := q.db.QueryContext(ctx, listPersonHistory, personID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PersonHistory
+	for rows.Next() {
+		var i PersonHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.PersonID,
+			&i.Aktualisiert,
+			&i.SyncRunID,
+			&i.Snapshot,
+			&i.Diff,
+			&i.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPlenarprotokollHistory = `-- name: ListPlenarprotokollHistory :many
+SELECT id, plenarprotokoll_id, aktualisiert, sync_run_id, snapshot, diff, recorded_at FROM plenarprotokoll_history
+WHERE plenarprotokoll_id = $1
+ORDER BY aktualisiert DESC, id DESC
+`
+
+func (q *Queries) ListPlenarprotokollHistory(ctx context.Context, plenarprotokollID string) ([]PlenarprotokollHistory, error) {
+	rows, err := q.db.QueryContext(ctx, listPlenarprotokollHistory, plenarprotokollID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PlenarprotokollHistory
+	for rows.Next() {
+		var i PlenarprotokollHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.PlenarprotokollID,
+			&i.Aktualisiert,
+			&i.SyncRunID,
+			&i.Snapshot,
+			&i.Diff,
+			&i.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listVorgangHistory = `-- name: ListVorgangHistory :many
+SELECT id, vorgang_id, aktualisiert, sync_run_id, snapshot, diff, recorded_at FROM vorgang_history
+WHERE vorgang_id = $1
+ORDER BY aktualisiert DESC, id DESC
+`
+
+func (q *Queries) ListVorgangHistory(ctx context.Context, vorgangID string) ([]VorgangHistory, error) {
+	rows, err := q.db.QueryContext(ctx, listVorgangHistory, vorgangID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []VorgangHistory
+	for rows.Next() {
+		var i VorgangHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.VorgangID,
+			&i.Aktualisiert,
+			&i.SyncRunID,
+			&i.Snapshot,
+			&i.Diff,
+			&i.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listVorgangspositionHistory = `-- name: ListVorgangspositionHistory :many
+SELECT id, vorgangsposition_id, aktualisiert, sync_run_id, snapshot, diff, recorded_at FROM vorgangsposition_history
+WHERE vorgangsposition_id = $1
+ORDER BY aktualisiert DESC, id DESC
+`
+
+func (q *Queries) ListVorgangspositionHistory(ctx context.Context, vorgangspositionID string) ([]VorgangspositionHistory, error) {
+	rows, err := q.db.QueryContext(ctx, listVorgangspositionHistory, vorgangspositionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []VorgangspositionHistory
+	for rows.Next() {
+		var i VorgangspositionHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.VorgangspositionID,
+			&i.Aktualisiert,
+			&i.SyncRunID,
+			&i.Snapshot,
+			&i.Diff,
+			&i.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -6,6 +6,7 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
@@ -39,6 +40,8 @@ type Aktivitaet struct {
 	FundstelleVerteildatum    sql.NullTime   `json:"fundstelle_verteildatum"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
 }
 
 type AktivitaetAnzeige struct {
@@ -60,6 +63,16 @@ type AktivitaetDeskriptor struct {
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+type AktivitaetHistory struct {
+	ID           int64           `json:"id"`
+	AktivitaetID string          `json:"aktivitaet_id"`
+	Aktualisiert time.Time       `json:"aktualisiert"`
+	SyncRunID    string          `json:"sync_run_id"`
+	Snapshot     json.RawMessage `json:"snapshot"`
+	Diff         json.RawMessage `json:"diff"`
+	RecordedAt   time.Time       `json:"recorded_at"`
+}
+
 type AktivitaetVorgangsbezug struct {
 	AktivitaetID     string `json:"aktivitaet_id"`
 	VorgangID        string `json:"vorgang_id"`
@@ -121,6 +134,8 @@ type Drucksache struct {
 	FundstelleVerteildatum    sql.NullTime   `json:"fundstelle_verteildatum"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
 }
 
 type DrucksacheAutorAnzeige struct {
@@ -133,6 +148,16 @@ type DrucksacheAutorAnzeige struct {
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+type DrucksacheHistory struct {
+	ID           int64           `json:"id"`
+	DrucksacheID string          `json:"drucksache_id"`
+	Aktualisiert time.Time       `json:"aktualisiert"`
+	SyncRunID    string          `json:"sync_run_id"`
+	Snapshot     json.RawMessage `json:"snapshot"`
+	Diff         json.RawMessage `json:"diff"`
+	RecordedAt   time.Time       `json:"recorded_at"`
+}
+
 type DrucksacheRessort struct {
 	DrucksacheID  string `json:"drucksache_id"`
 	RessortID     int32  `json:"ressort_id"`
@@ -177,6 +202,136 @@ type Inkrafttreten struct {
 	CreatedAt    time.Time      `json:"created_at"`
 }
 
+type MdbBiographical struct {
+	ID                            int32          `json:"id"`
+	MdbID                         string         `json:"mdb_id"`
+	Geburtsdatum                  sql.NullString `json:"geburtsdatum"`
+	Geburtsort                    sql.NullString `json:"geburtsort"`
+	Geburtsland                   sql.NullString `json:"geburtsland"`
+	Sterbedatum                   sql.NullString `json:"sterbedatum"`
+	Geschlecht                    sql.NullString `json:"geschlecht"`
+	Familienstand                 sql.NullString `json:"familienstand"`
+	Religion                      sql.NullString `json:"religion"`
+	Beruf                         sql.NullString `json:"beruf"`
+	ParteiKurz                    sql.NullString `json:"partei_kurz"`
+	VitaKurz                      sql.NullString `json:"vita_kurz"`
+	Veroeffentlichungspflichtiges sql.NullString `json:"veroeffentlichungspflichtiges"`
+	CreatedAt                     time.Time      `json:"created_at"`
+	UpdatedAt                     time.Time      `json:"updated_at"`
+}
+
+type MdbCareerSummary struct {
+	MdbID              string         `json:"mdb_id"`
+	Nachname           string         `json:"nachname"`
+	Vorname            string         `json:"vorname"`
+	ParteiKurz         sql.NullString `json:"partei_kurz"`
+	AnzahlWahlperioden int64          `json:"anzahl_wahlperioden"`
+	ErsteWahlperiode   interface{}    `json:"erste_wahlperiode"`
+	LetzteWahlperiode  interface{}    `json:"letzte_wahlperiode"`
+	MandatBeginn       interface{}    `json:"mandat_beginn"`
+	MandatEnde         interface{}    `json:"mandat_ende"`
+	AnzahlFraktionen   int64          `json:"anzahl_fraktionen"`
+}
+
+type MdbCurrentMember struct {
+	MdbID       string         `json:"mdb_id"`
+	Nachname    string         `json:"nachname"`
+	Vorname     string         `json:"vorname"`
+	Ortszusatz  sql.NullString `json:"ortszusatz"`
+	AnredeTitel sql.NullString `json:"anrede_titel"`
+	AkadTitel   sql.NullString `json:"akad_titel"`
+	ParteiKurz  sql.NullString `json:"partei_kurz"`
+	Geschlecht  sql.NullString `json:"geschlecht"`
+	Wahlperiode int32          `json:"wahlperiode"`
+	Mandatsart  sql.NullString `json:"mandatsart"`
+	WkrName     sql.NullString `json:"wkr_name"`
+	WkrLand     sql.NullString `json:"wkr_land"`
+}
+
+type MdbFraktionSummary struct {
+	Fraktion         string `json:"fraktion"`
+	Wahlperiode      int32  `json:"wahlperiode"`
+	AnzahlMitglieder int64  `json:"anzahl_mitglieder"`
+	Direktmandate    int64  `json:"direktmandate"`
+	Listenmandate    int64  `json:"listenmandate"`
+}
+
+type MdbFullName struct {
+	MdbID        string         `json:"mdb_id"`
+	NameID       int32          `json:"name_id"`
+	DisplayName  interface{}    `json:"display_name"`
+	FullNachname interface{}    `json:"full_nachname"`
+	Vorname      string         `json:"vorname"`
+	Nachname     string         `json:"nachname"`
+	Ortszusatz   sql.NullString `json:"ortszusatz"`
+	Adel         sql.NullString `json:"adel"`
+	Praefix      sql.NullString `json:"praefix"`
+	AnredeTitel  sql.NullString `json:"anrede_titel"`
+	AkadTitel    sql.NullString `json:"akad_titel"`
+	HistorieVon  sql.NullString `json:"historie_von"`
+	HistorieBis  sql.NullString `json:"historie_bis"`
+	IsCurrent    int32          `json:"is_current"`
+}
+
+type MdbInstitutionMembership struct {
+	ID                         int32          `json:"id"`
+	MdbWahlperiodeMembershipID int32          `json:"mdb_wahlperiode_membership_id"`
+	InsartLang                 string         `json:"insart_lang"`
+	InsLang                    string         `json:"ins_lang"`
+	MdbinsVon                  sql.NullString `json:"mdbins_von"`
+	MdbinsBis                  sql.NullString `json:"mdbins_bis"`
+	FktLang                    sql.NullString `json:"fkt_lang"`
+	FktinsVon                  sql.NullString `json:"fktins_von"`
+	FktinsBis                  sql.NullString `json:"fktins_bis"`
+	CreatedAt                  time.Time      `json:"created_at"`
+	UpdatedAt                  time.Time      `json:"updated_at"`
+}
+
+type MdbName struct {
+	ID          int32          `json:"id"`
+	MdbID       string         `json:"mdb_id"`
+	Nachname    string         `json:"nachname"`
+	Vorname     string         `json:"vorname"`
+	Ortszusatz  sql.NullString `json:"ortszusatz"`
+	Adel        sql.NullString `json:"adel"`
+	Praefix     sql.NullString `json:"praefix"`
+	AnredeTitel sql.NullString `json:"anrede_titel"`
+	AkadTitel   sql.NullString `json:"akad_titel"`
+	HistorieVon sql.NullString `json:"historie_von"`
+	HistorieBis sql.NullString `json:"historie_bis"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+type MdbPerson struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type MdbStammdatenVersion struct {
+	ID         int32     `json:"id"`
+	Version    string    `json:"version"`
+	ImportDate time.Time `json:"import_date"`
+	SourceFile string    `json:"source_file"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type MdbWahlperiodeMembership struct {
+	ID         int32          `json:"id"`
+	MdbID      string         `json:"mdb_id"`
+	Wp         int32          `json:"wp"`
+	MdbwpVon   string         `json:"mdbwp_von"`
+	MdbwpBis   sql.NullString `json:"mdbwp_bis"`
+	WkrNummer  sql.NullString `json:"wkr_nummer"`
+	WkrName    sql.NullString `json:"wkr_name"`
+	WkrLand    sql.NullString `json:"wkr_land"`
+	Liste      sql.NullString `json:"liste"`
+	Mandatsart sql.NullString `json:"mandatsart"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
 type Person struct {
 	ID           string         `json:"id"`
 	Vorname      string         `json:"vorname"`
@@ -189,6 +344,29 @@ type Person struct {
 	Datum        sql.NullTime   `json:"datum"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
+}
+
+type PersonHistory struct {
+	ID           int64           `json:"id"`
+	PersonID     string          `json:"person_id"`
+	Aktualisiert time.Time       `json:"aktualisiert"`
+	SyncRunID    string          `json:"sync_run_id"`
+	Snapshot     json.RawMessage `json:"snapshot"`
+	Diff         json.RawMessage `json:"diff"`
+	RecordedAt   time.Time       `json:"recorded_at"`
+}
+
+type PersonMdbLink struct {
+	PersonID        string         `json:"person_id"`
+	MdbID           string         `json:"mdb_id"`
+	MatchConfidence string         `json:"match_confidence"`
+	MatchMethod     string         `json:"match_method"`
+	VerifiedBy      sql.NullString `json:"verified_by"`
+	VerifiedAt      sql.NullTime   `json:"verified_at"`
+	Notes           sql.NullString `json:"notes"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
 }
 
 type PersonRole struct {
@@ -218,6 +396,47 @@ type PersonWahlperiode struct {
 	CreatedAt         time.Time `json:"created_at"`
 }
 
+type PersonWahlperiodeWithBio struct {
+	PersonID          string         `json:"person_id"`
+	DipNachname       string         `json:"dip_nachname"`
+	DipVorname        string         `json:"dip_vorname"`
+	WahlperiodeNummer int32          `json:"wahlperiode_nummer"`
+	MdbID             sql.NullString `json:"mdb_id"`
+	MdbNachname       sql.NullString `json:"mdb_nachname"`
+	MdbVorname        sql.NullString `json:"mdb_vorname"`
+	ParteiKurz        sql.NullString `json:"partei_kurz"`
+	Geschlecht        sql.NullString `json:"geschlecht"`
+	Geburtsdatum      sql.NullString `json:"geburtsdatum"`
+	Mandatsart        sql.NullString `json:"mandatsart"`
+	WkrName           sql.NullString `json:"wkr_name"`
+	WkrLand           sql.NullString `json:"wkr_land"`
+	MdbwpVon          sql.NullString `json:"mdbwp_von"`
+	MdbwpBis          sql.NullString `json:"mdbwp_bis"`
+}
+
+type PersonWithMdbBio struct {
+	PersonID             string         `json:"person_id"`
+	DipVorname           string         `json:"dip_vorname"`
+	DipNachname          string         `json:"dip_nachname"`
+	Typ                  string         `json:"typ"`
+	Aktualisiert         time.Time      `json:"aktualisiert"`
+	MdbID                sql.NullString `json:"mdb_id"`
+	MatchConfidence      sql.NullString `json:"match_confidence"`
+	MdbNachname          sql.NullString `json:"mdb_nachname"`
+	MdbVorname           sql.NullString `json:"mdb_vorname"`
+	AnredeTitel          sql.NullString `json:"anrede_titel"`
+	AkadTitel            sql.NullString `json:"akad_titel"`
+	Geburtsdatum         sql.NullString `json:"geburtsdatum"`
+	Geburtsort           sql.NullString `json:"geburtsort"`
+	Geschlecht           sql.NullString `json:"geschlecht"`
+	ParteiKurz           sql.NullString `json:"partei_kurz"`
+	Beruf                sql.NullString `json:"beruf"`
+	VitaKurz             sql.NullString `json:"vita_kurz"`
+	AnzahlWahlperioden   int64          `json:"anzahl_wahlperioden"`
+	ErsteMitgliedschaft  interface{}    `json:"erste_mitgliedschaft"`
+	LetzteMitgliedschaft interface{}    `json:"letzte_mitgliedschaft"`
+}
+
 type Plenarprotokoll struct {
 	ID                        string         `json:"id"`
 	Titel                     string         `json:"titel"`
@@ -246,6 +465,18 @@ type Plenarprotokoll struct {
 	FundstelleTopZusatz       sql.NullString `json:"fundstelle_top_zusatz"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
+}
+
+type PlenarprotokollHistory struct {
+	ID                int64           `json:"id"`
+	PlenarprotokollID string          `json:"plenarprotokoll_id"`
+	Aktualisiert      time.Time       `json:"aktualisiert"`
+	SyncRunID         string          `json:"sync_run_id"`
+	Snapshot          json.RawMessage `json:"snapshot"`
+	Diff              json.RawMessage `json:"diff"`
+	RecordedAt        time.Time       `json:"recorded_at"`
 }
 
 type PlenarprotokollText struct {
@@ -269,6 +500,12 @@ type Ressort struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type SyncRun struct {
+	ID           string    `json:"id"`
+	ResourceName string    `json:"resource_name"`
+	StartedAt    time.Time `json:"started_at"`
+}
+
 type Ueberweisung struct {
 	ID                 int32          `json:"id"`
 	VorgangspositionID string         `json:"vorgangsposition_id"`
@@ -321,6 +558,7 @@ type Vorgang struct {
 	Wahlperiode    int32          `json:"wahlperiode"`
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      sql.NullTime   `json:"deleted_at"`
 }
 
 type VorgangDeskriptor struct {
@@ -332,6 +570,16 @@ type VorgangDeskriptor struct {
 	CreatedAt  time.Time `json:"created_at"`
 }
 
+type VorgangHistory struct {
+	ID           int64           `json:"id"`
+	VorgangID    string          `json:"vorgang_id"`
+	Aktualisiert time.Time       `json:"aktualisiert"`
+	SyncRunID    string          `json:"sync_run_id"`
+	Snapshot     json.RawMessage `json:"snapshot"`
+	Diff         json.RawMessage `json:"diff"`
+	RecordedAt   time.Time       `json:"recorded_at"`
+}
+
 type VorgangInitiative struct {
 	ID         int32     `json:"id"`
 	VorgangID  string    `json:"vorgang_id"`
@@ -402,6 +650,18 @@ type Vorgangsposition struct {
 	FundstelleVerteildatum    sql.NullTime   `json:"fundstelle_verteildatum"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
+}
+
+type VorgangspositionHistory struct {
+	ID                 int64           `json:"id"`
+	VorgangspositionID string          `json:"vorgangsposition_id"`
+	Aktualisiert       time.Time       `json:"aktualisiert"`
+	SyncRunID          string          `json:"sync_run_id"`
+	Snapshot           json.RawMessage `json:"snapshot"`
+	Diff               json.RawMessage `json:"diff"`
+	RecordedAt         time.Time       `json:"recorded_at"`
 }
 
 type VorgangspositionMitberaten struct {
@@ -426,7 +686,9 @@ type VorgangspositionUrheber struct {
 }
 
 type Wahlperiode struct {
-	Nummer    int32     `json:"nummer"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Nummer    int32         `json:"nummer"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	StartYear sql.NullInt32 `json:"start_year"`
+	EndYear   sql.NullInt32 `json:"end_year"`
 }
@@ -47,7 +47,7 @@ INSERT INTO person (
     id, vorname, nachname, namenszusatz, titel, typ,
     aktualisiert, basisdatum, datum
 ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-RETURNING id, vorname, nachname, namenszusatz, titel, typ, aktualisiert, basisdatum, datum, created_at, updated_at
+RETURNING id, vorname, nachname, namenszusatz, titel, typ, aktualisiert, basisdatum, datum, created_at, updated_at, deleted_at
 `
 
 type CreatePersonParams struct {
@@ -87,6 +87,7 @@ func (q *Queries) CreatePerson(ctx context.Context, arg CreatePersonParams) (Per
 		&i.Datum,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -185,6 +186,25 @@ func (q *Queries) DeletePerson(ctx context.Context, id string) error {
 	return err
 }
 
+const deletePersonRoleWahlperioden = `-- name: DeletePersonRoleWahlperioden :exec
+DELETE FROM person_role_wahlperiode
+WHERE person_role_id IN (SELECT id FROM person_role WHERE person_id = $1)
+`
+
+func (q *Queries) DeletePersonRoleWahlperioden(ctx context.Context, personID string) error {
+	_, err := q.db.ExecContext(ctx, deletePersonRoleWahlperioden, personID)
+	return err
+}
+
+const deletePersonRoles = `-- name: DeletePersonRoles :exec
+DELETE FROM person_role WHERE person_id = $1
+`
+
+func (q *Queries) DeletePersonRoles(ctx context.Context, personID string) error {
+	_, err := q.db.ExecContext(ctx, deletePersonRoles, personID)
+	return err
+}
+
 const deletePersonWahlperioden = `-- name: DeletePersonWahlperioden :exec
 DELETE FROM person_wahlperiode WHERE person_id = $1
 `
@@ -194,9 +214,20 @@ func (q *Queries) DeletePersonWahlperioden(ctx context.Context, personID string)
 	return err
 }
 
+const getLatestPersonDatum = `-- name: GetLatestPersonDatum :one
+SELECT MIN(datum) AS datum FROM person
+`
+
+func (q *Queries) GetLatestPersonDatum(ctx context.Context) (interface{}, error) {
+	row := q.db.QueryRowContext(ctx, getLatestPersonDatum)
+	var datum interface{}
+	err := row.Scan(&datum)
+	return datum, err
+}
+
 const getPerson = `-- name: GetPerson :one
 SELECT 
-    p.id, p.vorname, p.nachname, p.namenszusatz, p.titel, p.typ, p.aktualisiert, p.basisdatum, p.datum, p.created_at, p.updated_at,
+    p.id, p.vorname, p.nachname, p.namenszusatz, p.titel, p.typ, p.aktualisiert, p.basisdatum, p.datum, p.created_at, p.updated_at, p.deleted_at,
     COALESCE(
         json_agg(
             DISTINCT jsonb_build_object(
@@ -236,6 +267,7 @@ type GetPersonRow struct {
 	Datum        sql.NullTime   `json:"datum"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
 	PersonRoles  interface{}    `json:"person_roles"`
 }
 
@@ -254,6 +286,7 @@ func (q *Queries) GetPerson(ctx context.Context, id string) (GetPersonRow, error
 		&i.Datum,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 		&i.PersonRoles,
 	)
 	return i, err
@@ -291,7 +324,7 @@ func (q *Queries) GetPersonWahlperioden(ctx context.Context, personID string) ([
 
 const listPersonen = `-- name: ListPersonen :many
 SELECT 
-    p.id, p.vorname, p.nachname, p.namenszusatz, p.titel, p.typ, p.aktualisiert, p.basisdatum, p.datum, p.created_at, p.updated_at,
+    p.id, p.vorname, p.nachname, p.namenszusatz, p.titel, p.typ, p.aktualisiert, p.basisdatum, p.datum, p.created_at, p.updated_at, p.deleted_at,
     COALESCE(
         json_agg(
             DISTINCT jsonb_build_object(
@@ -348,6 +381,7 @@ type ListPersonenRow struct {
 	Datum        sql.NullTime   `json:"datum"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
 	PersonRoles  interface{}    `json:"person_roles"`
 }
 
@@ -380,6 +414,7 @@ func (q *Queries) ListPersonen(ctx context.Context, arg ListPersonenParams) ([]L
 			&i.Datum,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
 			&i.PersonRoles,
 		); err != nil {
 			return nil, err
@@ -405,9 +440,10 @@ SET
     aktualisiert = $6,
     basisdatum = $7,
     datum = $8,
+    deleted_at = NULL,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, vorname, nachname, namenszusatz, titel, typ, aktualisiert, basisdatum, datum, created_at, updated_at
+RETURNING id, vorname, nachname, namenszusatz, titel, typ, aktualisiert, basisdatum, datum, created_at, updated_at, deleted_at
 `
 
 type UpdatePersonParams struct {
@@ -445,6 +481,53 @@ func (q *Queries) UpdatePerson(ctx context.Context, arg UpdatePersonParams) (Per
 		&i.Datum,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
+
+const upsertPerson = `-- name: UpsertPerson :exec
+INSERT INTO person (
+    id, vorname, nachname, namenszusatz, titel, typ,
+    aktualisiert, basisdatum, datum
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (id) DO UPDATE
+SET
+    vorname = excluded.vorname,
+    nachname = excluded.nachname,
+    namenszusatz = excluded.namenszusatz,
+    titel = excluded.titel,
+    typ = excluded.typ,
+    aktualisiert = excluded.aktualisiert,
+    basisdatum = excluded.basisdatum,
+    datum = excluded.datum,
+    deleted_at = NULL,
+    updated_at = NOW()
+`
+
+type UpsertPersonParams struct {
+	ID           string         `json:"id"`
+	Vorname      string         `json:"vorname"`
+	Nachname     string         `json:"nachname"`
+	Namenszusatz sql.NullString `json:"namenszusatz"`
+	Titel        string         `json:"titel"`
+	Typ          string         `json:"typ"`
+	Aktualisiert time.Time      `json:"aktualisiert"`
+	Basisdatum   sql.NullTime   `json:"basisdatum"`
+	Datum        sql.NullTime   `json:"datum"`
+}
+
+func (q *Queries) UpsertPerson(ctx context.Context, arg UpsertPersonParams) error {
+	_, err := q.db.ExecContext(ctx, upsertPerson,
+		arg.ID,
+		arg.Vorname,
+		arg.Nachname,
+		arg.Namenszusatz,
+		arg.Titel,
+		arg.Typ,
+		arg.Aktualisiert,
+		arg.Basisdatum,
+		arg.Datum,
+	)
+	return err
+}
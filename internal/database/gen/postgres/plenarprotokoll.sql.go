@@ -52,12 +52,12 @@ INSERT INTO plenarprotokoll (
     fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
     fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite,
     fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
-    fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
     $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
-    $21, $22, $23, $24, $25
-) RETURNING id, titel, dokumentnummer, dokumentart, typ, herausgeber, datum, aktualisiert, pdf_hash, sitzungsbemerkung, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, created_at, updated_at
+    $21, $22, $23, $24, $25, $26
+) RETURNING id, titel, dokumentnummer, dokumentart, typ, herausgeber, datum, aktualisiert, pdf_hash, sitzungsbemerkung, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, created_at, updated_at, fundstelle_xml_url, deleted_at
 `
 
 type CreatePlenarprotokollParams struct {
@@ -84,6 +84,7 @@ type CreatePlenarprotokollParams struct {
 	FundstelleEndquadrant     sql.NullString `json:"fundstelle_endquadrant"`
 	FundstelleSeite           sql.NullString `json:"fundstelle_seite"`
 	FundstellePdfUrl          sql.NullString `json:"fundstelle_pdf_url"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
 	FundstelleTop             sql.NullInt32  `json:"fundstelle_top"`
 	FundstelleTopZusatz       sql.NullString `json:"fundstelle_top_zusatz"`
 }
@@ -113,6 +114,7 @@ func (q *Queries) CreatePlenarprotokoll(ctx context.Context, arg CreatePlenarpro
 		arg.FundstelleEndquadrant,
 		arg.FundstelleSeite,
 		arg.FundstellePdfUrl,
+		arg.FundstelleXmlUrl,
 		arg.FundstelleTop,
 		arg.FundstelleTopZusatz,
 	)
@@ -145,6 +147,8 @@ func (q *Queries) CreatePlenarprotokoll(ctx context.Context, arg CreatePlenarpro
 		&i.FundstelleTopZusatz,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -184,9 +188,38 @@ func (q *Queries) DeletePlenarprotokoll(ctx context.Context, id string) error {
 	return err
 }
 
+const deletePlenarprotokollFundstelleUrheber = `-- name: DeletePlenarprotokollFundstelleUrheber :exec
+DELETE FROM fundstelle_urheber WHERE plenarprotokoll_id = $1
+`
+
+func (q *Queries) DeletePlenarprotokollFundstelleUrheber(ctx context.Context, plenarprotokollID sql.NullString) error {
+	_, err := q.db.ExecContext(ctx, deletePlenarprotokollFundstelleUrheber, plenarprotokollID)
+	return err
+}
+
+const deletePlenarprotokollVorgangsbezuege = `-- name: DeletePlenarprotokollVorgangsbezuege :exec
+DELETE FROM plenarprotokoll_vorgangsbezug WHERE plenarprotokoll_id = $1
+`
+
+func (q *Queries) DeletePlenarprotokollVorgangsbezuege(ctx context.Context, plenarprotokollID string) error {
+	_, err := q.db.ExecContext(ctx, deletePlenarprotokollVorgangsbezuege, plenarprotokollID)
+	return err
+}
+
+const getLatestPlenarprotokollDatum = `-- name: GetLatestPlenarprotokollDatum :one
+SELECT MIN(datum) AS datum FROM plenarprotokoll
+`
+
+func (q *Queries) GetLatestPlenarprotokollDatum(ctx context.Context) (interface{}, error) {
+	row := q.db.QueryRowContext(ctx, getLatestPlenarprotokollDatum)
+	var datum interface{}
+	err := row.Scan(&datum)
+	return datum, err
+}
+
 const getPlenarprotokoll = `-- name: GetPlenarprotokoll :one
 SELECT 
-    p.id, p.titel, p.dokumentnummer, p.dokumentart, p.typ, p.herausgeber, p.datum, p.aktualisiert, p.pdf_hash, p.sitzungsbemerkung, p.vorgangsbezug_anzahl, p.wahlperiode, p.fundstelle_dokumentnummer, p.fundstelle_datum, p.fundstelle_dokumentart, p.fundstelle_herausgeber, p.fundstelle_id, p.fundstelle_anfangsseite, p.fundstelle_endseite, p.fundstelle_anfangsquadrant, p.fundstelle_endquadrant, p.fundstelle_seite, p.fundstelle_pdf_url, p.fundstelle_top, p.fundstelle_top_zusatz, p.created_at, p.updated_at,
+    p.id, p.titel, p.dokumentnummer, p.dokumentart, p.typ, p.herausgeber, p.datum, p.aktualisiert, p.pdf_hash, p.sitzungsbemerkung, p.vorgangsbezug_anzahl, p.wahlperiode, p.fundstelle_dokumentnummer, p.fundstelle_datum, p.fundstelle_dokumentart, p.fundstelle_herausgeber, p.fundstelle_id, p.fundstelle_anfangsseite, p.fundstelle_endseite, p.fundstelle_anfangsquadrant, p.fundstelle_endquadrant, p.fundstelle_seite, p.fundstelle_pdf_url, p.fundstelle_top, p.fundstelle_top_zusatz, p.created_at, p.updated_at, p.fundstelle_xml_url, p.deleted_at,
     COALESCE(
         json_agg(
             DISTINCT jsonb_build_object(
@@ -231,6 +264,8 @@ type GetPlenarprotokollRow struct {
 	FundstelleTopZusatz       sql.NullString `json:"fundstelle_top_zusatz"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
 	Vorgangsbezug             interface{}    `json:"vorgangsbezug"`
 }
 
@@ -265,6 +300,8 @@ func (q *Queries) GetPlenarprotokoll(ctx context.Context, id string) (GetPlenarp
 		&i.FundstelleTopZusatz,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 		&i.Vorgangsbezug,
 	)
 	return i, err
@@ -272,7 +309,7 @@ func (q *Queries) GetPlenarprotokoll(ctx context.Context, id string) (GetPlenarp
 
 const listPlenarprotokolle = `-- name: ListPlenarprotokolle :many
 SELECT 
-    p.id, p.titel, p.dokumentnummer, p.dokumentart, p.typ, p.herausgeber, p.datum, p.aktualisiert, p.pdf_hash, p.sitzungsbemerkung, p.vorgangsbezug_anzahl, p.wahlperiode, p.fundstelle_dokumentnummer, p.fundstelle_datum, p.fundstelle_dokumentart, p.fundstelle_herausgeber, p.fundstelle_id, p.fundstelle_anfangsseite, p.fundstelle_endseite, p.fundstelle_anfangsquadrant, p.fundstelle_endquadrant, p.fundstelle_seite, p.fundstelle_pdf_url, p.fundstelle_top, p.fundstelle_top_zusatz, p.created_at, p.updated_at,
+    p.id, p.titel, p.dokumentnummer, p.dokumentart, p.typ, p.herausgeber, p.datum, p.aktualisiert, p.pdf_hash, p.sitzungsbemerkung, p.vorgangsbezug_anzahl, p.wahlperiode, p.fundstelle_dokumentnummer, p.fundstelle_datum, p.fundstelle_dokumentart, p.fundstelle_herausgeber, p.fundstelle_id, p.fundstelle_anfangsseite, p.fundstelle_endseite, p.fundstelle_anfangsquadrant, p.fundstelle_endquadrant, p.fundstelle_seite, p.fundstelle_pdf_url, p.fundstelle_top, p.fundstelle_top_zusatz, p.created_at, p.updated_at, p.fundstelle_xml_url, p.deleted_at,
     COALESCE(
         json_agg(
             DISTINCT jsonb_build_object(
@@ -336,6 +373,8 @@ type ListPlenarprotokolleRow struct {
 	FundstelleTopZusatz       sql.NullString `json:"fundstelle_top_zusatz"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
 	Vorgangsbezug             interface{}    `json:"vorgangsbezug"`
 }
 
@@ -385,6 +424,8 @@ func (q *Queries) ListPlenarprotokolle(ctx context.Context, arg ListPlenarprotok
 			&i.FundstelleTopZusatz,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 			&i.Vorgangsbezug,
 		); err != nil {
 			return nil, err
@@ -408,9 +449,10 @@ SET
     pdf_hash = $4,
     sitzungsbemerkung = $5,
     vorgangsbezug_anzahl = $6,
+    deleted_at = NULL,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, titel, dokumentnummer, dokumentart, typ, herausgeber, datum, aktualisiert, pdf_hash, sitzungsbemerkung, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, created_at, updated_at
+RETURNING id, titel, dokumentnummer, dokumentart, typ, herausgeber, datum, aktualisiert, pdf_hash, sitzungsbemerkung, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, created_at, updated_at, fundstelle_xml_url, deleted_at
 `
 
 type UpdatePlenarprotokollParams struct {
@@ -460,6 +502,112 @@ func (q *Queries) UpdatePlenarprotokoll(ctx context.Context, arg UpdatePlenarpro
 		&i.FundstelleTopZusatz,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
+
+const upsertPlenarprotokoll = `-- name: UpsertPlenarprotokoll :exec
+INSERT INTO plenarprotokoll (
+    id, titel, dokumentnummer, dokumentart, typ, herausgeber,
+    datum, aktualisiert, pdf_hash, sitzungsbemerkung, vorgangsbezug_anzahl, wahlperiode,
+    fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+    fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite,
+    fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+    $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+    $21, $22, $23, $24, $25, $26
+) ON CONFLICT (id) DO UPDATE
+SET
+    titel = excluded.titel,
+    dokumentnummer = excluded.dokumentnummer,
+    dokumentart = excluded.dokumentart,
+    typ = excluded.typ,
+    herausgeber = excluded.herausgeber,
+    datum = excluded.datum,
+    aktualisiert = excluded.aktualisiert,
+    pdf_hash = excluded.pdf_hash,
+    sitzungsbemerkung = excluded.sitzungsbemerkung,
+    vorgangsbezug_anzahl = excluded.vorgangsbezug_anzahl,
+    wahlperiode = excluded.wahlperiode,
+    fundstelle_dokumentnummer = excluded.fundstelle_dokumentnummer,
+    fundstelle_datum = excluded.fundstelle_datum,
+    fundstelle_dokumentart = excluded.fundstelle_dokumentart,
+    fundstelle_herausgeber = excluded.fundstelle_herausgeber,
+    fundstelle_id = excluded.fundstelle_id,
+    fundstelle_anfangsseite = excluded.fundstelle_anfangsseite,
+    fundstelle_endseite = excluded.fundstelle_endseite,
+    fundstelle_anfangsquadrant = excluded.fundstelle_anfangsquadrant,
+    fundstelle_endquadrant = excluded.fundstelle_endquadrant,
+    fundstelle_seite = excluded.fundstelle_seite,
+    fundstelle_pdf_url = excluded.fundstelle_pdf_url,
+    fundstelle_xml_url = excluded.fundstelle_xml_url,
+    fundstelle_top = excluded.fundstelle_top,
+    fundstelle_top_zusatz = excluded.fundstelle_top_zusatz,
+    deleted_at = NULL,
+    updated_at = NOW()
+`
+
+type UpsertPlenarprotokollParams struct {
+	ID                        string         `json:"id"`
+	Titel                     string         `json:"titel"`
+	Dokumentnummer            string         `json:"dokumentnummer"`
+	Dokumentart               string         `json:"dokumentart"`
+	Typ                       string         `json:"typ"`
+	Herausgeber               string         `json:"herausgeber"`
+	Datum                     time.Time      `json:"datum"`
+	Aktualisiert              time.Time      `json:"aktualisiert"`
+	PdfHash                   sql.NullString `json:"pdf_hash"`
+	Sitzungsbemerkung         sql.NullString `json:"sitzungsbemerkung"`
+	VorgangsbezugAnzahl       int32          `json:"vorgangsbezug_anzahl"`
+	Wahlperiode               sql.NullInt32  `json:"wahlperiode"`
+	FundstelleDokumentnummer  string         `json:"fundstelle_dokumentnummer"`
+	FundstelleDatum           time.Time      `json:"fundstelle_datum"`
+	FundstelleDokumentart     string         `json:"fundstelle_dokumentart"`
+	FundstelleHerausgeber     string         `json:"fundstelle_herausgeber"`
+	FundstelleID              string         `json:"fundstelle_id"`
+	FundstelleAnfangsseite    sql.NullInt32  `json:"fundstelle_anfangsseite"`
+	FundstelleEndseite        sql.NullInt32  `json:"fundstelle_endseite"`
+	FundstelleAnfangsquadrant sql.NullString `json:"fundstelle_anfangsquadrant"`
+	FundstelleEndquadrant     sql.NullString `json:"fundstelle_endquadrant"`
+	FundstelleSeite           sql.NullString `json:"fundstelle_seite"`
+	FundstellePdfUrl          sql.NullString `json:"fundstelle_pdf_url"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	FundstelleTop             sql.NullInt32  `json:"fundstelle_top"`
+	FundstelleTopZusatz       sql.NullString `json:"fundstelle_top_zusatz"`
+}
+
+func (q *Queries) UpsertPlenarprotokoll(ctx context.Context, arg UpsertPlenarprotokollParams) error {
+	_, err := q.db.ExecContext(ctx, upsertPlenarprotokoll,
+		arg.ID,
+		arg.Titel,
+		arg.Dokumentnummer,
+		arg.Dokumentart,
+		arg.Typ,
+		arg.Herausgeber,
+		arg.Datum,
+		arg.Aktualisiert,
+		arg.PdfHash,
+		arg.Sitzungsbemerkung,
+		arg.VorgangsbezugAnzahl,
+		arg.Wahlperiode,
+		arg.FundstelleDokumentnummer,
+		arg.FundstelleDatum,
+		arg.FundstelleDokumentart,
+		arg.FundstelleHerausgeber,
+		arg.FundstelleID,
+		arg.FundstelleAnfangsseite,
+		arg.FundstelleEndseite,
+		arg.FundstelleAnfangsquadrant,
+		arg.FundstelleEndquadrant,
+		arg.FundstelleSeite,
+		arg.FundstellePdfUrl,
+		arg.FundstelleXmlUrl,
+		arg.FundstelleTop,
+		arg.FundstelleTopZusatz,
+	)
+	return err
+}
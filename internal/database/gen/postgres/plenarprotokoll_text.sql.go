@@ -83,7 +83,7 @@ func (q *Queries) DeletePlenarprotokollText(ctx context.Context, id string) erro
 
 const getPlenarprotokollText = `-- name: GetPlenarprotokollText :one
 SELECT 
-    p.id, p.titel, p.dokumentnummer, p.dokumentart, p.typ, p.herausgeber, p.datum, p.aktualisiert, p.pdf_hash, p.sitzungsbemerkung, p.vorgangsbezug_anzahl, p.wahlperiode, p.fundstelle_dokumentnummer, p.fundstelle_datum, p.fundstelle_dokumentart, p.fundstelle_herausgeber, p.fundstelle_id, p.fundstelle_anfangsseite, p.fundstelle_endseite, p.fundstelle_anfangsquadrant, p.fundstelle_endquadrant, p.fundstelle_seite, p.fundstelle_pdf_url, p.fundstelle_top, p.fundstelle_top_zusatz, p.created_at, p.updated_at,
+    p.id, p.titel, p.dokumentnummer, p.dokumentart, p.typ, p.herausgeber, p.datum, p.aktualisiert, p.pdf_hash, p.sitzungsbemerkung, p.vorgangsbezug_anzahl, p.wahlperiode, p.fundstelle_dokumentnummer, p.fundstelle_datum, p.fundstelle_dokumentart, p.fundstelle_herausgeber, p.fundstelle_id, p.fundstelle_anfangsseite, p.fundstelle_endseite, p.fundstelle_anfangsquadrant, p.fundstelle_endquadrant, p.fundstelle_seite, p.fundstelle_pdf_url, p.fundstelle_top, p.fundstelle_top_zusatz, p.created_at, p.updated_at, p.fundstelle_xml_url, p.deleted_at,
     pt.text,
     COALESCE(
         json_agg(
@@ -130,6 +130,8 @@ type GetPlenarprotokollTextRow struct {
 	FundstelleTopZusatz       sql.NullString `json:"fundstelle_top_zusatz"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
 	Text                      sql.NullString `json:"text"`
 	Vorgangsbezug             interface{}    `json:"vorgangsbezug"`
 }
@@ -165,6 +167,8 @@ func (q *Queries) GetPlenarprotokollText(ctx context.Context, id string) (GetPle
 		&i.FundstelleTopZusatz,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 		&i.Text,
 		&i.Vorgangsbezug,
 	)
@@ -173,7 +177,7 @@ func (q *Queries) GetPlenarprotokollText(ctx context.Context, id string) (GetPle
 
 const listPlenarprotokollTexte = `-- name: ListPlenarprotokollTexte :many
 SELECT 
-    p.id, p.titel, p.dokumentnummer, p.dokumentart, p.typ, p.herausgeber, p.datum, p.aktualisiert, p.pdf_hash, p.sitzungsbemerkung, p.vorgangsbezug_anzahl, p.wahlperiode, p.fundstelle_dokumentnummer, p.fundstelle_datum, p.fundstelle_dokumentart, p.fundstelle_herausgeber, p.fundstelle_id, p.fundstelle_anfangsseite, p.fundstelle_endseite, p.fundstelle_anfangsquadrant, p.fundstelle_endquadrant, p.fundstelle_seite, p.fundstelle_pdf_url, p.fundstelle_top, p.fundstelle_top_zusatz, p.created_at, p.updated_at,
+    p.id, p.titel, p.dokumentnummer, p.dokumentart, p.typ, p.herausgeber, p.datum, p.aktualisiert, p.pdf_hash, p.sitzungsbemerkung, p.vorgangsbezug_anzahl, p.wahlperiode, p.fundstelle_dokumentnummer, p.fundstelle_datum, p.fundstelle_dokumentart, p.fundstelle_herausgeber, p.fundstelle_id, p.fundstelle_anfangsseite, p.fundstelle_endseite, p.fundstelle_anfangsquadrant, p.fundstelle_endquadrant, p.fundstelle_seite, p.fundstelle_pdf_url, p.fundstelle_top, p.fundstelle_top_zusatz, p.created_at, p.updated_at, p.fundstelle_xml_url, p.deleted_at,
     pt.text,
     COALESCE(
         json_agg(
@@ -239,6 +243,8 @@ type ListPlenarprotokollTexteRow struct {
 	FundstelleTopZusatz       sql.NullString `json:"fundstelle_top_zusatz"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
 	Text                      sql.NullString `json:"text"`
 	Vorgangsbezug             interface{}    `json:"vorgangsbezug"`
 }
@@ -289,6 +295,8 @@ func (q *Queries) ListPlenarprotokollTexte(ctx context.Context, arg ListPlenarpr
 			&i.FundstelleTopZusatz,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 			&i.Text,
 			&i.Vorgangsbezug,
 		); err != nil {
@@ -13,6 +13,14 @@ type Querier interface {
 	CountAktivitaeten(ctx context.Context, arg CountAktivitaetenParams) (int64, error)
 	CountDrucksacheTexte(ctx context.Context, arg CountDrucksacheTexteParams) (int64, error)
 	CountDrucksachen(ctx context.Context, arg CountDrucksachenParams) (int64, error)
+	CountMdbBiographical(ctx context.Context) (int64, error)
+	CountMdbInstitutionMemberships(ctx context.Context) (int64, error)
+	CountMdbNames(ctx context.Context) (int64, error)
+	// ============================================================================
+	// STATISTICS AND COUNTS
+	// ============================================================================
+	CountMdbPersons(ctx context.Context) (int64, error)
+	CountMdbWahlperiodeMemberships(ctx context.Context) (int64, error)
 	CountPersonen(ctx context.Context, arg CountPersonenParams) (int64, error)
 	CountPlenarprotokollTexte(ctx context.Context, arg CountPlenarprotokollTexteParams) (int64, error)
 	CountPlenarprotokolle(ctx context.Context, arg CountPlenarprotokolleParams) (int64, error)
@@ -21,81 +29,244 @@ type Querier interface {
 	CreateAktivitaet(ctx context.Context, arg CreateAktivitaetParams) (Aktivitaet, error)
 	CreateAktivitaetAnzeige(ctx context.Context, arg CreateAktivitaetAnzeigeParams) (AktivitaetAnzeige, error)
 	CreateAktivitaetDeskriptor(ctx context.Context, arg CreateAktivitaetDeskriptorParams) (AktivitaetDeskriptor, error)
+	CreateAktivitaetHistory(ctx context.Context, arg CreateAktivitaetHistoryParams) error
 	CreateAktivitaetVorgangsbezug(ctx context.Context, arg CreateAktivitaetVorgangsbezugParams) error
 	CreateBeschlussfassung(ctx context.Context, arg CreateBeschlussfassungParams) (Beschlussfassung, error)
 	CreateDrucksache(ctx context.Context, arg CreateDrucksacheParams) (Drucksache, error)
 	CreateDrucksacheAutorAnzeige(ctx context.Context, arg CreateDrucksacheAutorAnzeigeParams) (DrucksacheAutorAnzeige, error)
+	CreateDrucksacheHistory(ctx context.Context, arg CreateDrucksacheHistoryParams) error
 	CreateDrucksacheRessort(ctx context.Context, arg CreateDrucksacheRessortParams) error
 	CreateDrucksacheText(ctx context.Context, arg CreateDrucksacheTextParams) (DrucksacheText, error)
 	CreateDrucksacheUrheber(ctx context.Context, arg CreateDrucksacheUrheberParams) error
 	CreateDrucksacheVorgangsbezug(ctx context.Context, arg CreateDrucksacheVorgangsbezugParams) error
 	CreateFundstelleUrheber(ctx context.Context, arg CreateFundstelleUrheberParams) (FundstelleUrheber, error)
 	CreateInkrafttreten(ctx context.Context, arg CreateInkrafttretenParams) (Inkrafttreten, error)
+	// ============================================================================
+	// BIOGRAPHICAL DATA MANAGEMENT
+	// ============================================================================
+	CreateMdbBiographical(ctx context.Context, arg CreateMdbBiographicalParams) error
+	// ============================================================================
+	// INSTITUTION MEMBERSHIP MANAGEMENT
+	// ============================================================================
+	CreateMdbInstitutionMembership(ctx context.Context, arg CreateMdbInstitutionMembershipParams) error
+	// ============================================================================
+	// NAME MANAGEMENT
+	// ============================================================================
+	CreateMdbName(ctx context.Context, arg CreateMdbNameParams) error
+	// ============================================================================
+	// PERSON MANAGEMENT
+	// ============================================================================
+	CreateMdbPerson(ctx context.Context, arg CreateMdbPersonParams) error
+	// MdB Stammdaten Queries
+	// Queries for importing and managing MdB biographical master data
+	// ============================================================================
+	// VERSION MANAGEMENT
+	// ============================================================================
+	CreateMdbStammdatenVersion(ctx context.Context, arg CreateMdbStammdatenVersionParams) (MdbStammdatenVersion, error)
+	// ============================================================================
+	// WAHLPERIODE MEMBERSHIP MANAGEMENT
+	// ============================================================================
+	CreateMdbWahlperiodeMembership(ctx context.Context, arg CreateMdbWahlperiodeMembershipParams) (int32, error)
 	CreatePerson(ctx context.Context, arg CreatePersonParams) (Person, error)
+	CreatePersonHistory(ctx context.Context, arg CreatePersonHistoryParams) error
+	// ============================================================================
+	// PERSON-MDB LINKING
+	// ============================================================================
+	CreatePersonMdbLink(ctx context.Context, arg CreatePersonMdbLinkParams) error
 	CreatePersonRole(ctx context.Context, arg CreatePersonRoleParams) (PersonRole, error)
 	CreatePersonRoleWahlperiode(ctx context.Context, arg CreatePersonRoleWahlperiodeParams) error
 	CreatePersonWahlperiode(ctx context.Context, arg CreatePersonWahlperiodeParams) error
 	CreatePlenarprotokoll(ctx context.Context, arg CreatePlenarprotokollParams) (Plenarprotokoll, error)
+	CreatePlenarprotokollHistory(ctx context.Context, arg CreatePlenarprotokollHistoryParams) error
 	CreatePlenarprotokollText(ctx context.Context, arg CreatePlenarprotokollTextParams) (PlenarprotokollText, error)
 	CreatePlenarprotokollVorgangsbezug(ctx context.Context, arg CreatePlenarprotokollVorgangsbezugParams) error
+	// Queries for the optional change history of synced entities.
+	CreateSyncRun(ctx context.Context, arg CreateSyncRunParams) error
 	CreateUeberweisung(ctx context.Context, arg CreateUeberweisungParams) (Ueberweisung, error)
 	CreateVerkuendung(ctx context.Context, arg CreateVerkuendungParams) (Verkuendung, error)
 	CreateVorgang(ctx context.Context, arg CreateVorgangParams) (Vorgang, error)
 	CreateVorgangDeskriptor(ctx context.Context, arg CreateVorgangDeskriptorParams) (VorgangDeskriptor, error)
+	CreateVorgangHistory(ctx context.Context, arg CreateVorgangHistoryParams) error
 	CreateVorgangInitiative(ctx context.Context, arg CreateVorgangInitiativeParams) error
 	CreateVorgangSachgebiet(ctx context.Context, arg CreateVorgangSachgebietParams) error
 	CreateVorgangVerlinkung(ctx context.Context, arg CreateVorgangVerlinkungParams) (VorgangVerlinkung, error)
 	CreateVorgangZustimmungsbeduerftigkeit(ctx context.Context, arg CreateVorgangZustimmungsbeduerftigkeitParams) error
 	CreateVorgangsposition(ctx context.Context, arg CreateVorgangspositionParams) (Vorgangsposition, error)
+	CreateVorgangspositionHistory(ctx context.Context, arg CreateVorgangspositionHistoryParams) error
 	CreateVorgangspositionMitberaten(ctx context.Context, arg CreateVorgangspositionMitberatenParams) error
 	CreateVorgangspositionRessort(ctx context.Context, arg CreateVorgangspositionRessortParams) error
 	CreateVorgangspositionUrheber(ctx context.Context, arg CreateVorgangspositionUrheberParams) error
 	DeleteAktivitaet(ctx context.Context, id string) error
+	DeleteAktivitaetAnzeigen(ctx context.Context, vorgangspositionID string) error
+	DeleteAktivitaetDeskriptoren(ctx context.Context, aktivitaetID string) error
+	DeleteAktivitaetVorgangsbezuege(ctx context.Context, aktivitaetID string) error
+	DeleteBeschlussfassungen(ctx context.Context, vorgangspositionID string) error
 	DeleteDrucksache(ctx context.Context, id string) error
+	DeleteDrucksacheAutorAnzeigen(ctx context.Context, drucksacheID string) error
+	DeleteDrucksacheFundstelleUrheber(ctx context.Context, drucksacheID sql.NullString) error
+	DeleteDrucksacheRessorts(ctx context.Context, drucksacheID string) error
 	DeleteDrucksacheText(ctx context.Context, id string) error
+	DeleteDrucksacheUrheber(ctx context.Context, drucksacheID string) error
+	DeleteDrucksacheVorgangsbezuege(ctx context.Context, drucksacheID string) error
+	DeleteInkrafttreten(ctx context.Context, vorgangID string) error
+	DeleteMdbBiographical(ctx context.Context, mdbID string) error
+	DeleteMdbInstitutionMembershipsByWahlperiode(ctx context.Context, mdbWahlperiodeMembershipID int32) error
+	DeleteMdbNames(ctx context.Context, mdbID string) error
+	DeleteMdbPerson(ctx context.Context, id string) error
+	DeleteMdbWahlperiodeMemberships(ctx context.Context, mdbID string) error
 	DeletePerson(ctx context.Context, id string) error
+	DeletePersonMdbLink(ctx context.Context, arg DeletePersonMdbLinkParams) error
+	DeletePersonRoleWahlperioden(ctx context.Context, personID string) error
+	DeletePersonRoles(ctx context.Context, personID string) error
 	DeletePersonWahlperioden(ctx context.Context, personID string) error
 	DeletePlenarprotokoll(ctx context.Context, id string) error
+	DeletePlenarprotokollFundstelleUrheber(ctx context.Context, plenarprotokollID sql.NullString) error
 	DeletePlenarprotokollText(ctx context.Context, id string) error
+	DeletePlenarprotokollVorgangsbezuege(ctx context.Context, plenarprotokollID string) error
+	DeleteUeberweisungen(ctx context.Context, vorgangspositionID string) error
+	DeleteVerkuendungen(ctx context.Context, vorgangID string) error
 	DeleteVorgang(ctx context.Context, id string) error
+	DeleteVorgangDeskriptoren(ctx context.Context, vorgangID string) error
+	DeleteVorgangInitiativen(ctx context.Context, vorgangID string) error
+	DeleteVorgangSachgebiete(ctx context.Context, vorgangID string) error
+	DeleteVorgangVerlinkungen(ctx context.Context, sourceVorgangID string) error
+	DeleteVorgangZustimmungsbeduerftigkeiten(ctx context.Context, vorgangID string) error
 	DeleteVorgangsposition(ctx context.Context, id string) error
+	DeleteVorgangspositionMitberaten(ctx context.Context, vorgangspositionID string) error
+	DeleteVorgangspositionRessorts(ctx context.Context, vorgangspositionID string) error
+	DeleteVorgangspositionUrheber(ctx context.Context, vorgangspositionID string) error
 	GetAktivitaet(ctx context.Context, id string) (GetAktivitaetRow, error)
+	// Returns the latest previous version that was current at the given time
+	GetAktivitaetHistoryAsOf(ctx context.Context, arg GetAktivitaetHistoryAsOfParams) (AktivitaetHistory, error)
 	GetDrucksache(ctx context.Context, id string) (GetDrucksacheRow, error)
+	// Returns the latest previous version that was current at the given time
+	GetDrucksacheHistoryAsOf(ctx context.Context, arg GetDrucksacheHistoryAsOfParams) (DrucksacheHistory, error)
 	GetDrucksacheText(ctx context.Context, id string) (GetDrucksacheTextRow, error)
 	GetFundstelleUrheberByDrucksache(ctx context.Context, drucksacheID sql.NullString) ([]FundstelleUrheber, error)
 	GetFundstelleUrheberByPlenarprotokoll(ctx context.Context, plenarprotokollID sql.NullString) ([]FundstelleUrheber, error)
+	GetLatestAktivitaetDatum(ctx context.Context) (interface{}, error)
+	GetLatestDrucksacheDatum(ctx context.Context) (interface{}, error)
+	GetLatestMdbStammdatenVersion(ctx context.Context) (MdbStammdatenVersion, error)
+	GetLatestPersonDatum(ctx context.Context) (interface{}, error)
+	GetLatestPlenarprotokollDatum(ctx context.Context) (interface{}, error)
+	GetLatestVorgangDatum(ctx context.Context) (interface{}, error)
+	GetLatestVorgangspositionDatum(ctx context.Context) (interface{}, error)
+	GetLinkStats(ctx context.Context) (GetLinkStatsRow, error)
+	GetLinksByConfidence(ctx context.Context, matchConfidence string) ([]PersonMdbLink, error)
+	GetMdbBiographical(ctx context.Context, mdbID string) (MdbBiographical, error)
+	GetMdbByPartei(ctx context.Context, parteiKurz sql.NullString) ([]GetMdbByParteiRow, error)
+	GetMdbByWahlperiode(ctx context.Context, wp int32) ([]GetMdbByWahlperiodeRow, error)
+	GetMdbCareerSummary(ctx context.Context, mdbID string) (MdbCareerSummary, error)
+	// ============================================================================
+	// VIEWS AND COMPLEX QUERIES
+	// ============================================================================
+	GetMdbCurrentMembers(ctx context.Context) ([]MdbCurrentMember, error)
+	GetMdbCurrentName(ctx context.Context, mdbID string) (MdbName, error)
+	GetMdbFraktionSummaryAll(ctx context.Context) ([]MdbFraktionSummary, error)
+	GetMdbFraktionSummaryByWP(ctx context.Context, wahlperiode int32) ([]MdbFraktionSummary, error)
+	GetMdbFullName(ctx context.Context, mdbID string) (MdbFullName, error)
+	GetMdbInstitutionMemberships(ctx context.Context, mdbWahlperiodeMembershipID int32) ([]MdbInstitutionMembership, error)
+	GetMdbInstitutionMembershipsByMdbId(ctx context.Context, mdbID string) ([]MdbInstitutionMembership, error)
+	GetMdbNames(ctx context.Context, mdbID string) ([]MdbName, error)
+	GetMdbPerson(ctx context.Context, id string) (MdbPerson, error)
+	GetMdbPersonLinks(ctx context.Context, mdbID string) ([]PersonMdbLink, error)
+	GetMdbStatsByGeschlecht(ctx context.Context) (GetMdbStatsByGeschlechtRow, error)
+	GetMdbStatsByPartei(ctx context.Context) ([]GetMdbStatsByParteiRow, error)
+	GetMdbStatsByWahlperiode(ctx context.Context) ([]GetMdbStatsByWahlperiodeRow, error)
+	GetMdbWahlperiodeMembership(ctx context.Context, arg GetMdbWahlperiodeMembershipParams) (MdbWahlperiodeMembership, error)
+	GetMdbWahlperiodeMemberships(ctx context.Context, mdbID string) ([]MdbWahlperiodeMembership, error)
+	GetMdbWithFullDetails(ctx context.Context, id string) (GetMdbWithFullDetailsRow, error)
 	GetOrCreateBundesland(ctx context.Context, name string) (Bundesland, error)
 	GetOrCreateRessort(ctx context.Context, titel string) (Ressort, error)
 	GetOrCreateUrheber(ctx context.Context, arg GetOrCreateUrheberParams) (Urheber, error)
 	GetOrCreateWahlperiode(ctx context.Context, nummer int32) (Wahlperiode, error)
 	GetPerson(ctx context.Context, id string) (GetPersonRow, error)
+	// Returns the latest previous version that was current at the given time
+	GetPersonHistoryAsOf(ctx context.Context, arg GetPersonHistoryAsOfParams) (PersonHistory, error)
+	GetPersonMdbLink(ctx context.Context, arg GetPersonMdbLinkParams) (PersonMdbLink, error)
+	GetPersonMdbLinks(ctx context.Context, personID string) ([]PersonMdbLink, error)
 	GetPersonWahlperioden(ctx context.Context, personID string) ([]int32, error)
 	GetPlenarprotokoll(ctx context.Context, id string) (GetPlenarprotokollRow, error)
+	// Returns the latest previous version that was current at the given time
+	GetPlenarprotokollHistoryAsOf(ctx context.Context, arg GetPlenarprotokollHistoryAsOfParams) (PlenarprotokollHistory, error)
 	GetPlenarprotokollText(ctx context.Context, id string) (GetPlenarprotokollTextRow, error)
 	GetRessortByTitle(ctx context.Context, titel string) (Ressort, error)
+	GetUnlinkedDIPPersons(ctx context.Context, arg GetUnlinkedDIPPersonsParams) ([]GetUnlinkedDIPPersonsRow, error)
+	GetUnlinkedMdBPersons(ctx context.Context, arg GetUnlinkedMdBPersonsParams) ([]GetUnlinkedMdBPersonsRow, error)
 	GetUrheberByDesignationAndTitle(ctx context.Context, arg GetUrheberByDesignationAndTitleParams) (Urheber, error)
 	GetVorgang(ctx context.Context, id string) (GetVorgangRow, error)
+	// Returns the latest previous version that was current at the given time
+	GetVorgangHistoryAsOf(ctx context.Context, arg GetVorgangHistoryAsOfParams) (VorgangHistory, error)
 	GetVorgangsposition(ctx context.Context, id string) (GetVorgangspositionRow, error)
+	// Returns the latest previous version that was current at the given time
+	GetVorgangspositionHistoryAsOf(ctx context.Context, arg GetVorgangspositionHistoryAsOfParams) (VorgangspositionHistory, error)
+	ListAktivitaetHistory(ctx context.Context, aktivitaetID string) ([]AktivitaetHistory, error)
+	ListAktivitaetIDsByWahlperiode(ctx context.Context, wahlperiode int32) ([]string, error)
 	ListAktivitaeten(ctx context.Context, arg ListAktivitaetenParams) ([]ListAktivitaetenRow, error)
 	ListBundeslaender(ctx context.Context) ([]Bundesland, error)
+	ListDanglingAktivitaetFundstelleDrucksache(ctx context.Context) ([]string, error)
+	ListDanglingAktivitaetFundstellePlenarprotokoll(ctx context.Context) ([]string, error)
+	ListDanglingAutorAnzeigePerson(ctx context.Context) ([]string, error)
+	ListDanglingMitberatenVorgang(ctx context.Context) ([]string, error)
+	ListDanglingVerlinkungVorgang(ctx context.Context) ([]string, error)
+	ListDanglingVorgangsbezugAktivitaet(ctx context.Context) ([]string, error)
+	// Queries listing referenced IDs that are missing from their parent table.
+	// Used to backfill entities that are referenced but were never synced.
+	ListDanglingVorgangsbezugDrucksache(ctx context.Context) ([]string, error)
+	ListDanglingVorgangsbezugPlenarprotokoll(ctx context.Context) ([]string, error)
+	ListDanglingVorgangspositionFundstelleDrucksache(ctx context.Context) ([]string, error)
+	ListDanglingVorgangspositionFundstellePlenarprotokoll(ctx context.Context) ([]string, error)
+	ListDanglingVorgangspositionVorgang(ctx context.Context) ([]string, error)
+	ListDrucksacheHistory(ctx context.Context, drucksacheID string) ([]DrucksacheHistory, error)
+	ListDrucksacheIDsByWahlperiode(ctx context.Context, wahlperiode sql.NullInt32) ([]string, error)
 	ListDrucksacheTexte(ctx context.Context, arg ListDrucksacheTexteParams) ([]ListDrucksacheTexteRow, error)
 	ListDrucksachen(ctx context.Context, arg ListDrucksachenParams) ([]ListDrucksachenRow, error)
+	ListMdbPersons(ctx context.Context, arg ListMdbPersonsParams) ([]MdbPerson, error)
+	ListMdbStammdatenVersions(ctx context.Context) ([]MdbStammdatenVersion, error)
+	ListPersonHistory(ctx context.Context, personID string) ([]PersonHistory, error)
+	// Queries used to detect records that were removed from the DIP API.
+	ListPersonIDsByWahlperiode(ctx context.Context, wahlperiodeNummer int32) ([]string, error)
 	ListPersonen(ctx context.Context, arg ListPersonenParams) ([]ListPersonenRow, error)
+	ListPlenarprotokollHistory(ctx context.Context, plenarprotokollID string) ([]PlenarprotokollHistory, error)
+	ListPlenarprotokollIDsByWahlperiode(ctx context.Context, wahlperiode sql.NullInt32) ([]string, error)
 	ListPlenarprotokollTexte(ctx context.Context, arg ListPlenarprotokollTexteParams) ([]ListPlenarprotokollTexteRow, error)
 	ListPlenarprotokolle(ctx context.Context, arg ListPlenarprotokolleParams) ([]ListPlenarprotokolleRow, error)
 	ListRessorts(ctx context.Context) ([]Ressort, error)
 	ListUrheber(ctx context.Context) ([]Urheber, error)
 	ListVorgaenge(ctx context.Context, arg ListVorgaengeParams) ([]ListVorgaengeRow, error)
+	ListVorgangHistory(ctx context.Context, vorgangID string) ([]VorgangHistory, error)
+	ListVorgangIDsByWahlperiode(ctx context.Context, wahlperiode int32) ([]string, error)
+	ListVorgangspositionHistory(ctx context.Context, vorgangspositionID string) ([]VorgangspositionHistory, error)
+	ListVorgangspositionIDsByWahlperiode(ctx context.Context, wahlperiode int32) ([]string, error)
 	ListVorgangspositionen(ctx context.Context, arg ListVorgangspositionenParams) ([]ListVorgangspositionenRow, error)
 	ListWahlperioden(ctx context.Context) ([]Wahlperiode, error)
+	MarkAktivitaetDeleted(ctx context.Context, arg MarkAktivitaetDeletedParams) error
+	MarkDrucksacheDeleted(ctx context.Context, arg MarkDrucksacheDeletedParams) error
+	MarkPersonDeleted(ctx context.Context, arg MarkPersonDeletedParams) error
+	MarkPlenarprotokollDeleted(ctx context.Context, arg MarkPlenarprotokollDeletedParams) error
+	MarkVorgangDeleted(ctx context.Context, arg MarkVorgangDeletedParams) error
+	MarkVorgangspositionDeleted(ctx context.Context, arg MarkVorgangspositionDeletedParams) error
+	// ============================================================================
+	// SEARCH AND LOOKUP QUERIES
+	// ============================================================================
+	SearchMdbByName(ctx context.Context, arg SearchMdbByNameParams) ([]SearchMdbByNameRow, error)
 	UpdateAktivitaet(ctx context.Context, arg UpdateAktivitaetParams) (Aktivitaet, error)
 	UpdateDrucksache(ctx context.Context, arg UpdateDrucksacheParams) (Drucksache, error)
 	UpdateDrucksacheText(ctx context.Context, arg UpdateDrucksacheTextParams) (DrucksacheText, error)
+	UpdateMdbBiographical(ctx context.Context, arg UpdateMdbBiographicalParams) error
 	UpdatePerson(ctx context.Context, arg UpdatePersonParams) (Person, error)
+	UpdatePersonMdbLinkConfidence(ctx context.Context, arg UpdatePersonMdbLinkConfidenceParams) error
 	UpdatePlenarprotokoll(ctx context.Context, arg UpdatePlenarprotokollParams) (Plenarprotokoll, error)
 	UpdatePlenarprotokollText(ctx context.Context, arg UpdatePlenarprotokollTextParams) (PlenarprotokollText, error)
 	UpdateVorgang(ctx context.Context, arg UpdateVorgangParams) (Vorgang, error)
 	UpdateVorgangsposition(ctx context.Context, arg UpdateVorgangspositionParams) (Vorgangsposition, error)
+	UpsertAktivitaet(ctx context.Context, arg UpsertAktivitaetParams) error
+	UpsertDrucksache(ctx context.Context, arg UpsertDrucksacheParams) error
+	UpsertPerson(ctx context.Context, arg UpsertPersonParams) error
+	UpsertPlenarprotokoll(ctx context.Context, arg UpsertPlenarprotokollParams) error
+	UpsertVorgang(ctx context.Context, arg UpsertVorgangParams) error
+	UpsertVorgangsposition(ctx context.Context, arg UpsertVorgangspositionParams) error
+	VerifyPersonMdbLink(ctx context.Context, arg VerifyPersonMdbLinkParams) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -141,7 +141,7 @@ INSERT INTO vorgang (
     archiv, beratungsstand, datum, gesta, kom, mitteilung,
     ratsdok, sek, wahlperiode
 ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
-RETURNING id, titel, vorgangstyp, typ, abstract, aktualisiert, archiv, beratungsstand, datum, gesta, kom, mitteilung, ratsdok, sek, wahlperiode, created_at, updated_at
+RETURNING id, titel, vorgangstyp, typ, abstract, aktualisiert, archiv, beratungsstand, datum, gesta, kom, mitteilung, ratsdok, sek, wahlperiode, created_at, updated_at, deleted_at
 `
 
 type CreateVorgangParams struct {
@@ -199,6 +199,7 @@ func (q *Queries) CreateVorgang(ctx context.Context, arg CreateVorgangParams) (V
 		&i.Wahlperiode,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -326,6 +327,24 @@ func (q *Queries) CreateVorgangZustimmungsbeduerftigkeit(ctx context.Context, ar
 	return err
 }
 
+const deleteInkrafttreten = `-- name: DeleteInkrafttreten :exec
+DELETE FROM inkrafttreten WHERE vorgang_id = $1
+`
+
+func (q *Queries) DeleteInkrafttreten(ctx context.Context, vorgangID string) error {
+	_, err := q.db.ExecContext(ctx, deleteInkrafttreten, vorgangID)
+	return err
+}
+
+const deleteVerkuendungen = `-- name: DeleteVerkuendungen :exec
+DELETE FROM verkuendung WHERE vorgang_id = $1
+`
+
+func (q *Queries) DeleteVerkuendungen(ctx context.Context, vorgangID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVerkuendungen, vorgangID)
+	return err
+}
+
 const deleteVorgang = `-- name: DeleteVorgang :exec
 DELETE FROM vorgang WHERE id = $1
 `
@@ -335,9 +354,65 @@ func (q *Queries) DeleteVorgang(ctx context.Context, id string) error {
 	return err
 }
 
+const deleteVorgangDeskriptoren = `-- name: DeleteVorgangDeskriptoren :exec
+DELETE FROM vorgang_deskriptor WHERE vorgang_id = $1
+`
+
+func (q *Queries) DeleteVorgangDeskriptoren(ctx context.Context, vorgangID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVorgangDeskriptoren, vorgangID)
+	return err
+}
+
+const deleteVorgangInitiativen = `-- name: DeleteVorgangInitiativen :exec
+DELETE FROM vorgang_initiative WHERE vorgang_id = $1
+`
+
+func (q *Queries) DeleteVorgangInitiativen(ctx context.Context, vorgangID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVorgangInitiativen, vorgangID)
+	return err
+}
+
+const deleteVorgangSachgebiete = `-- name: DeleteVorgangSachgebiete :exec
+DELETE FROM vorgang_sachgebiet WHERE vorgang_id = $1
+`
+
+func (q *Queries) DeleteVorgangSachgebiete(ctx context.Context, vorgangID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVorgangSachgebiete, vorgangID)
+	return err
+}
+
+const deleteVorgangVerlinkungen = `-- name: DeleteVorgangVerlinkungen :exec
+DELETE FROM vorgang_verlinkung WHERE source_vorgang_id = $1
+`
+
+func (q *Queries) DeleteVorgangVerlinkungen(ctx context.Context, sourceVorgangID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVorgangVerlinkungen, sourceVorgangID)
+	return err
+}
+
+const deleteVorgangZustimmungsbeduerftigkeiten = `-- name: DeleteVorgangZustimmungsbeduerftigkeiten :exec
+DELETE FROM vorgang_zustimmungsbeduerftigkeit WHERE vorgang_id = $1
+`
+
+func (q *Queries) DeleteVorgangZustimmungsbeduerftigkeiten(ctx context.Context, vorgangID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVorgangZustimmungsbeduerftigkeiten, vorgangID)
+	return err
+}
+
+const getLatestVorgangDatum = `-- name: GetLatestVorgangDatum :one
+SELECT MIN(datum) AS datum FROM vorgang
+`
+
+func (q *Queries) GetLatestVorgangDatum(ctx context.Context) (interface{}, error) {
+	row := q.db.QueryRowContext(ctx, getLatestVorgangDatum)
+	var datum interface{}
+	err := row.Scan(&datum)
+	return datum, err
+}
+
 const getVorgang = `-- name: GetVorgang :one
 SELECT 
-    v.id, v.titel, v.vorgangstyp, v.typ, v.abstract, v.aktualisiert, v.archiv, v.beratungsstand, v.datum, v.gesta, v.kom, v.mitteilung, v.ratsdok, v.sek, v.wahlperiode, v.created_at, v.updated_at,
+    v.id, v.titel, v.vorgangstyp, v.typ, v.abstract, v.aktualisiert, v.archiv, v.beratungsstand, v.datum, v.gesta, v.kom, v.mitteilung, v.ratsdok, v.sek, v.wahlperiode, v.created_at, v.updated_at, v.deleted_at,
     COALESCE(
         json_agg(DISTINCT vi.initiative) FILTER (WHERE vi.id IS NOT NULL),
         '[]'
@@ -430,6 +505,7 @@ type GetVorgangRow struct {
 	Wahlperiode               int32          `json:"wahlperiode"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
 	Initiative                interface{}    `json:"initiative"`
 	Sachgebiet                interface{}    `json:"sachgebiet"`
 	Zustimmungsbeduerftigkeit interface{}    `json:"zustimmungsbeduerftigkeit"`
@@ -460,6 +536,7 @@ func (q *Queries) GetVorgang(ctx context.Context, id string) (GetVorgangRow, err
 		&i.Wahlperiode,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 		&i.Initiative,
 		&i.Sachgebiet,
 		&i.Zustimmungsbeduerftigkeit,
@@ -473,7 +550,7 @@ func (q *Queries) GetVorgang(ctx context.Context, id string) (GetVorgangRow, err
 
 const listVorgaenge = `-- name: ListVorgaenge :many
 SELECT 
-    v.id, v.titel, v.vorgangstyp, v.typ, v.abstract, v.aktualisiert, v.archiv, v.beratungsstand, v.datum, v.gesta, v.kom, v.mitteilung, v.ratsdok, v.sek, v.wahlperiode, v.created_at, v.updated_at,
+    v.id, v.titel, v.vorgangstyp, v.typ, v.abstract, v.aktualisiert, v.archiv, v.beratungsstand, v.datum, v.gesta, v.kom, v.mitteilung, v.ratsdok, v.sek, v.wahlperiode, v.created_at, v.updated_at, v.deleted_at,
     COALESCE(
         json_agg(DISTINCT vi.initiative) FILTER (WHERE vi.id IS NOT NULL),
         '[]'
@@ -568,6 +645,7 @@ type ListVorgaengeRow struct {
 	Wahlperiode               int32          `json:"wahlperiode"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
 	Initiative                interface{}    `json:"initiative"`
 	Sachgebiet                interface{}    `json:"sachgebiet"`
 	Zustimmungsbeduerftigkeit interface{}    `json:"zustimmungsbeduerftigkeit"`
@@ -613,6 +691,7 @@ func (q *Queries) ListVorgaenge(ctx context.Context, arg ListVorgaengeParams) ([
 			&i.Wahlperiode,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
 			&i.Initiative,
 			&i.Sachgebiet,
 			&i.Zustimmungsbeduerftigkeit,
@@ -642,9 +721,10 @@ SET
     beratungsstand = $5,
     datum = $6,
     mitteilung = $7,
+    deleted_at = NULL,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, titel, vorgangstyp, typ, abstract, aktualisiert, archiv, beratungsstand, datum, gesta, kom, mitteilung, ratsdok, sek, wahlperiode, created_at, updated_at
+RETURNING id, titel, vorgangstyp, typ, abstract, aktualisiert, archiv, beratungsstand, datum, gesta, kom, mitteilung, ratsdok, sek, wahlperiode, created_at, updated_at, deleted_at
 `
 
 type UpdateVorgangParams struct {
@@ -686,6 +766,72 @@ func (q *Queries) UpdateVorgang(ctx context.Context, arg UpdateVorgangParams) (V
 		&i.Wahlperiode,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
+
+const upsertVorgang = `-- name: UpsertVorgang :exec
+INSERT INTO vorgang (
+    id, titel, vorgangstyp, typ, abstract, aktualisiert,
+    archiv, beratungsstand, datum, gesta, kom, mitteilung,
+    ratsdok, sek, wahlperiode
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+ON CONFLICT (id) DO UPDATE
+SET
+    titel = excluded.titel,
+    vorgangstyp = excluded.vorgangstyp,
+    typ = excluded.typ,
+    abstract = excluded.abstract,
+    aktualisiert = excluded.aktualisiert,
+    archiv = excluded.archiv,
+    beratungsstand = excluded.beratungsstand,
+    datum = excluded.datum,
+    gesta = excluded.gesta,
+    kom = excluded.kom,
+    mitteilung = excluded.mitteilung,
+    ratsdok = excluded.ratsdok,
+    sek = excluded.sek,
+    wahlperiode = excluded.wahlperiode,
+    deleted_at = NULL,
+    updated_at = NOW()
+`
+
+type UpsertVorgangParams struct {
+	ID             string         `json:"id"`
+	Titel          string         `json:"titel"`
+	Vorgangstyp    string         `json:"vorgangstyp"`
+	Typ            string         `json:"typ"`
+	Abstract       sql.NullString `json:"abstract"`
+	Aktualisiert   time.Time      `json:"aktualisiert"`
+	Archiv         sql.NullString `json:"archiv"`
+	Beratungsstand sql.NullString `json:"beratungsstand"`
+	Datum          sql.NullTime   `json:"datum"`
+	Gesta          sql.NullString `json:"gesta"`
+	Kom            sql.NullString `json:"kom"`
+	Mitteilung     sql.NullString `json:"mitteilung"`
+	Ratsdok        sql.NullString `json:"ratsdok"`
+	Sek            sql.NullString `json:"sek"`
+	Wahlperiode    int32          `json:"wahlperiode"`
+}
+
+func (q *Queries) UpsertVorgang(ctx context.Context, arg UpsertVorgangParams) error {
+	_, err := q.db.ExecContext(ctx, upsertVorgang,
+		arg.ID,
+		arg.Titel,
+		arg.Vorgangstyp,
+		arg.Typ,
+		arg.Abstract,
+		arg.Aktualisiert,
+		arg.Archiv,
+		arg.Beratungsstand,
+		arg.Datum,
+		arg.Gesta,
+		arg.Kom,
+		arg.Mitteilung,
+		arg.Ratsdok,
+		arg.Sek,
+		arg.Wahlperiode,
+	)
+	return err
+}
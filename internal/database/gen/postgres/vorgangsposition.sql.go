@@ -181,14 +181,14 @@ INSERT INTO vorgangsposition (
     fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
     fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
     fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
-    fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
     fundstelle_frage_nummer, fundstelle_verteildatum
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
     $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
     $21, $22, $23, $24, $25, $26, $27, $28, $29, $30,
-    $31, $32, $33, $34, $35
-) RETURNING id, vorgang_id, titel, vorgangsposition, vorgangstyp, typ, dokumentart, datum, aktualisiert, abstract, fortsetzung, gang, nachtrag, aktivitaet_anzahl, kom, ratsdok, sek, zuordnung, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at
+    $31, $32, $33, $34, $35, $36
+) RETURNING id, vorgang_id, titel, vorgangsposition, vorgangstyp, typ, dokumentart, datum, aktualisiert, abstract, fortsetzung, gang, nachtrag, aktivitaet_anzahl, kom, ratsdok, sek, zuordnung, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url, deleted_at
 `
 
 type CreateVorgangspositionParams struct {
@@ -223,6 +223,7 @@ type CreateVorgangspositionParams struct {
 	FundstelleEndquadrant     sql.NullString `json:"fundstelle_endquadrant"`
 	FundstelleSeite           sql.NullString `json:"fundstelle_seite"`
 	FundstellePdfUrl          sql.NullString `json:"fundstelle_pdf_url"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
 	FundstelleTop             sql.NullInt32  `json:"fundstelle_top"`
 	FundstelleTopZusatz       sql.NullString `json:"fundstelle_top_zusatz"`
 	FundstelleFrageNummer     sql.NullString `json:"fundstelle_frage_nummer"`
@@ -262,6 +263,7 @@ func (q *Queries) CreateVorgangsposition(ctx context.Context, arg CreateVorgangs
 		arg.FundstelleEndquadrant,
 		arg.FundstelleSeite,
 		arg.FundstellePdfUrl,
+		arg.FundstelleXmlUrl,
 		arg.FundstelleTop,
 		arg.FundstelleTopZusatz,
 		arg.FundstelleFrageNummer,
@@ -306,6 +308,8 @@ func (q *Queries) CreateVorgangsposition(ctx context.Context, arg CreateVorgangs
 		&i.FundstelleVerteildatum,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -383,6 +387,33 @@ func (q *Queries) CreateVorgangspositionUrheber(ctx context.Context, arg CreateV
 	return err
 }
 
+const deleteAktivitaetAnzeigen = `-- name: DeleteAktivitaetAnzeigen :exec
+DELETE FROM aktivitaet_anzeige WHERE vorgangsposition_id = $1
+`
+
+func (q *Queries) DeleteAktivitaetAnzeigen(ctx context.Context, vorgangspositionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteAktivitaetAnzeigen, vorgangspositionID)
+	return err
+}
+
+const deleteBeschlussfassungen = `-- name: DeleteBeschlussfassungen :exec
+DELETE FROM beschlussfassung WHERE vorgangsposition_id = $1
+`
+
+func (q *Queries) DeleteBeschlussfassungen(ctx context.Context, vorgangspositionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteBeschlussfassungen, vorgangspositionID)
+	return err
+}
+
+const deleteUeberweisungen = `-- name: DeleteUeberweisungen :exec
+DELETE FROM ueberweisung WHERE vorgangsposition_id = $1
+`
+
+func (q *Queries) DeleteUeberweisungen(ctx context.Context, vorgangspositionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteUeberweisungen, vorgangspositionID)
+	return err
+}
+
 const deleteVorgangsposition = `-- name: DeleteVorgangsposition :exec
 DELETE FROM vorgangsposition WHERE id = $1
 `
@@ -392,9 +423,47 @@ func (q *Queries) DeleteVorgangsposition(ctx context.Context, id string) error {
 	return err
 }
 
+const deleteVorgangspositionMitberaten = `-- name: DeleteVorgangspositionMitberaten :exec
+DELETE FROM vorgangsposition_mitberaten WHERE vorgangsposition_id = $1
+`
+
+func (q *Queries) DeleteVorgangspositionMitberaten(ctx context.Context, vorgangspositionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVorgangspositionMitberaten, vorgangspositionID)
+	return err
+}
+
+const deleteVorgangspositionRessorts = `-- name: DeleteVorgangspositionRessorts :exec
+DELETE FROM vorgangsposition_ressort WHERE vorgangsposition_id = $1
+`
+
+func (q *Queries) DeleteVorgangspositionRessorts(ctx context.Context, vorgangspositionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVorgangspositionRessorts, vorgangspositionID)
+	return err
+}
+
+const deleteVorgangspositionUrheber = `-- name: DeleteVorgangspositionUrheber :exec
+DELETE FROM vorgangsposition_urheber WHERE vorgangsposition_id = $1
+`
+
+func (q *Queries) DeleteVorgangspositionUrheber(ctx context.Context, vorgangspositionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVorgangspositionUrheber, vorgangspositionID)
+	return err
+}
+
+const getLatestVorgangspositionDatum = `-- name: GetLatestVorgangspositionDatum :one
+SELECT MIN(datum) AS datum FROM vorgangsposition
+`
+
+func (q *Queries) GetLatestVorgangspositionDatum(ctx context.Context) (interface{}, error) {
+	row := q.db.QueryRowContext(ctx, getLatestVorgangspositionDatum)
+	var datum interface{}
+	err := row.Scan(&datum)
+	return datum, err
+}
+
 const getVorgangsposition = `-- name: GetVorgangsposition :one
 SELECT 
-    vp.id, vp.vorgang_id, vp.titel, vp.vorgangsposition, vp.vorgangstyp, vp.typ, vp.dokumentart, vp.datum, vp.aktualisiert, vp.abstract, vp.fortsetzung, vp.gang, vp.nachtrag, vp.aktivitaet_anzahl, vp.kom, vp.ratsdok, vp.sek, vp.zuordnung, vp.fundstelle_dokumentnummer, vp.fundstelle_datum, vp.fundstelle_dokumentart, vp.fundstelle_herausgeber, vp.fundstelle_id, vp.fundstelle_drucksachetyp, vp.fundstelle_anlagen, vp.fundstelle_anfangsseite, vp.fundstelle_endseite, vp.fundstelle_anfangsquadrant, vp.fundstelle_endquadrant, vp.fundstelle_seite, vp.fundstelle_pdf_url, vp.fundstelle_top, vp.fundstelle_top_zusatz, vp.fundstelle_frage_nummer, vp.fundstelle_verteildatum, vp.created_at, vp.updated_at,
+    vp.id, vp.vorgang_id, vp.titel, vp.vorgangsposition, vp.vorgangstyp, vp.typ, vp.dokumentart, vp.datum, vp.aktualisiert, vp.abstract, vp.fortsetzung, vp.gang, vp.nachtrag, vp.aktivitaet_anzahl, vp.kom, vp.ratsdok, vp.sek, vp.zuordnung, vp.fundstelle_dokumentnummer, vp.fundstelle_datum, vp.fundstelle_dokumentart, vp.fundstelle_herausgeber, vp.fundstelle_id, vp.fundstelle_drucksachetyp, vp.fundstelle_anlagen, vp.fundstelle_anfangsseite, vp.fundstelle_endseite, vp.fundstelle_anfangsquadrant, vp.fundstelle_endquadrant, vp.fundstelle_seite, vp.fundstelle_pdf_url, vp.fundstelle_top, vp.fundstelle_top_zusatz, vp.fundstelle_frage_nummer, vp.fundstelle_verteildatum, vp.created_at, vp.updated_at, vp.fundstelle_xml_url, vp.deleted_at,
     COALESCE(
         json_agg(
             DISTINCT jsonb_build_object(
@@ -513,6 +582,8 @@ type GetVorgangspositionRow struct {
 	FundstelleVerteildatum    sql.NullTime   `json:"fundstelle_verteildatum"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
 	Ressort                   interface{}    `json:"ressort"`
 	Urheber                   interface{}    `json:"urheber"`
 	Ueberweisung              interface{}    `json:"ueberweisung"`
@@ -562,6 +633,8 @@ func (q *Queries) GetVorgangsposition(ctx context.Context, id string) (GetVorgan
 		&i.FundstelleVerteildatum,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 		&i.Ressort,
 		&i.Urheber,
 		&i.Ueberweisung,
@@ -574,7 +647,7 @@ func (q *Queries) GetVorgangsposition(ctx context.Context, id string) (GetVorgan
 
 const listVorgangspositionen = `-- name: ListVorgangspositionen :many
 SELECT 
-    vp.id, vp.vorgang_id, vp.titel, vp.vorgangsposition, vp.vorgangstyp, vp.typ, vp.dokumentart, vp.datum, vp.aktualisiert, vp.abstract, vp.fortsetzung, vp.gang, vp.nachtrag, vp.aktivitaet_anzahl, vp.kom, vp.ratsdok, vp.sek, vp.zuordnung, vp.fundstelle_dokumentnummer, vp.fundstelle_datum, vp.fundstelle_dokumentart, vp.fundstelle_herausgeber, vp.fundstelle_id, vp.fundstelle_drucksachetyp, vp.fundstelle_anlagen, vp.fundstelle_anfangsseite, vp.fundstelle_endseite, vp.fundstelle_anfangsquadrant, vp.fundstelle_endquadrant, vp.fundstelle_seite, vp.fundstelle_pdf_url, vp.fundstelle_top, vp.fundstelle_top_zusatz, vp.fundstelle_frage_nummer, vp.fundstelle_verteildatum, vp.created_at, vp.updated_at,
+    vp.id, vp.vorgang_id, vp.titel, vp.vorgangsposition, vp.vorgangstyp, vp.typ, vp.dokumentart, vp.datum, vp.aktualisiert, vp.abstract, vp.fortsetzung, vp.gang, vp.nachtrag, vp.aktivitaet_anzahl, vp.kom, vp.ratsdok, vp.sek, vp.zuordnung, vp.fundstelle_dokumentnummer, vp.fundstelle_datum, vp.fundstelle_dokumentart, vp.fundstelle_herausgeber, vp.fundstelle_id, vp.fundstelle_drucksachetyp, vp.fundstelle_anlagen, vp.fundstelle_anfangsseite, vp.fundstelle_endseite, vp.fundstelle_anfangsquadrant, vp.fundstelle_endquadrant, vp.fundstelle_seite, vp.fundstelle_pdf_url, vp.fundstelle_top, vp.fundstelle_top_zusatz, vp.fundstelle_frage_nummer, vp.fundstelle_verteildatum, vp.created_at, vp.updated_at, vp.fundstelle_xml_url, vp.deleted_at,
     COALESCE(
         json_agg(
             DISTINCT jsonb_build_object(
@@ -700,6 +773,8 @@ type ListVorgangspositionenRow struct {
 	FundstelleVerteildatum    sql.NullTime   `json:"fundstelle_verteildatum"`
 	CreatedAt                 time.Time      `json:"created_at"`
 	UpdatedAt                 time.Time      `json:"updated_at"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullTime   `json:"deleted_at"`
 	Ressort                   interface{}    `json:"ressort"`
 	Urheber                   interface{}    `json:"urheber"`
 	Ueberweisung              interface{}    `json:"ueberweisung"`
@@ -765,6 +840,8 @@ func (q *Queries) ListVorgangspositionen(ctx context.Context, arg ListVorgangspo
 			&i.FundstelleVerteildatum,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 			&i.Ressort,
 			&i.Urheber,
 			&i.Ueberweisung,
@@ -791,9 +868,10 @@ SET
     aktualisiert = $3,
     abstract = $4,
     aktivitaet_anzahl = $5,
+    deleted_at = NULL,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, vorgang_id, titel, vorgangsposition, vorgangstyp, typ, dokumentart, datum, aktualisiert, abstract, fortsetzung, gang, nachtrag, aktivitaet_anzahl, kom, ratsdok, sek, zuordnung, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at
+RETURNING id, vorgang_id, titel, vorgangsposition, vorgangstyp, typ, dokumentart, datum, aktualisiert, abstract, fortsetzung, gang, nachtrag, aktivitaet_anzahl, kom, ratsdok, sek, zuordnung, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url, deleted_at
 `
 
 type UpdateVorgangspositionParams struct {
@@ -851,6 +929,146 @@ func (q *Queries) UpdateVorgangsposition(ctx context.Context, arg UpdateVorgangs
 		&i.FundstelleVerteildatum,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
+
+const upsertVorgangsposition = `-- name: UpsertVorgangsposition :exec
+INSERT INTO vorgangsposition (
+    id, vorgang_id, titel, vorgangsposition, vorgangstyp, typ, dokumentart,
+    datum, aktualisiert, abstract, fortsetzung, gang, nachtrag, aktivitaet_anzahl,
+    kom, ratsdok, sek, zuordnung,
+    fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+    fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
+    fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
+    fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_frage_nummer, fundstelle_verteildatum
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+    $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+    $21, $22, $23, $24, $25, $26, $27, $28, $29, $30,
+    $31, $32, $33, $34, $35, $36
+) ON CONFLICT (id) DO UPDATE
+SET
+    vorgang_id = excluded.vorgang_id,
+    titel = excluded.titel,
+    vorgangsposition = excluded.vorgangsposition,
+    vorgangstyp = excluded.vorgangstyp,
+    typ = excluded.typ,
+    dokumentart = excluded.dokumentart,
+    datum = excluded.datum,
+    aktualisiert = excluded.aktualisiert,
+    abstract = excluded.abstract,
+    fortsetzung = excluded.fortsetzung,
+    gang = excluded.gang,
+    nachtrag = excluded.nachtrag,
+    aktivitaet_anzahl = excluded.aktivitaet_anzahl,
+    kom = excluded.kom,
+    ratsdok = excluded.ratsdok,
+    sek = excluded.sek,
+    zuordnung = excluded.zuordnung,
+    fundstelle_dokumentnummer = excluded.fundstelle_dokumentnummer,
+    fundstelle_datum = excluded.fundstelle_datum,
+    fundstelle_dokumentart = excluded.fundstelle_dokumentart,
+    fundstelle_herausgeber = excluded.fundstelle_herausgeber,
+    fundstelle_id = excluded.fundstelle_id,
+    fundstelle_drucksachetyp = excluded.fundstelle_drucksachetyp,
+    fundstelle_anlagen = excluded.fundstelle_anlagen,
+    fundstelle_anfangsseite = excluded.fundstelle_anfangsseite,
+    fundstelle_endseite = excluded.fundstelle_endseite,
+    fundstelle_anfangsquadrant = excluded.fundstelle_anfangsquadrant,
+    fundstelle_endquadrant = excluded.fundstelle_endquadrant,
+    fundstelle_seite = excluded.fundstelle_seite,
+    fundstelle_pdf_url = excluded.fundstelle_pdf_url,
+    fundstelle_xml_url = excluded.fundstelle_xml_url,
+    fundstelle_top = excluded.fundstelle_top,
+    fundstelle_top_zusatz = excluded.fundstelle_top_zusatz,
+    fundstelle_frage_nummer = excluded.fundstelle_frage_nummer,
+    fundstelle_verteildatum = excluded.fundstelle_verteildatum,
+    deleted_at = NULL,
+    updated_at = NOW()
+`
+
+type UpsertVorgangspositionParams struct {
+	ID                        string         `json:"id"`
+	VorgangID                 string         `json:"vorgang_id"`
+	Titel                     string         `json:"titel"`
+	Vorgangsposition          string         `json:"vorgangsposition"`
+	Vorgangstyp               string         `json:"vorgangstyp"`
+	Typ                       string         `json:"typ"`
+	Dokumentart               string         `json:"dokumentart"`
+	Datum                     time.Time      `json:"datum"`
+	Aktualisiert              time.Time      `json:"aktualisiert"`
+	Abstract                  sql.NullString `json:"abstract"`
+	Fortsetzung               bool           `json:"fortsetzung"`
+	Gang                      bool           `json:"gang"`
+	Nachtrag                  bool           `json:"nachtrag"`
+	AktivitaetAnzahl          int32          `json:"aktivitaet_anzahl"`
+	Kom                       sql.NullString `json:"kom"`
+	Ratsdok                   sql.NullString `json:"ratsdok"`
+	Sek                       sql.NullString `json:"sek"`
+	Zuordnung                 string         `json:"zuordnung"`
+	FundstelleDokumentnummer  string         `json:"fundstelle_dokumentnummer"`
+	FundstelleDatum           time.Time      `json:"fundstelle_datum"`
+	FundstelleDokumentart     string         `json:"fundstelle_dokumentart"`
+	FundstelleHerausgeber     string         `json:"fundstelle_herausgeber"`
+	FundstelleID              string         `json:"fundstelle_id"`
+	FundstelleDrucksachetyp   sql.NullString `json:"fundstelle_drucksachetyp"`
+	FundstelleAnlagen         sql.NullString `json:"fundstelle_anlagen"`
+	FundstelleAnfangsseite    sql.NullInt32  `json:"fundstelle_anfangsseite"`
+	FundstelleEndseite        sql.NullInt32  `json:"fundstelle_endseite"`
+	FundstelleAnfangsquadrant sql.NullString `json:"fundstelle_anfangsquadrant"`
+	FundstelleEndquadrant     sql.NullString `json:"fundstelle_endquadrant"`
+	FundstelleSeite           sql.NullString `json:"fundstelle_seite"`
+	FundstellePdfUrl          sql.NullString `json:"fundstelle_pdf_url"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	FundstelleTop             sql.NullInt32  `json:"fundstelle_top"`
+	FundstelleTopZusatz       sql.NullString `json:"fundstelle_top_zusatz"`
+	FundstelleFrageNummer     sql.NullString `json:"fundstelle_frage_nummer"`
+	FundstelleVerteildatum    sql.NullTime   `json:"fundstelle_verteildatum"`
+}
+
+func (q *Queries) UpsertVorgangsposition(ctx context.Context, arg UpsertVorgangspositionParams) error {
+	_, err := q.db.ExecContext(ctx, upsertVorgangsposition,
+		arg.ID,
+		arg.VorgangID,
+		arg.Titel,
+		arg.Vorgangsposition,
+		arg.Vorgangstyp,
+		arg.Typ,
+		arg.Dokumentart,
+		arg.Datum,
+		arg.Aktualisiert,
+		arg.Abstract,
+		arg.Fortsetzung,
+		arg.Gang,
+		arg.Nachtrag,
+		arg.AktivitaetAnzahl,
+		arg.Kom,
+		arg.Ratsdok,
+		arg.Sek,
+		arg.Zuordnung,
+		arg.FundstelleDokumentnummer,
+		arg.FundstelleDatum,
+		arg.FundstelleDokumentart,
+		arg.FundstelleHerausgeber,
+		arg.FundstelleID,
+		arg.FundstelleDrucksachetyp,
+		arg.FundstelleAnlagen,
+		arg.FundstelleAnfangsseite,
+		arg.FundstelleEndseite,
+		arg.FundstelleAnfangsquadrant,
+		arg.FundstelleEndquadrant,
+		arg.FundstelleSeite,
+		arg.FundstellePdfUrl,
+		arg.FundstelleXmlUrl,
+		arg.FundstelleTop,
+		arg.FundstelleTopZusatz,
+		arg.FundstelleFrageNummer,
+		arg.FundstelleVerteildatum,
+	)
+	return err
+}
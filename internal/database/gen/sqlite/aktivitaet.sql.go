@@ -75,7 +75,7 @@ INSERT INTO aktivitaet (
     ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
     ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
     ?, ?, ?, ?, ?, ?, ?, ?
-) RETURNING id, titel, aktivitaetsart, typ, dokumentart, datum, aktualisiert, abstract, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url
+) RETURNING id, titel, aktivitaetsart, typ, dokumentart, datum, aktualisiert, abstract, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url, deleted_at
 `
 
 type CreateAktivitaetParams struct {
@@ -172,6 +172,7 @@ func (q *Queries) CreateAktivitaet(ctx context.Context, arg CreateAktivitaetPara
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -239,9 +240,27 @@ func (q *Queries) DeleteAktivitaet(ctx context.Context, id string) error {
 	return err
 }
 
+const deleteAktivitaetDeskriptoren = `-- name: DeleteAktivitaetDeskriptoren :exec
+DELETE FROM aktivitaet_deskriptor WHERE aktivitaet_id = ?
+`
+
+func (q *Queries) DeleteAktivitaetDeskriptoren(ctx context.Context, aktivitaetID string) error {
+	_, err := q.db.ExecContext(ctx, deleteAktivitaetDeskriptoren, aktivitaetID)
+	return err
+}
+
+const deleteAktivitaetVorgangsbezuege = `-- name: DeleteAktivitaetVorgangsbezuege :exec
+DELETE FROM aktivitaet_vorgangsbezug WHERE aktivitaet_id = ?
+`
+
+func (q *Queries) DeleteAktivitaetVorgangsbezuege(ctx context.Context, aktivitaetID string) error {
+	_, err := q.db.ExecContext(ctx, deleteAktivitaetVorgangsbezuege, aktivitaetID)
+	return err
+}
+
 const getAktivitaet = `-- name: GetAktivitaet :one
 SELECT 
-    a.id, a.titel, a.aktivitaetsart, a.typ, a.dokumentart, a.datum, a.aktualisiert, a.abstract, a.vorgangsbezug_anzahl, a.wahlperiode, a.fundstelle_dokumentnummer, a.fundstelle_datum, a.fundstelle_dokumentart, a.fundstelle_herausgeber, a.fundstelle_id, a.fundstelle_drucksachetyp, a.fundstelle_anlagen, a.fundstelle_anfangsseite, a.fundstelle_endseite, a.fundstelle_anfangsquadrant, a.fundstelle_endquadrant, a.fundstelle_seite, a.fundstelle_pdf_url, a.fundstelle_top, a.fundstelle_top_zusatz, a.fundstelle_frage_nummer, a.fundstelle_verteildatum, a.created_at, a.updated_at, a.fundstelle_xml_url
+    a.id, a.titel, a.aktivitaetsart, a.typ, a.dokumentart, a.datum, a.aktualisiert, a.abstract, a.vorgangsbezug_anzahl, a.wahlperiode, a.fundstelle_dokumentnummer, a.fundstelle_datum, a.fundstelle_dokumentart, a.fundstelle_herausgeber, a.fundstelle_id, a.fundstelle_drucksachetyp, a.fundstelle_anlagen, a.fundstelle_anfangsseite, a.fundstelle_endseite, a.fundstelle_anfangsquadrant, a.fundstelle_endquadrant, a.fundstelle_seite, a.fundstelle_pdf_url, a.fundstelle_top, a.fundstelle_top_zusatz, a.fundstelle_frage_nummer, a.fundstelle_verteildatum, a.created_at, a.updated_at, a.fundstelle_xml_url, a.deleted_at
 FROM aktivitaet a
 WHERE a.id = ?
 `
@@ -281,6 +300,7 @@ func (q *Queries) GetAktivitaet(ctx context.Context, id string) (Aktivitaet, err
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -367,7 +387,7 @@ func (q *Queries) GetLatestAktivitaetDatum(ctx context.Context) (interface{}, er
 }
 
 const listAktivitaeten = `-- name: ListAktivitaeten :many
-SELECT id, titel, aktivitaetsart, typ, dokumentart, datum, aktualisiert, abstract, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url
+SELECT id, titel, aktivitaetsart, typ, dokumentart, datum, aktualisiert, abstract, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url, deleted_at
 FROM aktivitaet
 WHERE 
     (? IS NULL OR aktualisiert >= ?)
@@ -457,6 +477,7 @@ func (q *Queries) ListAktivitaeten(ctx context.Context, arg ListAktivitaetenPara
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -479,9 +500,10 @@ SET
     aktualisiert = ?,
     abstract = ?,
     vorgangsbezug_anzahl = ?,
+    deleted_at = NULL,
     updated_at = datetime('now')
 WHERE id = ?
-RETURNING id, titel, aktivitaetsart, typ, dokumentart, datum, aktualisiert, abstract, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url
+RETURNING id, titel, aktivitaetsart, typ, dokumentart, datum, aktualisiert, abstract, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url, deleted_at
 `
 
 type UpdateAktivitaetParams struct {
@@ -534,6 +556,119 @@ func (q *Queries) UpdateAktivitaet(ctx context.Context, arg UpdateAktivitaetPara
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
+
+const upsertAktivitaet = `-- name: UpsertAktivitaet :exec
+INSERT INTO aktivitaet (
+    id, titel, aktivitaetsart, typ, dokumentart, datum, aktualisiert,
+    abstract, vorgangsbezug_anzahl, wahlperiode,
+    fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+    fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
+    fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
+    fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_frage_nummer, fundstelle_verteildatum
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?, ?, ?
+) ON CONFLICT (id) DO UPDATE
+SET
+    titel = excluded.titel,
+    aktivitaetsart = excluded.aktivitaetsart,
+    typ = excluded.typ,
+    dokumentart = excluded.dokumentart,
+    datum = excluded.datum,
+    aktualisiert = excluded.aktualisiert,
+    abstract = excluded.abstract,
+    vorgangsbezug_anzahl = excluded.vorgangsbezug_anzahl,
+    wahlperiode = excluded.wahlperiode,
+    fundstelle_dokumentnummer = excluded.fundstelle_dokumentnummer,
+    fundstelle_datum = excluded.fundstelle_datum,
+    fundstelle_dokumentart = excluded.fundstelle_dokumentart,
+    fundstelle_herausgeber = excluded.fundstelle_herausgeber,
+    fundstelle_id = excluded.fundstelle_id,
+    fundstelle_drucksachetyp = excluded.fundstelle_drucksachetyp,
+    fundstelle_anlagen = excluded.fundstelle_anlagen,
+    fundstelle_anfangsseite = excluded.fundstelle_anfangsseite,
+    fundstelle_endseite = excluded.fundstelle_endseite,
+    fundstelle_anfangsquadrant = excluded.fundstelle_anfangsquadrant,
+    fundstelle_endquadrant = excluded.fundstelle_endquadrant,
+    fundstelle_seite = excluded.fundstelle_seite,
+    fundstelle_pdf_url = excluded.fundstelle_pdf_url,
+    fundstelle_xml_url = excluded.fundstelle_xml_url,
+    fundstelle_top = excluded.fundstelle_top,
+    fundstelle_top_zusatz = excluded.fundstelle_top_zusatz,
+    fundstelle_frage_nummer = excluded.fundstelle_frage_nummer,
+    fundstelle_verteildatum = excluded.fundstelle_verteildatum,
+    deleted_at = NULL,
+    updated_at = datetime('now')
+`
+
+type UpsertAktivitaetParams struct {
+	ID                        string         `json:"id"`
+	Titel                     string         `json:"titel"`
+	Aktivitaetsart            string         `json:"aktivitaetsart"`
+	Typ                       string         `json:"typ"`
+	Dokumentart               string         `json:"dokumentart"`
+	Datum                     string         `json:"datum"`
+	Aktualisiert              string         `json:"aktualisiert"`
+	Abstract                  sql.NullString `json:"abstract"`
+	VorgangsbezugAnzahl       int64          `json:"vorgangsbezug_anzahl"`
+	Wahlperiode               int64          `json:"wahlperiode"`
+	FundstelleDokumentnummer  string         `json:"fundstelle_dokumentnummer"`
+	FundstelleDatum           string         `json:"fundstelle_datum"`
+	FundstelleDokumentart     string         `json:"fundstelle_dokumentart"`
+	FundstelleHerausgeber     string         `json:"fundstelle_herausgeber"`
+	FundstelleID              string         `json:"fundstelle_id"`
+	FundstelleDrucksachetyp   sql.NullString `json:"fundstelle_drucksachetyp"`
+	FundstelleAnlagen         sql.NullString `json:"fundstelle_anlagen"`
+	FundstelleAnfangsseite    sql.NullInt64  `json:"fundstelle_anfangsseite"`
+	FundstelleEndseite        sql.NullInt64  `json:"fundstelle_endseite"`
+	FundstelleAnfangsquadrant sql.NullString `json:"fundstelle_anfangsquadrant"`
+	FundstelleEndquadrant     sql.NullString `json:"fundstelle_endquadrant"`
+	FundstelleSeite           sql.NullString `json:"fundstelle_seite"`
+	FundstellePdfUrl          sql.NullString `json:"fundstelle_pdf_url"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	FundstelleTop             sql.NullInt64  `json:"fundstelle_top"`
+	FundstelleTopZusatz       sql.NullString `json:"fundstelle_top_zusatz"`
+	FundstelleFrageNummer     sql.NullString `json:"fundstelle_frage_nummer"`
+	FundstelleVerteildatum    sql.NullString `json:"fundstelle_verteildatum"`
+}
+
+func (q *Queries) UpsertAktivitaet(ctx context.Context, arg UpsertAktivitaetParams) error {
+	_, err := q.db.ExecContext(ctx, upsertAktivitaet,
+		arg.ID,
+		arg.Titel,
+		arg.Aktivitaetsart,
+		arg.Typ,
+		arg.Dokumentart,
+		arg.Datum,
+		arg.Aktualisiert,
+		arg.Abstract,
+		arg.VorgangsbezugAnzahl,
+		arg.Wahlperiode,
+		arg.FundstelleDokumentnummer,
+		arg.FundstelleDatum,
+		arg.FundstelleDokumentart,
+		arg.FundstelleHerausgeber,
+		arg.FundstelleID,
+		arg.FundstelleDrucksachetyp,
+		arg.FundstelleAnlagen,
+		arg.FundstelleAnfangsseite,
+		arg.FundstelleEndseite,
+		arg.FundstelleAnfangsquadrant,
+		arg.FundstelleEndquadrant,
+		arg.FundstelleSeite,
+		arg.FundstellePdfUrl,
+		arg.FundstelleXmlUrl,
+		arg.FundstelleTop,
+		arg.FundstelleTopZusatz,
+		arg.FundstelleFrageNummer,
+		arg.FundstelleVerteildatum,
+	)
+	return err
+}
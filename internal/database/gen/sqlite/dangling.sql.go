@@ -0,0 +1,354 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: dangling.sql
+
+package db
+
+import (
+	"context"
+)
+
+const listDanglingAktivitaetFundstelleDrucksache = `-- name: ListDanglingAktivitaetFundstelleDrucksache :many
+SELECT DISTINCT a.fundstelle_id
+FROM aktivitaet a
+LEFT JOIN drucksache d ON d.id = a.fundstelle_id
+WHERE a.fundstelle_dokumentart = 'Drucksache' AND d.id IS NULL
+ORDER BY a.fundstelle_id
+`
+
+func (q *Queries) ListDanglingAktivitaetFundstelleDrucksache(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listDanglingAktivitaetFundstelleDrucksache)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var fundstelle_id string
+		if err := rows.Scan(&fundstelle_id); err != nil {
+			return nil, err
+		}
+		items = append(items, fundstelle_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDanglingAktivitaetFundstellePlenarprotokoll = `-- name: ListDanglingAktivitaetFundstellePlenarprotokoll :many
+SELECT DISTINCT a.fundstelle_id
+FROM aktivitaet a
+LEFT JOIN plenarprotokoll p ON p.id = a.fundstelle_id
+WHERE a.fundstelle_dokumentart = 'Plenarprotokoll' AND p.id IS NULL
+ORDER BY a.fundstelle_id
+`
+
+func (q *Queries) ListDanglingAktivitaetFundstellePlenarprotokoll(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listDanglingAktivitaetFundstellePlenarprotokoll)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var fundstelle_id string
+		if err := rows.Scan(&fundstelle_id); err != nil {
+			return nil, err
+		}
+		items = append(items, fundstelle_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDanglingAutorAnzeigePerson = `-- name: ListDanglingAutorAnzeigePerson :many
+SELECT DISTINCT daa.person_id
+FROM drucksache_autor_anzeige daa
+LEFT JOIN person p ON p.id = daa.person_id
+WHERE p.id IS NULL
+ORDER BY daa.person_id
+`
+
+func (q *Queries) ListDanglingAutorAnzeigePerson(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listDanglingAutorAnzeigePerson)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var person_id string
+		if err := rows.Scan(&person_id); err != nil {
+			return nil, err
+		}
+		items = append(items, person_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDanglingMitberatenVorgang = `-- name: ListDanglingMitberatenVorgang :many
+SELECT DISTINCT vm.mitberaten_vorgang_id
+FROM vorgangsposition_mitberaten vm
+LEFT JOIN vorgang v ON v.id = vm.mitberaten_vorgang_id
+WHERE v.id IS NULL
+ORDER BY vm.mitberaten_vorgang_id
+`
+
+func (q *Queries) ListDanglingMitberatenVorgang(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listDanglingMitberatenVorgang)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var mitberaten_vorgang_id string
+		if err := rows.Scan(&mitberaten_vorgang_id); err != nil {
+			return nil, err
+		}
+		items = append(items, mitberaten_vorgang_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDanglingVerlinkungVorgang = `-- name: ListDanglingVerlinkungVorgang :many
+SELECT DISTINCT vv.target_vorgang_id
+FROM vorgang_verlinkung vv
+LEFT JOIN vorgang v ON v.id = vv.target_vorgang_id
+WHERE v.id IS NULL
+ORDER BY vv.target_vorgang_id
+`
+
+func (q *Queries) ListDanglingVerlinkungVorgang(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listDanglingVerlinkungVorgang)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var target_vorgang_id string
+		if err := rows.Scan(&target_vorgang_id); err != nil {
+			return nil, err
+		}
+		items = append(items, target_vorgang_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDanglingVorgangsbezugAktivitaet = `-- name: ListDanglingVorgangsbezugAktivitaet :many
+SELECT DISTINCT avb.vorgang_id
+FROM aktivitaet_vorgangsbezug avb
+LEFT JOIN vorgang v ON v.id = avb.vorgang_id
+WHERE v.id IS NULL
+ORDER BY avb.vorgang_id
+`
+
+func (q *Queries) ListDanglingVorgangsbezugAktivitaet(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listDanglingVorgangsbezugAktivitaet)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var vorgang_id string
+		if err := rows.Scan(&vorgang_id); err != nil {
+			return nil, err
+		}
+		items = append(items, vorgang_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDanglingVorgangsbezugDrucksache = `-- name: ListDanglingVorgangsbezugDrucksache :many
+
+SELECT DISTINCT dvb.vorgang_id
+FROM drucksache_vorgangsbezug dvb
+LEFT JOIN vorgang v ON v.id = dvb.vorgang_id
+WHERE v.id IS NULL
+ORDER BY dvb.vorgang_id
+`
+
+// Queries listing referenced IDs that are missing from their parent table.
+// Used to backfill entities that are referenced but were never synced.
+func (q *Queries) ListDanglingVorgangsbezugDrucksache(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listDanglingVorgangsbezugDrucksache)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var vorgang_id string
+		if err := rows.Scan(&vorgang_id); err != nil {
+			return nil, err
+		}
+		items = append(items, vorgang_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDanglingVorgangsbezugPlenarprotokoll = `-- name: ListDanglingVorgangsbezugPlenarprotokoll :many
+SELECT DISTINCT pvb.vorgang_id
+FROM plenarprotokoll_vorgangsbezug pvb
+LEFT JOIN vorgang v ON v.id = pvb.vorgang_id
+WHERE v.id IS NULL
+ORDER BY pvb.vorgang_id
+`
+
+func (q *Queries) ListDanglingVorgangsbezugPlenarprotokoll(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listDanglingVorgangsbezugPlenarprotokoll)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var vorgang_id string
+		if err := rows.Scan(&vorgang_id); err != nil {
+			return nil, err
+		}
+		items = append(items, vorgang_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDanglingVorgangspositionFundstelleDrucksache = `-- name: ListDanglingVorgangspositionFundstelleDrucksache :many
+SELECT DISTINCT vp.fundstelle_id
+FROM vorgangsposition vp
+LEFT JOIN drucksache d ON d.id = vp.fundstelle_id
+WHERE vp.fundstelle_dokumentart = 'Drucksache' AND d.id IS NULL
+ORDER BY vp.fundstelle_id
+`
+
+func (q *Queries) ListDanglingVorgangspositionFundstelleDrucksache(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listDanglingVorgangspositionFundstelleDrucksache)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var fundstelle_id string
+		if err := rows.Scan(&fundstelle_id); err != nil {
+			return nil, err
+		}
+		items = append(items, fundstelle_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDanglingVorgangspositionFundstellePlenarprotokoll = `-- name: ListDanglingVorgangspositionFundstellePlenarprotokoll :many
+SELECT DISTINCT vp.fundstelle_id
+FROM vorgangsposition vp
+LEFT JOIN plenarprotokoll p ON p.id = vp.fundstelle_id
+WHERE vp.fundstelle_dokumentart = 'Plenarprotokoll' AND p.id IS NULL
+ORDER BY vp.fundstelle_id
+`
+
+func (q *Queries) ListDanglingVorgangspositionFundstellePlenarprotokoll(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listDanglingVorgangspositionFundstellePlenarprotokoll)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var fundstelle_id string
+		if err := rows.Scan(&fundstelle_id); err != nil {
+			return nil, err
+		}
+		items = append(items, fundstelle_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDanglingVorgangspositionVorgang = `-- name: ListDanglingVorgangspositionVorgang :many
+SELECT DISTINCT vp.vorgang_id
+FROM vorgangsposition vp
+LEFT JOIN vorgang v ON v.id = vp.vorgang_id
+WHERE v.id IS NULL
+ORDER BY vp.vorgang_id
+`
+
+func (q *Queries) ListDanglingVorgangspositionVorgang(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listDanglingVorgangspositionVorgang)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var vorgang_id string
+		if err := rows.Scan(&vorgang_id); err != nil {
+			return nil, err
+		}
+		items = append(items, vorgang_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,193 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: dokument_pdf.sql
+
+package db
+
+import (
+	"context"
+)
+
+const deleteDokumentPdf = `-- name: DeleteDokumentPdf :exec
+DELETE FROM dokument_pdf WHERE pdf_hash = ?
+`
+
+func (q *Queries) DeleteDokumentPdf(ctx context.Context, pdfHash string) error {
+	_, err := q.db.ExecContext(ctx, deleteDokumentPdf, pdfHash)
+	return err
+}
+
+const deleteDokumentPdfFehler = `-- name: DeleteDokumentPdfFehler :exec
+DELETE FROM dokument_pdf_fehler WHERE pdf_hash = ?
+`
+
+func (q *Queries) DeleteDokumentPdfFehler(ctx context.Context, pdfHash string) error {
+	_, err := q.db.ExecContext(ctx, deleteDokumentPdfFehler, pdfHash)
+	return err
+}
+
+const listDokumentPdfs = `-- name: ListDokumentPdfs :many
+SELECT pdf_hash, pfad, groesse, url, gespeichert_at
+FROM dokument_pdf
+ORDER BY pdf_hash
+`
+
+func (q *Queries) ListDokumentPdfs(ctx context.Context) ([]DokumentPdf, error) {
+	rows, err := q.db.QueryContext(ctx, listDokumentPdfs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DokumentPdf
+	for rows.Next() {
+		var i DokumentPdf
+		if err := rows.Scan(
+			&i.PdfHash,
+			&i.Pfad,
+			&i.Groesse,
+			&i.Url,
+			&i.GespeichertAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingPdfs = `-- name: ListPendingPdfs :many
+
+SELECT
+    CAST(d.pdf_hash AS TEXT) AS pdf_hash,
+    CAST(MIN(d.url) AS TEXT) AS url,
+    CAST(MIN(d.dokumentart) AS TEXT) AS dokumentart,
+    CAST(MIN(d.dokumentnummer) AS TEXT) AS dokumentnummer
+FROM (
+    SELECT ds.pdf_hash, ds.fundstelle_pdf_url AS url, 'Drucksache' AS dokumentart, ds.dokumentnummer, ds.wahlperiode
+    FROM drucksache ds
+    WHERE ds.pdf_hash IS NOT NULL AND ds.pdf_hash <> ''
+      AND ds.fundstelle_pdf_url IS NOT NULL
+      AND ds.deleted_at IS NULL
+      AND (CAST(?1 AS INTEGER) = 0 OR ds.wahlperiode = ?1)
+      AND (CAST(?2 AS TEXT) = '' OR ?2 = 'Drucksache')
+      AND (CAST(?3 AS TEXT) = '' OR ds.drucksachetyp = ?3)
+    UNION ALL
+    SELECT pp.pdf_hash, pp.fundstelle_pdf_url AS url, 'Plenarprotokoll' AS dokumentart, pp.dokumentnummer, pp.wahlperiode
+    FROM plenarprotokoll pp
+    WHERE pp.pdf_hash IS NOT NULL AND pp.pdf_hash <> ''
+      AND pp.fundstelle_pdf_url IS NOT NULL
+      AND pp.deleted_at IS NULL
+      AND (?1 = 0 OR pp.wahlperiode = ?1)
+      AND (?2 = '' OR ?2 = 'Plenarprotokoll')
+      AND ?3 = ''
+) d
+WHERE d.pdf_hash NOT IN (SELECT pdf_hash FROM dokument_pdf)
+  AND (CAST(?4 AS INTEGER) = 1 OR d.pdf_hash NOT IN (SELECT pdf_hash FROM dokument_pdf_fehler))
+GROUP BY d.pdf_hash
+ORDER BY MIN(d.wahlperiode), d.pdf_hash
+`
+
+type ListPendingPdfsParams struct {
+	Wahlperiode   int64  `json:"wahlperiode"`
+	Dokumentart   string `json:"dokumentart"`
+	Drucksachetyp string `json:"drucksachetyp"`
+	RetryFailed   int64  `json:"retry_failed"`
+}
+
+type ListPendingPdfsRow struct {
+	PdfHash        string `json:"pdf_hash"`
+	Url            string `json:"url"`
+	Dokumentart    string `json:"dokumentart"`
+	Dokumentnummer string `json:"dokumentnummer"`
+}
+
+// PDF archive (sync-pdfs)
+// PDFs of Drucksachen and Plenarprotokolle that are not stored yet, one row per pdf_hash.
+// drucksachetyp only matches Drucksachen; PDFs that failed before are skipped unless retry_failed.
+func (q *Queries) ListPendingPdfs(ctx context.Context, arg ListPendingPdfsParams) ([]ListPendingPdfsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingPdfs,
+		arg.Wahlperiode,
+		arg.Dokumentart,
+		arg.Drucksachetyp,
+		arg.RetryFailed,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPendingPdfsRow
+	for rows.Next() {
+		var i ListPendingPdfsRow
+		if err := rows.Scan(
+			&i.PdfHash,
+			&i.Url,
+			&i.Dokumentart,
+			&i.Dokumentnummer,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordDokumentPdfFehler = `-- name: RecordDokumentPdfFehler :exec
+INSERT INTO dokument_pdf_fehler (pdf_hash, url, fehler)
+VALUES (?, ?, ?)
+ON CONFLICT (pdf_hash) DO UPDATE SET
+    url = excluded.url,
+    fehler = excluded.fehler,
+    versuche = dokument_pdf_fehler.versuche + 1,
+    letzter_versuch_at = datetime('now')
+`
+
+type RecordDokumentPdfFehlerParams struct {
+	PdfHash string `json:"pdf_hash"`
+	Url     string `json:"url"`
+	Fehler  string `json:"fehler"`
+}
+
+func (q *Queries) RecordDokumentPdfFehler(ctx context.Context, arg RecordDokumentPdfFehlerParams) error {
+	_, err := q.db.ExecContext(ctx, recordDokumentPdfFehler, arg.PdfHash, arg.Url, arg.Fehler)
+	return err
+}
+
+const upsertDokumentPdf = `-- name: UpsertDokumentPdf :exec
+INSERT INTO dokument_pdf (pdf_hash, pfad, groesse, url)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (pdf_hash) DO UPDATE SET
+    pfad = excluded.pfad,
+    groesse = excluded.groesse,
+    url = excluded.url,
+    gespeichert_at = datetime('now')
+`
+
+type UpsertDokumentPdfParams struct {
+	PdfHash string `json:"pdf_hash"`
+	Pfad    string `json:"pfad"`
+	Groesse int64  `json:"groesse"`
+	Url     string `json:"url"`
+}
+
+func (q *Queries) UpsertDokumentPdf(ctx context.Context, arg UpsertDokumentPdfParams) error {
+	_, err := q.db.ExecContext(ctx, upsertDokumentPdf,
+		arg.PdfHash,
+		arg.Pfad,
+		arg.Groesse,
+		arg.Url,
+	)
+	return err
+}
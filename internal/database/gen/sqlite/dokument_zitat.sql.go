@@ -0,0 +1,238 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: dokument_zitat.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const countUnresolvedDokumentZitate = `-- name: CountUnresolvedDokumentZitate :one
+SELECT COUNT(*) FROM dokument_zitat WHERE ziel_id IS NULL
+`
+
+func (q *Queries) CountUnresolvedDokumentZitate(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUnresolvedDokumentZitate)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createDokumentZitat = `-- name: CreateDokumentZitat :exec
+INSERT INTO dokument_zitat (
+    quelle_dokumentart, quelle_id, ziel_dokumentart, ziel_herausgeber,
+    ziel_dokumentnummer, anzahl, erster_offset, roh
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateDokumentZitatParams struct {
+	QuelleDokumentart  string `json:"quelle_dokumentart"`
+	QuelleID           string `json:"quelle_id"`
+	ZielDokumentart    string `json:"ziel_dokumentart"`
+	ZielHerausgeber    string `json:"ziel_herausgeber"`
+	ZielDokumentnummer string `json:"ziel_dokumentnummer"`
+	Anzahl             int64  `json:"anzahl"`
+	ErsterOffset       int64  `json:"erster_offset"`
+	Roh                string `json:"roh"`
+}
+
+func (q *Queries) CreateDokumentZitat(ctx context.Context, arg CreateDokumentZitatParams) error {
+	_, err := q.db.ExecContext(ctx, createDokumentZitat,
+		arg.QuelleDokumentart,
+		arg.QuelleID,
+		arg.ZielDokumentart,
+		arg.ZielHerausgeber,
+		arg.ZielDokumentnummer,
+		arg.Anzahl,
+		arg.ErsterOffset,
+		arg.Roh,
+	)
+	return err
+}
+
+const deleteDokumentZitate = `-- name: DeleteDokumentZitate :exec
+DELETE FROM dokument_zitat
+WHERE quelle_dokumentart = ? AND quelle_id = ?
+`
+
+type DeleteDokumentZitateParams struct {
+	QuelleDokumentart string `json:"quelle_dokumentart"`
+	QuelleID          string `json:"quelle_id"`
+}
+
+func (q *Queries) DeleteDokumentZitate(ctx context.Context, arg DeleteDokumentZitateParams) error {
+	_, err := q.db.ExecContext(ctx, deleteDokumentZitate, arg.QuelleDokumentart, arg.QuelleID)
+	return err
+}
+
+const getDrucksacheTextContent = `-- name: GetDrucksacheTextContent :one
+SELECT text FROM drucksache_text WHERE id = ?
+`
+
+func (q *Queries) GetDrucksacheTextContent(ctx context.Context, id string) (sql.NullString, error) {
+	row := q.db.QueryRowContext(ctx, getDrucksacheTextContent, id)
+	var text sql.NullString
+	err := row.Scan(&text)
+	return text, err
+}
+
+const listPendingZitatTexte = `-- name: ListPendingZitatTexte :many
+
+SELECT
+    CAST(t.dokumentart AS TEXT) AS dokumentart,
+    CAST(t.id AS TEXT) AS id,
+    CAST(t.updated_at AS TEXT) AS updated_at,
+    CAST(t.dokumentnummer AS TEXT) AS dokumentnummer,
+    CAST(t.herausgeber AS TEXT) AS herausgeber
+FROM (
+    SELECT 'Drucksache' AS dokumentart, dt.id, dt.updated_at, d.dokumentnummer, d.herausgeber
+    FROM drucksache_text dt
+    JOIN drucksache d ON d.id = dt.id
+    WHERE dt.text IS NOT NULL
+      AND (CAST(?1 AS TEXT) = '' OR ?1 = 'Drucksache')
+    UNION ALL
+    SELECT 'Plenarprotokoll' AS dokumentart, pt.id, pt.updated_at, p.dokumentnummer, p.herausgeber
+    FROM plenarprotokoll_text pt
+    JOIN plenarprotokoll p ON p.id = pt.id
+    WHERE pt.text IS NOT NULL
+      AND (?1 = '' OR ?1 = 'Plenarprotokoll')
+) t
+LEFT JOIN dokument_zitat_verarbeitung v ON v.dokumentart = t.dokumentart AND v.dokument_id = t.id
+WHERE CAST(?2 AS INTEGER) = 1
+   OR v.dokument_id IS NULL
+   OR v.parser_version < CAST(?3 AS INTEGER)
+   OR v.text_updated_at <> t.updated_at
+ORDER BY t.dokumentart, t.id
+`
+
+type ListPendingZitatTexteParams struct {
+	Dokumentart   string `json:"dokumentart"`
+	AllTexte      int64  `json:"all_texte"`
+	ParserVersion int64  `json:"parser_version"`
+}
+
+type ListPendingZitatTexteRow struct {
+	Dokumentart    string `json:"dokumentart"`
+	ID             string `json:"id"`
+	UpdatedAt      string `json:"updated_at"`
+	Dokumentnummer string `json:"dokumentnummer"`
+	Herausgeber    string `json:"herausgeber"`
+}
+
+// Citation graph (process-dokument-zitate)
+// Drucksache and Plenarprotokoll texts that were not processed yet, changed since,
+// or were processed with an older parser (all_texte: every text)
+func (q *Queries) ListPendingZitatTexte(ctx context.Context, arg ListPendingZitatTexteParams) ([]ListPendingZitatTexteRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingZitatTexte, arg.Dokumentart, arg.AllTexte, arg.ParserVersion)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPendingZitatTexteRow
+	for rows.Next() {
+		var i ListPendingZitatTexteRow
+		if err := rows.Scan(
+			&i.Dokumentart,
+			&i.ID,
+			&i.UpdatedAt,
+			&i.Dokumentnummer,
+			&i.Herausgeber,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const resolveDrucksacheZitate = `-- name: ResolveDrucksacheZitate :execrows
+UPDATE dokument_zitat
+SET ziel_id = (
+    SELECT MIN(d.id) FROM drucksache d
+    WHERE d.herausgeber = dokument_zitat.ziel_herausgeber
+      AND d.dokumentnummer = dokument_zitat.ziel_dokumentnummer
+      AND d.deleted_at IS NULL
+)
+WHERE ziel_id IS NULL
+  AND ziel_dokumentart = 'Drucksache'
+  AND EXISTS (
+    SELECT 1 FROM drucksache d
+    WHERE d.herausgeber = dokument_zitat.ziel_herausgeber
+      AND d.dokumentnummer = dokument_zitat.ziel_dokumentnummer
+      AND d.deleted_at IS NULL
+  )
+`
+
+// Resolves cited Drucksachen that were not synced when the citing text was processed
+func (q *Queries) ResolveDrucksacheZitate(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, resolveDrucksacheZitate)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const resolvePlenarprotokollZitate = `-- name: ResolvePlenarprotokollZitate :execrows
+UPDATE dokument_zitat
+SET ziel_id = (
+    SELECT MIN(p.id) FROM plenarprotokoll p
+    WHERE p.herausgeber = dokument_zitat.ziel_herausgeber
+      AND p.dokumentnummer = dokument_zitat.ziel_dokumentnummer
+      AND p.deleted_at IS NULL
+)
+WHERE ziel_id IS NULL
+  AND ziel_dokumentart = 'Plenarprotokoll'
+  AND EXISTS (
+    SELECT 1 FROM plenarprotokoll p
+    WHERE p.herausgeber = dokument_zitat.ziel_herausgeber
+      AND p.dokumentnummer = dokument_zitat.ziel_dokumentnummer
+      AND p.deleted_at IS NULL
+  )
+`
+
+// Resolves cited Plenarprotokolle that were not synced when the citing text was processed
+func (q *Queries) ResolvePlenarprotokollZitate(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, resolvePlenarprotokollZitate)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertDokumentZitatVerarbeitung = `-- name: UpsertDokumentZitatVerarbeitung :exec
+INSERT INTO dokument_zitat_verarbeitung (dokumentart, dokument_id, parser_version, text_updated_at, zitate_anzahl)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (dokumentart, dokument_id) DO UPDATE SET
+    parser_version = excluded.parser_version,
+    text_updated_at = excluded.text_updated_at,
+    zitate_anzahl = excluded.zitate_anzahl,
+    processed_at = datetime('now')
+`
+
+type UpsertDokumentZitatVerarbeitungParams struct {
+	Dokumentart   string `json:"dokumentart"`
+	DokumentID    string `json:"dokument_id"`
+	ParserVersion int64  `json:"parser_version"`
+	TextUpdatedAt string `json:"text_updated_at"`
+	ZitateAnzahl  int64  `json:"zitate_anzahl"`
+}
+
+func (q *Queries) UpsertDokumentZitatVerarbeitung(ctx context.Context, arg UpsertDokumentZitatVerarbeitungParams) error {
+	_, err := q.db.ExecContext(ctx, upsertDokumentZitatVerarbeitung,
+		arg.Dokumentart,
+		arg.DokumentID,
+		arg.ParserVersion,
+		arg.TextUpdatedAt,
+		arg.ZitateAnzahl,
+	)
+	return err
+}
@@ -76,7 +76,7 @@ INSERT INTO drucksache (
     ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
     ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
     ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
-) RETURNING id, titel, dokumentnummer, dokumentart, typ, drucksachetyp, herausgeber, datum, aktualisiert, anlagen, autoren_anzahl, vorgangsbezug_anzahl, pdf_hash, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url
+) RETURNING id, titel, dokumentnummer, dokumentart, typ, drucksachetyp, herausgeber, datum, aktualisiert, anlagen, autoren_anzahl, vorgangsbezug_anzahl, pdf_hash, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url, deleted_at
 `
 
 type CreateDrucksacheParams struct {
@@ -185,6 +185,7 @@ func (q *Queries) CreateDrucksache(ctx context.Context, arg CreateDrucksachePara
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -307,8 +308,53 @@ func (q *Queries) DeleteDrucksache(ctx context.Context, id string) error {
 	return err
 }
 
+const deleteDrucksacheAutorAnzeigen = `-- name: DeleteDrucksacheAutorAnzeigen :exec
+DELETE FROM drucksache_autor_anzeige WHERE drucksache_id = ?
+`
+
+func (q *Queries) DeleteDrucksacheAutorAnzeigen(ctx context.Context, drucksacheID string) error {
+	_, err := q.db.ExecContext(ctx, deleteDrucksacheAutorAnzeigen, drucksacheID)
+	return err
+}
+
+const deleteDrucksacheFundstelleUrheber = `-- name: DeleteDrucksacheFundstelleUrheber :exec
+DELETE FROM fundstelle_urheber WHERE drucksache_id = ?
+`
+
+func (q *Queries) DeleteDrucksacheFundstelleUrheber(ctx context.Context, drucksacheID sql.NullString) error {
+	_, err := q.db.ExecContext(ctx, deleteDrucksacheFundstelleUrheber, drucksacheID)
+	return err
+}
+
+const deleteDrucksacheRessorts = `-- name: DeleteDrucksacheRessorts :exec
+DELETE FROM drucksache_ressort WHERE drucksache_id = ?
+`
+
+func (q *Queries) DeleteDrucksacheRessorts(ctx context.Context, drucksacheID string) error {
+	_, err := q.db.ExecContext(ctx, deleteDrucksacheRessorts, drucksacheID)
+	return err
+}
+
+const deleteDrucksacheUrheber = `-- name: DeleteDrucksacheUrheber :exec
+DELETE FROM drucksache_urheber WHERE drucksache_id = ?
+`
+
+func (q *Queries) DeleteDrucksacheUrheber(ctx context.Context, drucksacheID string) error {
+	_, err := q.db.ExecContext(ctx, deleteDrucksacheUrheber, drucksacheID)
+	return err
+}
+
+const deleteDrucksacheVorgangsbezuege = `-- name: DeleteDrucksacheVorgangsbezuege :exec
+DELETE FROM drucksache_vorgangsbezug WHERE drucksache_id = ?
+`
+
+func (q *Queries) DeleteDrucksacheVorgangsbezuege(ctx context.Context, drucksacheID string) error {
+	_, err := q.db.ExecContext(ctx, deleteDrucksacheVorgangsbezuege, drucksacheID)
+	return err
+}
+
 const getDrucksache = `-- name: GetDrucksache :one
-SELECT id, titel, dokumentnummer, dokumentart, typ, drucksachetyp, herausgeber, datum, aktualisiert, anlagen, autoren_anzahl, vorgangsbezug_anzahl, pdf_hash, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url
+SELECT id, titel, dokumentnummer, dokumentart, typ, drucksachetyp, herausgeber, datum, aktualisiert, anlagen, autoren_anzahl, vorgangsbezug_anzahl, pdf_hash, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url, deleted_at
 FROM drucksache
 WHERE id = ?
 `
@@ -351,13 +397,14 @@ func (q *Queries) GetDrucksache(ctx context.Context, id string) (Drucksache, err
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getDrucksacheWithRelations = `-- name: GetDrucksacheWithRelations :many
 SELECT 
-    d.id, d.titel, d.dokumentnummer, d.dokumentart, d.typ, d.drucksachetyp, d.herausgeber, d.datum, d.aktualisiert, d.anlagen, d.autoren_anzahl, d.vorgangsbezug_anzahl, d.pdf_hash, d.wahlperiode, d.fundstelle_dokumentnummer, d.fundstelle_datum, d.fundstelle_dokumentart, d.fundstelle_herausgeber, d.fundstelle_id, d.fundstelle_drucksachetyp, d.fundstelle_anlagen, d.fundstelle_anfangsseite, d.fundstelle_endseite, d.fundstelle_anfangsquadrant, d.fundstelle_endquadrant, d.fundstelle_seite, d.fundstelle_pdf_url, d.fundstelle_top, d.fundstelle_top_zusatz, d.fundstelle_frage_nummer, d.fundstelle_verteildatum, d.created_at, d.updated_at, d.fundstelle_xml_url,
+    d.id, d.titel, d.dokumentnummer, d.dokumentart, d.typ, d.drucksachetyp, d.herausgeber, d.datum, d.aktualisiert, d.anlagen, d.autoren_anzahl, d.vorgangsbezug_anzahl, d.pdf_hash, d.wahlperiode, d.fundstelle_dokumentnummer, d.fundstelle_datum, d.fundstelle_dokumentart, d.fundstelle_herausgeber, d.fundstelle_id, d.fundstelle_drucksachetyp, d.fundstelle_anlagen, d.fundstelle_anfangsseite, d.fundstelle_endseite, d.fundstelle_anfangsquadrant, d.fundstelle_endquadrant, d.fundstelle_seite, d.fundstelle_pdf_url, d.fundstelle_top, d.fundstelle_top_zusatz, d.fundstelle_frage_nummer, d.fundstelle_verteildatum, d.created_at, d.updated_at, d.fundstelle_xml_url, d.deleted_at,
     daa.person_id as autor_person_id,
     daa.autor_titel,
     daa.title as autor_title,
@@ -412,6 +459,7 @@ type GetDrucksacheWithRelationsRow struct {
 	CreatedAt                 string         `json:"created_at"`
 	UpdatedAt                 string         `json:"updated_at"`
 	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullString `json:"deleted_at"`
 	AutorPersonID             sql.NullString `json:"autor_person_id"`
 	AutorTitel                sql.NullString `json:"autor_titel"`
 	AutorTitle                sql.NullString `json:"autor_title"`
@@ -468,6 +516,7 @@ func (q *Queries) GetDrucksacheWithRelations(ctx context.Context, id string) ([]
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 			&i.AutorPersonID,
 			&i.AutorTitel,
 			&i.AutorTitle,
@@ -504,7 +553,7 @@ func (q *Queries) GetLatestDrucksacheDatum(ctx context.Context) (interface{}, er
 }
 
 const listDrucksachen = `-- name: ListDrucksachen :many
-SELECT id, titel, dokumentnummer, dokumentart, typ, drucksachetyp, herausgeber, datum, aktualisiert, anlagen, autoren_anzahl, vorgangsbezug_anzahl, pdf_hash, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url
+SELECT id, titel, dokumentnummer, dokumentart, typ, drucksachetyp, herausgeber, datum, aktualisiert, anlagen, autoren_anzahl, vorgangsbezug_anzahl, pdf_hash, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url, deleted_at
 FROM drucksache
 WHERE 
     (? IS NULL OR aktualisiert >= ?)
@@ -598,6 +647,7 @@ func (q *Queries) ListDrucksachen(ctx context.Context, arg ListDrucksachenParams
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -621,9 +671,10 @@ SET
     autoren_anzahl = ?,
     vorgangsbezug_anzahl = ?,
     pdf_hash = ?,
+    deleted_at = NULL,
     updated_at = datetime('now')
 WHERE id = ?
-RETURNING id, titel, dokumentnummer, dokumentart, typ, drucksachetyp, herausgeber, datum, aktualisiert, anlagen, autoren_anzahl, vorgangsbezug_anzahl, pdf_hash, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url
+RETURNING id, titel, dokumentnummer, dokumentart, typ, drucksachetyp, herausgeber, datum, aktualisiert, anlagen, autoren_anzahl, vorgangsbezug_anzahl, pdf_hash, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url, deleted_at
 `
 
 type UpdateDrucksacheParams struct {
@@ -682,6 +733,132 @@ func (q *Queries) UpdateDrucksache(ctx context.Context, arg UpdateDrucksachePara
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
+
+const upsertDrucksache = `-- name: UpsertDrucksache :exec
+INSERT INTO drucksache (
+    id, titel, dokumentnummer, dokumentart, typ, drucksachetyp, herausgeber,
+    datum, aktualisiert, anlagen, autoren_anzahl, vorgangsbezug_anzahl,
+    pdf_hash, wahlperiode,
+    fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+    fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
+    fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
+    fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_frage_nummer, fundstelle_verteildatum
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+) ON CONFLICT (id) DO UPDATE
+SET
+    titel = excluded.titel,
+    dokumentnummer = excluded.dokumentnummer,
+    dokumentart = excluded.dokumentart,
+    typ = excluded.typ,
+    drucksachetyp = excluded.drucksachetyp,
+    herausgeber = excluded.herausgeber,
+    datum = excluded.datum,
+    aktualisiert = excluded.aktualisiert,
+    anlagen = excluded.anlagen,
+    autoren_anzahl = excluded.autoren_anzahl,
+    vorgangsbezug_anzahl = excluded.vorgangsbezug_anzahl,
+    pdf_hash = excluded.pdf_hash,
+    wahlperiode = excluded.wahlperiode,
+    fundstelle_dokumentnummer = excluded.fundstelle_dokumentnummer,
+    fundstelle_datum = excluded.fundstelle_datum,
+    fundstelle_dokumentart = excluded.fundstelle_dokumentart,
+    fundstelle_herausgeber = excluded.fundstelle_herausgeber,
+    fundstelle_id = excluded.fundstelle_id,
+    fundstelle_drucksachetyp = excluded.fundstelle_drucksachetyp,
+    fundstelle_anlagen = excluded.fundstelle_anlagen,
+    fundstelle_anfangsseite = excluded.fundstelle_anfangsseite,
+    fundstelle_endseite = excluded.fundstelle_endseite,
+    fundstelle_anfangsquadrant = excluded.fundstelle_anfangsquadrant,
+    fundstelle_endquadrant = excluded.fundstelle_endquadrant,
+    fundstelle_seite = excluded.fundstelle_seite,
+    fundstelle_pdf_url = excluded.fundstelle_pdf_url,
+    fundstelle_xml_url = excluded.fundstelle_xml_url,
+    fundstelle_top = excluded.fundstelle_top,
+    fundstelle_top_zusatz = excluded.fundstelle_top_zusatz,
+    fundstelle_frage_nummer = excluded.fundstelle_frage_nummer,
+    fundstelle_verteildatum = excluded.fundstelle_verteildatum,
+    deleted_at = NULL,
+    updated_at = datetime('now')
+`
+
+type UpsertDrucksacheParams struct {
+	ID                        string         `json:"id"`
+	Titel                     string         `json:"titel"`
+	Dokumentnummer            string         `json:"dokumentnummer"`
+	Dokumentart               string         `json:"dokumentart"`
+	Typ                       string         `json:"typ"`
+	Drucksachetyp             string         `json:"drucksachetyp"`
+	Herausgeber               string         `json:"herausgeber"`
+	Datum                     string         `json:"datum"`
+	Aktualisiert              string         `json:"aktualisiert"`
+	Anlagen                   sql.NullString `json:"anlagen"`
+	AutorenAnzahl             int64          `json:"autoren_anzahl"`
+	VorgangsbezugAnzahl       int64          `json:"vorgangsbezug_anzahl"`
+	PdfHash                   sql.NullString `json:"pdf_hash"`
+	Wahlperiode               sql.NullInt64  `json:"wahlperiode"`
+	FundstelleDokumentnummer  string         `json:"fundstelle_dokumentnummer"`
+	FundstelleDatum           string         `json:"fundstelle_datum"`
+	FundstelleDokumentart     string         `json:"fundstelle_dokumentart"`
+	FundstelleHerausgeber     string         `json:"fundstelle_herausgeber"`
+	FundstelleID              string         `json:"fundstelle_id"`
+	FundstelleDrucksachetyp   sql.NullString `json:"fundstelle_drucksachetyp"`
+	FundstelleAnlagen         sql.NullString `json:"fundstelle_anlagen"`
+	FundstelleAnfangsseite    sql.NullInt64  `json:"fundstelle_anfangsseite"`
+	FundstelleEndseite        sql.NullInt64  `json:"fundstelle_endseite"`
+	FundstelleAnfangsquadrant sql.NullString `json:"fundstelle_anfangsquadrant"`
+	FundstelleEndquadrant     sql.NullString `json:"fundstelle_endquadrant"`
+	FundstelleSeite           sql.NullString `json:"fundstelle_seite"`
+	FundstellePdfUrl          sql.NullString `json:"fundstelle_pdf_url"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	FundstelleTop             sql.NullInt64  `json:"fundstelle_top"`
+	FundstelleTopZusatz       sql.NullString `json:"fundstelle_top_zusatz"`
+	FundstelleFrageNummer     sql.NullString `json:"fundstelle_frage_nummer"`
+	FundstelleVerteildatum    sql.NullString `json:"fundstelle_verteildatum"`
+}
+
+func (q *Queries) UpsertDrucksache(ctx context.Context, arg UpsertDrucksacheParams) error {
+	_, err := q.db.ExecContext(ctx, upsertDrucksache,
+		arg.ID,
+		arg.Titel,
+		arg.Dokumentnummer,
+		arg.Dokumentart,
+		arg.Typ,
+		arg.Drucksachetyp,
+		arg.Herausgeber,
+		arg.Datum,
+		arg.Aktualisiert,
+		arg.Anlagen,
+		arg.AutorenAnzahl,
+		arg.VorgangsbezugAnzahl,
+		arg.PdfHash,
+		arg.Wahlperiode,
+		arg.FundstelleDokumentnummer,
+		arg.FundstelleDatum,
+		arg.FundstelleDokumentart,
+		arg.FundstelleHerausgeber,
+		arg.FundstelleID,
+		arg.FundstelleDrucksachetyp,
+		arg.FundstelleAnlagen,
+		arg.FundstelleAnfangsseite,
+		arg.FundstelleEndseite,
+		arg.FundstelleAnfangsquadrant,
+		arg.FundstelleEndquadrant,
+		arg.FundstelleSeite,
+		arg.FundstellePdfUrl,
+		arg.FundstelleXmlUrl,
+		arg.FundstelleTop,
+		arg.FundstelleTopZusatz,
+		arg.FundstelleFrageNummer,
+		arg.FundstelleVerteildatum,
+	)
+	return err
+}
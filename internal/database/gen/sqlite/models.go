@@ -39,6 +39,7 @@ type Aktivitaet struct {
 	CreatedAt                 string         `json:"created_at"`
 	UpdatedAt                 string         `json:"updated_at"`
 	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullString `json:"deleted_at"`
 }
 
 type AktivitaetAnzeige struct {
@@ -60,6 +61,34 @@ type AktivitaetDeskriptor struct {
 	CreatedAt    string `json:"created_at"`
 }
 
+type AktivitaetHistory struct {
+	ID           int64  `json:"id"`
+	AktivitaetID string `json:"aktivitaet_id"`
+	Aktualisiert string `json:"aktualisiert"`
+	SyncRunID    string `json:"sync_run_id"`
+	Snapshot     string `json:"snapshot"`
+	Diff         string `json:"diff"`
+	RecordedAt   string `json:"recorded_at"`
+}
+
+type AktivitaetOverview struct {
+	ID                       string         `json:"id"`
+	Titel                    string         `json:"titel"`
+	Aktivitaetsart           string         `json:"aktivitaetsart"`
+	Dokumentart              string         `json:"dokumentart"`
+	Datum                    string         `json:"datum"`
+	Aktualisiert             string         `json:"aktualisiert"`
+	Abstract                 sql.NullString `json:"abstract"`
+	Wahlperiode              int64          `json:"wahlperiode"`
+	FundstelleDokumentnummer string         `json:"fundstelle_dokumentnummer"`
+	FundstelleDatum          string         `json:"fundstelle_datum"`
+	FundstellePdfUrl         sql.NullString `json:"fundstelle_pdf_url"`
+	Deskriptoren             string         `json:"deskriptoren"`
+	Vorgaenge                string         `json:"vorgaenge"`
+	CreatedAt                string         `json:"created_at"`
+	UpdatedAt                string         `json:"updated_at"`
+}
+
 type AktivitaetVorgangsbezug struct {
 	AktivitaetID     string `json:"aktivitaet_id"`
 	VorgangID        string `json:"vorgang_id"`
@@ -87,6 +116,44 @@ type Bundesland struct {
 	CreatedAt string `json:"created_at"`
 }
 
+type DokumentPdf struct {
+	PdfHash       string `json:"pdf_hash"`
+	Pfad          string `json:"pfad"`
+	Groesse       int64  `json:"groesse"`
+	Url           string `json:"url"`
+	GespeichertAt string `json:"gespeichert_at"`
+}
+
+type DokumentPdfFehler struct {
+	PdfHash          string `json:"pdf_hash"`
+	Url              string `json:"url"`
+	Fehler           string `json:"fehler"`
+	Versuche         int64  `json:"versuche"`
+	LetzterVersuchAt string `json:"letzter_versuch_at"`
+}
+
+type DokumentZitat struct {
+	ID                 int64          `json:"id"`
+	QuelleDokumentart  string         `json:"quelle_dokumentart"`
+	QuelleID           string         `json:"quelle_id"`
+	ZielDokumentart    string         `json:"ziel_dokumentart"`
+	ZielHerausgeber    string         `json:"ziel_herausgeber"`
+	ZielDokumentnummer string         `json:"ziel_dokumentnummer"`
+	ZielID             sql.NullString `json:"ziel_id"`
+	Anzahl             int64          `json:"anzahl"`
+	ErsterOffset       int64          `json:"erster_offset"`
+	Roh                string         `json:"roh"`
+}
+
+type DokumentZitatVerarbeitung struct {
+	Dokumentart   string `json:"dokumentart"`
+	DokumentID    string `json:"dokument_id"`
+	ParserVersion int64  `json:"parser_version"`
+	TextUpdatedAt string `json:"text_updated_at"`
+	ZitateAnzahl  int64  `json:"zitate_anzahl"`
+	ProcessedAt   string `json:"processed_at"`
+}
+
 type Drucksache struct {
 	ID                        string         `json:"id"`
 	Titel                     string         `json:"titel"`
@@ -122,6 +189,7 @@ type Drucksache struct {
 	CreatedAt                 string         `json:"created_at"`
 	UpdatedAt                 string         `json:"updated_at"`
 	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullString `json:"deleted_at"`
 }
 
 type DrucksacheAutorAnzeige struct {
@@ -134,6 +202,36 @@ type DrucksacheAutorAnzeige struct {
 	CreatedAt    string `json:"created_at"`
 }
 
+type DrucksacheHistory struct {
+	ID           int64  `json:"id"`
+	DrucksacheID string `json:"drucksache_id"`
+	Aktualisiert string `json:"aktualisiert"`
+	SyncRunID    string `json:"sync_run_id"`
+	Snapshot     string `json:"snapshot"`
+	Diff         string `json:"diff"`
+	RecordedAt   string `json:"recorded_at"`
+}
+
+type DrucksacheOverview struct {
+	ID               string         `json:"id"`
+	Titel            string         `json:"titel"`
+	Dokumentnummer   string         `json:"dokumentnummer"`
+	Dokumentart      string         `json:"dokumentart"`
+	Drucksachetyp    string         `json:"drucksachetyp"`
+	Herausgeber      string         `json:"herausgeber"`
+	Datum            string         `json:"datum"`
+	Aktualisiert     string         `json:"aktualisiert"`
+	Wahlperiode      sql.NullInt64  `json:"wahlperiode"`
+	FundstellePdfUrl sql.NullString `json:"fundstelle_pdf_url"`
+	Volltext         sql.NullString `json:"volltext"`
+	Autoren          string         `json:"autoren"`
+	Ressorts         string         `json:"ressorts"`
+	Urheber          string         `json:"urheber"`
+	Vorgaenge        string         `json:"vorgaenge"`
+	CreatedAt        string         `json:"created_at"`
+	UpdatedAt        string         `json:"updated_at"`
+}
+
 type DrucksacheRessort struct {
 	DrucksacheID  string `json:"drucksache_id"`
 	RessortID     int64  `json:"ressort_id"`
@@ -147,6 +245,10 @@ type DrucksacheText struct {
 	UpdatedAt string         `json:"updated_at"`
 }
 
+type DrucksacheTextFt struct {
+	Text string `json:"text"`
+}
+
 type DrucksacheUrheber struct {
 	DrucksacheID string         `json:"drucksache_id"`
 	UrheberID    int64          `json:"urheber_id"`
@@ -162,6 +264,16 @@ type DrucksacheVorgangsbezug struct {
 	DisplayOrder int64  `json:"display_order"`
 }
 
+type DrucksacheZitat struct {
+	DrucksacheID             string         `json:"drucksache_id"`
+	DrucksacheDokumentnummer string         `json:"drucksache_dokumentnummer"`
+	Wahlperiode              sql.NullInt64  `json:"wahlperiode"`
+	ZitiertID                sql.NullString `json:"zitiert_id"`
+	ZitiertDokumentnummer    string         `json:"zitiert_dokumentnummer"`
+	Anzahl                   int64          `json:"anzahl"`
+	GemeinsamerVorgang       int64          `json:"gemeinsamer_vorgang"`
+}
+
 type FundstelleUrheber struct {
 	ID                int64          `json:"id"`
 	DrucksacheID      sql.NullString `json:"drucksache_id"`
@@ -170,6 +282,43 @@ type FundstelleUrheber struct {
 	CreatedAt         string         `json:"created_at"`
 }
 
+type GesetzTimeline struct {
+	EntityType       string         `json:"entity_type"`
+	EntityID         string         `json:"entity_id"`
+	EventDate        sql.NullString `json:"event_date"`
+	EventTitle       string         `json:"event_title"`
+	EventDescription string         `json:"event_description"`
+	Status           sql.NullString `json:"status"`
+	PdfUrl           interface{}    `json:"pdf_url"`
+	VorgangID        string         `json:"vorgang_id"`
+}
+
+type GesetzTrace struct {
+	VorgangID                 string         `json:"vorgang_id"`
+	GesetzTitel               string         `json:"gesetz_titel"`
+	Vorgangstyp               string         `json:"vorgangstyp"`
+	Beratungsstand            sql.NullString `json:"beratungsstand"`
+	VorgangDatum              sql.NullString `json:"vorgang_datum"`
+	VorgangAktualisiert       string         `json:"vorgang_aktualisiert"`
+	Wahlperiode               int64          `json:"wahlperiode"`
+	Gesta                     sql.NullString `json:"gesta"`
+	Sachgebiete               string         `json:"sachgebiete"`
+	Initiativen               string         `json:"initiativen"`
+	Deskriptoren              string         `json:"deskriptoren"`
+	Ausfertigungsdatum        sql.NullString `json:"ausfertigungsdatum"`
+	Verkuendungsdatum         sql.NullString `json:"verkuendungsdatum"`
+	VerkuendungFundstelle     sql.NullString `json:"verkuendung_fundstelle"`
+	VerkuendungPdfUrl         sql.NullString `json:"verkuendung_pdf_url"`
+	InkrafttretenDatum        sql.NullString `json:"inkrafttreten_datum"`
+	InkrafttretenErlaeuterung sql.NullString `json:"inkrafttreten_erlaeuterung"`
+	AnzahlVorgangspositionen  int64          `json:"anzahl_vorgangspositionen"`
+	AnzahlAktivitaeten        int64          `json:"anzahl_aktivitaeten"`
+	AnzahlDrucksachen         int64          `json:"anzahl_drucksachen"`
+	AnzahlPlenarprotokolle    int64          `json:"anzahl_plenarprotokolle"`
+	CreatedAt                 string         `json:"created_at"`
+	UpdatedAt                 string         `json:"updated_at"`
+}
+
 type Inkrafttreten struct {
 	ID           int64          `json:"id"`
 	VorgangID    string         `json:"vorgang_id"`
@@ -320,6 +469,17 @@ type Person struct {
 	Datum        sql.NullString `json:"datum"`
 	CreatedAt    string         `json:"created_at"`
 	UpdatedAt    string         `json:"updated_at"`
+	DeletedAt    sql.NullString `json:"deleted_at"`
+}
+
+type PersonHistory struct {
+	ID           int64  `json:"id"`
+	PersonID     string `json:"person_id"`
+	Aktualisiert string `json:"aktualisiert"`
+	SyncRunID    string `json:"sync_run_id"`
+	Snapshot     string `json:"snapshot"`
+	Diff         string `json:"diff"`
+	RecordedAt   string `json:"recorded_at"`
 }
 
 type PersonMdbLink struct {
@@ -431,6 +591,34 @@ type Plenarprotokoll struct {
 	CreatedAt                 string         `json:"created_at"`
 	UpdatedAt                 string         `json:"updated_at"`
 	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullString `json:"deleted_at"`
+}
+
+type PlenarprotokollHistory struct {
+	ID                int64  `json:"id"`
+	PlenarprotokollID string `json:"plenarprotokoll_id"`
+	Aktualisiert      string `json:"aktualisiert"`
+	SyncRunID         string `json:"sync_run_id"`
+	Snapshot          string `json:"snapshot"`
+	Diff              string `json:"diff"`
+	RecordedAt        string `json:"recorded_at"`
+}
+
+type PlenarprotokollOverview struct {
+	ID                string         `json:"id"`
+	Titel             string         `json:"titel"`
+	Dokumentnummer    string         `json:"dokumentnummer"`
+	Dokumentart       string         `json:"dokumentart"`
+	Herausgeber       string         `json:"herausgeber"`
+	Datum             string         `json:"datum"`
+	Aktualisiert      string         `json:"aktualisiert"`
+	Sitzungsbemerkung sql.NullString `json:"sitzungsbemerkung"`
+	Wahlperiode       sql.NullInt64  `json:"wahlperiode"`
+	FundstellePdfUrl  sql.NullString `json:"fundstelle_pdf_url"`
+	Volltext          sql.NullString `json:"volltext"`
+	Vorgaenge         string         `json:"vorgaenge"`
+	CreatedAt         string         `json:"created_at"`
+	UpdatedAt         string         `json:"updated_at"`
 }
 
 type PlenarprotokollText struct {
@@ -440,6 +628,10 @@ type PlenarprotokollText struct {
 	UpdatedAt string         `json:"updated_at"`
 }
 
+type PlenarprotokollTextFt struct {
+	Text string `json:"text"`
+}
+
 type PlenarprotokollVorgangsbezug struct {
 	PlenarprotokollID string `json:"plenarprotokoll_id"`
 	VorgangID         string `json:"vorgang_id"`
@@ -448,12 +640,145 @@ type PlenarprotokollVorgangsbezug struct {
 	DisplayOrder      int64  `json:"display_order"`
 }
 
+type PlenarprotokollXml struct {
+	ID                int64          `json:"id"`
+	Dokumentnummer    string         `json:"dokumentnummer"`
+	PlenarprotokollID sql.NullString `json:"plenarprotokoll_id"`
+	Wahlperiode       int64          `json:"wahlperiode"`
+	SitzungNr         int64          `json:"sitzung_nr"`
+	Datum             sql.NullString `json:"datum"`
+	StartUhrzeit      sql.NullString `json:"start_uhrzeit"`
+	EndeUhrzeit       sql.NullString `json:"ende_uhrzeit"`
+	Ort               sql.NullString `json:"ort"`
+	Version           sql.NullString `json:"version"`
+	Quelle            string         `json:"quelle"`
+	Sha256            string         `json:"sha256"`
+	DtdValidiert      int64          `json:"dtd_validiert"`
+	LoadedAt          string         `json:"loaded_at"`
+}
+
+type PlenarprotokollXmlAbsatz struct {
+	ID       int64          `json:"id"`
+	RedeID   int64          `json:"rede_id"`
+	Position int64          `json:"position"`
+	Typ      string         `json:"typ"`
+	Klasse   sql.NullString `json:"klasse"`
+	RednerID sql.NullString `json:"redner_id"`
+	Sprecher sql.NullString `json:"sprecher"`
+	Text     string         `json:"text"`
+}
+
+type PlenarprotokollXmlRede struct {
+	ID                   int64          `json:"id"`
+	ProtokollXmlID       int64          `json:"protokoll_xml_id"`
+	TagesordnungspunktID int64          `json:"tagesordnungspunkt_id"`
+	Position             int64          `json:"position"`
+	RedeID               sql.NullString `json:"rede_id"`
+	RednerID             sql.NullString `json:"redner_id"`
+}
+
+type PlenarprotokollXmlRedner struct {
+	ProtokollXmlID int64          `json:"protokoll_xml_id"`
+	RednerID       string         `json:"redner_id"`
+	Titel          sql.NullString `json:"titel"`
+	Vorname        sql.NullString `json:"vorname"`
+	Namenszusatz   sql.NullString `json:"namenszusatz"`
+	Nachname       sql.NullString `json:"nachname"`
+	Ortszusatz     sql.NullString `json:"ortszusatz"`
+	Fraktion       sql.NullString `json:"fraktion"`
+	RolleLang      sql.NullString `json:"rolle_lang"`
+	RolleKurz      sql.NullString `json:"rolle_kurz"`
+	Bundesland     sql.NullString `json:"bundesland"`
+}
+
+type PlenarprotokollXmlTagesordnungspunkt struct {
+	ID             int64          `json:"id"`
+	ProtokollXmlID int64          `json:"protokoll_xml_id"`
+	Position       int64          `json:"position"`
+	TopID          sql.NullString `json:"top_id"`
+	Titel          sql.NullString `json:"titel"`
+}
+
+type Rede struct {
+	ID                 int64           `json:"id"`
+	PlenarprotokollID  string          `json:"plenarprotokoll_id"`
+	Position           int64           `json:"position"`
+	Sprecher           string          `json:"sprecher"`
+	Rolle              sql.NullString  `json:"rolle"`
+	Fraktion           sql.NullString  `json:"fraktion"`
+	Tagesordnungspunkt sql.NullString  `json:"tagesordnungspunkt"`
+	StartOffset        int64           `json:"start_offset"`
+	EndOffset          int64           `json:"end_offset"`
+	Text               string          `json:"text"`
+	CreatedAt          string          `json:"created_at"`
+	PersonID           sql.NullString  `json:"person_id"`
+	MdbID              sql.NullString  `json:"mdb_id"`
+	SprecherConfidence sql.NullFloat64 `json:"sprecher_confidence"`
+	SprecherMethode    sql.NullString  `json:"sprecher_methode"`
+}
+
+type RedeReaktion struct {
+	ID                int64          `json:"id"`
+	RedeID            int64          `json:"rede_id"`
+	PlenarprotokollID string         `json:"plenarprotokoll_id"`
+	Position          int64          `json:"position"`
+	Kommentar         int64          `json:"kommentar"`
+	Art               string         `json:"art"`
+	Fraktion          sql.NullString `json:"fraktion"`
+	Abgeordnete       int64          `json:"abgeordnete"`
+	Sprecher          sql.NullString `json:"sprecher"`
+	PersonID          sql.NullString `json:"person_id"`
+	MdbID             sql.NullString `json:"mdb_id"`
+	Text              sql.NullString `json:"text"`
+	KommentarOffset   int64          `json:"kommentar_offset"`
+	Roh               string         `json:"roh"`
+}
+
+type RedeReaktionFraktion struct {
+	Wahlperiode         sql.NullInt64  `json:"wahlperiode"`
+	RednerFraktion      sql.NullString `json:"redner_fraktion"`
+	ReagierendeFraktion sql.NullString `json:"reagierende_fraktion"`
+	Art                 string         `json:"art"`
+	Anzahl              int64          `json:"anzahl"`
+	Reden               int64          `json:"reden"`
+}
+
+type RedeSprecherReview struct {
+	ID              int64           `json:"id"`
+	Sprecher        string          `json:"sprecher"`
+	Fraktion        string          `json:"fraktion"`
+	Rolle           sql.NullString  `json:"rolle"`
+	Kandidaten      string          `json:"kandidaten"`
+	BesteConfidence sql.NullFloat64 `json:"beste_confidence"`
+	BeispielRedeID  sql.NullInt64   `json:"beispiel_rede_id"`
+	PersonID        sql.NullString  `json:"person_id"`
+	MdbID           sql.NullString  `json:"mdb_id"`
+	ReviewedBy      sql.NullString  `json:"reviewed_by"`
+	ReviewedAt      sql.NullString  `json:"reviewed_at"`
+	CreatedAt       string          `json:"created_at"`
+	UpdatedAt       string          `json:"updated_at"`
+}
+
+type RedeVerarbeitung struct {
+	PlenarprotokollID string `json:"plenarprotokoll_id"`
+	ParserVersion     int64  `json:"parser_version"`
+	TextUpdatedAt     string `json:"text_updated_at"`
+	RedenAnzahl       int64  `json:"reden_anzahl"`
+	ProcessedAt       string `json:"processed_at"`
+}
+
 type Ressort struct {
 	ID        int64  `json:"id"`
 	Titel     string `json:"titel"`
 	CreatedAt string `json:"created_at"`
 }
 
+type SyncRun struct {
+	ID           string `json:"id"`
+	ResourceName string `json:"resource_name"`
+	StartedAt    string `json:"started_at"`
+}
+
 type Ueberweisung struct {
 	ID                 int64          `json:"id"`
 	VorgangspositionID string         `json:"vorgangsposition_id"`
@@ -506,6 +831,7 @@ type Vorgang struct {
 	Wahlperiode    int64          `json:"wahlperiode"`
 	CreatedAt      string         `json:"created_at"`
 	UpdatedAt      string         `json:"updated_at"`
+	DeletedAt      sql.NullString `json:"deleted_at"`
 }
 
 type VorgangDeskriptor struct {
@@ -517,6 +843,16 @@ type VorgangDeskriptor struct {
 	CreatedAt  string `json:"created_at"`
 }
 
+type VorgangHistory struct {
+	ID           int64  `json:"id"`
+	VorgangID    string `json:"vorgang_id"`
+	Aktualisiert string `json:"aktualisiert"`
+	SyncRunID    string `json:"sync_run_id"`
+	Snapshot     string `json:"snapshot"`
+	Diff         string `json:"diff"`
+	RecordedAt   string `json:"recorded_at"`
+}
+
 type VorgangInitiative struct {
 	ID         int64  `json:"id"`
 	VorgangID  string `json:"vorgang_id"`
@@ -588,6 +924,17 @@ type Vorgangsposition struct {
 	CreatedAt                 string         `json:"created_at"`
 	UpdatedAt                 string         `json:"updated_at"`
 	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullString `json:"deleted_at"`
+}
+
+type VorgangspositionHistory struct {
+	ID                 int64  `json:"id"`
+	VorgangspositionID string `json:"vorgangsposition_id"`
+	Aktualisiert       string `json:"aktualisiert"`
+	SyncRunID          string `json:"sync_run_id"`
+	Snapshot           string `json:"snapshot"`
+	Diff               string `json:"diff"`
+	RecordedAt         string `json:"recorded_at"`
 }
 
 type VorgangspositionMitberaten struct {
@@ -598,6 +945,26 @@ type VorgangspositionMitberaten struct {
 	MitberatenVorgangstyp      string `json:"mitberaten_vorgangstyp"`
 }
 
+type VorgangspositionOverview struct {
+	ID                       string         `json:"id"`
+	VorgangID                string         `json:"vorgang_id"`
+	Titel                    string         `json:"titel"`
+	Vorgangsposition         string         `json:"vorgangsposition"`
+	Vorgangstyp              string         `json:"vorgangstyp"`
+	Dokumentart              string         `json:"dokumentart"`
+	Datum                    string         `json:"datum"`
+	Aktualisiert             string         `json:"aktualisiert"`
+	Abstract                 sql.NullString `json:"abstract"`
+	Zuordnung                string         `json:"zuordnung"`
+	FundstelleDokumentnummer string         `json:"fundstelle_dokumentnummer"`
+	FundstelleDatum          string         `json:"fundstelle_datum"`
+	FundstellePdfUrl         sql.NullString `json:"fundstelle_pdf_url"`
+	Ressorts                 string         `json:"ressorts"`
+	Urheber                  string         `json:"urheber"`
+	CreatedAt                string         `json:"created_at"`
+	UpdatedAt                string         `json:"updated_at"`
+}
+
 type VorgangspositionRessort struct {
 	VorgangspositionID string `json:"vorgangsposition_id"`
 	RessortID          int64  `json:"ressort_id"`
@@ -56,7 +56,7 @@ INSERT INTO person (
     id, vorname, nachname, namenszusatz, titel, typ,
     aktualisiert, basisdatum, datum
 ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, vorname, nachname, namenszusatz, titel, typ, aktualisiert, basisdatum, datum, created_at, updated_at
+RETURNING id, vorname, nachname, namenszusatz, titel, typ, aktualisiert, basisdatum, datum, created_at, updated_at, deleted_at
 `
 
 type CreatePersonParams struct {
@@ -96,6 +96,7 @@ func (q *Queries) CreatePerson(ctx context.Context, arg CreatePersonParams) (Per
 		&i.Datum,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -194,6 +195,25 @@ func (q *Queries) DeletePerson(ctx context.Context, id string) error {
 	return err
 }
 
+const deletePersonRoleWahlperioden = `-- name: DeletePersonRoleWahlperioden :exec
+DELETE FROM person_role_wahlperiode
+WHERE person_role_id IN (SELECT id FROM person_role WHERE person_id = ?)
+`
+
+func (q *Queries) DeletePersonRoleWahlperioden(ctx context.Context, personID string) error {
+	_, err := q.db.ExecContext(ctx, deletePersonRoleWahlperioden, personID)
+	return err
+}
+
+const deletePersonRoles = `-- name: DeletePersonRoles :exec
+DELETE FROM person_role WHERE person_id = ?
+`
+
+func (q *Queries) DeletePersonRoles(ctx context.Context, personID string) error {
+	_, err := q.db.ExecContext(ctx, deletePersonRoles, personID)
+	return err
+}
+
 const deletePersonWahlperioden = `-- name: DeletePersonWahlperioden :exec
 DELETE FROM person_wahlperiode WHERE person_id = ?
 `
@@ -215,7 +235,7 @@ func (q *Queries) GetLatestPersonDatum(ctx context.Context) (interface{}, error)
 }
 
 const getPerson = `-- name: GetPerson :one
-SELECT id, vorname, nachname, namenszusatz, titel, typ, aktualisiert, basisdatum, datum, created_at, updated_at
+SELECT id, vorname, nachname, namenszusatz, titel, typ, aktualisiert, basisdatum, datum, created_at, updated_at, deleted_at
 FROM person
 WHERE id = ?
 `
@@ -235,6 +255,7 @@ func (q *Queries) GetPerson(ctx context.Context, id string) (Person, error) {
 		&i.Datum,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -271,7 +292,7 @@ func (q *Queries) GetPersonWahlperioden(ctx context.Context, personID string) ([
 
 const getPersonWithRoles = `-- name: GetPersonWithRoles :many
 SELECT 
-    p.id, p.vorname, p.nachname, p.namenszusatz, p.titel, p.typ, p.aktualisiert, p.basisdatum, p.datum, p.created_at, p.updated_at,
+    p.id, p.vorname, p.nachname, p.namenszusatz, p.titel, p.typ, p.aktualisiert, p.basisdatum, p.datum, p.created_at, p.updated_at, p.deleted_at,
     pr.id as role_id,
     pr.funktion,
     pr.funktionszusatz,
@@ -299,6 +320,7 @@ type GetPersonWithRolesRow struct {
 	Datum            sql.NullString `json:"datum"`
 	CreatedAt        string         `json:"created_at"`
 	UpdatedAt        string         `json:"updated_at"`
+	DeletedAt        sql.NullString `json:"deleted_at"`
 	RoleID           sql.NullInt64  `json:"role_id"`
 	Funktion         sql.NullString `json:"funktion"`
 	Funktionszusatz  sql.NullString `json:"funktionszusatz"`
@@ -332,6 +354,7 @@ func (q *Queries) GetPersonWithRoles(ctx context.Context, id string) ([]GetPerso
 			&i.Datum,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
 			&i.RoleID,
 			&i.Funktion,
 			&i.Funktionszusatz,
@@ -357,7 +380,7 @@ func (q *Queries) GetPersonWithRoles(ctx context.Context, id string) ([]GetPerso
 }
 
 const listPersonen = `-- name: ListPersonen :many
-SELECT id, vorname, nachname, namenszusatz, titel, typ, aktualisiert, basisdatum, datum, created_at, updated_at
+SELECT id, vorname, nachname, namenszusatz, titel, typ, aktualisiert, basisdatum, datum, created_at, updated_at, deleted_at
 FROM person
 WHERE 
     (? IS NULL OR aktualisiert >= ?)
@@ -418,6 +441,7 @@ func (q *Queries) ListPersonen(ctx context.Context, arg ListPersonenParams) ([]P
 			&i.Datum,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -442,9 +466,10 @@ SET
     aktualisiert = ?,
     basisdatum = ?,
     datum = ?,
+    deleted_at = NULL,
     updated_at = datetime('now')
 WHERE id = ?
-RETURNING id, vorname, nachname, namenszusatz, titel, typ, aktualisiert, basisdatum, datum, created_at, updated_at
+RETURNING id, vorname, nachname, namenszusatz, titel, typ, aktualisiert, basisdatum, datum, created_at, updated_at, deleted_at
 `
 
 type UpdatePersonParams struct {
@@ -482,6 +507,53 @@ func (q *Queries) UpdatePerson(ctx context.Context, arg UpdatePersonParams) (Per
 		&i.Datum,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
+
+const upsertPerson = `-- name: UpsertPerson :exec
+INSERT INTO person (
+    id, vorname, nachname, namenszusatz, titel, typ,
+    aktualisiert, basisdatum, datum
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE
+SET
+    vorname = excluded.vorname,
+    nachname = excluded.nachname,
+    namenszusatz = excluded.namenszusatz,
+    titel = excluded.titel,
+    typ = excluded.typ,
+    aktualisiert = excluded.aktualisiert,
+    basisdatum = excluded.basisdatum,
+    datum = excluded.datum,
+    deleted_at = NULL,
+    updated_at = datetime('now')
+`
+
+type UpsertPersonParams struct {
+	ID           string         `json:"id"`
+	Vorname      string         `json:"vorname"`
+	Nachname     string         `json:"nachname"`
+	Namenszusatz sql.NullString `json:"namenszusatz"`
+	Titel        string         `json:"titel"`
+	Typ          string         `json:"typ"`
+	Aktualisiert string         `json:"aktualisiert"`
+	Basisdatum   sql.NullString `json:"basisdatum"`
+	Datum        sql.NullString `json:"datum"`
+}
+
+func (q *Queries) UpsertPerson(ctx context.Context, arg UpsertPersonParams) error {
+	_, err := q.db.ExecContext(ctx, upsertPerson,
+		arg.ID,
+		arg.Vorname,
+		arg.Nachname,
+		arg.Namenszusatz,
+		arg.Titel,
+		arg.Typ,
+		arg.Aktualisiert,
+		arg.Basisdatum,
+		arg.Datum,
+	)
+	return err
+}
@@ -68,7 +68,7 @@ INSERT INTO plenarprotokoll (
     ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
     ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
     ?, ?, ?, ?, ?, ?
-) RETURNING id, titel, dokumentnummer, dokumentart, typ, herausgeber, datum, aktualisiert, pdf_hash, sitzungsbemerkung, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, created_at, updated_at, fundstelle_xml_url
+) RETURNING id, titel, dokumentnummer, dokumentart, typ, herausgeber, datum, aktualisiert, pdf_hash, sitzungsbemerkung, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, created_at, updated_at, fundstelle_xml_url, deleted_at
 `
 
 type CreatePlenarprotokollParams struct {
@@ -159,6 +159,7 @@ func (q *Queries) CreatePlenarprotokoll(ctx context.Context, arg CreatePlenarpro
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -198,6 +199,24 @@ func (q *Queries) DeletePlenarprotokoll(ctx context.Context, id string) error {
 	return err
 }
 
+const deletePlenarprotokollFundstelleUrheber = `-- name: DeletePlenarprotokollFundstelleUrheber :exec
+DELETE FROM fundstelle_urheber WHERE plenarprotokoll_id = ?
+`
+
+func (q *Queries) DeletePlenarprotokollFundstelleUrheber(ctx context.Context, plenarprotokollID sql.NullString) error {
+	_, err := q.db.ExecContext(ctx, deletePlenarprotokollFundstelleUrheber, plenarprotokollID)
+	return err
+}
+
+const deletePlenarprotokollVorgangsbezuege = `-- name: DeletePlenarprotokollVorgangsbezuege :exec
+DELETE FROM plenarprotokoll_vorgangsbezug WHERE plenarprotokoll_id = ?
+`
+
+func (q *Queries) DeletePlenarprotokollVorgangsbezuege(ctx context.Context, plenarprotokollID string) error {
+	_, err := q.db.ExecContext(ctx, deletePlenarprotokollVorgangsbezuege, plenarprotokollID)
+	return err
+}
+
 const getLatestPlenarprotokollDatum = `-- name: GetLatestPlenarprotokollDatum :one
 SELECT MIN(datum) as datum FROM plenarprotokoll
 `
@@ -210,7 +229,7 @@ func (q *Queries) GetLatestPlenarprotokollDatum(ctx context.Context) (interface{
 }
 
 const getPlenarprotokoll = `-- name: GetPlenarprotokoll :one
-SELECT id, titel, dokumentnummer, dokumentart, typ, herausgeber, datum, aktualisiert, pdf_hash, sitzungsbemerkung, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, created_at, updated_at, fundstelle_xml_url
+SELECT id, titel, dokumentnummer, dokumentart, typ, herausgeber, datum, aktualisiert, pdf_hash, sitzungsbemerkung, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, created_at, updated_at, fundstelle_xml_url, deleted_at
 FROM plenarprotokoll
 WHERE id = ?
 `
@@ -247,13 +266,14 @@ func (q *Queries) GetPlenarprotokoll(ctx context.Context, id string) (Plenarprot
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getPlenarprotokollWithVorgangsbezug = `-- name: GetPlenarprotokollWithVorgangsbezug :many
 SELECT 
-    p.id, p.titel, p.dokumentnummer, p.dokumentart, p.typ, p.herausgeber, p.datum, p.aktualisiert, p.pdf_hash, p.sitzungsbemerkung, p.vorgangsbezug_anzahl, p.wahlperiode, p.fundstelle_dokumentnummer, p.fundstelle_datum, p.fundstelle_dokumentart, p.fundstelle_herausgeber, p.fundstelle_id, p.fundstelle_anfangsseite, p.fundstelle_endseite, p.fundstelle_anfangsquadrant, p.fundstelle_endquadrant, p.fundstelle_seite, p.fundstelle_pdf_url, p.fundstelle_top, p.fundstelle_top_zusatz, p.created_at, p.updated_at, p.fundstelle_xml_url,
+    p.id, p.titel, p.dokumentnummer, p.dokumentart, p.typ, p.herausgeber, p.datum, p.aktualisiert, p.pdf_hash, p.sitzungsbemerkung, p.vorgangsbezug_anzahl, p.wahlperiode, p.fundstelle_dokumentnummer, p.fundstelle_datum, p.fundstelle_dokumentart, p.fundstelle_herausgeber, p.fundstelle_id, p.fundstelle_anfangsseite, p.fundstelle_endseite, p.fundstelle_anfangsquadrant, p.fundstelle_endquadrant, p.fundstelle_seite, p.fundstelle_pdf_url, p.fundstelle_top, p.fundstelle_top_zusatz, p.created_at, p.updated_at, p.fundstelle_xml_url, p.deleted_at,
     pvb.vorgang_id,
     pvb.titel as vorgangsbezug_titel,
     pvb.vorgangstyp as vorgangsbezug_typ,
@@ -293,6 +313,7 @@ type GetPlenarprotokollWithVorgangsbezugRow struct {
 	CreatedAt                 string         `json:"created_at"`
 	UpdatedAt                 string         `json:"updated_at"`
 	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullString `json:"deleted_at"`
 	VorgangID                 sql.NullString `json:"vorgang_id"`
 	VorgangsbezugTitel        sql.NullString `json:"vorgangsbezug_titel"`
 	VorgangsbezugTyp          sql.NullString `json:"vorgangsbezug_typ"`
@@ -337,6 +358,7 @@ func (q *Queries) GetPlenarprotokollWithVorgangsbezug(ctx context.Context, id st
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 			&i.VorgangID,
 			&i.VorgangsbezugTitel,
 			&i.VorgangsbezugTyp,
@@ -356,7 +378,7 @@ func (q *Queries) GetPlenarprotokollWithVorgangsbezug(ctx context.Context, id st
 }
 
 const listPlenarprotokolle = `-- name: ListPlenarprotokolle :many
-SELECT id, titel, dokumentnummer, dokumentart, typ, herausgeber, datum, aktualisiert, pdf_hash, sitzungsbemerkung, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, created_at, updated_at, fundstelle_xml_url
+SELECT id, titel, dokumentnummer, dokumentart, typ, herausgeber, datum, aktualisiert, pdf_hash, sitzungsbemerkung, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, created_at, updated_at, fundstelle_xml_url, deleted_at
 FROM plenarprotokoll
 WHERE 
     (? IS NULL OR aktualisiert >= ?)
@@ -439,6 +461,7 @@ func (q *Queries) ListPlenarprotokolle(ctx context.Context, arg ListPlenarprotok
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -461,9 +484,10 @@ SET
     pdf_hash = ?,
     sitzungsbemerkung = ?,
     vorgangsbezug_anzahl = ?,
+    deleted_at = NULL,
     updated_at = datetime('now')
 WHERE id = ?
-RETURNING id, titel, dokumentnummer, dokumentart, typ, herausgeber, datum, aktualisiert, pdf_hash, sitzungsbemerkung, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, created_at, updated_at, fundstelle_xml_url
+RETURNING id, titel, dokumentnummer, dokumentart, typ, herausgeber, datum, aktualisiert, pdf_hash, sitzungsbemerkung, vorgangsbezug_anzahl, wahlperiode, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, created_at, updated_at, fundstelle_xml_url, deleted_at
 `
 
 type UpdatePlenarprotokollParams struct {
@@ -514,6 +538,111 @@ func (q *Queries) UpdatePlenarprotokoll(ctx context.Context, arg UpdatePlenarpro
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
+
+const upsertPlenarprotokoll = `-- name: UpsertPlenarprotokoll :exec
+INSERT INTO plenarprotokoll (
+    id, titel, dokumentnummer, dokumentart, typ, herausgeber,
+    datum, aktualisiert, pdf_hash, sitzungsbemerkung, vorgangsbezug_anzahl, wahlperiode,
+    fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+    fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite,
+    fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?
+) ON CONFLICT (id) DO UPDATE
+SET
+    titel = excluded.titel,
+    dokumentnummer = excluded.dokumentnummer,
+    dokumentart = excluded.dokumentart,
+    typ = excluded.typ,
+    herausgeber = excluded.herausgeber,
+    datum = excluded.datum,
+    aktualisiert = excluded.aktualisiert,
+    pdf_hash = excluded.pdf_hash,
+    sitzungsbemerkung = excluded.sitzungsbemerkung,
+    vorgangsbezug_anzahl = excluded.vorgangsbezug_anzahl,
+    wahlperiode = excluded.wahlperiode,
+    fundstelle_dokumentnummer = excluded.fundstelle_dokumentnummer,
+    fundstelle_datum = excluded.fundstelle_datum,
+    fundstelle_dokumentart = excluded.fundstelle_dokumentart,
+    fundstelle_herausgeber = excluded.fundstelle_herausgeber,
+    fundstelle_id = excluded.fundstelle_id,
+    fundstelle_anfangsseite = excluded.fundstelle_anfangsseite,
+    fundstelle_endseite = excluded.fundstelle_endseite,
+    fundstelle_anfangsquadrant = excluded.fundstelle_anfangsquadrant,
+    fundstelle_endquadrant = excluded.fundstelle_endquadrant,
+    fundstelle_seite = excluded.fundstelle_seite,
+    fundstelle_pdf_url = excluded.fundstelle_pdf_url,
+    fundstelle_xml_url = excluded.fundstelle_xml_url,
+    fundstelle_top = excluded.fundstelle_top,
+    fundstelle_top_zusatz = excluded.fundstelle_top_zusatz,
+    deleted_at = NULL,
+    updated_at = datetime('now')
+`
+
+type UpsertPlenarprotokollParams struct {
+	ID                        string         `json:"id"`
+	Titel                     string         `json:"titel"`
+	Dokumentnummer            string         `json:"dokumentnummer"`
+	Dokumentart               string         `json:"dokumentart"`
+	Typ                       string         `json:"typ"`
+	Herausgeber               string         `json:"herausgeber"`
+	Datum                     string         `json:"datum"`
+	Aktualisiert              string         `json:"aktualisiert"`
+	PdfHash                   sql.NullString `json:"pdf_hash"`
+	Sitzungsbemerkung         sql.NullString `json:"sitzungsbemerkung"`
+	VorgangsbezugAnzahl       int64          `json:"vorgangsbezug_anzahl"`
+	Wahlperiode               sql.NullInt64  `json:"wahlperiode"`
+	FundstelleDokumentnummer  string         `json:"fundstelle_dokumentnummer"`
+	FundstelleDatum           string         `json:"fundstelle_datum"`
+	FundstelleDokumentart     string         `json:"fundstelle_dokumentart"`
+	FundstelleHerausgeber     string         `json:"fundstelle_herausgeber"`
+	FundstelleID              string         `json:"fundstelle_id"`
+	FundstelleAnfangsseite    sql.NullInt64  `json:"fundstelle_anfangsseite"`
+	FundstelleEndseite        sql.NullInt64  `json:"fundstelle_endseite"`
+	FundstelleAnfangsquadrant sql.NullString `json:"fundstelle_anfangsquadrant"`
+	FundstelleEndquadrant     sql.NullString `json:"fundstelle_endquadrant"`
+	FundstelleSeite           sql.NullString `json:"fundstelle_seite"`
+	FundstellePdfUrl          sql.NullString `json:"fundstelle_pdf_url"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	FundstelleTop             sql.NullInt64  `json:"fundstelle_top"`
+	FundstelleTopZusatz       sql.NullString `json:"fundstelle_top_zusatz"`
+}
+
+func (q *Queries) UpsertPlenarprotokoll(ctx context.Context, arg UpsertPlenarprotokollParams) error {
+	_, err := q.db.ExecContext(ctx, upsertPlenarprotokoll,
+		arg.ID,
+		arg.Titel,
+		arg.Dokumentnummer,
+		arg.Dokumentart,
+		arg.Typ,
+		arg.Herausgeber,
+		arg.Datum,
+		arg.Aktualisiert,
+		arg.PdfHash,
+		arg.Sitzungsbemerkung,
+		arg.VorgangsbezugAnzahl,
+		arg.Wahlperiode,
+		arg.FundstelleDokumentnummer,
+		arg.FundstelleDatum,
+		arg.FundstelleDokumentart,
+		arg.FundstelleHerausgeber,
+		arg.FundstelleID,
+		arg.FundstelleAnfangsseite,
+		arg.FundstelleEndseite,
+		arg.FundstelleAnfangsquadrant,
+		arg.FundstelleEndquadrant,
+		arg.FundstelleSeite,
+		arg.FundstellePdfUrl,
+		arg.FundstelleXmlUrl,
+		arg.FundstelleTop,
+		arg.FundstelleTopZusatz,
+	)
+	return err
+}
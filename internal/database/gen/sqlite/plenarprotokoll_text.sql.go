@@ -94,7 +94,7 @@ func (q *Queries) DeletePlenarprotokollText(ctx context.Context, id string) erro
 
 const getPlenarprotokollText = `-- name: GetPlenarprotokollText :one
 SELECT 
-    p.id, p.titel, p.dokumentnummer, p.dokumentart, p.typ, p.herausgeber, p.datum, p.aktualisiert, p.pdf_hash, p.sitzungsbemerkung, p.vorgangsbezug_anzahl, p.wahlperiode, p.fundstelle_dokumentnummer, p.fundstelle_datum, p.fundstelle_dokumentart, p.fundstelle_herausgeber, p.fundstelle_id, p.fundstelle_anfangsseite, p.fundstelle_endseite, p.fundstelle_anfangsquadrant, p.fundstelle_endquadrant, p.fundstelle_seite, p.fundstelle_pdf_url, p.fundstelle_top, p.fundstelle_top_zusatz, p.created_at, p.updated_at, p.fundstelle_xml_url,
+    p.id, p.titel, p.dokumentnummer, p.dokumentart, p.typ, p.herausgeber, p.datum, p.aktualisiert, p.pdf_hash, p.sitzungsbemerkung, p.vorgangsbezug_anzahl, p.wahlperiode, p.fundstelle_dokumentnummer, p.fundstelle_datum, p.fundstelle_dokumentart, p.fundstelle_herausgeber, p.fundstelle_id, p.fundstelle_anfangsseite, p.fundstelle_endseite, p.fundstelle_anfangsquadrant, p.fundstelle_endquadrant, p.fundstelle_seite, p.fundstelle_pdf_url, p.fundstelle_top, p.fundstelle_top_zusatz, p.created_at, p.updated_at, p.fundstelle_xml_url, p.deleted_at,
     pt.text
 FROM plenarprotokoll p
 LEFT JOIN plenarprotokoll_text pt ON p.id = pt.id
@@ -130,6 +130,7 @@ type GetPlenarprotokollTextRow struct {
 	CreatedAt                 string         `json:"created_at"`
 	UpdatedAt                 string         `json:"updated_at"`
 	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullString `json:"deleted_at"`
 	Text                      sql.NullString `json:"text"`
 }
 
@@ -165,6 +166,7 @@ func (q *Queries) GetPlenarprotokollText(ctx context.Context, id string) (GetPle
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 		&i.Text,
 	)
 	return i, err
@@ -172,7 +174,7 @@ func (q *Queries) GetPlenarprotokollText(ctx context.Context, id string) (GetPle
 
 const listPlenarprotokollTexte = `-- name: ListPlenarprotokollTexte :many
 SELECT 
-    p.id, p.titel, p.dokumentnummer, p.dokumentart, p.typ, p.herausgeber, p.datum, p.aktualisiert, p.pdf_hash, p.sitzungsbemerkung, p.vorgangsbezug_anzahl, p.wahlperiode, p.fundstelle_dokumentnummer, p.fundstelle_datum, p.fundstelle_dokumentart, p.fundstelle_herausgeber, p.fundstelle_id, p.fundstelle_anfangsseite, p.fundstelle_endseite, p.fundstelle_anfangsquadrant, p.fundstelle_endquadrant, p.fundstelle_seite, p.fundstelle_pdf_url, p.fundstelle_top, p.fundstelle_top_zusatz, p.created_at, p.updated_at, p.fundstelle_xml_url,
+    p.id, p.titel, p.dokumentnummer, p.dokumentart, p.typ, p.herausgeber, p.datum, p.aktualisiert, p.pdf_hash, p.sitzungsbemerkung, p.vorgangsbezug_anzahl, p.wahlperiode, p.fundstelle_dokumentnummer, p.fundstelle_datum, p.fundstelle_dokumentart, p.fundstelle_herausgeber, p.fundstelle_id, p.fundstelle_anfangsseite, p.fundstelle_endseite, p.fundstelle_anfangsquadrant, p.fundstelle_endquadrant, p.fundstelle_seite, p.fundstelle_pdf_url, p.fundstelle_top, p.fundstelle_top_zusatz, p.created_at, p.updated_at, p.fundstelle_xml_url, p.deleted_at,
     pt.text
 FROM plenarprotokoll p
 INNER JOIN plenarprotokoll_text pt ON p.id = pt.id
@@ -233,6 +235,7 @@ type ListPlenarprotokollTexteRow struct {
 	CreatedAt                 string         `json:"created_at"`
 	UpdatedAt                 string         `json:"updated_at"`
 	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullString `json:"deleted_at"`
 	Text                      sql.NullString `json:"text"`
 }
 
@@ -289,6 +292,7 @@ func (q *Queries) ListPlenarprotokollTexte(ctx context.Context, arg ListPlenarpr
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 			&i.Text,
 		); err != nil {
 			return nil, err
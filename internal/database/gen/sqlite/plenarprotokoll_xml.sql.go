@@ -0,0 +1,298 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: plenarprotokoll_xml.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createPlenarprotokollXML = `-- name: CreatePlenarprotokollXML :one
+INSERT INTO plenarprotokoll_xml (
+    dokumentnummer, plenarprotokoll_id, wahlperiode, sitzung_nr, datum,
+    start_uhrzeit, ende_uhrzeit, ort, version, quelle, sha256, dtd_validiert
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id
+`
+
+type CreatePlenarprotokollXMLParams struct {
+	Dokumentnummer    string         `json:"dokumentnummer"`
+	PlenarprotokollID sql.NullString `json:"plenarprotokoll_id"`
+	Wahlperiode       int64          `json:"wahlperiode"`
+	SitzungNr         int64          `json:"sitzung_nr"`
+	Datum             sql.NullString `json:"datum"`
+	StartUhrzeit      sql.NullString `json:"start_uhrzeit"`
+	EndeUhrzeit       sql.NullString `json:"ende_uhrzeit"`
+	Ort               sql.NullString `json:"ort"`
+	Version           sql.NullString `json:"version"`
+	Quelle            string         `json:"quelle"`
+	Sha256            string         `json:"sha256"`
+	DtdValidiert      int64          `json:"dtd_validiert"`
+}
+
+func (q *Queries) CreatePlenarprotokollXML(ctx context.Context, arg CreatePlenarprotokollXMLParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createPlenarprotokollXML,
+		arg.Dokumentnummer,
+		arg.PlenarprotokollID,
+		arg.Wahlperiode,
+		arg.SitzungNr,
+		arg.Datum,
+		arg.StartUhrzeit,
+		arg.EndeUhrzeit,
+		arg.Ort,
+		arg.Version,
+		arg.Quelle,
+		arg.Sha256,
+		arg.DtdValidiert,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createPlenarprotokollXMLAbsatz = `-- name: CreatePlenarprotokollXMLAbsatz :exec
+INSERT INTO plenarprotokoll_xml_absatz (rede_id, position, typ, klasse, redner_id, sprecher, text)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreatePlenarprotokollXMLAbsatzParams struct {
+	RedeID   int64          `json:"rede_id"`
+	Position int64          `json:"position"`
+	Typ      string         `json:"typ"`
+	Klasse   sql.NullString `json:"klasse"`
+	RednerID sql.NullString `json:"redner_id"`
+	Sprecher sql.NullString `json:"sprecher"`
+	Text     string         `json:"text"`
+}
+
+func (q *Queries) CreatePlenarprotokollXMLAbsatz(ctx context.Context, arg CreatePlenarprotokollXMLAbsatzParams) error {
+	_, err := q.db.ExecContext(ctx, createPlenarprotokollXMLAbsatz,
+		arg.RedeID,
+		arg.Position,
+		arg.Typ,
+		arg.Klasse,
+		arg.RednerID,
+		arg.Sprecher,
+		arg.Text,
+	)
+	return err
+}
+
+const createPlenarprotokollXMLRede = `-- name: CreatePlenarprotokollXMLRede :one
+INSERT INTO plenarprotokoll_xml_rede (protokoll_xml_id, tagesordnungspunkt_id, position, rede_id, redner_id)
+VALUES (?, ?, ?, ?, ?)
+RETURNING id
+`
+
+type CreatePlenarprotokollXMLRedeParams struct {
+	ProtokollXmlID       int64          `json:"protokoll_xml_id"`
+	TagesordnungspunktID int64          `json:"tagesordnungspunkt_id"`
+	Position             int64          `json:"position"`
+	RedeID               sql.NullString `json:"rede_id"`
+	RednerID             sql.NullString `json:"redner_id"`
+}
+
+func (q *Queries) CreatePlenarprotokollXMLRede(ctx context.Context, arg CreatePlenarprotokollXMLRedeParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createPlenarprotokollXMLRede,
+		arg.ProtokollXmlID,
+		arg.TagesordnungspunktID,
+		arg.Position,
+		arg.RedeID,
+		arg.RednerID,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createPlenarprotokollXMLRedner = `-- name: CreatePlenarprotokollXMLRedner :exec
+INSERT INTO plenarprotokoll_xml_redner (
+    protokoll_xml_id, redner_id, titel, vorname, namenszusatz, nachname,
+    ortszusatz, fraktion, rolle_lang, rolle_kurz, bundesland
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreatePlenarprotokollXMLRednerParams struct {
+	ProtokollXmlID int64          `json:"protokoll_xml_id"`
+	RednerID       string         `json:"redner_id"`
+	Titel          sql.NullString `json:"titel"`
+	Vorname        sql.NullString `json:"vorname"`
+	Namenszusatz   sql.NullString `json:"namenszusatz"`
+	Nachname       sql.NullString `json:"nachname"`
+	Ortszusatz     sql.NullString `json:"ortszusatz"`
+	Fraktion       sql.NullString `json:"fraktion"`
+	RolleLang      sql.NullString `json:"rolle_lang"`
+	RolleKurz      sql.NullString `json:"rolle_kurz"`
+	Bundesland     sql.NullString `json:"bundesland"`
+}
+
+func (q *Queries) CreatePlenarprotokollXMLRedner(ctx context.Context, arg CreatePlenarprotokollXMLRednerParams) error {
+	_, err := q.db.ExecContext(ctx, createPlenarprotokollXMLRedner,
+		arg.ProtokollXmlID,
+		arg.RednerID,
+		arg.Titel,
+		arg.Vorname,
+		arg.Namenszusatz,
+		arg.Nachname,
+		arg.Ortszusatz,
+		arg.Fraktion,
+		arg.RolleLang,
+		arg.RolleKurz,
+		arg.Bundesland,
+	)
+	return err
+}
+
+const createPlenarprotokollXMLTagesordnungspunkt = `-- name: CreatePlenarprotokollXMLTagesordnungspunkt :one
+INSERT INTO plenarprotokoll_xml_tagesordnungspunkt (protokoll_xml_id, position, top_id, titel)
+VALUES (?, ?, ?, ?)
+RETURNING id
+`
+
+type CreatePlenarprotokollXMLTagesordnungspunktParams struct {
+	ProtokollXmlID int64          `json:"protokoll_xml_id"`
+	Position       int64          `json:"position"`
+	TopID          sql.NullString `json:"top_id"`
+	Titel          sql.NullString `json:"titel"`
+}
+
+func (q *Queries) CreatePlenarprotokollXMLTagesordnungspunkt(ctx context.Context, arg CreatePlenarprotokollXMLTagesordnungspunktParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createPlenarprotokollXMLTagesordnungspunkt,
+		arg.ProtokollXmlID,
+		arg.Position,
+		arg.TopID,
+		arg.Titel,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deletePlenarprotokollXML = `-- name: DeletePlenarprotokollXML :exec
+DELETE FROM plenarprotokoll_xml WHERE dokumentnummer = ?
+`
+
+func (q *Queries) DeletePlenarprotokollXML(ctx context.Context, dokumentnummer string) error {
+	_, err := q.db.ExecContext(ctx, deletePlenarprotokollXML, dokumentnummer)
+	return err
+}
+
+const deletePlenarprotokollXMLAbsaetze = `-- name: DeletePlenarprotokollXMLAbsaetze :exec
+DELETE FROM plenarprotokoll_xml_absatz
+WHERE rede_id IN (
+    SELECT r.id FROM plenarprotokoll_xml_rede r
+    JOIN plenarprotokoll_xml x ON x.id = r.protokoll_xml_id
+    WHERE x.dokumentnummer = ?
+)
+`
+
+func (q *Queries) DeletePlenarprotokollXMLAbsaetze(ctx context.Context, dokumentnummer string) error {
+	_, err := q.db.ExecContext(ctx, deletePlenarprotokollXMLAbsaetze, dokumentnummer)
+	return err
+}
+
+const deletePlenarprotokollXMLReden = `-- name: DeletePlenarprotokollXMLReden :exec
+DELETE FROM plenarprotokoll_xml_rede
+WHERE protokoll_xml_id IN (SELECT id FROM plenarprotokoll_xml WHERE dokumentnummer = ?)
+`
+
+func (q *Queries) DeletePlenarprotokollXMLReden(ctx context.Context, dokumentnummer string) error {
+	_, err := q.db.ExecContext(ctx, deletePlenarprotokollXMLReden, dokumentnummer)
+	return err
+}
+
+const deletePlenarprotokollXMLRedner = `-- name: DeletePlenarprotokollXMLRedner :exec
+DELETE FROM plenarprotokoll_xml_redner
+WHERE protokoll_xml_id IN (SELECT id FROM plenarprotokoll_xml WHERE dokumentnummer = ?)
+`
+
+func (q *Queries) DeletePlenarprotokollXMLRedner(ctx context.Context, dokumentnummer string) error {
+	_, err := q.db.ExecContext(ctx, deletePlenarprotokollXMLRedner, dokumentnummer)
+	return err
+}
+
+const deletePlenarprotokollXMLTagesordnungspunkte = `-- name: DeletePlenarprotokollXMLTagesordnungspunkte :exec
+DELETE FROM plenarprotokoll_xml_tagesordnungspunkt
+WHERE protokoll_xml_id IN (SELECT id FROM plenarprotokoll_xml WHERE dokumentnummer = ?)
+`
+
+func (q *Queries) DeletePlenarprotokollXMLTagesordnungspunkte(ctx context.Context, dokumentnummer string) error {
+	_, err := q.db.ExecContext(ctx, deletePlenarprotokollXMLTagesordnungspunkte, dokumentnummer)
+	return err
+}
+
+const getPlenarprotokollIDByDokumentnummer = `-- name: GetPlenarprotokollIDByDokumentnummer :one
+SELECT id FROM plenarprotokoll
+WHERE herausgeber = 'BT' AND dokumentnummer = ?
+LIMIT 1
+`
+
+func (q *Queries) GetPlenarprotokollIDByDokumentnummer(ctx context.Context, dokumentnummer string) (string, error) {
+	row := q.db.QueryRowContext(ctx, getPlenarprotokollIDByDokumentnummer, dokumentnummer)
+	var id string
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getPlenarprotokollXMLHash = `-- name: GetPlenarprotokollXMLHash :one
+SELECT sha256 FROM plenarprotokoll_xml WHERE dokumentnummer = ?
+`
+
+func (q *Queries) GetPlenarprotokollXMLHash(ctx context.Context, dokumentnummer string) (string, error) {
+	row := q.db.QueryRowContext(ctx, getPlenarprotokollXMLHash, dokumentnummer)
+	var sha256 string
+	err := row.Scan(&sha256)
+	return sha256, err
+}
+
+const listPendingPlenarprotokollXML = `-- name: ListPendingPlenarprotokollXML :many
+
+SELECT p.id, p.dokumentnummer, p.fundstelle_xml_url
+FROM plenarprotokoll p
+LEFT JOIN plenarprotokoll_xml x ON x.dokumentnummer = p.dokumentnummer
+WHERE p.herausgeber = 'BT'
+  AND p.fundstelle_xml_url IS NOT NULL
+  AND p.deleted_at IS NULL
+  AND (CAST(?1 AS INTEGER) = 0 OR p.wahlperiode = ?1)
+  AND (CAST(?2 AS INTEGER) = 1 OR x.id IS NULL)
+ORDER BY p.wahlperiode, p.id
+`
+
+type ListPendingPlenarprotokollXMLParams struct {
+	Wahlperiode   int64 `json:"wahlperiode"`
+	AllProtokolle int64 `json:"all_protokolle"`
+}
+
+type ListPendingPlenarprotokollXMLRow struct {
+	ID               string         `json:"id"`
+	Dokumentnummer   string         `json:"dokumentnummer"`
+	FundstelleXmlUrl sql.NullString `json:"fundstelle_xml_url"`
+}
+
+// Structured Plenarprotokoll XML (sync-plenarprotokoll-xml)
+// Plenarprotokolle with an XML URL that were not loaded yet, from any source
+func (q *Queries) ListPendingPlenarprotokollXML(ctx context.Context, arg ListPendingPlenarprotokollXMLParams) ([]ListPendingPlenarprotokollXMLRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingPlenarprotokollXML, arg.Wahlperiode, arg.AllProtokolle)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPendingPlenarprotokollXMLRow
+	for rows.Next() {
+		var i ListPendingPlenarprotokollXMLRow
+		if err := rows.Scan(&i.ID, &i.Dokumentnummer, &i.FundstelleXmlUrl); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
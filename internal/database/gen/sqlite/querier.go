@@ -24,15 +24,19 @@ type Querier interface {
 	CountPersonen(ctx context.Context, arg CountPersonenParams) (int64, error)
 	CountPlenarprotokollTexte(ctx context.Context, arg CountPlenarprotokollTexteParams) (int64, error)
 	CountPlenarprotokolle(ctx context.Context, arg CountPlenarprotokolleParams) (int64, error)
+	CountUnresolvedDokumentZitate(ctx context.Context) (int64, error)
 	CountVorgaenge(ctx context.Context, arg CountVorgaengeParams) (int64, error)
 	CountVorgangspositionen(ctx context.Context, arg CountVorgangspositionenParams) (int64, error)
 	CreateAktivitaet(ctx context.Context, arg CreateAktivitaetParams) (Aktivitaet, error)
 	CreateAktivitaetAnzeige(ctx context.Context, arg CreateAktivitaetAnzeigeParams) (AktivitaetAnzeige, error)
 	CreateAktivitaetDeskriptor(ctx context.Context, arg CreateAktivitaetDeskriptorParams) (AktivitaetDeskriptor, error)
+	CreateAktivitaetHistory(ctx context.Context, arg CreateAktivitaetHistoryParams) error
 	CreateAktivitaetVorgangsbezug(ctx context.Context, arg CreateAktivitaetVorgangsbezugParams) error
 	CreateBeschlussfassung(ctx context.Context, arg CreateBeschlussfassungParams) (Beschlussfassung, error)
+	CreateDokumentZitat(ctx context.Context, arg CreateDokumentZitatParams) error
 	CreateDrucksache(ctx context.Context, arg CreateDrucksacheParams) (Drucksache, error)
 	CreateDrucksacheAutorAnzeige(ctx context.Context, arg CreateDrucksacheAutorAnzeigeParams) (DrucksacheAutorAnzeige, error)
+	CreateDrucksacheHistory(ctx context.Context, arg CreateDrucksacheHistoryParams) error
 	CreateDrucksacheRessort(ctx context.Context, arg CreateDrucksacheRessortParams) error
 	CreateDrucksacheText(ctx context.Context, arg CreateDrucksacheTextParams) (DrucksacheText, error)
 	CreateDrucksacheUrheber(ctx context.Context, arg CreateDrucksacheUrheberParams) error
@@ -66,6 +70,7 @@ type Querier interface {
 	// ============================================================================
 	CreateMdbWahlperiodeMembership(ctx context.Context, arg CreateMdbWahlperiodeMembershipParams) (int64, error)
 	CreatePerson(ctx context.Context, arg CreatePersonParams) (Person, error)
+	CreatePersonHistory(ctx context.Context, arg CreatePersonHistoryParams) error
 	// ============================================================================
 	// PERSON-MDB LINKING
 	// ============================================================================
@@ -74,40 +79,93 @@ type Querier interface {
 	CreatePersonRoleWahlperiode(ctx context.Context, arg CreatePersonRoleWahlperiodeParams) error
 	CreatePersonWahlperiode(ctx context.Context, arg CreatePersonWahlperiodeParams) error
 	CreatePlenarprotokoll(ctx context.Context, arg CreatePlenarprotokollParams) (Plenarprotokoll, error)
+	CreatePlenarprotokollHistory(ctx context.Context, arg CreatePlenarprotokollHistoryParams) error
 	CreatePlenarprotokollText(ctx context.Context, arg CreatePlenarprotokollTextParams) (PlenarprotokollText, error)
 	CreatePlenarprotokollVorgangsbezug(ctx context.Context, arg CreatePlenarprotokollVorgangsbezugParams) error
+	CreatePlenarprotokollXML(ctx context.Context, arg CreatePlenarprotokollXMLParams) (int64, error)
+	CreatePlenarprotokollXMLAbsatz(ctx context.Context, arg CreatePlenarprotokollXMLAbsatzParams) error
+	CreatePlenarprotokollXMLRede(ctx context.Context, arg CreatePlenarprotokollXMLRedeParams) (int64, error)
+	CreatePlenarprotokollXMLRedner(ctx context.Context, arg CreatePlenarprotokollXMLRednerParams) error
+	CreatePlenarprotokollXMLTagesordnungspunkt(ctx context.Context, arg CreatePlenarprotokollXMLTagesordnungspunktParams) (int64, error)
+	CreateRede(ctx context.Context, arg CreateRedeParams) (int64, error)
+	CreateRedeReaktion(ctx context.Context, arg CreateRedeReaktionParams) error
+	// Queries for the optional change history of synced entities.
+	CreateSyncRun(ctx context.Context, arg CreateSyncRunParams) error
 	CreateUeberweisung(ctx context.Context, arg CreateUeberweisungParams) (Ueberweisung, error)
 	CreateVerkuendung(ctx context.Context, arg CreateVerkuendungParams) (Verkuendung, error)
 	CreateVorgang(ctx context.Context, arg CreateVorgangParams) (Vorgang, error)
 	CreateVorgangDeskriptor(ctx context.Context, arg CreateVorgangDeskriptorParams) (VorgangDeskriptor, error)
+	CreateVorgangHistory(ctx context.Context, arg CreateVorgangHistoryParams) error
 	CreateVorgangInitiative(ctx context.Context, arg CreateVorgangInitiativeParams) error
 	CreateVorgangSachgebiet(ctx context.Context, arg CreateVorgangSachgebietParams) error
 	CreateVorgangVerlinkung(ctx context.Context, arg CreateVorgangVerlinkungParams) (VorgangVerlinkung, error)
 	CreateVorgangZustimmungsbeduerftigkeit(ctx context.Context, arg CreateVorgangZustimmungsbeduerftigkeitParams) error
 	CreateVorgangsposition(ctx context.Context, arg CreateVorgangspositionParams) (Vorgangsposition, error)
+	CreateVorgangspositionHistory(ctx context.Context, arg CreateVorgangspositionHistoryParams) error
 	CreateVorgangspositionMitberaten(ctx context.Context, arg CreateVorgangspositionMitberatenParams) error
 	CreateVorgangspositionRessort(ctx context.Context, arg CreateVorgangspositionRessortParams) error
 	CreateVorgangspositionUrheber(ctx context.Context, arg CreateVorgangspositionUrheberParams) error
 	DeleteAktivitaet(ctx context.Context, id string) error
+	DeleteAktivitaetAnzeigen(ctx context.Context, vorgangspositionID string) error
+	DeleteAktivitaetDeskriptoren(ctx context.Context, aktivitaetID string) error
+	DeleteAktivitaetVorgangsbezuege(ctx context.Context, aktivitaetID string) error
+	DeleteBeschlussfassungen(ctx context.Context, vorgangspositionID string) error
+	DeleteDokumentPdf(ctx context.Context, pdfHash string) error
+	DeleteDokumentPdfFehler(ctx context.Context, pdfHash string) error
+	DeleteDokumentZitate(ctx context.Context, arg DeleteDokumentZitateParams) error
 	DeleteDrucksache(ctx context.Context, id string) error
+	DeleteDrucksacheAutorAnzeigen(ctx context.Context, drucksacheID string) error
+	DeleteDrucksacheFundstelleUrheber(ctx context.Context, drucksacheID sql.NullString) error
+	DeleteDrucksacheRessorts(ctx context.Context, drucksacheID string) error
 	DeleteDrucksacheText(ctx context.Context, id string) error
+	DeleteDrucksacheUrheber(ctx context.Context, drucksacheID string) error
+	DeleteDrucksacheVorgangsbezuege(ctx context.Context, drucksacheID string) error
+	DeleteInkrafttreten(ctx context.Context, vorgangID string) error
 	DeleteMdbBiographical(ctx context.Context, mdbID string) error
 	DeleteMdbInstitutionMembershipsByWahlperiode(ctx context.Context, mdbWahlperiodeMembershipID int64) error
 	DeleteMdbNames(ctx context.Context, mdbID string) error
 	DeleteMdbPerson(ctx context.Context, id string) error
 	DeleteMdbWahlperiodeMemberships(ctx context.Context, mdbID string) error
+	// Removes a review entry without decision once the speaker was resolved automatically
+	DeleteOpenSprecherReview(ctx context.Context, arg DeleteOpenSprecherReviewParams) error
 	DeletePerson(ctx context.Context, id string) error
 	DeletePersonMdbLink(ctx context.Context, arg DeletePersonMdbLinkParams) error
+	DeletePersonRoleWahlperioden(ctx context.Context, personID string) error
+	DeletePersonRoles(ctx context.Context, personID string) error
 	DeletePersonWahlperioden(ctx context.Context, personID string) error
 	DeletePlenarprotokoll(ctx context.Context, id string) error
+	DeletePlenarprotokollFundstelleUrheber(ctx context.Context, plenarprotokollID sql.NullString) error
 	DeletePlenarprotokollText(ctx context.Context, id string) error
+	DeletePlenarprotokollVorgangsbezuege(ctx context.Context, plenarprotokollID string) error
+	DeletePlenarprotokollXML(ctx context.Context, dokumentnummer string) error
+	DeletePlenarprotokollXMLAbsaetze(ctx context.Context, dokumentnummer string) error
+	DeletePlenarprotokollXMLReden(ctx context.Context, dokumentnummer string) error
+	DeletePlenarprotokollXMLRedner(ctx context.Context, dokumentnummer string) error
+	DeletePlenarprotokollXMLTagesordnungspunkte(ctx context.Context, dokumentnummer string) error
+	DeleteRedeReaktionen(ctx context.Context, plenarprotokollID string) error
+	DeleteReden(ctx context.Context, plenarprotokollID string) error
+	DeleteUeberweisungen(ctx context.Context, vorgangspositionID string) error
+	DeleteVerkuendungen(ctx context.Context, vorgangID string) error
 	DeleteVorgang(ctx context.Context, id string) error
+	DeleteVorgangDeskriptoren(ctx context.Context, vorgangID string) error
+	DeleteVorgangInitiativen(ctx context.Context, vorgangID string) error
+	DeleteVorgangSachgebiete(ctx context.Context, vorgangID string) error
+	DeleteVorgangVerlinkungen(ctx context.Context, sourceVorgangID string) error
+	DeleteVorgangZustimmungsbeduerftigkeiten(ctx context.Context, vorgangID string) error
 	DeleteVorgangsposition(ctx context.Context, id string) error
+	DeleteVorgangspositionMitberaten(ctx context.Context, vorgangspositionID string) error
+	DeleteVorgangspositionRessorts(ctx context.Context, vorgangspositionID string) error
+	DeleteVorgangspositionUrheber(ctx context.Context, vorgangspositionID string) error
 	// SQLite version - uses json_object instead of jsonb_build_object, no FILTER clause
 	GetAktivitaet(ctx context.Context, id string) (Aktivitaet, error)
+	// Returns the latest previous version that was current at the given time
+	GetAktivitaetHistoryAsOf(ctx context.Context, arg GetAktivitaetHistoryAsOfParams) (AktivitaetHistory, error)
 	GetAktivitaetWithDeskriptor(ctx context.Context, id string) ([]GetAktivitaetWithDeskriptorRow, error)
 	GetDrucksache(ctx context.Context, id string) (Drucksache, error)
+	// Returns the latest previous version that was current at the given time
+	GetDrucksacheHistoryAsOf(ctx context.Context, arg GetDrucksacheHistoryAsOfParams) (DrucksacheHistory, error)
 	GetDrucksacheText(ctx context.Context, id string) (GetDrucksacheTextRow, error)
+	GetDrucksacheTextContent(ctx context.Context, id string) (sql.NullString, error)
 	GetDrucksacheWithRelations(ctx context.Context, id string) ([]GetDrucksacheWithRelationsRow, error)
 	GetFundstelleUrheberByDrucksache(ctx context.Context, drucksacheID sql.NullString) ([]FundstelleUrheber, error)
 	GetFundstelleUrheberByPlenarprotokoll(ctx context.Context, plenarprotokollID sql.NullString) ([]FundstelleUrheber, error)
@@ -149,41 +207,115 @@ type Querier interface {
 	GetOrCreateUrheber(ctx context.Context, arg GetOrCreateUrheberParams) (Urheber, error)
 	GetOrCreateWahlperiode(ctx context.Context, nummer int64) (Wahlperiode, error)
 	GetPerson(ctx context.Context, id string) (Person, error)
+	// Returns the latest previous version that was current at the given time
+	GetPersonHistoryAsOf(ctx context.Context, arg GetPersonHistoryAsOfParams) (PersonHistory, error)
 	GetPersonMdbLink(ctx context.Context, arg GetPersonMdbLinkParams) (PersonMdbLink, error)
 	GetPersonMdbLinks(ctx context.Context, personID string) ([]PersonMdbLink, error)
 	GetPersonWahlperioden(ctx context.Context, personID string) ([]int64, error)
 	GetPersonWithRoles(ctx context.Context, id string) ([]GetPersonWithRolesRow, error)
 	GetPlenarprotokoll(ctx context.Context, id string) (Plenarprotokoll, error)
+	// Returns the latest previous version that was current at the given time
+	GetPlenarprotokollHistoryAsOf(ctx context.Context, arg GetPlenarprotokollHistoryAsOfParams) (PlenarprotokollHistory, error)
+	GetPlenarprotokollIDByDokumentnummer(ctx context.Context, dokumentnummer string) (string, error)
 	GetPlenarprotokollText(ctx context.Context, id string) (GetPlenarprotokollTextRow, error)
+	GetPlenarprotokollTextContent(ctx context.Context, id string) (sql.NullString, error)
 	GetPlenarprotokollWithVorgangsbezug(ctx context.Context, id string) ([]GetPlenarprotokollWithVorgangsbezugRow, error)
+	GetPlenarprotokollXMLHash(ctx context.Context, dokumentnummer string) (string, error)
 	GetRessortByTitle(ctx context.Context, titel string) (Ressort, error)
 	GetUnlinkedDIPPersons(ctx context.Context, arg GetUnlinkedDIPPersonsParams) ([]GetUnlinkedDIPPersonsRow, error)
 	GetUnlinkedMdBPersons(ctx context.Context, arg GetUnlinkedMdBPersonsParams) ([]GetUnlinkedMdBPersonsRow, error)
 	GetUrheberByDesignationAndTitle(ctx context.Context, arg GetUrheberByDesignationAndTitleParams) (Urheber, error)
 	GetVorgang(ctx context.Context, id string) (Vorgang, error)
+	// Returns the latest previous version that was current at the given time
+	GetVorgangHistoryAsOf(ctx context.Context, arg GetVorgangHistoryAsOfParams) (VorgangHistory, error)
 	GetVorgangWithDeskriptor(ctx context.Context, id string) ([]GetVorgangWithDeskriptorRow, error)
 	GetVorgangWithInitiative(ctx context.Context, id string) ([]GetVorgangWithInitiativeRow, error)
 	GetVorgangWithSachgebiet(ctx context.Context, id string) ([]GetVorgangWithSachgebietRow, error)
 	GetVorgangsposition(ctx context.Context, id string) (Vorgangsposition, error)
+	// Returns the latest previous version that was current at the given time
+	GetVorgangspositionHistoryAsOf(ctx context.Context, arg GetVorgangspositionHistoryAsOfParams) (VorgangspositionHistory, error)
 	GetVorgangspositionWithAktivitaet(ctx context.Context, id string) ([]GetVorgangspositionWithAktivitaetRow, error)
 	GetVorgangspositionWithBeschlussfassung(ctx context.Context, id string) ([]GetVorgangspositionWithBeschlussfassungRow, error)
 	GetVorgangspositionWithRessort(ctx context.Context, id string) ([]GetVorgangspositionWithRessortRow, error)
 	GetVorgangspositionWithUeberweisung(ctx context.Context, id string) ([]GetVorgangspositionWithUeberweisungRow, error)
 	GetVorgangspositionWithUrheber(ctx context.Context, id string) ([]GetVorgangspositionWithUrheberRow, error)
+	ListAktivitaetHistory(ctx context.Context, aktivitaetID string) ([]AktivitaetHistory, error)
+	ListAktivitaetIDsByWahlperiode(ctx context.Context, wahlperiode int64) ([]string, error)
 	ListAktivitaeten(ctx context.Context, arg ListAktivitaetenParams) ([]Aktivitaet, error)
+	ListAllRedenProtokolle(ctx context.Context) ([]ListAllRedenProtokolleRow, error)
 	ListBundeslaender(ctx context.Context) ([]Bundesland, error)
+	ListDanglingAktivitaetFundstelleDrucksache(ctx context.Context) ([]string, error)
+	ListDanglingAktivitaetFundstellePlenarprotokoll(ctx context.Context) ([]string, error)
+	ListDanglingAutorAnzeigePerson(ctx context.Context) ([]string, error)
+	ListDanglingMitberatenVorgang(ctx context.Context) ([]string, error)
+	ListDanglingVerlinkungVorgang(ctx context.Context) ([]string, error)
+	ListDanglingVorgangsbezugAktivitaet(ctx context.Context) ([]string, error)
+	// Queries listing referenced IDs that are missing from their parent table.
+	// Used to backfill entities that are referenced but were never synced.
+	ListDanglingVorgangsbezugDrucksache(ctx context.Context) ([]string, error)
+	ListDanglingVorgangsbezugPlenarprotokoll(ctx context.Context) ([]string, error)
+	ListDanglingVorgangspositionFundstelleDrucksache(ctx context.Context) ([]string, error)
+	ListDanglingVorgangspositionFundstellePlenarprotokoll(ctx context.Context) ([]string, error)
+	ListDanglingVorgangspositionVorgang(ctx context.Context) ([]string, error)
+	ListDokumentPdfs(ctx context.Context) ([]DokumentPdf, error)
+	ListDrucksacheHistory(ctx context.Context, drucksacheID string) ([]DrucksacheHistory, error)
+	ListDrucksacheIDsByWahlperiode(ctx context.Context, wahlperiode sql.NullInt64) ([]string, error)
 	ListDrucksacheTexte(ctx context.Context, arg ListDrucksacheTexteParams) ([]ListDrucksacheTexteRow, error)
 	ListDrucksachen(ctx context.Context, arg ListDrucksachenParams) ([]Drucksache, error)
 	ListMdbPersons(ctx context.Context, arg ListMdbPersonsParams) ([]MdbPerson, error)
 	ListMdbStammdatenVersions(ctx context.Context) ([]MdbStammdatenVersion, error)
+	// PDF archive (sync-pdfs)
+	// PDFs of Drucksachen and Plenarprotokolle that are not stored yet, one row per pdf_hash.
+	// drucksachetyp only matches Drucksachen; PDFs that failed before are skipped unless retry_failed.
+	ListPendingPdfs(ctx context.Context, arg ListPendingPdfsParams) ([]ListPendingPdfsRow, error)
+	// Structured Plenarprotokoll XML (sync-plenarprotokoll-xml)
+	// Plenarprotokolle with an XML URL that were not loaded yet, from any source
+	ListPendingPlenarprotokollXML(ctx context.Context, arg ListPendingPlenarprotokollXMLParams) ([]ListPendingPlenarprotokollXMLRow, error)
+	// Plenarprotokolle whose text was not parsed yet, changed since, or was parsed with an older parser
+	ListPendingRedenProtokolle(ctx context.Context, parserVersion int64) ([]ListPendingRedenProtokolleRow, error)
+	// Citation graph (process-dokument-zitate)
+	// Drucksache and Plenarprotokoll texts that were not processed yet, changed since,
+	// or were processed with an older parser (all_texte: every text)
+	ListPendingZitatTexte(ctx context.Context, arg ListPendingZitatTexteParams) ([]ListPendingZitatTexteRow, error)
+	ListPersonHistory(ctx context.Context, personID string) ([]PersonHistory, error)
+	// Queries used to detect records that were removed from the DIP API.
+	ListPersonIDsByWahlperiode(ctx context.Context, wahlperiodeNummer int64) ([]string, error)
 	ListPersonen(ctx context.Context, arg ListPersonenParams) ([]Person, error)
+	ListPlenarprotokollHistory(ctx context.Context, plenarprotokollID string) ([]PlenarprotokollHistory, error)
+	ListPlenarprotokollIDsByWahlperiode(ctx context.Context, wahlperiode sql.NullInt64) ([]string, error)
 	ListPlenarprotokollTexte(ctx context.Context, arg ListPlenarprotokollTexteParams) ([]ListPlenarprotokollTexteRow, error)
 	ListPlenarprotokolle(ctx context.Context, arg ListPlenarprotokolleParams) ([]Plenarprotokoll, error)
+	// Zurufe of members whose person is resolved again by process-plenarprotokoll-reden -resolve-only
+	ListRedeReaktionenForSprecher(ctx context.Context, allReden int64) ([]ListRedeReaktionenForSprecherRow, error)
+	// Reden whose speaker is resolved again by process-plenarprotokoll-reden -resolve-only
+	ListRedenForSprecher(ctx context.Context, allReden int64) ([]ListRedenForSprecherRow, error)
 	ListRessorts(ctx context.Context) ([]Ressort, error)
+	ListSprecherMdbFraktionen(ctx context.Context) ([]ListSprecherMdbFraktionenRow, error)
+	ListSprecherMdbMitgliedschaften(ctx context.Context) ([]ListSprecherMdbMitgliedschaftenRow, error)
+	// Data of the speaker resolver (internal/sprecher)
+	ListSprecherMdbNamen(ctx context.Context) ([]ListSprecherMdbNamenRow, error)
+	ListSprecherPersonMdbLinks(ctx context.Context) ([]ListSprecherPersonMdbLinksRow, error)
+	ListSprecherPersonen(ctx context.Context) ([]ListSprecherPersonenRow, error)
+	ListSprecherReviewEntscheidungen(ctx context.Context) ([]ListSprecherReviewEntscheidungenRow, error)
 	ListUrheber(ctx context.Context) ([]Urheber, error)
 	ListVorgaenge(ctx context.Context, arg ListVorgaengeParams) ([]Vorgang, error)
+	ListVorgangHistory(ctx context.Context, vorgangID string) ([]VorgangHistory, error)
+	ListVorgangIDsByWahlperiode(ctx context.Context, wahlperiode int64) ([]string, error)
+	ListVorgangspositionHistory(ctx context.Context, vorgangspositionID string) ([]VorgangspositionHistory, error)
+	ListVorgangspositionIDsByWahlperiode(ctx context.Context, wahlperiode int64) ([]string, error)
 	ListVorgangspositionen(ctx context.Context, arg ListVorgangspositionenParams) ([]Vorgangsposition, error)
 	ListWahlperioden(ctx context.Context) ([]Wahlperiode, error)
+	MarkAktivitaetDeleted(ctx context.Context, arg MarkAktivitaetDeletedParams) error
+	MarkDrucksacheDeleted(ctx context.Context, arg MarkDrucksacheDeletedParams) error
+	MarkPersonDeleted(ctx context.Context, arg MarkPersonDeletedParams) error
+	MarkPlenarprotokollDeleted(ctx context.Context, arg MarkPlenarprotokollDeletedParams) error
+	MarkVorgangDeleted(ctx context.Context, arg MarkVorgangDeletedParams) error
+	MarkVorgangspositionDeleted(ctx context.Context, arg MarkVorgangspositionDeletedParams) error
+	RecordDokumentPdfFehler(ctx context.Context, arg RecordDokumentPdfFehlerParams) error
+	// Resolves cited Drucksachen that were not synced when the citing text was processed
+	ResolveDrucksacheZitate(ctx context.Context) (int64, error)
+	// Resolves cited Plenarprotokolle that were not synced when the citing text was processed
+	ResolvePlenarprotokollZitate(ctx context.Context) (int64, error)
 	// ============================================================================
 	// SEARCH AND LOOKUP QUERIES
 	// ============================================================================
@@ -196,8 +328,20 @@ type Querier interface {
 	UpdatePersonMdbLinkConfidence(ctx context.Context, arg UpdatePersonMdbLinkConfidenceParams) error
 	UpdatePlenarprotokoll(ctx context.Context, arg UpdatePlenarprotokollParams) (Plenarprotokoll, error)
 	UpdatePlenarprotokollText(ctx context.Context, arg UpdatePlenarprotokollTextParams) (PlenarprotokollText, error)
+	UpdateRedeReaktionSprecher(ctx context.Context, arg UpdateRedeReaktionSprecherParams) error
+	UpdateRedeSprecher(ctx context.Context, arg UpdateRedeSprecherParams) error
 	UpdateVorgang(ctx context.Context, arg UpdateVorgangParams) (Vorgang, error)
 	UpdateVorgangsposition(ctx context.Context, arg UpdateVorgangspositionParams) (Vorgangsposition, error)
+	UpsertAktivitaet(ctx context.Context, arg UpsertAktivitaetParams) error
+	UpsertDokumentPdf(ctx context.Context, arg UpsertDokumentPdfParams) error
+	UpsertDokumentZitatVerarbeitung(ctx context.Context, arg UpsertDokumentZitatVerarbeitungParams) error
+	UpsertDrucksache(ctx context.Context, arg UpsertDrucksacheParams) error
+	UpsertPerson(ctx context.Context, arg UpsertPersonParams) error
+	UpsertPlenarprotokoll(ctx context.Context, arg UpsertPlenarprotokollParams) error
+	UpsertRedeVerarbeitung(ctx context.Context, arg UpsertRedeVerarbeitungParams) error
+	UpsertSprecherReview(ctx context.Context, arg UpsertSprecherReviewParams) error
+	UpsertVorgang(ctx context.Context, arg UpsertVorgangParams) error
+	UpsertVorgangsposition(ctx context.Context, arg UpsertVorgangspositionParams) error
 	VerifyPersonMdbLink(ctx context.Context, arg VerifyPersonMdbLinkParams) error
 }
 
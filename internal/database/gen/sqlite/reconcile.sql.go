@@ -0,0 +1,275 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: reconcile.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const listAktivitaetIDsByWahlperiode = `-- name: ListAktivitaetIDsByWahlperiode :many
+SELECT id FROM aktivitaet
+WHERE wahlperiode = ? AND deleted_at IS NULL
+ORDER BY id
+`
+
+func (q *Queries) ListAktivitaetIDsByWahlperiode(ctx context.Context, wahlperiode int64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listAktivitaetIDsByWahlperiode, wahlperiode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDrucksacheIDsByWahlperiode = `-- name: ListDrucksacheIDsByWahlperiode :many
+SELECT id FROM drucksache
+WHERE wahlperiode = ? AND deleted_at IS NULL
+ORDER BY id
+`
+
+func (q *Queries) ListDrucksacheIDsByWahlperiode(ctx context.Context, wahlperiode sql.NullInt64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listDrucksacheIDsByWahlperiode, wahlperiode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPersonIDsByWahlperiode = `-- name: ListPersonIDsByWahlperiode :many
+
+SELECT p.id
+FROM person p
+JOIN person_wahlperiode pw ON pw.person_id = p.id
+WHERE pw.wahlperiode_nummer = ? AND p.deleted_at IS NULL
+ORDER BY p.id
+`
+
+// Queries used to detect records that were removed from the DIP API.
+func (q *Queries) ListPersonIDsByWahlperiode(ctx context.Context, wahlperiodeNummer int64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listPersonIDsByWahlperiode, wahlperiodeNummer)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPlenarprotokollIDsByWahlperiode = `-- name: ListPlenarprotokollIDsByWahlperiode :many
+SELECT id FROM plenarprotokoll
+WHERE wahlperiode = ? AND deleted_at IS NULL
+ORDER BY id
+`
+
+func (q *Queries) ListPlenarprotokollIDsByWahlperiode(ctx context.Context, wahlperiode sql.NullInt64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listPlenarprotokollIDsByWahlperiode, wahlperiode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listVorgangIDsByWahlperiode = `-- name: ListVorgangIDsByWahlperiode :many
+SELECT id FROM vorgang
+WHERE wahlperiode = ? AND deleted_at IS NULL
+ORDER BY id
+`
+
+func (q *Queries) ListVorgangIDsByWahlperiode(ctx context.Context, wahlperiode int64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listVorgangIDsByWahlperiode, wahlperiode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listVorgangspositionIDsByWahlperiode = `-- name: ListVorgangspositionIDsByWahlperiode :many
+SELECT vp.id
+FROM vorgangsposition vp
+JOIN vorgang v ON v.id = vp.vorgang_id
+WHERE v.wahlperiode = ? AND vp.deleted_at IS NULL
+ORDER BY vp.id
+`
+
+func (q *Queries) ListVorgangspositionIDsByWahlperiode(ctx context.Context, wahlperiode int64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listVorgangspositionIDsByWahlperiode, wahlperiode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markAktivitaetDeleted = `-- name: MarkAktivitaetDeleted :exec
+UPDATE aktivitaet SET deleted_at = ? WHERE id = ?
+`
+
+type MarkAktivitaetDeletedParams struct {
+	DeletedAt sql.NullString `json:"deleted_at"`
+	ID        string         `json:"id"`
+}
+
+func (q *Queries) MarkAktivitaetDeleted(ctx context.Context, arg MarkAktivitaetDeletedParams) error {
+	_, err := q.db.ExecContext(ctx, markAktivitaetDeleted, arg.DeletedAt, arg.ID)
+	return err
+}
+
+const markDrucksacheDeleted = `-- name: MarkDrucksacheDeleted :exec
+UPDATE drucksache SET deleted_at = ? WHERE id = ?
+`
+
+type MarkDrucksacheDeletedParams struct {
+	DeletedAt sql.NullString `json:"deleted_at"`
+	ID        string         `json:"id"`
+}
+
+func (q *Queries) MarkDrucksacheDeleted(ctx context.Context, arg MarkDrucksacheDeletedParams) error {
+	_, err := q.db.ExecContext(ctx, markDrucksacheDeleted, arg.DeletedAt, arg.ID)
+	return err
+}
+
+const markPersonDeleted = `-- name: MarkPersonDeleted :exec
+UPDATE person SET deleted_at = ? WHERE id = ?
+`
+
+type MarkPersonDeletedParams struct {
+	DeletedAt sql.NullString `json:"deleted_at"`
+	ID        string         `json:"id"`
+}
+
+func (q *Queries) MarkPersonDeleted(ctx context.Context, arg MarkPersonDeletedParams) error {
+	_, err := q.db.ExecContext(ctx, markPersonDeleted, arg.DeletedAt, arg.ID)
+	return err
+}
+
+const markPlenarprotokollDeleted = `-- name: MarkPlenarprotokollDeleted :exec
+UPDATE plenarprotokoll SET deleted_at = ? WHERE id = ?
+`
+
+type MarkPlenarprotokollDeletedParams struct {
+	DeletedAt sql.NullString `json:"deleted_at"`
+	ID        string         `json:"id"`
+}
+
+func (q *Queries) MarkPlenarprotokollDeleted(ctx context.Context, arg MarkPlenarprotokollDeletedParams) error {
+	_, err := q.db.ExecContext(ctx, markPlenarprotokollDeleted, arg.DeletedAt, arg.ID)
+	return err
+}
+
+const markVorgangDeleted = `-- name: MarkVorgangDeleted :exec
+UPDATE vorgang SET deleted_at = ? WHERE id = ?
+`
+
+type MarkVorgangDeletedParams struct {
+	DeletedAt sql.NullString `json:"deleted_at"`
+	ID        string         `json:"id"`
+}
+
+func (q *Queries) MarkVorgangDeleted(ctx context.Context, arg MarkVorgangDeletedParams) error {
+	_, err := q.db.ExecContext(ctx, markVorgangDeleted, arg.DeletedAt, arg.ID)
+	return err
+}
+
+const markVorgangspositionDeleted = `-- name: MarkVorgangspositionDeleted :exec
+UPDATE vorgangsposition SET deleted_at = ? WHERE id = ?
+`
+
+type MarkVorgangspositionDeletedParams struct {
+	DeletedAt sql.NullString `json:"deleted_at"`
+	ID        string         `json:"id"`
+}
+
+func (q *Queries) MarkVorgangspositionDeleted(ctx context.Context, arg MarkVorgangspositionDeletedParams) error {
+	_, err := q.db.ExecContext(ctx, markVorgangspositionDeleted, arg.DeletedAt, arg.ID)
+	return err
+}
@@ -0,0 +1,381 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: rede.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createRede = `-- name: CreateRede :one
+INSERT INTO rede (
+    plenarprotokoll_id, position, sprecher, rolle, fraktion,
+    tagesordnungspunkt, start_offset, end_offset, text,
+    person_id, mdb_id, sprecher_confidence, sprecher_methode
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id
+`
+
+type CreateRedeParams struct {
+	PlenarprotokollID  string          `json:"plenarprotokoll_id"`
+	Position           int64           `json:"position"`
+	Sprecher           string          `json:"sprecher"`
+	Rolle              sql.NullString  `json:"rolle"`
+	Fraktion           sql.NullString  `json:"fraktion"`
+	Tagesordnungspunkt sql.NullString  `json:"tagesordnungspunkt"`
+	StartOffset        int64           `json:"start_offset"`
+	EndOffset          int64           `json:"end_offset"`
+	Text               string          `json:"text"`
+	PersonID           sql.NullString  `json:"person_id"`
+	MdbID              sql.NullString  `json:"mdb_id"`
+	SprecherConfidence sql.NullFloat64 `json:"sprecher_confidence"`
+	SprecherMethode    sql.NullString  `json:"sprecher_methode"`
+}
+
+func (q *Queries) CreateRede(ctx context.Context, arg CreateRedeParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createRede,
+		arg.PlenarprotokollID,
+		arg.Position,
+		arg.Sprecher,
+		arg.Rolle,
+		arg.Fraktion,
+		arg.Tagesordnungspunkt,
+		arg.StartOffset,
+		arg.EndOffset,
+		arg.Text,
+		arg.PersonID,
+		arg.MdbID,
+		arg.SprecherConfidence,
+		arg.SprecherMethode,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createRedeReaktion = `-- name: CreateRedeReaktion :exec
+INSERT INTO rede_reaktion (
+    rede_id, plenarprotokoll_id, position, kommentar, art, fraktion, abgeordnete,
+    sprecher, person_id, mdb_id, text, kommentar_offset, roh
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateRedeReaktionParams struct {
+	RedeID            int64          `json:"rede_id"`
+	PlenarprotokollID string         `json:"plenarprotokoll_id"`
+	Position          int64          `json:"position"`
+	Kommentar         int64          `json:"kommentar"`
+	Art               string         `json:"art"`
+	Fraktion          sql.NullString `json:"fraktion"`
+	Abgeordnete       int64          `json:"abgeordnete"`
+	Sprecher          sql.NullString `json:"sprecher"`
+	PersonID          sql.NullString `json:"person_id"`
+	MdbID             sql.NullString `json:"mdb_id"`
+	Text              sql.NullString `json:"text"`
+	KommentarOffset   int64          `json:"kommentar_offset"`
+	Roh               string         `json:"roh"`
+}
+
+func (q *Queries) CreateRedeReaktion(ctx context.Context, arg CreateRedeReaktionParams) error {
+	_, err := q.db.ExecContext(ctx, createRedeReaktion,
+		arg.RedeID,
+		arg.PlenarprotokollID,
+		arg.Position,
+		arg.Kommentar,
+		arg.Art,
+		arg.Fraktion,
+		arg.Abgeordnete,
+		arg.Sprecher,
+		arg.PersonID,
+		arg.MdbID,
+		arg.Text,
+		arg.KommentarOffset,
+		arg.Roh,
+	)
+	return err
+}
+
+const deleteRedeReaktionen = `-- name: DeleteRedeReaktionen :exec
+DELETE FROM rede_reaktion WHERE plenarprotokoll_id = ?
+`
+
+func (q *Queries) DeleteRedeReaktionen(ctx context.Context, plenarprotokollID string) error {
+	_, err := q.db.ExecContext(ctx, deleteRedeReaktionen, plenarprotokollID)
+	return err
+}
+
+const deleteReden = `-- name: DeleteReden :exec
+DELETE FROM rede WHERE plenarprotokoll_id = ?
+`
+
+func (q *Queries) DeleteReden(ctx context.Context, plenarprotokollID string) error {
+	_, err := q.db.ExecContext(ctx, deleteReden, plenarprotokollID)
+	return err
+}
+
+const getPlenarprotokollTextContent = `-- name: GetPlenarprotokollTextContent :one
+SELECT text FROM plenarprotokoll_text WHERE id = ?
+`
+
+func (q *Queries) GetPlenarprotokollTextContent(ctx context.Context, id string) (sql.NullString, error) {
+	row := q.db.QueryRowContext(ctx, getPlenarprotokollTextContent, id)
+	var text sql.NullString
+	err := row.Scan(&text)
+	return text, err
+}
+
+const listAllRedenProtokolle = `-- name: ListAllRedenProtokolle :many
+SELECT pt.id, pt.updated_at, p.datum, p.wahlperiode
+FROM plenarprotokoll_text pt
+LEFT JOIN plenarprotokoll p ON p.id = pt.id
+WHERE pt.text IS NOT NULL
+ORDER BY pt.id
+`
+
+type ListAllRedenProtokolleRow struct {
+	ID          string         `json:"id"`
+	UpdatedAt   string         `json:"updated_at"`
+	Datum       sql.NullString `json:"datum"`
+	Wahlperiode sql.NullInt64  `json:"wahlperiode"`
+}
+
+func (q *Queries) ListAllRedenProtokolle(ctx context.Context) ([]ListAllRedenProtokolleRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAllRedenProtokolle)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAllRedenProtokolleRow
+	for rows.Next() {
+		var i ListAllRedenProtokolleRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UpdatedAt,
+			&i.Datum,
+			&i.Wahlperiode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingRedenProtokolle = `-- name: ListPendingRedenProtokolle :many
+SELECT pt.id, pt.updated_at, p.datum, p.wahlperiode
+FROM plenarprotokoll_text pt
+LEFT JOIN plenarprotokoll p ON p.id = pt.id
+LEFT JOIN rede_verarbeitung rv ON rv.plenarprotokoll_id = pt.id
+WHERE pt.text IS NOT NULL
+  AND (rv.plenarprotokoll_id IS NULL
+       OR rv.parser_version < ?1
+       OR rv.text_updated_at <> pt.updated_at)
+ORDER BY pt.id
+`
+
+type ListPendingRedenProtokolleRow struct {
+	ID          string         `json:"id"`
+	UpdatedAt   string         `json:"updated_at"`
+	Datum       sql.NullString `json:"datum"`
+	Wahlperiode sql.NullInt64  `json:"wahlperiode"`
+}
+
+// Plenarprotokolle whose text was not parsed yet, changed since, or was parsed with an older parser
+func (q *Queries) ListPendingRedenProtokolle(ctx context.Context, parserVersion int64) ([]ListPendingRedenProtokolleRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingRedenProtokolle, parserVersion)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPendingRedenProtokolleRow
+	for rows.Next() {
+		var i ListPendingRedenProtokolleRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UpdatedAt,
+			&i.Datum,
+			&i.Wahlperiode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRedeReaktionenForSprecher = `-- name: ListRedeReaktionenForSprecher :many
+SELECT rr.id, rr.sprecher, rr.fraktion, p.datum, p.wahlperiode
+FROM rede_reaktion rr
+LEFT JOIN plenarprotokoll p ON p.id = rr.plenarprotokoll_id
+WHERE rr.sprecher IS NOT NULL
+  AND (CAST(?1 AS INTEGER) = 1 OR rr.person_id IS NULL OR rr.mdb_id IS NULL)
+ORDER BY rr.id
+`
+
+type ListRedeReaktionenForSprecherRow struct {
+	ID          int64          `json:"id"`
+	Sprecher    sql.NullString `json:"sprecher"`
+	Fraktion    sql.NullString `json:"fraktion"`
+	Datum       sql.NullString `json:"datum"`
+	Wahlperiode sql.NullInt64  `json:"wahlperiode"`
+}
+
+// Zurufe of members whose person is resolved again by process-plenarprotokoll-reden -resolve-only
+func (q *Queries) ListRedeReaktionenForSprecher(ctx context.Context, allReden int64) ([]ListRedeReaktionenForSprecherRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRedeReaktionenForSprecher, allReden)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRedeReaktionenForSprecherRow
+	for rows.Next() {
+		var i ListRedeReaktionenForSprecherRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Sprecher,
+			&i.Fraktion,
+			&i.Datum,
+			&i.Wahlperiode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRedenForSprecher = `-- name: ListRedenForSprecher :many
+SELECT r.id, r.sprecher, r.rolle, r.fraktion, p.datum, p.wahlperiode
+FROM rede r
+LEFT JOIN plenarprotokoll p ON p.id = r.plenarprotokoll_id
+WHERE CAST(?1 AS INTEGER) = 1 OR r.person_id IS NULL OR r.mdb_id IS NULL
+ORDER BY r.id
+`
+
+type ListRedenForSprecherRow struct {
+	ID          int64          `json:"id"`
+	Sprecher    string         `json:"sprecher"`
+	Rolle       sql.NullString `json:"rolle"`
+	Fraktion    sql.NullString `json:"fraktion"`
+	Datum       sql.NullString `json:"datum"`
+	Wahlperiode sql.NullInt64  `json:"wahlperiode"`
+}
+
+// Reden whose speaker is resolved again by process-plenarprotokoll-reden -resolve-only
+func (q *Queries) ListRedenForSprecher(ctx context.Context, allReden int64) ([]ListRedenForSprecherRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRedenForSprecher, allReden)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRedenForSprecherRow
+	for rows.Next() {
+		var i ListRedenForSprecherRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Sprecher,
+			&i.Rolle,
+			&i.Fraktion,
+			&i.Datum,
+			&i.Wahlperiode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateRedeReaktionSprecher = `-- name: UpdateRedeReaktionSprecher :exec
+UPDATE rede_reaktion SET person_id = ?, mdb_id = ? WHERE id = ?
+`
+
+type UpdateRedeReaktionSprecherParams struct {
+	PersonID sql.NullString `json:"person_id"`
+	MdbID    sql.NullString `json:"mdb_id"`
+	ID       int64          `json:"id"`
+}
+
+func (q *Queries) UpdateRedeReaktionSprecher(ctx context.Context, arg UpdateRedeReaktionSprecherParams) error {
+	_, err := q.db.ExecContext(ctx, updateRedeReaktionSprecher, arg.PersonID, arg.MdbID, arg.ID)
+	return err
+}
+
+const updateRedeSprecher = `-- name: UpdateRedeSprecher :exec
+UPDATE rede
+SET person_id = ?, mdb_id = ?, sprecher_confidence = ?, sprecher_methode = ?
+WHERE id = ?
+`
+
+type UpdateRedeSprecherParams struct {
+	PersonID           sql.NullString  `json:"person_id"`
+	MdbID              sql.NullString  `json:"mdb_id"`
+	SprecherConfidence sql.NullFloat64 `json:"sprecher_confidence"`
+	SprecherMethode    sql.NullString  `json:"sprecher_methode"`
+	ID                 int64           `json:"id"`
+}
+
+func (q *Queries) UpdateRedeSprecher(ctx context.Context, arg UpdateRedeSprecherParams) error {
+	_, err := q.db.ExecContext(ctx, updateRedeSprecher,
+		arg.PersonID,
+		arg.MdbID,
+		arg.SprecherConfidence,
+		arg.SprecherMethode,
+		arg.ID,
+	)
+	return err
+}
+
+const upsertRedeVerarbeitung = `-- name: UpsertRedeVerarbeitung :exec
+INSERT INTO rede_verarbeitung (plenarprotokoll_id, parser_version, text_updated_at, reden_anzahl)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (plenarprotokoll_id) DO UPDATE
+SET parser_version = excluded.parser_version,
+    text_updated_at = excluded.text_updated_at,
+    reden_anzahl = excluded.reden_anzahl,
+    processed_at = datetime('now')
+`
+
+type UpsertRedeVerarbeitungParams struct {
+	PlenarprotokollID string `json:"plenarprotokoll_id"`
+	ParserVersion     int64  `json:"parser_version"`
+	TextUpdatedAt     string `json:"text_updated_at"`
+	RedenAnzahl       int64  `json:"reden_anzahl"`
+}
+
+func (q *Queries) UpsertRedeVerarbeitung(ctx context.Context, arg UpsertRedeVerarbeitungParams) error {
+	_, err := q.db.ExecContext(ctx, upsertRedeVerarbeitung,
+		arg.PlenarprotokollID,
+		arg.ParserVersion,
+		arg.TextUpdatedAt,
+		arg.RedenAnzahl,
+	)
+	return err
+}
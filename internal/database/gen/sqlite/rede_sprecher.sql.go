@@ -0,0 +1,314 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: rede_sprecher.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const deleteOpenSprecherReview = `-- name: DeleteOpenSprecherReview :exec
+DELETE FROM rede_sprecher_review
+WHERE sprecher = ? AND fraktion = ? AND person_id IS NULL AND mdb_id IS NULL
+`
+
+type DeleteOpenSprecherReviewParams struct {
+	Sprecher string `json:"sprecher"`
+	Fraktion string `json:"fraktion"`
+}
+
+// Removes a review entry without decision once the speaker was resolved automatically
+func (q *Queries) DeleteOpenSprecherReview(ctx context.Context, arg DeleteOpenSprecherReviewParams) error {
+	_, err := q.db.ExecContext(ctx, deleteOpenSprecherReview, arg.Sprecher, arg.Fraktion)
+	return err
+}
+
+const listSprecherMdbFraktionen = `-- name: ListSprecherMdbFraktionen :many
+SELECT mwm.mdb_id, mwm.wp, mim.ins_lang, mim.mdbins_von, mim.mdbins_bis
+FROM mdb_institution_membership mim
+JOIN mdb_wahlperiode_membership mwm ON mwm.id = mim.mdb_wahlperiode_membership_id
+WHERE mim.insart_lang LIKE 'Fraktion%'
+`
+
+type ListSprecherMdbFraktionenRow struct {
+	MdbID     string         `json:"mdb_id"`
+	Wp        int64          `json:"wp"`
+	InsLang   string         `json:"ins_lang"`
+	MdbinsVon sql.NullString `json:"mdbins_von"`
+	MdbinsBis sql.NullString `json:"mdbins_bis"`
+}
+
+func (q *Queries) ListSprecherMdbFraktionen(ctx context.Context) ([]ListSprecherMdbFraktionenRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSprecherMdbFraktionen)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSprecherMdbFraktionenRow
+	for rows.Next() {
+		var i ListSprecherMdbFraktionenRow
+		if err := rows.Scan(
+			&i.MdbID,
+			&i.Wp,
+			&i.InsLang,
+			&i.MdbinsVon,
+			&i.MdbinsBis,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSprecherMdbMitgliedschaften = `-- name: ListSprecherMdbMitgliedschaften :many
+SELECT mdb_id, wp, mdbwp_von, mdbwp_bis
+FROM mdb_wahlperiode_membership
+`
+
+type ListSprecherMdbMitgliedschaftenRow struct {
+	MdbID    string         `json:"mdb_id"`
+	Wp       int64          `json:"wp"`
+	MdbwpVon string         `json:"mdbwp_von"`
+	MdbwpBis sql.NullString `json:"mdbwp_bis"`
+}
+
+func (q *Queries) ListSprecherMdbMitgliedschaften(ctx context.Context) ([]ListSprecherMdbMitgliedschaftenRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSprecherMdbMitgliedschaften)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSprecherMdbMitgliedschaftenRow
+	for rows.Next() {
+		var i ListSprecherMdbMitgliedschaftenRow
+		if err := rows.Scan(
+			&i.MdbID,
+			&i.Wp,
+			&i.MdbwpVon,
+			&i.MdbwpBis,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSprecherMdbNamen = `-- name: ListSprecherMdbNamen :many
+
+SELECT mn.mdb_id, mn.vorname, mn.nachname, mn.praefix, mn.adel, mn.historie_von, mn.historie_bis, mb.partei_kurz
+FROM mdb_name mn
+LEFT JOIN mdb_biographical mb ON mb.mdb_id = mn.mdb_id
+`
+
+type ListSprecherMdbNamenRow struct {
+	MdbID       string         `json:"mdb_id"`
+	Vorname     string         `json:"vorname"`
+	Nachname    string         `json:"nachname"`
+	Praefix     sql.NullString `json:"praefix"`
+	Adel        sql.NullString `json:"adel"`
+	HistorieVon sql.NullString `json:"historie_von"`
+	HistorieBis sql.NullString `json:"historie_bis"`
+	ParteiKurz  sql.NullString `json:"partei_kurz"`
+}
+
+// Data of the speaker resolver (internal/sprecher)
+func (q *Queries) ListSprecherMdbNamen(ctx context.Context) ([]ListSprecherMdbNamenRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSprecherMdbNamen)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSprecherMdbNamenRow
+	for rows.Next() {
+		var i ListSprecherMdbNamenRow
+		if err := rows.Scan(
+			&i.MdbID,
+			&i.Vorname,
+			&i.Nachname,
+			&i.Praefix,
+			&i.Adel,
+			&i.HistorieVon,
+			&i.HistorieBis,
+			&i.ParteiKurz,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSprecherPersonMdbLinks = `-- name: ListSprecherPersonMdbLinks :many
+SELECT person_id, mdb_id
+FROM person_mdb_link
+ORDER BY CASE match_confidence WHEN 'manual' THEN 0 WHEN 'exact' THEN 1 WHEN 'high' THEN 2 WHEN 'medium' THEN 3 ELSE 4 END
+`
+
+type ListSprecherPersonMdbLinksRow struct {
+	PersonID string `json:"person_id"`
+	MdbID    string `json:"mdb_id"`
+}
+
+func (q *Queries) ListSprecherPersonMdbLinks(ctx context.Context) ([]ListSprecherPersonMdbLinksRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSprecherPersonMdbLinks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSprecherPersonMdbLinksRow
+	for rows.Next() {
+		var i ListSprecherPersonMdbLinksRow
+		if err := rows.Scan(&i.PersonID, &i.MdbID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSprecherPersonen = `-- name: ListSprecherPersonen :many
+SELECT p.id, p.vorname, p.nachname,
+       CAST(COALESCE((SELECT group_concat(pw.wahlperiode_nummer) FROM person_wahlperiode pw WHERE pw.person_id = p.id), '') AS TEXT) AS wahlperioden,
+       CAST(COALESCE((SELECT group_concat(DISTINCT pr.fraktion) FROM person_role pr WHERE pr.person_id = p.id AND pr.fraktion IS NOT NULL), '') AS TEXT) AS fraktionen
+FROM person p
+WHERE p.deleted_at IS NULL
+`
+
+type ListSprecherPersonenRow struct {
+	ID           string `json:"id"`
+	Vorname      string `json:"vorname"`
+	Nachname     string `json:"nachname"`
+	Wahlperioden string `json:"wahlperioden"`
+	Fraktionen   string `json:"fraktionen"`
+}
+
+func (q *Queries) ListSprecherPersonen(ctx context.Context) ([]ListSprecherPersonenRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSprecherPersonen)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSprecherPersonenRow
+	for rows.Next() {
+		var i ListSprecherPersonenRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Vorname,
+			&i.Nachname,
+			&i.Wahlperioden,
+			&i.Fraktionen,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSprecherReviewEntscheidungen = `-- name: ListSprecherReviewEntscheidungen :many
+SELECT sprecher, fraktion, person_id, mdb_id
+FROM rede_sprecher_review
+WHERE person_id IS NOT NULL OR mdb_id IS NOT NULL
+`
+
+type ListSprecherReviewEntscheidungenRow struct {
+	Sprecher string         `json:"sprecher"`
+	Fraktion string         `json:"fraktion"`
+	PersonID sql.NullString `json:"person_id"`
+	MdbID    sql.NullString `json:"mdb_id"`
+}
+
+func (q *Queries) ListSprecherReviewEntscheidungen(ctx context.Context) ([]ListSprecherReviewEntscheidungenRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSprecherReviewEntscheidungen)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSprecherReviewEntscheidungenRow
+	for rows.Next() {
+		var i ListSprecherReviewEntscheidungenRow
+		if err := rows.Scan(
+			&i.Sprecher,
+			&i.Fraktion,
+			&i.PersonID,
+			&i.MdbID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertSprecherReview = `-- name: UpsertSprecherReview :exec
+INSERT INTO rede_sprecher_review (sprecher, fraktion, rolle, kandidaten, beste_confidence, beispiel_rede_id)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (sprecher, fraktion) DO UPDATE
+SET rolle = excluded.rolle,
+    kandidaten = excluded.kandidaten,
+    beste_confidence = excluded.beste_confidence,
+    beispiel_rede_id = excluded.beispiel_rede_id,
+    updated_at = datetime('now')
+`
+
+type UpsertSprecherReviewParams struct {
+	Sprecher        string          `json:"sprecher"`
+	Fraktion        string          `json:"fraktion"`
+	Rolle           sql.NullString  `json:"rolle"`
+	Kandidaten      string          `json:"kandidaten"`
+	BesteConfidence sql.NullFloat64 `json:"beste_confidence"`
+	BeispielRedeID  sql.NullInt64   `json:"beispiel_rede_id"`
+}
+
+func (q *Queries) UpsertSprecherReview(ctx context.Context, arg UpsertSprecherReviewParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSprecherReview,
+		arg.Sprecher,
+		arg.Fraktion,
+		arg.Rolle,
+		arg.Kandidaten,
+		arg.BesteConfidence,
+		arg.BeispielRedeID,
+	)
+	return err
+}
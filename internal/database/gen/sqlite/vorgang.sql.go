@@ -154,7 +154,7 @@ INSERT INTO vorgang (
     archiv, beratungsstand, datum, gesta, kom, mitteilung,
     ratsdok, sek, wahlperiode
 ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, titel, vorgangstyp, typ, abstract, aktualisiert, archiv, beratungsstand, datum, gesta, kom, mitteilung, ratsdok, sek, wahlperiode, created_at, updated_at
+RETURNING id, titel, vorgangstyp, typ, abstract, aktualisiert, archiv, beratungsstand, datum, gesta, kom, mitteilung, ratsdok, sek, wahlperiode, created_at, updated_at, deleted_at
 `
 
 type CreateVorgangParams struct {
@@ -212,6 +212,7 @@ func (q *Queries) CreateVorgang(ctx context.Context, arg CreateVorgangParams) (V
 		&i.Wahlperiode,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -339,6 +340,24 @@ func (q *Queries) CreateVorgangZustimmungsbeduerftigkeit(ctx context.Context, ar
 	return err
 }
 
+const deleteInkrafttreten = `-- name: DeleteInkrafttreten :exec
+DELETE FROM inkrafttreten WHERE vorgang_id = ?
+`
+
+func (q *Queries) DeleteInkrafttreten(ctx context.Context, vorgangID string) error {
+	_, err := q.db.ExecContext(ctx, deleteInkrafttreten, vorgangID)
+	return err
+}
+
+const deleteVerkuendungen = `-- name: DeleteVerkuendungen :exec
+DELETE FROM verkuendung WHERE vorgang_id = ?
+`
+
+func (q *Queries) DeleteVerkuendungen(ctx context.Context, vorgangID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVerkuendungen, vorgangID)
+	return err
+}
+
 const deleteVorgang = `-- name: DeleteVorgang :exec
 DELETE FROM vorgang WHERE id = ?
 `
@@ -348,6 +367,51 @@ func (q *Queries) DeleteVorgang(ctx context.Context, id string) error {
 	return err
 }
 
+const deleteVorgangDeskriptoren = `-- name: DeleteVorgangDeskriptoren :exec
+DELETE FROM vorgang_deskriptor WHERE vorgang_id = ?
+`
+
+func (q *Queries) DeleteVorgangDeskriptoren(ctx context.Context, vorgangID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVorgangDeskriptoren, vorgangID)
+	return err
+}
+
+const deleteVorgangInitiativen = `-- name: DeleteVorgangInitiativen :exec
+DELETE FROM vorgang_initiative WHERE vorgang_id = ?
+`
+
+func (q *Queries) DeleteVorgangInitiativen(ctx context.Context, vorgangID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVorgangInitiativen, vorgangID)
+	return err
+}
+
+const deleteVorgangSachgebiete = `-- name: DeleteVorgangSachgebiete :exec
+DELETE FROM vorgang_sachgebiet WHERE vorgang_id = ?
+`
+
+func (q *Queries) DeleteVorgangSachgebiete(ctx context.Context, vorgangID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVorgangSachgebiete, vorgangID)
+	return err
+}
+
+const deleteVorgangVerlinkungen = `-- name: DeleteVorgangVerlinkungen :exec
+DELETE FROM vorgang_verlinkung WHERE source_vorgang_id = ?
+`
+
+func (q *Queries) DeleteVorgangVerlinkungen(ctx context.Context, sourceVorgangID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVorgangVerlinkungen, sourceVorgangID)
+	return err
+}
+
+const deleteVorgangZustimmungsbeduerftigkeiten = `-- name: DeleteVorgangZustimmungsbeduerftigkeiten :exec
+DELETE FROM vorgang_zustimmungsbeduerftigkeit WHERE vorgang_id = ?
+`
+
+func (q *Queries) DeleteVorgangZustimmungsbeduerftigkeiten(ctx context.Context, vorgangID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVorgangZustimmungsbeduerftigkeiten, vorgangID)
+	return err
+}
+
 const getLatestVorgangDatum = `-- name: GetLatestVorgangDatum :one
 SELECT MIN(datum) as datum FROM vorgang
 `
@@ -360,7 +424,7 @@ func (q *Queries) GetLatestVorgangDatum(ctx context.Context) (interface{}, error
 }
 
 const getVorgang = `-- name: GetVorgang :one
-SELECT id, titel, vorgangstyp, typ, abstract, aktualisiert, archiv, beratungsstand, datum, gesta, kom, mitteilung, ratsdok, sek, wahlperiode, created_at, updated_at
+SELECT id, titel, vorgangstyp, typ, abstract, aktualisiert, archiv, beratungsstand, datum, gesta, kom, mitteilung, ratsdok, sek, wahlperiode, created_at, updated_at, deleted_at
 FROM vorgang
 WHERE id = ?
 `
@@ -386,13 +450,14 @@ func (q *Queries) GetVorgang(ctx context.Context, id string) (Vorgang, error) {
 		&i.Wahlperiode,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getVorgangWithDeskriptor = `-- name: GetVorgangWithDeskriptor :many
 SELECT 
-    v.id, v.titel, v.vorgangstyp, v.typ, v.abstract, v.aktualisiert, v.archiv, v.beratungsstand, v.datum, v.gesta, v.kom, v.mitteilung, v.ratsdok, v.sek, v.wahlperiode, v.created_at, v.updated_at,
+    v.id, v.titel, v.vorgangstyp, v.typ, v.abstract, v.aktualisiert, v.archiv, v.beratungsstand, v.datum, v.gesta, v.kom, v.mitteilung, v.ratsdok, v.sek, v.wahlperiode, v.created_at, v.updated_at, v.deleted_at,
     vd.name as deskriptor_name,
     vd.typ as deskriptor_typ,
     vd.fundstelle as deskriptor_fundstelle
@@ -419,6 +484,7 @@ type GetVorgangWithDeskriptorRow struct {
 	Wahlperiode          int64          `json:"wahlperiode"`
 	CreatedAt            string         `json:"created_at"`
 	UpdatedAt            string         `json:"updated_at"`
+	DeletedAt            sql.NullString `json:"deleted_at"`
 	DeskriptorName       sql.NullString `json:"deskriptor_name"`
 	DeskriptorTyp        sql.NullString `json:"deskriptor_typ"`
 	DeskriptorFundstelle sql.NullInt64  `json:"deskriptor_fundstelle"`
@@ -451,6 +517,7 @@ func (q *Queries) GetVorgangWithDeskriptor(ctx context.Context, id string) ([]Ge
 			&i.Wahlperiode,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
 			&i.DeskriptorName,
 			&i.DeskriptorTyp,
 			&i.DeskriptorFundstelle,
@@ -470,7 +537,7 @@ func (q *Queries) GetVorgangWithDeskriptor(ctx context.Context, id string) ([]Ge
 
 const getVorgangWithInitiative = `-- name: GetVorgangWithInitiative :many
 SELECT 
-    v.id, v.titel, v.vorgangstyp, v.typ, v.abstract, v.aktualisiert, v.archiv, v.beratungsstand, v.datum, v.gesta, v.kom, v.mitteilung, v.ratsdok, v.sek, v.wahlperiode, v.created_at, v.updated_at,
+    v.id, v.titel, v.vorgangstyp, v.typ, v.abstract, v.aktualisiert, v.archiv, v.beratungsstand, v.datum, v.gesta, v.kom, v.mitteilung, v.ratsdok, v.sek, v.wahlperiode, v.created_at, v.updated_at, v.deleted_at,
     vi.initiative
 FROM vorgang v
 LEFT JOIN vorgang_initiative vi ON v.id = vi.vorgang_id
@@ -495,6 +562,7 @@ type GetVorgangWithInitiativeRow struct {
 	Wahlperiode    int64          `json:"wahlperiode"`
 	CreatedAt      string         `json:"created_at"`
 	UpdatedAt      string         `json:"updated_at"`
+	DeletedAt      sql.NullString `json:"deleted_at"`
 	Initiative     sql.NullString `json:"initiative"`
 }
 
@@ -525,6 +593,7 @@ func (q *Queries) GetVorgangWithInitiative(ctx context.Context, id string) ([]Ge
 			&i.Wahlperiode,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
 			&i.Initiative,
 		); err != nil {
 			return nil, err
@@ -542,7 +611,7 @@ func (q *Queries) GetVorgangWithInitiative(ctx context.Context, id string) ([]Ge
 
 const getVorgangWithSachgebiet = `-- name: GetVorgangWithSachgebiet :many
 SELECT 
-    v.id, v.titel, v.vorgangstyp, v.typ, v.abstract, v.aktualisiert, v.archiv, v.beratungsstand, v.datum, v.gesta, v.kom, v.mitteilung, v.ratsdok, v.sek, v.wahlperiode, v.created_at, v.updated_at,
+    v.id, v.titel, v.vorgangstyp, v.typ, v.abstract, v.aktualisiert, v.archiv, v.beratungsstand, v.datum, v.gesta, v.kom, v.mitteilung, v.ratsdok, v.sek, v.wahlperiode, v.created_at, v.updated_at, v.deleted_at,
     vs.sachgebiet
 FROM vorgang v
 LEFT JOIN vorgang_sachgebiet vs ON v.id = vs.vorgang_id
@@ -567,6 +636,7 @@ type GetVorgangWithSachgebietRow struct {
 	Wahlperiode    int64          `json:"wahlperiode"`
 	CreatedAt      string         `json:"created_at"`
 	UpdatedAt      string         `json:"updated_at"`
+	DeletedAt      sql.NullString `json:"deleted_at"`
 	Sachgebiet     sql.NullString `json:"sachgebiet"`
 }
 
@@ -597,6 +667,7 @@ func (q *Queries) GetVorgangWithSachgebiet(ctx context.Context, id string) ([]Ge
 			&i.Wahlperiode,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
 			&i.Sachgebiet,
 		); err != nil {
 			return nil, err
@@ -613,7 +684,7 @@ func (q *Queries) GetVorgangWithSachgebiet(ctx context.Context, id string) ([]Ge
 }
 
 const listVorgaenge = `-- name: ListVorgaenge :many
-SELECT id, titel, vorgangstyp, typ, abstract, aktualisiert, archiv, beratungsstand, datum, gesta, kom, mitteilung, ratsdok, sek, wahlperiode, created_at, updated_at
+SELECT id, titel, vorgangstyp, typ, abstract, aktualisiert, archiv, beratungsstand, datum, gesta, kom, mitteilung, ratsdok, sek, wahlperiode, created_at, updated_at, deleted_at
 FROM vorgang
 WHERE 
     (? IS NULL OR aktualisiert >= ?)
@@ -690,6 +761,7 @@ func (q *Queries) ListVorgaenge(ctx context.Context, arg ListVorgaengeParams) ([
 			&i.Wahlperiode,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -713,9 +785,10 @@ SET
     beratungsstand = ?,
     datum = ?,
     mitteilung = ?,
+    deleted_at = NULL,
     updated_at = datetime('now')
 WHERE id = ?
-RETURNING id, titel, vorgangstyp, typ, abstract, aktualisiert, archiv, beratungsstand, datum, gesta, kom, mitteilung, ratsdok, sek, wahlperiode, created_at, updated_at
+RETURNING id, titel, vorgangstyp, typ, abstract, aktualisiert, archiv, beratungsstand, datum, gesta, kom, mitteilung, ratsdok, sek, wahlperiode, created_at, updated_at, deleted_at
 `
 
 type UpdateVorgangParams struct {
@@ -757,6 +830,72 @@ func (q *Queries) UpdateVorgang(ctx context.Context, arg UpdateVorgangParams) (V
 		&i.Wahlperiode,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
+
+const upsertVorgang = `-- name: UpsertVorgang :exec
+INSERT INTO vorgang (
+    id, titel, vorgangstyp, typ, abstract, aktualisiert,
+    archiv, beratungsstand, datum, gesta, kom, mitteilung,
+    ratsdok, sek, wahlperiode
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE
+SET
+    titel = excluded.titel,
+    vorgangstyp = excluded.vorgangstyp,
+    typ = excluded.typ,
+    abstract = excluded.abstract,
+    aktualisiert = excluded.aktualisiert,
+    archiv = excluded.archiv,
+    beratungsstand = excluded.beratungsstand,
+    datum = excluded.datum,
+    gesta = excluded.gesta,
+    kom = excluded.kom,
+    mitteilung = excluded.mitteilung,
+    ratsdok = excluded.ratsdok,
+    sek = excluded.sek,
+    wahlperiode = excluded.wahlperiode,
+    deleted_at = NULL,
+    updated_at = datetime('now')
+`
+
+type UpsertVorgangParams struct {
+	ID             string         `json:"id"`
+	Titel          string         `json:"titel"`
+	Vorgangstyp    string         `json:"vorgangstyp"`
+	Typ            string         `json:"typ"`
+	Abstract       sql.NullString `json:"abstract"`
+	Aktualisiert   string         `json:"aktualisiert"`
+	Archiv         sql.NullString `json:"archiv"`
+	Beratungsstand sql.NullString `json:"beratungsstand"`
+	Datum          sql.NullString `json:"datum"`
+	Gesta          sql.NullString `json:"gesta"`
+	Kom            sql.NullString `json:"kom"`
+	Mitteilung     sql.NullString `json:"mitteilung"`
+	Ratsdok        sql.NullString `json:"ratsdok"`
+	Sek            sql.NullString `json:"sek"`
+	Wahlperiode    int64          `json:"wahlperiode"`
+}
+
+func (q *Queries) UpsertVorgang(ctx context.Context, arg UpsertVorgangParams) error {
+	_, err := q.db.ExecContext(ctx, upsertVorgang,
+		arg.ID,
+		arg.Titel,
+		arg.Vorgangstyp,
+		arg.Typ,
+		arg.Abstract,
+		arg.Aktualisiert,
+		arg.Archiv,
+		arg.Beratungsstand,
+		arg.Datum,
+		arg.Gesta,
+		arg.Kom,
+		arg.Mitteilung,
+		arg.Ratsdok,
+		arg.Sek,
+		arg.Wahlperiode,
+	)
+	return err
+}
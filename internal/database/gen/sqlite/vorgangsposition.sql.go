@@ -203,7 +203,7 @@ INSERT INTO vorgangsposition (
     ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
     ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
     ?, ?, ?, ?, ?, ?
-) RETURNING id, vorgang_id, titel, vorgangsposition, vorgangstyp, typ, dokumentart, datum, aktualisiert, abstract, fortsetzung, gang, nachtrag, aktivitaet_anzahl, kom, ratsdok, sek, zuordnung, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url
+) RETURNING id, vorgang_id, titel, vorgangsposition, vorgangstyp, typ, dokumentart, datum, aktualisiert, abstract, fortsetzung, gang, nachtrag, aktivitaet_anzahl, kom, ratsdok, sek, zuordnung, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url, deleted_at
 `
 
 type CreateVorgangspositionParams struct {
@@ -324,6 +324,7 @@ func (q *Queries) CreateVorgangsposition(ctx context.Context, arg CreateVorgangs
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -401,6 +402,33 @@ func (q *Queries) CreateVorgangspositionUrheber(ctx context.Context, arg CreateV
 	return err
 }
 
+const deleteAktivitaetAnzeigen = `-- name: DeleteAktivitaetAnzeigen :exec
+DELETE FROM aktivitaet_anzeige WHERE vorgangsposition_id = ?
+`
+
+func (q *Queries) DeleteAktivitaetAnzeigen(ctx context.Context, vorgangspositionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteAktivitaetAnzeigen, vorgangspositionID)
+	return err
+}
+
+const deleteBeschlussfassungen = `-- name: DeleteBeschlussfassungen :exec
+DELETE FROM beschlussfassung WHERE vorgangsposition_id = ?
+`
+
+func (q *Queries) DeleteBeschlussfassungen(ctx context.Context, vorgangspositionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteBeschlussfassungen, vorgangspositionID)
+	return err
+}
+
+const deleteUeberweisungen = `-- name: DeleteUeberweisungen :exec
+DELETE FROM ueberweisung WHERE vorgangsposition_id = ?
+`
+
+func (q *Queries) DeleteUeberweisungen(ctx context.Context, vorgangspositionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteUeberweisungen, vorgangspositionID)
+	return err
+}
+
 const deleteVorgangsposition = `-- name: DeleteVorgangsposition :exec
 DELETE FROM vorgangsposition WHERE id = ?
 `
@@ -410,6 +438,33 @@ func (q *Queries) DeleteVorgangsposition(ctx context.Context, id string) error {
 	return err
 }
 
+const deleteVorgangspositionMitberaten = `-- name: DeleteVorgangspositionMitberaten :exec
+DELETE FROM vorgangsposition_mitberaten WHERE vorgangsposition_id = ?
+`
+
+func (q *Queries) DeleteVorgangspositionMitberaten(ctx context.Context, vorgangspositionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVorgangspositionMitberaten, vorgangspositionID)
+	return err
+}
+
+const deleteVorgangspositionRessorts = `-- name: DeleteVorgangspositionRessorts :exec
+DELETE FROM vorgangsposition_ressort WHERE vorgangsposition_id = ?
+`
+
+func (q *Queries) DeleteVorgangspositionRessorts(ctx context.Context, vorgangspositionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVorgangspositionRessorts, vorgangspositionID)
+	return err
+}
+
+const deleteVorgangspositionUrheber = `-- name: DeleteVorgangspositionUrheber :exec
+DELETE FROM vorgangsposition_urheber WHERE vorgangsposition_id = ?
+`
+
+func (q *Queries) DeleteVorgangspositionUrheber(ctx context.Context, vorgangspositionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteVorgangspositionUrheber, vorgangspositionID)
+	return err
+}
+
 const getLatestVorgangspositionDatum = `-- name: GetLatestVorgangspositionDatum :one
 SELECT MIN(datum) as datum FROM vorgangsposition
 `
@@ -422,7 +477,7 @@ func (q *Queries) GetLatestVorgangspositionDatum(ctx context.Context) (interface
 }
 
 const getVorgangsposition = `-- name: GetVorgangsposition :one
-SELECT id, vorgang_id, titel, vorgangsposition, vorgangstyp, typ, dokumentart, datum, aktualisiert, abstract, fortsetzung, gang, nachtrag, aktivitaet_anzahl, kom, ratsdok, sek, zuordnung, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url
+SELECT id, vorgang_id, titel, vorgangsposition, vorgangstyp, typ, dokumentart, datum, aktualisiert, abstract, fortsetzung, gang, nachtrag, aktivitaet_anzahl, kom, ratsdok, sek, zuordnung, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url, deleted_at
 FROM vorgangsposition
 WHERE id = ?
 `
@@ -469,13 +524,14 @@ func (q *Queries) GetVorgangsposition(ctx context.Context, id string) (Vorgangsp
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getVorgangspositionWithAktivitaet = `-- name: GetVorgangspositionWithAktivitaet :many
 SELECT 
-    vp.id, vp.vorgang_id, vp.titel, vp.vorgangsposition, vp.vorgangstyp, vp.typ, vp.dokumentart, vp.datum, vp.aktualisiert, vp.abstract, vp.fortsetzung, vp.gang, vp.nachtrag, vp.aktivitaet_anzahl, vp.kom, vp.ratsdok, vp.sek, vp.zuordnung, vp.fundstelle_dokumentnummer, vp.fundstelle_datum, vp.fundstelle_dokumentart, vp.fundstelle_herausgeber, vp.fundstelle_id, vp.fundstelle_drucksachetyp, vp.fundstelle_anlagen, vp.fundstelle_anfangsseite, vp.fundstelle_endseite, vp.fundstelle_anfangsquadrant, vp.fundstelle_endquadrant, vp.fundstelle_seite, vp.fundstelle_pdf_url, vp.fundstelle_top, vp.fundstelle_top_zusatz, vp.fundstelle_frage_nummer, vp.fundstelle_verteildatum, vp.created_at, vp.updated_at, vp.fundstelle_xml_url,
+    vp.id, vp.vorgang_id, vp.titel, vp.vorgangsposition, vp.vorgangstyp, vp.typ, vp.dokumentart, vp.datum, vp.aktualisiert, vp.abstract, vp.fortsetzung, vp.gang, vp.nachtrag, vp.aktivitaet_anzahl, vp.kom, vp.ratsdok, vp.sek, vp.zuordnung, vp.fundstelle_dokumentnummer, vp.fundstelle_datum, vp.fundstelle_dokumentart, vp.fundstelle_herausgeber, vp.fundstelle_id, vp.fundstelle_drucksachetyp, vp.fundstelle_anlagen, vp.fundstelle_anfangsseite, vp.fundstelle_endseite, vp.fundstelle_anfangsquadrant, vp.fundstelle_endquadrant, vp.fundstelle_seite, vp.fundstelle_pdf_url, vp.fundstelle_top, vp.fundstelle_top_zusatz, vp.fundstelle_frage_nummer, vp.fundstelle_verteildatum, vp.created_at, vp.updated_at, vp.fundstelle_xml_url, vp.deleted_at,
     aa.aktivitaetsart,
     aa.titel as aktivitaet_titel,
     aa.seite as aktivitaet_seite,
@@ -526,6 +582,7 @@ type GetVorgangspositionWithAktivitaetRow struct {
 	CreatedAt                 string         `json:"created_at"`
 	UpdatedAt                 string         `json:"updated_at"`
 	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullString `json:"deleted_at"`
 	Aktivitaetsart            sql.NullString `json:"aktivitaetsart"`
 	AktivitaetTitel           sql.NullString `json:"aktivitaet_titel"`
 	AktivitaetSeite           sql.NullString `json:"aktivitaet_seite"`
@@ -581,6 +638,7 @@ func (q *Queries) GetVorgangspositionWithAktivitaet(ctx context.Context, id stri
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 			&i.Aktivitaetsart,
 			&i.AktivitaetTitel,
 			&i.AktivitaetSeite,
@@ -602,7 +660,7 @@ func (q *Queries) GetVorgangspositionWithAktivitaet(ctx context.Context, id stri
 
 const getVorgangspositionWithBeschlussfassung = `-- name: GetVorgangspositionWithBeschlussfassung :many
 SELECT 
-    vp.id, vp.vorgang_id, vp.titel, vp.vorgangsposition, vp.vorgangstyp, vp.typ, vp.dokumentart, vp.datum, vp.aktualisiert, vp.abstract, vp.fortsetzung, vp.gang, vp.nachtrag, vp.aktivitaet_anzahl, vp.kom, vp.ratsdok, vp.sek, vp.zuordnung, vp.fundstelle_dokumentnummer, vp.fundstelle_datum, vp.fundstelle_dokumentart, vp.fundstelle_herausgeber, vp.fundstelle_id, vp.fundstelle_drucksachetyp, vp.fundstelle_anlagen, vp.fundstelle_anfangsseite, vp.fundstelle_endseite, vp.fundstelle_anfangsquadrant, vp.fundstelle_endquadrant, vp.fundstelle_seite, vp.fundstelle_pdf_url, vp.fundstelle_top, vp.fundstelle_top_zusatz, vp.fundstelle_frage_nummer, vp.fundstelle_verteildatum, vp.created_at, vp.updated_at, vp.fundstelle_xml_url,
+    vp.id, vp.vorgang_id, vp.titel, vp.vorgangsposition, vp.vorgangstyp, vp.typ, vp.dokumentart, vp.datum, vp.aktualisiert, vp.abstract, vp.fortsetzung, vp.gang, vp.nachtrag, vp.aktivitaet_anzahl, vp.kom, vp.ratsdok, vp.sek, vp.zuordnung, vp.fundstelle_dokumentnummer, vp.fundstelle_datum, vp.fundstelle_dokumentart, vp.fundstelle_herausgeber, vp.fundstelle_id, vp.fundstelle_drucksachetyp, vp.fundstelle_anlagen, vp.fundstelle_anfangsseite, vp.fundstelle_endseite, vp.fundstelle_anfangsquadrant, vp.fundstelle_endquadrant, vp.fundstelle_seite, vp.fundstelle_pdf_url, vp.fundstelle_top, vp.fundstelle_top_zusatz, vp.fundstelle_frage_nummer, vp.fundstelle_verteildatum, vp.created_at, vp.updated_at, vp.fundstelle_xml_url, vp.deleted_at,
     bf.beschlusstenor,
     bf.abstimmungsart,
     bf.mehrheit,
@@ -654,6 +712,7 @@ type GetVorgangspositionWithBeschlussfassungRow struct {
 	CreatedAt                 string         `json:"created_at"`
 	UpdatedAt                 string         `json:"updated_at"`
 	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullString `json:"deleted_at"`
 	Beschlusstenor            sql.NullString `json:"beschlusstenor"`
 	Abstimmungsart            sql.NullString `json:"abstimmungsart"`
 	Mehrheit                  sql.NullString `json:"mehrheit"`
@@ -711,6 +770,7 @@ func (q *Queries) GetVorgangspositionWithBeschlussfassung(ctx context.Context, i
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 			&i.Beschlusstenor,
 			&i.Abstimmungsart,
 			&i.Mehrheit,
@@ -734,7 +794,7 @@ func (q *Queries) GetVorgangspositionWithBeschlussfassung(ctx context.Context, i
 
 const getVorgangspositionWithRessort = `-- name: GetVorgangspositionWithRessort :many
 SELECT 
-    vp.id, vp.vorgang_id, vp.titel, vp.vorgangsposition, vp.vorgangstyp, vp.typ, vp.dokumentart, vp.datum, vp.aktualisiert, vp.abstract, vp.fortsetzung, vp.gang, vp.nachtrag, vp.aktivitaet_anzahl, vp.kom, vp.ratsdok, vp.sek, vp.zuordnung, vp.fundstelle_dokumentnummer, vp.fundstelle_datum, vp.fundstelle_dokumentart, vp.fundstelle_herausgeber, vp.fundstelle_id, vp.fundstelle_drucksachetyp, vp.fundstelle_anlagen, vp.fundstelle_anfangsseite, vp.fundstelle_endseite, vp.fundstelle_anfangsquadrant, vp.fundstelle_endquadrant, vp.fundstelle_seite, vp.fundstelle_pdf_url, vp.fundstelle_top, vp.fundstelle_top_zusatz, vp.fundstelle_frage_nummer, vp.fundstelle_verteildatum, vp.created_at, vp.updated_at, vp.fundstelle_xml_url,
+    vp.id, vp.vorgang_id, vp.titel, vp.vorgangsposition, vp.vorgangstyp, vp.typ, vp.dokumentart, vp.datum, vp.aktualisiert, vp.abstract, vp.fortsetzung, vp.gang, vp.nachtrag, vp.aktivitaet_anzahl, vp.kom, vp.ratsdok, vp.sek, vp.zuordnung, vp.fundstelle_dokumentnummer, vp.fundstelle_datum, vp.fundstelle_dokumentart, vp.fundstelle_herausgeber, vp.fundstelle_id, vp.fundstelle_drucksachetyp, vp.fundstelle_anlagen, vp.fundstelle_anfangsseite, vp.fundstelle_endseite, vp.fundstelle_anfangsquadrant, vp.fundstelle_endquadrant, vp.fundstelle_seite, vp.fundstelle_pdf_url, vp.fundstelle_top, vp.fundstelle_top_zusatz, vp.fundstelle_frage_nummer, vp.fundstelle_verteildatum, vp.created_at, vp.updated_at, vp.fundstelle_xml_url, vp.deleted_at,
     r.titel as ressort_titel,
     vpr.federfuehrend as ressort_federfuehrend
 FROM vorgangsposition vp
@@ -782,6 +842,7 @@ type GetVorgangspositionWithRessortRow struct {
 	CreatedAt                 string         `json:"created_at"`
 	UpdatedAt                 string         `json:"updated_at"`
 	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullString `json:"deleted_at"`
 	RessortTitel              sql.NullString `json:"ressort_titel"`
 	RessortFederfuehrend      sql.NullInt64  `json:"ressort_federfuehrend"`
 }
@@ -834,6 +895,7 @@ func (q *Queries) GetVorgangspositionWithRessort(ctx context.Context, id string)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 			&i.RessortTitel,
 			&i.RessortFederfuehrend,
 		); err != nil {
@@ -852,7 +914,7 @@ func (q *Queries) GetVorgangspositionWithRessort(ctx context.Context, id string)
 
 const getVorgangspositionWithUeberweisung = `-- name: GetVorgangspositionWithUeberweisung :many
 SELECT 
-    vp.id, vp.vorgang_id, vp.titel, vp.vorgangsposition, vp.vorgangstyp, vp.typ, vp.dokumentart, vp.datum, vp.aktualisiert, vp.abstract, vp.fortsetzung, vp.gang, vp.nachtrag, vp.aktivitaet_anzahl, vp.kom, vp.ratsdok, vp.sek, vp.zuordnung, vp.fundstelle_dokumentnummer, vp.fundstelle_datum, vp.fundstelle_dokumentart, vp.fundstelle_herausgeber, vp.fundstelle_id, vp.fundstelle_drucksachetyp, vp.fundstelle_anlagen, vp.fundstelle_anfangsseite, vp.fundstelle_endseite, vp.fundstelle_anfangsquadrant, vp.fundstelle_endquadrant, vp.fundstelle_seite, vp.fundstelle_pdf_url, vp.fundstelle_top, vp.fundstelle_top_zusatz, vp.fundstelle_frage_nummer, vp.fundstelle_verteildatum, vp.created_at, vp.updated_at, vp.fundstelle_xml_url,
+    vp.id, vp.vorgang_id, vp.titel, vp.vorgangsposition, vp.vorgangstyp, vp.typ, vp.dokumentart, vp.datum, vp.aktualisiert, vp.abstract, vp.fortsetzung, vp.gang, vp.nachtrag, vp.aktivitaet_anzahl, vp.kom, vp.ratsdok, vp.sek, vp.zuordnung, vp.fundstelle_dokumentnummer, vp.fundstelle_datum, vp.fundstelle_dokumentart, vp.fundstelle_herausgeber, vp.fundstelle_id, vp.fundstelle_drucksachetyp, vp.fundstelle_anlagen, vp.fundstelle_anfangsseite, vp.fundstelle_endseite, vp.fundstelle_anfangsquadrant, vp.fundstelle_endquadrant, vp.fundstelle_seite, vp.fundstelle_pdf_url, vp.fundstelle_top, vp.fundstelle_top_zusatz, vp.fundstelle_frage_nummer, vp.fundstelle_verteildatum, vp.created_at, vp.updated_at, vp.fundstelle_xml_url, vp.deleted_at,
     ue.ausschuss,
     ue.ausschuss_kuerzel,
     ue.federfuehrung,
@@ -901,6 +963,7 @@ type GetVorgangspositionWithUeberweisungRow struct {
 	CreatedAt                 string         `json:"created_at"`
 	UpdatedAt                 string         `json:"updated_at"`
 	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullString `json:"deleted_at"`
 	Ausschuss                 sql.NullString `json:"ausschuss"`
 	AusschussKuerzel          sql.NullString `json:"ausschuss_kuerzel"`
 	Federfuehrung             sql.NullInt64  `json:"federfuehrung"`
@@ -955,6 +1018,7 @@ func (q *Queries) GetVorgangspositionWithUeberweisung(ctx context.Context, id st
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 			&i.Ausschuss,
 			&i.AusschussKuerzel,
 			&i.Federfuehrung,
@@ -975,7 +1039,7 @@ func (q *Queries) GetVorgangspositionWithUeberweisung(ctx context.Context, id st
 
 const getVorgangspositionWithUrheber = `-- name: GetVorgangspositionWithUrheber :many
 SELECT 
-    vp.id, vp.vorgang_id, vp.titel, vp.vorgangsposition, vp.vorgangstyp, vp.typ, vp.dokumentart, vp.datum, vp.aktualisiert, vp.abstract, vp.fortsetzung, vp.gang, vp.nachtrag, vp.aktivitaet_anzahl, vp.kom, vp.ratsdok, vp.sek, vp.zuordnung, vp.fundstelle_dokumentnummer, vp.fundstelle_datum, vp.fundstelle_dokumentart, vp.fundstelle_herausgeber, vp.fundstelle_id, vp.fundstelle_drucksachetyp, vp.fundstelle_anlagen, vp.fundstelle_anfangsseite, vp.fundstelle_endseite, vp.fundstelle_anfangsquadrant, vp.fundstelle_endquadrant, vp.fundstelle_seite, vp.fundstelle_pdf_url, vp.fundstelle_top, vp.fundstelle_top_zusatz, vp.fundstelle_frage_nummer, vp.fundstelle_verteildatum, vp.created_at, vp.updated_at, vp.fundstelle_xml_url,
+    vp.id, vp.vorgang_id, vp.titel, vp.vorgangsposition, vp.vorgangstyp, vp.typ, vp.dokumentart, vp.datum, vp.aktualisiert, vp.abstract, vp.fortsetzung, vp.gang, vp.nachtrag, vp.aktivitaet_anzahl, vp.kom, vp.ratsdok, vp.sek, vp.zuordnung, vp.fundstelle_dokumentnummer, vp.fundstelle_datum, vp.fundstelle_dokumentart, vp.fundstelle_herausgeber, vp.fundstelle_id, vp.fundstelle_drucksachetyp, vp.fundstelle_anlagen, vp.fundstelle_anfangsseite, vp.fundstelle_endseite, vp.fundstelle_anfangsquadrant, vp.fundstelle_endquadrant, vp.fundstelle_seite, vp.fundstelle_pdf_url, vp.fundstelle_top, vp.fundstelle_top_zusatz, vp.fundstelle_frage_nummer, vp.fundstelle_verteildatum, vp.created_at, vp.updated_at, vp.fundstelle_xml_url, vp.deleted_at,
     u.bezeichnung as urheber_bezeichnung,
     u.titel as urheber_titel,
     vpu.rolle as urheber_rolle,
@@ -1025,6 +1089,7 @@ type GetVorgangspositionWithUrheberRow struct {
 	CreatedAt                 string         `json:"created_at"`
 	UpdatedAt                 string         `json:"updated_at"`
 	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	DeletedAt                 sql.NullString `json:"deleted_at"`
 	UrheberBezeichnung        sql.NullString `json:"urheber_bezeichnung"`
 	UrheberTitel              sql.NullString `json:"urheber_titel"`
 	UrheberRolle              sql.NullString `json:"urheber_rolle"`
@@ -1079,6 +1144,7 @@ func (q *Queries) GetVorgangspositionWithUrheber(ctx context.Context, id string)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 			&i.UrheberBezeichnung,
 			&i.UrheberTitel,
 			&i.UrheberRolle,
@@ -1098,7 +1164,7 @@ func (q *Queries) GetVorgangspositionWithUrheber(ctx context.Context, id string)
 }
 
 const listVorgangspositionen = `-- name: ListVorgangspositionen :many
-SELECT id, vorgang_id, titel, vorgangsposition, vorgangstyp, typ, dokumentart, datum, aktualisiert, abstract, fortsetzung, gang, nachtrag, aktivitaet_anzahl, kom, ratsdok, sek, zuordnung, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url
+SELECT id, vorgang_id, titel, vorgangsposition, vorgangstyp, typ, dokumentart, datum, aktualisiert, abstract, fortsetzung, gang, nachtrag, aktivitaet_anzahl, kom, ratsdok, sek, zuordnung, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url, deleted_at
 FROM vorgangsposition
 WHERE 
     (? IS NULL OR aktualisiert >= ?)
@@ -1201,6 +1267,7 @@ func (q *Queries) ListVorgangspositionen(ctx context.Context, arg ListVorgangspo
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.FundstelleXmlUrl,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1222,9 +1289,10 @@ SET
     aktualisiert = ?,
     abstract = ?,
     aktivitaet_anzahl = ?,
+    deleted_at = NULL,
     updated_at = datetime('now')
 WHERE id = ?
-RETURNING id, vorgang_id, titel, vorgangsposition, vorgangstyp, typ, dokumentart, datum, aktualisiert, abstract, fortsetzung, gang, nachtrag, aktivitaet_anzahl, kom, ratsdok, sek, zuordnung, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url
+RETURNING id, vorgang_id, titel, vorgangsposition, vorgangstyp, typ, dokumentart, datum, aktualisiert, abstract, fortsetzung, gang, nachtrag, aktivitaet_anzahl, kom, ratsdok, sek, zuordnung, fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart, fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite, fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz, fundstelle_frage_nummer, fundstelle_verteildatum, created_at, updated_at, fundstelle_xml_url, deleted_at
 `
 
 type UpdateVorgangspositionParams struct {
@@ -1283,6 +1351,145 @@ func (q *Queries) UpdateVorgangsposition(ctx context.Context, arg UpdateVorgangs
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.FundstelleXmlUrl,
+		&i.DeletedAt,
 	)
 	return i, err
 }
+
+const upsertVorgangsposition = `-- name: UpsertVorgangsposition :exec
+INSERT INTO vorgangsposition (
+    id, vorgang_id, titel, vorgangsposition, vorgangstyp, typ, dokumentart,
+    datum, aktualisiert, abstract, fortsetzung, gang, nachtrag, aktivitaet_anzahl,
+    kom, ratsdok, sek, zuordnung,
+    fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+    fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
+    fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
+    fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_frage_nummer, fundstelle_verteildatum
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?
+) ON CONFLICT (id) DO UPDATE
+SET
+    vorgang_id = excluded.vorgang_id,
+    titel = excluded.titel,
+    vorgangsposition = excluded.vorgangsposition,
+    vorgangstyp = excluded.vorgangstyp,
+    typ = excluded.typ,
+    dokumentart = excluded.dokumentart,
+    datum = excluded.datum,
+    aktualisiert = excluded.aktualisiert,
+    abstract = excluded.abstract,
+    fortsetzung = excluded.fortsetzung,
+    gang = excluded.gang,
+    nachtrag = excluded.nachtrag,
+    aktivitaet_anzahl = excluded.aktivitaet_anzahl,
+    kom = excluded.kom,
+    ratsdok = excluded.ratsdok,
+    sek = excluded.sek,
+    zuordnung = excluded.zuordnung,
+    fundstelle_dokumentnummer = excluded.fundstelle_dokumentnummer,
+    fundstelle_datum = excluded.fundstelle_datum,
+    fundstelle_dokumentart = excluded.fundstelle_dokumentart,
+    fundstelle_herausgeber = excluded.fundstelle_herausgeber,
+    fundstelle_id = excluded.fundstelle_id,
+    fundstelle_drucksachetyp = excluded.fundstelle_drucksachetyp,
+    fundstelle_anlagen = excluded.fundstelle_anlagen,
+    fundstelle_anfangsseite = excluded.fundstelle_anfangsseite,
+    fundstelle_endseite = excluded.fundstelle_endseite,
+    fundstelle_anfangsquadrant = excluded.fundstelle_anfangsquadrant,
+    fundstelle_endquadrant = excluded.fundstelle_endquadrant,
+    fundstelle_seite = excluded.fundstelle_seite,
+    fundstelle_pdf_url = excluded.fundstelle_pdf_url,
+    fundstelle_xml_url = excluded.fundstelle_xml_url,
+    fundstelle_top = excluded.fundstelle_top,
+    fundstelle_top_zusatz = excluded.fundstelle_top_zusatz,
+    fundstelle_frage_nummer = excluded.fundstelle_frage_nummer,
+    fundstelle_verteildatum = excluded.fundstelle_verteildatum,
+    deleted_at = NULL,
+    updated_at = datetime('now')
+`
+
+type UpsertVorgangspositionParams struct {
+	ID                        string         `json:"id"`
+	VorgangID                 string         `json:"vorgang_id"`
+	Titel                     string         `json:"titel"`
+	Vorgangsposition          string         `json:"vorgangsposition"`
+	Vorgangstyp               string         `json:"vorgangstyp"`
+	Typ                       string         `json:"typ"`
+	Dokumentart               string         `json:"dokumentart"`
+	Datum                     string         `json:"datum"`
+	Aktualisiert              string         `json:"aktualisiert"`
+	Abstract                  sql.NullString `json:"abstract"`
+	Fortsetzung               int64          `json:"fortsetzung"`
+	Gang                      int64          `json:"gang"`
+	Nachtrag                  int64          `json:"nachtrag"`
+	AktivitaetAnzahl          int64          `json:"aktivitaet_anzahl"`
+	Kom                       sql.NullString `json:"kom"`
+	Ratsdok                   sql.NullString `json:"ratsdok"`
+	Sek                       sql.NullString `json:"sek"`
+	Zuordnung                 string         `json:"zuordnung"`
+	FundstelleDokumentnummer  string         `json:"fundstelle_dokumentnummer"`
+	FundstelleDatum           string         `json:"fundstelle_datum"`
+	FundstelleDokumentart     string         `json:"fundstelle_dokumentart"`
+	FundstelleHerausgeber     string         `json:"fundstelle_herausgeber"`
+	FundstelleID              string         `json:"fundstelle_id"`
+	FundstelleDrucksachetyp   sql.NullString `json:"fundstelle_drucksachetyp"`
+	FundstelleAnlagen         sql.NullString `json:"fundstelle_anlagen"`
+	FundstelleAnfangsseite    sql.NullInt64  `json:"fundstelle_anfangsseite"`
+	FundstelleEndseite        sql.NullInt64  `json:"fundstelle_endseite"`
+	FundstelleAnfangsquadrant sql.NullString `json:"fundstelle_anfangsquadrant"`
+	FundstelleEndquadrant     sql.NullString `json:"fundstelle_endquadrant"`
+	FundstelleSeite           sql.NullString `json:"fundstelle_seite"`
+	FundstellePdfUrl          sql.NullString `json:"fundstelle_pdf_url"`
+	FundstelleXmlUrl          sql.NullString `json:"fundstelle_xml_url"`
+	FundstelleTop             sql.NullInt64  `json:"fundstelle_top"`
+	FundstelleTopZusatz       sql.NullString `json:"fundstelle_top_zusatz"`
+	FundstelleFrageNummer     sql.NullString `json:"fundstelle_frage_nummer"`
+	FundstelleVerteildatum    sql.NullString `json:"fundstelle_verteildatum"`
+}
+
+func (q *Queries) UpsertVorgangsposition(ctx context.Context, arg UpsertVorgangspositionParams) error {
+	_, err := q.db.ExecContext(ctx, upsertVorgangsposition,
+		arg.ID,
+		arg.VorgangID,
+		arg.Titel,
+		arg.Vorgangsposition,
+		arg.Vorgangstyp,
+		arg.Typ,
+		arg.Dokumentart,
+		arg.Datum,
+		arg.Aktualisiert,
+		arg.Abstract,
+		arg.Fortsetzung,
+		arg.Gang,
+		arg.Nachtrag,
+		arg.AktivitaetAnzahl,
+		arg.Kom,
+		arg.Ratsdok,
+		arg.Sek,
+		arg.Zuordnung,
+		arg.FundstelleDokumentnummer,
+		arg.FundstelleDatum,
+		arg.FundstelleDokumentart,
+		arg.FundstelleHerausgeber,
+		arg.FundstelleID,
+		arg.FundstelleDrucksachetyp,
+		arg.FundstelleAnlagen,
+		arg.FundstelleAnfangsseite,
+		arg.FundstelleEndseite,
+		arg.FundstelleAnfangsquadrant,
+		arg.FundstelleEndquadrant,
+		arg.FundstelleSeite,
+		arg.FundstellePdfUrl,
+		arg.FundstelleXmlUrl,
+		arg.FundstelleTop,
+		arg.FundstelleTopZusatz,
+		arg.FundstelleFrageNummer,
+		arg.FundstelleVerteildatum,
+	)
+	return err
+}
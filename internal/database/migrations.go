@@ -0,0 +1,11 @@
+// Package database holds the schema migrations and the sqlc queries of the
+// SQLite and PostgreSQL backends.
+package database
+
+import "embed"
+
+// Migrations contains the goose migrations of both backends, so the commands
+// and tests do not depend on the working directory.
+//
+//go:embed migrations/sqlite/*.sql migrations/postgres/*.sql
+var Migrations embed.FS
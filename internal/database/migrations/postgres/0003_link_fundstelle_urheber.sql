@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Link fundstelle_urheber.plenarprotokoll_id to the plenarprotokoll table
+-- (SQLite: 0003_link_fundstelle_urheber.sql / 0008_link_tables_via_fk.sql)
+ALTER TABLE fundstelle_urheber
+    ADD CONSTRAINT fundstelle_urheber_plenarprotokoll_id_fkey
+    FOREIGN KEY (plenarprotokoll_id) REFERENCES plenarprotokoll(id) ON DELETE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE fundstelle_urheber DROP CONSTRAINT fundstelle_urheber_plenarprotokoll_id_fkey;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- References to other synced entities are not enforced.
+--
+-- The sync stores an entity before the entities it references are synced (or
+-- they belong to a Wahlperiode that was never synced). SQLite declares these
+-- foreign keys but does not enforce them during sync, and sync-references /
+-- the reference backfill of sync-all fetch the missing entities afterwards.
+-- PostgreSQL always enforces foreign keys, so it would reject those rows and
+-- the dangling IDs could never be detected. The following references therefore
+-- stay plain columns in PostgreSQL (the SQLite foreign keys added in
+-- 0004/0007/0008 on the *_vorgangsbezug and vorgang_verlinkung tables are not
+-- added here for the same reason):
+--   vorgangsposition.vorgang_id          -> vorgang(id)
+--   drucksache_autor_anzeige.person_id   -> person(id)
+ALTER TABLE vorgangsposition DROP CONSTRAINT vorgangsposition_vorgang_id_fkey;
+ALTER TABLE drucksache_autor_anzeige DROP CONSTRAINT drucksache_autor_anzeige_person_id_fkey;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE drucksache_autor_anzeige
+    ADD CONSTRAINT drucksache_autor_anzeige_person_id_fkey
+    FOREIGN KEY (person_id) REFERENCES person(id);
+ALTER TABLE vorgangsposition
+    ADD CONSTRAINT vorgangsposition_vorgang_id_fkey
+    FOREIGN KEY (vorgang_id) REFERENCES vorgang(id) ON DELETE CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Add start_year and end_year columns to wahlperiode table
+-- (see SQLite 0009_wahlperiode_add_years.sql for sources and historical context)
+--
+-- Unlike SQLite, PostgreSQL enforces the wahlperiode foreign keys of vorgang,
+-- drucksache, plenarprotokoll and aktivitaet, so the known Wahlperioden are
+-- inserted here instead of only being updated.
+
+ALTER TABLE wahlperiode ADD COLUMN start_year INTEGER;
+ALTER TABLE wahlperiode ADD COLUMN end_year INTEGER;
+
+INSERT INTO wahlperiode (nummer, start_year, end_year) VALUES
+    (7, 1972, 1976),
+    (8, 1976, 1980),
+    (9, 1980, 1983),
+    (10, 1983, 1987),
+    (11, 1987, 1990),
+    (12, 1990, 1994),
+    (13, 1994, 1998),
+    (14, 1998, 2002),
+    (15, 2002, 2005),
+    (16, 2005, 2009),
+    (17, 2009, 2013),
+    (18, 2013, 2017),
+    (19, 2017, 2021),
+    (20, 2021, 2025),
+    (21, 2025, 2029)
+ON CONFLICT (nummer) DO UPDATE
+SET start_year = EXCLUDED.start_year,
+    end_year = EXCLUDED.end_year;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE wahlperiode DROP COLUMN start_year;
+ALTER TABLE wahlperiode DROP COLUMN end_year;
+-- +goose StatementEnd
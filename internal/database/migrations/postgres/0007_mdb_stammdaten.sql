@@ -0,0 +1,293 @@
+-- +goose Up
+-- +goose StatementBegin
+-- MdB Stammdaten (Master Data) from MDB_STAMMDATEN.XML and the link to DIP persons.
+-- Combines SQLite 0010_mdb_stammdaten.sql, 0011_mdb_foreign_keys.sql and
+-- 0012_fix_mdb_views.sql (see 0010_mdb_stammdaten.md for the data structure).
+-- Dates of the XML file (DD.MM.YYYY) are kept as TEXT like in SQLite.
+
+-- Document version tracking
+CREATE TABLE mdb_stammdaten_version (
+    id SERIAL PRIMARY KEY,
+    version TEXT NOT NULL,
+    import_date TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    source_file TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- MDB Person - Main entity for Bundestag members
+CREATE TABLE mdb_person (
+    id TEXT PRIMARY KEY,                    -- <ID> - 8-digit identifier
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- MDB Name - Name components with history
+CREATE TABLE mdb_name (
+    id SERIAL PRIMARY KEY,
+    mdb_id TEXT NOT NULL REFERENCES mdb_person(id) ON DELETE CASCADE,
+    nachname TEXT NOT NULL,
+    vorname TEXT NOT NULL,
+    ortszusatz TEXT,
+    adel TEXT,
+    praefix TEXT,
+    anrede_titel TEXT,
+    akad_titel TEXT,
+    historie_von TEXT,                      -- DD.MM.YYYY
+    historie_bis TEXT,                      -- DD.MM.YYYY, NULL if current
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_mdb_name_mdb_id ON mdb_name(mdb_id);
+CREATE INDEX idx_mdb_name_nachname ON mdb_name(nachname);
+CREATE INDEX idx_mdb_name_vorname ON mdb_name(vorname);
+CREATE INDEX idx_mdb_name_historie_von ON mdb_name(historie_von);
+
+-- MDB Biographical Data
+CREATE TABLE mdb_biographical (
+    id SERIAL PRIMARY KEY,
+    mdb_id TEXT NOT NULL UNIQUE REFERENCES mdb_person(id) ON DELETE CASCADE,
+    geburtsdatum TEXT,
+    geburtsort TEXT,
+    geburtsland TEXT,
+    sterbedatum TEXT,
+    geschlecht TEXT,
+    familienstand TEXT,
+    religion TEXT,
+    beruf TEXT,
+    partei_kurz TEXT,
+    vita_kurz TEXT,
+    veroeffentlichungspflichtiges TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_mdb_biographical_mdb_id ON mdb_biographical(mdb_id);
+CREATE INDEX idx_mdb_biographical_partei_kurz ON mdb_biographical(partei_kurz);
+CREATE INDEX idx_mdb_biographical_geschlecht ON mdb_biographical(geschlecht);
+CREATE INDEX idx_mdb_biographical_geburtsdatum ON mdb_biographical(geburtsdatum);
+
+-- MDB Wahlperiode Membership
+CREATE TABLE mdb_wahlperiode_membership (
+    id SERIAL PRIMARY KEY,
+    mdb_id TEXT NOT NULL REFERENCES mdb_person(id) ON DELETE CASCADE,
+    wp INTEGER NOT NULL REFERENCES wahlperiode(nummer),
+    mdbwp_von TEXT NOT NULL,
+    mdbwp_bis TEXT,
+    wkr_nummer TEXT,
+    wkr_name TEXT,
+    wkr_land TEXT,
+    liste TEXT,
+    mandatsart TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (mdb_id, wp)
+);
+
+CREATE INDEX idx_mdb_wahlperiode_membership_mdb_id ON mdb_wahlperiode_membership(mdb_id);
+CREATE INDEX idx_mdb_wahlperiode_membership_wp ON mdb_wahlperiode_membership(wp);
+CREATE INDEX idx_mdb_wahlperiode_membership_mandatsart ON mdb_wahlperiode_membership(mandatsart);
+CREATE INDEX idx_mdb_wahlperiode_membership_wkr_land ON mdb_wahlperiode_membership(wkr_land);
+CREATE INDEX idx_mdb_wahlperiode_membership_mdbwp_von ON mdb_wahlperiode_membership(mdbwp_von);
+
+-- MDB Institution Membership
+CREATE TABLE mdb_institution_membership (
+    id SERIAL PRIMARY KEY,
+    mdb_wahlperiode_membership_id INTEGER NOT NULL REFERENCES mdb_wahlperiode_membership(id) ON DELETE CASCADE,
+    insart_lang TEXT NOT NULL,
+    ins_lang TEXT NOT NULL,
+    mdbins_von TEXT,
+    mdbins_bis TEXT,
+    fkt_lang TEXT,
+    fktins_von TEXT,
+    fktins_bis TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_mdb_institution_membership_wahlperiode_id ON mdb_institution_membership(mdb_wahlperiode_membership_id);
+CREATE INDEX idx_mdb_institution_membership_insart_lang ON mdb_institution_membership(insart_lang);
+CREATE INDEX idx_mdb_institution_membership_ins_lang ON mdb_institution_membership(ins_lang);
+CREATE INDEX idx_mdb_institution_membership_mdbins_von ON mdb_institution_membership(mdbins_von);
+
+-- Link between DIP persons and MdB persons
+CREATE TABLE person_mdb_link (
+    person_id TEXT NOT NULL REFERENCES person(id) ON DELETE CASCADE,
+    mdb_id TEXT NOT NULL REFERENCES mdb_person(id) ON DELETE CASCADE,
+    match_confidence TEXT NOT NULL CHECK(match_confidence IN ('exact', 'high', 'medium', 'low', 'manual')),
+    match_method TEXT NOT NULL,
+    verified_by TEXT,
+    verified_at TIMESTAMPTZ,
+    notes TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (person_id, mdb_id)
+);
+
+CREATE INDEX idx_person_mdb_link_person_id ON person_mdb_link(person_id);
+CREATE INDEX idx_person_mdb_link_mdb_id ON person_mdb_link(mdb_id);
+CREATE INDEX idx_person_mdb_link_confidence ON person_mdb_link(match_confidence);
+
+-- View: Current MDB Members (latest wahlperiode)
+CREATE VIEW mdb_current_members AS
+SELECT 
+    mp.id as mdb_id,
+    mn.nachname,
+    mn.vorname,
+    mn.ortszusatz,
+    mn.anrede_titel,
+    mn.akad_titel,
+    mb.partei_kurz,
+    mb.geschlecht,
+    mwm.wp as wahlperiode,
+    mwm.mandatsart,
+    mwm.wkr_name,
+    mwm.wkr_land
+FROM mdb_person mp
+JOIN mdb_name mn ON mp.id = mn.mdb_id AND mn.historie_bis IS NULL  -- Current name
+JOIN mdb_biographical mb ON mp.id = mb.mdb_id
+JOIN mdb_wahlperiode_membership mwm ON mp.id = mwm.mdb_id
+WHERE mwm.wp = (SELECT MAX(wp) FROM mdb_wahlperiode_membership)  -- Latest wahlperiode
+  AND mwm.mdbwp_bis IS NULL;  -- Still active
+
+-- View: MDB Full Name with History
+CREATE VIEW mdb_full_names AS
+SELECT 
+    mp.id as mdb_id,
+    mn.id as name_id,
+    CASE 
+        WHEN mn.anrede_titel IS NOT NULL AND mn.anrede_titel != '' 
+        THEN mn.anrede_titel || ' ' || mn.vorname || ' ' || mn.nachname
+        ELSE mn.vorname || ' ' || mn.nachname
+    END as display_name,
+    CASE 
+        WHEN mn.praefix IS NOT NULL AND mn.praefix != ''
+        THEN mn.praefix || ' ' || mn.nachname
+        ELSE mn.nachname
+    END as full_nachname,
+    mn.vorname,
+    mn.nachname,
+    mn.ortszusatz,
+    mn.adel,
+    mn.praefix,
+    mn.anrede_titel,
+    mn.akad_titel,
+    mn.historie_von,
+    mn.historie_bis,
+    CASE 
+        WHEN mn.historie_bis IS NULL THEN 1
+        ELSE 0
+    END as is_current
+FROM mdb_person mp
+JOIN mdb_name mn ON mp.id = mn.mdb_id;
+
+-- View: MDB Career Summary
+CREATE VIEW mdb_career_summary AS
+SELECT 
+    mp.id as mdb_id,
+    mn.nachname,
+    mn.vorname,
+    mb.partei_kurz,
+    COUNT(DISTINCT mwm.wp) as anzahl_wahlperioden,
+    MIN(mwm.wp) as erste_wahlperiode,
+    MAX(mwm.wp) as letzte_wahlperiode,
+    MIN(mwm.mdbwp_von) as mandat_beginn,
+    MAX(CASE WHEN mwm.mdbwp_bis IS NULL THEN CURRENT_DATE::text ELSE mwm.mdbwp_bis END) as mandat_ende,
+    COUNT(DISTINCT mim.ins_lang) as anzahl_fraktionen
+FROM mdb_person mp
+JOIN mdb_name mn ON mp.id = mn.mdb_id AND mn.historie_bis IS NULL
+LEFT JOIN mdb_biographical mb ON mp.id = mb.mdb_id
+JOIN mdb_wahlperiode_membership mwm ON mp.id = mwm.mdb_id
+LEFT JOIN mdb_institution_membership mim ON mwm.id = mim.mdb_wahlperiode_membership_id 
+    AND mim.insart_lang LIKE '%Fraktion%'
+GROUP BY mp.id, mn.nachname, mn.vorname, mb.partei_kurz;
+
+-- View: Fraktion Membership Summary
+CREATE VIEW mdb_fraktion_summary AS
+SELECT 
+    mim.ins_lang as fraktion,
+    mwm.wp as wahlperiode,
+    COUNT(DISTINCT mp.id) as anzahl_mitglieder,
+    COUNT(DISTINCT CASE WHEN mwm.mandatsart = 'Direktmandat' THEN mp.id END) as direktmandate,
+    COUNT(DISTINCT CASE WHEN mwm.mandatsart = 'Landesliste' THEN mp.id END) as listenmandate
+FROM mdb_person mp
+JOIN mdb_wahlperiode_membership mwm ON mp.id = mwm.mdb_id
+JOIN mdb_institution_membership mim ON mwm.id = mim.mdb_wahlperiode_membership_id
+WHERE mim.insart_lang LIKE '%Fraktion%'
+GROUP BY mim.ins_lang, mwm.wp
+ORDER BY mwm.wp DESC, anzahl_mitglieder DESC;
+
+-- View: DIP person data combined with MdB biographical data
+CREATE VIEW person_with_mdb_bio AS
+SELECT 
+    p.id as person_id,
+    p.vorname as dip_vorname,
+    p.nachname as dip_nachname,
+    p.typ,
+    p.aktualisiert,
+    pml.mdb_id,
+    pml.match_confidence,
+    mn.nachname as mdb_nachname,
+    mn.vorname as mdb_vorname,
+    mn.anrede_titel,
+    mn.akad_titel,
+    mb.geburtsdatum,
+    mb.geburtsort,
+    mb.geschlecht,
+    mb.partei_kurz,
+    mb.beruf,
+    mb.vita_kurz,
+    (SELECT COUNT(DISTINCT wp) FROM mdb_wahlperiode_membership WHERE mdb_id = pml.mdb_id) as anzahl_wahlperioden,
+    (SELECT MIN(mdbwp_von) FROM mdb_wahlperiode_membership WHERE mdb_id = pml.mdb_id) as erste_mitgliedschaft,
+    (SELECT MAX(mdbwp_bis) FROM mdb_wahlperiode_membership WHERE mdb_id = pml.mdb_id) as letzte_mitgliedschaft
+FROM person p
+LEFT JOIN person_mdb_link pml ON p.id = pml.person_id
+LEFT JOIN mdb_person mp ON pml.mdb_id = mp.id
+LEFT JOIN mdb_name mn ON mp.id = mn.mdb_id AND (mn.historie_von IS NULL OR mn.historie_von = '')
+LEFT JOIN mdb_biographical mb ON mp.id = mb.mdb_id;
+
+-- View: Persons active in a Wahlperiode with biographical data
+CREATE VIEW person_wahlperiode_with_bio AS
+SELECT 
+    p.id as person_id,
+    p.nachname as dip_nachname,
+    p.vorname as dip_vorname,
+    pw.wahlperiode_nummer,
+    pml.mdb_id,
+    mn.nachname as mdb_nachname,
+    mn.vorname as mdb_vorname,
+    mb.partei_kurz,
+    mb.geschlecht,
+    mb.geburtsdatum,
+    mwm.mandatsart,
+    mwm.wkr_name,
+    mwm.wkr_land,
+    mwm.mdbwp_von,
+    mwm.mdbwp_bis
+FROM person p
+JOIN person_wahlperiode pw ON p.id = pw.person_id
+LEFT JOIN person_mdb_link pml ON p.id = pml.person_id
+LEFT JOIN mdb_person mp ON pml.mdb_id = mp.id
+LEFT JOIN mdb_name mn ON mp.id = mn.mdb_id AND (mn.historie_von IS NULL OR mn.historie_von = '')
+LEFT JOIN mdb_biographical mb ON mp.id = mb.mdb_id
+LEFT JOIN mdb_wahlperiode_membership mwm ON mp.id = mwm.mdb_id AND pw.wahlperiode_nummer = mwm.wp;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW IF EXISTS person_wahlperiode_with_bio;
+DROP VIEW IF EXISTS person_with_mdb_bio;
+DROP VIEW IF EXISTS mdb_fraktion_summary;
+DROP VIEW IF EXISTS mdb_career_summary;
+DROP VIEW IF EXISTS mdb_full_names;
+DROP VIEW IF EXISTS mdb_current_members;
+
+DROP TABLE IF EXISTS person_mdb_link;
+DROP TABLE IF EXISTS mdb_institution_membership;
+DROP TABLE IF EXISTS mdb_wahlperiode_membership;
+DROP TABLE IF EXISTS mdb_biographical;
+DROP TABLE IF EXISTS mdb_name;
+DROP TABLE IF EXISTS mdb_person;
+DROP TABLE IF EXISTS mdb_stammdaten_version;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Add xml_url field to fundstelle in all relevant tables
+-- This field was added in DIP API v1.4 for structured XML versions of documents
+-- (currently only available for BT-Plenarprotokolle from WP 18 onwards)
+ALTER TABLE aktivitaet ADD COLUMN fundstelle_xml_url TEXT;
+ALTER TABLE drucksache ADD COLUMN fundstelle_xml_url TEXT;
+ALTER TABLE plenarprotokoll ADD COLUMN fundstelle_xml_url TEXT;
+ALTER TABLE vorgangsposition ADD COLUMN fundstelle_xml_url TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE vorgangsposition DROP COLUMN fundstelle_xml_url;
+ALTER TABLE plenarprotokoll DROP COLUMN fundstelle_xml_url;
+ALTER TABLE drucksache DROP COLUMN fundstelle_xml_url;
+ALTER TABLE aktivitaet DROP COLUMN fundstelle_xml_url;
+-- +goose StatementEnd
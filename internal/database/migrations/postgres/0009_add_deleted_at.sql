@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Track records that were removed from the DIP API (see SQLite 0014_add_deleted_at.sql).
+ALTER TABLE person ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE vorgang ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE vorgangsposition ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE aktivitaet ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE drucksache ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE plenarprotokoll ADD COLUMN deleted_at TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE plenarprotokoll DROP COLUMN deleted_at;
+ALTER TABLE drucksache DROP COLUMN deleted_at;
+ALTER TABLE aktivitaet DROP COLUMN deleted_at;
+ALTER TABLE vorgangsposition DROP COLUMN deleted_at;
+ALTER TABLE vorgang DROP COLUMN deleted_at;
+ALTER TABLE person DROP COLUMN deleted_at;
+-- +goose StatementEnd
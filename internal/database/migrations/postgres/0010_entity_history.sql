@@ -0,0 +1,101 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Optional change history (see SQLite 0015_entity_history.sql).
+-- Snapshot and diff are stored as JSONB.
+
+CREATE TABLE sync_run (
+    id TEXT PRIMARY KEY,
+    resource_name TEXT NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+
+CREATE TABLE person_history (
+    id BIGSERIAL PRIMARY KEY,
+    person_id TEXT NOT NULL,
+    aktualisiert TIMESTAMPTZ NOT NULL,
+    sync_run_id TEXT NOT NULL,
+    snapshot JSONB NOT NULL,
+    diff JSONB NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_person_history_person_id ON person_history(person_id, aktualisiert);
+
+
+CREATE TABLE vorgang_history (
+    id BIGSERIAL PRIMARY KEY,
+    vorgang_id TEXT NOT NULL,
+    aktualisiert TIMESTAMPTZ NOT NULL,
+    sync_run_id TEXT NOT NULL,
+    snapshot JSONB NOT NULL,
+    diff JSONB NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_vorgang_history_vorgang_id ON vorgang_history(vorgang_id, aktualisiert);
+
+
+CREATE TABLE vorgangsposition_history (
+    id BIGSERIAL PRIMARY KEY,
+    vorgangsposition_id TEXT NOT NULL,
+    aktualisiert TIMESTAMPTZ NOT NULL,
+    sync_run_id TEXT NOT NULL,
+    snapshot JSONB NOT NULL,
+    diff JSONB NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_vorgangsposition_history_vorgangsposition_id ON vorgangsposition_history(vorgangsposition_id, aktualisiert);
+
+
+CREATE TABLE aktivitaet_history (
+    id BIGSERIAL PRIMARY KEY,
+    aktivitaet_id TEXT NOT NULL,
+    aktualisiert TIMESTAMPTZ NOT NULL,
+    sync_run_id TEXT NOT NULL,
+    snapshot JSONB NOT NULL,
+    diff JSONB NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_aktivitaet_history_aktivitaet_id ON aktivitaet_history(aktivitaet_id, aktualisiert);
+
+
+CREATE TABLE drucksache_history (
+    id BIGSERIAL PRIMARY KEY,
+    drucksache_id TEXT NOT NULL,
+    aktualisiert TIMESTAMPTZ NOT NULL,
+    sync_run_id TEXT NOT NULL,
+    snapshot JSONB NOT NULL,
+    diff JSONB NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_drucksache_history_drucksache_id ON drucksache_history(drucksache_id, aktualisiert);
+
+
+CREATE TABLE plenarprotokoll_history (
+    id BIGSERIAL PRIMARY KEY,
+    plenarprotokoll_id TEXT NOT NULL,
+    aktualisiert TIMESTAMPTZ NOT NULL,
+    sync_run_id TEXT NOT NULL,
+    snapshot JSONB NOT NULL,
+    diff JSONB NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_plenarprotokoll_history_plenarprotokoll_id ON plenarprotokoll_history(plenarprotokoll_id, aktualisiert);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS person_history;
+DROP TABLE IF EXISTS vorgang_history;
+DROP TABLE IF EXISTS vorgangsposition_history;
+DROP TABLE IF EXISTS aktivitaet_history;
+DROP TABLE IF EXISTS drucksache_history;
+DROP TABLE IF EXISTS plenarprotokoll_history;
+DROP TABLE IF EXISTS sync_run;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Track records that were removed from the DIP API.
+-- Sync only upserts, so records deleted or merged upstream would otherwise stay
+-- in the database forever. Reconciliation (sync-reconcile) compares the IDs
+-- returned by the API with the local tables and sets deleted_at for records
+-- that disappeared. A later sync that sees the record again resets it to NULL.
+
+ALTER TABLE person ADD COLUMN deleted_at TEXT;
+ALTER TABLE vorgang ADD COLUMN deleted_at TEXT;
+ALTER TABLE vorgangsposition ADD COLUMN deleted_at TEXT;
+ALTER TABLE aktivitaet ADD COLUMN deleted_at TEXT;
+ALTER TABLE drucksache ADD COLUMN deleted_at TEXT;
+ALTER TABLE plenarprotokoll ADD COLUMN deleted_at TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE plenarprotokoll DROP COLUMN deleted_at;
+ALTER TABLE drucksache DROP COLUMN deleted_at;
+ALTER TABLE aktivitaet DROP COLUMN deleted_at;
+ALTER TABLE vorgangsposition DROP COLUMN deleted_at;
+ALTER TABLE vorgang DROP COLUMN deleted_at;
+ALTER TABLE person DROP COLUMN deleted_at;
+-- +goose StatementEnd
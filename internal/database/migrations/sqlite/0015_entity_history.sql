@@ -0,0 +1,104 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Optional change history (enabled with the -history flag of the sync commands).
+-- Whenever a sync changes an existing row, the previous version of the row is
+-- stored as JSON snapshot together with a diff of the changed columns, the
+-- aktualisiert timestamp of the previous version and the ID of the sync run.
+-- Only the main entity rows are versioned, child tables are not.
+
+CREATE TABLE sync_run (
+    id TEXT PRIMARY KEY,
+    resource_name TEXT NOT NULL,
+    started_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+
+CREATE TABLE person_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    person_id TEXT NOT NULL,
+    aktualisiert TEXT NOT NULL,
+    sync_run_id TEXT NOT NULL,
+    snapshot TEXT NOT NULL,
+    diff TEXT NOT NULL,
+    recorded_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX idx_person_history_person_id ON person_history(person_id, aktualisiert);
+
+
+CREATE TABLE vorgang_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    vorgang_id TEXT NOT NULL,
+    aktualisiert TEXT NOT NULL,
+    sync_run_id TEXT NOT NULL,
+    snapshot TEXT NOT NULL,
+    diff TEXT NOT NULL,
+    recorded_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX idx_vorgang_history_vorgang_id ON vorgang_history(vorgang_id, aktualisiert);
+
+
+CREATE TABLE vorgangsposition_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    vorgangsposition_id TEXT NOT NULL,
+    aktualisiert TEXT NOT NULL,
+    sync_run_id TEXT NOT NULL,
+    snapshot TEXT NOT NULL,
+    diff TEXT NOT NULL,
+    recorded_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX idx_vorgangsposition_history_vorgangsposition_id ON vorgangsposition_history(vorgangsposition_id, aktualisiert);
+
+
+CREATE TABLE aktivitaet_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    aktivitaet_id TEXT NOT NULL,
+    aktualisiert TEXT NOT NULL,
+    sync_run_id TEXT NOT NULL,
+    snapshot TEXT NOT NULL,
+    diff TEXT NOT NULL,
+    recorded_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX idx_aktivitaet_history_aktivitaet_id ON aktivitaet_history(aktivitaet_id, aktualisiert);
+
+
+CREATE TABLE drucksache_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    drucksache_id TEXT NOT NULL,
+    aktualisiert TEXT NOT NULL,
+    sync_run_id TEXT NOT NULL,
+    snapshot TEXT NOT NULL,
+    diff TEXT NOT NULL,
+    recorded_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX idx_drucksache_history_drucksache_id ON drucksache_history(drucksache_id, aktualisiert);
+
+
+CREATE TABLE plenarprotokoll_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    plenarprotokoll_id TEXT NOT NULL,
+    aktualisiert TEXT NOT NULL,
+    sync_run_id TEXT NOT NULL,
+    snapshot TEXT NOT NULL,
+    diff TEXT NOT NULL,
+    recorded_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX idx_plenarprotokoll_history_plenarprotokoll_id ON plenarprotokoll_history(plenarprotokoll_id, aktualisiert);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS person_history;
+DROP TABLE IF EXISTS vorgang_history;
+DROP TABLE IF EXISTS vorgangsposition_history;
+DROP TABLE IF EXISTS aktivitaet_history;
+DROP TABLE IF EXISTS drucksache_history;
+DROP TABLE IF EXISTS plenarprotokoll_history;
+DROP TABLE IF EXISTS sync_run;
+-- +goose StatementEnd
@@ -0,0 +1,87 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Full-text search over drucksache_text and plenarprotokoll_text (used by dip search).
+-- The FTS5 tables are external-content tables: they only store the index and read the
+-- text from the text tables, keyed by the numeric DIP ID as rowid. Triggers on the
+-- text tables keep the index in sync, passing the old text that an FTS5 'delete' needs.
+-- The unicode61 tokenizer with remove_diacritics folds umlauts (ä -> a), so
+-- "Wärmeplanung" and "Warmeplanung" find the same documents. Spellings like
+-- "Waermeplanung" or "Strasse" are expanded at query time (see internal/search).
+-- Building the index for an existing database reads every text once and takes a while.
+
+CREATE VIRTUAL TABLE drucksache_text_fts USING fts5(
+    text,
+    content = 'drucksache_text',
+    content_rowid = 'id',
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+CREATE VIRTUAL TABLE plenarprotokoll_text_fts USING fts5(
+    text,
+    content = 'plenarprotokoll_text',
+    content_rowid = 'id',
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+
+CREATE TRIGGER drucksache_text_fts_insert AFTER INSERT ON drucksache_text
+WHEN new.text IS NOT NULL
+BEGIN
+    INSERT INTO drucksache_text_fts (rowid, text) VALUES (CAST(new.id AS INTEGER), new.text);
+END;
+
+CREATE TRIGGER drucksache_text_fts_update AFTER UPDATE OF text ON drucksache_text
+BEGIN
+    INSERT INTO drucksache_text_fts (drucksache_text_fts, rowid, text)
+    SELECT 'delete', CAST(old.id AS INTEGER), old.text WHERE old.text IS NOT NULL;
+    INSERT INTO drucksache_text_fts (rowid, text)
+    SELECT CAST(new.id AS INTEGER), new.text WHERE new.text IS NOT NULL;
+END;
+
+CREATE TRIGGER drucksache_text_fts_delete AFTER DELETE ON drucksache_text
+WHEN old.text IS NOT NULL
+BEGIN
+    INSERT INTO drucksache_text_fts (drucksache_text_fts, rowid, text)
+    VALUES ('delete', CAST(old.id AS INTEGER), old.text);
+END;
+
+
+CREATE TRIGGER plenarprotokoll_text_fts_insert AFTER INSERT ON plenarprotokoll_text
+WHEN new.text IS NOT NULL
+BEGIN
+    INSERT INTO plenarprotokoll_text_fts (rowid, text) VALUES (CAST(new.id AS INTEGER), new.text);
+END;
+
+CREATE TRIGGER plenarprotokoll_text_fts_update AFTER UPDATE OF text ON plenarprotokoll_text
+BEGIN
+    INSERT INTO plenarprotokoll_text_fts (plenarprotokoll_text_fts, rowid, text)
+    SELECT 'delete', CAST(old.id AS INTEGER), old.text WHERE old.text IS NOT NULL;
+    INSERT INTO plenarprotokoll_text_fts (rowid, text)
+    SELECT CAST(new.id AS INTEGER), new.text WHERE new.text IS NOT NULL;
+END;
+
+CREATE TRIGGER plenarprotokoll_text_fts_delete AFTER DELETE ON plenarprotokoll_text
+WHEN old.text IS NOT NULL
+BEGIN
+    INSERT INTO plenarprotokoll_text_fts (plenarprotokoll_text_fts, rowid, text)
+    VALUES ('delete', CAST(old.id AS INTEGER), old.text);
+END;
+
+
+INSERT INTO drucksache_text_fts (drucksache_text_fts) VALUES ('rebuild');
+
+INSERT INTO plenarprotokoll_text_fts (plenarprotokoll_text_fts) VALUES ('rebuild');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS plenarprotokoll_text_fts_delete;
+DROP TRIGGER IF EXISTS plenarprotokoll_text_fts_update;
+DROP TRIGGER IF EXISTS plenarprotokoll_text_fts_insert;
+DROP TRIGGER IF EXISTS drucksache_text_fts_delete;
+DROP TRIGGER IF EXISTS drucksache_text_fts_update;
+DROP TRIGGER IF EXISTS drucksache_text_fts_insert;
+DROP TABLE IF EXISTS plenarprotokoll_text_fts;
+DROP TABLE IF EXISTS drucksache_text_fts;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Speaker turns (Reden) parsed from plenarprotokoll_text by process-plenarprotokoll-reden.
+-- start_offset and end_offset are character offsets into plenarprotokoll_text.text,
+-- so substr(text, start_offset + 1, end_offset - start_offset) returns the Rede
+-- including its speaker line.
+-- rede_verarbeitung records which text version was parsed with which parser version,
+-- so only new or changed texts are parsed again.
+
+CREATE TABLE rede (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    plenarprotokoll_id TEXT NOT NULL REFERENCES plenarprotokoll(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,              -- order within the Plenarprotokoll
+    sprecher TEXT NOT NULL,                 -- name including titles, e.g. Dr. Alice Weidel
+    rolle TEXT,                             -- e.g. Präsidentin, Bundesminister der Finanzen
+    fraktion TEXT,                          -- e.g. SPD, BÜNDNIS 90/DIE GRÜNEN
+    tagesordnungspunkt TEXT,                -- e.g. Tagesordnungspunkt 3, Zusatzpunkt 5 a
+    start_offset INTEGER NOT NULL,
+    end_offset INTEGER NOT NULL,
+    text TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    UNIQUE (plenarprotokoll_id, position)
+);
+
+CREATE INDEX idx_rede_sprecher ON rede(sprecher);
+CREATE INDEX idx_rede_fraktion ON rede(fraktion);
+
+CREATE TABLE rede_verarbeitung (
+    plenarprotokoll_id TEXT PRIMARY KEY REFERENCES plenarprotokoll(id) ON DELETE CASCADE,
+    parser_version INTEGER NOT NULL,
+    text_updated_at TEXT NOT NULL,          -- plenarprotokoll_text.updated_at of the parsed text
+    reden_anzahl INTEGER NOT NULL,
+    processed_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS rede_verarbeitung;
+DROP INDEX IF EXISTS idx_rede_fraktion;
+DROP INDEX IF EXISTS idx_rede_sprecher;
+DROP TABLE IF EXISTS rede;
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Speakers of Reden resolved to DIP persons and MdB Stammdaten (see internal/sprecher).
+-- sprecher_confidence is between 0 and 1; Reden below the minimum confidence of
+-- process-plenarprotokoll-reden keep person_id and mdb_id NULL.
+-- Unresolved speakers are collected in rede_sprecher_review. A reviewer sets
+-- person_id and/or mdb_id of a review row, and the next run of
+-- process-plenarprotokoll-reden -resolve-only assigns them to all Reden of the speaker.
+
+ALTER TABLE rede ADD COLUMN person_id TEXT;       -- person.id
+ALTER TABLE rede ADD COLUMN mdb_id TEXT;          -- mdb_person.id
+ALTER TABLE rede ADD COLUMN sprecher_confidence REAL;
+ALTER TABLE rede ADD COLUMN sprecher_methode TEXT;  -- mdb_name, person_name, mdb_name+person_name, manual
+
+CREATE INDEX idx_rede_person_id ON rede(person_id);
+CREATE INDEX idx_rede_mdb_id ON rede(mdb_id);
+
+CREATE TABLE rede_sprecher_review (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    sprecher TEXT NOT NULL,
+    fraktion TEXT NOT NULL DEFAULT '',      -- empty for speakers without Fraktion
+    rolle TEXT,
+    kandidaten TEXT NOT NULL,               -- JSON array of the best candidates with confidence
+    beste_confidence REAL,
+    beispiel_rede_id INTEGER REFERENCES rede(id) ON DELETE SET NULL,
+    person_id TEXT REFERENCES person(id),   -- set by the reviewer
+    mdb_id TEXT REFERENCES mdb_person(id),  -- set by the reviewer
+    reviewed_by TEXT,
+    reviewed_at TEXT,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now')),
+    UNIQUE (sprecher, fraktion)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS rede_sprecher_review;
+DROP INDEX IF EXISTS idx_rede_mdb_id;
+DROP INDEX IF EXISTS idx_rede_person_id;
+ALTER TABLE rede DROP COLUMN sprecher_methode;
+ALTER TABLE rede DROP COLUMN sprecher_confidence;
+ALTER TABLE rede DROP COLUMN mdb_id;
+ALTER TABLE rede DROP COLUMN person_id;
+-- +goose StatementEnd
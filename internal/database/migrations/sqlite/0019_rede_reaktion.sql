@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Reactions to Reden parsed from the stage directions of the Plenarprotokolle
+-- ("(Beifall bei der SPD)", "(Zuruf des Abg. Friedrich Merz [CDU/CSU]: Unsinn!)")
+-- by process-plenarprotokoll-reden (see internal/protokoll/reaktionen.go).
+-- A stage direction yields one row per kind and reacting Fraktion or member.
+
+CREATE TABLE rede_reaktion (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    rede_id INTEGER NOT NULL REFERENCES rede(id) ON DELETE CASCADE,
+    plenarprotokoll_id TEXT NOT NULL REFERENCES plenarprotokoll(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,              -- order within the Rede
+    kommentar INTEGER NOT NULL,             -- index of the stage direction within the Rede
+    art TEXT NOT NULL CHECK (art IN ('Beifall', 'Heiterkeit', 'Zuruf', 'Widerspruch', 'Unruhe')),
+    fraktion TEXT,                          -- normalized, e.g. BÜNDNIS 90/DIE GRÜNEN; NULL for the whole house
+    abgeordnete INTEGER NOT NULL DEFAULT 0, -- 1 if only some members of the Fraktion reacted
+    sprecher TEXT,                          -- reacting member, e.g. Friedrich Merz
+    person_id TEXT,                         -- person.id of the member, if resolved
+    mdb_id TEXT,                            -- mdb_person.id of the member, if resolved
+    text TEXT,                              -- what was called out
+    kommentar_offset INTEGER NOT NULL,      -- character offset of the stage direction in rede.text
+    roh TEXT NOT NULL,                      -- the part of the stage direction
+    UNIQUE (rede_id, position)
+);
+
+CREATE INDEX idx_rede_reaktion_plenarprotokoll_id ON rede_reaktion(plenarprotokoll_id);
+CREATE INDEX idx_rede_reaktion_art_fraktion ON rede_reaktion(art, fraktion);
+CREATE INDEX idx_rede_reaktion_person_id ON rede_reaktion(person_id);
+CREATE INDEX idx_rede_reaktion_mdb_id ON rede_reaktion(mdb_id);
+
+-- Reactions of Fraktionen to the Reden of Fraktionen per Wahlperiode, e.g.
+-- SELECT * FROM rede_reaktion_fraktion WHERE wahlperiode = 20 AND art = 'Beifall'
+CREATE VIEW rede_reaktion_fraktion AS
+SELECT
+    p.wahlperiode,
+    r.fraktion AS redner_fraktion,
+    rr.fraktion AS reagierende_fraktion,
+    rr.art,
+    COUNT(*) AS anzahl,
+    COUNT(DISTINCT rr.rede_id) AS reden
+FROM rede_reaktion rr
+JOIN rede r ON r.id = rr.rede_id
+JOIN plenarprotokoll p ON p.id = rr.plenarprotokoll_id
+WHERE r.fraktion IS NOT NULL AND rr.fraktion IS NOT NULL
+GROUP BY p.wahlperiode, r.fraktion, rr.fraktion, rr.art;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW IF EXISTS rede_reaktion_fraktion;
+DROP TABLE IF EXISTS rede_reaktion;
+-- +goose StatementEnd
@@ -0,0 +1,88 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Structured Plenarprotokoll XML (DTD dbtplenarprotokoll, Wahlperiode 18 onwards)
+-- loaded by sync-plenarprotokoll-xml from plenarprotokoll.fundstelle_xml_url,
+-- local files or an offline archive (see internal/plenarxml).
+-- A session is identified by its dokumentnummer (e.g. 20/123); plenarprotokoll_id
+-- is set when the Plenarprotokoll was synced from DIP.
+-- redner_id is the ID of the MdB Stammdaten (mdb_person.id) for members.
+
+CREATE TABLE plenarprotokoll_xml (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    dokumentnummer TEXT NOT NULL UNIQUE,     -- e.g. 20/123
+    plenarprotokoll_id TEXT,                 -- plenarprotokoll.id
+    wahlperiode INTEGER NOT NULL,
+    sitzung_nr INTEGER NOT NULL,
+    datum TEXT,                              -- YYYY-MM-DD
+    start_uhrzeit TEXT,
+    ende_uhrzeit TEXT,
+    ort TEXT,
+    version TEXT,                            -- version attribute of the document
+    quelle TEXT NOT NULL,                    -- URL, file or archive entry the document was loaded from
+    sha256 TEXT NOT NULL,                    -- of the document, unchanged documents are skipped
+    dtd_validiert INTEGER NOT NULL DEFAULT 0, -- 1 if the document was validated against the DTD
+    loaded_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX idx_plenarprotokoll_xml_plenarprotokoll_id ON plenarprotokoll_xml(plenarprotokoll_id);
+
+CREATE TABLE plenarprotokoll_xml_redner (
+    protokoll_xml_id INTEGER NOT NULL REFERENCES plenarprotokoll_xml(id) ON DELETE CASCADE,
+    redner_id TEXT NOT NULL,                 -- mdb_person.id for members
+    titel TEXT,
+    vorname TEXT,
+    namenszusatz TEXT,
+    nachname TEXT,
+    ortszusatz TEXT,
+    fraktion TEXT,
+    rolle_lang TEXT,                         -- e.g. Bundesminister der Finanzen
+    rolle_kurz TEXT,
+    bundesland TEXT,                         -- for members of the Bundesrat
+    PRIMARY KEY (protokoll_xml_id, redner_id)
+);
+
+CREATE INDEX idx_plenarprotokoll_xml_redner_redner_id ON plenarprotokoll_xml_redner(redner_id);
+
+CREATE TABLE plenarprotokoll_xml_tagesordnungspunkt (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    protokoll_xml_id INTEGER NOT NULL REFERENCES plenarprotokoll_xml(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    top_id TEXT,                             -- e.g. Tagesordnungspunkt 3, Zusatzpunkt 5
+    titel TEXT,
+    UNIQUE (protokoll_xml_id, position)
+);
+
+CREATE TABLE plenarprotokoll_xml_rede (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    protokoll_xml_id INTEGER NOT NULL REFERENCES plenarprotokoll_xml(id) ON DELETE CASCADE,
+    tagesordnungspunkt_id INTEGER NOT NULL REFERENCES plenarprotokoll_xml_tagesordnungspunkt(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,               -- order within the Plenarprotokoll
+    rede_id TEXT,                            -- id attribute, e.g. ID2012300100
+    redner_id TEXT,                          -- the speaker giving the Rede
+    UNIQUE (protokoll_xml_id, position)
+);
+
+CREATE INDEX idx_plenarprotokoll_xml_rede_redner_id ON plenarprotokoll_xml_rede(redner_id);
+
+CREATE TABLE plenarprotokoll_xml_absatz (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    rede_id INTEGER NOT NULL REFERENCES plenarprotokoll_xml_rede(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,               -- order within the Rede
+    typ TEXT NOT NULL CHECK (typ IN ('p', 'kommentar', 'name')),
+    klasse TEXT,                             -- formatting class of paragraphs, redner for the speaker line
+    redner_id TEXT,                          -- current speaker, NULL while the chair speaks
+    sprecher TEXT,                           -- name line of the chair, e.g. Präsidentin Bärbel Bas
+    text TEXT NOT NULL,
+    UNIQUE (rede_id, position)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS plenarprotokoll_xml_absatz;
+DROP TABLE IF EXISTS plenarprotokoll_xml_rede;
+DROP TABLE IF EXISTS plenarprotokoll_xml_tagesordnungspunkt;
+DROP TABLE IF EXISTS plenarprotokoll_xml_redner;
+DROP TABLE IF EXISTS plenarprotokoll_xml;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Local copies of the PDFs of Drucksachen and Plenarprotokolle, downloaded by
+-- sync-pdfs into a content-addressed store (see internal/pdfstore).
+-- pdf_hash is the MD5 checksum given by DIP; Drucksachen and Plenarprotokolle
+-- join on it, so a PDF referenced by several documents is stored once.
+
+CREATE TABLE dokument_pdf (
+    pdf_hash TEXT PRIMARY KEY,               -- MD5, lower case hex
+    pfad TEXT NOT NULL,                      -- path relative to the store root, e.g. a3/3a/a33af31e7c4524db8db172ef8f9e0f6d.pdf
+    groesse INTEGER NOT NULL,                -- bytes
+    url TEXT NOT NULL,                       -- fundstelle_pdf_url the file was downloaded from
+    gespeichert_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+-- Failed downloads, e.g. a PDF whose content does not match its pdf_hash.
+-- Removed once the PDF was stored.
+CREATE TABLE dokument_pdf_fehler (
+    pdf_hash TEXT PRIMARY KEY,
+    url TEXT NOT NULL,
+    fehler TEXT NOT NULL,
+    versuche INTEGER NOT NULL DEFAULT 1,
+    letzter_versuch_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX idx_drucksache_pdf_hash ON drucksache(pdf_hash);
+CREATE INDEX idx_plenarprotokoll_pdf_hash ON plenarprotokoll(pdf_hash);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_plenarprotokoll_pdf_hash;
+DROP INDEX IF EXISTS idx_drucksache_pdf_hash;
+DROP TABLE IF EXISTS dokument_pdf_fehler;
+DROP TABLE IF EXISTS dokument_pdf;
+-- +goose StatementEnd
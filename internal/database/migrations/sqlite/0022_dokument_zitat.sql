@@ -0,0 +1,70 @@
+-- +goose Up
+-- +goose StatementBegin
+-- References between documents, extracted by process-dokument-zitate from the
+-- texts of Drucksachen and Plenarprotokolle (see internal/zitat), e.g.
+-- "Drucksache 20/1234" or "Plenarprotokoll 20/45".
+-- One row per citing document and cited document. The cited document is resolved
+-- to ziel_id via its dokumentnummer; references to documents that are not synced
+-- keep ziel_id NULL and are resolved on later runs.
+-- dokument_zitat_verarbeitung records which text version was processed with
+-- which parser version, so only new or changed texts are processed again.
+
+CREATE TABLE dokument_zitat (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    quelle_dokumentart TEXT NOT NULL CHECK (quelle_dokumentart IN ('Drucksache', 'Plenarprotokoll')),
+    quelle_id TEXT NOT NULL,                 -- drucksache.id or plenarprotokoll.id
+    ziel_dokumentart TEXT NOT NULL CHECK (ziel_dokumentart IN ('Drucksache', 'Plenarprotokoll')),
+    ziel_herausgeber TEXT NOT NULL CHECK (ziel_herausgeber IN ('BT', 'BR')),
+    ziel_dokumentnummer TEXT NOT NULL,       -- e.g. 20/1234
+    ziel_id TEXT,                            -- drucksache.id or plenarprotokoll.id, NULL if not synced
+    anzahl INTEGER NOT NULL,                 -- number of references in the text
+    erster_offset INTEGER NOT NULL,          -- character offset of the first reference
+    roh TEXT NOT NULL,                       -- first reference as written, e.g. BT-Drs. 20/1234
+    UNIQUE (quelle_dokumentart, quelle_id, ziel_dokumentart, ziel_herausgeber, ziel_dokumentnummer)
+);
+
+CREATE INDEX idx_dokument_zitat_ziel ON dokument_zitat(ziel_dokumentart, ziel_id);
+CREATE INDEX idx_dokument_zitat_ziel_dokumentnummer ON dokument_zitat(ziel_herausgeber, ziel_dokumentnummer);
+
+CREATE TABLE dokument_zitat_verarbeitung (
+    dokumentart TEXT NOT NULL,
+    dokument_id TEXT NOT NULL,
+    parser_version INTEGER NOT NULL,
+    text_updated_at TEXT NOT NULL,           -- updated_at of the processed text
+    zitate_anzahl INTEGER NOT NULL,
+    processed_at TEXT NOT NULL DEFAULT (datetime('now')),
+    PRIMARY KEY (dokumentart, dokument_id)
+);
+
+-- Citations between Drucksachen, with whether the API links both to a common Vorgang
+CREATE VIEW drucksache_zitat AS
+SELECT
+    z.quelle_id AS drucksache_id,
+    q.dokumentnummer AS drucksache_dokumentnummer,
+    q.wahlperiode,
+    z.ziel_id AS zitiert_id,
+    z.ziel_dokumentnummer AS zitiert_dokumentnummer,
+    z.anzahl,
+    EXISTS (
+        SELECT 1
+        FROM drucksache_vorgangsbezug a
+        JOIN drucksache_vorgangsbezug b ON b.vorgang_id = a.vorgang_id
+        WHERE a.drucksache_id = z.quelle_id AND b.drucksache_id = z.ziel_id
+    ) AS gemeinsamer_vorgang
+FROM dokument_zitat z
+JOIN drucksache q ON q.id = z.quelle_id
+WHERE z.quelle_dokumentart = 'Drucksache'
+  AND z.ziel_dokumentart = 'Drucksache'
+  AND z.ziel_herausgeber = 'BT'
+  AND z.ziel_id IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW IF EXISTS drucksache_zitat;
+DROP TABLE IF EXISTS dokument_zitat_verarbeitung;
+DROP INDEX IF EXISTS idx_dokument_zitat_ziel_dokumentnummer;
+DROP INDEX IF EXISTS idx_dokument_zitat_ziel;
+DROP TABLE IF EXISTS dokument_zitat;
+-- +goose StatementEnd
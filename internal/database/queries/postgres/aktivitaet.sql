@@ -26,6 +26,9 @@ LEFT JOIN aktivitaet_vorgangsbezug avb ON a.id = avb.aktivitaet_id
 WHERE a.id = $1
 GROUP BY a.id;
 
+-- name: GetLatestAktivitaetDatum :one
+SELECT MIN(datum) AS datum FROM aktivitaet;
+
 -- name: ListAktivitaeten :many
 SELECT 
     a.*,
@@ -71,14 +74,60 @@ INSERT INTO aktivitaet (
     fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
     fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
     fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
-    fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
     fundstelle_frage_nummer, fundstelle_verteildatum
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
     $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
-    $21, $22, $23, $24, $25, $26, $27
+    $21, $22, $23, $24, $25, $26, $27, $28
 ) RETURNING *;
 
+-- name: UpsertAktivitaet :exec
+INSERT INTO aktivitaet (
+    id, titel, aktivitaetsart, typ, dokumentart, datum, aktualisiert,
+    abstract, vorgangsbezug_anzahl, wahlperiode,
+    fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+    fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
+    fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
+    fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_frage_nummer, fundstelle_verteildatum
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+    $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+    $21, $22, $23, $24, $25, $26, $27, $28
+) ON CONFLICT (id) DO UPDATE
+SET
+    titel = excluded.titel,
+    aktivitaetsart = excluded.aktivitaetsart,
+    typ = excluded.typ,
+    dokumentart = excluded.dokumentart,
+    datum = excluded.datum,
+    aktualisiert = excluded.aktualisiert,
+    abstract = excluded.abstract,
+    vorgangsbezug_anzahl = excluded.vorgangsbezug_anzahl,
+    wahlperiode = excluded.wahlperiode,
+    fundstelle_dokumentnummer = excluded.fundstelle_dokumentnummer,
+    fundstelle_datum = excluded.fundstelle_datum,
+    fundstelle_dokumentart = excluded.fundstelle_dokumentart,
+    fundstelle_herausgeber = excluded.fundstelle_herausgeber,
+    fundstelle_id = excluded.fundstelle_id,
+    fundstelle_drucksachetyp = excluded.fundstelle_drucksachetyp,
+    fundstelle_anlagen = excluded.fundstelle_anlagen,
+    fundstelle_anfangsseite = excluded.fundstelle_anfangsseite,
+    fundstelle_endseite = excluded.fundstelle_endseite,
+    fundstelle_anfangsquadrant = excluded.fundstelle_anfangsquadrant,
+    fundstelle_endquadrant = excluded.fundstelle_endquadrant,
+    fundstelle_seite = excluded.fundstelle_seite,
+    fundstelle_pdf_url = excluded.fundstelle_pdf_url,
+    fundstelle_xml_url = excluded.fundstelle_xml_url,
+    fundstelle_top = excluded.fundstelle_top,
+    fundstelle_top_zusatz = excluded.fundstelle_top_zusatz,
+    fundstelle_frage_nummer = excluded.fundstelle_frage_nummer,
+    fundstelle_verteildatum = excluded.fundstelle_verteildatum,
+    deleted_at = NULL,
+    updated_at = NOW();
+
 -- name: UpdateAktivitaet :one
 UPDATE aktivitaet
 SET 
@@ -87,6 +136,7 @@ SET
     aktualisiert = $4,
     abstract = $5,
     vorgangsbezug_anzahl = $6,
+    deleted_at = NULL,
     updated_at = NOW()
 WHERE id = $1
 RETURNING *;
@@ -94,6 +144,12 @@ RETURNING *;
 -- name: DeleteAktivitaet :exec
 DELETE FROM aktivitaet WHERE id = $1;
 
+-- name: DeleteAktivitaetDeskriptoren :exec
+DELETE FROM aktivitaet_deskriptor WHERE aktivitaet_id = $1;
+
+-- name: DeleteAktivitaetVorgangsbezuege :exec
+DELETE FROM aktivitaet_vorgangsbezug WHERE aktivitaet_id = $1;
+
 -- name: CreateAktivitaetDeskriptor :one
 INSERT INTO aktivitaet_deskriptor (aktivitaet_id, name, typ)
 VALUES ($1, $2, $3)
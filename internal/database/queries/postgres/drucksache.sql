@@ -51,6 +51,9 @@ LEFT JOIN drucksache_vorgangsbezug dvb ON d.id = dvb.drucksache_id
 WHERE d.id = $1
 GROUP BY d.id;
 
+-- name: GetLatestDrucksacheDatum :one
+SELECT MIN(datum) AS datum FROM drucksache;
+
 -- name: ListDrucksachen :many
 SELECT 
     d.*,
@@ -122,14 +125,65 @@ INSERT INTO drucksache (
     fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
     fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
     fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
-    fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
     fundstelle_frage_nummer, fundstelle_verteildatum
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
     $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
-    $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31
+    $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32
 ) RETURNING *;
 
+-- name: UpsertDrucksache :exec
+INSERT INTO drucksache (
+    id, titel, dokumentnummer, dokumentart, typ, drucksachetyp, herausgeber,
+    datum, aktualisiert, anlagen, autoren_anzahl, vorgangsbezug_anzahl,
+    pdf_hash, wahlperiode,
+    fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+    fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
+    fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
+    fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_frage_nummer, fundstelle_verteildatum
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+    $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+    $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32
+) ON CONFLICT (id) DO UPDATE
+SET
+    titel = excluded.titel,
+    dokumentnummer = excluded.dokumentnummer,
+    dokumentart = excluded.dokumentart,
+    typ = excluded.typ,
+    drucksachetyp = excluded.drucksachetyp,
+    herausgeber = excluded.herausgeber,
+    datum = excluded.datum,
+    aktualisiert = excluded.aktualisiert,
+    anlagen = excluded.anlagen,
+    autoren_anzahl = excluded.autoren_anzahl,
+    vorgangsbezug_anzahl = excluded.vorgangsbezug_anzahl,
+    pdf_hash = excluded.pdf_hash,
+    wahlperiode = excluded.wahlperiode,
+    fundstelle_dokumentnummer = excluded.fundstelle_dokumentnummer,
+    fundstelle_datum = excluded.fundstelle_datum,
+    fundstelle_dokumentart = excluded.fundstelle_dokumentart,
+    fundstelle_herausgeber = excluded.fundstelle_herausgeber,
+    fundstelle_id = excluded.fundstelle_id,
+    fundstelle_drucksachetyp = excluded.fundstelle_drucksachetyp,
+    fundstelle_anlagen = excluded.fundstelle_anlagen,
+    fundstelle_anfangsseite = excluded.fundstelle_anfangsseite,
+    fundstelle_endseite = excluded.fundstelle_endseite,
+    fundstelle_anfangsquadrant = excluded.fundstelle_anfangsquadrant,
+    fundstelle_endquadrant = excluded.fundstelle_endquadrant,
+    fundstelle_seite = excluded.fundstelle_seite,
+    fundstelle_pdf_url = excluded.fundstelle_pdf_url,
+    fundstelle_xml_url = excluded.fundstelle_xml_url,
+    fundstelle_top = excluded.fundstelle_top,
+    fundstelle_top_zusatz = excluded.fundstelle_top_zusatz,
+    fundstelle_frage_nummer = excluded.fundstelle_frage_nummer,
+    fundstelle_verteildatum = excluded.fundstelle_verteildatum,
+    deleted_at = NULL,
+    updated_at = NOW();
+
 -- name: UpdateDrucksache :one
 UPDATE drucksache
 SET 
@@ -139,6 +193,7 @@ SET
     autoren_anzahl = $5,
     vorgangsbezug_anzahl = $6,
     pdf_hash = $7,
+    deleted_at = NULL,
     updated_at = NOW()
 WHERE id = $1
 RETURNING *;
@@ -146,6 +201,21 @@ RETURNING *;
 -- name: DeleteDrucksache :exec
 DELETE FROM drucksache WHERE id = $1;
 
+-- name: DeleteDrucksacheAutorAnzeigen :exec
+DELETE FROM drucksache_autor_anzeige WHERE drucksache_id = $1;
+
+-- name: DeleteDrucksacheRessorts :exec
+DELETE FROM drucksache_ressort WHERE drucksache_id = $1;
+
+-- name: DeleteDrucksacheUrheber :exec
+DELETE FROM drucksache_urheber WHERE drucksache_id = $1;
+
+-- name: DeleteDrucksacheVorgangsbezuege :exec
+DELETE FROM drucksache_vorgangsbezug WHERE drucksache_id = $1;
+
+-- name: DeleteDrucksacheFundstelleUrheber :exec
+DELETE FROM fundstelle_urheber WHERE drucksache_id = $1;
+
 -- name: CreateDrucksacheAutorAnzeige :one
 INSERT INTO drucksache_autor_anzeige (
     drucksache_id, person_id, autor_titel, title, display_order
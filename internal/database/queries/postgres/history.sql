@@ -0,0 +1,102 @@
+-- Queries for the optional change history of synced entities.
+
+-- name: CreateSyncRun :exec
+INSERT INTO sync_run (id, resource_name)
+VALUES ($1, $2)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: CreatePersonHistory :exec
+INSERT INTO person_history (person_id, aktualisiert, sync_run_id, snapshot, diff)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListPersonHistory :many
+SELECT * FROM person_history
+WHERE person_id = $1
+ORDER BY aktualisiert DESC, id DESC;
+
+-- name: GetPersonHistoryAsOf :one
+-- Returns the latest previous version that was current at the given time
+SELECT * FROM person_history
+WHERE person_id = $1 AND aktualisiert <= sqlc.arg(as_of)
+ORDER BY aktualisiert DESC, id DESC
+LIMIT 1;
+
+-- name: CreateVorgangHistory :exec
+INSERT INTO vorgang_history (vorgang_id, aktualisiert, sync_run_id, snapshot, diff)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListVorgangHistory :many
+SELECT * FROM vorgang_history
+WHERE vorgang_id = $1
+ORDER BY aktualisiert DESC, id DESC;
+
+-- name: GetVorgangHistoryAsOf :one
+-- Returns the latest previous version that was current at the given time
+SELECT * FROM vorgang_history
+WHERE vorgang_id = $1 AND aktualisiert <= sqlc.arg(as_of)
+ORDER BY aktualisiert DESC, id DESC
+LIMIT 1;
+
+-- name: CreateVorgangspositionHistory :exec
+INSERT INTO vorgangsposition_history (vorgangsposition_id, aktualisiert, sync_run_id, snapshot, diff)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListVorgangspositionHistory :many
+SELECT * FROM vorgangsposition_history
+WHERE vorgangsposition_id = $1
+ORDER BY aktualisiert DESC, id DESC;
+
+-- name: GetVorgangspositionHistoryAsOf :one
+-- Returns the latest previous version that was current at the given time
+SELECT * FROM vorgangsposition_history
+WHERE vorgangsposition_id = $1 AND aktualisiert <= sqlc.arg(as_of)
+ORDER BY aktualisiert DESC, id DESC
+LIMIT 1;
+
+-- name: CreateAktivitaetHistory :exec
+INSERT INTO aktivitaet_history (aktivitaet_id, aktualisiert, sync_run_id, snapshot, diff)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListAktivitaetHistory :many
+SELECT * FROM aktivitaet_history
+WHERE aktivitaet_id = $1
+ORDER BY aktualisiert DESC, id DESC;
+
+-- name: GetAktivitaetHistoryAsOf :one
+-- Returns the latest previous version that was current at the given time
+SELECT * FROM aktivitaet_history
+WHERE aktivitaet_id = $1 AND aktualisiert <= sqlc.arg(as_of)
+ORDER BY aktualisiert DESC, id DESC
+LIMIT 1;
+
+-- name: CreateDrucksacheHistory :exec
+INSERT INTO drucksache_history (drucksache_id, aktualisiert, sync_run_id, snapshot, diff)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListDrucksacheHistory :many
+SELECT * FROM drucksache_history
+WHERE drucksache_id = $1
+ORDER BY aktualisiert DESC, id DESC;
+
+-- name: GetDrucksacheHistoryAsOf :one
+-- Returns the latest previous version that was current at the given time
+SELECT * FROM drucksache_history
+WHERE drucksache_id = $1 AND aktualisiert <= sqlc.arg(as_of)
+ORDER BY aktualisiert DESC, id DESC
+LIMIT 1;
+
+-- name: CreatePlenarprotokollHistory :exec
+INSERT INTO plenarprotokoll_history (plenarprotokoll_id, aktualisiert, sync_run_id, snapshot, diff)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListPlenarprotokollHistory :many
+SELECT * FROM plenarprotokoll_history
+WHERE plenarprotokoll_id = $1
+ORDER BY aktualisiert DESC, id DESC;
+
+-- name: GetPlenarprotokollHistoryAsOf :one
+-- Returns the latest previous version that was current at the given time
+SELECT * FROM plenarprotokoll_history
+WHERE plenarprotokoll_id = $1 AND aktualisiert <= sqlc.arg(as_of)
+ORDER BY aktualisiert DESC, id DESC
+LIMIT 1;
@@ -0,0 +1,398 @@
+-- MdB Stammdaten Queries
+-- Queries for importing and managing MdB biographical master data
+
+-- ============================================================================
+-- VERSION MANAGEMENT
+-- ============================================================================
+
+-- name: CreateMdbStammdatenVersion :one
+INSERT INTO mdb_stammdaten_version (version, import_date, source_file)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetLatestMdbStammdatenVersion :one
+SELECT * FROM mdb_stammdaten_version
+ORDER BY import_date DESC
+LIMIT 1;
+
+-- name: ListMdbStammdatenVersions :many
+SELECT * FROM mdb_stammdaten_version
+ORDER BY import_date DESC;
+
+-- ============================================================================
+-- PERSON MANAGEMENT
+-- ============================================================================
+
+-- name: CreateMdbPerson :exec
+INSERT INTO mdb_person (id, created_at, updated_at)
+VALUES ($1, $2, $3);
+
+-- name: GetMdbPerson :one
+SELECT * FROM mdb_person WHERE id = $1;
+
+-- name: ListMdbPersons :many
+SELECT * FROM mdb_person
+ORDER BY id
+LIMIT $1 OFFSET $2;
+
+-- name: DeleteMdbPerson :exec
+DELETE FROM mdb_person WHERE id = $1;
+
+-- ============================================================================
+-- NAME MANAGEMENT
+-- ============================================================================
+
+-- name: CreateMdbName :exec
+INSERT INTO mdb_name (
+    mdb_id, nachname, vorname, ortszusatz, adel, praefix,
+    anrede_titel, akad_titel, historie_von, historie_bis,
+    created_at, updated_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
+
+-- name: GetMdbNames :many
+SELECT * FROM mdb_name
+WHERE mdb_id = $1
+ORDER BY 
+    CASE WHEN historie_von IS NULL OR historie_von = '' THEN 0 ELSE 1 END,
+    historie_von;
+
+-- name: GetMdbCurrentName :one
+SELECT * FROM mdb_name
+WHERE mdb_id = $1
+AND (historie_von IS NULL OR historie_von = '')
+LIMIT 1;
+
+-- name: DeleteMdbNames :exec
+DELETE FROM mdb_name WHERE mdb_id = $1;
+
+-- ============================================================================
+-- BIOGRAPHICAL DATA MANAGEMENT
+-- ============================================================================
+
+-- name: CreateMdbBiographical :exec
+INSERT INTO mdb_biographical (
+    mdb_id, geburtsdatum, geburtsort, geburtsland, sterbedatum,
+    geschlecht, familienstand, religion, beruf, vita_kurz,
+    veroeffentlichungspflichtiges, partei_kurz,
+    created_at, updated_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14);
+
+-- name: GetMdbBiographical :one
+SELECT * FROM mdb_biographical WHERE mdb_id = $1;
+
+-- name: UpdateMdbBiographical :exec
+UPDATE mdb_biographical
+SET 
+    geburtsdatum = $1,
+    geburtsort = $2,
+    geburtsland = $3,
+    sterbedatum = $4,
+    geschlecht = $5,
+    familienstand = $6,
+    religion = $7,
+    beruf = $8,
+    vita_kurz = $9,
+    veroeffentlichungspflichtiges = $10,
+    partei_kurz = $11,
+    updated_at = NOW()
+WHERE mdb_id = $12;
+
+-- name: DeleteMdbBiographical :exec
+DELETE FROM mdb_biographical WHERE mdb_id = $1;
+
+-- ============================================================================
+-- WAHLPERIODE MEMBERSHIP MANAGEMENT
+-- ============================================================================
+
+-- name: CreateMdbWahlperiodeMembership :one
+INSERT INTO mdb_wahlperiode_membership (
+    mdb_id, wp, mdbwp_von, mdbwp_bis, wkr_nummer, wkr_name,
+    wkr_land, liste, mandatsart,
+    created_at, updated_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id;
+
+-- name: GetMdbWahlperiodeMemberships :many
+SELECT * FROM mdb_wahlperiode_membership
+WHERE mdb_id = $1
+ORDER BY wp;
+
+-- name: GetMdbWahlperiodeMembership :one
+SELECT * FROM mdb_wahlperiode_membership
+WHERE mdb_id = $1 AND wp = $2;
+
+-- name: DeleteMdbWahlperiodeMemberships :exec
+DELETE FROM mdb_wahlperiode_membership WHERE mdb_id = $1;
+
+-- ============================================================================
+-- INSTITUTION MEMBERSHIP MANAGEMENT
+-- ============================================================================
+
+-- name: CreateMdbInstitutionMembership :exec
+INSERT INTO mdb_institution_membership (
+    mdb_wahlperiode_membership_id, insart_lang, ins_lang,
+    mdbins_von, mdbins_bis, fkt_lang, fktins_von, fktins_bis,
+    created_at, updated_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: GetMdbInstitutionMemberships :many
+SELECT * FROM mdb_institution_membership
+WHERE mdb_wahlperiode_membership_id = $1
+ORDER BY mdbins_von;
+
+-- name: GetMdbInstitutionMembershipsByMdbId :many
+SELECT im.* 
+FROM mdb_institution_membership im
+JOIN mdb_wahlperiode_membership wm ON im.mdb_wahlperiode_membership_id = wm.id
+WHERE wm.mdb_id = $1
+ORDER BY wm.wp, im.mdbins_von;
+
+-- name: DeleteMdbInstitutionMembershipsByWahlperiode :exec
+DELETE FROM mdb_institution_membership
+WHERE mdb_wahlperiode_membership_id = $1;
+
+-- ============================================================================
+-- VIEWS AND COMPLEX QUERIES
+-- ============================================================================
+
+-- name: GetMdbCurrentMembers :many
+SELECT * FROM mdb_current_members
+ORDER BY nachname, vorname;
+
+-- name: GetMdbFullName :one
+SELECT * FROM mdb_full_names
+WHERE mdb_id = $1;
+
+-- name: GetMdbCareerSummary :one
+SELECT * FROM mdb_career_summary
+WHERE mdb_id = $1;
+
+-- name: GetMdbFraktionSummaryByWP :many
+SELECT * FROM mdb_fraktion_summary
+WHERE wahlperiode = $1
+ORDER BY anzahl_mitglieder DESC;
+
+-- name: GetMdbFraktionSummaryAll :many
+SELECT * FROM mdb_fraktion_summary
+ORDER BY wahlperiode DESC, anzahl_mitglieder DESC;
+
+-- ============================================================================
+-- SEARCH AND LOOKUP QUERIES
+-- ============================================================================
+
+-- name: SearchMdbByName :many
+SELECT DISTINCT
+    mp.id,
+    mn.nachname,
+    mn.vorname,
+    mn.ortszusatz,
+    mn.anrede_titel,
+    mn.akad_titel,
+    mb.partei_kurz,
+    mb.geschlecht
+FROM mdb_person mp
+JOIN mdb_name mn ON mp.id = mn.mdb_id
+LEFT JOIN mdb_biographical mb ON mp.id = mb.mdb_id
+WHERE 
+    (sqlc.arg(nachname)::text = '' OR LOWER(mn.nachname) LIKE LOWER('%' || sqlc.arg(nachname)::text || '%'))
+    AND (sqlc.arg(vorname)::text = '' OR LOWER(mn.vorname) LIKE LOWER('%' || sqlc.arg(vorname)::text || '%'))
+ORDER BY mn.nachname, mn.vorname
+LIMIT sqlc.arg(lim) OFFSET sqlc.arg(off);
+
+-- name: GetMdbByPartei :many
+SELECT DISTINCT
+    mp.id,
+    mn.nachname,
+    mn.vorname,
+    mb.partei_kurz
+FROM mdb_person mp
+JOIN mdb_name mn ON mp.id = mn.mdb_id
+JOIN mdb_biographical mb ON mp.id = mb.mdb_id
+WHERE mb.partei_kurz = $1
+AND (mn.historie_von IS NULL OR mn.historie_von = '')
+ORDER BY mn.nachname, mn.vorname;
+
+-- name: GetMdbByWahlperiode :many
+SELECT 
+    mp.id,
+    mn.nachname,
+    mn.vorname,
+    mb.partei_kurz,
+    mwm.wp,
+    mwm.mandatsart
+FROM mdb_person mp
+JOIN mdb_name mn ON mp.id = mn.mdb_id
+JOIN mdb_biographical mb ON mp.id = mb.mdb_id
+JOIN mdb_wahlperiode_membership mwm ON mp.id = mwm.mdb_id
+WHERE mwm.wp = $1
+AND (mn.historie_von IS NULL OR mn.historie_von = '')
+ORDER BY mn.nachname, mn.vorname;
+
+-- name: GetMdbWithFullDetails :one
+SELECT 
+    mp.id,
+    mn.nachname,
+    mn.vorname,
+    mn.ortszusatz,
+    mn.adel,
+    mn.praefix,
+    mn.anrede_titel,
+    mn.akad_titel,
+    mb.geburtsdatum,
+    mb.geburtsort,
+    mb.geburtsland,
+    mb.sterbedatum,
+    mb.geschlecht,
+    mb.familienstand,
+    mb.religion,
+    mb.beruf,
+    mb.vita_kurz,
+    mb.veroeffentlichungspflichtiges,
+    mb.partei_kurz
+FROM mdb_person mp
+JOIN mdb_name mn ON mp.id = mn.mdb_id
+LEFT JOIN mdb_biographical mb ON mp.id = mb.mdb_id
+WHERE mp.id = $1
+AND (mn.historie_von IS NULL OR mn.historie_von = '');
+
+-- ============================================================================
+-- STATISTICS AND COUNTS
+-- ============================================================================
+
+-- name: CountMdbPersons :one
+SELECT COUNT(*) FROM mdb_person;
+
+-- name: CountMdbNames :one
+SELECT COUNT(*) FROM mdb_name;
+
+-- name: CountMdbBiographical :one
+SELECT COUNT(*) FROM mdb_biographical;
+
+-- name: CountMdbWahlperiodeMemberships :one
+SELECT COUNT(*) FROM mdb_wahlperiode_membership;
+
+-- name: CountMdbInstitutionMemberships :one
+SELECT COUNT(*) FROM mdb_institution_membership;
+
+-- name: GetMdbStatsByWahlperiode :many
+SELECT 
+    wp,
+    COUNT(*) as anzahl_mitglieder,
+    COUNT(DISTINCT CASE WHEN mandatsart = 'Direktmandat' THEN mdb_id END) as direktmandate,
+    COUNT(DISTINCT CASE WHEN mandatsart = 'Landesliste' THEN mdb_id END) as listenmandate
+FROM mdb_wahlperiode_membership
+GROUP BY wp
+ORDER BY wp;
+
+-- name: GetMdbStatsByGeschlecht :one
+SELECT 
+    COUNT(CASE WHEN geschlecht = 'männlich' THEN 1 END) as maennlich,
+    COUNT(CASE WHEN geschlecht = 'weiblich' THEN 1 END) as weiblich,
+    COUNT(CASE WHEN geschlecht IS NULL OR geschlecht = '' THEN 1 END) as unbekannt
+FROM mdb_biographical;
+
+-- name: GetMdbStatsByPartei :many
+SELECT 
+    partei_kurz,
+    COUNT(*) as anzahl
+FROM mdb_biographical
+WHERE partei_kurz IS NOT NULL AND partei_kurz != ''
+GROUP BY partei_kurz
+ORDER BY anzahl DESC;
+
+-- ============================================================================
+-- PERSON-MDB LINKING
+-- ============================================================================
+
+-- name: CreatePersonMdbLink :exec
+INSERT INTO person_mdb_link (
+    person_id, mdb_id, match_confidence, match_method,
+    verified_by, verified_at, notes,
+    created_at, updated_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: GetPersonMdbLinks :many
+SELECT 
+    person_id, mdb_id, match_confidence, match_method,
+    verified_by, verified_at, notes,
+    created_at, updated_at
+FROM person_mdb_link
+WHERE person_id = $1;
+
+-- name: GetMdbPersonLinks :many
+SELECT 
+    person_id, mdb_id, match_confidence, match_method,
+    verified_by, verified_at, notes,
+    created_at, updated_at
+FROM person_mdb_link
+WHERE mdb_id = $1;
+
+-- name: GetPersonMdbLink :one
+SELECT 
+    person_id, mdb_id, match_confidence, match_method,
+    verified_by, verified_at, notes,
+    created_at, updated_at
+FROM person_mdb_link
+WHERE person_id = $1 AND mdb_id = $2;
+
+-- name: UpdatePersonMdbLinkConfidence :exec
+UPDATE person_mdb_link
+SET 
+    match_confidence = $1,
+    match_method = $2,
+    updated_at = NOW()
+WHERE person_id = $3 AND mdb_id = $4;
+
+-- name: VerifyPersonMdbLink :exec
+UPDATE person_mdb_link
+SET 
+    match_confidence = 'manual',
+    verified_by = $1,
+    verified_at = $2,
+    notes = $3,
+    updated_at = NOW()
+WHERE person_id = $4 AND mdb_id = $5;
+
+-- name: DeletePersonMdbLink :exec
+DELETE FROM person_mdb_link
+WHERE person_id = $1 AND mdb_id = $2;
+
+-- name: GetUnlinkedDIPPersons :many
+SELECT p.id, p.vorname, p.nachname
+FROM person p
+WHERE NOT EXISTS (
+    SELECT 1 FROM person_mdb_link WHERE person_id = p.id
+)
+ORDER BY p.nachname, p.vorname
+LIMIT $1 OFFSET $2;
+
+-- name: GetUnlinkedMdBPersons :many
+SELECT mp.id, mn.vorname, mn.nachname
+FROM mdb_person mp
+JOIN mdb_name mn ON mp.id = mn.mdb_id
+WHERE (mn.historie_bis IS NULL OR mn.historie_bis = '')
+  AND NOT EXISTS (
+      SELECT 1 FROM person_mdb_link WHERE mdb_id = mp.id
+  )
+ORDER BY mn.nachname, mn.vorname
+LIMIT $1 OFFSET $2;
+
+-- name: GetLinksByConfidence :many
+SELECT 
+    person_id, mdb_id, match_confidence, match_method,
+    verified_by, verified_at, notes,
+    created_at, updated_at
+FROM person_mdb_link
+WHERE match_confidence = $1
+ORDER BY created_at DESC;
+
+-- name: GetLinkStats :one
+SELECT 
+    COUNT(*) as total_links,
+    COUNT(CASE WHEN match_confidence = 'exact' THEN 1 END) as exact,
+    COUNT(CASE WHEN match_confidence = 'high' THEN 1 END) as high,
+    COUNT(CASE WHEN match_confidence = 'medium' THEN 1 END) as medium,
+    COUNT(CASE WHEN match_confidence = 'low' THEN 1 END) as low,
+    COUNT(CASE WHEN match_confidence = 'manual' THEN 1 END) as manual,
+    COUNT(CASE WHEN verified_by IS NOT NULL THEN 1 END) as verified
+FROM person_mdb_link;
@@ -27,6 +27,9 @@ LEFT JOIN person_role pr ON p.id = pr.person_id
 WHERE p.id = $1
 GROUP BY p.id;
 
+-- name: GetLatestPersonDatum :one
+SELECT MIN(datum) AS datum FROM person;
+
 -- name: ListPersonen :many
 SELECT 
     p.*,
@@ -70,6 +73,24 @@ INSERT INTO person (
 ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
+-- name: UpsertPerson :exec
+INSERT INTO person (
+    id, vorname, nachname, namenszusatz, titel, typ,
+    aktualisiert, basisdatum, datum
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (id) DO UPDATE
+SET
+    vorname = excluded.vorname,
+    nachname = excluded.nachname,
+    namenszusatz = excluded.namenszusatz,
+    titel = excluded.titel,
+    typ = excluded.typ,
+    aktualisiert = excluded.aktualisiert,
+    basisdatum = excluded.basisdatum,
+    datum = excluded.datum,
+    deleted_at = NULL,
+    updated_at = NOW();
+
 -- name: UpdatePerson :one
 UPDATE person
 SET 
@@ -80,6 +101,7 @@ SET
     aktualisiert = $6,
     basisdatum = $7,
     datum = $8,
+    deleted_at = NULL,
     updated_at = NOW()
 WHERE id = $1
 RETURNING *;
@@ -101,6 +123,13 @@ DELETE FROM person_wahlperiode WHERE person_id = $1;
 -- name: DeletePerson :exec
 DELETE FROM person WHERE id = $1;
 
+-- name: DeletePersonRoleWahlperioden :exec
+DELETE FROM person_role_wahlperiode
+WHERE person_role_id IN (SELECT id FROM person_role WHERE person_id = $1);
+
+-- name: DeletePersonRoles :exec
+DELETE FROM person_role WHERE person_id = $1;
+
 -- name: CreatePersonRole :one
 INSERT INTO person_role (
     person_id, funktion, funktionszusatz, vorname, nachname, namenszusatz,
@@ -16,6 +16,9 @@ LEFT JOIN plenarprotokoll_vorgangsbezug pvb ON p.id = pvb.plenarprotokoll_id
 WHERE p.id = $1
 GROUP BY p.id;
 
+-- name: GetLatestPlenarprotokollDatum :one
+SELECT MIN(datum) AS datum FROM plenarprotokoll;
+
 -- name: ListPlenarprotokolle :many
 SELECT 
     p.*,
@@ -49,13 +52,55 @@ INSERT INTO plenarprotokoll (
     fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
     fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite,
     fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
-    fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
     $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
-    $21, $22, $23, $24, $25
+    $21, $22, $23, $24, $25, $26
 ) RETURNING *;
 
+-- name: UpsertPlenarprotokoll :exec
+INSERT INTO plenarprotokoll (
+    id, titel, dokumentnummer, dokumentart, typ, herausgeber,
+    datum, aktualisiert, pdf_hash, sitzungsbemerkung, vorgangsbezug_anzahl, wahlperiode,
+    fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+    fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite,
+    fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+    $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+    $21, $22, $23, $24, $25, $26
+) ON CONFLICT (id) DO UPDATE
+SET
+    titel = excluded.titel,
+    dokumentnummer = excluded.dokumentnummer,
+    dokumentart = excluded.dokumentart,
+    typ = excluded.typ,
+    herausgeber = excluded.herausgeber,
+    datum = excluded.datum,
+    aktualisiert = excluded.aktualisiert,
+    pdf_hash = excluded.pdf_hash,
+    sitzungsbemerkung = excluded.sitzungsbemerkung,
+    vorgangsbezug_anzahl = excluded.vorgangsbezug_anzahl,
+    wahlperiode = excluded.wahlperiode,
+    fundstelle_dokumentnummer = excluded.fundstelle_dokumentnummer,
+    fundstelle_datum = excluded.fundstelle_datum,
+    fundstelle_dokumentart = excluded.fundstelle_dokumentart,
+    fundstelle_herausgeber = excluded.fundstelle_herausgeber,
+    fundstelle_id = excluded.fundstelle_id,
+    fundstelle_anfangsseite = excluded.fundstelle_anfangsseite,
+    fundstelle_endseite = excluded.fundstelle_endseite,
+    fundstelle_anfangsquadrant = excluded.fundstelle_anfangsquadrant,
+    fundstelle_endquadrant = excluded.fundstelle_endquadrant,
+    fundstelle_seite = excluded.fundstelle_seite,
+    fundstelle_pdf_url = excluded.fundstelle_pdf_url,
+    fundstelle_xml_url = excluded.fundstelle_xml_url,
+    fundstelle_top = excluded.fundstelle_top,
+    fundstelle_top_zusatz = excluded.fundstelle_top_zusatz,
+    deleted_at = NULL,
+    updated_at = NOW();
+
 -- name: UpdatePlenarprotokoll :one
 UPDATE plenarprotokoll
 SET 
@@ -64,6 +109,7 @@ SET
     pdf_hash = $4,
     sitzungsbemerkung = $5,
     vorgangsbezug_anzahl = $6,
+    deleted_at = NULL,
     updated_at = NOW()
 WHERE id = $1
 RETURNING *;
@@ -71,6 +117,12 @@ RETURNING *;
 -- name: DeletePlenarprotokoll :exec
 DELETE FROM plenarprotokoll WHERE id = $1;
 
+-- name: DeletePlenarprotokollVorgangsbezuege :exec
+DELETE FROM plenarprotokoll_vorgangsbezug WHERE plenarprotokoll_id = $1;
+
+-- name: DeletePlenarprotokollFundstelleUrheber :exec
+DELETE FROM fundstelle_urheber WHERE plenarprotokoll_id = $1;
+
 -- name: CreatePlenarprotokollVorgangsbezug :exec
 INSERT INTO plenarprotokoll_vorgangsbezug (
     plenarprotokoll_id, vorgang_id, titel, vorgangstyp, display_order
@@ -0,0 +1,53 @@
+-- Queries used to detect records that were removed from the DIP API.
+
+-- name: ListPersonIDsByWahlperiode :many
+SELECT p.id
+FROM person p
+JOIN person_wahlperiode pw ON pw.person_id = p.id
+WHERE pw.wahlperiode_nummer = $1 AND p.deleted_at IS NULL
+ORDER BY p.id;
+
+-- name: ListVorgangIDsByWahlperiode :many
+SELECT id FROM vorgang
+WHERE wahlperiode = $1 AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: ListVorgangspositionIDsByWahlperiode :many
+SELECT vp.id
+FROM vorgangsposition vp
+JOIN vorgang v ON v.id = vp.vorgang_id
+WHERE v.wahlperiode = $1 AND vp.deleted_at IS NULL
+ORDER BY vp.id;
+
+-- name: ListAktivitaetIDsByWahlperiode :many
+SELECT id FROM aktivitaet
+WHERE wahlperiode = $1 AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: ListDrucksacheIDsByWahlperiode :many
+SELECT id FROM drucksache
+WHERE wahlperiode = $1 AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: ListPlenarprotokollIDsByWahlperiode :many
+SELECT id FROM plenarprotokoll
+WHERE wahlperiode = $1 AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: MarkPersonDeleted :exec
+UPDATE person SET deleted_at = $2 WHERE id = $1;
+
+-- name: MarkVorgangDeleted :exec
+UPDATE vorgang SET deleted_at = $2 WHERE id = $1;
+
+-- name: MarkVorgangspositionDeleted :exec
+UPDATE vorgangsposition SET deleted_at = $2 WHERE id = $1;
+
+-- name: MarkAktivitaetDeleted :exec
+UPDATE aktivitaet SET deleted_at = $2 WHERE id = $1;
+
+-- name: MarkDrucksacheDeleted :exec
+UPDATE drucksache SET deleted_at = $2 WHERE id = $1;
+
+-- name: MarkPlenarprotokollDeleted :exec
+UPDATE plenarprotokoll SET deleted_at = $2 WHERE id = $1;
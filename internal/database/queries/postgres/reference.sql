@@ -42,7 +42,8 @@ ORDER BY nummer DESC;
 -- name: GetOrCreateBundesland :one
 INSERT INTO bundesland (name)
 VALUES ($1)
-ON CONFLICT (name) DO NOTHING
+ON CONFLICT (name) DO UPDATE
+SET name = EXCLUDED.name
 RETURNING *;
 
 -- name: ListBundeslaender :many
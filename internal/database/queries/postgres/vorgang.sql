@@ -74,6 +74,9 @@ LEFT JOIN vorgang_verlinkung vvl ON v.id = vvl.source_vorgang_id
 WHERE v.id = $1
 GROUP BY v.id;
 
+-- name: GetLatestVorgangDatum :one
+SELECT MIN(datum) AS datum FROM vorgang;
+
 -- name: ListVorgaenge :many
 SELECT 
     v.*,
@@ -148,6 +151,31 @@ INSERT INTO vorgang (
 ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 RETURNING *;
 
+-- name: UpsertVorgang :exec
+INSERT INTO vorgang (
+    id, titel, vorgangstyp, typ, abstract, aktualisiert,
+    archiv, beratungsstand, datum, gesta, kom, mitteilung,
+    ratsdok, sek, wahlperiode
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+ON CONFLICT (id) DO UPDATE
+SET
+    titel = excluded.titel,
+    vorgangstyp = excluded.vorgangstyp,
+    typ = excluded.typ,
+    abstract = excluded.abstract,
+    aktualisiert = excluded.aktualisiert,
+    archiv = excluded.archiv,
+    beratungsstand = excluded.beratungsstand,
+    datum = excluded.datum,
+    gesta = excluded.gesta,
+    kom = excluded.kom,
+    mitteilung = excluded.mitteilung,
+    ratsdok = excluded.ratsdok,
+    sek = excluded.sek,
+    wahlperiode = excluded.wahlperiode,
+    deleted_at = NULL,
+    updated_at = NOW();
+
 -- name: UpdateVorgang :one
 UPDATE vorgang
 SET 
@@ -157,6 +185,7 @@ SET
     beratungsstand = $5,
     datum = $6,
     mitteilung = $7,
+    deleted_at = NULL,
     updated_at = NOW()
 WHERE id = $1
 RETURNING *;
@@ -164,6 +193,27 @@ RETURNING *;
 -- name: DeleteVorgang :exec
 DELETE FROM vorgang WHERE id = $1;
 
+-- name: DeleteVorgangInitiativen :exec
+DELETE FROM vorgang_initiative WHERE vorgang_id = $1;
+
+-- name: DeleteVorgangSachgebiete :exec
+DELETE FROM vorgang_sachgebiet WHERE vorgang_id = $1;
+
+-- name: DeleteVorgangZustimmungsbeduerftigkeiten :exec
+DELETE FROM vorgang_zustimmungsbeduerftigkeit WHERE vorgang_id = $1;
+
+-- name: DeleteVorgangDeskriptoren :exec
+DELETE FROM vorgang_deskriptor WHERE vorgang_id = $1;
+
+-- name: DeleteVerkuendungen :exec
+DELETE FROM verkuendung WHERE vorgang_id = $1;
+
+-- name: DeleteInkrafttreten :exec
+DELETE FROM inkrafttreten WHERE vorgang_id = $1;
+
+-- name: DeleteVorgangVerlinkungen :exec
+DELETE FROM vorgang_verlinkung WHERE source_vorgang_id = $1;
+
 -- name: CreateVorgangInitiative :exec
 INSERT INTO vorgang_initiative (vorgang_id, initiative)
 VALUES ($1, $2);
@@ -80,6 +80,9 @@ LEFT JOIN vorgangsposition_mitberaten vpm ON vp.id = vpm.vorgangsposition_id
 WHERE vp.id = $1
 GROUP BY vp.id;
 
+-- name: GetLatestVorgangspositionDatum :one
+SELECT MIN(datum) AS datum FROM vorgangsposition;
+
 -- name: ListVorgangspositionen :many
 SELECT 
     vp.*,
@@ -165,15 +168,71 @@ INSERT INTO vorgangsposition (
     fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
     fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
     fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
-    fundstelle_pdf_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
     fundstelle_frage_nummer, fundstelle_verteildatum
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
     $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
     $21, $22, $23, $24, $25, $26, $27, $28, $29, $30,
-    $31, $32, $33, $34, $35
+    $31, $32, $33, $34, $35, $36
 ) RETURNING *;
 
+-- name: UpsertVorgangsposition :exec
+INSERT INTO vorgangsposition (
+    id, vorgang_id, titel, vorgangsposition, vorgangstyp, typ, dokumentart,
+    datum, aktualisiert, abstract, fortsetzung, gang, nachtrag, aktivitaet_anzahl,
+    kom, ratsdok, sek, zuordnung,
+    fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+    fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
+    fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
+    fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_frage_nummer, fundstelle_verteildatum
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+    $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+    $21, $22, $23, $24, $25, $26, $27, $28, $29, $30,
+    $31, $32, $33, $34, $35, $36
+) ON CONFLICT (id) DO UPDATE
+SET
+    vorgang_id = excluded.vorgang_id,
+    titel = excluded.titel,
+    vorgangsposition = excluded.vorgangsposition,
+    vorgangstyp = excluded.vorgangstyp,
+    typ = excluded.typ,
+    dokumentart = excluded.dokumentart,
+    datum = excluded.datum,
+    aktualisiert = excluded.aktualisiert,
+    abstract = excluded.abstract,
+    fortsetzung = excluded.fortsetzung,
+    gang = excluded.gang,
+    nachtrag = excluded.nachtrag,
+    aktivitaet_anzahl = excluded.aktivitaet_anzahl,
+    kom = excluded.kom,
+    ratsdok = excluded.ratsdok,
+    sek = excluded.sek,
+    zuordnung = excluded.zuordnung,
+    fundstelle_dokumentnummer = excluded.fundstelle_dokumentnummer,
+    fundstelle_datum = excluded.fundstelle_datum,
+    fundstelle_dokumentart = excluded.fundstelle_dokumentart,
+    fundstelle_herausgeber = excluded.fundstelle_herausgeber,
+    fundstelle_id = excluded.fundstelle_id,
+    fundstelle_drucksachetyp = excluded.fundstelle_drucksachetyp,
+    fundstelle_anlagen = excluded.fundstelle_anlagen,
+    fundstelle_anfangsseite = excluded.fundstelle_anfangsseite,
+    fundstelle_endseite = excluded.fundstelle_endseite,
+    fundstelle_anfangsquadrant = excluded.fundstelle_anfangsquadrant,
+    fundstelle_endquadrant = excluded.fundstelle_endquadrant,
+    fundstelle_seite = excluded.fundstelle_seite,
+    fundstelle_pdf_url = excluded.fundstelle_pdf_url,
+    fundstelle_xml_url = excluded.fundstelle_xml_url,
+    fundstelle_top = excluded.fundstelle_top,
+    fundstelle_top_zusatz = excluded.fundstelle_top_zusatz,
+    fundstelle_frage_nummer = excluded.fundstelle_frage_nummer,
+    fundstelle_verteildatum = excluded.fundstelle_verteildatum,
+    deleted_at = NULL,
+    updated_at = NOW();
+
 -- name: UpdateVorgangsposition :one
 UPDATE vorgangsposition
 SET 
@@ -181,6 +240,7 @@ SET
     aktualisiert = $3,
     abstract = $4,
     aktivitaet_anzahl = $5,
+    deleted_at = NULL,
     updated_at = NOW()
 WHERE id = $1
 RETURNING *;
@@ -188,6 +248,24 @@ RETURNING *;
 -- name: DeleteVorgangsposition :exec
 DELETE FROM vorgangsposition WHERE id = $1;
 
+-- name: DeleteAktivitaetAnzeigen :exec
+DELETE FROM aktivitaet_anzeige WHERE vorgangsposition_id = $1;
+
+-- name: DeleteBeschlussfassungen :exec
+DELETE FROM beschlussfassung WHERE vorgangsposition_id = $1;
+
+-- name: DeleteVorgangspositionRessorts :exec
+DELETE FROM vorgangsposition_ressort WHERE vorgangsposition_id = $1;
+
+-- name: DeleteVorgangspositionUrheber :exec
+DELETE FROM vorgangsposition_urheber WHERE vorgangsposition_id = $1;
+
+-- name: DeleteUeberweisungen :exec
+DELETE FROM ueberweisung WHERE vorgangsposition_id = $1;
+
+-- name: DeleteVorgangspositionMitberaten :exec
+DELETE FROM vorgangsposition_mitberaten WHERE vorgangsposition_id = $1;
+
 -- name: CreateVorgangspositionRessort :exec
 INSERT INTO vorgangsposition_ressort (vorgangsposition_id, ressort_id, federfuehrend)
 VALUES ($1, $2, $3)
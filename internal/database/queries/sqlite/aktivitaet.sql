@@ -56,6 +56,52 @@ INSERT INTO aktivitaet (
     ?, ?, ?, ?, ?, ?, ?, ?
 ) RETURNING *;
 
+-- name: UpsertAktivitaet :exec
+INSERT INTO aktivitaet (
+    id, titel, aktivitaetsart, typ, dokumentart, datum, aktualisiert,
+    abstract, vorgangsbezug_anzahl, wahlperiode,
+    fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+    fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
+    fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
+    fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_frage_nummer, fundstelle_verteildatum
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?, ?, ?
+) ON CONFLICT (id) DO UPDATE
+SET
+    titel = excluded.titel,
+    aktivitaetsart = excluded.aktivitaetsart,
+    typ = excluded.typ,
+    dokumentart = excluded.dokumentart,
+    datum = excluded.datum,
+    aktualisiert = excluded.aktualisiert,
+    abstract = excluded.abstract,
+    vorgangsbezug_anzahl = excluded.vorgangsbezug_anzahl,
+    wahlperiode = excluded.wahlperiode,
+    fundstelle_dokumentnummer = excluded.fundstelle_dokumentnummer,
+    fundstelle_datum = excluded.fundstelle_datum,
+    fundstelle_dokumentart = excluded.fundstelle_dokumentart,
+    fundstelle_herausgeber = excluded.fundstelle_herausgeber,
+    fundstelle_id = excluded.fundstelle_id,
+    fundstelle_drucksachetyp = excluded.fundstelle_drucksachetyp,
+    fundstelle_anlagen = excluded.fundstelle_anlagen,
+    fundstelle_anfangsseite = excluded.fundstelle_anfangsseite,
+    fundstelle_endseite = excluded.fundstelle_endseite,
+    fundstelle_anfangsquadrant = excluded.fundstelle_anfangsquadrant,
+    fundstelle_endquadrant = excluded.fundstelle_endquadrant,
+    fundstelle_seite = excluded.fundstelle_seite,
+    fundstelle_pdf_url = excluded.fundstelle_pdf_url,
+    fundstelle_xml_url = excluded.fundstelle_xml_url,
+    fundstelle_top = excluded.fundstelle_top,
+    fundstelle_top_zusatz = excluded.fundstelle_top_zusatz,
+    fundstelle_frage_nummer = excluded.fundstelle_frage_nummer,
+    fundstelle_verteildatum = excluded.fundstelle_verteildatum,
+    deleted_at = NULL,
+    updated_at = datetime('now');
+
 -- name: UpdateAktivitaet :one
 UPDATE aktivitaet
 SET 
@@ -64,6 +110,7 @@ SET
     aktualisiert = ?,
     abstract = ?,
     vorgangsbezug_anzahl = ?,
+    deleted_at = NULL,
     updated_at = datetime('now')
 WHERE id = ?
 RETURNING *;
@@ -71,6 +118,12 @@ RETURNING *;
 -- name: DeleteAktivitaet :exec
 DELETE FROM aktivitaet WHERE id = ?;
 
+-- name: DeleteAktivitaetDeskriptoren :exec
+DELETE FROM aktivitaet_deskriptor WHERE aktivitaet_id = ?;
+
+-- name: DeleteAktivitaetVorgangsbezuege :exec
+DELETE FROM aktivitaet_vorgangsbezug WHERE aktivitaet_id = ?;
+
 -- name: CreateAktivitaetDeskriptor :one
 INSERT INTO aktivitaet_deskriptor (aktivitaet_id, name, typ)
 VALUES (?, ?, ?)
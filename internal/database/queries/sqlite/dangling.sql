@@ -0,0 +1,79 @@
+-- Queries listing referenced IDs that are missing from their parent table.
+-- Used to backfill entities that are referenced but were never synced.
+
+-- name: ListDanglingVorgangsbezugDrucksache :many
+SELECT DISTINCT dvb.vorgang_id
+FROM drucksache_vorgangsbezug dvb
+LEFT JOIN vorgang v ON v.id = dvb.vorgang_id
+WHERE v.id IS NULL
+ORDER BY dvb.vorgang_id;
+
+-- name: ListDanglingVorgangsbezugPlenarprotokoll :many
+SELECT DISTINCT pvb.vorgang_id
+FROM plenarprotokoll_vorgangsbezug pvb
+LEFT JOIN vorgang v ON v.id = pvb.vorgang_id
+WHERE v.id IS NULL
+ORDER BY pvb.vorgang_id;
+
+-- name: ListDanglingVorgangsbezugAktivitaet :many
+SELECT DISTINCT avb.vorgang_id
+FROM aktivitaet_vorgangsbezug avb
+LEFT JOIN vorgang v ON v.id = avb.vorgang_id
+WHERE v.id IS NULL
+ORDER BY avb.vorgang_id;
+
+-- name: ListDanglingVorgangspositionVorgang :many
+SELECT DISTINCT vp.vorgang_id
+FROM vorgangsposition vp
+LEFT JOIN vorgang v ON v.id = vp.vorgang_id
+WHERE v.id IS NULL
+ORDER BY vp.vorgang_id;
+
+-- name: ListDanglingMitberatenVorgang :many
+SELECT DISTINCT vm.mitberaten_vorgang_id
+FROM vorgangsposition_mitberaten vm
+LEFT JOIN vorgang v ON v.id = vm.mitberaten_vorgang_id
+WHERE v.id IS NULL
+ORDER BY vm.mitberaten_vorgang_id;
+
+-- name: ListDanglingVerlinkungVorgang :many
+SELECT DISTINCT vv.target_vorgang_id
+FROM vorgang_verlinkung vv
+LEFT JOIN vorgang v ON v.id = vv.target_vorgang_id
+WHERE v.id IS NULL
+ORDER BY vv.target_vorgang_id;
+
+-- name: ListDanglingAutorAnzeigePerson :many
+SELECT DISTINCT daa.person_id
+FROM drucksache_autor_anzeige daa
+LEFT JOIN person p ON p.id = daa.person_id
+WHERE p.id IS NULL
+ORDER BY daa.person_id;
+
+-- name: ListDanglingVorgangspositionFundstelleDrucksache :many
+SELECT DISTINCT vp.fundstelle_id
+FROM vorgangsposition vp
+LEFT JOIN drucksache d ON d.id = vp.fundstelle_id
+WHERE vp.fundstelle_dokumentart = 'Drucksache' AND d.id IS NULL
+ORDER BY vp.fundstelle_id;
+
+-- name: ListDanglingVorgangspositionFundstellePlenarprotokoll :many
+SELECT DISTINCT vp.fundstelle_id
+FROM vorgangsposition vp
+LEFT JOIN plenarprotokoll p ON p.id = vp.fundstelle_id
+WHERE vp.fundstelle_dokumentart = 'Plenarprotokoll' AND p.id IS NULL
+ORDER BY vp.fundstelle_id;
+
+-- name: ListDanglingAktivitaetFundstelleDrucksache :many
+SELECT DISTINCT a.fundstelle_id
+FROM aktivitaet a
+LEFT JOIN drucksache d ON d.id = a.fundstelle_id
+WHERE a.fundstelle_dokumentart = 'Drucksache' AND d.id IS NULL
+ORDER BY a.fundstelle_id;
+
+-- name: ListDanglingAktivitaetFundstellePlenarprotokoll :many
+SELECT DISTINCT a.fundstelle_id
+FROM aktivitaet a
+LEFT JOIN plenarprotokoll p ON p.id = a.fundstelle_id
+WHERE a.fundstelle_dokumentart = 'Plenarprotokoll' AND p.id IS NULL
+ORDER BY a.fundstelle_id;
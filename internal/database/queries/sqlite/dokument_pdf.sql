@@ -0,0 +1,62 @@
+-- PDF archive (sync-pdfs)
+
+-- name: ListPendingPdfs :many
+-- PDFs of Drucksachen and Plenarprotokolle that are not stored yet, one row per pdf_hash.
+-- drucksachetyp only matches Drucksachen; PDFs that failed before are skipped unless retry_failed.
+SELECT
+    CAST(d.pdf_hash AS TEXT) AS pdf_hash,
+    CAST(MIN(d.url) AS TEXT) AS url,
+    CAST(MIN(d.dokumentart) AS TEXT) AS dokumentart,
+    CAST(MIN(d.dokumentnummer) AS TEXT) AS dokumentnummer
+FROM (
+    SELECT ds.pdf_hash, ds.fundstelle_pdf_url AS url, 'Drucksache' AS dokumentart, ds.dokumentnummer, ds.wahlperiode
+    FROM drucksache ds
+    WHERE ds.pdf_hash IS NOT NULL AND ds.pdf_hash <> ''
+      AND ds.fundstelle_pdf_url IS NOT NULL
+      AND ds.deleted_at IS NULL
+      AND (CAST(sqlc.arg(wahlperiode) AS INTEGER) = 0 OR ds.wahlperiode = sqlc.arg(wahlperiode))
+      AND (CAST(sqlc.arg(dokumentart) AS TEXT) = '' OR sqlc.arg(dokumentart) = 'Drucksache')
+      AND (CAST(sqlc.arg(drucksachetyp) AS TEXT) = '' OR ds.drucksachetyp = sqlc.arg(drucksachetyp))
+    UNION ALL
+    SELECT pp.pdf_hash, pp.fundstelle_pdf_url AS url, 'Plenarprotokoll' AS dokumentart, pp.dokumentnummer, pp.wahlperiode
+    FROM plenarprotokoll pp
+    WHERE pp.pdf_hash IS NOT NULL AND pp.pdf_hash <> ''
+      AND pp.fundstelle_pdf_url IS NOT NULL
+      AND pp.deleted_at IS NULL
+      AND (sqlc.arg(wahlperiode) = 0 OR pp.wahlperiode = sqlc.arg(wahlperiode))
+      AND (sqlc.arg(dokumentart) = '' OR sqlc.arg(dokumentart) = 'Plenarprotokoll')
+      AND sqlc.arg(drucksachetyp) = ''
+) d
+WHERE d.pdf_hash NOT IN (SELECT pdf_hash FROM dokument_pdf)
+  AND (CAST(sqlc.arg(retry_failed) AS INTEGER) = 1 OR d.pdf_hash NOT IN (SELECT pdf_hash FROM dokument_pdf_fehler))
+GROUP BY d.pdf_hash
+ORDER BY MIN(d.wahlperiode), d.pdf_hash;
+
+-- name: ListDokumentPdfs :many
+SELECT pdf_hash, pfad, groesse, url, gespeichert_at
+FROM dokument_pdf
+ORDER BY pdf_hash;
+
+-- name: UpsertDokumentPdf :exec
+INSERT INTO dokument_pdf (pdf_hash, pfad, groesse, url)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (pdf_hash) DO UPDATE SET
+    pfad = excluded.pfad,
+    groesse = excluded.groesse,
+    url = excluded.url,
+    gespeichert_at = datetime('now');
+
+-- name: DeleteDokumentPdf :exec
+DELETE FROM dokument_pdf WHERE pdf_hash = ?;
+
+-- name: RecordDokumentPdfFehler :exec
+INSERT INTO dokument_pdf_fehler (pdf_hash, url, fehler)
+VALUES (?, ?, ?)
+ON CONFLICT (pdf_hash) DO UPDATE SET
+    url = excluded.url,
+    fehler = excluded.fehler,
+    versuche = dokument_pdf_fehler.versuche + 1,
+    letzter_versuch_at = datetime('now');
+
+-- name: DeleteDokumentPdfFehler :exec
+DELETE FROM dokument_pdf_fehler WHERE pdf_hash = ?;
@@ -0,0 +1,91 @@
+-- Citation graph (process-dokument-zitate)
+
+-- name: ListPendingZitatTexte :many
+-- Drucksache and Plenarprotokoll texts that were not processed yet, changed since,
+-- or were processed with an older parser (all_texte: every text)
+SELECT
+    CAST(t.dokumentart AS TEXT) AS dokumentart,
+    CAST(t.id AS TEXT) AS id,
+    CAST(t.updated_at AS TEXT) AS updated_at,
+    CAST(t.dokumentnummer AS TEXT) AS dokumentnummer,
+    CAST(t.herausgeber AS TEXT) AS herausgeber
+FROM (
+    SELECT 'Drucksache' AS dokumentart, dt.id, dt.updated_at, d.dokumentnummer, d.herausgeber
+    FROM drucksache_text dt
+    JOIN drucksache d ON d.id = dt.id
+    WHERE dt.text IS NOT NULL
+      AND (CAST(sqlc.arg(dokumentart) AS TEXT) = '' OR sqlc.arg(dokumentart) = 'Drucksache')
+    UNION ALL
+    SELECT 'Plenarprotokoll' AS dokumentart, pt.id, pt.updated_at, p.dokumentnummer, p.herausgeber
+    FROM plenarprotokoll_text pt
+    JOIN plenarprotokoll p ON p.id = pt.id
+    WHERE pt.text IS NOT NULL
+      AND (sqlc.arg(dokumentart) = '' OR sqlc.arg(dokumentart) = 'Plenarprotokoll')
+) t
+LEFT JOIN dokument_zitat_verarbeitung v ON v.dokumentart = t.dokumentart AND v.dokument_id = t.id
+WHERE CAST(sqlc.arg(all_texte) AS INTEGER) = 1
+   OR v.dokument_id IS NULL
+   OR v.parser_version < CAST(sqlc.arg(parser_version) AS INTEGER)
+   OR v.text_updated_at <> t.updated_at
+ORDER BY t.dokumentart, t.id;
+
+-- name: GetDrucksacheTextContent :one
+SELECT text FROM drucksache_text WHERE id = ?;
+
+-- name: DeleteDokumentZitate :exec
+DELETE FROM dokument_zitat
+WHERE quelle_dokumentart = ? AND quelle_id = ?;
+
+-- name: CreateDokumentZitat :exec
+INSERT INTO dokument_zitat (
+    quelle_dokumentart, quelle_id, ziel_dokumentart, ziel_herausgeber,
+    ziel_dokumentnummer, anzahl, erster_offset, roh
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: UpsertDokumentZitatVerarbeitung :exec
+INSERT INTO dokument_zitat_verarbeitung (dokumentart, dokument_id, parser_version, text_updated_at, zitate_anzahl)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (dokumentart, dokument_id) DO UPDATE SET
+    parser_version = excluded.parser_version,
+    text_updated_at = excluded.text_updated_at,
+    zitate_anzahl = excluded.zitate_anzahl,
+    processed_at = datetime('now');
+
+-- name: ResolveDrucksacheZitate :execrows
+-- Resolves cited Drucksachen that were not synced when the citing text was processed
+UPDATE dokument_zitat
+SET ziel_id = (
+    SELECT MIN(d.id) FROM drucksache d
+    WHERE d.herausgeber = dokument_zitat.ziel_herausgeber
+      AND d.dokumentnummer = dokument_zitat.ziel_dokumentnummer
+      AND d.deleted_at IS NULL
+)
+WHERE ziel_id IS NULL
+  AND ziel_dokumentart = 'Drucksache'
+  AND EXISTS (
+    SELECT 1 FROM drucksache d
+    WHERE d.herausgeber = dokument_zitat.ziel_herausgeber
+      AND d.dokumentnummer = dokument_zitat.ziel_dokumentnummer
+      AND d.deleted_at IS NULL
+  );
+
+-- name: ResolvePlenarprotokollZitate :execrows
+-- Resolves cited Plenarprotokolle that were not synced when the citing text was processed
+UPDATE dokument_zitat
+SET ziel_id = (
+    SELECT MIN(p.id) FROM plenarprotokoll p
+    WHERE p.herausgeber = dokument_zitat.ziel_herausgeber
+      AND p.dokumentnummer = dokument_zitat.ziel_dokumentnummer
+      AND p.deleted_at IS NULL
+)
+WHERE ziel_id IS NULL
+  AND ziel_dokumentart = 'Plenarprotokoll'
+  AND EXISTS (
+    SELECT 1 FROM plenarprotokoll p
+    WHERE p.herausgeber = dokument_zitat.ziel_herausgeber
+      AND p.dokumentnummer = dokument_zitat.ziel_dokumentnummer
+      AND p.deleted_at IS NULL
+  );
+
+-- name: CountUnresolvedDokumentZitate :one
+SELECT COUNT(*) FROM dokument_zitat WHERE ziel_id IS NULL;
@@ -58,6 +58,57 @@ INSERT INTO drucksache (
     ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 ) RETURNING *;
 
+-- name: UpsertDrucksache :exec
+INSERT INTO drucksache (
+    id, titel, dokumentnummer, dokumentart, typ, drucksachetyp, herausgeber,
+    datum, aktualisiert, anlagen, autoren_anzahl, vorgangsbezug_anzahl,
+    pdf_hash, wahlperiode,
+    fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+    fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
+    fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
+    fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_frage_nummer, fundstelle_verteildatum
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+) ON CONFLICT (id) DO UPDATE
+SET
+    titel = excluded.titel,
+    dokumentnummer = excluded.dokumentnummer,
+    dokumentart = excluded.dokumentart,
+    typ = excluded.typ,
+    drucksachetyp = excluded.drucksachetyp,
+    herausgeber = excluded.herausgeber,
+    datum = excluded.datum,
+    aktualisiert = excluded.aktualisiert,
+    anlagen = excluded.anlagen,
+    autoren_anzahl = excluded.autoren_anzahl,
+    vorgangsbezug_anzahl = excluded.vorgangsbezug_anzahl,
+    pdf_hash = excluded.pdf_hash,
+    wahlperiode = excluded.wahlperiode,
+    fundstelle_dokumentnummer = excluded.fundstelle_dokumentnummer,
+    fundstelle_datum = excluded.fundstelle_datum,
+    fundstelle_dokumentart = excluded.fundstelle_dokumentart,
+    fundstelle_herausgeber = excluded.fundstelle_herausgeber,
+    fundstelle_id = excluded.fundstelle_id,
+    fundstelle_drucksachetyp = excluded.fundstelle_drucksachetyp,
+    fundstelle_anlagen = excluded.fundstelle_anlagen,
+    fundstelle_anfangsseite = excluded.fundstelle_anfangsseite,
+    fundstelle_endseite = excluded.fundstelle_endseite,
+    fundstelle_anfangsquadrant = excluded.fundstelle_anfangsquadrant,
+    fundstelle_endquadrant = excluded.fundstelle_endquadrant,
+    fundstelle_seite = excluded.fundstelle_seite,
+    fundstelle_pdf_url = excluded.fundstelle_pdf_url,
+    fundstelle_xml_url = excluded.fundstelle_xml_url,
+    fundstelle_top = excluded.fundstelle_top,
+    fundstelle_top_zusatz = excluded.fundstelle_top_zusatz,
+    fundstelle_frage_nummer = excluded.fundstelle_frage_nummer,
+    fundstelle_verteildatum = excluded.fundstelle_verteildatum,
+    deleted_at = NULL,
+    updated_at = datetime('now');
+
 -- name: UpdateDrucksache :one
 UPDATE drucksache
 SET 
@@ -67,6 +118,7 @@ SET
     autoren_anzahl = ?,
     vorgangsbezug_anzahl = ?,
     pdf_hash = ?,
+    deleted_at = NULL,
     updated_at = datetime('now')
 WHERE id = ?
 RETURNING *;
@@ -74,6 +126,21 @@ RETURNING *;
 -- name: DeleteDrucksache :exec
 DELETE FROM drucksache WHERE id = ?;
 
+-- name: DeleteDrucksacheAutorAnzeigen :exec
+DELETE FROM drucksache_autor_anzeige WHERE drucksache_id = ?;
+
+-- name: DeleteDrucksacheRessorts :exec
+DELETE FROM drucksache_ressort WHERE drucksache_id = ?;
+
+-- name: DeleteDrucksacheUrheber :exec
+DELETE FROM drucksache_urheber WHERE drucksache_id = ?;
+
+-- name: DeleteDrucksacheVorgangsbezuege :exec
+DELETE FROM drucksache_vorgangsbezug WHERE drucksache_id = ?;
+
+-- name: DeleteDrucksacheFundstelleUrheber :exec
+DELETE FROM fundstelle_urheber WHERE drucksache_id = ?;
+
 -- name: CreateDrucksacheAutorAnzeige :one
 INSERT INTO drucksache_autor_anzeige (
     drucksache_id, person_id, autor_titel, title, display_order
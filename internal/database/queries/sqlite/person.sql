@@ -42,6 +42,24 @@ INSERT INTO person (
 ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
+-- name: UpsertPerson :exec
+INSERT INTO person (
+    id, vorname, nachname, namenszusatz, titel, typ,
+    aktualisiert, basisdatum, datum
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE
+SET
+    vorname = excluded.vorname,
+    nachname = excluded.nachname,
+    namenszusatz = excluded.namenszusatz,
+    titel = excluded.titel,
+    typ = excluded.typ,
+    aktualisiert = excluded.aktualisiert,
+    basisdatum = excluded.basisdatum,
+    datum = excluded.datum,
+    deleted_at = NULL,
+    updated_at = datetime('now');
+
 -- name: UpdatePerson :one
 UPDATE person
 SET 
@@ -52,6 +70,7 @@ SET
     aktualisiert = ?,
     basisdatum = ?,
     datum = ?,
+    deleted_at = NULL,
     updated_at = datetime('now')
 WHERE id = ?
 RETURNING *;
@@ -73,6 +92,13 @@ DELETE FROM person_wahlperiode WHERE person_id = ?;
 -- name: DeletePerson :exec
 DELETE FROM person WHERE id = ?;
 
+-- name: DeletePersonRoleWahlperioden :exec
+DELETE FROM person_role_wahlperiode
+WHERE person_role_id IN (SELECT id FROM person_role WHERE person_id = ?);
+
+-- name: DeletePersonRoles :exec
+DELETE FROM person_role WHERE person_id = ?;
+
 -- name: CreatePersonRole :one
 INSERT INTO person_role (
     person_id, funktion, funktionszusatz, vorname, nachname, namenszusatz,
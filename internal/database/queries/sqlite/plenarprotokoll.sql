@@ -45,6 +45,48 @@ INSERT INTO plenarprotokoll (
     ?, ?, ?, ?, ?, ?
 ) RETURNING *;
 
+-- name: UpsertPlenarprotokoll :exec
+INSERT INTO plenarprotokoll (
+    id, titel, dokumentnummer, dokumentart, typ, herausgeber,
+    datum, aktualisiert, pdf_hash, sitzungsbemerkung, vorgangsbezug_anzahl, wahlperiode,
+    fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+    fundstelle_herausgeber, fundstelle_id, fundstelle_anfangsseite, fundstelle_endseite,
+    fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?
+) ON CONFLICT (id) DO UPDATE
+SET
+    titel = excluded.titel,
+    dokumentnummer = excluded.dokumentnummer,
+    dokumentart = excluded.dokumentart,
+    typ = excluded.typ,
+    herausgeber = excluded.herausgeber,
+    datum = excluded.datum,
+    aktualisiert = excluded.aktualisiert,
+    pdf_hash = excluded.pdf_hash,
+    sitzungsbemerkung = excluded.sitzungsbemerkung,
+    vorgangsbezug_anzahl = excluded.vorgangsbezug_anzahl,
+    wahlperiode = excluded.wahlperiode,
+    fundstelle_dokumentnummer = excluded.fundstelle_dokumentnummer,
+    fundstelle_datum = excluded.fundstelle_datum,
+    fundstelle_dokumentart = excluded.fundstelle_dokumentart,
+    fundstelle_herausgeber = excluded.fundstelle_herausgeber,
+    fundstelle_id = excluded.fundstelle_id,
+    fundstelle_anfangsseite = excluded.fundstelle_anfangsseite,
+    fundstelle_endseite = excluded.fundstelle_endseite,
+    fundstelle_anfangsquadrant = excluded.fundstelle_anfangsquadrant,
+    fundstelle_endquadrant = excluded.fundstelle_endquadrant,
+    fundstelle_seite = excluded.fundstelle_seite,
+    fundstelle_pdf_url = excluded.fundstelle_pdf_url,
+    fundstelle_xml_url = excluded.fundstelle_xml_url,
+    fundstelle_top = excluded.fundstelle_top,
+    fundstelle_top_zusatz = excluded.fundstelle_top_zusatz,
+    deleted_at = NULL,
+    updated_at = datetime('now');
+
 -- name: UpdatePlenarprotokoll :one
 UPDATE plenarprotokoll
 SET 
@@ -53,6 +95,7 @@ SET
     pdf_hash = ?,
     sitzungsbemerkung = ?,
     vorgangsbezug_anzahl = ?,
+    deleted_at = NULL,
     updated_at = datetime('now')
 WHERE id = ?
 RETURNING *;
@@ -60,6 +103,12 @@ RETURNING *;
 -- name: DeletePlenarprotokoll :exec
 DELETE FROM plenarprotokoll WHERE id = ?;
 
+-- name: DeletePlenarprotokollVorgangsbezuege :exec
+DELETE FROM plenarprotokoll_vorgangsbezug WHERE plenarprotokoll_id = ?;
+
+-- name: DeletePlenarprotokollFundstelleUrheber :exec
+DELETE FROM fundstelle_urheber WHERE plenarprotokoll_id = ?;
+
 -- name: CreatePlenarprotokollVorgangsbezug :exec
 INSERT INTO plenarprotokoll_vorgangsbezug (
     plenarprotokoll_id, vorgang_id, titel, vorgangstyp, display_order
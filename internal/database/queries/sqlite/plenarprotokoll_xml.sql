@@ -0,0 +1,71 @@
+-- Structured Plenarprotokoll XML (sync-plenarprotokoll-xml)
+
+-- name: ListPendingPlenarprotokollXML :many
+-- Plenarprotokolle with an XML URL that were not loaded yet, from any source
+SELECT p.id, p.dokumentnummer, p.fundstelle_xml_url
+FROM plenarprotokoll p
+LEFT JOIN plenarprotokoll_xml x ON x.dokumentnummer = p.dokumentnummer
+WHERE p.herausgeber = 'BT'
+  AND p.fundstelle_xml_url IS NOT NULL
+  AND p.deleted_at IS NULL
+  AND (CAST(sqlc.arg(wahlperiode) AS INTEGER) = 0 OR p.wahlperiode = sqlc.arg(wahlperiode))
+  AND (CAST(sqlc.arg(all_protokolle) AS INTEGER) = 1 OR x.id IS NULL)
+ORDER BY p.wahlperiode, p.id;
+
+-- name: GetPlenarprotokollIDByDokumentnummer :one
+SELECT id FROM plenarprotokoll
+WHERE herausgeber = 'BT' AND dokumentnummer = ?
+LIMIT 1;
+
+-- name: GetPlenarprotokollXMLHash :one
+SELECT sha256 FROM plenarprotokoll_xml WHERE dokumentnummer = ?;
+
+-- name: DeletePlenarprotokollXMLAbsaetze :exec
+DELETE FROM plenarprotokoll_xml_absatz
+WHERE rede_id IN (
+    SELECT r.id FROM plenarprotokoll_xml_rede r
+    JOIN plenarprotokoll_xml x ON x.id = r.protokoll_xml_id
+    WHERE x.dokumentnummer = ?
+);
+
+-- name: DeletePlenarprotokollXMLReden :exec
+DELETE FROM plenarprotokoll_xml_rede
+WHERE protokoll_xml_id IN (SELECT id FROM plenarprotokoll_xml WHERE dokumentnummer = ?);
+
+-- name: DeletePlenarprotokollXMLTagesordnungspunkte :exec
+DELETE FROM plenarprotokoll_xml_tagesordnungspunkt
+WHERE protokoll_xml_id IN (SELECT id FROM plenarprotokoll_xml WHERE dokumentnummer = ?);
+
+-- name: DeletePlenarprotokollXMLRedner :exec
+DELETE FROM plenarprotokoll_xml_redner
+WHERE protokoll_xml_id IN (SELECT id FROM plenarprotokoll_xml WHERE dokumentnummer = ?);
+
+-- name: DeletePlenarprotokollXML :exec
+DELETE FROM plenarprotokoll_xml WHERE dokumentnummer = ?;
+
+-- name: CreatePlenarprotokollXML :one
+INSERT INTO plenarprotokoll_xml (
+    dokumentnummer, plenarprotokoll_id, wahlperiode, sitzung_nr, datum,
+    start_uhrzeit, ende_uhrzeit, ort, version, quelle, sha256, dtd_validiert
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id;
+
+-- name: CreatePlenarprotokollXMLRedner :exec
+INSERT INTO plenarprotokoll_xml_redner (
+    protokoll_xml_id, redner_id, titel, vorname, namenszusatz, nachname,
+    ortszusatz, fraktion, rolle_lang, rolle_kurz, bundesland
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: CreatePlenarprotokollXMLTagesordnungspunkt :one
+INSERT INTO plenarprotokoll_xml_tagesordnungspunkt (protokoll_xml_id, position, top_id, titel)
+VALUES (?, ?, ?, ?)
+RETURNING id;
+
+-- name: CreatePlenarprotokollXMLRede :one
+INSERT INTO plenarprotokoll_xml_rede (protokoll_xml_id, tagesordnungspunkt_id, position, rede_id, redner_id)
+VALUES (?, ?, ?, ?, ?)
+RETURNING id;
+
+-- name: CreatePlenarprotokollXMLAbsatz :exec
+INSERT INTO plenarprotokoll_xml_absatz (rede_id, position, typ, klasse, redner_id, sprecher, text)
+VALUES (?, ?, ?, ?, ?, ?, ?);
@@ -0,0 +1,75 @@
+-- name: ListPendingRedenProtokolle :many
+-- Plenarprotokolle whose text was not parsed yet, changed since, or was parsed with an older parser
+SELECT pt.id, pt.updated_at, p.datum, p.wahlperiode
+FROM plenarprotokoll_text pt
+LEFT JOIN plenarprotokoll p ON p.id = pt.id
+LEFT JOIN rede_verarbeitung rv ON rv.plenarprotokoll_id = pt.id
+WHERE pt.text IS NOT NULL
+  AND (rv.plenarprotokoll_id IS NULL
+       OR rv.parser_version < sqlc.arg(parser_version)
+       OR rv.text_updated_at <> pt.updated_at)
+ORDER BY pt.id;
+
+-- name: ListAllRedenProtokolle :many
+SELECT pt.id, pt.updated_at, p.datum, p.wahlperiode
+FROM plenarprotokoll_text pt
+LEFT JOIN plenarprotokoll p ON p.id = pt.id
+WHERE pt.text IS NOT NULL
+ORDER BY pt.id;
+
+-- name: GetPlenarprotokollTextContent :one
+SELECT text FROM plenarprotokoll_text WHERE id = ?;
+
+-- name: DeleteReden :exec
+DELETE FROM rede WHERE plenarprotokoll_id = ?;
+
+-- name: CreateRede :one
+INSERT INTO rede (
+    plenarprotokoll_id, position, sprecher, rolle, fraktion,
+    tagesordnungspunkt, start_offset, end_offset, text,
+    person_id, mdb_id, sprecher_confidence, sprecher_methode
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id;
+
+-- name: UpsertRedeVerarbeitung :exec
+INSERT INTO rede_verarbeitung (plenarprotokoll_id, parser_version, text_updated_at, reden_anzahl)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (plenarprotokoll_id) DO UPDATE
+SET parser_version = excluded.parser_version,
+    text_updated_at = excluded.text_updated_at,
+    reden_anzahl = excluded.reden_anzahl,
+    processed_at = datetime('now');
+
+-- name: ListRedenForSprecher :many
+-- Reden whose speaker is resolved again by process-plenarprotokoll-reden -resolve-only
+SELECT r.id, r.sprecher, r.rolle, r.fraktion, p.datum, p.wahlperiode
+FROM rede r
+LEFT JOIN plenarprotokoll p ON p.id = r.plenarprotokoll_id
+WHERE CAST(sqlc.arg(all_reden) AS INTEGER) = 1 OR r.person_id IS NULL OR r.mdb_id IS NULL
+ORDER BY r.id;
+
+-- name: UpdateRedeSprecher :exec
+UPDATE rede
+SET person_id = ?, mdb_id = ?, sprecher_confidence = ?, sprecher_methode = ?
+WHERE id = ?;
+
+-- name: DeleteRedeReaktionen :exec
+DELETE FROM rede_reaktion WHERE plenarprotokoll_id = ?;
+
+-- name: CreateRedeReaktion :exec
+INSERT INTO rede_reaktion (
+    rede_id, plenarprotokoll_id, position, kommentar, art, fraktion, abgeordnete,
+    sprecher, person_id, mdb_id, text, kommentar_offset, roh
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListRedeReaktionenForSprecher :many
+-- Zurufe of members whose person is resolved again by process-plenarprotokoll-reden -resolve-only
+SELECT rr.id, rr.sprecher, rr.fraktion, p.datum, p.wahlperiode
+FROM rede_reaktion rr
+LEFT JOIN plenarprotokoll p ON p.id = rr.plenarprotokoll_id
+WHERE rr.sprecher IS NOT NULL
+  AND (CAST(sqlc.arg(all_reden) AS INTEGER) = 1 OR rr.person_id IS NULL OR rr.mdb_id IS NULL)
+ORDER BY rr.id;
+
+-- name: UpdateRedeReaktionSprecher :exec
+UPDATE rede_reaktion SET person_id = ?, mdb_id = ? WHERE id = ?;
@@ -0,0 +1,48 @@
+-- Data of the speaker resolver (internal/sprecher)
+
+-- name: ListSprecherMdbNamen :many
+SELECT mn.mdb_id, mn.vorname, mn.nachname, mn.praefix, mn.adel, mn.historie_von, mn.historie_bis, mb.partei_kurz
+FROM mdb_name mn
+LEFT JOIN mdb_biographical mb ON mb.mdb_id = mn.mdb_id;
+
+-- name: ListSprecherMdbMitgliedschaften :many
+SELECT mdb_id, wp, mdbwp_von, mdbwp_bis
+FROM mdb_wahlperiode_membership;
+
+-- name: ListSprecherMdbFraktionen :many
+SELECT mwm.mdb_id, mwm.wp, mim.ins_lang, mim.mdbins_von, mim.mdbins_bis
+FROM mdb_institution_membership mim
+JOIN mdb_wahlperiode_membership mwm ON mwm.id = mim.mdb_wahlperiode_membership_id
+WHERE mim.insart_lang LIKE 'Fraktion%';
+
+-- name: ListSprecherPersonen :many
+SELECT p.id, p.vorname, p.nachname,
+       CAST(COALESCE((SELECT group_concat(pw.wahlperiode_nummer) FROM person_wahlperiode pw WHERE pw.person_id = p.id), '') AS TEXT) AS wahlperioden,
+       CAST(COALESCE((SELECT group_concat(DISTINCT pr.fraktion) FROM person_role pr WHERE pr.person_id = p.id AND pr.fraktion IS NOT NULL), '') AS TEXT) AS fraktionen
+FROM person p
+WHERE p.deleted_at IS NULL;
+
+-- name: ListSprecherPersonMdbLinks :many
+SELECT person_id, mdb_id
+FROM person_mdb_link
+ORDER BY CASE match_confidence WHEN 'manual' THEN 0 WHEN 'exact' THEN 1 WHEN 'high' THEN 2 WHEN 'medium' THEN 3 ELSE 4 END;
+
+-- name: ListSprecherReviewEntscheidungen :many
+SELECT sprecher, fraktion, person_id, mdb_id
+FROM rede_sprecher_review
+WHERE person_id IS NOT NULL OR mdb_id IS NOT NULL;
+
+-- name: UpsertSprecherReview :exec
+INSERT INTO rede_sprecher_review (sprecher, fraktion, rolle, kandidaten, beste_confidence, beispiel_rede_id)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (sprecher, fraktion) DO UPDATE
+SET rolle = excluded.rolle,
+    kandidaten = excluded.kandidaten,
+    beste_confidence = excluded.beste_confidence,
+    beispiel_rede_id = excluded.beispiel_rede_id,
+    updated_at = datetime('now');
+
+-- name: DeleteOpenSprecherReview :exec
+-- Removes a review entry without decision once the speaker was resolved automatically
+DELETE FROM rede_sprecher_review
+WHERE sprecher = ? AND fraktion = ? AND person_id IS NULL AND mdb_id IS NULL;
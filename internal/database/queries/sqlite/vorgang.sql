@@ -54,6 +54,31 @@ INSERT INTO vorgang (
 ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
+-- name: UpsertVorgang :exec
+INSERT INTO vorgang (
+    id, titel, vorgangstyp, typ, abstract, aktualisiert,
+    archiv, beratungsstand, datum, gesta, kom, mitteilung,
+    ratsdok, sek, wahlperiode
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE
+SET
+    titel = excluded.titel,
+    vorgangstyp = excluded.vorgangstyp,
+    typ = excluded.typ,
+    abstract = excluded.abstract,
+    aktualisiert = excluded.aktualisiert,
+    archiv = excluded.archiv,
+    beratungsstand = excluded.beratungsstand,
+    datum = excluded.datum,
+    gesta = excluded.gesta,
+    kom = excluded.kom,
+    mitteilung = excluded.mitteilung,
+    ratsdok = excluded.ratsdok,
+    sek = excluded.sek,
+    wahlperiode = excluded.wahlperiode,
+    deleted_at = NULL,
+    updated_at = datetime('now');
+
 -- name: UpdateVorgang :one
 UPDATE vorgang
 SET 
@@ -63,6 +88,7 @@ SET
     beratungsstand = ?,
     datum = ?,
     mitteilung = ?,
+    deleted_at = NULL,
     updated_at = datetime('now')
 WHERE id = ?
 RETURNING *;
@@ -70,6 +96,27 @@ RETURNING *;
 -- name: DeleteVorgang :exec
 DELETE FROM vorgang WHERE id = ?;
 
+-- name: DeleteVorgangInitiativen :exec
+DELETE FROM vorgang_initiative WHERE vorgang_id = ?;
+
+-- name: DeleteVorgangSachgebiete :exec
+DELETE FROM vorgang_sachgebiet WHERE vorgang_id = ?;
+
+-- name: DeleteVorgangZustimmungsbeduerftigkeiten :exec
+DELETE FROM vorgang_zustimmungsbeduerftigkeit WHERE vorgang_id = ?;
+
+-- name: DeleteVorgangDeskriptoren :exec
+DELETE FROM vorgang_deskriptor WHERE vorgang_id = ?;
+
+-- name: DeleteVerkuendungen :exec
+DELETE FROM verkuendung WHERE vorgang_id = ?;
+
+-- name: DeleteInkrafttreten :exec
+DELETE FROM inkrafttreten WHERE vorgang_id = ?;
+
+-- name: DeleteVorgangVerlinkungen :exec
+DELETE FROM vorgang_verlinkung WHERE source_vorgang_id = ?;
+
 -- name: CreateVorgangInitiative :exec
 INSERT INTO vorgang_initiative (vorgang_id, initiative)
 VALUES (?, ?);
@@ -99,6 +99,62 @@ INSERT INTO vorgangsposition (
     ?, ?, ?, ?, ?, ?
 ) RETURNING *;
 
+-- name: UpsertVorgangsposition :exec
+INSERT INTO vorgangsposition (
+    id, vorgang_id, titel, vorgangsposition, vorgangstyp, typ, dokumentart,
+    datum, aktualisiert, abstract, fortsetzung, gang, nachtrag, aktivitaet_anzahl,
+    kom, ratsdok, sek, zuordnung,
+    fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+    fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp,
+    fundstelle_anlagen, fundstelle_anfangsseite, fundstelle_endseite,
+    fundstelle_anfangsquadrant, fundstelle_endquadrant, fundstelle_seite,
+    fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
+    fundstelle_frage_nummer, fundstelle_verteildatum
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+    ?, ?, ?, ?, ?, ?
+) ON CONFLICT (id) DO UPDATE
+SET
+    vorgang_id = excluded.vorgang_id,
+    titel = excluded.titel,
+    vorgangsposition = excluded.vorgangsposition,
+    vorgangstyp = excluded.vorgangstyp,
+    typ = excluded.typ,
+    dokumentart = excluded.dokumentart,
+    datum = excluded.datum,
+    aktualisiert = excluded.aktualisiert,
+    abstract = excluded.abstract,
+    fortsetzung = excluded.fortsetzung,
+    gang = excluded.gang,
+    nachtrag = excluded.nachtrag,
+    aktivitaet_anzahl = excluded.aktivitaet_anzahl,
+    kom = excluded.kom,
+    ratsdok = excluded.ratsdok,
+    sek = excluded.sek,
+    zuordnung = excluded.zuordnung,
+    fundstelle_dokumentnummer = excluded.fundstelle_dokumentnummer,
+    fundstelle_datum = excluded.fundstelle_datum,
+    fundstelle_dokumentart = excluded.fundstelle_dokumentart,
+    fundstelle_herausgeber = excluded.fundstelle_herausgeber,
+    fundstelle_id = excluded.fundstelle_id,
+    fundstelle_drucksachetyp = excluded.fundstelle_drucksachetyp,
+    fundstelle_anlagen = excluded.fundstelle_anlagen,
+    fundstelle_anfangsseite = excluded.fundstelle_anfangsseite,
+    fundstelle_endseite = excluded.fundstelle_endseite,
+    fundstelle_anfangsquadrant = excluded.fundstelle_anfangsquadrant,
+    fundstelle_endquadrant = excluded.fundstelle_endquadrant,
+    fundstelle_seite = excluded.fundstelle_seite,
+    fundstelle_pdf_url = excluded.fundstelle_pdf_url,
+    fundstelle_xml_url = excluded.fundstelle_xml_url,
+    fundstelle_top = excluded.fundstelle_top,
+    fundstelle_top_zusatz = excluded.fundstelle_top_zusatz,
+    fundstelle_frage_nummer = excluded.fundstelle_frage_nummer,
+    fundstelle_verteildatum = excluded.fundstelle_verteildatum,
+    deleted_at = NULL,
+    updated_at = datetime('now');
+
 -- name: UpdateVorgangsposition :one
 UPDATE vorgangsposition
 SET 
@@ -106,6 +162,7 @@ SET
     aktualisiert = ?,
     abstract = ?,
     aktivitaet_anzahl = ?,
+    deleted_at = NULL,
     updated_at = datetime('now')
 WHERE id = ?
 RETURNING *;
@@ -113,6 +170,24 @@ RETURNING *;
 -- name: DeleteVorgangsposition :exec
 DELETE FROM vorgangsposition WHERE id = ?;
 
+-- name: DeleteAktivitaetAnzeigen :exec
+DELETE FROM aktivitaet_anzeige WHERE vorgangsposition_id = ?;
+
+-- name: DeleteBeschlussfassungen :exec
+DELETE FROM beschlussfassung WHERE vorgangsposition_id = ?;
+
+-- name: DeleteVorgangspositionRessorts :exec
+DELETE FROM vorgangsposition_ressort WHERE vorgangsposition_id = ?;
+
+-- name: DeleteVorgangspositionUrheber :exec
+DELETE FROM vorgangsposition_urheber WHERE vorgangsposition_id = ?;
+
+-- name: DeleteUeberweisungen :exec
+DELETE FROM ueberweisung WHERE vorgangsposition_id = ?;
+
+-- name: DeleteVorgangspositionMitberaten :exec
+DELETE FROM vorgangsposition_mitberaten WHERE vorgangsposition_id = ?;
+
 -- name: CreateVorgangspositionRessort :exec
 INSERT INTO vorgangsposition_ressort (vorgangsposition_id, ressort_id, federfuehrend)
 VALUES (?, ?, ?)
@@ -0,0 +1,282 @@
+// Package drift compares DIP entities of the live API with the copy in the
+// local database. The live entity is first passed through the store mapping
+// (see Normalizer), so fields the database does not keep and the order the
+// store imposes on child rows do not show up as differences; what remains is
+// what a new sync would change.
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	"github.com/Johanneslueke/dip-client/internal/offline"
+	"github.com/Johanneslueke/dip-client/internal/store"
+)
+
+// Change is a field that differs between the local and the live entity
+type Change struct {
+	Path  string `json:"path"`  // JSON path of the field, e.g. urheber[0].titel
+	Local any    `json:"local"` // nil if the field is missing locally
+	Live  any    `json:"live"`  // nil if the field is missing in the API
+}
+
+// Compare returns the fields that differ between local and live, two entities
+// of the same API type, ordered by path. Child lists such as urheber or
+// deskriptor are compared element by element. A missing field, null and an
+// empty list or object are the same.
+func Compare(local, live any) ([]Change, error) {
+	l, err := generic(local)
+	if err != nil {
+		return nil, err
+	}
+	r, err := generic(live)
+	if err != nil {
+		return nil, err
+	}
+	var changes []Change
+	compare("", l, r, &changes)
+	return changes, nil
+}
+
+func generic(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	return out, json.Unmarshal(data, &out)
+}
+
+func compare(path string, local, live any, changes *[]Change) {
+	local, live = empty(local), empty(live)
+	lm, lok := local.(map[string]any)
+	rm, rok := live.(map[string]any)
+	if lok && rok {
+		keys := make(map[string]bool)
+		for k := range lm {
+			keys[k] = true
+		}
+		for k := range rm {
+			keys[k] = true
+		}
+		names := make([]string, 0, len(keys))
+		for k := range keys {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		for _, k := range names {
+			compare(join(path, k), lm[k], rm[k], changes)
+		}
+		return
+	}
+	ls, lok := local.([]any)
+	rs, rok := live.([]any)
+	if lok && rok {
+		for i := 0; i < len(ls) || i < len(rs); i++ {
+			var l, r any
+			if i < len(ls) {
+				l = ls[i]
+			}
+			if i < len(rs) {
+				r = rs[i]
+			}
+			compare(path+"["+strconv.Itoa(i)+"]", l, r, changes)
+		}
+		return
+	}
+	if !reflect.DeepEqual(local, live) {
+		*changes = append(*changes, Change{Path: path, Local: local, Live: live})
+	}
+}
+
+// empty returns nil for empty lists and objects
+func empty(v any) any {
+	switch v := v.(type) {
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+	case map[string]any:
+		if len(v) == 0 {
+			return nil
+		}
+	}
+	return v
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+var index = regexp.MustCompile(`\[\d+\]`)
+
+// Field returns the path of a change without list indexes, e.g. urheber[].titel,
+// to count changes of the same field across entities
+func Field(path string) string {
+	return index.ReplaceAllString(path, "[]")
+}
+
+// Normalizer passes live entities through the store mapping: it writes them to
+// a scratch SQLite database and reads them back like the local database.
+type Normalizer struct {
+	dir string
+	s   store.Store
+	db  *offline.DB
+}
+
+// NewNormalizer creates the scratch database in a temporary directory. Its
+// migrations are not logged.
+func NewNormalizer() (*Normalizer, error) {
+	dir, err := os.MkdirTemp("", "dip-drift-")
+	if err != nil {
+		return nil, err
+	}
+	s, err := store.Open(store.SQLite, filepath.Join(dir, "scratch.db"), store.WithLogger(log.New(io.Discard, "", 0)))
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return &Normalizer{dir: dir, s: s, db: offline.New(s.DB())}, nil
+}
+
+// Close removes the scratch database
+func (n *Normalizer) Close() error {
+	err := n.s.Close()
+	if rmErr := os.RemoveAll(n.dir); err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// Normalize returns the entity as the local database would return it after
+// storing it. entity is a pointer to an entity type of the API, e.g. *client.Vorgang.
+func (n *Normalizer) Normalize(ctx context.Context, entity any) (any, error) {
+	switch e := entity.(type) {
+	case *client.Aktivitaet:
+		return roundtrip(ctx, e.Id, n.s.UpsertAktivitaet(ctx, *e), n.db.GetAktivitaet)
+	case *client.Drucksache:
+		return roundtrip(ctx, e.Id, n.s.UpsertDrucksache(ctx, *e), n.db.GetDrucksache)
+	case *client.DrucksacheText:
+		var meta client.Drucksache
+		if err := convert(e, &meta); err != nil {
+			return nil, err
+		}
+		err := n.s.UpsertDrucksache(ctx, meta)
+		if err == nil {
+			err = n.s.UpsertDrucksacheText(ctx, *e)
+		}
+		return roundtrip(ctx, e.Id, err, n.db.GetDrucksacheText)
+	case *client.Person:
+		var person store.PersonWithArrayWahlperiode
+		if err := convert(e, &person); err != nil {
+			return nil, err
+		}
+		return roundtrip(ctx, e.Id, n.s.UpsertPerson(ctx, person), n.db.GetPerson)
+	case *client.Plenarprotokoll:
+		return roundtrip(ctx, e.Id, n.s.UpsertPlenarprotokoll(ctx, *e), n.db.GetPlenarprotokoll)
+	case *client.PlenarprotokollText:
+		var meta client.Plenarprotokoll
+		if err := convert(e, &meta); err != nil {
+			return nil, err
+		}
+		err := n.s.UpsertPlenarprotokoll(ctx, meta)
+		if err == nil {
+			err = n.s.UpsertPlenarprotokollText(ctx, *e)
+		}
+		return roundtrip(ctx, e.Id, err, n.db.GetPlenarprotokollText)
+	case *client.Vorgang:
+		return roundtrip(ctx, e.Id, n.s.UpsertVorgang(ctx, *e), n.db.GetVorgang)
+	case *client.Vorgangsposition:
+		return roundtrip(ctx, e.Id, n.s.UpsertVorgangsposition(ctx, *e), n.db.GetVorgangsposition)
+	}
+	return nil, fmt.Errorf("cannot normalize %T", entity)
+}
+
+// roundtrip reads back an entity after its upsert, which returned err
+func roundtrip[P, E any](ctx context.Context, id string, err error, get func(context.Context, client.Id, *P) (*E, error)) (any, error) {
+	if err != nil {
+		return nil, fmt.Errorf("store %s: %w", id, err)
+	}
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID %q", id)
+	}
+	return get(ctx, n, nil)
+}
+
+// convert copies an entity into a type with the same JSON fields
+func convert(from, to any) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, to)
+}
+
+// Summary counts the drift of the entities of a Wahlperiode
+type Summary struct {
+	Wahlperiode int            `json:"wahlperiode"`
+	Checked     int            `json:"checked"` // entities found in both the API and the database
+	Drifted     int            `json:"drifted"` // entities with at least one change
+	Missing     int            `json:"missing"` // entities of the database that the API no longer returns
+	Fields      map[string]int `json:"fields"`  // drifted entities per field, see Field
+}
+
+// Add counts the changes of one checked entity
+func (s *Summary) Add(changes []Change) {
+	s.Checked++
+	if len(changes) == 0 {
+		return
+	}
+	s.Drifted++
+	if s.Fields == nil {
+		s.Fields = make(map[string]int)
+	}
+	seen := make(map[string]bool)
+	for _, c := range changes {
+		f := Field(c.Path)
+		if !seen[f] {
+			seen[f] = true
+			s.Fields[f]++
+		}
+	}
+}
+
+// Rate returns the share of checked entities that drifted
+func (s *Summary) Rate() float64 {
+	if s.Checked == 0 {
+		return 0
+	}
+	return float64(s.Drifted) / float64(s.Checked)
+}
+
+// TopFields returns the n fields that drifted most often, most frequent first
+func (s *Summary) TopFields(n int) []string {
+	fields := make([]string, 0, len(s.Fields))
+	for f := range s.Fields {
+		fields = append(fields, f)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		if s.Fields[fields[i]] != s.Fields[fields[j]] {
+			return s.Fields[fields[i]] > s.Fields[fields[j]]
+		}
+		return fields[i] < fields[j]
+	})
+	if len(fields) > n {
+		fields = fields[:n]
+	}
+	return fields
+}
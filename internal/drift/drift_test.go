@@ -0,0 +1,92 @@
+package drift
+
+import (
+	"context"
+	"testing"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	"github.com/Johanneslueke/dip-client/internal/offline"
+	"github.com/Johanneslueke/dip-client/internal/store/storetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	local := map[string]any{
+		"titel":      "A",
+		"sachgebiet": []any{"Energie"},
+		"urheber":    []any{},
+		"deskriptor": []any{map[string]any{"name": "Klimaschutz", "fundstelle": false}},
+	}
+	live := map[string]any{
+		"titel":      "B",
+		"sachgebiet": []any{"Energie", "Umwelt"},
+		"deskriptor": []any{map[string]any{"name": "Klimaschutz", "fundstelle": true}},
+		"abstract":   "neu",
+	}
+	changes, err := Compare(local, live)
+	require.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Path: "abstract", Live: "neu"},
+		{Path: "deskriptor[0].fundstelle", Local: false, Live: true},
+		{Path: "sachgebiet[1]", Live: "Umwelt"},
+		{Path: "titel", Local: "A", Live: "B"},
+	}, changes)
+
+	changes, err = Compare(local, local)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestNormalizeAgainstLocalCopy(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.Open(t)
+	vorgang := storetest.Vorgang(t, `{
+		"beratungsstand": "Dem Bundesrat zugeleitet",
+		"deskriptor": [{"name": "Wärmeplanung", "typ": "Sachbegriffe", "fundstelle": true}]
+	}`)
+	require.NoError(t, s.UpsertVorgang(ctx, vorgang))
+	local, err := offline.New(s.DB()).GetVorgang(ctx, 303271, nil)
+	require.NoError(t, err)
+
+	n, err := NewNormalizer()
+	require.NoError(t, err)
+	t.Cleanup(func() { n.Close() })
+
+	// An unchanged entity has no drift after the store mapping
+	normalized, err := n.Normalize(ctx, &vorgang)
+	require.NoError(t, err)
+	changes, err := Compare(local, normalized)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	live := vorgang
+	beratungsstand := "Verkündet"
+	live.Beratungsstand = &beratungsstand
+	deskriptoren := append(*vorgang.Deskriptor, client.VorgangDeskriptor{Name: "Klimaschutz", Typ: "Sachbegriffe"})
+	live.Deskriptor = &deskriptoren
+	normalized, err = n.Normalize(ctx, &live)
+	require.NoError(t, err)
+	changes, err = Compare(local, normalized)
+	require.NoError(t, err)
+	var paths []string
+	for _, c := range changes {
+		paths = append(paths, c.Path)
+	}
+	assert.Equal(t, []string{"beratungsstand", "deskriptor[1]"}, paths)
+	assert.Equal(t, "Verkündet", changes[0].Live)
+}
+
+func TestSummary(t *testing.T) {
+	var s Summary
+	s.Add(nil)
+	s.Add([]Change{{Path: "urheber[0].titel"}, {Path: "urheber[1].titel"}, {Path: "titel"}})
+	s.Add([]Change{{Path: "urheber[2].titel"}})
+	s.Add(nil)
+
+	assert.Equal(t, 4, s.Checked)
+	assert.Equal(t, 2, s.Drifted)
+	assert.Equal(t, 0.5, s.Rate())
+	assert.Equal(t, map[string]int{"urheber[].titel": 2, "titel": 1}, s.Fields)
+	assert.Equal(t, []string{"urheber[].titel"}, s.TopFields(1))
+}
@@ -0,0 +1,268 @@
+// Package gesetz traces Gesetze through the legislative process. It reads the
+// gesetz_trace and gesetz_timeline views of a SQLite database, created by
+// migration 0005, dropped by 0007 and restored by 0023. They join a
+// Gesetzgebung Vorgang with its Vorgangspositionen, Drucksachen,
+// Plenarprotokolle, Aktivitäten, Verkündung and Inkrafttreten. Rows marked as
+// deleted by sync-reconcile are left out.
+package gesetz
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrNotFound is returned by Find if no Gesetz matches the reference
+var ErrNotFound = errors.New("no Gesetzgebung Vorgang found")
+
+// DefaultLimit is the number of Gesetze returned by List if Options.Limit is not set
+const DefaultLimit = 100
+
+// Phases of the legislative process, see Event.Phase
+const (
+	PhaseVorgang       = "Vorgang"
+	PhaseDrucksache    = "Drucksache"
+	PhaseBeratung      = "Beratung"
+	PhaseBeschluss     = "Beschlussfassung"
+	PhaseAktivitaet    = "Aktivität"
+	PhaseVerkuendung   = "Verkündung"
+	PhaseInkrafttreten = "Inkrafttreten"
+)
+
+// Gesetz is a Gesetzgebung Vorgang with the summary of the gesetz_trace view
+type Gesetz struct {
+	VorgangID          string   `json:"vorgang_id"`
+	Titel              string   `json:"titel"`
+	Beratungsstand     string   `json:"beratungsstand,omitempty"`
+	Datum              string   `json:"datum,omitempty"`
+	Wahlperiode        int      `json:"wahlperiode"`
+	Gesta              string   `json:"gesta,omitempty"`
+	Sachgebiete        []string `json:"sachgebiete,omitempty"`
+	Initiativen        []string `json:"initiativen,omitempty"`
+	Ausfertigungsdatum string   `json:"ausfertigungsdatum,omitempty"`
+	Verkuendungsdatum  string   `json:"verkuendungsdatum,omitempty"`
+	Fundstelle         string   `json:"verkuendung_fundstelle,omitempty"`
+	VerkuendungPdfURL  string   `json:"verkuendung_pdf_url,omitempty"`
+	Inkrafttreten      string   `json:"inkrafttreten_datum,omitempty"`
+	Vorgangspositionen int      `json:"anzahl_vorgangspositionen"`
+	Aktivitaeten       int      `json:"anzahl_aktivitaeten"`
+	Drucksachen        int      `json:"anzahl_drucksachen"`
+	Plenarprotokolle   int      `json:"anzahl_plenarprotokolle"`
+}
+
+// Event is an entry of the timeline of a Gesetz
+type Event struct {
+	Datum        string `json:"datum"`
+	Phase        string `json:"phase"`       // one of the Phase constants
+	EntityType   string `json:"entity_type"` // table of the entity, e.g. drucksache
+	EntityID     string `json:"entity_id"`
+	Titel        string `json:"titel"`
+	Beschreibung string `json:"beschreibung,omitempty"` // e.g. "20/8654 - Gesetzentwurf"
+	Status       string `json:"status,omitempty"`
+	PdfURL       string `json:"pdf_url,omitempty"`
+}
+
+// Trace is a Gesetz with its chronological timeline
+type Trace struct {
+	Gesetz
+	Timeline []Event `json:"timeline"`
+}
+
+// Options filters List
+type Options struct {
+	Wahlperiode    int    // 0 = all
+	Beratungsstand string // e.g. Verkündet, empty for all
+	Limit          int    // 0 = DefaultLimit
+}
+
+// gesetzColumns are the columns of gesetz_trace t. Sachgebiete contain commas,
+// so the lists are read as JSON arrays instead of the GROUP_CONCATs of the view.
+const gesetzColumns = `
+	t.vorgang_id, t.gesetz_titel, t.beratungsstand, t.vorgang_datum, t.wahlperiode, t.gesta,
+	(SELECT json_group_array(sachgebiet) FROM (SELECT sachgebiet FROM vorgang_sachgebiet WHERE vorgang_id = t.vorgang_id ORDER BY id)),
+	(SELECT json_group_array(initiative) FROM (SELECT initiative FROM vorgang_initiative WHERE vorgang_id = t.vorgang_id ORDER BY id)),
+	t.ausfertigungsdatum, t.verkuendungsdatum,
+	t.verkuendung_fundstelle, t.verkuendung_pdf_url, t.inkrafttreten_datum,
+	t.anzahl_vorgangspositionen, t.anzahl_aktivitaeten, t.anzahl_drucksachen, t.anzahl_plenarprotokolle`
+
+// List returns the Gesetze matching opts, newest first
+func List(ctx context.Context, sqlDB *sql.DB, opts Options) ([]Gesetz, error) {
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultLimit
+	}
+	rows, err := sqlDB.QueryContext(ctx, `
+		SELECT `+gesetzColumns+`
+		FROM gesetz_trace t
+		JOIN vorgang v ON v.id = t.vorgang_id
+		WHERE v.deleted_at IS NULL
+		  AND (? = 0 OR t.wahlperiode = ?)
+		  AND (? = '' OR t.beratungsstand = ?)
+		ORDER BY t.vorgang_datum DESC, t.vorgang_id DESC
+		LIMIT ?`,
+		opts.Wahlperiode, opts.Wahlperiode, opts.Beratungsstand, opts.Beratungsstand, opts.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Gesetze: %w", err)
+	}
+	defer rows.Close()
+	return scanGesetze(rows)
+}
+
+// Find returns the trace of a Gesetz by its Vorgang ID or GESTA-Ordnungsnummer.
+// GESTA numbers repeat across Wahlperioden; wahlperiode (0 = any) selects one.
+func Find(ctx context.Context, sqlDB *sql.DB, ref string, wahlperiode int) (*Trace, error) {
+	column := "t.gesta"
+	if _, err := strconv.Atoi(ref); err == nil {
+		column = "t.vorgang_id"
+	}
+	rows, err := sqlDB.QueryContext(ctx, `
+		SELECT `+gesetzColumns+`
+		FROM gesetz_trace t
+		JOIN vorgang v ON v.id = t.vorgang_id
+		WHERE v.deleted_at IS NULL
+		  AND `+column+` = ?
+		  AND (? = 0 OR t.wahlperiode = ?)
+		ORDER BY t.wahlperiode DESC`,
+		ref, wahlperiode, wahlperiode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find Gesetz %s: %w", ref, err)
+	}
+	defer rows.Close()
+	gesetze, err := scanGesetze(rows)
+	if err != nil {
+		return nil, err
+	}
+	switch len(gesetze) {
+	case 0:
+		return nil, fmt.Errorf("%w for %q", ErrNotFound, ref)
+	case 1:
+	default:
+		var candidates []string
+		for _, g := range gesetze {
+			candidates = append(candidates, fmt.Sprintf("%s (WP %d)", g.VorgangID, g.Wahlperiode))
+		}
+		return nil, fmt.Errorf("GESTA %s matches several Vorgänge, select the Wahlperiode or use an ID: %s",
+			ref, strings.Join(candidates, ", "))
+	}
+
+	trace := &Trace{Gesetz: gesetze[0]}
+	if trace.Timeline, err = timeline(ctx, sqlDB, trace.VorgangID); err != nil {
+		return nil, fmt.Errorf("failed to load the timeline of %s: %w", trace.VorgangID, err)
+	}
+	return trace, nil
+}
+
+func scanGesetze(rows *sql.Rows) ([]Gesetz, error) {
+	var gesetze []Gesetz
+	for rows.Next() {
+		var (
+			g                                                      Gesetz
+			beratungsstand, datum, gesta                           sql.NullString
+			sachgebiete, initiativen                               string
+			ausfertigung, verkuendung, fundstelle, pdfURL, inkraft sql.NullString
+			wahlperiode                                            sql.NullInt64
+		)
+		if err := rows.Scan(&g.VorgangID, &g.Titel, &beratungsstand, &datum, &wahlperiode, &gesta,
+			&sachgebiete, &initiativen, &ausfertigung, &verkuendung, &fundstelle, &pdfURL, &inkraft,
+			&g.Vorgangspositionen, &g.Aktivitaeten, &g.Drucksachen, &g.Plenarprotokolle); err != nil {
+			return nil, err
+		}
+		g.Beratungsstand = beratungsstand.String
+		g.Datum = datum.String
+		g.Wahlperiode = int(wahlperiode.Int64)
+		g.Gesta = gesta.String
+		if err := json.Unmarshal([]byte(sachgebiete), &g.Sachgebiete); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(initiativen), &g.Initiativen); err != nil {
+			return nil, err
+		}
+		g.Ausfertigungsdatum = ausfertigung.String
+		g.Verkuendungsdatum = verkuendung.String
+		g.Fundstelle = fundstelle.String
+		g.VerkuendungPdfURL = pdfURL.String
+		g.Inkrafttreten = inkraft.String
+		gesetze = append(gesetze, g)
+	}
+	return gesetze, rows.Err()
+}
+
+// timeline returns the events of a Vorgang in chronological order. Events of
+// the same day follow the order of the process, e.g. Drucksache before Beratung.
+func timeline(ctx context.Context, sqlDB *sql.DB, vorgangID string) ([]Event, error) {
+	rows, err := sqlDB.QueryContext(ctx, `
+		WITH deleted(entity_type, id) AS (
+			SELECT 'vorgangsposition', id FROM vorgangsposition WHERE deleted_at IS NOT NULL
+			UNION ALL SELECT 'aktivitaet', id FROM aktivitaet WHERE deleted_at IS NOT NULL
+			UNION ALL SELECT 'drucksache', id FROM drucksache WHERE deleted_at IS NOT NULL
+			UNION ALL SELECT 'plenarprotokoll', id FROM plenarprotokoll WHERE deleted_at IS NOT NULL
+		)
+		SELECT t.entity_type, t.entity_id, t.event_date, t.event_title, t.event_description, t.status, t.pdf_url
+		FROM gesetz_timeline t
+		WHERE t.vorgang_id = ?
+		  AND (t.entity_type, t.entity_id) NOT IN (SELECT entity_type, id FROM deleted)
+		ORDER BY t.event_date,
+		         CASE t.entity_type
+		             WHEN 'vorgang' THEN 0 WHEN 'drucksache' THEN 1 WHEN 'vorgangsposition' THEN 2
+		             WHEN 'plenarprotokoll' THEN 3 WHEN 'aktivitaet' THEN 4 WHEN 'verkuendung' THEN 5
+		             ELSE 6 END,
+		         t.entity_id`, vorgangID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var (
+			e                                          Event
+			datum, titel, beschreibung, status, pdfURL sql.NullString
+		)
+		if err := rows.Scan(&e.EntityType, &e.EntityID, &datum, &titel, &beschreibung, &status, &pdfURL); err != nil {
+			return nil, err
+		}
+		e.Datum = datum.String
+		e.Titel = titel.String
+		e.Beschreibung = beschreibung.String
+		e.Status = status.String
+		e.PdfURL = pdfURL.String
+		e.Phase = phase(e)
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// phase classifies an event. Vorgangspositionen are named like the step, e.g.
+// "1. Beratung", "Beschlussempfehlung und Bericht" or "Gesetzesbeschluss"; their
+// description is "<vorgangsposition> - <dokumentart>".
+func phase(e Event) string {
+	switch e.EntityType {
+	case "vorgang":
+		return PhaseVorgang
+	case "drucksache":
+		return PhaseDrucksache
+	case "plenarprotokoll":
+		return PhaseBeratung
+	case "aktivitaet":
+		return PhaseAktivitaet
+	case "verkuendung":
+		return PhaseVerkuendung
+	case "inkrafttreten":
+		return PhaseInkrafttreten
+	}
+	position := strings.ToLower(e.Beschreibung)
+	switch {
+	case strings.Contains(position, "beschlussempfehlung"):
+		return PhaseDrucksache
+	case strings.Contains(position, "beschluss"), strings.Contains(position, "abstimmung"),
+		strings.Contains(position, "zustimmung"), strings.Contains(position, "einspruch"):
+		return PhaseBeschluss
+	case strings.Contains(position, "beratung"), strings.Contains(position, "durchgang"),
+		strings.HasSuffix(position, "plenarprotokoll"):
+		return PhaseBeratung
+	}
+	return PhaseDrucksache
+}
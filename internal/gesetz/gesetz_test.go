@@ -0,0 +1,107 @@
+package gesetz
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/store/storetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openTestDB(t *testing.T) store.Store {
+	t.Helper()
+	ctx := context.Background()
+	s := storetest.Open(t)
+	require.NoError(t, s.UpsertVorgang(ctx, storetest.Vorgang(t)))
+	require.NoError(t, s.UpsertDrucksache(ctx, storetest.Drucksache(t)))
+	require.NoError(t, s.UpsertVorgangsposition(ctx, storetest.Beratung(t)))
+
+	// The same GESTA number in the previous Wahlperiode
+	require.NoError(t, s.UpsertVorgang(ctx, storetest.Vorgang(t, `{
+		"id": "250001", "wahlperiode": 19, "beratungsstand": "Erledigt durch Ablauf der Wahlperiode",
+		"verkuendung": null, "inkrafttreten": null
+	}`)))
+	return s
+}
+
+func TestFind(t *testing.T) {
+	ctx := context.Background()
+	s := openTestDB(t)
+
+	trace, err := Find(ctx, s.DB(), "303271", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "Wärmeplanungsgesetz", trace.Titel)
+	assert.Equal(t, []string{"Energie", "Raumordnung, Bau- und Wohnungswesen"}, trace.Sachgebiete)
+	assert.Equal(t, "2023-12-22", trace.Verkuendungsdatum)
+	assert.Equal(t, 1, trace.Drucksachen)
+
+	var phases []string
+	for _, e := range trace.Timeline {
+		phases = append(phases, e.Datum+" "+e.Phase)
+	}
+	assert.Equal(t, []string{
+		"2023-08-16 Vorgang",
+		"2023-10-02 Drucksache",
+		"2023-10-12 Beratung",
+		"2023-12-22 Verkündung",
+		"2024-01-01 Inkrafttreten",
+	}, phases)
+	assert.Equal(t, "https://dserver.bundestag.de/btd/20/086/2008654.pdf", trace.Timeline[1].PdfURL)
+
+	// GESTA numbers repeat across Wahlperioden
+	_, err = Find(ctx, s.DB(), "N001", 0)
+	assert.ErrorContains(t, err, "303271 (WP 20), 250001 (WP 19)")
+	trace, err = Find(ctx, s.DB(), "N001", 19)
+	require.NoError(t, err)
+	assert.Equal(t, "250001", trace.VorgangID)
+
+	_, err = Find(ctx, s.DB(), "999", 0)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Deleted rows are left out
+	require.NoError(t, s.MarkDeleted(ctx, store.DrucksacheTable, "264030", time.Now()))
+	trace, err = Find(ctx, s.DB(), "303271", 0)
+	require.NoError(t, err)
+	assert.Len(t, trace.Timeline, 4)
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+	s := openTestDB(t)
+
+	gesetze, err := List(ctx, s.DB(), Options{})
+	require.NoError(t, err)
+	assert.Len(t, gesetze, 2)
+
+	gesetze, err = List(ctx, s.DB(), Options{Wahlperiode: 20, Beratungsstand: "Verkündet"})
+	require.NoError(t, err)
+	require.Len(t, gesetze, 1)
+	assert.Equal(t, "303271", gesetze[0].VorgangID)
+
+	gesetze, err = List(ctx, s.DB(), Options{Wahlperiode: 19, Beratungsstand: "Verkündet"})
+	require.NoError(t, err)
+	assert.Empty(t, gesetze)
+}
+
+func TestReports(t *testing.T) {
+	trace, err := Find(context.Background(), openTestDB(t).DB(), "303271", 0)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteMarkdown(&buf, trace))
+	assert.Contains(t, buf.String(), "# Wärmeplanungsgesetz\n")
+	assert.Contains(t, buf.String(), "| 2023-10-02 | Drucksache | 20/8654 - Gesetzentwurf: Entwurf eines Gesetzes für die Wärmeplanung | [PDF](https://dserver.bundestag.de/btd/20/086/2008654.pdf) |")
+
+	buf.Reset()
+	require.NoError(t, WriteHTML(&buf, trace))
+	assert.Contains(t, buf.String(), `<a href="https://dserver.bundestag.de/btp/20/20128.pdf">PDF</a>`)
+	assert.Contains(t, buf.String(), "<dt>Verkündet</dt><dd>2023-12-22 BGBl I 2023 Nr. 394</dd>")
+
+	buf.Reset()
+	require.NoError(t, WriteText(&buf, trace))
+	assert.Contains(t, buf.String(), "2024-01-01  Inkrafttreten")
+}
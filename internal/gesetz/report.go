@@ -0,0 +1,200 @@
+package gesetz
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// WriteText writes the trace for the terminal: the summary and one line per
+// event, with the PDF link below
+func WriteText(w io.Writer, t *Trace) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", t.Titel)
+	fmt.Fprintf(&b, "Vorgang %s, WP %d", t.VorgangID, t.Wahlperiode)
+	if t.Gesta != "" {
+		fmt.Fprintf(&b, ", GESTA %s", t.Gesta)
+	}
+	fmt.Fprintf(&b, "\n")
+	for _, f := range summaryFields(&t.Gesetz) {
+		fmt.Fprintf(&b, "%-16s %s\n", f.Name+":", f.Value)
+	}
+	fmt.Fprintf(&b, "\n")
+
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	for _, e := range t.Timeline {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", e.Datum, e.Phase, eventText(e))
+		if e.PdfURL != "" {
+			fmt.Fprintf(tw, "\t\t%s\n", e.PdfURL)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMarkdown writes the trace as a Markdown report
+func WriteMarkdown(w io.Writer, t *Trace) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", t.Titel)
+	fmt.Fprintf(&b, "- **Vorgang:** %s\n", t.VorgangID)
+	fmt.Fprintf(&b, "- **Wahlperiode:** %d\n", t.Wahlperiode)
+	if t.Gesta != "" {
+		fmt.Fprintf(&b, "- **GESTA:** %s\n", t.Gesta)
+	}
+	for _, f := range summaryFields(&t.Gesetz) {
+		fmt.Fprintf(&b, "- **%s:** %s\n", f.Name, markdownCell(f.Value))
+	}
+	if t.VerkuendungPdfURL != "" {
+		fmt.Fprintf(&b, "- **Verkündung:** [PDF](%s)\n", t.VerkuendungPdfURL)
+	}
+	fmt.Fprintf(&b, "\n## Timeline\n\n")
+	fmt.Fprintf(&b, "| Datum | Phase | Ereignis | PDF |\n")
+	fmt.Fprintf(&b, "|---|---|---|---|\n")
+	for _, e := range t.Timeline {
+		pdf := ""
+		if e.PdfURL != "" {
+			pdf = fmt.Sprintf("[PDF](%s)", e.PdfURL)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", e.Datum, e.Phase, markdownCell(eventText(e)), pdf)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteHTML writes the trace as a standalone HTML page
+func WriteHTML(w io.Writer, t *Trace) error {
+	return htmlReport.Execute(w, struct {
+		*Trace
+		Summary []field
+	}{t, summaryFields(&t.Gesetz)})
+}
+
+// WriteListText writes Gesetze as a table for the terminal
+func WriteListText(w io.Writer, gesetze []Gesetz) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VORGANG\tWP\tGESTA\tDATUM\tBERATUNGSSTAND\tVERKÜNDET\tTITEL")
+	for _, g := range gesetze {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			g.VorgangID, g.Wahlperiode, g.Gesta, g.Datum, g.Beratungsstand, g.Verkuendungsdatum, shorten(g.Titel, 80))
+	}
+	return tw.Flush()
+}
+
+// WriteListMarkdown writes Gesetze as a Markdown table
+func WriteListMarkdown(w io.Writer, gesetze []Gesetz) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "| Vorgang | WP | GESTA | Datum | Beratungsstand | Verkündet | Titel |\n")
+	fmt.Fprintf(&b, "|---|---|---|---|---|---|---|\n")
+	for _, g := range gesetze {
+		fmt.Fprintf(&b, "| %s | %d | %s | %s | %s | %s | %s |\n",
+			g.VorgangID, g.Wahlperiode, g.Gesta, g.Datum, markdownCell(g.Beratungsstand), g.Verkuendungsdatum, markdownCell(g.Titel))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteListHTML writes Gesetze as a standalone HTML page
+func WriteListHTML(w io.Writer, gesetze []Gesetz) error {
+	return htmlList.Execute(w, gesetze)
+}
+
+// field is a line of the summary of a Gesetz
+type field struct {
+	Name, Value string
+}
+
+func summaryFields(g *Gesetz) []field {
+	var fields []field
+	add := func(name, value string) {
+		if value != "" {
+			fields = append(fields, field{name, value})
+		}
+	}
+	add("Beratungsstand", g.Beratungsstand)
+	add("Initiative", strings.Join(g.Initiativen, "; "))
+	add("Sachgebiete", strings.Join(g.Sachgebiete, "; "))
+	add("Ausgefertigt", g.Ausfertigungsdatum)
+	verkuendet := g.Verkuendungsdatum
+	if g.Fundstelle != "" {
+		verkuendet = strings.TrimSpace(verkuendet + " " + g.Fundstelle)
+	}
+	add("Verkündet", verkuendet)
+	add("In Kraft", g.Inkrafttreten)
+	add("Dokumente", fmt.Sprintf("%d Drucksachen, %d Plenarprotokolle, %d Vorgangspositionen, %d Aktivitäten",
+		g.Drucksachen, g.Plenarprotokolle, g.Vorgangspositionen, g.Aktivitaeten))
+	return fields
+}
+
+// eventText is the title of an event with its description, e.g. the Dokumentnummer
+func eventText(e Event) string {
+	if e.Beschreibung == "" || e.Beschreibung == e.Titel {
+		return e.Titel
+	}
+	return e.Beschreibung + ": " + e.Titel
+}
+
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func shorten(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+const htmlStyle = `<style>
+body { font-family: sans-serif; max-width: 70em; margin: 2em auto; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid #ddd; padding: 0.3em 0.6em; text-align: left; vertical-align: top; }
+th { background: #f3f3f3; }
+td.datum { white-space: nowrap; }
+</style>`
+
+var htmlReport = template.Must(template.New("trace").Parse(`<!DOCTYPE html>
+<html lang="de">
+<head>
+<meta charset="utf-8">
+<title>{{.Titel}}</title>
+` + htmlStyle + `
+</head>
+<body>
+<h1>{{.Titel}}</h1>
+<dl>
+<dt>Vorgang</dt><dd>{{.VorgangID}}, WP {{.Wahlperiode}}{{if .Gesta}}, GESTA {{.Gesta}}{{end}}</dd>
+{{range .Summary}}<dt>{{.Name}}</dt><dd>{{.Value}}</dd>
+{{end}}{{if .VerkuendungPdfURL}}<dt>Verkündung</dt><dd><a href="{{.VerkuendungPdfURL}}">PDF</a></dd>
+{{end}}</dl>
+<h2>Timeline</h2>
+<table>
+<tr><th>Datum</th><th>Phase</th><th>Ereignis</th><th>PDF</th></tr>
+{{range .Timeline}}<tr><td class="datum">{{.Datum}}</td><td>{{.Phase}}</td><td>{{if .Beschreibung}}{{.Beschreibung}}<br>{{end}}{{.Titel}}</td><td>{{if .PdfURL}}<a href="{{.PdfURL}}">PDF</a>{{end}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+var htmlList = template.Must(template.New("list").Parse(`<!DOCTYPE html>
+<html lang="de">
+<head>
+<meta charset="utf-8">
+<title>Gesetze</title>
+` + htmlStyle + `
+</head>
+<body>
+<h1>Gesetze</h1>
+<table>
+<tr><th>Vorgang</th><th>WP</th><th>GESTA</th><th>Datum</th><th>Beratungsstand</th><th>Verkündet</th><th>Titel</th></tr>
+{{range .}}<tr><td>{{.VorgangID}}</td><td>{{.Wahlperiode}}</td><td>{{.Gesta}}</td><td class="datum">{{.Datum}}</td><td>{{.Beratungsstand}}</td><td class="datum">{{.Verkuendungsdatum}}</td><td>{{.Titel}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
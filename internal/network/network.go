@@ -0,0 +1,307 @@
+// Package network builds co-authorship graphs from the local SQLite database:
+// persons connected by the Drucksachen they share, and Fraktionen connected by
+// the Drucksachen they introduce together. Rows marked as deleted by
+// sync-reconcile are left out.
+package network
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Graph kinds, see Options.Kind
+const (
+	Person   = "person"
+	Fraktion = "fraktion"
+)
+
+// Kinds are the valid values of Options.Kind
+var Kinds = []string{Person, Fraktion}
+
+// Attribute types
+const (
+	String  = "string"
+	Integer = "integer"
+)
+
+// Attribute describes a node attribute
+type Attribute struct {
+	Name string
+	Type string // String or Integer
+}
+
+// Node is a person or Fraktion
+type Node struct {
+	ID    string
+	Label string
+	Attrs map[string]string // by Attribute.Name, missing values are left out
+}
+
+// Edge connects two nodes, Source < Target. Weight is the number of shared Drucksachen.
+type Edge struct {
+	Source string
+	Target string
+	Weight int
+}
+
+// Graph is an undirected, weighted graph
+type Graph struct {
+	Kind        string
+	Description string
+	Attributes  []Attribute
+	Nodes       []Node // sorted by ID
+	Edges       []Edge // sorted by Source and Target
+}
+
+// Options selects the graph and the Drucksachen it is built from
+type Options struct {
+	Kind         string // one of Kinds, empty = Person
+	Wahlperioden []int  // empty = all
+	From, To     string // Drucksache datum range YYYY-MM-DD, empty = open
+	MinWeight    int    // edges with fewer shared Drucksachen are left out, 0 = 1
+}
+
+// personAttributes are the node attributes of the person graph, from the
+// MdB Stammdaten linked by link-person-mdb
+var personAttributes = []Attribute{
+	{"mdb_id", String},
+	{"partei", String},
+	{"geschlecht", String},
+	{"bundesland", String},
+	{"drucksachen", Integer},
+}
+
+var fraktionAttributes = []Attribute{
+	{"drucksachen", Integer},
+}
+
+// Build builds the graph of opts. Nodes without an edge are left out.
+//
+// In the person graph, two persons are connected for every Drucksache both are
+// an author of, from the displayed authors (drucksache_autor_anzeige, up to
+// four per Drucksache) and the Aktivitäten citing the Drucksache. Aktivitäten
+// carry no person ID; their titel, e.g. "Name, MdB, Fraktion", is matched
+// against the titles of persons and authors and left out if it is ambiguous.
+//
+// In the Fraktion graph, two Fraktionen are connected for every Drucksache
+// they are both an Urheber of.
+func Build(ctx context.Context, sqlDB *sql.DB, opts Options) (*Graph, error) {
+	if opts.Kind == "" {
+		opts.Kind = Person
+	}
+	if !slices.Contains(Kinds, opts.Kind) {
+		return nil, fmt.Errorf("unknown graph %q, must be one of %s", opts.Kind, strings.Join(Kinds, ", "))
+	}
+	if opts.MinWeight < 1 {
+		opts.MinWeight = 1
+	}
+
+	query, args := membershipQuery(opts)
+	members, err := loadMembers(ctx, sqlDB, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the %s graph: %w", opts.Kind, err)
+	}
+
+	g := &Graph{Kind: opts.Kind, Description: describe(opts)}
+	weights := map[[2]string]int{}
+	drucksachen := map[string]int{}
+	for _, ids := range members {
+		slices.Sort(ids)
+		ids = slices.Compact(ids)
+		for i, a := range ids {
+			drucksachen[a]++
+			for _, b := range ids[i+1:] {
+				weights[[2]string{a, b}]++
+			}
+		}
+	}
+	connected := map[string]bool{}
+	for pair, weight := range weights {
+		if weight < opts.MinWeight {
+			continue
+		}
+		g.Edges = append(g.Edges, Edge{Source: pair[0], Target: pair[1], Weight: weight})
+		connected[pair[0]], connected[pair[1]] = true, true
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		a, b := g.Edges[i], g.Edges[j]
+		return a.Source < b.Source || a.Source == b.Source && a.Target < b.Target
+	})
+
+	ids := make([]string, 0, len(connected))
+	for id := range connected {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	if opts.Kind == Fraktion {
+		g.Attributes = fraktionAttributes
+		for _, id := range ids {
+			g.Nodes = append(g.Nodes, Node{ID: id, Label: id, Attrs: map[string]string{}})
+		}
+	} else {
+		g.Attributes = personAttributes
+		if g.Nodes, err = personNodes(ctx, sqlDB, ids); err != nil {
+			return nil, fmt.Errorf("failed to load the persons: %w", err)
+		}
+	}
+	for i := range g.Nodes {
+		g.Nodes[i].Attrs["drucksachen"] = strconv.Itoa(drucksachen[g.Nodes[i].ID])
+	}
+	return g, nil
+}
+
+// membershipQuery returns the (drucksache_id, node_id) pairs of the graph
+func membershipQuery(opts Options) (string, []any) {
+	var (
+		filters = []string{"d.deleted_at IS NULL"}
+		args    []any
+	)
+	if len(opts.Wahlperioden) > 0 {
+		filters = append(filters, "d.wahlperiode IN (?"+strings.Repeat(", ?", len(opts.Wahlperioden)-1)+")")
+		for _, wp := range opts.Wahlperioden {
+			args = append(args, wp)
+		}
+	}
+	if opts.From != "" {
+		filters = append(filters, "d.datum >= ?")
+		args = append(args, opts.From)
+	}
+	if opts.To != "" {
+		filters = append(filters, "d.datum <= ?")
+		args = append(args, opts.To)
+	}
+	drucksachen := "SELECT d.id FROM drucksache d WHERE " + strings.Join(filters, " AND ")
+
+	if opts.Kind == Fraktion {
+		return `
+			WITH drucksachen AS (` + drucksachen + `)
+			SELECT du.drucksache_id, u.titel
+			FROM drucksache_urheber du
+			JOIN drucksachen d ON d.id = du.drucksache_id
+			JOIN urheber u ON u.id = du.urheber_id
+			WHERE u.titel LIKE 'Fraktion%'`, args
+	}
+	return `
+		WITH drucksachen AS (` + drucksachen + `),
+		titles AS (
+			SELECT titel, MIN(person_id) AS person_id
+			FROM (
+				SELECT autor_titel AS titel, person_id FROM drucksache_autor_anzeige
+				UNION
+				SELECT titel, id FROM person WHERE deleted_at IS NULL
+			)
+			WHERE titel IS NOT NULL AND titel != ''
+			GROUP BY titel
+			HAVING COUNT(DISTINCT person_id) = 1
+		)
+		SELECT da.drucksache_id, da.person_id
+		FROM drucksache_autor_anzeige da
+		JOIN drucksachen d ON d.id = da.drucksache_id
+		WHERE da.person_id IS NOT NULL AND da.person_id != ''
+		UNION
+		SELECT a.fundstelle_id, t.person_id
+		FROM aktivitaet a
+		JOIN drucksachen d ON d.id = a.fundstelle_id
+		JOIN titles t ON t.titel = a.titel
+		WHERE a.deleted_at IS NULL AND a.fundstelle_dokumentart = 'Drucksache'`, args
+}
+
+// loadMembers returns the node IDs per Drucksache
+func loadMembers(ctx context.Context, sqlDB *sql.DB, query string, args []any) (map[string][]string, error) {
+	rows, err := sqlDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	members := map[string][]string{}
+	for rows.Next() {
+		var drucksache, id string
+		if err := rows.Scan(&drucksache, &id); err != nil {
+			return nil, err
+		}
+		members[drucksache] = append(members[drucksache], id)
+	}
+	return members, rows.Err()
+}
+
+// personNodes returns the persons with their MdB Stammdaten. Persons not synced
+// are labeled with their displayed author name. The Bundesland is the one of the
+// latest Wahlperiode: the Land of the Wahlkreis or the Landesliste.
+func personNodes(ctx context.Context, sqlDB *sql.DB, ids []string) ([]Node, error) {
+	nodes := make([]Node, 0, len(ids))
+	stmt, err := sqlDB.PrepareContext(ctx, `
+		SELECT p.vorname, p.nachname,
+			(SELECT da.title FROM drucksache_autor_anzeige da
+			 WHERE da.person_id = x.id AND da.title != '' LIMIT 1),
+			l.mdb_id, b.partei_kurz, b.geschlecht,
+			(SELECT COALESCE(NULLIF(m.wkr_land, ''), m.liste)
+			 FROM mdb_wahlperiode_membership m WHERE m.mdb_id = l.mdb_id
+			 ORDER BY m.wp DESC LIMIT 1)
+		FROM (SELECT ? AS id) x
+		LEFT JOIN person p ON p.id = x.id AND p.deleted_at IS NULL
+		LEFT JOIN person_mdb_link l ON l.person_id = x.id
+		LEFT JOIN mdb_biographical b ON b.mdb_id = l.mdb_id
+		ORDER BY l.mdb_id
+		LIMIT 1`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	for _, id := range ids {
+		var vorname, nachname, autor, mdbID, partei, geschlecht, bundesland sql.NullString
+		err := stmt.QueryRowContext(ctx, id).Scan(&vorname, &nachname, &autor, &mdbID, &partei, &geschlecht, &bundesland)
+		if err != nil {
+			return nil, err
+		}
+		n := Node{ID: id, Label: strings.TrimSpace(vorname.String + " " + nachname.String), Attrs: map[string]string{}}
+		if n.Label == "" {
+			n.Label = or(autor.String, id)
+		}
+		for name, value := range map[string]sql.NullString{
+			"mdb_id": mdbID, "partei": partei, "geschlecht": geschlecht, "bundesland": bundesland,
+		} {
+			if value.String != "" {
+				n.Attrs[name] = value.String
+			}
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+// describe summarizes the selection of the graph
+func describe(opts Options) string {
+	var parts []string
+	if opts.Kind == Fraktion {
+		parts = append(parts, "Fraktionen connected by the Drucksachen they introduced together")
+	} else {
+		parts = append(parts, "Persons connected by the Drucksachen they authored together")
+	}
+	if len(opts.Wahlperioden) > 0 {
+		wps := make([]string, len(opts.Wahlperioden))
+		for i, wp := range opts.Wahlperioden {
+			wps[i] = strconv.Itoa(wp)
+		}
+		parts = append(parts, "Wahlperiode "+strings.Join(wps, ", "))
+	}
+	if opts.From != "" || opts.To != "" {
+		parts = append(parts, fmt.Sprintf("Drucksachen from %s to %s", or(opts.From, "the start"), or(opts.To, "today")))
+	}
+	if opts.MinWeight > 1 {
+		parts = append(parts, fmt.Sprintf("at least %d shared Drucksachen per edge", opts.MinWeight))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func or(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
@@ -0,0 +1,221 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/store/storetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// aktivitaetJSON is an Aktivität citing a Drucksache
+const aktivitaetJSON = `{
+	"id": "900001", "titel": "Christian Lindner, MdB, FDP", "aktivitaetsart": "Antrag", "typ": "Aktivität",
+	"dokumentart": "Drucksache", "datum": "2024-03-01", "aktualisiert": "2024-03-05T11:12:13+01:00",
+	"vorgangsbezug_anzahl": 0, "wahlperiode": 20,
+	"fundstelle": {"id": "300002", "dokumentnummer": "20/2", "datum": "2024-03-01", "dokumentart": "Drucksache", "herausgeber": "BT"}
+}`
+
+// autor is a displayed author of a Drucksache
+func autor(id, titel string) map[string]string {
+	name, _, _ := strings.Cut(titel, ",")
+	return map[string]string{"id": id, "autor_titel": titel, "title": name}
+}
+
+func urheber(titel ...string) []map[string]string {
+	var u []map[string]string
+	for _, t := range titel {
+		u = append(u, map[string]string{"bezeichnung": t, "titel": t})
+	}
+	return u
+}
+
+func openTestDB(t *testing.T) store.Store {
+	t.Helper()
+	ctx := context.Background()
+	s := storetest.Open(t)
+
+	var (
+		geywitz  = autor("7001", "Klara Geywitz, MdB, SPD")
+		goering  = autor("7002", "Katrin Göring-Eckardt, MdB, BÜNDNIS 90/DIE GRÜNEN")
+		lindner  = autor("7003", "Christian Lindner, MdB, FDP")
+		spd      = "Fraktion der SPD"
+		gruene   = "Fraktion BÜNDNIS 90/DIE GRÜNEN"
+		fdp      = "Fraktion der FDP"
+		overlays = []map[string]any{
+			{"id": "300001", "dokumentnummer": "20/1", "datum": "2023-10-02", "wahlperiode": 20,
+				"autoren_anzeige": []any{geywitz, goering}, "urheber": urheber(spd, gruene)},
+			{"id": "300002", "dokumentnummer": "20/2", "datum": "2024-03-01", "wahlperiode": 20,
+				"autoren_anzeige": []any{geywitz, goering}, "urheber": urheber(spd, gruene, fdp)},
+			{"id": "200001", "dokumentnummer": "19/1", "datum": "2019-05-02", "wahlperiode": 19,
+				"autoren_anzeige": []any{geywitz, lindner}, "urheber": urheber(spd, "Bundesregierung")},
+		}
+	)
+	for _, overlay := range overlays {
+		data, err := json.Marshal(overlay)
+		require.NoError(t, err)
+		require.NoError(t, s.UpsertDrucksache(ctx, storetest.Drucksache(t, string(data))))
+	}
+	var aktivitaet client.Aktivitaet
+	require.NoError(t, json.Unmarshal([]byte(aktivitaetJSON), &aktivitaet))
+	require.NoError(t, s.UpsertAktivitaet(ctx, aktivitaet))
+
+	// MdB Stammdaten of Klara Geywitz, linked by link-person-mdb
+	for _, stmt := range []string{
+		`INSERT INTO person (id, nachname, vorname, typ, aktualisiert, titel)
+		 VALUES ('7001', 'Geywitz', 'Klara', 'Person', '2024-01-01T00:00:00Z', 'Klara Geywitz, MdB, SPD')`,
+		`INSERT INTO mdb_person (id) VALUES ('11000001')`,
+		`INSERT INTO mdb_biographical (mdb_id, geschlecht, partei_kurz) VALUES ('11000001', 'weiblich', 'SPD')`,
+		`INSERT INTO mdb_wahlperiode_membership (mdb_id, wp, mdbwp_von, wkr_land, liste)
+		 VALUES ('11000001', 19, '24.10.2017', 'NW', 'NW'), ('11000001', 20, '26.10.2021', '', 'BB')`,
+		`INSERT INTO person_mdb_link (person_id, mdb_id, match_confidence, match_method)
+		 VALUES ('7001', '11000001', 'exact', 'name_exact')`,
+	} {
+		_, err := s.DB().ExecContext(ctx, stmt)
+		require.NoError(t, err)
+	}
+	return s
+}
+
+func edges(g *Graph) []string {
+	var e []string
+	for _, edge := range g.Edges {
+		e = append(e, fmt.Sprintf("%s-%s:%d", edge.Source, edge.Target, edge.Weight))
+	}
+	return e
+}
+
+func TestBuildPerson(t *testing.T) {
+	ctx := context.Background()
+	s := openTestDB(t)
+
+	// Christian Lindner is an author of 20/2 by the Aktivität citing it
+	g, err := Build(ctx, s.DB(), Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"7001-7002:2", "7001-7003:2", "7002-7003:1"}, edges(g))
+	require.Len(t, g.Nodes, 3)
+	assert.Equal(t, Node{ID: "7001", Label: "Klara Geywitz", Attrs: map[string]string{
+		"mdb_id": "11000001", "partei": "SPD", "geschlecht": "weiblich", "bundesland": "BB", "drucksachen": "3",
+	}}, g.Nodes[0])
+	assert.Equal(t, Node{ID: "7002", Label: "Katrin Göring-Eckardt", Attrs: map[string]string{"drucksachen": "2"}}, g.Nodes[1])
+
+	g, err = Build(ctx, s.DB(), Options{Wahlperioden: []int{20}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"7001-7002:2", "7001-7003:1", "7002-7003:1"}, edges(g))
+
+	g, err = Build(ctx, s.DB(), Options{From: "2024-01-01", To: "2024-12-31"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"7001-7002:1", "7001-7003:1", "7002-7003:1"}, edges(g))
+
+	g, err = Build(ctx, s.DB(), Options{MinWeight: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"7001-7002:2", "7001-7003:2"}, edges(g))
+	assert.Contains(t, g.Description, "at least 2 shared Drucksachen")
+
+	// Deleted Drucksachen are left out
+	require.NoError(t, s.MarkDeleted(ctx, store.DrucksacheTable, "300002", time.Now()))
+	g, err = Build(ctx, s.DB(), Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"7001-7002:1", "7001-7003:1"}, edges(g))
+
+	_, err = Build(ctx, s.DB(), Options{Kind: "partei"})
+	assert.ErrorContains(t, err, "unknown graph")
+}
+
+func TestBuildFraktion(t *testing.T) {
+	ctx := context.Background()
+	s := openTestDB(t)
+
+	g, err := Build(ctx, s.DB(), Options{Kind: Fraktion})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Fraktion BÜNDNIS 90/DIE GRÜNEN-Fraktion der FDP:1",
+		"Fraktion BÜNDNIS 90/DIE GRÜNEN-Fraktion der SPD:2",
+		"Fraktion der FDP-Fraktion der SPD:1",
+	}, edges(g))
+	require.Len(t, g.Nodes, 3)
+	assert.Equal(t, "3", g.Nodes[2].Attrs["drucksachen"])
+
+	// In the 19th Wahlperiode, the SPD introduced its Drucksache with the Bundesregierung only
+	g, err = Build(ctx, s.DB(), Options{Kind: Fraktion, Wahlperioden: []int{19}})
+	require.NoError(t, err)
+	assert.Empty(t, g.Edges)
+	assert.Empty(t, g.Nodes)
+}
+
+func TestWrite(t *testing.T) {
+	ctx := context.Background()
+	s := openTestDB(t)
+	g, err := Build(ctx, s.DB(), Options{MinWeight: 2})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteEdgesCSV(&buf, g))
+	assert.Equal(t, "source,target,weight,source_label,target_label\n"+
+		"7001,7002,2,Klara Geywitz,Katrin Göring-Eckardt\n"+
+		"7001,7003,2,Klara Geywitz,Christian Lindner\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteNodesCSV(&buf, g))
+	assert.Equal(t, "id,label,mdb_id,partei,geschlecht,bundesland,drucksachen\n"+
+		"7001,Klara Geywitz,11000001,SPD,weiblich,BB,3\n"+
+		"7002,Katrin Göring-Eckardt,,,,,2\n"+
+		"7003,Christian Lindner,,,,,2\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteGraphML(&buf, g))
+	var graphml struct {
+		Keys []struct {
+			ID   string `xml:"id,attr"`
+			Type string `xml:"attr.type,attr"`
+		} `xml:"key"`
+		Nodes []struct {
+			ID string `xml:"id,attr"`
+		} `xml:"graph>node"`
+		Edges []struct {
+			Data string `xml:"data"`
+		} `xml:"graph>edge"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &graphml))
+	require.Len(t, graphml.Keys, 7)
+	assert.Equal(t, "int", graphml.Keys[5].Type)
+	assert.Len(t, graphml.Nodes, 3)
+	require.Len(t, graphml.Edges, 2)
+	assert.Equal(t, "2", graphml.Edges[0].Data)
+	assert.Contains(t, buf.String(), `<data key="bundesland">BB</data>`)
+
+	buf.Reset()
+	require.NoError(t, WriteGEXF(&buf, g))
+	var gexf struct {
+		Attributes []struct {
+			Title string `xml:"title,attr"`
+		} `xml:"graph>attributes>attribute"`
+		Nodes []struct {
+			Label     string `xml:"label,attr"`
+			AttValues []struct {
+				For   string `xml:"for,attr"`
+				Value string `xml:"value,attr"`
+			} `xml:"attvalues>attvalue"`
+		} `xml:"graph>nodes>node"`
+		Edges []struct {
+			Weight int `xml:"weight,attr"`
+		} `xml:"graph>edges>edge"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &gexf))
+	assert.Len(t, gexf.Attributes, 5)
+	require.Len(t, gexf.Nodes, 3)
+	assert.Equal(t, "Klara Geywitz", gexf.Nodes[0].Label)
+	assert.Len(t, gexf.Nodes[0].AttValues, 5)
+	assert.Len(t, gexf.Nodes[1].AttValues, 1)
+	require.Len(t, gexf.Edges, 2)
+	assert.Equal(t, 2, gexf.Edges[1].Weight)
+}
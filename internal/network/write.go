@@ -0,0 +1,187 @@
+package network
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+)
+
+// WriteGraphML writes the graph as GraphML, e.g. for Gephi, Cytoscape or networkx
+func WriteGraphML(w io.Writer, g *Graph) error {
+	type data struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:",chardata"`
+	}
+	type key struct {
+		ID   string `xml:"id,attr"`
+		For  string `xml:"for,attr"`
+		Name string `xml:"attr.name,attr"`
+		Type string `xml:"attr.type,attr"`
+	}
+	type node struct {
+		ID   string `xml:"id,attr"`
+		Data []data `xml:"data"`
+	}
+	type edge struct {
+		Source string `xml:"source,attr"`
+		Target string `xml:"target,attr"`
+		Data   []data `xml:"data"`
+	}
+	doc := struct {
+		XMLName xml.Name `xml:"graphml"`
+		Xmlns   string   `xml:"xmlns,attr"`
+		Keys    []key    `xml:"key"`
+		Graph   struct {
+			ID          string `xml:"id,attr"`
+			EdgeDefault string `xml:"edgedefault,attr"`
+			Desc        string `xml:"desc,omitempty"`
+			Nodes       []node `xml:"node"`
+			Edges       []edge `xml:"edge"`
+		} `xml:"graph"`
+	}{Xmlns: "http://graphml.graphdrawing.org/xmlns"}
+
+	doc.Keys = append(doc.Keys, key{"label", "node", "label", "string"})
+	for _, a := range g.Attributes {
+		doc.Keys = append(doc.Keys, key{a.Name, "node", a.Name, graphMLType(a.Type)})
+	}
+	doc.Keys = append(doc.Keys, key{"weight", "edge", "weight", "int"})
+	doc.Graph.ID = g.Kind
+	doc.Graph.EdgeDefault = "undirected"
+	doc.Graph.Desc = g.Description
+	for _, n := range g.Nodes {
+		xn := node{ID: n.ID, Data: []data{{"label", n.Label}}}
+		for _, a := range g.Attributes {
+			if v, ok := n.Attrs[a.Name]; ok {
+				xn.Data = append(xn.Data, data{a.Name, v})
+			}
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, xn)
+	}
+	for _, e := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, edge{e.Source, e.Target, []data{{"weight", strconv.Itoa(e.Weight)}}})
+	}
+	return writeXML(w, doc)
+}
+
+// WriteGEXF writes the graph as GEXF 1.3, the native format of Gephi
+func WriteGEXF(w io.Writer, g *Graph) error {
+	type attribute struct {
+		ID    string `xml:"id,attr"`
+		Title string `xml:"title,attr"`
+		Type  string `xml:"type,attr"`
+	}
+	type attvalue struct {
+		For   string `xml:"for,attr"`
+		Value string `xml:"value,attr"`
+	}
+	type node struct {
+		ID        string     `xml:"id,attr"`
+		Label     string     `xml:"label,attr"`
+		AttValues []attvalue `xml:"attvalues>attvalue,omitempty"`
+	}
+	type edge struct {
+		ID     string `xml:"id,attr"`
+		Source string `xml:"source,attr"`
+		Target string `xml:"target,attr"`
+		Weight int    `xml:"weight,attr"`
+	}
+	doc := struct {
+		XMLName xml.Name `xml:"gexf"`
+		Xmlns   string   `xml:"xmlns,attr"`
+		Version string   `xml:"version,attr"`
+		Meta    struct {
+			LastModified string `xml:"lastmodifieddate,attr"`
+			Creator      string `xml:"creator"`
+			Description  string `xml:"description,omitempty"`
+		} `xml:"meta"`
+		Graph struct {
+			DefaultEdgeType string `xml:"defaultedgetype,attr"`
+			Mode            string `xml:"mode,attr"`
+			Attributes      struct {
+				Class      string      `xml:"class,attr"`
+				Attributes []attribute `xml:"attribute"`
+			} `xml:"attributes"`
+			Nodes []node `xml:"nodes>node"`
+			Edges []edge `xml:"edges>edge"`
+		} `xml:"graph"`
+	}{Xmlns: "http://gexf.net/1.3", Version: "1.3"}
+
+	doc.Meta.LastModified = time.Now().Format(time.DateOnly)
+	doc.Meta.Creator = "dip export network"
+	doc.Meta.Description = g.Description
+	doc.Graph.DefaultEdgeType = "undirected"
+	doc.Graph.Mode = "static"
+	doc.Graph.Attributes.Class = "node"
+	for i, a := range g.Attributes {
+		doc.Graph.Attributes.Attributes = append(doc.Graph.Attributes.Attributes, attribute{strconv.Itoa(i), a.Name, a.Type})
+	}
+	for _, n := range g.Nodes {
+		xn := node{ID: n.ID, Label: n.Label}
+		for i, a := range g.Attributes {
+			if v, ok := n.Attrs[a.Name]; ok {
+				xn.AttValues = append(xn.AttValues, attvalue{strconv.Itoa(i), v})
+			}
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, xn)
+	}
+	for i, e := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, edge{strconv.Itoa(i), e.Source, e.Target, e.Weight})
+	}
+	return writeXML(w, doc)
+}
+
+// WriteEdgesCSV writes the edge list with the labels of both nodes
+func WriteEdgesCSV(w io.Writer, g *Graph) error {
+	labels := make(map[string]string, len(g.Nodes))
+	for _, n := range g.Nodes {
+		labels[n.ID] = n.Label
+	}
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"source", "target", "weight", "source_label", "target_label"})
+	for _, e := range g.Edges {
+		cw.Write([]string{e.Source, e.Target, strconv.Itoa(e.Weight), labels[e.Source], labels[e.Target]})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteNodesCSV writes the nodes with their attributes
+func WriteNodesCSV(w io.Writer, g *Graph) error {
+	cw := csv.NewWriter(w)
+	header := []string{"id", "label"}
+	for _, a := range g.Attributes {
+		header = append(header, a.Name)
+	}
+	cw.Write(header)
+	for _, n := range g.Nodes {
+		record := []string{n.ID, n.Label}
+		for _, a := range g.Attributes {
+			record = append(record, n.Attrs[a.Name])
+		}
+		cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func graphMLType(t string) string {
+	if t == Integer {
+		return "int"
+	}
+	return t
+}
+
+func writeXML(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,985 @@
+package offline
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+)
+
+// The load functions in this file are the reverse of the map functions of the
+// store: they read a main row with its child rows and rebuild the API type.
+// Child rows are read in the order the store wrote them, which is the order of
+// the API response.
+
+// fundstelleColumns are the fundstelle_* columns in the order of fundstelleRow.dest
+const fundstelleColumns = `fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+	fundstelle_herausgeber, fundstelle_id, fundstelle_drucksachetyp, fundstelle_anlagen,
+	fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant,
+	fundstelle_seite, fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
+	fundstelle_frage_nummer, fundstelle_verteildatum`
+
+// plenarprotokollFundstelleColumns are fundstelleColumns of the plenarprotokoll
+// table, which has no Drucksache specific columns
+const plenarprotokollFundstelleColumns = `fundstelle_dokumentnummer, fundstelle_datum, fundstelle_dokumentart,
+	fundstelle_herausgeber, fundstelle_id, NULL, NULL,
+	fundstelle_anfangsseite, fundstelle_endseite, fundstelle_anfangsquadrant, fundstelle_endquadrant,
+	fundstelle_seite, fundstelle_pdf_url, fundstelle_xml_url, fundstelle_top, fundstelle_top_zusatz,
+	NULL, NULL`
+
+type fundstelleRow struct {
+	dokumentnummer, datum, dokumentart, herausgeber, id string
+	drucksachetyp, anlagen                              sql.NullString
+	anfangsseite, endseite                              sql.NullInt64
+	anfangsquadrant, endquadrant                        sql.NullString
+	seite, pdfURL, xmlURL                               sql.NullString
+	top                                                 sql.NullInt64
+	topZusatz, frageNummer, verteildatum                sql.NullString
+}
+
+func (f *fundstelleRow) dest() []any {
+	return []any{&f.dokumentnummer, &f.datum, &f.dokumentart, &f.herausgeber, &f.id,
+		&f.drucksachetyp, &f.anlagen, &f.anfangsseite, &f.endseite, &f.anfangsquadrant, &f.endquadrant,
+		&f.seite, &f.pdfURL, &f.xmlURL, &f.top, &f.topZusatz, &f.frageNummer, &f.verteildatum}
+}
+
+func (f *fundstelleRow) fundstelle(urheber []string) (client.Fundstelle, error) {
+	datum, err := parseDate(f.datum)
+	if err != nil {
+		return client.Fundstelle{}, err
+	}
+	verteildatum, err := parseNullDate(f.verteildatum)
+	if err != nil {
+		return client.Fundstelle{}, err
+	}
+	if urheber == nil {
+		urheber = []string{}
+	}
+	return client.Fundstelle{
+		Dokumentnummer:  f.dokumentnummer,
+		Datum:           datum,
+		Dokumentart:     client.FundstelleDokumentart(f.dokumentart),
+		Herausgeber:     client.Zuordnung(f.herausgeber),
+		Id:              f.id,
+		Drucksachetyp:   stringPtr(f.drucksachetyp),
+		Anlagen:         stringPtr(f.anlagen),
+		Anfangsseite:    intPtr(f.anfangsseite),
+		Endseite:        intPtr(f.endseite),
+		Anfangsquadrant: quadrantPtr(f.anfangsquadrant),
+		Endquadrant:     quadrantPtr(f.endquadrant),
+		Seite:           stringPtr(f.seite),
+		PdfUrl:          stringPtr(f.pdfURL),
+		XmlUrl:          stringPtr(f.xmlURL),
+		Top:             int32Ptr(f.top),
+		TopZusatz:       stringPtr(f.topZusatz),
+		FrageNummer:     stringPtr(f.frageNummer),
+		Verteildatum:    verteildatum,
+		Urheber:         urheber,
+	}, nil
+}
+
+func quadrantPtr(s sql.NullString) *client.Quadrant {
+	if !s.Valid {
+		return nil
+	}
+	q := client.Quadrant(s.String)
+	return &q
+}
+
+// scanRows runs a query and calls scan for every row
+func (d *DB) scanRows(ctx context.Context, scan func(*sql.Rows) error, query string, args ...any) error {
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// queryStrings returns the single text column of a query
+func (d *DB) queryStrings(ctx context.Context, query string, args ...any) ([]string, error) {
+	var values []string
+	err := d.scanRows(ctx, func(rows *sql.Rows) error {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return err
+		}
+		values = append(values, s)
+		return nil
+	}, query, args...)
+	return values, err
+}
+
+// queryInt32s returns the single integer column of a query
+func (d *DB) queryInt32s(ctx context.Context, query string, args ...any) ([]int32, error) {
+	var values []int32
+	err := d.scanRows(ctx, func(rows *sql.Rows) error {
+		var n int64
+		if err := rows.Scan(&n); err != nil {
+			return err
+		}
+		values = append(values, int32(n))
+		return nil
+	}, query, args...)
+	return values, err
+}
+
+// notFound turns sql.ErrNoRows of a main row into ErrNotFound
+func notFound(resource, id string, err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%s %s: %w", resource, id, ErrNotFound)
+	}
+	return fmt.Errorf("failed to load %s %s: %w", resource, id, err)
+}
+
+// listOf loads the entities of a page of a list request
+func listOf[T any](ctx context.Context, d *DB, t table, params any, load func(context.Context, string) (*T, error)) ([]T, page, error) {
+	p, err := d.list(ctx, t, params)
+	if err != nil {
+		return nil, page{}, err
+	}
+	documents := make([]T, 0, len(p.ids))
+	for _, id := range p.ids {
+		entity, err := load(ctx, id)
+		if err != nil {
+			return nil, page{}, err
+		}
+		documents = append(documents, *entity)
+	}
+	return documents, p, nil
+}
+
+func (d *DB) loadPerson(ctx context.Context, id string) (*client.Person, error) {
+	var (
+		person            client.Person
+		namenszusatz      sql.NullString
+		aktualisiert      string
+		basisdatum, datum sql.NullString
+	)
+	err := d.db.QueryRowContext(ctx, `
+		SELECT id, vorname, nachname, namenszusatz, titel, typ, aktualisiert, basisdatum, datum
+		FROM person
+		WHERE id = ? AND deleted_at IS NULL`, id,
+	).Scan(&person.Id, &person.Vorname, &person.Nachname, &namenszusatz, &person.Titel, &person.Typ,
+		&aktualisiert, &basisdatum, &datum)
+	if err != nil {
+		return nil, notFound("person", id, err)
+	}
+	person.Namenszusatz = stringPtr(namenszusatz)
+	if person.Aktualisiert, err = parseTimestamp(aktualisiert); err != nil {
+		return nil, err
+	}
+	if person.Basisdatum, err = parseNullDate(basisdatum); err != nil {
+		return nil, err
+	}
+	if person.Datum, err = parseNullDate(datum); err != nil {
+		return nil, err
+	}
+
+	wahlperioden, err := d.queryInt32s(ctx, `
+		SELECT wahlperiode_nummer FROM person_wahlperiode WHERE person_id = ? ORDER BY wahlperiode_nummer`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Wahlperioden of person %s: %w", id, err)
+	}
+	person.Wahlperiode = sliceOrNil(wahlperioden)
+
+	type roleRow struct {
+		id   int64
+		role client.PersonRole
+	}
+	var roles []roleRow
+	err = d.scanRows(ctx, func(rows *sql.Rows) error {
+		var (
+			r                                         roleRow
+			funktionszusatz, namenszusatz, fraktion   sql.NullString
+			bundesland, ressortTitel, wahlkreiszusatz sql.NullString
+		)
+		if err := rows.Scan(&r.id, &r.role.Funktion, &funktionszusatz, &r.role.Vorname, &r.role.Nachname,
+			&namenszusatz, &fraktion, &bundesland, &ressortTitel, &wahlkreiszusatz); err != nil {
+			return err
+		}
+		r.role.Funktionszusatz = stringPtr(funktionszusatz)
+		r.role.Namenszusatz = stringPtr(namenszusatz)
+		r.role.Fraktion = stringPtr(fraktion)
+		r.role.RessortTitel = stringPtr(ressortTitel)
+		r.role.Wahlkreiszusatz = stringPtr(wahlkreiszusatz)
+		if bundesland.Valid {
+			b := client.Bundesland(bundesland.String)
+			r.role.Bundesland = &b
+		}
+		roles = append(roles, r)
+		return nil
+	}, `
+		SELECT id, funktion, funktionszusatz, vorname, nachname, namenszusatz, fraktion, bundesland,
+		       ressort_titel, wahlkreiszusatz
+		FROM person_role
+		WHERE person_id = ?
+		ORDER BY id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load roles of person %s: %w", id, err)
+	}
+
+	var personRoles []client.PersonRole
+	for _, r := range roles {
+		wahlperioden, err := d.queryInt32s(ctx, `
+			SELECT wahlperiode_nummer FROM person_role_wahlperiode WHERE person_role_id = ? ORDER BY wahlperiode_nummer`, r.id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Wahlperioden of a role of person %s: %w", id, err)
+		}
+		r.role.WahlperiodeNummer = sliceOrNil(wahlperioden)
+		personRoles = append(personRoles, r.role)
+	}
+	person.PersonRoles = sliceOrNil(personRoles)
+
+	// The API repeats the current role at the top level. The store keeps the
+	// roles only, so the first role is used.
+	if len(personRoles) > 0 {
+		role := personRoles[0]
+		person.Funktion = role.Funktion
+		person.Funktionszusatz = role.Funktionszusatz
+		person.Fraktion = role.Fraktion
+		person.Bundesland = role.Bundesland
+		person.Ressort = role.RessortTitel
+		person.Wahlkreiszusatz = role.Wahlkreiszusatz
+	}
+	return &person, nil
+}
+
+func (d *DB) loadVorgang(ctx context.Context, id string) (*client.Vorgang, error) {
+	var (
+		vorgang                                 client.Vorgang
+		typ, aktualisiert                       string
+		abstract, archiv, beratungsstand, datum sql.NullString
+		gesta, kom, mitteilung, ratsdok, sek    sql.NullString
+	)
+	err := d.db.QueryRowContext(ctx, `
+		SELECT id, titel, vorgangstyp, typ, abstract, aktualisiert, archiv, beratungsstand, datum,
+		       gesta, kom, mitteilung, ratsdok, sek, wahlperiode
+		FROM vorgang
+		WHERE id = ? AND deleted_at IS NULL`, id,
+	).Scan(&vorgang.Id, &vorgang.Titel, &vorgang.Vorgangstyp, &typ, &abstract, &aktualisiert, &archiv,
+		&beratungsstand, &datum, &gesta, &kom, &mitteilung, &ratsdok, &sek, &vorgang.Wahlperiode)
+	if err != nil {
+		return nil, notFound("vorgang", id, err)
+	}
+	vorgang.Typ = client.VorgangTyp(typ)
+	vorgang.Abstract = stringPtr(abstract)
+	vorgang.Archiv = stringPtr(archiv)
+	vorgang.Beratungsstand = stringPtr(beratungsstand)
+	vorgang.Gesta = stringPtr(gesta)
+	vorgang.Kom = stringPtr(kom)
+	vorgang.Mitteilung = stringPtr(mitteilung)
+	vorgang.Ratsdok = stringPtr(ratsdok)
+	vorgang.Sek = stringPtr(sek)
+	if vorgang.Aktualisiert, err = parseTimestamp(aktualisiert); err != nil {
+		return nil, err
+	}
+	if vorgang.Datum, err = parseNullDate(datum); err != nil {
+		return nil, err
+	}
+
+	initiativen, err := d.queryStrings(ctx, `SELECT initiative FROM vorgang_initiative WHERE vorgang_id = ? ORDER BY id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Initiativen of vorgang %s: %w", id, err)
+	}
+	vorgang.Initiative = sliceOrNil(initiativen)
+
+	sachgebiete, err := d.queryStrings(ctx, `SELECT sachgebiet FROM vorgang_sachgebiet WHERE vorgang_id = ? ORDER BY id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Sachgebiete of vorgang %s: %w", id, err)
+	}
+	vorgang.Sachgebiet = sliceOrNil(sachgebiete)
+
+	zustimmungsbeduerftigkeit, err := d.queryStrings(ctx, `
+		SELECT zustimmungsbeduerftigkeit FROM vorgang_zustimmungsbeduerftigkeit WHERE vorgang_id = ? ORDER BY id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Zustimmungsbedürftigkeit of vorgang %s: %w", id, err)
+	}
+	vorgang.Zustimmungsbeduerftigkeit = sliceOrNil(zustimmungsbeduerftigkeit)
+
+	var deskriptoren []client.VorgangDeskriptor
+	err = d.scanRows(ctx, func(rows *sql.Rows) error {
+		var (
+			desk       client.VorgangDeskriptor
+			typ        string
+			fundstelle int64
+		)
+		if err := rows.Scan(&desk.Name, &typ, &fundstelle); err != nil {
+			return err
+		}
+		desk.Typ = client.VorgangDeskriptorTyp(typ)
+		desk.Fundstelle = fundstelle != 0
+		deskriptoren = append(deskriptoren, desk)
+		return nil
+	}, `SELECT name, typ, fundstelle FROM vorgang_deskriptor WHERE vorgang_id = ? ORDER BY id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Deskriptoren of vorgang %s: %w", id, err)
+	}
+	vorgang.Deskriptor = sliceOrNil(deskriptoren)
+
+	var verkuendungen []client.Verkuendung
+	err = d.scanRows(ctx, func(rows *sql.Rows) error {
+		var (
+			verk                                  client.Verkuendung
+			ausfertigungsdatum, verkuendungsdatum string
+			heftnummer, pdfURL, rubrikNr, titel   sql.NullString
+			blattBezeichnung, blattKuerzel        sql.NullString
+			err                                   error
+		)
+		if err := rows.Scan(&ausfertigungsdatum, &verkuendungsdatum, &verk.Einleitungstext, &verk.Fundstelle,
+			&verk.Jahrgang, &verk.Seite, &heftnummer, &pdfURL, &rubrikNr, &titel, &blattBezeichnung, &blattKuerzel); err != nil {
+			return err
+		}
+		if verk.Ausfertigungsdatum, err = parseDate(ausfertigungsdatum); err != nil {
+			return err
+		}
+		if verk.Verkuendungsdatum, err = parseDate(verkuendungsdatum); err != nil {
+			return err
+		}
+		verk.Heftnummer = stringPtr(heftnummer)
+		verk.PdfUrl = stringPtr(pdfURL)
+		verk.RubrikNr = stringPtr(rubrikNr)
+		verk.Titel = stringPtr(titel)
+		verk.VerkuendungsblattBezeichnung = stringPtr(blattBezeichnung)
+		verk.VerkuendungsblattKuerzel = stringPtr(blattKuerzel)
+		verkuendungen = append(verkuendungen, verk)
+		return nil
+	}, `
+		SELECT ausfertigungsdatum, verkuendungsdatum, einleitungstext, fundstelle, jahrgang, seite,
+		       heftnummer, pdf_url, rubrik_nr, titel, verkuendungsblatt_bezeichnung, verkuendungsblatt_kuerzel
+		FROM verkuendung
+		WHERE vorgang_id = ?
+		ORDER BY id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Verkündungen of vorgang %s: %w", id, err)
+	}
+	vorgang.Verkuendung = sliceOrNil(verkuendungen)
+
+	var inkrafttreten []client.Inkrafttreten
+	err = d.scanRows(ctx, func(rows *sql.Rows) error {
+		var (
+			ink          client.Inkrafttreten
+			datum        string
+			erlaeuterung sql.NullString
+			err          error
+		)
+		if err := rows.Scan(&datum, &erlaeuterung); err != nil {
+			return err
+		}
+		if ink.Datum, err = parseDate(datum); err != nil {
+			return err
+		}
+		ink.Erlaeuterung = stringPtr(erlaeuterung)
+		inkrafttreten = append(inkrafttreten, ink)
+		return nil
+	}, `SELECT datum, erlaeuterung FROM inkrafttreten WHERE vorgang_id = ? ORDER BY id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Inkrafttreten of vorgang %s: %w", id, err)
+	}
+	vorgang.Inkrafttreten = sliceOrNil(inkrafttreten)
+
+	var verlinkungen []client.VorgangVerlinkung
+	err = d.scanRows(ctx, func(rows *sql.Rows) error {
+		var (
+			verlinkung client.VorgangVerlinkung
+			gesta      sql.NullString
+		)
+		if err := rows.Scan(&verlinkung.Id, &verlinkung.Titel, &verlinkung.Verweisung, &gesta, &verlinkung.Wahlperiode); err != nil {
+			return err
+		}
+		verlinkung.Gesta = stringPtr(gesta)
+		verlinkungen = append(verlinkungen, verlinkung)
+		return nil
+	}, `
+		SELECT target_vorgang_id, titel, verweisung, gesta, wahlperiode
+		FROM vorgang_verlinkung
+		WHERE source_vorgang_id = ?
+		ORDER BY id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Verlinkungen of vorgang %s: %w", id, err)
+	}
+	vorgang.VorgangVerlinkung = sliceOrNil(verlinkungen)
+
+	return &vorgang, nil
+}
+
+// loadRessorts returns the Ressorts linked in a junction table, e.g. drucksache_ressort
+func (d *DB) loadRessorts(ctx context.Context, junction, column, id string) (*[]client.Ressort, error) {
+	var ressorts []client.Ressort
+	err := d.scanRows(ctx, func(rows *sql.Rows) error {
+		var (
+			ressort       client.Ressort
+			federfuehrend int64
+		)
+		if err := rows.Scan(&ressort.Titel, &federfuehrend); err != nil {
+			return err
+		}
+		ressort.Federfuehrend = federfuehrend != 0
+		ressorts = append(ressorts, ressort)
+		return nil
+	}, fmt.Sprintf(`
+		SELECT r.titel, j.federfuehrend
+		FROM %s j
+		JOIN ressort r ON r.id = j.ressort_id
+		WHERE j.%s = ?
+		ORDER BY j.rowid`, junction, column), id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Ressorts: %w", err)
+	}
+	return sliceOrNil(ressorts), nil
+}
+
+// loadUrheber returns the Urheber linked in a junction table, e.g. drucksache_urheber
+func (d *DB) loadUrheber(ctx context.Context, junction, column, id string) (*[]client.Urheber, error) {
+	var urheber []client.Urheber
+	err := d.scanRows(ctx, func(rows *sql.Rows) error {
+		var (
+			u          client.Urheber
+			rolle      sql.NullString
+			einbringer sql.NullInt64
+		)
+		if err := rows.Scan(&u.Bezeichnung, &u.Titel, &rolle, &einbringer); err != nil {
+			return err
+		}
+		if rolle.Valid {
+			r := client.UrheberRolle(rolle.String)
+			u.Rolle = &r
+		}
+		if einbringer.Valid {
+			b := einbringer.Int64 != 0
+			u.Einbringer = &b
+		}
+		urheber = append(urheber, u)
+		return nil
+	}, fmt.Sprintf(`
+		SELECT u.bezeichnung, u.titel, j.rolle, j.einbringer
+		FROM %s j
+		JOIN urheber u ON u.id = j.urheber_id
+		WHERE j.%s = ?
+		ORDER BY j.rowid`, junction, column), id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Urheber: %w", err)
+	}
+	return sliceOrNil(urheber), nil
+}
+
+// loadVorgangsbezuege returns the Vorgänge of a Drucksache or Plenarprotokoll
+func (d *DB) loadVorgangsbezuege(ctx context.Context, junction, column, id string) (*[]client.Vorgangsbezug, error) {
+	var bezuege []client.Vorgangsbezug
+	err := d.scanRows(ctx, func(rows *sql.Rows) error {
+		var bezug client.Vorgangsbezug
+		if err := rows.Scan(&bezug.Id, &bezug.Titel, &bezug.Vorgangstyp); err != nil {
+			return err
+		}
+		bezuege = append(bezuege, bezug)
+		return nil
+	}, fmt.Sprintf(`
+		SELECT vorgang_id, titel, vorgangstyp
+		FROM %s
+		WHERE %s = ?
+		ORDER BY display_order`, junction, column), id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Vorgangsbezüge: %w", err)
+	}
+	return sliceOrNil(bezuege), nil
+}
+
+func (d *DB) loadVorgangsposition(ctx context.Context, id string) (*client.Vorgangsposition, error) {
+	var (
+		vp                          client.Vorgangsposition
+		typ, dokumentart, zuordnung string
+		datum, aktualisiert         string
+		abstract, kom, ratsdok, sek sql.NullString
+		fortsetzung, gang, nachtrag int64
+		fs                          fundstelleRow
+	)
+	dest := append([]any{&vp.Id, &vp.VorgangId, &vp.Titel, &vp.Vorgangsposition, &vp.Vorgangstyp, &typ,
+		&dokumentart, &datum, &aktualisiert, &abstract, &fortsetzung, &gang, &nachtrag, &vp.AktivitaetAnzahl,
+		&kom, &ratsdok, &sek, &zuordnung}, fs.dest()...)
+	err := d.db.QueryRowContext(ctx, `
+		SELECT id, vorgang_id, titel, vorgangsposition, vorgangstyp, typ, dokumentart, datum, aktualisiert,
+		       abstract, fortsetzung, gang, nachtrag, aktivitaet_anzahl, kom, ratsdok, sek, zuordnung,
+		       `+fundstelleColumns+`
+		FROM vorgangsposition
+		WHERE id = ? AND deleted_at IS NULL`, id,
+	).Scan(dest...)
+	if err != nil {
+		return nil, notFound("vorgangsposition", id, err)
+	}
+	vp.Typ = client.VorgangspositionTyp(typ)
+	vp.Dokumentart = client.VorgangspositionDokumentart(dokumentart)
+	vp.Zuordnung = client.Zuordnung(zuordnung)
+	vp.Abstract = stringPtr(abstract)
+	vp.Kom = stringPtr(kom)
+	vp.Ratsdok = stringPtr(ratsdok)
+	vp.Sek = stringPtr(sek)
+	vp.Fortsetzung = fortsetzung != 0
+	vp.Gang = gang != 0
+	vp.Nachtrag = nachtrag != 0
+	if vp.Datum, err = parseDate(datum); err != nil {
+		return nil, err
+	}
+	if vp.Aktualisiert, err = parseTimestamp(aktualisiert); err != nil {
+		return nil, err
+	}
+	if vp.Fundstelle, err = fs.fundstelle(nil); err != nil {
+		return nil, err
+	}
+
+	var anzeigen []client.AktivitaetAnzeige
+	err = d.scanRows(ctx, func(rows *sql.Rows) error {
+		var (
+			anzeige       client.AktivitaetAnzeige
+			seite, pdfURL sql.NullString
+		)
+		if err := rows.Scan(&anzeige.Aktivitaetsart, &anzeige.Titel, &seite, &pdfURL); err != nil {
+			return err
+		}
+		anzeige.Seite = stringPtr(seite)
+		anzeige.PdfUrl = stringPtr(pdfURL)
+		anzeigen = append(anzeigen, anzeige)
+		return nil
+	}, `
+		SELECT aktivitaetsart, titel, seite, pdf_url
+		FROM aktivitaet_anzeige
+		WHERE vorgangsposition_id = ?
+		ORDER BY display_order`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Aktivitäten of vorgangsposition %s: %w", id, err)
+	}
+	vp.AktivitaetAnzeige = sliceOrNil(anzeigen)
+
+	var beschluesse []client.Beschlussfassung
+	err = d.scanRows(ctx, func(rows *sql.Rows) error {
+		var (
+			beschluss                           client.Beschlussfassung
+			abstimmungsart, mehrheit, bemerkung sql.NullString
+			dokumentnummer, grundlage, seite    sql.NullString
+		)
+		if err := rows.Scan(&beschluss.Beschlusstenor, &abstimmungsart, &mehrheit, &bemerkung,
+			&dokumentnummer, &grundlage, &seite); err != nil {
+			return err
+		}
+		if abstimmungsart.Valid {
+			a := client.BeschlussfassungAbstimmungsart(abstimmungsart.String)
+			beschluss.Abstimmungsart = &a
+		}
+		if mehrheit.Valid {
+			m := client.BeschlussfassungMehrheit(mehrheit.String)
+			beschluss.Mehrheit = &m
+		}
+		beschluss.AbstimmErgebnisBemerkung = stringPtr(bemerkung)
+		beschluss.Dokumentnummer = stringPtr(dokumentnummer)
+		beschluss.Grundlage = stringPtr(grundlage)
+		beschluss.Seite = stringPtr(seite)
+		beschluesse = append(beschluesse, beschluss)
+		return nil
+	}, `
+		SELECT beschlusstenor, abstimmungsart, mehrheit, abstimm_ergebnis_bemerkung, dokumentnummer, grundlage, seite
+		FROM beschlussfassung
+		WHERE vorgangsposition_id = ?
+		ORDER BY id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Beschlussfassungen of vorgangsposition %s: %w", id, err)
+	}
+	vp.Beschlussfassung = sliceOrNil(beschluesse)
+
+	if vp.Ressort, err = d.loadRessorts(ctx, "vorgangsposition_ressort", "vorgangsposition_id", id); err != nil {
+		return nil, fmt.Errorf("vorgangsposition %s: %w", id, err)
+	}
+	if vp.Urheber, err = d.loadUrheber(ctx, "vorgangsposition_urheber", "vorgangsposition_id", id); err != nil {
+		return nil, fmt.Errorf("vorgangsposition %s: %w", id, err)
+	}
+
+	var ueberweisungen []client.Ueberweisung
+	err = d.scanRows(ctx, func(rows *sql.Rows) error {
+		var (
+			ueberweisung     client.Ueberweisung
+			federfuehrung    int64
+			ueberweisungsart sql.NullString
+		)
+		if err := rows.Scan(&ueberweisung.Ausschuss, &ueberweisung.AusschussKuerzel, &federfuehrung, &ueberweisungsart); err != nil {
+			return err
+		}
+		ueberweisung.Federfuehrung = federfuehrung != 0
+		ueberweisung.Ueberweisungsart = stringPtr(ueberweisungsart)
+		ueberweisungen = append(ueberweisungen, ueberweisung)
+		return nil
+	}, `
+		SELECT ausschuss, ausschuss_kuerzel, federfuehrung, ueberweisungsart
+		FROM ueberweisung
+		WHERE vorgangsposition_id = ?
+		ORDER BY id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Überweisungen of vorgangsposition %s: %w", id, err)
+	}
+	vp.Ueberweisung = sliceOrNil(ueberweisungen)
+
+	var mitberaten []client.Vorgangspositionbezug
+	err = d.scanRows(ctx, func(rows *sql.Rows) error {
+		var bezug client.Vorgangspositionbezug
+		if err := rows.Scan(&bezug.Id, &bezug.Titel, &bezug.Vorgangsposition, &bezug.Vorgangstyp); err != nil {
+			return err
+		}
+		mitberaten = append(mitberaten, bezug)
+		return nil
+	}, `
+		SELECT mitberaten_vorgang_id, mitberaten_titel, mitberaten_vorgangsposition, mitberaten_vorgangstyp
+		FROM vorgangsposition_mitberaten
+		WHERE vorgangsposition_id = ?
+		ORDER BY rowid`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Mitberatungen of vorgangsposition %s: %w", id, err)
+	}
+	vp.Mitberaten = sliceOrNil(mitberaten)
+
+	return &vp, nil
+}
+
+// loadAktivitaet leaves person_id empty, the store does not keep it
+func (d *DB) loadAktivitaet(ctx context.Context, id string) (*client.Aktivitaet, error) {
+	var (
+		aktivitaet          client.Aktivitaet
+		typ, dokumentart    string
+		datum, aktualisiert string
+		abstract            sql.NullString
+		fs                  fundstelleRow
+	)
+	dest := append([]any{&aktivitaet.Id, &aktivitaet.Titel, &aktivitaet.Aktivitaetsart, &typ, &dokumentart,
+		&datum, &aktualisiert, &abstract, &aktivitaet.VorgangsbezugAnzahl, &aktivitaet.Wahlperiode}, fs.dest()...)
+	err := d.db.QueryRowContext(ctx, `
+		SELECT id, titel, aktivitaetsart, typ, dokumentart, datum, aktualisiert, abstract,
+		       vorgangsbezug_anzahl, wahlperiode, `+fundstelleColumns+`
+		FROM aktivitaet
+		WHERE id = ? AND deleted_at IS NULL`, id,
+	).Scan(dest...)
+	if err != nil {
+		return nil, notFound("aktivitaet", id, err)
+	}
+	aktivitaet.Typ = client.AktivitaetTyp(typ)
+	aktivitaet.Dokumentart = client.AktivitaetDokumentart(dokumentart)
+	aktivitaet.Abstract = stringPtr(abstract)
+	if aktivitaet.Datum, err = parseDate(datum); err != nil {
+		return nil, err
+	}
+	if aktivitaet.Aktualisiert, err = parseTimestamp(aktualisiert); err != nil {
+		return nil, err
+	}
+	if aktivitaet.Fundstelle, err = fs.fundstelle(nil); err != nil {
+		return nil, err
+	}
+
+	var deskriptoren []client.Deskriptor
+	err = d.scanRows(ctx, func(rows *sql.Rows) error {
+		var (
+			desk client.Deskriptor
+			typ  string
+		)
+		if err := rows.Scan(&desk.Name, &typ); err != nil {
+			return err
+		}
+		desk.Typ = client.DeskriptorTyp(typ)
+		deskriptoren = append(deskriptoren, desk)
+		return nil
+	}, `SELECT name, typ FROM aktivitaet_deskriptor WHERE aktivitaet_id = ? ORDER BY id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Deskriptoren of aktivitaet %s: %w", id, err)
+	}
+	aktivitaet.Deskriptor = sliceOrNil(deskriptoren)
+
+	var bezuege []client.Vorgangspositionbezug
+	err = d.scanRows(ctx, func(rows *sql.Rows) error {
+		var bezug client.Vorgangspositionbezug
+		if err := rows.Scan(&bezug.Id, &bezug.Titel, &bezug.Vorgangsposition, &bezug.Vorgangstyp); err != nil {
+			return err
+		}
+		bezuege = append(bezuege, bezug)
+		return nil
+	}, `
+		SELECT vorgang_id, titel, vorgangsposition, vorgangstyp
+		FROM aktivitaet_vorgangsbezug
+		WHERE aktivitaet_id = ?
+		ORDER BY display_order`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Vorgangsbezüge of aktivitaet %s: %w", id, err)
+	}
+	aktivitaet.Vorgangsbezug = sliceOrNil(bezuege)
+
+	return &aktivitaet, nil
+}
+
+func (d *DB) loadDrucksache(ctx context.Context, id string) (*client.Drucksache, error) {
+	var (
+		drucksache                    client.Drucksache
+		dokumentart, typ, herausgeber string
+		datum, aktualisiert           string
+		anlagen, pdfHash              sql.NullString
+		wahlperiode                   sql.NullInt64
+		fs                            fundstelleRow
+	)
+	dest := append([]any{&drucksache.Id, &drucksache.Titel, &drucksache.Dokumentnummer, &dokumentart, &typ,
+		&drucksache.Drucksachetyp, &herausgeber, &datum, &aktualisiert, &anlagen, &drucksache.AutorenAnzahl,
+		&drucksache.VorgangsbezugAnzahl, &pdfHash, &wahlperiode}, fs.dest()...)
+	err := d.db.QueryRowContext(ctx, `
+		SELECT id, titel, dokumentnummer, dokumentart, typ, drucksachetyp, herausgeber, datum, aktualisiert,
+		       anlagen, autoren_anzahl, vorgangsbezug_anzahl, pdf_hash, wahlperiode, `+fundstelleColumns+`
+		FROM drucksache
+		WHERE id = ? AND deleted_at IS NULL`, id,
+	).Scan(dest...)
+	if err != nil {
+		return nil, notFound("drucksache", id, err)
+	}
+	drucksache.Dokumentart = client.DrucksacheDokumentart(dokumentart)
+	drucksache.Typ = client.DrucksacheTyp(typ)
+	drucksache.Herausgeber = client.DrucksacheHerausgeber(herausgeber)
+	drucksache.Anlagen = stringPtr(anlagen)
+	drucksache.PdfHash = stringPtr(pdfHash)
+	drucksache.Wahlperiode = int32Ptr(wahlperiode)
+	if drucksache.Datum, err = parseDate(datum); err != nil {
+		return nil, err
+	}
+	if drucksache.Aktualisiert, err = parseTimestamp(aktualisiert); err != nil {
+		return nil, err
+	}
+
+	urheber, err := d.queryStrings(ctx, `SELECT urheber FROM fundstelle_urheber WHERE drucksache_id = ? ORDER BY id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Fundstelle Urheber of drucksache %s: %w", id, err)
+	}
+	if drucksache.Fundstelle, err = fs.fundstelle(urheber); err != nil {
+		return nil, err
+	}
+
+	type autor = struct {
+		AutorTitel string `json:"autor_titel"`
+		Id         string `json:"id"`
+		Title      string `json:"title"`
+	}
+	var autoren []autor
+	err = d.scanRows(ctx, func(rows *sql.Rows) error {
+		var a autor
+		if err := rows.Scan(&a.Id, &a.AutorTitel, &a.Title); err != nil {
+			return err
+		}
+		autoren = append(autoren, a)
+		return nil
+	}, `
+		SELECT person_id, autor_titel, title
+		FROM drucksache_autor_anzeige
+		WHERE drucksache_id = ?
+		ORDER BY display_order`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Autoren of drucksache %s: %w", id, err)
+	}
+	drucksache.AutorenAnzeige = sliceOrNil(autoren)
+
+	if drucksache.Ressort, err = d.loadRessorts(ctx, "drucksache_ressort", "drucksache_id", id); err != nil {
+		return nil, fmt.Errorf("drucksache %s: %w", id, err)
+	}
+	if drucksache.Urheber, err = d.loadUrheber(ctx, "drucksache_urheber", "drucksache_id", id); err != nil {
+		return nil, fmt.Errorf("drucksache %s: %w", id, err)
+	}
+	if drucksache.Vorgangsbezug, err = d.loadVorgangsbezuege(ctx, "drucksache_vorgangsbezug", "drucksache_id", id); err != nil {
+		return nil, fmt.Errorf("drucksache %s: %w", id, err)
+	}
+	return &drucksache, nil
+}
+
+func (d *DB) loadDrucksacheText(ctx context.Context, id string) (*client.DrucksacheText, error) {
+	var text sql.NullString
+	if err := d.db.QueryRowContext(ctx, `SELECT text FROM drucksache_text WHERE id = ?`, id).Scan(&text); err != nil {
+		return nil, notFound("drucksache-text", id, err)
+	}
+	drucksache, err := d.loadDrucksache(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	var drucksacheText client.DrucksacheText
+	if err := convert(drucksache, &drucksacheText); err != nil {
+		return nil, err
+	}
+	drucksacheText.Text = stringPtr(text)
+	return &drucksacheText, nil
+}
+
+func (d *DB) loadPlenarprotokoll(ctx context.Context, id string) (*client.Plenarprotokoll, error) {
+	var (
+		plenarprotokoll               client.Plenarprotokoll
+		dokumentart, typ, herausgeber string
+		datum, aktualisiert           string
+		pdfHash, sitzungsbemerkung    sql.NullString
+		wahlperiode                   sql.NullInt64
+		fs                            fundstelleRow
+	)
+	dest := append([]any{&plenarprotokoll.Id, &plenarprotokoll.Titel, &plenarprotokoll.Dokumentnummer,
+		&dokumentart, &typ, &herausgeber, &datum, &aktualisiert, &pdfHash, &sitzungsbemerkung,
+		&plenarprotokoll.VorgangsbezugAnzahl, &wahlperiode}, fs.dest()...)
+	err := d.db.QueryRowContext(ctx, `
+		SELECT id, titel, dokumentnummer, dokumentart, typ, herausgeber, datum, aktualisiert, pdf_hash,
+		       sitzungsbemerkung, vorgangsbezug_anzahl, wahlperiode, `+plenarprotokollFundstelleColumns+`
+		FROM plenarprotokoll
+		WHERE id = ? AND deleted_at IS NULL`, id,
+	).Scan(dest...)
+	if err != nil {
+		return nil, notFound("plenarprotokoll", id, err)
+	}
+	plenarprotokoll.Dokumentart = client.PlenarprotokollDokumentart(dokumentart)
+	plenarprotokoll.Typ = client.PlenarprotokollTyp(typ)
+	plenarprotokoll.Herausgeber = client.Zuordnung(herausgeber)
+	plenarprotokoll.PdfHash = stringPtr(pdfHash)
+	plenarprotokoll.Sitzungsbemerkung = stringPtr(sitzungsbemerkung)
+	plenarprotokoll.Wahlperiode = int32Ptr(wahlperiode)
+	if plenarprotokoll.Datum, err = parseDate(datum); err != nil {
+		return nil, err
+	}
+	if plenarprotokoll.Aktualisiert, err = parseTimestamp(aktualisiert); err != nil {
+		return nil, err
+	}
+
+	urheber, err := d.queryStrings(ctx, `SELECT urheber FROM fundstelle_urheber WHERE plenarprotokoll_id = ? ORDER BY id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Fundstelle Urheber of plenarprotokoll %s: %w", id, err)
+	}
+	if plenarprotokoll.Fundstelle, err = fs.fundstelle(urheber); err != nil {
+		return nil, err
+	}
+
+	if plenarprotokoll.Vorgangsbezug, err = d.loadVorgangsbezuege(ctx, "plenarprotokoll_vorgangsbezug", "plenarprotokoll_id", id); err != nil {
+		return nil, fmt.Errorf("plenarprotokoll %s: %w", id, err)
+	}
+	return &plenarprotokoll, nil
+}
+
+func (d *DB) loadPlenarprotokollText(ctx context.Context, id string) (*client.PlenarprotokollText, error) {
+	var text sql.NullString
+	if err := d.db.QueryRowContext(ctx, `SELECT text FROM plenarprotokoll_text WHERE id = ?`, id).Scan(&text); err != nil {
+		return nil, notFound("plenarprotokoll-text", id, err)
+	}
+	plenarprotokoll, err := d.loadPlenarprotokoll(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	var plenarprotokollText client.PlenarprotokollText
+	if err := convert(plenarprotokoll, &plenarprotokollText); err != nil {
+		return nil, err
+	}
+	plenarprotokollText.Text = stringPtr(text)
+	return &plenarprotokollText, nil
+}
+
+// convert copies the metadata of a document into its text type, which has the
+// same JSON fields plus the text
+func convert(from, to any) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, to)
+}
+
+// Get and list methods with the signatures of the dipclient.Client
+
+func (d *DB) GetPerson(ctx context.Context, id client.Id, params *client.GetPersonParams) (*client.Person, error) {
+	return d.loadPerson(ctx, strconv.Itoa(id))
+}
+
+func (d *DB) GetPersonList(ctx context.Context, params *client.GetPersonListParams) (*client.PersonListResponse, error) {
+	documents, p, err := listOf(ctx, d, personTable, params, d.loadPerson)
+	if err != nil {
+		return nil, err
+	}
+	return &client.PersonListResponse{Documents: documents, NumFound: int32(p.numFound), Cursor: p.cursor}, nil
+}
+
+func (d *DB) GetVorgang(ctx context.Context, id client.Id, params *client.GetVorgangParams) (*client.Vorgang, error) {
+	return d.loadVorgang(ctx, strconv.Itoa(id))
+}
+
+func (d *DB) GetVorgangList(ctx context.Context, params *client.GetVorgangListParams) (*client.VorgangListResponse, error) {
+	documents, p, err := listOf(ctx, d, vorgangTable, params, d.loadVorgang)
+	if err != nil {
+		return nil, err
+	}
+	return &client.VorgangListResponse{Documents: documents, NumFound: int32(p.numFound), Cursor: p.cursor}, nil
+}
+
+func (d *DB) GetVorgangsposition(ctx context.Context, id client.Id, params *client.GetVorgangspositionParams) (*client.Vorgangsposition, error) {
+	return d.loadVorgangsposition(ctx, strconv.Itoa(id))
+}
+
+func (d *DB) GetVorgangspositionList(ctx context.Context, params *client.GetVorgangspositionListParams) (*client.VorgangspositionListResponse, error) {
+	documents, p, err := listOf(ctx, d, vorgangspositionTable, params, d.loadVorgangsposition)
+	if err != nil {
+		return nil, err
+	}
+	return &client.VorgangspositionListResponse{Documents: documents, NumFound: int32(p.numFound), Cursor: p.cursor}, nil
+}
+
+func (d *DB) GetAktivitaet(ctx context.Context, id client.Id, params *client.GetAktivitaetParams) (*client.Aktivitaet, error) {
+	return d.loadAktivitaet(ctx, strconv.Itoa(id))
+}
+
+func (d *DB) GetAktivitaetList(ctx context.Context, params *client.GetAktivitaetListParams) (*client.AktivitaetListResponse, error) {
+	documents, p, err := listOf(ctx, d, aktivitaetTable, params, d.loadAktivitaet)
+	if err != nil {
+		return nil, err
+	}
+	return &client.AktivitaetListResponse{Documents: documents, NumFound: int32(p.numFound), Cursor: p.cursor}, nil
+}
+
+func (d *DB) GetDrucksache(ctx context.Context, id client.Id, params *client.GetDrucksacheParams) (*client.Drucksache, error) {
+	return d.loadDrucksache(ctx, strconv.Itoa(id))
+}
+
+func (d *DB) GetDrucksacheList(ctx context.Context, params *client.GetDrucksacheListParams) (*client.DrucksacheListResponse, error) {
+	documents, p, err := listOf(ctx, d, drucksacheTable, params, d.loadDrucksache)
+	if err != nil {
+		return nil, err
+	}
+	return &client.DrucksacheListResponse{Documents: documents, NumFound: int32(p.numFound), Cursor: p.cursor}, nil
+}
+
+func (d *DB) GetDrucksacheText(ctx context.Context, id client.Id, params *client.GetDrucksacheTextParams) (*client.DrucksacheText, error) {
+	return d.loadDrucksacheText(ctx, strconv.Itoa(id))
+}
+
+func (d *DB) GetDrucksacheTextList(ctx context.Context, params *client.GetDrucksacheTextListParams) (*client.DrucksacheTextListResponse, error) {
+	documents, p, err := listOf(ctx, d, drucksacheTextTable, params, d.loadDrucksacheText)
+	if err != nil {
+		return nil, err
+	}
+	return &client.DrucksacheTextListResponse{Documents: documents, NumFound: int32(p.numFound), Cursor: p.cursor}, nil
+}
+
+func (d *DB) GetPlenarprotokoll(ctx context.Context, id client.Id, params *client.GetPlenarprotokollParams) (*client.Plenarprotokoll, error) {
+	return d.loadPlenarprotokoll(ctx, strconv.Itoa(id))
+}
+
+func (d *DB) GetPlenarprotokollList(ctx context.Context, params *client.GetPlenarprotokollListParams) (*client.PlenarprotokollListResponse, error) {
+	documents, p, err := listOf(ctx, d, plenarprotokollTable, params, d.loadPlenarprotokoll)
+	if err != nil {
+		return nil, err
+	}
+	return &client.PlenarprotokollListResponse{Documents: documents, NumFound: int32(p.numFound), Cursor: p.cursor}, nil
+}
+
+func (d *DB) GetPlenarprotokollText(ctx context.Context, id client.Id, params *client.GetPlenarprotokollTextParams) (*client.PlenarprotokollText, error) {
+	return d.loadPlenarprotokollText(ctx, strconv.Itoa(id))
+}
+
+func (d *DB) GetPlenarprotokollTextList(ctx context.Context, params *client.GetPlenarprotokollTextListParams) (*client.PlenarprotokollTextListResponse, error) {
+	documents, p, err := listOf(ctx, d, plenarprotokollTextTable, params, d.loadPlenarprotokollText)
+	if err != nil {
+		return nil, err
+	}
+	return &client.PlenarprotokollTextListResponse{Documents: documents, NumFound: int32(p.numFound), Cursor: p.cursor}, nil
+}
@@ -0,0 +1,177 @@
+package offline
+
+// The filters of the list endpoints, by parameter name. Parameters that are not
+// listed here, e.g. f.vorgangstyp_notation, are not kept by the store and fail
+// with an UnsupportedFilterError instead of being ignored.
+
+// Filters shared by all main tables
+var (
+	aktualisiertStart = filter{cond: "datetime(t.aktualisiert) >= datetime(?)"}
+	aktualisiertEnd   = filter{cond: "datetime(t.aktualisiert) <= datetime(?)"}
+	datumStart        = filter{cond: "t.datum >= ?"}
+	datumEnd          = filter{cond: "t.datum <= ?"}
+	id                = filter{cond: "t.id = ?"}
+	titel             = filter{cond: "t.titel LIKE '%' || ? || '%'", all: true}
+	wahlperiode       = filter{cond: "t.wahlperiode = ?"}
+)
+
+// Filters on the fundstelle_* columns of Aktivitaet and Vorgangsposition
+var (
+	fundstelleDokumentnummer  = filter{cond: "t.fundstelle_dokumentnummer = ?"}
+	fundstelleDrucksache      = filter{cond: "t.fundstelle_dokumentart = 'Drucksache' AND t.fundstelle_id = ?"}
+	fundstellePlenarprotokoll = filter{cond: "t.fundstelle_dokumentart = 'Plenarprotokoll' AND t.fundstelle_id = ?"}
+	fundstelleDrucksachetyp   = filter{cond: "t.fundstelle_drucksachetyp = ?"}
+	fundstelleFrageNummer     = filter{cond: "t.fundstelle_frage_nummer = ?"}
+)
+
+var personTable = table{
+	resource: "person",
+	name:     "person",
+	pageSize: pageSize,
+	filters: map[string]filter{
+		"f.aktualisiert.start": aktualisiertStart,
+		"f.aktualisiert.end":   aktualisiertEnd,
+		"f.datum.start":        datumStart,
+		"f.datum.end":          datumEnd,
+		"f.id":                 id,
+		"f.person":             {cond: "(t.vorname || ' ' || t.nachname) LIKE '%' || ? || '%'"},
+		"f.wahlperiode":        {cond: "EXISTS (SELECT 1 FROM person_wahlperiode pw WHERE pw.person_id = t.id AND pw.wahlperiode_nummer = ?)"},
+	},
+}
+
+var vorgangTable = table{
+	resource: "vorgang",
+	name:     "vorgang",
+	pageSize: pageSize,
+	filters: map[string]filter{
+		"f.aktualisiert.start":     aktualisiertStart,
+		"f.aktualisiert.end":       aktualisiertEnd,
+		"f.beratungsstand":         {cond: "t.beratungsstand = ?"},
+		"f.datum.start":            datumStart,
+		"f.datum.end":              datumEnd,
+		"f.deskriptor":             {cond: "EXISTS (SELECT 1 FROM vorgang_deskriptor d WHERE d.vorgang_id = t.id AND d.name = ?)", all: true},
+		"f.dokumentart":            {cond: "EXISTS (SELECT 1 FROM vorgangsposition p WHERE p.vorgang_id = t.id AND p.dokumentart = ?)"},
+		"f.dokumentnummer":         {cond: "EXISTS (SELECT 1 FROM vorgangsposition p WHERE p.vorgang_id = t.id AND p.fundstelle_dokumentnummer = ?)"},
+		"f.drucksache":             {cond: "EXISTS (SELECT 1 FROM drucksache_vorgangsbezug b WHERE b.vorgang_id = t.id AND b.drucksache_id = ?)"},
+		"f.drucksachetyp":          {cond: "EXISTS (SELECT 1 FROM vorgangsposition p WHERE p.vorgang_id = t.id AND p.fundstelle_drucksachetyp = ?)"},
+		"f.frage_nummer":           {cond: "EXISTS (SELECT 1 FROM vorgangsposition p WHERE p.vorgang_id = t.id AND p.fundstelle_frage_nummer = ?)"},
+		"f.gesta":                  {cond: "t.gesta = ?"},
+		"f.id":                     id,
+		"f.initiative":             {cond: "EXISTS (SELECT 1 FROM vorgang_initiative i WHERE i.vorgang_id = t.id AND i.initiative = ?)", all: true},
+		"f.plenarprotokoll":        {cond: "EXISTS (SELECT 1 FROM plenarprotokoll_vorgangsbezug b WHERE b.vorgang_id = t.id AND b.plenarprotokoll_id = ?)"},
+		"f.ressort_fdf":            {cond: "EXISTS (SELECT 1 FROM vorgangsposition p JOIN vorgangsposition_ressort pr ON pr.vorgangsposition_id = p.id JOIN ressort r ON r.id = pr.ressort_id WHERE p.vorgang_id = t.id AND pr.federfuehrend = 1 AND r.titel = ?)", all: true},
+		"f.sachgebiet":             {cond: "EXISTS (SELECT 1 FROM vorgang_sachgebiet s WHERE s.vorgang_id = t.id AND s.sachgebiet = ?)", all: true},
+		"f.titel":                  titel,
+		"f.urheber":                {cond: "EXISTS (SELECT 1 FROM vorgangsposition p JOIN vorgangsposition_urheber pu ON pu.vorgangsposition_id = p.id JOIN urheber u ON u.id = pu.urheber_id WHERE p.vorgang_id = t.id AND ? IN (u.titel, u.bezeichnung))", all: true},
+		"f.verkuendung_fundstelle": {cond: "EXISTS (SELECT 1 FROM verkuendung v WHERE v.vorgang_id = t.id AND v.fundstelle = ?)"},
+		"f.vorgangstyp":            {cond: "t.vorgangstyp = ?"},
+		"f.wahlperiode":            wahlperiode,
+	},
+}
+
+var vorgangspositionTable = table{
+	resource: "vorgangsposition",
+	name:     "vorgangsposition",
+	pageSize: pageSize,
+	filters: map[string]filter{
+		"f.aktualisiert.start": aktualisiertStart,
+		"f.aktualisiert.end":   aktualisiertEnd,
+		"f.datum.start":        datumStart,
+		"f.datum.end":          datumEnd,
+		"f.dokumentart":        {cond: "t.dokumentart = ?"},
+		"f.dokumentnummer":     fundstelleDokumentnummer,
+		"f.drucksache":         fundstelleDrucksache,
+		"f.drucksachetyp":      fundstelleDrucksachetyp,
+		"f.frage_nummer":       fundstelleFrageNummer,
+		"f.id":                 id,
+		"f.plenarprotokoll":    fundstellePlenarprotokoll,
+		"f.ressort_fdf":        {cond: "EXISTS (SELECT 1 FROM vorgangsposition_ressort pr JOIN ressort r ON r.id = pr.ressort_id WHERE pr.vorgangsposition_id = t.id AND pr.federfuehrend = 1 AND r.titel = ?)", all: true},
+		"f.titel":              titel,
+		"f.urheber":            {cond: "EXISTS (SELECT 1 FROM vorgangsposition_urheber pu JOIN urheber u ON u.id = pu.urheber_id WHERE pu.vorgangsposition_id = t.id AND ? IN (u.titel, u.bezeichnung))", all: true},
+		"f.vorgang":            {cond: "t.vorgang_id = ?"},
+		"f.vorgangstyp":        {cond: "t.vorgangstyp = ?"},
+		"f.wahlperiode":        {cond: "EXISTS (SELECT 1 FROM vorgang v WHERE v.id = t.vorgang_id AND v.wahlperiode = ?)"},
+		"f.zuordnung":          {cond: "t.zuordnung = ?"},
+	},
+}
+
+var aktivitaetTable = table{
+	resource: "aktivitaet",
+	name:     "aktivitaet",
+	pageSize: pageSize,
+	filters: map[string]filter{
+		"f.aktualisiert.start": aktualisiertStart,
+		"f.aktualisiert.end":   aktualisiertEnd,
+		"f.datum.start":        datumStart,
+		"f.datum.end":          datumEnd,
+		"f.deskriptor":         {cond: "EXISTS (SELECT 1 FROM aktivitaet_deskriptor d WHERE d.aktivitaet_id = t.id AND d.name = ?)", all: true},
+		"f.dokumentart":        {cond: "t.dokumentart = ?"},
+		"f.dokumentnummer":     fundstelleDokumentnummer,
+		"f.drucksache":         fundstelleDrucksache,
+		"f.drucksachetyp":      fundstelleDrucksachetyp,
+		"f.frage_nummer":       fundstelleFrageNummer,
+		"f.id":                 id,
+		"f.plenarprotokoll":    fundstellePlenarprotokoll,
+		"f.vorgangstyp":        {cond: "EXISTS (SELECT 1 FROM aktivitaet_vorgangsbezug b WHERE b.aktivitaet_id = t.id AND b.vorgangstyp = ?)"},
+		"f.wahlperiode":        wahlperiode,
+		"f.zuordnung":          {cond: "t.fundstelle_herausgeber = ?"},
+	},
+}
+
+var drucksacheFilters = map[string]filter{
+	"f.aktualisiert.start": aktualisiertStart,
+	"f.aktualisiert.end":   aktualisiertEnd,
+	"f.datum.start":        datumStart,
+	"f.datum.end":          datumEnd,
+	"f.dokumentnummer":     {cond: "t.dokumentnummer = ?"},
+	"f.drucksachetyp":      {cond: "t.drucksachetyp = ?"},
+	"f.id":                 id,
+	"f.ressort_fdf":        {cond: "EXISTS (SELECT 1 FROM drucksache_ressort dr JOIN ressort r ON r.id = dr.ressort_id WHERE dr.drucksache_id = t.id AND dr.federfuehrend = 1 AND r.titel = ?)", all: true},
+	"f.titel":              titel,
+	"f.urheber":            {cond: "EXISTS (SELECT 1 FROM drucksache_urheber du JOIN urheber u ON u.id = du.urheber_id WHERE du.drucksache_id = t.id AND ? IN (u.titel, u.bezeichnung))", all: true},
+	"f.vorgangstyp":        {cond: "EXISTS (SELECT 1 FROM drucksache_vorgangsbezug b WHERE b.drucksache_id = t.id AND b.vorgangstyp = ?)"},
+	"f.wahlperiode":        wahlperiode,
+	"f.zuordnung":          {cond: "t.herausgeber = ?"},
+}
+
+var drucksacheTable = table{
+	resource: "drucksache",
+	name:     "drucksache",
+	pageSize: pageSize,
+	filters:  drucksacheFilters,
+}
+
+var drucksacheTextTable = table{
+	resource: "drucksache-text",
+	name:     "drucksache",
+	join:     "drucksache_text",
+	pageSize: textPageSize,
+	filters:  drucksacheFilters,
+}
+
+var plenarprotokollFilters = map[string]filter{
+	"f.aktualisiert.start": aktualisiertStart,
+	"f.aktualisiert.end":   aktualisiertEnd,
+	"f.datum.start":        datumStart,
+	"f.datum.end":          datumEnd,
+	"f.dokumentnummer":     {cond: "t.dokumentnummer = ?"},
+	"f.id":                 id,
+	"f.vorgangstyp":        {cond: "EXISTS (SELECT 1 FROM plenarprotokoll_vorgangsbezug b WHERE b.plenarprotokoll_id = t.id AND b.vorgangstyp = ?)"},
+	"f.wahlperiode":        wahlperiode,
+	"f.zuordnung":          {cond: "t.herausgeber = ?"},
+}
+
+var plenarprotokollTable = table{
+	resource: "plenarprotokoll",
+	name:     "plenarprotokoll",
+	pageSize: pageSize,
+	filters:  plenarprotokollFilters,
+}
+
+var plenarprotokollTextTable = table{
+	resource: "plenarprotokoll-text",
+	name:     "plenarprotokoll",
+	join:     "plenarprotokoll_text",
+	pageSize: textPageSize,
+	filters:  plenarprotokollFilters,
+}
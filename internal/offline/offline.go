@@ -0,0 +1,266 @@
+// Package offline answers DIP API requests from a synced SQLite database.
+// DB has the Get and Get*List methods of the dipclient.Client with the same
+// parameters and response types, so a caller can switch between the live API
+// and the local copy without changing anything else. The nested API shape is
+// rebuilt from the normalized tables the store writes.
+package offline
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// ErrNotFound is returned by the Get methods if the entity is not in the database
+var ErrNotFound = errors.New("not found in the local database")
+
+// Page sizes of the API: the text endpoints return fewer entities per page
+const (
+	pageSize     = 100
+	textPageSize = 10
+)
+
+// DB reads DIP entities from a SQLite database written by the sync commands.
+// Rows marked as deleted by sync-reconcile are skipped.
+type DB struct {
+	db *sql.DB
+}
+
+// New returns a DB reading from sqlDB
+func New(sqlDB *sql.DB) *DB {
+	return &DB{db: sqlDB}
+}
+
+// UnsupportedFilterError is returned for list filters that cannot be answered
+// from the database, e.g. because the store does not keep the field
+type UnsupportedFilterError struct {
+	Resource string
+	Filter   string
+}
+
+func (e *UnsupportedFilterError) Error() string {
+	return fmt.Sprintf("filter %s of %s is not supported by the local database", e.Filter, e.Resource)
+}
+
+// filter turns the values of a list parameter into a SQL condition on the main
+// table, aliased as t. cond has one placeholder for a single value.
+type filter struct {
+	cond string
+	all  bool // every value must match instead of any
+}
+
+// table describes how a resource is stored
+type table struct {
+	resource string // resource name of the API, for errors
+	name     string // main table
+	join     string // only rows with a row in this table, e.g. the text
+	pageSize int
+	filters  map[string]filter
+}
+
+// page is one page of a list request
+type page struct {
+	ids      []string
+	numFound int
+	cursor   string
+}
+
+// list returns the IDs of the page of entities that match the filters of
+// params, a *Get*ListParams. Like the API, the newest entities come first and
+// the cursor stays the same after the last page. The cursor is the offset of
+// the next page.
+func (d *DB) list(ctx context.Context, t table, params any) (page, error) {
+	where, args, cursor, err := t.where(params)
+	if err != nil {
+		return page{}, err
+	}
+	offset := 0
+	if cursor != "" {
+		if offset, err = strconv.Atoi(cursor); err != nil || offset < 0 {
+			return page{}, fmt.Errorf("invalid cursor %q: not a cursor of the local database", cursor)
+		}
+	}
+
+	from := t.name + " t"
+	if t.join != "" {
+		from += fmt.Sprintf(" JOIN %s j ON j.id = t.id", t.join)
+	}
+
+	var numFound int
+	if err := d.db.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", from, where), args...,
+	).Scan(&numFound); err != nil {
+		return page{}, fmt.Errorf("failed to count %s: %w", t.name, err)
+	}
+
+	rows, err := d.db.QueryContext(ctx,
+		fmt.Sprintf("SELECT t.id FROM %s WHERE %s ORDER BY t.datum DESC, CAST(t.id AS INTEGER) DESC LIMIT ? OFFSET ?", from, where),
+		append(args, t.pageSize, offset)...)
+	if err != nil {
+		return page{}, fmt.Errorf("failed to list %s: %w", t.name, err)
+	}
+	defer rows.Close()
+
+	p := page{numFound: numFound, cursor: strconv.Itoa(offset)}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return page{}, err
+		}
+		p.ids = append(p.ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return page{}, err
+	}
+	if offset+len(p.ids) < numFound {
+		p.cursor = strconv.Itoa(offset + len(p.ids))
+	}
+	return p, nil
+}
+
+// where returns the condition and arguments for the filters of params and the cursor
+func (t table) where(params any) (string, []any, string, error) {
+	conds := []string{"t.deleted_at IS NULL"}
+	var (
+		args   []any
+		cursor string
+	)
+	v := reflect.ValueOf(params)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return strings.Join(conds, " AND "), args, "", nil
+	}
+	v = v.Elem()
+
+	// Sort the parameters, so the same request always gives the same query
+	type param struct {
+		name  string
+		value reflect.Value
+	}
+	var set []param
+	for i := 0; i < v.NumField(); i++ {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("form"), ",")
+		if f := v.Field(i); name != "" && f.Kind() == reflect.Pointer && !f.IsNil() {
+			set = append(set, param{name: name, value: f.Elem()})
+		}
+	}
+	sort.Slice(set, func(i, j int) bool { return set[i].name < set[j].name })
+
+	for _, p := range set {
+		switch p.name {
+		case "cursor":
+			cursor = p.value.String()
+			continue
+		case "format":
+			continue
+		}
+		f, ok := t.filters[p.name]
+		if !ok {
+			return "", nil, "", &UnsupportedFilterError{Resource: t.resource, Filter: p.name}
+		}
+		values := filterValues(p.value)
+		if len(values) == 0 {
+			continue
+		}
+		parts := make([]string, len(values))
+		for i := range values {
+			parts[i] = "(" + f.cond + ")"
+		}
+		op := " OR "
+		if f.all {
+			op = " AND "
+		}
+		conds = append(conds, "("+strings.Join(parts, op)+")")
+		args = append(args, values...)
+	}
+	return strings.Join(conds, " AND "), args, cursor, nil
+}
+
+// filterValues returns the values of a list parameter as SQL arguments. Dates
+// and timestamps use the format of the store.
+func filterValues(v reflect.Value) []any {
+	switch {
+	case v.Type() == reflect.TypeOf(time.Time{}):
+		return []any{v.Interface().(time.Time).UTC().Format(time.RFC3339)}
+	case v.Type() == reflect.TypeOf(openapi_types.Date{}):
+		return []any{v.Interface().(openapi_types.Date).Format(time.DateOnly)}
+	case v.Kind() == reflect.Slice:
+		var values []any
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, filterValues(v.Index(i))...)
+		}
+		return values
+	case v.Kind() == reflect.String:
+		return []any{v.String()}
+	case v.CanInt():
+		return []any{v.Int()}
+	}
+	return []any{v.Interface()}
+}
+
+// Helpers to turn nullable columns into the optional fields of the API types
+
+func stringPtr(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+func intPtr(i sql.NullInt64) *int {
+	if !i.Valid {
+		return nil
+	}
+	n := int(i.Int64)
+	return &n
+}
+
+func int32Ptr(i sql.NullInt64) *int32 {
+	if !i.Valid {
+		return nil
+	}
+	n := int32(i.Int64)
+	return &n
+}
+
+func parseDate(s string) (openapi_types.Date, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return openapi_types.Date{}, fmt.Errorf("invalid date %q: %w", s, err)
+	}
+	return openapi_types.Date{Time: t}, nil
+}
+
+func parseNullDate(s sql.NullString) (*openapi_types.Date, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	d, err := parseDate(s.String)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func parseTimestamp(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q: %w", s, err)
+	}
+	return t, nil
+}
+
+// sliceOrNil returns nil for an empty list, the API omits empty lists
+func sliceOrNil[T any](s []T) *[]T {
+	if len(s) == 0 {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,177 @@
+package offline
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/store/storetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openTestDB(t *testing.T) (store.Store, *DB) {
+	t.Helper()
+	s := storetest.Open(t)
+	return s, New(s.DB())
+}
+
+// assertSameJSON compares the JSON of what the API returned and what the local database returns
+func assertSameJSON(t *testing.T, want, got any) {
+	t.Helper()
+	wantJSON, err := json.Marshal(want)
+	require.NoError(t, err)
+	gotJSON, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(wantJSON), string(gotJSON))
+}
+
+func TestGetRebuildsAPIShape(t *testing.T) {
+	ctx := context.Background()
+	s, d := openTestDB(t)
+
+	drucksache := storetest.Drucksache(t)
+	vorgang := storetest.Vorgang(t)
+	vorgangsposition := storetest.Vorgangsposition(t)
+	require.NoError(t, s.UpsertDrucksache(ctx, drucksache))
+	require.NoError(t, s.UpsertVorgang(ctx, vorgang))
+	require.NoError(t, s.UpsertVorgangsposition(ctx, vorgangsposition))
+	require.NoError(t, s.UpsertPerson(ctx, storetest.Person(t)))
+
+	gotDrucksache, err := d.GetDrucksache(ctx, 264030, nil)
+	require.NoError(t, err)
+	assertSameJSON(t, drucksache, gotDrucksache)
+
+	gotVorgang, err := d.GetVorgang(ctx, 303271, nil)
+	require.NoError(t, err)
+	assertSameJSON(t, vorgang, gotVorgang)
+
+	gotVorgangsposition, err := d.GetVorgangsposition(ctx, 555001, nil)
+	require.NoError(t, err)
+	assertSameJSON(t, vorgangsposition, gotVorgangsposition)
+
+	person, err := d.GetPerson(ctx, 7001, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Bundesministerin", person.Funktion)
+	assert.Equal(t, []int32{20}, *person.Wahlperiode)
+	require.NotNil(t, person.PersonRoles)
+	assert.Equal(t, []int32{20}, *(*person.PersonRoles)[0].WahlperiodeNummer)
+
+	text := "Die Länder stellen sicher, dass Wärmepläne erstellt werden."
+	require.NoError(t, s.UpsertDrucksacheText(ctx, client.DrucksacheText{Id: drucksache.Id, Text: &text}))
+	drucksacheText, err := d.GetDrucksacheText(ctx, 264030, nil)
+	require.NoError(t, err)
+	assert.Equal(t, text, *drucksacheText.Text)
+	assert.Equal(t, drucksache.Fundstelle.PdfUrl, drucksacheText.Fundstelle.PdfUrl)
+
+	_, err = d.GetVorgang(ctx, 1, nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Records removed from the API are gone offline as well
+	require.NoError(t, s.MarkDeleted(ctx, store.DrucksacheTable, drucksache.Id, time.Now()))
+	_, err = d.GetDrucksache(ctx, 264030, nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestListFilters(t *testing.T) {
+	ctx := context.Background()
+	s, d := openTestDB(t)
+
+	// Three Drucksachen of WP 20 and one of WP 19, newest first
+	base := storetest.Drucksache(t)
+	for i, typ := range []string{"Gesetzentwurf", "Antrag", "Antrag", "Antrag"} {
+		drucksache := base
+		drucksache.Id = strconv.Itoa(1000 + i)
+		drucksache.Drucksachetyp = typ
+		drucksache.Datum.Time = base.Datum.AddDate(0, 0, i)
+		wahlperiode := int32(20)
+		if i == 3 {
+			wahlperiode = 19
+		}
+		drucksache.Wahlperiode = &wahlperiode
+		require.NoError(t, s.UpsertDrucksache(ctx, drucksache))
+	}
+
+	ids := func(list *client.DrucksacheListResponse) []string {
+		var ids []string
+		for _, drucksache := range list.Documents {
+			ids = append(ids, drucksache.Id)
+		}
+		return ids
+	}
+
+	list, err := d.GetDrucksacheList(ctx, &client.GetDrucksacheListParams{
+		FWahlperiode:   &[]int{20},
+		FDrucksachetyp: ptr("Antrag"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1002", "1001"}, ids(list))
+	assert.EqualValues(t, 2, list.NumFound)
+	// The cursor stays the same after the last page
+	assert.Equal(t, "0", list.Cursor)
+
+	list, err = d.GetDrucksacheList(ctx, &client.GetDrucksacheListParams{FWahlperiode: &[]int{19, 20}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1003", "1002", "1001", "1000"}, ids(list))
+
+	list, err = d.GetDrucksacheList(ctx, &client.GetDrucksacheListParams{
+		FDatumStart: &client.Datum{Time: base.Datum.AddDate(0, 0, 1)},
+		FDatumEnd:   &client.Datum{Time: base.Datum.AddDate(0, 0, 2)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1002", "1001"}, ids(list))
+
+	// Words of the title must all match
+	list, err = d.GetDrucksacheList(ctx, &client.GetDrucksacheListParams{FTitel: &[]string{"Wärmeplanung", "Gesetz"}})
+	require.NoError(t, err)
+	assert.Len(t, list.Documents, 4)
+	list, err = d.GetDrucksacheList(ctx, &client.GetDrucksacheListParams{FTitel: &[]string{"Wärmeplanung", "Verordnung"}})
+	require.NoError(t, err)
+	assert.Empty(t, list.Documents)
+	assert.NotNil(t, list.Documents)
+
+	list, err = d.GetDrucksacheList(ctx, &client.GetDrucksacheListParams{FUrheber: &[]string{"Bundesregierung"}})
+	require.NoError(t, err)
+	assert.Len(t, list.Documents, 4)
+
+	_, err = d.GetDrucksacheList(ctx, &client.GetDrucksacheListParams{FVorgangstypNotation: &[]int{100}})
+	var unsupported *UnsupportedFilterError
+	require.ErrorAs(t, err, &unsupported)
+	assert.Equal(t, "f.vorgangstyp_notation", unsupported.Filter)
+
+	_, err = d.GetDrucksacheList(ctx, &client.GetDrucksacheListParams{Cursor: ptr("AoJw-IOX3JUDLl")})
+	assert.Error(t, err)
+}
+
+func TestListPages(t *testing.T) {
+	ctx := context.Background()
+	s, d := openTestDB(t)
+
+	base := storetest.Drucksache(t)
+	for i := 0; i < pageSize+5; i++ {
+		drucksache := base
+		drucksache.Id = strconv.Itoa(1000 + i)
+		require.NoError(t, s.UpsertDrucksache(ctx, drucksache))
+	}
+
+	params := &client.GetDrucksacheListParams{}
+	first, err := d.GetDrucksacheList(ctx, params)
+	require.NoError(t, err)
+	assert.Len(t, first.Documents, pageSize)
+	assert.EqualValues(t, pageSize+5, first.NumFound)
+	assert.Equal(t, "1104", first.Documents[0].Id)
+
+	params.Cursor = &first.Cursor
+	second, err := d.GetDrucksacheList(ctx, params)
+	require.NoError(t, err)
+	assert.Len(t, second.Documents, 5)
+	assert.Equal(t, first.Cursor, second.Cursor)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,237 @@
+// Package pdfstore keeps the PDFs of Drucksachen and Plenarprotokolle in a
+// content-addressed directory tree. A PDF is stored under the MD5 checksum DIP
+// gives as pdf_hash, e.g. a3/3a/a33af31e7c4524db8db172ef8f9e0f6d.pdf, so each
+// file is stored once and can be verified against its name.
+package pdfstore
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidHash is returned for a pdf_hash that is not an MD5 checksum
+	ErrInvalidHash = errors.New("invalid pdf_hash")
+	// ErrHashMismatch is returned when the content of a PDF does not match its pdf_hash
+	ErrHashMismatch = errors.New("PDF does not match pdf_hash")
+)
+
+// partSuffix marks incomplete downloads, which are resumed
+const partSuffix = ".part"
+
+// Store is a content-addressed directory of PDFs
+type Store struct {
+	root string
+}
+
+// Result describes a stored PDF
+type Result struct {
+	Path    string // path relative to the store root
+	Size    int64
+	Existed bool // the PDF was already present, nothing was downloaded
+	Resumed bool // a partial download was continued
+}
+
+// New opens the store at root, creating the directory if needed
+func New(root string) (*Store, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create PDF store %s: %w", root, err)
+	}
+	return &Store{root: root}, nil
+}
+
+// Root returns the root directory of the store
+func (s *Store) Root() string {
+	return s.root
+}
+
+// RelPath returns the path of a PDF relative to the store root
+func RelPath(hash string) (string, error) {
+	hash = strings.ToLower(hash)
+	if len(hash) != md5.Size*2 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidHash, hash)
+	}
+	if _, err := hex.DecodeString(hash); err != nil {
+		return "", fmt.Errorf("%w: %q", ErrInvalidHash, hash)
+	}
+	return filepath.Join(hash[0:2], hash[2:4], hash+".pdf"), nil
+}
+
+// Path returns the absolute location of a PDF in the store
+func (s *Store) Path(hash string) (string, error) {
+	rel, err := RelPath(hash)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, rel), nil
+}
+
+// Verify checks that a stored PDF exists and matches its hash. It returns the
+// size of the file, or an error wrapping os.ErrNotExist or ErrHashMismatch.
+func (s *Store) Verify(hash string) (int64, error) {
+	path, err := s.Path(hash)
+	if err != nil {
+		return 0, err
+	}
+	return verifyFile(path, hash)
+}
+
+// Stored reports whether a valid PDF is already in the store and returns it.
+// A corrupt file is removed, so that Download fetches it again without
+// hashing it a second time.
+func (s *Store) Stored(hash string) (Result, bool, error) {
+	rel, err := RelPath(hash)
+	if err != nil {
+		return Result{}, false, err
+	}
+	path := filepath.Join(s.root, rel)
+	result := Result{Path: rel}
+
+	size, err := verifyFile(path, hash)
+	switch {
+	case err == nil:
+		result.Size = size
+		result.Existed = true
+		return result, true, nil
+	case errors.Is(err, ErrHashMismatch):
+		// A corrupt file is replaced
+		if err := os.Remove(path); err != nil {
+			return result, false, err
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return result, false, err
+	}
+	return result, false, nil
+}
+
+// Download stores the PDF at url under hash. A PDF that is already present and
+// valid is not downloaded again; an incomplete download is resumed with a
+// Range request. The partial file is kept when the download is interrupted,
+// and removed when the completed file does not match the hash.
+func (s *Store) Download(ctx context.Context, client *http.Client, url, hash string) (Result, error) {
+	result, ok, err := s.Stored(hash)
+	if err != nil || ok {
+		return result, err
+	}
+	hash = strings.ToLower(hash)
+	path := filepath.Join(s.root, result.Path)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return result, err
+	}
+	part := path + partSuffix
+	resumed, err := fetch(ctx, client, url, part)
+	if err != nil {
+		return result, err
+	}
+	result.Resumed = resumed
+
+	size, err := verifyFile(part, hash)
+	if err != nil {
+		if errors.Is(err, ErrHashMismatch) {
+			os.Remove(part)
+		}
+		return result, err
+	}
+	if err := os.Rename(part, path); err != nil {
+		return result, err
+	}
+	result.Size = size
+	return result, nil
+}
+
+// fetch downloads url into part, continuing an existing partial file. It
+// reports whether the download was resumed.
+func fetch(ctx context.Context, client *http.Client, url, part string) (bool, error) {
+	var offset int64
+	if info, err := os.Stat(part); err == nil {
+		offset = info.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// The server ignored the Range header, start over
+		flags |= os.O_TRUNC
+		offset = 0
+	case http.StatusPartialContent:
+		if start, ok := contentRangeStart(resp.Header.Get("Content-Range")); !ok || start != offset {
+			return false, fmt.Errorf("unexpected Content-Range %q for offset %d", resp.Header.Get("Content-Range"), offset)
+		}
+		flags |= os.O_APPEND
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial file is already complete, or longer than the PDF and
+		// rejected by the hash check
+		return offset > 0, nil
+	default:
+		return false, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	f, err := os.OpenFile(part, flags, 0o644)
+	if err != nil {
+		return false, err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		return false, fmt.Errorf("download interrupted: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return false, err
+	}
+	return offset > 0, nil
+}
+
+// contentRangeStart parses the first byte of "bytes 100-199/200"
+func contentRangeStart(header string) (int64, bool) {
+	spec, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return 0, false
+	}
+	start, _, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(start, 10, 64)
+	return n, err == nil
+}
+
+// verifyFile returns the size of the file at path if its MD5 matches hash
+func verifyFile(path, hash string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	h := md5.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return 0, err
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != strings.ToLower(hash) {
+		return size, fmt.Errorf("%w: %s has MD5 %s", ErrHashMismatch, filepath.Base(path), sum)
+	}
+	return size, nil
+}
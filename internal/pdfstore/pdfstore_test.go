@@ -0,0 +1,171 @@
+package pdfstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pdf = bytes.Repeat([]byte("%PDF-1.4 Drucksache 20/1234\n"), 100)
+
+func pdfHash() string {
+	sum := md5.Sum(pdf)
+	return hex.EncodeToString(sum[:])
+}
+
+// pdfServer serves pdf with Range support and records the Range headers of the requests
+type pdfServer struct {
+	*httptest.Server
+	mu     sync.Mutex
+	ranges []string
+}
+
+func newPDFServer(t *testing.T) *pdfServer {
+	s := &pdfServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.ranges = append(s.ranges, r.Header.Get("Range"))
+		s.mu.Unlock()
+		http.ServeContent(w, r, "dokument.pdf", time.Time{}, bytes.NewReader(pdf))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestRelPath(t *testing.T) {
+	rel, err := RelPath("A33AF31E7C4524DB8DB172EF8F9E0F6D")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("a3", "3a", "a33af31e7c4524db8db172ef8f9e0f6d.pdf"), rel)
+
+	for _, hash := range []string{"", "a33af31e", "../3af31e7c4524db8db172ef8f9e0f6d", "z33af31e7c4524db8db172ef8f9e0f6d"} {
+		_, err := RelPath(hash)
+		assert.ErrorIs(t, err, ErrInvalidHash, hash)
+	}
+}
+
+func TestDownload(t *testing.T) {
+	ctx := context.Background()
+	server := newPDFServer(t)
+	store, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	result, err := store.Download(ctx, server.Client(), server.URL, pdfHash())
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(pdf)), result.Size)
+	assert.False(t, result.Existed)
+	assert.False(t, result.Resumed)
+
+	data, err := os.ReadFile(filepath.Join(store.Root(), result.Path))
+	require.NoError(t, err)
+	assert.Equal(t, pdf, data)
+
+	// Present files are verified, not downloaded again
+	result, err = store.Download(ctx, server.Client(), server.URL, pdfHash())
+	require.NoError(t, err)
+	assert.True(t, result.Existed)
+	assert.Len(t, server.ranges, 1)
+
+	size, err := store.Verify(pdfHash())
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(pdf)), size)
+}
+
+func TestDownload_Resume(t *testing.T) {
+	ctx := context.Background()
+	server := newPDFServer(t)
+	store, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	path, err := store.Path(pdfHash())
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path+partSuffix, pdf[:1000], 0o644))
+
+	result, err := store.Download(ctx, server.Client(), server.URL, pdfHash())
+	require.NoError(t, err)
+	assert.True(t, result.Resumed)
+	assert.Equal(t, []string{"bytes=1000-"}, server.ranges)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, pdf, data)
+	assert.NoFileExists(t, path+partSuffix)
+}
+
+func TestDownload_HashMismatch(t *testing.T) {
+	ctx := context.Background()
+	server := newPDFServer(t)
+	store, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	hash := "a33af31e7c4524db8db172ef8f9e0f6d"
+	_, err = store.Download(ctx, server.Client(), server.URL, hash)
+	assert.ErrorIs(t, err, ErrHashMismatch)
+
+	path, err := store.Path(hash)
+	require.NoError(t, err)
+	assert.NoFileExists(t, path)
+	assert.NoFileExists(t, path+partSuffix)
+
+	_, err = store.Verify(hash)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestDownload_ReplacesCorruptFile(t *testing.T) {
+	ctx := context.Background()
+	server := newPDFServer(t)
+	store, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	path, err := store.Path(pdfHash())
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte("truncated"), 0o644))
+
+	_, err = store.Verify(pdfHash())
+	assert.ErrorIs(t, err, ErrHashMismatch)
+
+	result, err := store.Download(ctx, server.Client(), server.URL, pdfHash())
+	require.NoError(t, err)
+	assert.False(t, result.Existed)
+	_, err = store.Verify(pdfHash())
+	assert.NoError(t, err)
+}
+
+func TestStored(t *testing.T) {
+	ctx := context.Background()
+	server := newPDFServer(t)
+	store, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	_, ok, err := store.Stored(pdfHash())
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = store.Download(ctx, server.Client(), server.URL, pdfHash())
+	require.NoError(t, err)
+	result, ok, err := store.Stored(pdfHash())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, result.Existed)
+	assert.Equal(t, int64(len(pdf)), result.Size)
+
+	// A corrupt file is removed so that Download fetches it again
+	path := filepath.Join(store.Root(), result.Path)
+	require.NoError(t, os.WriteFile(path, []byte("truncated"), 0o644))
+	_, ok, err = store.Stored(pdfHash())
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.NoFileExists(t, path)
+}
@@ -0,0 +1,237 @@
+// Package plenarxml parses the structured XML of the Plenarprotokolle of the
+// Bundestag (DTD dbtplenarprotokoll, from Wahlperiode 18 onwards), as linked by
+// fundstelle.xml_url of a Plenarprotokoll.
+//
+// The XML already marks up Tagesordnungspunkte, Reden, speakers (with the IDs of
+// the MdB Stammdaten) and Kommentare, so no text heuristics are needed as in
+// internal/protokoll.
+package plenarxml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DTDName is the document type of the Plenarprotokoll XML
+const DTDName = "dbtplenarprotokoll"
+
+// Kinds of Absaetze
+const (
+	AbsatzText      = "p"         // paragraph, klasse "redner" for the speaker line
+	AbsatzKommentar = "kommentar" // stage direction, e.g. "(Beifall bei der SPD)"
+	AbsatzName      = "name"      // the chair takes the floor within a Rede, e.g. "Präsident Dr. Wolfgang Schäuble:"
+)
+
+// Protokoll is a parsed Plenarprotokoll
+type Protokoll struct {
+	Wahlperiode         int
+	SitzungNr           int
+	Datum               string // YYYY-MM-DD
+	StartUhrzeit        string // e.g. "9:00"
+	EndeUhrzeit         string
+	Ort                 string
+	Version             string
+	Redner              []Redner // speakers of the session, from rednerliste and the Reden
+	Tagesordnungspunkte []Tagesordnungspunkt
+}
+
+// Dokumentnummer returns the Dokumentnummer of the Plenarprotokoll in DIP, e.g. "20/123"
+func (p Protokoll) Dokumentnummer() string {
+	return fmt.Sprintf("%d/%d", p.Wahlperiode, p.SitzungNr)
+}
+
+// Redner is a speaker. ID is the ID of the MdB Stammdaten (mdb_person.id) for members.
+type Redner struct {
+	ID           string
+	Titel        string
+	Vorname      string
+	Namenszusatz string
+	Nachname     string
+	Ortszusatz   string
+	Fraktion     string
+	RolleLang    string // e.g. "Bundesminister der Finanzen"
+	RolleKurz    string
+	Bundesland   string // for members of the Bundesrat
+}
+
+// Tagesordnungspunkt is a Tagesordnungspunkt with its Reden
+type Tagesordnungspunkt struct {
+	Position int    // order within the Protokoll, starting at 0
+	TopID    string // e.g. "Tagesordnungspunkt 3", "Zusatzpunkt 5"
+	Titel    string // the paragraphs before the first Rede
+	Reden    []Rede
+}
+
+// Rede is a speech, including interventions of the chair and Kommentare
+type Rede struct {
+	Position int    // order within the Protokoll, starting at 0
+	ID       string // e.g. "ID2011200100"
+	RednerID string // the speaker giving the speech
+	Absaetze []Absatz
+}
+
+// Absatz is a paragraph of a Rede
+type Absatz struct {
+	Position int    // order within the Rede, starting at 0
+	Typ      string // AbsatzText, AbsatzKommentar or AbsatzName
+	Klasse   string // formatting class of paragraphs, e.g. "J_1", "redner", "Z"
+	RednerID string // current speaker of paragraphs, empty while the chair speaks
+	Sprecher string // current speaker as written in the name line, if the chair speaks
+	Text     string
+}
+
+// xmlProtokoll matches the root element dbtplenarprotokoll
+type xmlProtokoll struct {
+	XMLName             xml.Name    `xml:"dbtplenarprotokoll"`
+	Wahlperiode         int         `xml:"wahlperiode,attr"`
+	SitzungNr           int         `xml:"sitzung-nr,attr"`
+	SitzungDatum        string      `xml:"sitzung-datum,attr"`
+	StartUhrzeit        string      `xml:"sitzung-start-uhrzeit,attr"`
+	EndeUhrzeit         string      `xml:"sitzung-ende-uhrzeit,attr"`
+	Ort                 string      `xml:"sitzung-ort,attr"`
+	Version             string      `xml:"version,attr"`
+	Tagesordnungspunkte []xmlNode   `xml:"sitzungsverlauf>tagesordnungspunkt"`
+	Rednerliste         []xmlRedner `xml:"rednerliste>redner"`
+}
+
+// xmlNode is an element with mixed content: tagesordnungspunkt, rede, p, kommentar, name
+type xmlNode struct {
+	XMLName xml.Name
+	TopID   string     `xml:"top-id,attr"`
+	ID      string     `xml:"id,attr"`
+	Klasse  string     `xml:"klasse,attr"`
+	Redner  *xmlRedner `xml:"redner"`
+	Inhalt  []xmlNode  `xml:",any"`
+	Text    string     `xml:",chardata"`
+}
+
+type xmlRedner struct {
+	ID           string `xml:"id,attr"`
+	Titel        string `xml:"name>titel"`
+	Vorname      string `xml:"name>vorname"`
+	Namenszusatz string `xml:"name>namenszusatz"`
+	Nachname     string `xml:"name>nachname"`
+	Ortszusatz   string `xml:"name>ortszusatz"`
+	Fraktion     string `xml:"name>fraktion"`
+	RolleLang    string `xml:"name>rolle>rolle_lang"`
+	RolleKurz    string `xml:"name>rolle>rolle_kurz"`
+	Bundesland   string `xml:"name>bdland"`
+}
+
+// xmlDateLayout is the format of sitzung-datum
+const xmlDateLayout = "02.01.2006"
+
+// Parse parses a Plenarprotokoll XML document. It does not validate the
+// document, see internal/xmlvalidate.
+func Parse(data []byte) (*Protokoll, error) {
+	var doc xmlProtokoll
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse Plenarprotokoll XML: %w", err)
+	}
+	if doc.Wahlperiode == 0 || doc.SitzungNr == 0 {
+		return nil, fmt.Errorf("Plenarprotokoll XML without wahlperiode or sitzung-nr")
+	}
+
+	p := &Protokoll{
+		Wahlperiode:  doc.Wahlperiode,
+		SitzungNr:    doc.SitzungNr,
+		StartUhrzeit: doc.StartUhrzeit,
+		EndeUhrzeit:  doc.EndeUhrzeit,
+		Ort:          doc.Ort,
+		Version:      doc.Version,
+	}
+	if doc.SitzungDatum != "" {
+		datum, err := time.Parse(xmlDateLayout, doc.SitzungDatum)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sitzung-datum %q: %w", doc.SitzungDatum, err)
+		}
+		p.Datum = datum.Format("2006-01-02")
+	}
+
+	redner := make(map[string]int)
+	addRedner := func(r *xmlRedner) {
+		if r == nil || r.ID == "" {
+			return
+		}
+		if _, ok := redner[r.ID]; ok {
+			return
+		}
+		redner[r.ID] = len(p.Redner)
+		p.Redner = append(p.Redner, Redner{
+			ID:           r.ID,
+			Titel:        clean(r.Titel),
+			Vorname:      clean(r.Vorname),
+			Namenszusatz: clean(r.Namenszusatz),
+			Nachname:     clean(r.Nachname),
+			Ortszusatz:   clean(r.Ortszusatz),
+			Fraktion:     clean(r.Fraktion),
+			RolleLang:    clean(r.RolleLang),
+			RolleKurz:    clean(r.RolleKurz),
+			Bundesland:   clean(r.Bundesland),
+		})
+	}
+	for i := range doc.Rednerliste {
+		addRedner(&doc.Rednerliste[i])
+	}
+
+	reden := 0
+	for i, top := range doc.Tagesordnungspunkte {
+		t := Tagesordnungspunkt{Position: i, TopID: clean(top.TopID)}
+		var titel []string
+		for _, node := range top.Inhalt {
+			switch node.XMLName.Local {
+			case "p":
+				if len(t.Reden) == 0 {
+					titel = append(titel, clean(node.Text))
+				}
+			case "rede":
+				rede := parseRede(node, addRedner)
+				rede.Position = reden
+				reden++
+				t.Reden = append(t.Reden, rede)
+			}
+		}
+		t.Titel = strings.Join(titel, " ")
+		p.Tagesordnungspunkte = append(p.Tagesordnungspunkte, t)
+	}
+
+	return p, nil
+}
+
+// parseRede parses the content of a rede element
+func parseRede(node xmlNode, addRedner func(*xmlRedner)) Rede {
+	rede := Rede{ID: node.ID}
+	var rednerID, sprecher string
+	for _, child := range node.Inhalt {
+		absatz := Absatz{Typ: child.XMLName.Local, Klasse: child.Klasse, Text: clean(child.Text)}
+		switch absatz.Typ {
+		case AbsatzText:
+			if child.Redner != nil {
+				addRedner(child.Redner)
+				rednerID, sprecher = child.Redner.ID, ""
+				if rede.RednerID == "" {
+					rede.RednerID = rednerID
+				}
+			}
+		case AbsatzName:
+			rednerID, sprecher = "", strings.TrimSuffix(absatz.Text, ":")
+		case AbsatzKommentar:
+		default:
+			continue
+		}
+		absatz.Position = len(rede.Absaetze)
+		absatz.RednerID = rednerID
+		absatz.Sprecher = sprecher
+		rede.Absaetze = append(rede.Absaetze, absatz)
+	}
+	return rede
+}
+
+// clean normalizes the whitespace of a text
+func clean(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,59 @@
+package plenarxml
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Johanneslueke/dip-client/internal/xmlvalidate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	require.NoError(t, xmlvalidate.ValidateFile("testdata/20123-data.xml", ""))
+	data, err := os.ReadFile("testdata/20123-data.xml")
+	require.NoError(t, err)
+
+	p, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, "20/123", p.Dokumentnummer())
+	assert.Equal(t, "2023-10-12", p.Datum)
+	assert.Equal(t, "9:00", p.StartUhrzeit)
+	assert.Equal(t, "23:12", p.EndeUhrzeit)
+
+	// Speakers of the rednerliste first, then those only found in Reden
+	require.Len(t, p.Redner, 2)
+	assert.Equal(t, "11003625", p.Redner[0].ID)
+	assert.Equal(t, "Bundesministerin für Wohnen, Stadtentwicklung und Bauwesen", p.Redner[0].RolleLang)
+	assert.Equal(t, Redner{ID: "11004930", Titel: "Dr.", Vorname: "Alice", Nachname: "Weidel", Fraktion: "AfD"}, p.Redner[1])
+
+	require.Len(t, p.Tagesordnungspunkte, 1)
+	top := p.Tagesordnungspunkte[0]
+	assert.Equal(t, "Tagesordnungspunkt 3", top.TopID)
+	assert.Equal(t, "Beratung des Entwurfs eines Gesetzes für die Wärmeplanung", top.Titel)
+	require.Len(t, top.Reden, 2)
+
+	rede := top.Reden[0]
+	assert.Equal(t, "ID2012300100", rede.ID)
+	assert.Equal(t, "11004930", rede.RednerID)
+	assert.Equal(t, []Absatz{
+		{Position: 0, Typ: AbsatzText, Klasse: "redner", RednerID: "11004930", Text: "Dr. Alice Weidel (AfD):"},
+		{Position: 1, Typ: AbsatzText, Klasse: "J_1", RednerID: "11004930", Text: "Frau Präsidentin! Meine Damen und Herren!"},
+		{Position: 2, Typ: AbsatzKommentar, RednerID: "11004930", Text: "(Beifall bei der AfD)"},
+		{Position: 3, Typ: AbsatzName, Sprecher: "Präsidentin Bärbel Bas", Text: "Präsidentin Bärbel Bas:"},
+		{Position: 4, Typ: AbsatzText, Klasse: "J_1", Sprecher: "Präsidentin Bärbel Bas", Text: "Gestatten Sie eine Zwischenfrage?"},
+		{Position: 5, Typ: AbsatzText, Klasse: "redner", RednerID: "11004930", Text: "Dr. Alice Weidel (AfD):"},
+		{Position: 6, Typ: AbsatzText, Klasse: "J", RednerID: "11004930", Text: "Nein."},
+	}, rede.Absaetze)
+
+	assert.Equal(t, 1, top.Reden[1].Position)
+	assert.Equal(t, "11003625", top.Reden[1].RednerID)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse([]byte(`<dbtplenarprotokoll><sitzungsverlauf/></dbtplenarprotokoll>`))
+	assert.Error(t, err)
+
+	_, err = Parse([]byte(`<dbtplenarprotokoll wahlperiode="20" sitzung-nr="1" sitzung-datum="2023-10-12"/>`))
+	assert.Error(t, err)
+}
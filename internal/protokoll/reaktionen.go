@@ -0,0 +1,309 @@
+package protokoll
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Kinds of Reaktionen
+const (
+	Beifall     = "Beifall"
+	Heiterkeit  = "Heiterkeit"
+	Zuruf       = "Zuruf"
+	Widerspruch = "Widerspruch"
+	Unruhe      = "Unruhe"
+)
+
+// Reaktion is a reaction of the plenary to a Rede, recorded as stage direction,
+// e.g. "(Beifall bei der SPD)" or "(Zuruf des Abg. Dr. Anton Hofreiter [BÜNDNIS 90/DIE GRÜNEN]: Falsch!)".
+// A stage direction yields one Reaktion per kind and Fraktion or person.
+type Reaktion struct {
+	Position    int    // order within the Rede, starting at 0
+	Kommentar   int    // index of the stage direction within the Rede, starting at 0
+	Art         string // Beifall, Heiterkeit, Zuruf, Widerspruch or Unruhe
+	Fraktion    string // reacting Fraktion, normalized ("der LINKEN" is "DIE LINKE"); empty for the whole house
+	Abgeordnete bool   // only some members of the Fraktion reacted ("bei Abgeordneten der SPD")
+	Person      string // reacting member, e.g. "Dr. Anton Hofreiter"; empty for Fraktionen
+	Text        string // what was called out, for Zurufe of a member
+	Offset      int    // offset of the stage direction in the Rede text, in characters
+	Raw         string // the part of the stage direction this Reaktion was parsed from
+}
+
+var (
+	// Keywords at the start of a part of a stage direction; the position of the
+	// keyword decides the order of kinds in "Heiterkeit und Beifall bei der SPD"
+	artPatterns = []struct {
+		art     string
+		pattern *regexp.Regexp
+	}{
+		{Beifall, regexp.MustCompile(`(?i)\bbeifall\b`)},
+		{Heiterkeit, regexp.MustCompile(`(?i)\b(?:heiterkeit|lachen)\b`)},
+		{Zuruf, regexp.MustCompile(`(?i)\b(?:zurufe?|gegenrufe?)\b`)},
+		{Widerspruch, regexp.MustCompile(`(?i)\bwiderspruch\b`)},
+		{Unruhe, regexp.MustCompile(`(?i)\bunruhe\b`)},
+	}
+
+	// Fraktionen in stage directions, mostly in genitive ("des BÜNDNISSES 90/DIE GRÜNEN", "der LINKEN").
+	// The first group that matches decides the normalized name.
+	fraktionPatterns = []struct {
+		name    string
+		pattern string
+	}{
+		{"CDU/CSU", `CDU/CSU`},
+		{"SPD", `SPD`},
+		{"FDP", `F\.\s?D\.\s?P\.|FDP`},
+		{"BÜNDNIS 90/DIE GRÜNEN", `BÜNDNIS(?:SES)?\s+90\s*/\s*DIE\s+GRÜNEN`},
+		{"DIE GRÜNEN", `GRÜNEN`},
+		{"DIE LINKE", `(?:DIE\s+)?LINKEN?|Die\s+Linke`},
+		{"PDS", `PDS(?:/Linke\s+Liste)?`},
+		{"AfD", `AfD`},
+		{"BSW", `BSW`},
+	}
+	fraktionPattern = compileFraktionPattern()
+
+	// "Zuruf des Abg. Dr. Anton Hofreiter [BÜNDNIS 90/DIE GRÜNEN]", "Dr. Anton Hofreiter [BÜNDNIS 90/DIE GRÜNEN]: Falsch!"
+	memberReferencePattern = regexp.MustCompile(`([^\[\]]*?)\s*\[([^\[\]]+)\]`)
+	// words before a member name, removed from it
+	memberPrefixPattern = regexp.MustCompile(`^(?:.*\bAbg\.\s+|(?:und|sowie)\s+|,\s*)`)
+
+	// "bei", "von", "im" start a new group of reacting Fraktionen
+	groupPattern = regexp.MustCompile(`\b(?:bei|von|im)\b`)
+	// "bei Abgeordneten der SPD", "bei einzelnen Abgeordneten der FDP"
+	abgeordnetePattern = regexp.MustCompile(`\bAbgeordnete[nr]?\b`)
+
+	// separators between the parts of a stage direction
+	partSeparatorPattern = regexp.MustCompile(`\s+[–—-]\s+`)
+)
+
+func compileFraktionPattern() *regexp.Regexp {
+	groups := make([]string, len(fraktionPatterns))
+	for i, f := range fraktionPatterns {
+		groups[i] = "(" + f.pattern + ")"
+	}
+	return regexp.MustCompile(`\b(?:` + strings.Join(groups, "|") + `)`)
+}
+
+// ParseReaktionen extracts the Reaktionen from the text of a Rede. Stage
+// directions are parenthesized lines; parts without one of the known kinds,
+// e.g. "(Abg. Dr. Alice Weidel [AfD] verlässt den Saal)", are skipped.
+func ParseReaktionen(text string) []Reaktion {
+	var (
+		reaktionen []Reaktion
+		kommentar  int
+		offsets    = NewRuneOffsets(text)
+	)
+	for _, loc := range stageDirections(text) {
+		content := strings.Join(strings.Fields(text[loc[0]+1:loc[1]-1]), " ")
+		offset := offsets.At(loc[0])
+
+		found := false
+		for _, part := range partSeparatorPattern.Split(content, -1) {
+			for _, r := range parsePart(part) {
+				r.Position = len(reaktionen)
+				r.Kommentar = kommentar
+				r.Offset = offset
+				reaktionen = append(reaktionen, r)
+				found = true
+			}
+		}
+		if found {
+			kommentar++
+		}
+	}
+	return reaktionen
+}
+
+// stageDirections returns the byte ranges of the stage directions in text,
+// including the parentheses. A stage direction starts at the beginning of a
+// line and may be wrapped over several lines.
+func stageDirections(text string) [][2]int {
+	var result [][2]int
+	for pos := 0; pos < len(text); {
+		lineStart := pos
+		for lineStart < len(text) && (text[lineStart] == ' ' || text[lineStart] == '\t') {
+			lineStart++
+		}
+		if lineStart < len(text) && text[lineStart] == '(' {
+			if end := closingParenthesis(text, lineStart); end > 0 {
+				result = append(result, [2]int{lineStart, end})
+				pos = end
+				continue
+			}
+		}
+		if i := strings.IndexByte(text[pos:], '\n'); i >= 0 {
+			pos += i + 1
+		} else {
+			break
+		}
+	}
+	return result
+}
+
+// closingParenthesis returns the offset after the parenthesis closing the one
+// at start, or -1 if it is not closed at the end of a line
+func closingParenthesis(text string, start int) int {
+	depth := 0
+	for i := start; i < len(text); i++ {
+		switch text[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				rest := strings.TrimLeft(text[i+1:], " \t")
+				if rest == "" || rest[0] == '\n' || rest[0] == '\r' {
+					return i + 1
+				}
+				return -1
+			}
+		}
+	}
+	return -1
+}
+
+// parsePart parses one part of a stage direction, e.g. "Beifall bei der SPD sowie
+// bei Abgeordneten der CDU/CSU" or "Zuruf des Abg. Friedrich Merz [CDU/CSU]: Unsinn!"
+func parsePart(part string) []Reaktion {
+	// The first colon after the last member reference starts what was called out
+	var zurufText string
+	last := 0
+	if refs := memberReferencePattern.FindAllStringIndex(part, -1); refs != nil {
+		last = refs[len(refs)-1][1]
+	}
+	if i := strings.Index(part[last:], ":"); i >= 0 {
+		zurufText = strings.TrimSpace(part[last+i+1:])
+		part = strings.TrimSpace(part[:last+i])
+	}
+
+	members := memberReferencePattern.FindAllStringSubmatch(part, -1)
+	arten := partArten(part)
+	if len(arten) == 0 {
+		if zurufText == "" || len(members) == 0 {
+			return nil
+		}
+		// "Dr. Anton Hofreiter [BÜNDNIS 90/DIE GRÜNEN]: Falsch!"
+		arten = []string{Zuruf}
+	}
+
+	var reaktionen []Reaktion
+	for _, art := range arten {
+		count := len(reaktionen)
+		for _, m := range members {
+			name := memberPrefixPattern.ReplaceAllString(strings.TrimSpace(m[1]), "")
+			name = strings.TrimSpace(stripArt(strings.TrimSpace(name)))
+			if !isName(name) {
+				continue
+			}
+			r := Reaktion{Art: art, Person: name, Fraktion: normalizeFraktion(m[2]), Raw: part}
+			if art == Zuruf {
+				r.Text = zurufText
+			}
+			reaktionen = append(reaktionen, r)
+		}
+		for _, f := range partFraktionen(memberReferencePattern.ReplaceAllString(part, "")) {
+			f.Art = art
+			f.Raw = part
+			if art == Zuruf {
+				f.Text = zurufText
+			}
+			reaktionen = append(reaktionen, f)
+		}
+		if len(reaktionen) == count {
+			// "Heiterkeit", "Beifall im ganzen Hause"
+			reaktionen = append(reaktionen, Reaktion{Art: art, Raw: part})
+		}
+	}
+	return reaktionen
+}
+
+// partArten returns the kinds mentioned before the first group of Fraktionen
+// or member, in order
+func partArten(part string) []string {
+	head := part
+	if loc := groupPattern.FindStringIndex(head); loc != nil {
+		head = head[:loc[0]]
+	}
+	if i := strings.IndexAny(head, "[:"); i >= 0 {
+		head = head[:i]
+	}
+
+	type found struct {
+		art string
+		at  int
+	}
+	var arten []found
+	for _, a := range artPatterns {
+		if loc := a.pattern.FindStringIndex(head); loc != nil {
+			arten = append(arten, found{a.art, loc[0]})
+		}
+	}
+	for i := 1; i < len(arten); i++ {
+		for j := i; j > 0 && arten[j].at < arten[j-1].at; j-- {
+			arten[j], arten[j-1] = arten[j-1], arten[j]
+		}
+	}
+	result := make([]string, len(arten))
+	for i, a := range arten {
+		result[i] = a.art
+	}
+	return result
+}
+
+// partFraktionen returns the reacting Fraktionen of a part. "Abgeordneten"
+// applies to the Fraktionen following it until the next "bei".
+func partFraktionen(part string) []Reaktion {
+	var (
+		result []Reaktion
+		seen   = make(map[string]bool)
+	)
+	groups := groupPattern.FindAllStringIndex(part, -1)
+	for i, g := range groups {
+		end := len(part)
+		if i+1 < len(groups) {
+			end = groups[i+1][0]
+		}
+		group := part[g[1]:end]
+
+		for _, loc := range fraktionPattern.FindAllStringSubmatchIndex(group, -1) {
+			name := fraktionName(loc)
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			result = append(result, Reaktion{
+				Fraktion:    name,
+				Abgeordnete: abgeordnetePattern.MatchString(group[:loc[0]]),
+			})
+		}
+	}
+	return result
+}
+
+// fraktionName returns the normalized name of a match of fraktionPattern
+func fraktionName(loc []int) string {
+	for i, f := range fraktionPatterns {
+		if loc[2+2*i] >= 0 {
+			return f.name
+		}
+	}
+	return ""
+}
+
+// normalizeFraktion normalizes the Fraktion of a member reference, e.g. "[BÜNDNIS 90/DIE GRÜNEN]"
+func normalizeFraktion(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if loc := fraktionPattern.FindStringSubmatchIndex(s); loc != nil {
+		return fraktionName(loc)
+	}
+	return s
+}
+
+// stripArt removes a leading kind from a member name ("Zuruf Dr. Anton Hofreiter")
+func stripArt(name string) string {
+	for _, a := range artPatterns {
+		if loc := a.pattern.FindStringIndex(name); loc != nil && loc[0] == 0 {
+			return name[loc[1]:]
+		}
+	}
+	return name
+}
@@ -0,0 +1,67 @@
+package protokoll
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const redeText = `Frau Präsidentin! Meine Damen und Herren! Die Lage ist ernst.
+(Beifall bei der SPD sowie bei Abgeordneten des BÜNDNISSES 90/DIE GRÜNEN)
+Wir handeln.
+(Zuruf des Abg. Friedrich Merz [CDU/CSU]: Wann
+denn?)
+Jetzt.
+(Heiterkeit und Beifall bei der SPD – Widerspruch bei der AfD – Zurufe von der LINKEN: Zu spät!)
+(Abg. Dr. Alice Weidel [AfD] verlässt den Saal)
+Ich bleibe dabei (und das sage ich deutlich).
+(Dr. Anton Hofreiter [BÜNDNIS 90/DIE GRÜNEN]: Sehr richtig! – Unruhe)
+(Beifall im ganzen Hause)`
+
+func TestParseReaktionen(t *testing.T) {
+	reaktionen := ParseReaktionen(redeText)
+
+	type reaktion struct {
+		kommentar   int
+		art         string
+		fraktion    string
+		abgeordnete bool
+		person      string
+		text        string
+	}
+	var got []reaktion
+	for i, r := range reaktionen {
+		assert.Equal(t, i, r.Position)
+		got = append(got, reaktion{r.Kommentar, r.Art, r.Fraktion, r.Abgeordnete, r.Person, r.Text})
+	}
+	assert.Equal(t, []reaktion{
+		{0, Beifall, "SPD", false, "", ""},
+		{0, Beifall, "BÜNDNIS 90/DIE GRÜNEN", true, "", ""},
+		{1, Zuruf, "CDU/CSU", false, "Friedrich Merz", "Wann denn?"},
+		{2, Heiterkeit, "SPD", false, "", ""},
+		{2, Beifall, "SPD", false, "", ""},
+		{2, Widerspruch, "AfD", false, "", ""},
+		{2, Zuruf, "DIE LINKE", false, "", "Zu spät!"},
+		{3, Zuruf, "BÜNDNIS 90/DIE GRÜNEN", false, "Dr. Anton Hofreiter", "Sehr richtig!"},
+		{3, Unruhe, "", false, "", ""},
+		{4, Beifall, "", false, "", ""},
+	}, got)
+
+	// Offsets are in characters and point to the stage direction
+	runes := []rune(redeText)
+	require.Len(t, reaktionen, 10)
+	assert.Equal(t, "(Zuruf", string(runes[reaktionen[2].Offset:reaktionen[2].Offset+6]))
+	assert.Equal(t, "Widerspruch bei der AfD", reaktionen[5].Raw)
+}
+
+func TestParseReaktionen_OlderProtokolle(t *testing.T) {
+	reaktionen := ParseReaktionen("(Beifall bei der F.D.P. und bei Abgeordneten der PDS)\n(Lachen bei der CDU/CSU)")
+	require.Len(t, reaktionen, 3)
+	assert.Equal(t, "FDP", reaktionen[0].Fraktion)
+	assert.False(t, reaktionen[0].Abgeordnete)
+	assert.Equal(t, "PDS", reaktionen[1].Fraktion)
+	assert.True(t, reaktionen[1].Abgeordnete)
+	assert.Equal(t, Heiterkeit, reaktionen[2].Art)
+	assert.Equal(t, "CDU/CSU", reaktionen[2].Fraktion)
+}
@@ -0,0 +1,238 @@
+// Package protokoll parses the plain text of Plenarprotokolle as returned by the
+// DIP API (plenarprotokoll_text.text) into structured records.
+package protokoll
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParserVersion is stored with the parsed Reden and Reaktionen of a
+// Plenarprotokoll. Increase it when the parser changes, so
+// process-plenarprotokoll-reden parses all Plenarprotokolle again.
+const ParserVersion = 2
+
+// Rede is a speaker turn: everything a speaker says until the next speaker takes the floor
+type Rede struct {
+	Position           int    // order within the Plenarprotokoll, starting at 0
+	Sprecher           string // name including titles, e.g. "Dr. Alice Weidel"
+	Rolle              string // e.g. "Präsidentin", "Bundesminister der Finanzen"; empty for members speaking for their Fraktion
+	Fraktion           string // e.g. "SPD", "BÜNDNIS 90/DIE GRÜNEN"; empty for chair and government
+	Tagesordnungspunkt string // e.g. "Tagesordnungspunkt 3", "Zusatzpunkt 5"; empty before the first one is called
+	Start              int    // offset of the speaker line in the text, in characters
+	End                int    // offset after the last character of the Rede, in characters
+	Text               string // the spoken text without the speaker line, including stage directions
+}
+
+// IsChair reports whether the Rede is by the presiding officer of the session
+func (r Rede) IsChair() bool {
+	return chairRoles[r.Rolle]
+}
+
+var (
+	// Session start and end, e.g. "Beginn: 9.00 Uhr" and "(Schluss: 23.12 Uhr)".
+	// The text before Beginn is the table of contents, the text after Schluss are the Anlagen.
+	beginnPattern  = regexp.MustCompile(`(?m)^\s*\(?Beginn:?\s+\d{1,2}[.:]\d{2}\s+Uhr\)?\s*$`)
+	schlussPattern = regexp.MustCompile(`(?m)^\s*\(?Schluss:?\s+\d{1,2}[.:]\d{2}\s+Uhr\)?\s*$`)
+
+	// "Vizepräsidentin Claudia Roth:"
+	chairPattern = regexp.MustCompile(`^(Alterspräsident(?:in)?|Vizepräsident(?:in)?|Präsident(?:in)?)\s+(.+):$`)
+	// "Dr. Alice Weidel (AfD):"
+	memberPattern = regexp.MustCompile(`^(.+?)\s*\(([^()]+)\):$`)
+	// "Olaf Scholz, Bundeskanzler:", "Winfried Kretschmann, Ministerpräsident (Baden-Württemberg):"
+	officePattern = regexp.MustCompile(`^(.+?),\s+((?:Bundeskanzler|Bundesminister|Parl\. Staatssekretär|Staatssekretär|Staatsminister|Minister|Senator|Bürgermeister|Wehrbeauftragt|Bundesbeauftragt|Beauftragt|Präsident)[^:]*):$`)
+
+	// "Ich rufe den Tagesordnungspunkt 3 auf", "Wir rufen die Zusatzpunkte 5 a und 5 b auf"
+	tagesordnungspunktPattern = regexp.MustCompile(`\bruf\w*\b[^.!?]{0,80}?\b(Tagesordnungspunkt|Zusatzpunkt)(?:e|es)?\s+(\d+(?:\s?[a-z]\b)?)`)
+)
+
+var chairRoles = map[string]bool{
+	"Präsident":         true,
+	"Präsidentin":       true,
+	"Vizepräsident":     true,
+	"Vizepräsidentin":   true,
+	"Alterspräsident":   true,
+	"Alterspräsidentin": true,
+}
+
+// nameParticles are lower-case words that may appear in a speaker name
+var nameParticles = map[string]bool{
+	"von": true, "vom": true, "van": true, "de": true, "der": true, "den": true,
+	"zu": true, "zur": true, "di": true, "da": true, "del": true, "la": true,
+	"le": true, "ten": true, "h.": true, "c.": true,
+}
+
+// maxNameWords limits speaker names, so sentences ending with a colon are not taken as speaker lines
+const maxNameWords = 8
+
+// speaker is a parsed speaker line
+type speaker struct {
+	name, rolle, fraktion string
+}
+
+// ParseReden splits the text of a Plenarprotokoll into Reden. Only the text
+// between "Beginn" and "Schluss" of the session is considered, if present.
+func ParseReden(text string) []Rede {
+	begin, end := 0, len(text)
+	if loc := beginnPattern.FindStringIndex(text); loc != nil {
+		begin = loc[1]
+	}
+	if loc := schlussPattern.FindStringIndex(text[begin:]); loc != nil {
+		end = begin + loc[0]
+	}
+
+	var (
+		reden              []Rede
+		current            *Rede
+		bodyStart          int
+		tagesordnungspunkt string
+		offsets            = NewRuneOffsets(text)
+	)
+	finish := func(at int) {
+		if current == nil {
+			return
+		}
+		current.Text = strings.TrimSpace(text[bodyStart:at])
+		current.End = offsets.At(at)
+		reden = append(reden, *current)
+		if current.IsChair() {
+			if top := lastTagesordnungspunkt(current.Text); top != "" {
+				tagesordnungspunkt = top
+			}
+		}
+		current = nil
+	}
+
+	for pos := begin; pos < end; {
+		lineEnd := lineEndAt(text, pos, end)
+		line := strings.TrimSpace(text[pos:lineEnd])
+		next := lineEnd
+		if next < end {
+			next++ // newline
+		}
+
+		// A long Fraktion may be wrapped: "Dr. Anton Hofreiter (BÜNDNIS 90/DIE" + "GRÜNEN):"
+		if strings.Count(line, "(") > strings.Count(line, ")") && next < end {
+			nextEnd := lineEndAt(text, next, end)
+			joined := line + " " + strings.TrimSpace(text[next:nextEnd])
+			if _, ok := parseSpeaker(joined); ok {
+				line, next = joined, min(nextEnd+1, end)
+			}
+		}
+
+		if s, ok := parseSpeaker(line); ok {
+			finish(pos)
+			current = &Rede{
+				Position:           len(reden),
+				Sprecher:           s.name,
+				Rolle:              s.rolle,
+				Fraktion:           s.fraktion,
+				Tagesordnungspunkt: tagesordnungspunkt,
+				Start:              offsets.At(pos),
+			}
+			bodyStart = next
+		}
+		pos = next
+	}
+	finish(end)
+
+	return reden
+}
+
+// parseSpeaker recognizes a speaker line
+func parseSpeaker(line string) (speaker, bool) {
+	if !strings.HasSuffix(line, ":") {
+		return speaker{}, false
+	}
+	line = strings.Join(strings.Fields(line), " ")
+
+	if m := chairPattern.FindStringSubmatch(line); m != nil && isName(m[2]) {
+		return speaker{name: m[2], rolle: m[1]}, true
+	}
+	if m := officePattern.FindStringSubmatch(line); m != nil && isName(m[1]) {
+		return speaker{name: m[1], rolle: m[2]}, true
+	}
+	if m := memberPattern.FindStringSubmatch(line); m != nil && isName(m[1]) && isFraktion(m[2]) {
+		return speaker{name: m[1], fraktion: m[2]}, true
+	}
+	return speaker{}, false
+}
+
+// isName reports whether s looks like a person name: a few capitalized words and name particles
+func isName(s string) bool {
+	words := strings.Fields(s)
+	if len(words) < 2 || len(words) > maxNameWords {
+		return false
+	}
+	for _, word := range words {
+		if nameParticles[word] {
+			continue
+		}
+		first, _ := utf8.DecodeRuneInString(word)
+		if !unicode.IsUpper(first) || strings.ContainsAny(word, "0123456789,;!?\"") {
+			return false
+		}
+	}
+	return true
+}
+
+// isFraktion reports whether s looks like a Fraktion: mostly upper-case, e.g.
+// "CDU/CSU", "BÜNDNIS 90/DIE GRÜNEN", "AfD", "DIE LINKE", or "fraktionslos"
+func isFraktion(s string) bool {
+	if s == "fraktionslos" {
+		return true
+	}
+	upper, letters := 0, 0
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	return letters > 0 && upper*2 >= letters
+}
+
+// lastTagesordnungspunkt returns the last Tagesordnungspunkt called in a text of the chair
+func lastTagesordnungspunkt(text string) string {
+	matches := tagesordnungspunktPattern.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+	m := matches[len(matches)-1]
+	return m[1] + " " + strings.Join(strings.Fields(m[2]), " ")
+}
+
+func lineEndAt(text string, pos, end int) int {
+	if i := strings.IndexByte(text[pos:end], '\n'); i >= 0 {
+		return pos + i
+	}
+	return end
+}
+
+// RuneOffsets converts increasing byte offsets of a text into character offsets
+// without counting the text from the start for every offset
+type RuneOffsets struct {
+	text  string
+	bytes int
+	runes int
+}
+
+// NewRuneOffsets returns the offset converter of text
+func NewRuneOffsets(text string) *RuneOffsets {
+	return &RuneOffsets{text: text}
+}
+
+// At returns the character offset of a byte offset. Going back to an earlier
+// offset counts again from the start of the text.
+func (o *RuneOffsets) At(byteOffset int) int {
+	if byteOffset < o.bytes {
+		o.bytes, o.runes = 0, 0
+	}
+	o.runes += utf8.RuneCountInString(o.text[o.bytes:byteOffset])
+	o.bytes = byteOffset
+	return o.runes
+}
@@ -0,0 +1,106 @@
+package protokoll
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const protokollText = `Deutscher Bundestag
+Stenografischer Bericht
+45. Sitzung
+Inhalt:
+Tagesordnungspunkt 1:
+Befragung der Bundesregierung
+Olaf Scholz, Bundeskanzler . . . . . . . . . . 4567 A
+Friedrich Merz (CDU/CSU) . . . . . . . . . . . 4568 B
+Beginn: 13.00 Uhr
+Präsidentin Bärbel Bas:
+Guten Tag, liebe Kolleginnen und Kollegen! Die Sitzung ist eröffnet.
+Ich rufe den Tagesordnungspunkt 1 auf:
+Befragung der Bundesregierung
+Olaf Scholz, Bundeskanzler:
+Frau Präsidentin! Meine Damen und Herren! Die Lage ist ernst.
+(Beifall bei der SPD)
+Friedrich Merz (CDU/CSU):
+Herr Bundeskanzler, dazu habe ich folgende Frage:
+Wann handeln Sie?
+Vizepräsidentin Katrin Göring-Eckardt:
+Vielen Dank. – Wir rufen nun die Zusatzpunkte 5 a und 5 b auf.
+Dr. Anton Hofreiter (BÜNDNIS 90/DIE
+GRÜNEN):
+Wärmepumpen für alle!
+(Schluss: 19.12 Uhr)
+Anlage 1
+Liste der entschuldigten Abgeordneten
+Max Mustermann (SPD):
+`
+
+func TestParseReden(t *testing.T) {
+	reden := ParseReden(protokollText)
+	require.Len(t, reden, 5)
+
+	assert.Equal(t, "Bärbel Bas", reden[0].Sprecher)
+	assert.Equal(t, "Präsidentin", reden[0].Rolle)
+	assert.True(t, reden[0].IsChair())
+	assert.Empty(t, reden[0].Tagesordnungspunkt)
+
+	assert.Equal(t, "Olaf Scholz", reden[1].Sprecher)
+	assert.Equal(t, "Bundeskanzler", reden[1].Rolle)
+	assert.Empty(t, reden[1].Fraktion)
+	assert.Equal(t, "Tagesordnungspunkt 1", reden[1].Tagesordnungspunkt)
+	assert.Equal(t, "Frau Präsidentin! Meine Damen und Herren! Die Lage ist ernst.\n(Beifall bei der SPD)", reden[1].Text)
+
+	assert.Equal(t, "Friedrich Merz", reden[2].Sprecher)
+	assert.Equal(t, "CDU/CSU", reden[2].Fraktion)
+	assert.Equal(t, "Herr Bundeskanzler, dazu habe ich folgende Frage:\nWann handeln Sie?", reden[2].Text)
+
+	assert.Equal(t, "Katrin Göring-Eckardt", reden[3].Sprecher)
+	assert.Equal(t, "Vizepräsidentin", reden[3].Rolle)
+
+	assert.Equal(t, "Dr. Anton Hofreiter", reden[4].Sprecher)
+	assert.Equal(t, "BÜNDNIS 90/DIE GRÜNEN", reden[4].Fraktion)
+	assert.Equal(t, "Zusatzpunkt 5 a", reden[4].Tagesordnungspunkt)
+	assert.Equal(t, "Wärmepumpen für alle!", reden[4].Text)
+
+	for i, rede := range reden {
+		assert.Equal(t, i, rede.Position)
+		// Offsets are in characters and cover speaker line and text
+		runes := []rune(protokollText)
+		segment := string(runes[rede.Start:rede.End])
+		assert.True(t, strings.HasPrefix(segment, rede.Sprecher) || strings.HasPrefix(segment, rede.Rolle), segment)
+		assert.Contains(t, segment, rede.Text)
+	}
+	assert.Equal(t, reden[0].End, reden[1].Start)
+}
+
+func TestParseReden_NoSessionMarkers(t *testing.T) {
+	reden := ParseReden("Präsident Dr. Norbert Lammert:\nDie Sitzung ist eröffnet.\nDas ist keine Rednerzeile, sagte er:\nWeiter geht es.")
+	require.Len(t, reden, 1)
+	assert.Equal(t, "Dr. Norbert Lammert", reden[0].Sprecher)
+	assert.Contains(t, reden[0].Text, "Weiter geht es.")
+}
+
+func TestParseSpeaker(t *testing.T) {
+	tests := []struct {
+		line string
+		want speaker
+		ok   bool
+	}{
+		{line: "Dr. Alice Weidel (AfD):", want: speaker{name: "Dr. Alice Weidel", fraktion: "AfD"}, ok: true},
+		{line: "Alterspräsident Dr. Wolfgang Schäuble:", want: speaker{name: "Dr. Wolfgang Schäuble", rolle: "Alterspräsident"}, ok: true},
+		{line: "Dr. Michael Meister, Parl. Staatssekretär beim Bundesminister der Finanzen:", want: speaker{name: "Dr. Michael Meister", rolle: "Parl. Staatssekretär beim Bundesminister der Finanzen"}, ok: true},
+		{line: "Winfried Kretschmann, Ministerpräsident (Baden-Württemberg):", want: speaker{name: "Winfried Kretschmann", rolle: "Ministerpräsident (Baden-Württemberg)"}, ok: true},
+		{line: "Karl-Theodor zu Guttenberg (CDU/CSU):", want: speaker{name: "Karl-Theodor zu Guttenberg", fraktion: "CDU/CSU"}, ok: true},
+		{line: "Ich rufe den Tagesordnungspunkt 1 auf:", ok: false},
+		{line: "Das zeigt die Statistik (Tabelle 3):", ok: false},
+		{line: "Friedrich Merz (CDU/CSU)", ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := parseSpeaker(tt.line)
+		assert.Equal(t, tt.ok, ok, tt.line)
+		assert.Equal(t, tt.want, got, tt.line)
+	}
+}
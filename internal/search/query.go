@@ -0,0 +1,141 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// germanSuffixes are stripped by stem, longest first. The replacement keeps
+// the word stem, e.g. "Regelungen" -> "regelung".
+var germanSuffixes = []struct{ suffix, replacement string }{
+	{"ungen", "ung"},
+	{"innen", "in"},
+	{"ern", ""},
+	{"en", ""},
+	{"er", ""},
+	{"es", ""},
+	{"em", ""},
+	{"e", ""},
+	{"n", ""},
+	{"s", ""},
+}
+
+// minStemLength keeps short words like "Bund" from being cut down to a prefix that matches everything
+const minStemLength = 4
+
+// term is a word or quoted phrase of a search query
+type term struct {
+	text   string
+	phrase bool
+	prefix bool
+}
+
+// parseQuery splits a query into words and "quoted phrases". A trailing * marks a prefix search.
+func parseQuery(query string) []term {
+	var terms []term
+	rest := strings.TrimSpace(query)
+	for rest != "" {
+		if rest[0] == '"' {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			if phrase := strings.TrimSpace(rest[1 : end+1]); phrase != "" {
+				terms = append(terms, term{text: strings.ToLower(phrase), phrase: true})
+			}
+			rest = strings.TrimSpace(rest[min(end+2, len(rest)):])
+			continue
+		}
+
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		if end < 0 {
+			end = len(rest)
+		}
+		word := rest[:end]
+		rest = strings.TrimSpace(rest[end:])
+
+		t := term{}
+		if strings.HasSuffix(word, "*") {
+			t.prefix = true
+			word = strings.TrimRight(word, "*")
+		}
+		t.text = strings.ToLower(strings.Trim(word, `"`))
+		if t.text != "" {
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
+
+// matchExpression builds the FTS5 MATCH expression of a query. All terms must
+// match. Each term is quoted, so FTS5 operators in the input are searched as text.
+// With stemming, words are reduced to their stem and searched as prefix.
+func matchExpression(query string, stemming bool) (string, error) {
+	terms := parseQuery(query)
+	if len(terms) == 0 {
+		return "", fmt.Errorf("empty search query")
+	}
+
+	parts := make([]string, 0, len(terms))
+	for _, t := range terms {
+		if stemming && !t.phrase && !t.prefix {
+			t.text = stem(t.text)
+			t.prefix = true
+		}
+
+		var alternatives []string
+		for _, variant := range spellingVariants(t.text) {
+			alternative := `"` + strings.ReplaceAll(variant, `"`, `""`) + `"`
+			if t.prefix {
+				alternative += "*"
+			}
+			alternatives = append(alternatives, alternative)
+		}
+		if len(alternatives) == 1 {
+			parts = append(parts, alternatives[0])
+		} else {
+			parts = append(parts, "("+strings.Join(alternatives, " OR ")+")")
+		}
+	}
+	return strings.Join(parts, " AND "), nil
+}
+
+// spellingVariants returns the lower-case term together with its alternative
+// German spellings: "ae", "oe", "ue" for umlauts and "ss" for "ß" and vice versa.
+// Umlauts themselves are folded by the tokenizer and need no variant.
+func spellingVariants(text string) []string {
+	umlauts := strings.NewReplacer("ae", "ä", "oe", "ö", "ue", "ü")
+	candidates := []string{
+		text,
+		umlauts.Replace(text),
+		strings.ReplaceAll(text, "ß", "ss"),
+		strings.ReplaceAll(text, "ss", "ß"),
+		strings.ReplaceAll(umlauts.Replace(text), "ss", "ß"),
+	}
+
+	var variants []string
+	seen := make(map[string]bool, len(candidates))
+	for _, candidate := range candidates {
+		if !seen[candidate] {
+			seen[candidate] = true
+			variants = append(variants, candidate)
+		}
+	}
+	return variants
+}
+
+// stem removes a common German inflection suffix from a lower-case word
+func stem(word string) string {
+	for _, s := range germanSuffixes {
+		if !strings.HasSuffix(word, s.suffix) {
+			continue
+		}
+		stemmed := strings.TrimSuffix(word, s.suffix) + s.replacement
+		if utf8.RuneCountInString(stemmed) >= minStemLength {
+			return stemmed
+		}
+	}
+	return word
+}
@@ -0,0 +1,210 @@
+// Package search runs full-text queries over the Drucksache and Plenarprotokoll
+// texts of a SQLite database. The FTS5 index is created by migration
+// 0016_fulltext_search and kept up to date by triggers, so every synced text
+// is searchable without an extra indexing step.
+package search
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Dokumentart values of a Hit
+const (
+	Drucksache      = "Drucksache"
+	Plenarprotokoll = "Plenarprotokoll"
+)
+
+// Markers around the matched terms in Hit.Snippet
+const (
+	HighlightStart = "»"
+	HighlightEnd   = "«"
+)
+
+// DefaultLimit is the number of hits returned if Options.Limit is not set
+const DefaultLimit = 20
+
+// snippetTokens is the length of a snippet in tokens (FTS5 allows at most 64)
+const snippetTokens = 32
+
+// Options controls a search
+type Options struct {
+	Query       string // words and "quoted phrases", all must match; word* searches a prefix
+	Dokumentart string // Drucksache, Plenarprotokoll or empty for both
+	Wahlperiode int    // 0 = all
+	Limit       int    // 0 = DefaultLimit
+	Stemming    bool   // also match other inflections of the words ("Gesetzes" finds "Gesetz", "Gesetze")
+}
+
+// Hit is a document that matches the query
+type Hit struct {
+	Dokumentart    string    `json:"dokumentart"`
+	ID             string    `json:"id"`
+	Dokumentnummer string    `json:"dokumentnummer"`
+	Titel          string    `json:"titel"`
+	Datum          string    `json:"datum"`
+	Wahlperiode    int       `json:"wahlperiode,omitempty"`
+	Drucksachetyp  string    `json:"drucksachetyp,omitempty"`
+	PdfURL         string    `json:"pdf_url,omitempty"`
+	Score          float64   `json:"score"`   // BM25 relevance, higher is better; comparable within a Dokumentart only
+	Rank           int       `json:"rank"`    // position among the hits of the same Dokumentart, starting at 1
+	Snippet        string    `json:"snippet"` // text around the matches, see HighlightStart
+	Vorgaenge      []Vorgang `json:"vorgaenge,omitempty"`
+}
+
+// Vorgang is a Vorgang the hit document belongs to
+type Vorgang struct {
+	ID          string `json:"id"`
+	Titel       string `json:"titel"`
+	Vorgangstyp string `json:"vorgangstyp"`
+}
+
+// source describes the tables of a searchable document type
+type source struct {
+	dokumentart   string
+	ftsTable      string
+	table         string
+	drucksachetyp string // column expression
+	bezugTable    string
+	bezugColumn   string
+}
+
+var sources = []source{
+	{
+		dokumentart:   Drucksache,
+		ftsTable:      "drucksache_text_fts",
+		table:         "drucksache",
+		drucksachetyp: "d.drucksachetyp",
+		bezugTable:    "drucksache_vorgangsbezug",
+		bezugColumn:   "drucksache_id",
+	},
+	{
+		dokumentart:   Plenarprotokoll,
+		ftsTable:      "plenarprotokoll_text_fts",
+		table:         "plenarprotokoll",
+		drucksachetyp: "NULL",
+		bezugTable:    "plenarprotokoll_vorgangsbezug",
+		bezugColumn:   "plenarprotokoll_id",
+	},
+}
+
+func sourceOf(dokumentart string) source {
+	for _, src := range sources {
+		if src.dokumentart == dokumentart {
+			return src
+		}
+	}
+	panic("search: unknown dokumentart " + dokumentart)
+}
+
+// Search returns the documents matching the query, best matches first.
+// Each document type has its own FTS5 index, and BM25 scores depend on the term
+// statistics of the index, so hits of both types are merged by score only
+// approximately. Hit.Rank is the exact order within a type; set
+// Options.Dokumentart to rank a single type.
+func Search(ctx context.Context, sqlDB *sql.DB, opts Options) ([]Hit, error) {
+	match, err := matchExpression(opts.Query, opts.Stemming)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultLimit
+	}
+
+	var hits []Hit
+	found := false
+	for _, src := range sources {
+		if opts.Dokumentart != "" && !strings.EqualFold(opts.Dokumentart, src.dokumentart) {
+			continue
+		}
+		found = true
+		sourceHits, err := searchSource(ctx, sqlDB, src, match, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search %s texts: %w", src.dokumentart, err)
+		}
+		hits = append(hits, sourceHits...)
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown dokumentart %q (use %s or %s)", opts.Dokumentart, Drucksache, Plenarprotokoll)
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	if len(hits) > opts.Limit {
+		hits = hits[:opts.Limit]
+	}
+
+	for i := range hits {
+		if hits[i].Vorgaenge, err = loadVorgaenge(ctx, sqlDB, sourceOf(hits[i].Dokumentart), hits[i].ID); err != nil {
+			return nil, fmt.Errorf("failed to load Vorgänge of %s %s: %w", hits[i].Dokumentart, hits[i].ID, err)
+		}
+	}
+	return hits, nil
+}
+
+func searchSource(ctx context.Context, sqlDB *sql.DB, src source, match string, opts Options) ([]Hit, error) {
+	query := fmt.Sprintf(`
+		SELECT d.id, d.dokumentnummer, d.titel, d.datum, d.wahlperiode, %s, d.fundstelle_pdf_url,
+		       -bm25(%s), snippet(%s, 0, ?, ?, '…', ?)
+		FROM %s f
+		JOIN %s d ON d.id = CAST(f.rowid AS TEXT)
+		WHERE %s MATCH ?
+		  AND d.deleted_at IS NULL
+		  AND (? = 0 OR d.wahlperiode = ?)
+		ORDER BY bm25(%s)
+		LIMIT ?`,
+		src.drucksachetyp, src.ftsTable, src.ftsTable, src.ftsTable, src.table, src.ftsTable, src.ftsTable)
+
+	rows, err := sqlDB.QueryContext(ctx, query,
+		HighlightStart, HighlightEnd, snippetTokens, match, opts.Wahlperiode, opts.Wahlperiode, opts.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []Hit
+	for rows.Next() {
+		hit := Hit{Dokumentart: src.dokumentart}
+		var (
+			wahlperiode   sql.NullInt64
+			drucksachetyp sql.NullString
+			pdfURL        sql.NullString
+		)
+		if err := rows.Scan(&hit.ID, &hit.Dokumentnummer, &hit.Titel, &hit.Datum, &wahlperiode,
+			&drucksachetyp, &pdfURL, &hit.Score, &hit.Snippet); err != nil {
+			return nil, err
+		}
+		hit.Wahlperiode = int(wahlperiode.Int64)
+		hit.Drucksachetyp = drucksachetyp.String
+		hit.PdfURL = pdfURL.String
+		hit.Rank = len(hits) + 1
+		hits = append(hits, hit)
+	}
+	return hits, rows.Err()
+}
+
+func loadVorgaenge(ctx context.Context, sqlDB *sql.DB, src source, id string) ([]Vorgang, error) {
+	query := fmt.Sprintf(`
+		SELECT vorgang_id, titel, vorgangstyp
+		FROM %s
+		WHERE %s = ?
+		ORDER BY display_order`, src.bezugTable, src.bezugColumn)
+
+	rows, err := sqlDB.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var vorgaenge []Vorgang
+	for rows.Next() {
+		var v Vorgang
+		if err := rows.Scan(&v.ID, &v.Titel, &v.Vorgangstyp); err != nil {
+			return nil, err
+		}
+		vorgaenge = append(vorgaenge, v)
+	}
+	return vorgaenge, rows.Err()
+}
@@ -0,0 +1,91 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	"github.com/Johanneslueke/dip-client/internal/store/storetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchExpression(t *testing.T) {
+	tests := []struct {
+		query    string
+		stemming bool
+		want     string
+	}{
+		{query: "Wärmeplanung", want: `"wärmeplanung"`},
+		{query: "Waermeplanung Kommunen", want: `("waermeplanung" OR "wärmeplanung") AND "kommunen"`},
+		{query: "Straße", want: `("straße" OR "strasse")`},
+		{query: `"grüne Energie" Wärme*`, want: `"grüne energie" AND "wärme"*`},
+		{query: "Gesetzes Bund", stemming: true, want: `"gesetz"* AND "bund"*`},
+		{query: "Regelungen", stemming: true, want: `"regelung"*`},
+		{query: `a"b OR c`, want: `"a""b" AND "or" AND "c"`},
+	}
+	for _, tt := range tests {
+		got, err := matchExpression(tt.query, tt.stemming)
+		require.NoError(t, err, tt.query)
+		assert.Equal(t, tt.want, got, tt.query)
+	}
+
+	_, err := matchExpression("  ", false)
+	assert.Error(t, err)
+}
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.Open(t)
+	drucksache := storetest.Drucksache(t)
+	require.NoError(t, s.UpsertDrucksache(ctx, drucksache))
+
+	text := "Die Länder stellen sicher, dass für ihr Hoheitsgebiet Wärmepläne erstellt werden."
+	require.NoError(t, s.UpsertDrucksacheText(ctx, client.DrucksacheText{Id: drucksache.Id, Text: &text}))
+
+	hits, err := Search(ctx, s.DB(), Options{Query: "Waermeplaene Laender"})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, Drucksache, hits[0].Dokumentart)
+	assert.Equal(t, "20/8654", hits[0].Dokumentnummer)
+	assert.Equal(t, 20, hits[0].Wahlperiode)
+	assert.Equal(t, 1, hits[0].Rank)
+	assert.Contains(t, hits[0].Snippet, "»Wärmepläne«")
+	assert.Equal(t, []Vorgang{{ID: "303271", Titel: "Wärmeplanungsgesetz", Vorgangstyp: "Gesetzgebung"}}, hits[0].Vorgaenge)
+
+	hits, err = Search(ctx, s.DB(), Options{Query: "Wärmeplan"})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+
+	hits, err = Search(ctx, s.DB(), Options{Query: "Wärmeplan", Stemming: true})
+	require.NoError(t, err)
+	assert.Len(t, hits, 1)
+
+	hits, err = Search(ctx, s.DB(), Options{Query: "Länder", Wahlperiode: 19})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+
+	// Updated texts replace the indexed text
+	text = "Der Text wurde ersetzt."
+	require.NoError(t, s.UpsertDrucksacheText(ctx, client.DrucksacheText{Id: drucksache.Id, Text: &text}))
+
+	hits, err = Search(ctx, s.DB(), Options{Query: "Länder"})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+
+	hits, err = Search(ctx, s.DB(), Options{Query: "ersetzt", Dokumentart: "drucksache"})
+	require.NoError(t, err)
+	assert.Len(t, hits, 1)
+
+	// The index matches the text table after updates and follows its deletes
+	_, err = s.DB().ExecContext(ctx, `INSERT INTO drucksache_text_fts (drucksache_text_fts, rank) VALUES ('integrity-check', 1)`)
+	require.NoError(t, err)
+	_, err = s.DB().ExecContext(ctx, `DELETE FROM drucksache_text WHERE id = ?`, drucksache.Id)
+	require.NoError(t, err)
+	hits, err = Search(ctx, s.DB(), Options{Query: "ersetzt"})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+
+	_, err = Search(ctx, s.DB(), Options{Query: "ersetzt", Dokumentart: "Vorgang"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,137 @@
+package sprecher
+
+import (
+	"strings"
+)
+
+// titles are dropped from speaker names before matching (lower case)
+var titles = map[string]bool{
+	"dr.": true, "prof.": true, "h.": true, "c.": true, "h.c.": true, "dr.-ing.": true,
+	"dipl.-ing.": true, "med.": true, "phil.": true, "rer.": true, "nat.": true,
+	"jur.": true, "pol.": true, "oec.": true, "mult.": true, "e.": true, "mdb": true,
+}
+
+// adelstitel are part of the name in the protocol, but stored separately in the MdB Stammdaten
+var adelstitel = map[string]bool{
+	"freiherr": true, "freifrau": true, "graf": true, "gräfin": true, "baron": true,
+	"baronin": true, "prinz": true, "prinzessin": true, "ritter": true, "edler": true,
+}
+
+// particles separate Vorname and Nachname ("Karl-Theodor zu Guttenberg")
+var particles = map[string]bool{
+	"von": true, "vom": true, "van": true, "de": true, "der": true, "den": true,
+	"zu": true, "zur": true, "und": true, "di": true, "da": true, "del": true,
+	"la": true, "le": true, "ten": true,
+}
+
+// fraktionAliases groups the spellings of a Fraktion in protocols, DIP person
+// roles and MdB Stammdaten (folded, see fold)
+var fraktionAliases = [][]string{
+	{"cdu/csu", "cdu", "csu", "christlich demokratisch", "christlich-demokratisch", "christlich - sozial", "christlich-sozial"},
+	{"spd", "sozialdemokratisch"},
+	{"fdp", "f.d.p.", "freie demokratische", "freien demokratischen"},
+	{"buendnis 90/die gruenen", "gruenen", "gruene", "buendnis 90"},
+	{"die linke", "linke", "pds"},
+	{"afd", "alternative fuer deutschland"},
+	{"bsw", "buendnis sahra wagenknecht"},
+}
+
+var folder = strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss", "é", "e", "è", "e", "á", "a", "ó", "o", "ç", "c")
+
+// fold lower-cases a name and replaces umlauts and accents
+func fold(s string) string {
+	return folder.Replace(strings.ToLower(strings.TrimSpace(s)))
+}
+
+// splitName splits a speaker name into Vorname and Nachname, without titles
+func splitName(name string) (vorname, nachname string) {
+	var words []string
+	for _, word := range strings.Fields(name) {
+		lower := strings.ToLower(word)
+		if titles[lower] || adelstitel[lower] {
+			continue
+		}
+		words = append(words, word)
+	}
+	if len(words) == 0 {
+		return "", ""
+	}
+
+	nachname = words[len(words)-1]
+	end := len(words) - 1
+	for i, word := range words[:end] {
+		if particles[strings.ToLower(word)] {
+			end = i
+			break
+		}
+	}
+	return strings.Join(words[:end], " "), nachname
+}
+
+// nachnameKey is the lookup key of a Nachname: its last word, folded
+// ("von der Leyen" and "Leyen" have the same key)
+func nachnameKey(nachname string) string {
+	words := strings.Fields(fold(nachname))
+	if len(words) == 0 {
+		return ""
+	}
+	return words[len(words)-1]
+}
+
+// vornameScore compares the Vorname of a speaker with the Vorname of a candidate
+func vornameScore(sprecher, kandidat string) float64 {
+	s := strings.Fields(strings.ReplaceAll(fold(sprecher), "-", " "))
+	k := strings.Fields(strings.ReplaceAll(fold(kandidat), "-", " "))
+	switch {
+	case len(s) == 0:
+		return 0.5
+	case len(k) == 0:
+		return 0
+	case strings.Join(s, " ") == strings.Join(k, " "):
+		return 1
+	case s[0] == k[0]:
+		return 0.8
+	}
+	for _, sw := range s {
+		for _, kw := range k {
+			if sw == kw {
+				return 0.6
+			}
+		}
+	}
+	// Initial, e.g. "H. Fuchtel"
+	if len(s[0]) == 2 && strings.HasSuffix(s[0], ".") && strings.HasPrefix(k[0], s[0][:1]) {
+		return 0.5
+	}
+	return 0
+}
+
+// fraktionMatches reports whether a Fraktion of a speaker line and a Fraktion
+// or party name of a data source denote the same Fraktion
+func fraktionMatches(sprecherFraktion, name string) bool {
+	s, n := fold(sprecherFraktion), fold(name)
+	if s == "" || n == "" {
+		return false
+	}
+	if s == n {
+		return true
+	}
+	for _, aliases := range fraktionAliases {
+		if !containsAny(s, aliases) {
+			continue
+		}
+		if containsAny(n, aliases) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(s string, substrings []string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,427 @@
+// Package sprecher resolves the speakers of parsed Reden ("Dr. Alice Weidel", AfD,
+// 2023-10-02) to DIP persons (person.id) and MdB Stammdaten (mdb_person.id).
+//
+// Candidates are found by Nachname in mdb_name, including earlier names of a
+// member, and in person. Each candidate is scored by how well Vorname,
+// membership in the Bundestag at the date of the session and Fraktion fit.
+// MdB and DIP candidates of the same person (see person_mdb_link) are merged.
+package sprecher
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/Johanneslueke/dip-client/internal/database/gen/sqlite"
+)
+
+// DefaultMinConfidence is the confidence a speaker needs to be assigned to a person
+const DefaultMinConfidence = 0.7
+
+// Methods of a Result
+const (
+	MethodMdbName    = "mdb_name"
+	MethodPersonName = "person_name"
+	MethodBoth       = "mdb_name+person_name"
+	MethodManual     = "manual"
+)
+
+// Score weights. Criteria that cannot be checked (no Fraktion in the speaker
+// line, unknown date) are left out and the score is scaled to the remaining weights.
+const (
+	weightNachname = 0.4
+	weightVorname  = 0.3
+	weightAktiv    = 0.15
+	weightFraktion = 0.15
+
+	// penalty for a name the member did not have at the date of the session
+	penaltyOutdatedName = 0.1
+	// bonus if MdB Stammdaten and DIP person agree on the same person
+	bonusBothSources = 0.05
+	// best candidates closer than this are ambiguous
+	ambiguityMargin = 0.05
+	// number of candidates kept in a Result for review
+	maxKandidaten = 3
+)
+
+// mdbDateLayout is the date format of the MdB Stammdaten
+const mdbDateLayout = "02.01.2006"
+
+// Input is a speaker of a Rede
+type Input struct {
+	Sprecher    string    // name including titles, e.g. "Dr. Alice Weidel"
+	Rolle       string    // e.g. "Präsidentin"
+	Fraktion    string    // e.g. "AfD"
+	Datum       time.Time // date of the session, zero if unknown
+	Wahlperiode int       // 0 if unknown
+}
+
+// Kandidat is a possible person for a speaker
+type Kandidat struct {
+	PersonID   string  `json:"person_id,omitempty"`
+	MdbID      string  `json:"mdb_id,omitempty"`
+	Name       string  `json:"name"`
+	Confidence float64 `json:"confidence"`
+	Methode    string  `json:"methode"`
+}
+
+// Result is the outcome of resolving a speaker
+type Result struct {
+	PersonID   string
+	MdbID      string
+	Confidence float64 // of the best candidate, reduced if the best candidates are ambiguous
+	Methode    string
+	Resolved   bool       // Confidence reached the minimum; PersonID and/or MdbID are set
+	Kandidaten []Kandidat // best candidates, best first
+}
+
+type period struct {
+	von, bis time.Time // zero = open
+}
+
+func (p period) contains(t time.Time) bool {
+	return (p.von.IsZero() || !t.Before(p.von)) && (p.bis.IsZero() || !t.After(p.bis))
+}
+
+type mdbName struct {
+	mdbID    string
+	vorname  string
+	nachname string
+	gueltig  period
+}
+
+type mdbFraktion struct {
+	wahlperiode int
+	name        string
+	zeitraum    period
+}
+
+type dipPerson struct {
+	id           string
+	vorname      string
+	nachname     string
+	wahlperioden map[int]bool
+	fraktionen   []string
+}
+
+type decision struct {
+	personID, mdbID string
+}
+
+// Resolver resolves speakers against the persons and MdB Stammdaten loaded by NewResolver
+type Resolver struct {
+	minConfidence float64
+
+	mdbByNachname      map[string][]mdbName
+	mdbMitgliedschaft  map[string][]period
+	mdbWahlperioden    map[string]map[int]bool
+	mdbFraktionen      map[string][]mdbFraktion
+	mdbPartei          map[string]string
+	personenByNachname map[string][]dipPerson
+	mdbToPerson        map[string]string
+	personToMdb        map[string]string
+	decisions          map[string]decision
+}
+
+// NewResolver loads persons, MdB Stammdaten, person_mdb_link and the decisions
+// of rede_sprecher_review into memory
+func NewResolver(ctx context.Context, q *db.Queries, minConfidence float64) (*Resolver, error) {
+	r := &Resolver{
+		minConfidence:      minConfidence,
+		mdbByNachname:      make(map[string][]mdbName),
+		mdbMitgliedschaft:  make(map[string][]period),
+		mdbWahlperioden:    make(map[string]map[int]bool),
+		mdbFraktionen:      make(map[string][]mdbFraktion),
+		mdbPartei:          make(map[string]string),
+		personenByNachname: make(map[string][]dipPerson),
+		mdbToPerson:        make(map[string]string),
+		personToMdb:        make(map[string]string),
+		decisions:          make(map[string]decision),
+	}
+
+	namen, err := q.ListSprecherMdbNamen(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load MdB names: %w", err)
+	}
+	for _, n := range namen {
+		name := mdbName{
+			mdbID:    n.MdbID,
+			vorname:  n.Vorname,
+			nachname: n.Nachname,
+			gueltig:  period{von: parseMdbDate(n.HistorieVon.String), bis: parseMdbDate(n.HistorieBis.String)},
+		}
+		key := nachnameKey(n.Nachname)
+		r.mdbByNachname[key] = append(r.mdbByNachname[key], name)
+		if n.ParteiKurz.Valid {
+			r.mdbPartei[n.MdbID] = n.ParteiKurz.String
+		}
+	}
+
+	mitgliedschaften, err := q.ListSprecherMdbMitgliedschaften(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load MdB memberships: %w", err)
+	}
+	for _, m := range mitgliedschaften {
+		r.mdbMitgliedschaft[m.MdbID] = append(r.mdbMitgliedschaft[m.MdbID],
+			period{von: parseMdbDate(m.MdbwpVon), bis: parseMdbDate(m.MdbwpBis.String)})
+		if r.mdbWahlperioden[m.MdbID] == nil {
+			r.mdbWahlperioden[m.MdbID] = make(map[int]bool)
+		}
+		r.mdbWahlperioden[m.MdbID][int(m.Wp)] = true
+	}
+
+	fraktionen, err := q.ListSprecherMdbFraktionen(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load MdB Fraktionen: %w", err)
+	}
+	for _, f := range fraktionen {
+		r.mdbFraktionen[f.MdbID] = append(r.mdbFraktionen[f.MdbID], mdbFraktion{
+			wahlperiode: int(f.Wp),
+			name:        f.InsLang,
+			zeitraum:    period{von: parseMdbDate(f.MdbinsVon.String), bis: parseMdbDate(f.MdbinsBis.String)},
+		})
+	}
+
+	personen, err := q.ListSprecherPersonen(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load persons: %w", err)
+	}
+	for _, p := range personen {
+		person := dipPerson{
+			id:           p.ID,
+			vorname:      p.Vorname,
+			nachname:     p.Nachname,
+			wahlperioden: make(map[int]bool),
+		}
+		for _, wp := range strings.Split(p.Wahlperioden, ",") {
+			if n, err := strconv.Atoi(wp); err == nil {
+				person.wahlperioden[n] = true
+			}
+		}
+		if p.Fraktionen != "" {
+			person.fraktionen = strings.Split(p.Fraktionen, ",")
+		}
+		key := nachnameKey(p.Nachname)
+		r.personenByNachname[key] = append(r.personenByNachname[key], person)
+	}
+
+	links, err := q.ListSprecherPersonMdbLinks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load person MdB links: %w", err)
+	}
+	// Links are ordered by confidence, the first link of a person wins
+	for _, link := range links {
+		if _, ok := r.mdbToPerson[link.MdbID]; !ok {
+			r.mdbToPerson[link.MdbID] = link.PersonID
+		}
+		if _, ok := r.personToMdb[link.PersonID]; !ok {
+			r.personToMdb[link.PersonID] = link.MdbID
+		}
+	}
+
+	entscheidungen, err := q.ListSprecherReviewEntscheidungen(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load review decisions: %w", err)
+	}
+	for _, e := range entscheidungen {
+		r.decisions[decisionKey(e.Sprecher, e.Fraktion)] = decision{personID: e.PersonID.String, mdbID: e.MdbID.String}
+	}
+
+	return r, nil
+}
+
+// Resolve finds the person of a speaker
+func (r *Resolver) Resolve(in Input) Result {
+	if d, ok := r.decisions[decisionKey(in.Sprecher, in.Fraktion)]; ok {
+		result := Result{PersonID: d.personID, MdbID: d.mdbID, Confidence: 1, Methode: MethodManual, Resolved: true}
+		if result.PersonID == "" {
+			result.PersonID = r.mdbToPerson[result.MdbID]
+		}
+		if result.MdbID == "" {
+			result.MdbID = r.personToMdb[result.PersonID]
+		}
+		return result
+	}
+
+	vorname, nachname := splitName(in.Sprecher)
+	key := nachnameKey(nachname)
+	candidates := make(map[string]*Kandidat)
+	add := func(k Kandidat) {
+		id := "person:" + k.PersonID
+		if k.MdbID != "" {
+			id = "mdb:" + k.MdbID
+		}
+		existing, ok := candidates[id]
+		if !ok {
+			candidates[id] = &k
+			return
+		}
+		if existing.Methode != k.Methode && existing.Methode != MethodBoth {
+			existing.Methode = MethodBoth
+			existing.Confidence = min(1, max(existing.Confidence, k.Confidence)+bonusBothSources)
+		} else {
+			existing.Confidence = max(existing.Confidence, k.Confidence)
+		}
+		if existing.PersonID == "" {
+			existing.PersonID = k.PersonID
+		}
+	}
+
+	for _, name := range r.mdbByNachname[key] {
+		score := r.scoreMdb(in, vorname, name)
+		add(Kandidat{
+			PersonID:   r.mdbToPerson[name.mdbID],
+			MdbID:      name.mdbID,
+			Name:       name.vorname + " " + name.nachname,
+			Confidence: score,
+			Methode:    MethodMdbName,
+		})
+	}
+	for _, person := range r.personenByNachname[key] {
+		score := r.scorePerson(in, vorname, person)
+		add(Kandidat{
+			PersonID:   person.id,
+			MdbID:      r.personToMdb[person.id],
+			Name:       person.vorname + " " + person.nachname,
+			Confidence: score,
+			Methode:    MethodPersonName,
+		})
+	}
+
+	ranked := make([]Kandidat, 0, len(candidates))
+	for _, k := range candidates {
+		k.Confidence = roundScore(k.Confidence)
+		ranked = append(ranked, *k)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Confidence != ranked[j].Confidence {
+			return ranked[i].Confidence > ranked[j].Confidence
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+	if len(ranked) > maxKandidaten {
+		ranked = ranked[:maxKandidaten]
+	}
+
+	result := Result{Kandidaten: ranked}
+	if len(ranked) == 0 {
+		return result
+	}
+	best := ranked[0]
+	result.Confidence = best.Confidence
+	if len(ranked) > 1 && best.Confidence-ranked[1].Confidence < ambiguityMargin {
+		result.Confidence = roundScore(best.Confidence / 2)
+	}
+	if result.Confidence >= r.minConfidence {
+		result.PersonID = best.PersonID
+		result.MdbID = best.MdbID
+		result.Methode = best.Methode
+		result.Resolved = true
+	}
+	return result
+}
+
+// scoreMdb scores an entry of mdb_name
+func (r *Resolver) scoreMdb(in Input, vorname string, name mdbName) float64 {
+	var s score
+	s.add(weightNachname, 1)
+	s.add(weightVorname, vornameScore(vorname, name.vorname))
+
+	switch {
+	case !in.Datum.IsZero():
+		aktiv := 0.0
+		for _, p := range r.mdbMitgliedschaft[name.mdbID] {
+			if p.contains(in.Datum) {
+				aktiv = 1
+			}
+		}
+		s.add(weightAktiv, aktiv)
+	case in.Wahlperiode > 0:
+		s.add(weightAktiv, boolScore(r.mdbWahlperioden[name.mdbID][in.Wahlperiode]))
+	}
+
+	if in.Fraktion != "" {
+		match := false
+		fraktionen := r.mdbFraktionen[name.mdbID]
+		for _, f := range fraktionen {
+			current := in.Datum.IsZero() && (in.Wahlperiode == 0 || f.wahlperiode == in.Wahlperiode) ||
+				!in.Datum.IsZero() && f.zeitraum.contains(in.Datum)
+			if current && fraktionMatches(in.Fraktion, f.name) {
+				match = true
+			}
+		}
+		if len(fraktionen) == 0 {
+			match = fraktionMatches(in.Fraktion, r.mdbPartei[name.mdbID])
+		}
+		s.add(weightFraktion, boolScore(match))
+	}
+
+	result := s.value()
+	if !in.Datum.IsZero() && !name.gueltig.contains(in.Datum) {
+		result -= penaltyOutdatedName
+	}
+	return max(0, result)
+}
+
+// scorePerson scores a DIP person
+func (r *Resolver) scorePerson(in Input, vorname string, person dipPerson) float64 {
+	var s score
+	s.add(weightNachname, 1)
+	s.add(weightVorname, vornameScore(vorname, person.vorname))
+	if in.Wahlperiode > 0 && len(person.wahlperioden) > 0 {
+		s.add(weightAktiv, boolScore(person.wahlperioden[in.Wahlperiode]))
+	}
+	if in.Fraktion != "" && len(person.fraktionen) > 0 {
+		match := false
+		for _, f := range person.fraktionen {
+			if fraktionMatches(in.Fraktion, f) {
+				match = true
+			}
+		}
+		s.add(weightFraktion, boolScore(match))
+	}
+	return s.value()
+}
+
+// score sums weighted criteria and scales the sum to the weights of the checked criteria
+type score struct {
+	sum, weights float64
+}
+
+func (s *score) add(weight, value float64) {
+	s.sum += weight * value
+	s.weights += weight
+}
+
+func (s score) value() float64 {
+	if s.weights == 0 {
+		return 0
+	}
+	return s.sum / s.weights
+}
+
+func boolScore(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func roundScore(f float64) float64 {
+	return float64(int(f*1000+0.5)) / 1000
+}
+
+func decisionKey(sprecher, fraktion string) string {
+	return strings.Join(strings.Fields(sprecher), " ") + "\x00" + fraktion
+}
+
+func parseMdbDate(s string) time.Time {
+	t, err := time.Parse(mdbDateLayout, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
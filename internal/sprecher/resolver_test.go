@@ -0,0 +1,153 @@
+package sprecher
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	db "github.com/Johanneslueke/dip-client/internal/database/gen/sqlite"
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixture = `
+INSERT INTO wahlperiode (nummer) VALUES (19), (20) ON CONFLICT DO NOTHING;
+
+INSERT INTO mdb_person (id) VALUES ('11004930'), ('11005002'), ('11003142');
+INSERT INTO mdb_name (mdb_id, nachname, vorname, historie_von, historie_bis) VALUES
+	('11004930', 'Weidel', 'Alice', '24.10.2017', NULL),
+	('11005002', 'Müller', 'Sepp', '24.10.2017', NULL),
+	('11003142', 'Müller', 'Stefan', '20.10.1998', '10.05.2010'),
+	('11003142', 'Müller-Lenz', 'Stefan', '11.05.2010', NULL);
+INSERT INTO mdb_wahlperiode_membership (id, mdb_id, wp, mdbwp_von, mdbwp_bis) VALUES
+	(1, '11004930', 19, '24.10.2017', '26.10.2021'),
+	(2, '11004930', 20, '26.10.2021', NULL),
+	(3, '11005002', 20, '26.10.2021', NULL),
+	(4, '11003142', 14, '26.10.1998', '17.10.2002');
+INSERT INTO mdb_institution_membership (mdb_wahlperiode_membership_id, insart_lang, ins_lang, mdbins_von, mdbins_bis) VALUES
+	(2, 'Fraktion/Gruppe', 'Fraktion der Alternative für Deutschland', '26.10.2021', NULL),
+	(3, 'Fraktion/Gruppe', 'Fraktion der Sozialdemokratischen Partei Deutschlands', '26.10.2021', NULL);
+
+INSERT INTO person (id, vorname, nachname, titel, typ, aktualisiert) VALUES
+	('7549', 'Alice', 'Weidel', 'Dr. Alice Weidel, MdB, AfD', 'Person', '2023-01-01'),
+	('8001', 'Klara', 'Geywitz', 'Klara Geywitz, Bundesministerin', 'Person', '2023-01-01');
+INSERT INTO person_wahlperiode (person_id, wahlperiode_nummer) VALUES ('7549', 20), ('8001', 20);
+INSERT INTO person_mdb_link (person_id, mdb_id, match_confidence, match_method) VALUES
+	('7549', '11004930', 'exact', 'name_exact');
+`
+
+func newTestResolver(t *testing.T) (*Resolver, store.Store) {
+	t.Helper()
+	ctx := context.Background()
+	s, err := store.Open(store.SQLite, filepath.Join(t.TempDir(), "dip.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+
+	_, err = s.DB().ExecContext(ctx, fixture)
+	require.NoError(t, err)
+
+	r, err := NewResolver(ctx, db.New(s.DB()), DefaultMinConfidence)
+	require.NoError(t, err)
+	return r, s
+}
+
+func TestResolve(t *testing.T) {
+	r, _ := newTestResolver(t)
+	datum := time.Date(2023, 10, 12, 0, 0, 0, 0, time.UTC)
+
+	result := r.Resolve(Input{Sprecher: "Dr. Alice Weidel", Fraktion: "AfD", Datum: datum, Wahlperiode: 20})
+	assert.True(t, result.Resolved)
+	assert.Equal(t, "7549", result.PersonID)
+	assert.Equal(t, "11004930", result.MdbID)
+	assert.Equal(t, MethodBoth, result.Methode)
+	assert.Equal(t, 1.0, result.Confidence)
+
+	// Fraktion decides between members with the same Nachname
+	result = r.Resolve(Input{Sprecher: "Sepp Müller", Fraktion: "SPD", Datum: datum, Wahlperiode: 20})
+	assert.True(t, result.Resolved)
+	assert.Equal(t, "11005002", result.MdbID)
+	assert.Equal(t, MethodMdbName, result.Methode)
+
+	// Speakers without Fraktion, e.g. members of the government, are found in DIP persons
+	result = r.Resolve(Input{Sprecher: "Klara Geywitz", Rolle: "Bundesministerin für Wohnen", Datum: datum, Wahlperiode: 20})
+	assert.True(t, result.Resolved)
+	assert.Equal(t, "8001", result.PersonID)
+	assert.Empty(t, result.MdbID)
+	assert.Equal(t, MethodPersonName, result.Methode)
+
+	// Only the Nachname matches: candidates are kept for review
+	result = r.Resolve(Input{Sprecher: "Hans Müller", Fraktion: "FDP", Datum: datum, Wahlperiode: 20})
+	assert.False(t, result.Resolved)
+	assert.Empty(t, result.PersonID)
+	assert.Empty(t, result.MdbID)
+	assert.NotEmpty(t, result.Kandidaten)
+	assert.Less(t, result.Confidence, DefaultMinConfidence)
+
+	result = r.Resolve(Input{Sprecher: "Unbekannt", Datum: datum})
+	assert.False(t, result.Resolved)
+	assert.Empty(t, result.Kandidaten)
+}
+
+func TestResolve_NameHistory(t *testing.T) {
+	r, _ := newTestResolver(t)
+
+	// Stefan Müller-Lenz was called Stefan Müller until 2010
+	result := r.Resolve(Input{Sprecher: "Stefan Müller", Datum: time.Date(2001, 3, 8, 0, 0, 0, 0, time.UTC), Wahlperiode: 14})
+	assert.True(t, result.Resolved)
+	assert.Equal(t, "11003142", result.MdbID)
+
+	// ... the old name is penalized after the change
+	later := r.Resolve(Input{Sprecher: "Stefan Müller", Datum: time.Date(2011, 3, 8, 0, 0, 0, 0, time.UTC)})
+	assert.Less(t, later.Confidence, result.Confidence)
+}
+
+func TestResolve_ManualDecision(t *testing.T) {
+	r, s := newTestResolver(t)
+	ctx := context.Background()
+	queries := db.New(s.DB())
+	in := Input{Sprecher: "Hans Müller", Fraktion: "FDP", Wahlperiode: 20}
+	require.False(t, r.Resolve(in).Resolved)
+
+	require.NoError(t, queries.UpsertSprecherReview(ctx, db.UpsertSprecherReviewParams{
+		Sprecher: in.Sprecher, Fraktion: in.Fraktion, Kandidaten: "[]",
+	}))
+	_, err := s.DB().ExecContext(ctx,
+		`UPDATE rede_sprecher_review SET mdb_id = '11005002' WHERE sprecher = ?`, in.Sprecher)
+	require.NoError(t, err)
+
+	r, err = NewResolver(ctx, queries, DefaultMinConfidence)
+	require.NoError(t, err)
+	result := r.Resolve(in)
+	assert.True(t, result.Resolved)
+	assert.Equal(t, MethodManual, result.Methode)
+	assert.Equal(t, "11005002", result.MdbID)
+	assert.Equal(t, 1.0, result.Confidence)
+}
+
+func TestSplitName(t *testing.T) {
+	tests := []struct {
+		name, vorname, nachname string
+	}{
+		{"Dr. Alice Weidel", "Alice", "Weidel"},
+		{"Prof. Dr. h. c. Thomas Heilmann", "Thomas", "Heilmann"},
+		{"Karl-Theodor Freiherr zu Guttenberg", "Karl-Theodor", "Guttenberg"},
+		{"Dr. Ursula von der Leyen", "Ursula", "Leyen"},
+		{"Weidel", "", "Weidel"},
+	}
+	for _, tt := range tests {
+		vorname, nachname := splitName(tt.name)
+		assert.Equal(t, tt.vorname, vorname, tt.name)
+		assert.Equal(t, tt.nachname, nachname, tt.name)
+	}
+}
+
+func TestFraktionMatches(t *testing.T) {
+	assert.True(t, fraktionMatches("BÜNDNIS 90/DIE GRÜNEN", "Fraktion BÜNDNIS 90/DIE GRÜNEN"))
+	assert.True(t, fraktionMatches("CDU/CSU", "Fraktion der Christlich Demokratischen Union/Christlich - Sozialen Union"))
+	assert.True(t, fraktionMatches("Die Linke", "Gruppe der PDS"))
+	assert.True(t, fraktionMatches("AfD", "AfD"))
+	assert.False(t, fraktionMatches("SPD", "Fraktion der Freien Demokratischen Partei"))
+	assert.False(t, fraktionMatches("", "SPD"))
+}
@@ -0,0 +1,691 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	pgdb "github.com/Johanneslueke/dip-client/internal/database/gen/postgres"
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+)
+
+// PostgreSQL stores dates and timestamps natively and integers as int4
+
+func nullInt64ToNullInt32(i sql.NullInt64) sql.NullInt32 {
+	if !i.Valid {
+		return sql.NullInt32{Valid: false}
+	}
+	return sql.NullInt32{Int32: int32(i.Int64), Valid: true}
+}
+
+func (s *postgresStore) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return runInTx(ctx, s.db, fn)
+}
+
+// inTx runs fn with the queries of a transaction; see InTx
+func (s *postgresStore) inTx(ctx context.Context, fn func(q *pgdb.Queries) error) error {
+	return s.InTx(ctx, func(ctx context.Context) error {
+		return fn(s.queries(ctx))
+	})
+}
+
+// queries returns the queries bound to the transaction of ctx, if there is one
+func (s *postgresStore) queries(ctx context.Context) *pgdb.Queries {
+	if tx := txFrom(ctx); tx != nil {
+		return s.q.WithTx(tx)
+	}
+	return s.q
+}
+
+// ensureWahlperiode creates the Wahlperiode row that entity rows reference.
+// Unlike SQLite, PostgreSQL enforces the foreign key during the sync.
+func ensureWahlperiode(ctx context.Context, q *pgdb.Queries, wp int32) error {
+	if _, err := q.GetOrCreateWahlperiode(ctx, wp); err != nil {
+		return fmt.Errorf("GetOrCreateWahlperiode %d: %w", wp, err)
+	}
+	return nil
+}
+
+func (s *postgresStore) UpsertPerson(ctx context.Context, person PersonWithArrayWahlperiode) error {
+	row := mapPerson(person)
+	return s.inTx(ctx, func(q *pgdb.Queries) error {
+		for _, wp := range row.Wahlperioden {
+			if err := ensureWahlperiode(ctx, q, wp); err != nil {
+				return err
+			}
+		}
+
+		if err := q.UpsertPerson(ctx, pgdb.UpsertPersonParams{
+			ID:           row.ID,
+			Vorname:      row.Vorname,
+			Nachname:     row.Nachname,
+			Namenszusatz: row.Namenszusatz,
+			Titel:        row.Titel,
+			Typ:          row.Typ,
+			Aktualisiert: row.Aktualisiert,
+			Basisdatum:   row.Basisdatum,
+			Datum:        row.Datum,
+		}); err != nil {
+			return fmt.Errorf("UpsertPerson: %w", err)
+		}
+		if err := deleteChildren(ctx, row.ID,
+			q.DeletePersonWahlperioden,
+			q.DeletePersonRoleWahlperioden,
+			q.DeletePersonRoles,
+		); err != nil {
+			return err
+		}
+
+		for _, wp := range row.Wahlperioden {
+			if err := q.CreatePersonWahlperiode(ctx, pgdb.CreatePersonWahlperiodeParams{
+				PersonID:          row.ID,
+				WahlperiodeNummer: wp,
+			}); err != nil {
+				return fmt.Errorf("CreatePersonWahlperiode %d: %w", wp, err)
+			}
+		}
+
+		for _, role := range row.Roles {
+			if role.Bundesland.Valid {
+				if _, err := q.GetOrCreateBundesland(ctx, role.Bundesland.String); err != nil {
+					return fmt.Errorf("GetOrCreateBundesland: %w", err)
+				}
+			}
+
+			personRole, err := q.CreatePersonRole(ctx, pgdb.CreatePersonRoleParams{
+				PersonID:        row.ID,
+				Funktion:        role.Funktion,
+				Funktionszusatz: role.Funktionszusatz,
+				Vorname:         role.Vorname,
+				Nachname:        role.Nachname,
+				Namenszusatz:    role.Namenszusatz,
+				Fraktion:        role.Fraktion,
+				Bundesland:      role.Bundesland,
+				RessortTitel:    role.RessortTitel,
+				Wahlkreiszusatz: role.Wahlkreiszusatz,
+			})
+			if err != nil {
+				return fmt.Errorf("CreatePersonRole: %w", err)
+			}
+
+			for _, wp := range role.Wahlperioden {
+				if err := ensureWahlperiode(ctx, q, wp); err != nil {
+					return err
+				}
+				if err := q.CreatePersonRoleWahlperiode(ctx, pgdb.CreatePersonRoleWahlperiodeParams{
+					PersonRoleID:      personRole.ID,
+					WahlperiodeNummer: wp,
+				}); err != nil {
+					return fmt.Errorf("CreatePersonRoleWahlperiode %d: %w", wp, err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *postgresStore) UpsertVorgang(ctx context.Context, vorgang client.Vorgang) error {
+	row := mapVorgang(vorgang)
+	return s.inTx(ctx, func(q *pgdb.Queries) error {
+		if err := ensureWahlperiode(ctx, q, row.Wahlperiode); err != nil {
+			return err
+		}
+		if err := q.UpsertVorgang(ctx, pgdb.UpsertVorgangParams{
+			ID:             row.ID,
+			Titel:          row.Titel,
+			Vorgangstyp:    row.Vorgangstyp,
+			Typ:            row.Typ,
+			Abstract:       row.Abstract,
+			Aktualisiert:   row.Aktualisiert,
+			Archiv:         row.Archiv,
+			Beratungsstand: row.Beratungsstand,
+			Datum:          row.Datum,
+			Gesta:          row.Gesta,
+			Kom:            row.Kom,
+			Mitteilung:     row.Mitteilung,
+			Ratsdok:        row.Ratsdok,
+			Sek:            row.Sek,
+			Wahlperiode:    row.Wahlperiode,
+		}); err != nil {
+			return fmt.Errorf("UpsertVorgang: %w", err)
+		}
+		if err := deleteChildren(ctx, row.ID,
+			q.DeleteVorgangInitiativen,
+			q.DeleteVorgangSachgebiete,
+			q.DeleteVorgangZustimmungsbeduerftigkeiten,
+			q.DeleteVorgangDeskriptoren,
+			q.DeleteVerkuendungen,
+			q.DeleteInkrafttreten,
+			q.DeleteVorgangVerlinkungen,
+		); err != nil {
+			return err
+		}
+
+		for _, initiative := range row.Initiativen {
+			if err := q.CreateVorgangInitiative(ctx, pgdb.CreateVorgangInitiativeParams{VorgangID: row.ID, Initiative: initiative}); err != nil {
+				return fmt.Errorf("CreateVorgangInitiative: %w", err)
+			}
+		}
+
+		for _, sachgebiet := range row.Sachgebiete {
+			if err := q.CreateVorgangSachgebiet(ctx, pgdb.CreateVorgangSachgebietParams{VorgangID: row.ID, Sachgebiet: sachgebiet}); err != nil {
+				return fmt.Errorf("CreateVorgangSachgebiet: %w", err)
+			}
+		}
+
+		for _, desk := range row.Deskriptoren {
+			if _, err := q.CreateVorgangDeskriptor(ctx, pgdb.CreateVorgangDeskriptorParams{
+				VorgangID:  row.ID,
+				Name:       desk.Name,
+				Typ:        desk.Typ,
+				Fundstelle: desk.Fundstelle,
+			}); err != nil {
+				return fmt.Errorf("CreateVorgangDeskriptor: %w", err)
+			}
+		}
+
+		for _, verk := range row.Verkuendungen {
+			if _, err := q.CreateVerkuendung(ctx, pgdb.CreateVerkuendungParams{
+				VorgangID:                    row.ID,
+				Ausfertigungsdatum:           verk.Ausfertigungsdatum,
+				Verkuendungsdatum:            verk.Verkuendungsdatum,
+				Einleitungstext:              verk.Einleitungstext,
+				Fundstelle:                   verk.Fundstelle,
+				Jahrgang:                     verk.Jahrgang,
+				Seite:                        verk.Seite,
+				Heftnummer:                   verk.Heftnummer,
+				PdfUrl:                       verk.PdfUrl,
+				RubrikNr:                     verk.RubrikNr,
+				Titel:                        verk.Titel,
+				VerkuendungsblattBezeichnung: verk.VerkuendungsblattBezeichnung,
+				VerkuendungsblattKuerzel:     verk.VerkuendungsblattKuerzel,
+			}); err != nil {
+				return fmt.Errorf("CreateVerkuendung: %w", err)
+			}
+		}
+
+		for _, ink := range row.Inkrafttreten {
+			if _, err := q.CreateInkrafttreten(ctx, pgdb.CreateInkrafttretenParams{
+				VorgangID:    row.ID,
+				Datum:        ink.Datum,
+				Erlaeuterung: ink.Erlaeuterung,
+			}); err != nil {
+				return fmt.Errorf("CreateInkrafttreten: %w", err)
+			}
+		}
+
+		for _, zust := range row.Zustimmungsbeduerftigkeit {
+			if err := q.CreateVorgangZustimmungsbeduerftigkeit(ctx, pgdb.CreateVorgangZustimmungsbeduerftigkeitParams{
+				VorgangID:                 row.ID,
+				Zustimmungsbeduerftigkeit: zust,
+			}); err != nil {
+				return fmt.Errorf("CreateVorgangZustimmungsbeduerftigkeit: %w", err)
+			}
+		}
+
+		for _, verlinkung := range row.Verlinkungen {
+			if err := ensureWahlperiode(ctx, q, verlinkung.Wahlperiode); err != nil {
+				return err
+			}
+			if _, err := q.CreateVorgangVerlinkung(ctx, pgdb.CreateVorgangVerlinkungParams{
+				SourceVorgangID: row.ID,
+				TargetVorgangID: verlinkung.TargetVorgangID,
+				Titel:           verlinkung.Titel,
+				Verweisung:      verlinkung.Verweisung,
+				Gesta:           verlinkung.Gesta,
+				Wahlperiode:     verlinkung.Wahlperiode,
+			}); err != nil {
+				return fmt.Errorf("CreateVorgangVerlinkung: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *postgresStore) createRessortLinks(ctx context.Context, q *pgdb.Queries, ressorts []ressortRow, link func(ressortID int32, federfuehrend bool) error) error {
+	for _, ressort := range ressorts {
+		record, err := q.GetOrCreateRessort(ctx, ressort.Titel)
+		if err != nil {
+			return fmt.Errorf("GetOrCreateRessort: %w", err)
+		}
+		if err := link(record.ID, ressort.Federfuehrend); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *postgresStore) createUrheberLinks(ctx context.Context, q *pgdb.Queries, urheber []urheberRow, link func(urheberID int32, u urheberRow) error) error {
+	for _, u := range urheber {
+		record, err := q.GetOrCreateUrheber(ctx, pgdb.GetOrCreateUrheberParams{Bezeichnung: u.Bezeichnung, Titel: u.Titel})
+		if err != nil {
+			return fmt.Errorf("GetOrCreateUrheber: %w", err)
+		}
+		if err := link(record.ID, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *postgresStore) UpsertVorgangsposition(ctx context.Context, vorgangsposition client.Vorgangsposition) error {
+	row := mapVorgangsposition(vorgangsposition)
+	fs := row.Fundstelle
+	return s.inTx(ctx, func(q *pgdb.Queries) error {
+		if err := q.UpsertVorgangsposition(ctx, pgdb.UpsertVorgangspositionParams{
+			ID:                        row.ID,
+			VorgangID:                 row.VorgangID,
+			Titel:                     row.Titel,
+			Vorgangsposition:          row.Vorgangsposition,
+			Vorgangstyp:               row.Vorgangstyp,
+			Typ:                       row.Typ,
+			Dokumentart:               row.Dokumentart,
+			Datum:                     row.Datum,
+			Aktualisiert:              row.Aktualisiert,
+			Abstract:                  row.Abstract,
+			Fortsetzung:               row.Fortsetzung,
+			Gang:                      row.Gang,
+			Nachtrag:                  row.Nachtrag,
+			AktivitaetAnzahl:          int32(row.AktivitaetAnzahl),
+			Kom:                       row.Kom,
+			Ratsdok:                   row.Ratsdok,
+			Sek:                       row.Sek,
+			Zuordnung:                 row.Zuordnung,
+			FundstelleDokumentnummer:  fs.Dokumentnummer,
+			FundstelleDatum:           fs.Datum,
+			FundstelleDokumentart:     fs.Dokumentart,
+			FundstelleHerausgeber:     fs.Herausgeber,
+			FundstelleID:              fs.ID,
+			FundstelleDrucksachetyp:   fs.Drucksachetyp,
+			FundstelleAnlagen:         fs.Anlagen,
+			FundstelleAnfangsseite:    nullInt64ToNullInt32(fs.Anfangsseite),
+			FundstelleEndseite:        nullInt64ToNullInt32(fs.Endseite),
+			FundstelleAnfangsquadrant: fs.Anfangsquadrant,
+			FundstelleEndquadrant:     fs.Endquadrant,
+			FundstelleSeite:           fs.Seite,
+			FundstellePdfUrl:          fs.PdfUrl,
+			FundstelleXmlUrl:          fs.XmlUrl,
+			FundstelleTop:             nullInt64ToNullInt32(fs.Top),
+			FundstelleTopZusatz:       fs.TopZusatz,
+			FundstelleFrageNummer:     fs.FrageNummer,
+			FundstelleVerteildatum:    fs.Verteildatum,
+		}); err != nil {
+			return fmt.Errorf("UpsertVorgangsposition: %w", err)
+		}
+		if err := deleteChildren(ctx, row.ID,
+			q.DeleteAktivitaetAnzeigen,
+			q.DeleteBeschlussfassungen,
+			q.DeleteVorgangspositionRessorts,
+			q.DeleteVorgangspositionUrheber,
+			q.DeleteUeberweisungen,
+			q.DeleteVorgangspositionMitberaten,
+		); err != nil {
+			return err
+		}
+
+		for idx, aktivitaet := range row.AktivitaetAnzeige {
+			if _, err := q.CreateAktivitaetAnzeige(ctx, pgdb.CreateAktivitaetAnzeigeParams{
+				VorgangspositionID: row.ID,
+				Aktivitaetsart:     aktivitaet.Aktivitaetsart,
+				Titel:              aktivitaet.Titel,
+				Seite:              aktivitaet.Seite,
+				PdfUrl:             aktivitaet.PdfUrl,
+				DisplayOrder:       int32(idx),
+			}); err != nil {
+				return fmt.Errorf("CreateAktivitaetAnzeige: %w", err)
+			}
+		}
+
+		for _, beschluss := range row.Beschlussfassung {
+			if _, err := q.CreateBeschlussfassung(ctx, pgdb.CreateBeschlussfassungParams{
+				VorgangspositionID:       row.ID,
+				Beschlusstenor:           beschluss.Beschlusstenor,
+				Abstimmungsart:           beschluss.Abstimmungsart,
+				Mehrheit:                 beschluss.Mehrheit,
+				AbstimmErgebnisBemerkung: beschluss.AbstimmErgebnisBemerkung,
+				Dokumentnummer:           beschluss.Dokumentnummer,
+				Grundlage:                beschluss.Grundlage,
+				Seite:                    beschluss.Seite,
+			}); err != nil {
+				return fmt.Errorf("CreateBeschlussfassung: %w", err)
+			}
+		}
+
+		if err := s.createRessortLinks(ctx, q, row.Ressorts, func(ressortID int32, federfuehrend bool) error {
+			if err := q.CreateVorgangspositionRessort(ctx, pgdb.CreateVorgangspositionRessortParams{
+				VorgangspositionID: row.ID,
+				RessortID:          ressortID,
+				Federfuehrend:      federfuehrend,
+			}); err != nil {
+				return fmt.Errorf("CreateVorgangspositionRessort: %w", err)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if err := s.createUrheberLinks(ctx, q, row.Urheber, func(urheberID int32, u urheberRow) error {
+			if err := q.CreateVorgangspositionUrheber(ctx, pgdb.CreateVorgangspositionUrheberParams{
+				VorgangspositionID: row.ID,
+				UrheberID:          urheberID,
+				Rolle:              u.Rolle,
+				Einbringer:         u.Einbringer,
+			}); err != nil {
+				return fmt.Errorf("CreateVorgangspositionUrheber: %w", err)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, ueberweisung := range row.Ueberweisungen {
+			if _, err := q.CreateUeberweisung(ctx, pgdb.CreateUeberweisungParams{
+				VorgangspositionID: row.ID,
+				Ausschuss:          ueberweisung.Ausschuss,
+				AusschussKuerzel:   ueberweisung.AusschussKuerzel,
+				Federfuehrung:      ueberweisung.Federfuehrung,
+				Ueberweisungsart:   ueberweisung.Ueberweisungsart,
+			}); err != nil {
+				return fmt.Errorf("CreateUeberweisung: %w", err)
+			}
+		}
+
+		for _, mitberaten := range row.Mitberaten {
+			if err := q.CreateVorgangspositionMitberaten(ctx, pgdb.CreateVorgangspositionMitberatenParams{
+				VorgangspositionID:         row.ID,
+				MitberatenVorgangID:        mitberaten.VorgangID,
+				MitberatenTitel:            mitberaten.Titel,
+				MitberatenVorgangsposition: mitberaten.Vorgangsposition,
+				MitberatenVorgangstyp:      mitberaten.Vorgangstyp,
+			}); err != nil {
+				return fmt.Errorf("CreateVorgangspositionMitberaten: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *postgresStore) UpsertAktivitaet(ctx context.Context, aktivitaet client.Aktivitaet) error {
+	row := mapAktivitaet(aktivitaet)
+	fs := row.Fundstelle
+	return s.inTx(ctx, func(q *pgdb.Queries) error {
+		if err := ensureWahlperiode(ctx, q, row.Wahlperiode); err != nil {
+			return err
+		}
+		if err := q.UpsertAktivitaet(ctx, pgdb.UpsertAktivitaetParams{
+			ID:                        row.ID,
+			Titel:                     row.Titel,
+			Aktivitaetsart:            row.Aktivitaetsart,
+			Typ:                       row.Typ,
+			Dokumentart:               row.Dokumentart,
+			Datum:                     row.Datum,
+			Aktualisiert:              row.Aktualisiert,
+			Abstract:                  row.Abstract,
+			VorgangsbezugAnzahl:       int32(row.VorgangsbezugAnzahl),
+			Wahlperiode:               row.Wahlperiode,
+			FundstelleDokumentnummer:  fs.Dokumentnummer,
+			FundstelleDatum:           fs.Datum,
+			FundstelleDokumentart:     fs.Dokumentart,
+			FundstelleHerausgeber:     fs.Herausgeber,
+			FundstelleID:              fs.ID,
+			FundstelleDrucksachetyp:   fs.Drucksachetyp,
+			FundstelleAnlagen:         fs.Anlagen,
+			FundstelleAnfangsseite:    nullInt64ToNullInt32(fs.Anfangsseite),
+			FundstelleEndseite:        nullInt64ToNullInt32(fs.Endseite),
+			FundstelleAnfangsquadrant: fs.Anfangsquadrant,
+			FundstelleEndquadrant:     fs.Endquadrant,
+			FundstelleSeite:           fs.Seite,
+			FundstellePdfUrl:          fs.PdfUrl,
+			FundstelleXmlUrl:          fs.XmlUrl,
+			FundstelleTop:             nullInt64ToNullInt32(fs.Top),
+			FundstelleTopZusatz:       fs.TopZusatz,
+			FundstelleFrageNummer:     fs.FrageNummer,
+			FundstelleVerteildatum:    fs.Verteildatum,
+		}); err != nil {
+			return fmt.Errorf("UpsertAktivitaet: %w", err)
+		}
+		if err := deleteChildren(ctx, row.ID,
+			q.DeleteAktivitaetDeskriptoren,
+			q.DeleteAktivitaetVorgangsbezuege,
+		); err != nil {
+			return err
+		}
+
+		for _, desk := range row.Deskriptoren {
+			// DO NOTHING on conflict returns no row
+			if _, err := q.CreateAktivitaetDeskriptor(ctx, pgdb.CreateAktivitaetDeskriptorParams{
+				AktivitaetID: row.ID,
+				Name:         desk.Name,
+				Typ:          desk.Typ,
+			}); err != nil && err != sql.ErrNoRows {
+				return fmt.Errorf("CreateAktivitaetDeskriptor: %w", err)
+			}
+		}
+
+		for idx, bezug := range row.Vorgangsbezuege {
+			if err := q.CreateAktivitaetVorgangsbezug(ctx, pgdb.CreateAktivitaetVorgangsbezugParams{
+				AktivitaetID:     row.ID,
+				VorgangID:        bezug.VorgangID,
+				Titel:            bezug.Titel,
+				Vorgangsposition: bezug.Vorgangsposition,
+				Vorgangstyp:      bezug.Vorgangstyp,
+				DisplayOrder:     int32(idx),
+			}); err != nil {
+				return fmt.Errorf("CreateAktivitaetVorgangsbezug: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *postgresStore) UpsertDrucksache(ctx context.Context, drucksache client.Drucksache) error {
+	row := mapDrucksache(drucksache)
+	fs := row.Fundstelle
+	wahlperiode := nullInt64ToNullInt32(row.Wahlperiode)
+	return s.inTx(ctx, func(q *pgdb.Queries) error {
+		if wahlperiode.Valid {
+			if err := ensureWahlperiode(ctx, q, wahlperiode.Int32); err != nil {
+				return err
+			}
+		}
+		if err := q.UpsertDrucksache(ctx, pgdb.UpsertDrucksacheParams{
+			ID:                        row.ID,
+			Titel:                     row.Titel,
+			Dokumentnummer:            row.Dokumentnummer,
+			Dokumentart:               row.Dokumentart,
+			Typ:                       row.Typ,
+			Drucksachetyp:             row.Drucksachetyp,
+			Herausgeber:               row.Herausgeber,
+			Datum:                     row.Datum,
+			Aktualisiert:              row.Aktualisiert,
+			Anlagen:                   row.Anlagen,
+			AutorenAnzahl:             int32(row.AutorenAnzahl),
+			VorgangsbezugAnzahl:       int32(row.VorgangsbezugAnzahl),
+			PdfHash:                   row.PdfHash,
+			Wahlperiode:               wahlperiode,
+			FundstelleDokumentnummer:  fs.Dokumentnummer,
+			FundstelleDatum:           fs.Datum,
+			FundstelleDokumentart:     fs.Dokumentart,
+			FundstelleHerausgeber:     fs.Herausgeber,
+			FundstelleID:              fs.ID,
+			FundstelleDrucksachetyp:   fs.Drucksachetyp,
+			FundstelleAnlagen:         fs.Anlagen,
+			FundstelleAnfangsseite:    nullInt64ToNullInt32(fs.Anfangsseite),
+			FundstelleEndseite:        nullInt64ToNullInt32(fs.Endseite),
+			FundstelleAnfangsquadrant: fs.Anfangsquadrant,
+			FundstelleEndquadrant:     fs.Endquadrant,
+			FundstelleSeite:           fs.Seite,
+			FundstellePdfUrl:          fs.PdfUrl,
+			FundstelleXmlUrl:          fs.XmlUrl,
+			FundstelleTop:             nullInt64ToNullInt32(fs.Top),
+			FundstelleTopZusatz:       fs.TopZusatz,
+			FundstelleFrageNummer:     fs.FrageNummer,
+			FundstelleVerteildatum:    fs.Verteildatum,
+		}); err != nil {
+			return fmt.Errorf("UpsertDrucksache: %w", err)
+		}
+		if err := deleteChildren(ctx, row.ID,
+			q.DeleteDrucksacheAutorAnzeigen,
+			q.DeleteDrucksacheRessorts,
+			q.DeleteDrucksacheUrheber,
+			q.DeleteDrucksacheVorgangsbezuege,
+			byNullID(q.DeleteDrucksacheFundstelleUrheber),
+		); err != nil {
+			return err
+		}
+
+		for idx, autor := range row.Autoren {
+			if _, err := q.CreateDrucksacheAutorAnzeige(ctx, pgdb.CreateDrucksacheAutorAnzeigeParams{
+				DrucksacheID: row.ID,
+				PersonID:     autor.PersonID,
+				AutorTitel:   autor.AutorTitel,
+				Title:        autor.Title,
+				DisplayOrder: int32(idx),
+			}); err != nil {
+				return fmt.Errorf("CreateDrucksacheAutorAnzeige: %w", err)
+			}
+		}
+
+		if err := s.createRessortLinks(ctx, q, row.Ressorts, func(ressortID int32, federfuehrend bool) error {
+			if err := q.CreateDrucksacheRessort(ctx, pgdb.CreateDrucksacheRessortParams{
+				DrucksacheID:  row.ID,
+				RessortID:     ressortID,
+				Federfuehrend: federfuehrend,
+			}); err != nil {
+				return fmt.Errorf("CreateDrucksacheRessort: %w", err)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if err := s.createUrheberLinks(ctx, q, row.Urheber, func(urheberID int32, u urheberRow) error {
+			if err := q.CreateDrucksacheUrheber(ctx, pgdb.CreateDrucksacheUrheberParams{
+				DrucksacheID: row.ID,
+				UrheberID:    urheberID,
+				Rolle:        u.Rolle,
+				Einbringer:   u.Einbringer,
+			}); err != nil {
+				return fmt.Errorf("CreateDrucksacheUrheber: %w", err)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for idx, bezug := range row.Vorgangsbezuege {
+			if err := q.CreateDrucksacheVorgangsbezug(ctx, pgdb.CreateDrucksacheVorgangsbezugParams{
+				DrucksacheID: row.ID,
+				VorgangID:    bezug.VorgangID,
+				Titel:        bezug.Titel,
+				Vorgangstyp:  bezug.Vorgangstyp,
+				DisplayOrder: int32(idx),
+			}); err != nil {
+				return fmt.Errorf("CreateDrucksacheVorgangsbezug: %w", err)
+			}
+		}
+
+		for _, urheber := range fs.Urheber {
+			if _, err := q.CreateFundstelleUrheber(ctx, pgdb.CreateFundstelleUrheberParams{
+				DrucksacheID: sql.NullString{String: row.ID, Valid: true},
+				Urheber:      urheber,
+			}); err != nil {
+				return fmt.Errorf("CreateFundstelleUrheber: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *postgresStore) UpsertDrucksacheText(ctx context.Context, text client.DrucksacheText) error {
+	row := textRow{ID: text.Id, Text: ptrToNullString(text.Text)}
+	if _, err := s.queries(ctx).CreateDrucksacheText(ctx, pgdb.CreateDrucksacheTextParams{ID: row.ID, Text: row.Text}); err != nil {
+		return fmt.Errorf("CreateDrucksacheText: %w", err)
+	}
+	return nil
+}
+
+func (s *postgresStore) UpsertPlenarprotokoll(ctx context.Context, plenarprotokoll client.Plenarprotokoll) error {
+	row := mapPlenarprotokoll(plenarprotokoll)
+	fs := row.Fundstelle
+	wahlperiode := nullInt64ToNullInt32(row.Wahlperiode)
+	return s.inTx(ctx, func(q *pgdb.Queries) error {
+		if wahlperiode.Valid {
+			if err := ensureWahlperiode(ctx, q, wahlperiode.Int32); err != nil {
+				return err
+			}
+		}
+		if err := q.UpsertPlenarprotokoll(ctx, pgdb.UpsertPlenarprotokollParams{
+			ID:                        row.ID,
+			Titel:                     row.Titel,
+			Dokumentnummer:            row.Dokumentnummer,
+			Dokumentart:               row.Dokumentart,
+			Typ:                       row.Typ,
+			Herausgeber:               row.Herausgeber,
+			Datum:                     row.Datum,
+			Aktualisiert:              row.Aktualisiert,
+			PdfHash:                   row.PdfHash,
+			Sitzungsbemerkung:         row.Sitzungsbemerkung,
+			VorgangsbezugAnzahl:       int32(row.VorgangsbezugAnzahl),
+			Wahlperiode:               wahlperiode,
+			FundstelleDokumentnummer:  fs.Dokumentnummer,
+			FundstelleDatum:           fs.Datum,
+			FundstelleDokumentart:     fs.Dokumentart,
+			FundstelleHerausgeber:     fs.Herausgeber,
+			FundstelleID:              fs.ID,
+			FundstelleAnfangsseite:    nullInt64ToNullInt32(fs.Anfangsseite),
+			FundstelleEndseite:        nullInt64ToNullInt32(fs.Endseite),
+			FundstelleAnfangsquadrant: fs.Anfangsquadrant,
+			FundstelleEndquadrant:     fs.Endquadrant,
+			FundstelleSeite:           fs.Seite,
+			FundstellePdfUrl:          fs.PdfUrl,
+			FundstelleXmlUrl:          fs.XmlUrl,
+			FundstelleTop:             nullInt64ToNullInt32(fs.Top),
+			FundstelleTopZusatz:       fs.TopZusatz,
+		}); err != nil {
+			return fmt.Errorf("UpsertPlenarprotokoll: %w", err)
+		}
+		if err := deleteChildren(ctx, row.ID,
+			q.DeletePlenarprotokollVorgangsbezuege,
+			byNullID(q.DeletePlenarprotokollFundstelleUrheber),
+		); err != nil {
+			return err
+		}
+
+		for idx, bezug := range row.Vorgangsbezuege {
+			if err := q.CreatePlenarprotokollVorgangsbezug(ctx, pgdb.CreatePlenarprotokollVorgangsbezugParams{
+				PlenarprotokollID: row.ID,
+				VorgangID:         bezug.VorgangID,
+				Titel:             bezug.Titel,
+				Vorgangstyp:       bezug.Vorgangstyp,
+				DisplayOrder:      int32(idx),
+			}); err != nil {
+				return fmt.Errorf("CreatePlenarprotokollVorgangsbezug: %w", err)
+			}
+		}
+
+		for _, urheber := range fs.Urheber {
+			if _, err := q.CreateFundstelleUrheber(ctx, pgdb.CreateFundstelleUrheberParams{
+				PlenarprotokollID: sql.NullString{String: row.ID, Valid: true},
+				Urheber:           urheber,
+			}); err != nil {
+				return fmt.Errorf("CreateFundstelleUrheber: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *postgresStore) UpsertPlenarprotokollText(ctx context.Context, text client.PlenarprotokollText) error {
+	row := textRow{ID: text.Id, Text: ptrToNullString(text.Text)}
+	if _, err := s.queries(ctx).CreatePlenarprotokollText(ctx, pgdb.CreatePlenarprotokollTextParams{ID: row.ID, Text: row.Text}); err != nil {
+		return fmt.Errorf("CreatePlenarprotokollText: %w", err)
+	}
+	return nil
+}
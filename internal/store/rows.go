@@ -0,0 +1,638 @@
+package store
+
+import (
+	"database/sql"
+	"time"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// The map functions in this file turn the DIP API types into rows. The rows
+// are backend-neutral: both backends write the same rows and only convert the
+// column types (dates, integers, booleans) to what their schema expects.
+
+type personRow struct {
+	ID           string
+	Vorname      string
+	Nachname     string
+	Namenszusatz sql.NullString
+	Titel        string
+	Typ          string
+	Aktualisiert time.Time
+	Basisdatum   sql.NullTime
+	Datum        sql.NullTime
+	Wahlperioden []int32
+	Roles        []personRoleRow
+}
+
+type personRoleRow struct {
+	Funktion        string
+	Funktionszusatz sql.NullString
+	Vorname         string
+	Nachname        string
+	Namenszusatz    sql.NullString
+	Fraktion        sql.NullString
+	Bundesland      sql.NullString
+	RessortTitel    sql.NullString
+	Wahlkreiszusatz sql.NullString
+	Wahlperioden    []int32
+}
+
+type vorgangRow struct {
+	ID                        string
+	Titel                     string
+	Vorgangstyp               string
+	Typ                       string
+	Abstract                  sql.NullString
+	Aktualisiert              time.Time
+	Archiv                    sql.NullString
+	Beratungsstand            sql.NullString
+	Datum                     sql.NullTime
+	Gesta                     sql.NullString
+	Kom                       sql.NullString
+	Mitteilung                sql.NullString
+	Ratsdok                   sql.NullString
+	Sek                       sql.NullString
+	Wahlperiode               int32
+	Initiativen               []string
+	Sachgebiete               []string
+	Zustimmungsbeduerftigkeit []string
+	Deskriptoren              []deskriptorRow
+	Verkuendungen             []verkuendungRow
+	Inkrafttreten             []inkrafttretenRow
+	Verlinkungen              []verlinkungRow
+}
+
+type deskriptorRow struct {
+	Name       string
+	Typ        string
+	Fundstelle bool // only used for Vorgang
+}
+
+type verkuendungRow struct {
+	Ausfertigungsdatum           time.Time
+	Verkuendungsdatum            time.Time
+	Einleitungstext              string
+	Fundstelle                   string
+	Jahrgang                     string
+	Seite                        string
+	Heftnummer                   sql.NullString
+	PdfUrl                       sql.NullString
+	RubrikNr                     sql.NullString
+	Titel                        sql.NullString
+	VerkuendungsblattBezeichnung sql.NullString
+	VerkuendungsblattKuerzel     sql.NullString
+}
+
+type inkrafttretenRow struct {
+	Datum        time.Time
+	Erlaeuterung sql.NullString
+}
+
+type verlinkungRow struct {
+	TargetVorgangID string
+	Titel           string
+	Verweisung      string
+	Gesta           sql.NullString
+	Wahlperiode     int32
+}
+
+// fundstelleRow holds the fundstelle_* columns of Vorgangsposition, Aktivitaet,
+// Drucksache and Plenarprotokoll
+type fundstelleRow struct {
+	Dokumentnummer  string
+	Datum           time.Time
+	Dokumentart     string
+	Herausgeber     string
+	ID              string
+	Drucksachetyp   sql.NullString
+	Anlagen         sql.NullString
+	Anfangsseite    sql.NullInt64
+	Endseite        sql.NullInt64
+	Anfangsquadrant sql.NullString
+	Endquadrant     sql.NullString
+	Seite           sql.NullString
+	PdfUrl          sql.NullString
+	XmlUrl          sql.NullString
+	Top             sql.NullInt64
+	TopZusatz       sql.NullString
+	FrageNummer     sql.NullString
+	Verteildatum    sql.NullTime
+	Urheber         []string
+}
+
+type vorgangspositionRow struct {
+	ID                string
+	VorgangID         string
+	Titel             string
+	Vorgangsposition  string
+	Vorgangstyp       string
+	Typ               string
+	Dokumentart       string
+	Datum             time.Time
+	Aktualisiert      time.Time
+	Abstract          sql.NullString
+	Fortsetzung       bool
+	Gang              bool
+	Nachtrag          bool
+	AktivitaetAnzahl  int64
+	Kom               sql.NullString
+	Ratsdok           sql.NullString
+	Sek               sql.NullString
+	Zuordnung         string
+	Fundstelle        fundstelleRow
+	AktivitaetAnzeige []aktivitaetAnzeigeRow
+	Beschlussfassung  []beschlussfassungRow
+	Ressorts          []ressortRow
+	Urheber           []urheberRow
+	Ueberweisungen    []ueberweisungRow
+	Mitberaten        []mitberatenRow
+}
+
+type aktivitaetAnzeigeRow struct {
+	Aktivitaetsart string
+	Titel          string
+	Seite          sql.NullString
+	PdfUrl         sql.NullString
+}
+
+type beschlussfassungRow struct {
+	Beschlusstenor           string
+	Abstimmungsart           sql.NullString
+	Mehrheit                 sql.NullString
+	AbstimmErgebnisBemerkung sql.NullString
+	Dokumentnummer           sql.NullString
+	Grundlage                sql.NullString
+	Seite                    sql.NullString
+}
+
+type ressortRow struct {
+	Titel         string
+	Federfuehrend bool
+}
+
+type urheberRow struct {
+	Bezeichnung string
+	Titel       string
+	Rolle       sql.NullString
+	Einbringer  sql.NullBool // only set (true) for the einbringing Urheber
+}
+
+type ueberweisungRow struct {
+	Ausschuss        string
+	AusschussKuerzel string
+	Federfuehrung    bool
+	Ueberweisungsart sql.NullString
+}
+
+type mitberatenRow struct {
+	VorgangID        string
+	Titel            string
+	Vorgangsposition string
+	Vorgangstyp      string
+}
+
+type aktivitaetRow struct {
+	ID                  string
+	Titel               string
+	Aktivitaetsart      string
+	Typ                 string
+	Dokumentart         string
+	Datum               time.Time
+	Aktualisiert        time.Time
+	Abstract            sql.NullString
+	VorgangsbezugAnzahl int64
+	Wahlperiode         int32
+	Fundstelle          fundstelleRow
+	Deskriptoren        []deskriptorRow
+	Vorgangsbezuege     []vorgangsbezugRow
+}
+
+// vorgangsbezugRow is a link to a Vorgang, in display order of the API
+type vorgangsbezugRow struct {
+	VorgangID        string
+	Titel            string
+	Vorgangstyp      string
+	Vorgangsposition string // only used for Aktivitaet
+}
+
+type drucksacheRow struct {
+	ID                  string
+	Titel               string
+	Dokumentnummer      string
+	Dokumentart         string
+	Typ                 string
+	Drucksachetyp       string
+	Herausgeber         string
+	Datum               time.Time
+	Aktualisiert        time.Time
+	Anlagen             sql.NullString
+	AutorenAnzahl       int64
+	VorgangsbezugAnzahl int64
+	PdfHash             sql.NullString
+	Wahlperiode         sql.NullInt64
+	Fundstelle          fundstelleRow
+	Autoren             []autorRow
+	Ressorts            []ressortRow
+	Urheber             []urheberRow
+	Vorgangsbezuege     []vorgangsbezugRow
+}
+
+type autorRow struct {
+	PersonID   string
+	AutorTitel string
+	Title      string
+}
+
+type plenarprotokollRow struct {
+	ID                  string
+	Titel               string
+	Dokumentnummer      string
+	Dokumentart         string
+	Typ                 string
+	Herausgeber         string
+	Datum               time.Time
+	Aktualisiert        time.Time
+	PdfHash             sql.NullString
+	Sitzungsbemerkung   sql.NullString
+	VorgangsbezugAnzahl int64
+	Wahlperiode         sql.NullInt64
+	Fundstelle          fundstelleRow
+	Vorgangsbezuege     []vorgangsbezugRow
+}
+
+type textRow struct {
+	ID   string
+	Text sql.NullString
+}
+
+func mapPerson(person PersonWithArrayWahlperiode) personRow {
+	row := personRow{
+		ID:           person.Id,
+		Vorname:      person.Vorname,
+		Nachname:     person.Nachname,
+		Namenszusatz: ptrToNullString(person.Namenszusatz),
+		Titel:        person.Titel,
+		Typ:          person.Typ,
+		Aktualisiert: person.Aktualisiert,
+		Basisdatum:   dateToNullTime(person.Basisdatum),
+		Datum:        dateToNullTime(person.Datum),
+	}
+	if person.WahlperiodeArray != nil {
+		row.Wahlperioden = *person.WahlperiodeArray
+	}
+	if person.PersonRoles != nil {
+		for _, role := range *person.PersonRoles {
+			roleRow := personRoleRow{
+				Funktion:        role.Funktion,
+				Funktionszusatz: ptrToNullString(role.Funktionszusatz),
+				Vorname:         role.Vorname,
+				Nachname:        role.Nachname,
+				Namenszusatz:    ptrToNullString(role.Namenszusatz),
+				Fraktion:        ptrToNullString(role.Fraktion),
+				RessortTitel:    ptrToNullString(role.RessortTitel),
+				Wahlkreiszusatz: ptrToNullString(role.Wahlkreiszusatz),
+			}
+			if role.Bundesland != nil {
+				roleRow.Bundesland = sql.NullString{String: string(*role.Bundesland), Valid: true}
+			}
+			if role.WahlperiodeNummer != nil {
+				for _, wp := range *role.WahlperiodeNummer {
+					roleRow.Wahlperioden = append(roleRow.Wahlperioden, int32(wp))
+				}
+			}
+			row.Roles = append(row.Roles, roleRow)
+		}
+	}
+	return row
+}
+
+func mapVorgang(vorgang client.Vorgang) vorgangRow {
+	row := vorgangRow{
+		ID:             vorgang.Id,
+		Titel:          vorgang.Titel,
+		Vorgangstyp:    vorgang.Vorgangstyp,
+		Typ:            string(vorgang.Typ),
+		Abstract:       ptrToNullString(vorgang.Abstract),
+		Aktualisiert:   vorgang.Aktualisiert,
+		Archiv:         ptrToNullString(vorgang.Archiv),
+		Beratungsstand: ptrToNullString(vorgang.Beratungsstand),
+		Datum:          dateToNullTime(vorgang.Datum),
+		Gesta:          ptrToNullString(vorgang.Gesta),
+		Kom:            ptrToNullString(vorgang.Kom),
+		Mitteilung:     ptrToNullString(vorgang.Mitteilung),
+		Ratsdok:        ptrToNullString(vorgang.Ratsdok),
+		Sek:            ptrToNullString(vorgang.Sek),
+		Wahlperiode:    int32(vorgang.Wahlperiode),
+	}
+	if vorgang.Initiative != nil {
+		row.Initiativen = *vorgang.Initiative
+	}
+	if vorgang.Sachgebiet != nil {
+		row.Sachgebiete = *vorgang.Sachgebiet
+	}
+	if vorgang.Zustimmungsbeduerftigkeit != nil {
+		row.Zustimmungsbeduerftigkeit = *vorgang.Zustimmungsbeduerftigkeit
+	}
+	if vorgang.Deskriptor != nil {
+		for _, desk := range *vorgang.Deskriptor {
+			row.Deskriptoren = append(row.Deskriptoren, deskriptorRow{Name: desk.Name, Typ: string(desk.Typ), Fundstelle: desk.Fundstelle})
+		}
+	}
+	if vorgang.Verkuendung != nil {
+		for _, verk := range *vorgang.Verkuendung {
+			row.Verkuendungen = append(row.Verkuendungen, verkuendungRow{
+				Ausfertigungsdatum:           verk.Ausfertigungsdatum.Time,
+				Verkuendungsdatum:            verk.Verkuendungsdatum.Time,
+				Einleitungstext:              verk.Einleitungstext,
+				Fundstelle:                   verk.Fundstelle,
+				Jahrgang:                     verk.Jahrgang,
+				Seite:                        verk.Seite,
+				Heftnummer:                   ptrToNullString(verk.Heftnummer),
+				PdfUrl:                       ptrToNullString(verk.PdfUrl),
+				RubrikNr:                     ptrToNullString(verk.RubrikNr),
+				Titel:                        ptrToNullString(verk.Titel),
+				VerkuendungsblattBezeichnung: ptrToNullString(verk.VerkuendungsblattBezeichnung),
+				VerkuendungsblattKuerzel:     ptrToNullString(verk.VerkuendungsblattKuerzel),
+			})
+		}
+	}
+	if vorgang.Inkrafttreten != nil {
+		for _, ink := range *vorgang.Inkrafttreten {
+			row.Inkrafttreten = append(row.Inkrafttreten, inkrafttretenRow{Datum: ink.Datum.Time, Erlaeuterung: ptrToNullString(ink.Erlaeuterung)})
+		}
+	}
+	if vorgang.VorgangVerlinkung != nil {
+		for _, verlinkung := range *vorgang.VorgangVerlinkung {
+			row.Verlinkungen = append(row.Verlinkungen, verlinkungRow{
+				TargetVorgangID: verlinkung.Id,
+				Titel:           verlinkung.Titel,
+				Verweisung:      verlinkung.Verweisung,
+				Gesta:           ptrToNullString(verlinkung.Gesta),
+				Wahlperiode:     verlinkung.Wahlperiode,
+			})
+		}
+	}
+	return row
+}
+
+func mapFundstelle(fundstelle client.Fundstelle) fundstelleRow {
+	return fundstelleRow{
+		Dokumentnummer:  fundstelle.Dokumentnummer,
+		Datum:           fundstelle.Datum.Time,
+		Dokumentart:     string(fundstelle.Dokumentart),
+		Herausgeber:     string(fundstelle.Herausgeber),
+		ID:              fundstelle.Id,
+		Drucksachetyp:   ptrToNullString(fundstelle.Drucksachetyp),
+		Anlagen:         ptrToNullString(fundstelle.Anlagen),
+		Anfangsseite:    ptrIntToNullInt64(fundstelle.Anfangsseite),
+		Endseite:        ptrIntToNullInt64(fundstelle.Endseite),
+		Anfangsquadrant: quadrantToNullString(fundstelle.Anfangsquadrant),
+		Endquadrant:     quadrantToNullString(fundstelle.Endquadrant),
+		Seite:           ptrToNullString(fundstelle.Seite),
+		PdfUrl:          ptrToNullString(fundstelle.PdfUrl),
+		XmlUrl:          ptrToNullString(fundstelle.XmlUrl),
+		Top:             ptrInt32ToNullInt64(fundstelle.Top),
+		TopZusatz:       ptrToNullString(fundstelle.TopZusatz),
+		FrageNummer:     ptrToNullString(fundstelle.FrageNummer),
+		Verteildatum:    dateToNullTime(fundstelle.Verteildatum),
+		Urheber:         fundstelle.Urheber,
+	}
+}
+
+func mapRessorts(ressorts *[]client.Ressort) []ressortRow {
+	if ressorts == nil {
+		return nil
+	}
+	rows := make([]ressortRow, 0, len(*ressorts))
+	for _, ressort := range *ressorts {
+		rows = append(rows, ressortRow{Titel: ressort.Titel, Federfuehrend: ressort.Federfuehrend})
+	}
+	return rows
+}
+
+func mapUrheber(urheber *[]client.Urheber) []urheberRow {
+	if urheber == nil {
+		return nil
+	}
+	rows := make([]urheberRow, 0, len(*urheber))
+	for _, u := range *urheber {
+		row := urheberRow{Bezeichnung: u.Bezeichnung, Titel: u.Titel}
+		if u.Rolle != nil {
+			row.Rolle = sql.NullString{String: string(*u.Rolle), Valid: true}
+		}
+		if u.Einbringer != nil && *u.Einbringer {
+			row.Einbringer = sql.NullBool{Bool: true, Valid: true}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func mapVorgangsbezuege(bezuege *[]client.Vorgangsbezug) []vorgangsbezugRow {
+	if bezuege == nil {
+		return nil
+	}
+	rows := make([]vorgangsbezugRow, 0, len(*bezuege))
+	for _, bezug := range *bezuege {
+		rows = append(rows, vorgangsbezugRow{VorgangID: bezug.Id, Titel: bezug.Titel, Vorgangstyp: bezug.Vorgangstyp})
+	}
+	return rows
+}
+
+func mapVorgangsposition(vorgangsposition client.Vorgangsposition) vorgangspositionRow {
+	row := vorgangspositionRow{
+		ID:               vorgangsposition.Id,
+		VorgangID:        vorgangsposition.VorgangId,
+		Titel:            vorgangsposition.Titel,
+		Vorgangsposition: vorgangsposition.Vorgangsposition,
+		Vorgangstyp:      vorgangsposition.Vorgangstyp,
+		Typ:              string(vorgangsposition.Typ),
+		Dokumentart:      string(vorgangsposition.Dokumentart),
+		Datum:            vorgangsposition.Datum.Time,
+		Aktualisiert:     vorgangsposition.Aktualisiert,
+		Abstract:         ptrToNullString(vorgangsposition.Abstract),
+		Fortsetzung:      vorgangsposition.Fortsetzung,
+		Gang:             vorgangsposition.Gang,
+		Nachtrag:         vorgangsposition.Nachtrag,
+		AktivitaetAnzahl: int64(vorgangsposition.AktivitaetAnzahl),
+		Kom:              ptrToNullString(vorgangsposition.Kom),
+		Ratsdok:          ptrToNullString(vorgangsposition.Ratsdok),
+		Sek:              ptrToNullString(vorgangsposition.Sek),
+		Zuordnung:        string(vorgangsposition.Zuordnung),
+		Fundstelle:       mapFundstelle(vorgangsposition.Fundstelle),
+		Ressorts:         mapRessorts(vorgangsposition.Ressort),
+		Urheber:          mapUrheber(vorgangsposition.Urheber),
+	}
+	if vorgangsposition.AktivitaetAnzeige != nil {
+		for _, aktivitaet := range *vorgangsposition.AktivitaetAnzeige {
+			row.AktivitaetAnzeige = append(row.AktivitaetAnzeige, aktivitaetAnzeigeRow{
+				Aktivitaetsart: aktivitaet.Aktivitaetsart,
+				Titel:          aktivitaet.Titel,
+				Seite:          ptrToNullString(aktivitaet.Seite),
+				PdfUrl:         ptrToNullString(aktivitaet.PdfUrl),
+			})
+		}
+	}
+	if vorgangsposition.Beschlussfassung != nil {
+		for _, beschluss := range *vorgangsposition.Beschlussfassung {
+			beschlussRow := beschlussfassungRow{
+				Beschlusstenor:           beschluss.Beschlusstenor,
+				AbstimmErgebnisBemerkung: ptrToNullString(beschluss.AbstimmErgebnisBemerkung),
+				Dokumentnummer:           ptrToNullString(beschluss.Dokumentnummer),
+				Grundlage:                ptrToNullString(beschluss.Grundlage),
+				Seite:                    ptrToNullString(beschluss.Seite),
+			}
+			if beschluss.Abstimmungsart != nil {
+				beschlussRow.Abstimmungsart = sql.NullString{String: string(*beschluss.Abstimmungsart), Valid: true}
+			}
+			if beschluss.Mehrheit != nil {
+				beschlussRow.Mehrheit = sql.NullString{String: string(*beschluss.Mehrheit), Valid: true}
+			}
+			row.Beschlussfassung = append(row.Beschlussfassung, beschlussRow)
+		}
+	}
+	if vorgangsposition.Ueberweisung != nil {
+		for _, ueberweisung := range *vorgangsposition.Ueberweisung {
+			row.Ueberweisungen = append(row.Ueberweisungen, ueberweisungRow{
+				Ausschuss:        ueberweisung.Ausschuss,
+				AusschussKuerzel: ueberweisung.AusschussKuerzel,
+				Federfuehrung:    ueberweisung.Federfuehrung,
+				Ueberweisungsart: ptrToNullString(ueberweisung.Ueberweisungsart),
+			})
+		}
+	}
+	if vorgangsposition.Mitberaten != nil {
+		for _, mitberaten := range *vorgangsposition.Mitberaten {
+			row.Mitberaten = append(row.Mitberaten, mitberatenRow{
+				VorgangID:        mitberaten.Id,
+				Titel:            mitberaten.Titel,
+				Vorgangsposition: mitberaten.Vorgangsposition,
+				Vorgangstyp:      mitberaten.Vorgangstyp,
+			})
+		}
+	}
+	return row
+}
+
+func mapAktivitaet(aktivitaet client.Aktivitaet) aktivitaetRow {
+	row := aktivitaetRow{
+		ID:                  aktivitaet.Id,
+		Titel:               aktivitaet.Titel,
+		Aktivitaetsart:      aktivitaet.Aktivitaetsart,
+		Typ:                 string(aktivitaet.Typ),
+		Dokumentart:         string(aktivitaet.Dokumentart),
+		Datum:               aktivitaet.Datum.Time,
+		Aktualisiert:        aktivitaet.Aktualisiert,
+		Abstract:            ptrToNullString(aktivitaet.Abstract),
+		VorgangsbezugAnzahl: int64(aktivitaet.VorgangsbezugAnzahl),
+		Wahlperiode:         int32(aktivitaet.Wahlperiode),
+		Fundstelle:          mapFundstelle(aktivitaet.Fundstelle),
+	}
+	if aktivitaet.Deskriptor != nil {
+		for _, desk := range *aktivitaet.Deskriptor {
+			row.Deskriptoren = append(row.Deskriptoren, deskriptorRow{Name: desk.Name, Typ: string(desk.Typ)})
+		}
+	}
+	if aktivitaet.Vorgangsbezug != nil {
+		for _, bezug := range *aktivitaet.Vorgangsbezug {
+			row.Vorgangsbezuege = append(row.Vorgangsbezuege, vorgangsbezugRow{
+				VorgangID:        bezug.Id,
+				Titel:            bezug.Titel,
+				Vorgangstyp:      bezug.Vorgangstyp,
+				Vorgangsposition: bezug.Vorgangsposition,
+			})
+		}
+	}
+	return row
+}
+
+func mapDrucksache(drucksache client.Drucksache) drucksacheRow {
+	row := drucksacheRow{
+		ID:                  drucksache.Id,
+		Titel:               drucksache.Titel,
+		Dokumentnummer:      drucksache.Dokumentnummer,
+		Dokumentart:         string(drucksache.Dokumentart),
+		Typ:                 string(drucksache.Typ),
+		Drucksachetyp:       drucksache.Drucksachetyp,
+		Herausgeber:         string(drucksache.Herausgeber),
+		Datum:               drucksache.Datum.Time,
+		Aktualisiert:        drucksache.Aktualisiert,
+		Anlagen:             ptrToNullString(drucksache.Anlagen),
+		AutorenAnzahl:       int64(drucksache.AutorenAnzahl),
+		VorgangsbezugAnzahl: int64(drucksache.VorgangsbezugAnzahl),
+		PdfHash:             ptrToNullString(drucksache.PdfHash),
+		Wahlperiode:         ptrInt32ToNullInt64(drucksache.Wahlperiode),
+		Fundstelle:          mapFundstelle(drucksache.Fundstelle),
+		Ressorts:            mapRessorts(drucksache.Ressort),
+		Urheber:             mapUrheber(drucksache.Urheber),
+		Vorgangsbezuege:     mapVorgangsbezuege(drucksache.Vorgangsbezug),
+	}
+	if drucksache.AutorenAnzeige != nil {
+		for _, autor := range *drucksache.AutorenAnzeige {
+			row.Autoren = append(row.Autoren, autorRow{PersonID: autor.Id, AutorTitel: autor.AutorTitel, Title: autor.Title})
+		}
+	}
+	return row
+}
+
+func mapPlenarprotokoll(plenarprotokoll client.Plenarprotokoll) plenarprotokollRow {
+	return plenarprotokollRow{
+		ID:                  plenarprotokoll.Id,
+		Titel:               plenarprotokoll.Titel,
+		Dokumentnummer:      plenarprotokoll.Dokumentnummer,
+		Dokumentart:         string(plenarprotokoll.Dokumentart),
+		Typ:                 string(plenarprotokoll.Typ),
+		Herausgeber:         string(plenarprotokoll.Herausgeber),
+		Datum:               plenarprotokoll.Datum.Time,
+		Aktualisiert:        plenarprotokoll.Aktualisiert,
+		PdfHash:             ptrToNullString(plenarprotokoll.PdfHash),
+		Sitzungsbemerkung:   ptrToNullString(plenarprotokoll.Sitzungsbemerkung),
+		VorgangsbezugAnzahl: int64(plenarprotokoll.VorgangsbezugAnzahl),
+		Wahlperiode:         ptrInt32ToNullInt64(plenarprotokoll.Wahlperiode),
+		Fundstelle:          mapFundstelle(plenarprotokoll.Fundstelle),
+		Vorgangsbezuege:     mapVorgangsbezuege(plenarprotokoll.Vorgangsbezug),
+	}
+}
+
+// NullString maps an empty string to NULL, e.g. for the optional attributes
+// of the parsed Plenarprotokoll XML
+func NullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func ptrToNullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{Valid: false}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
+
+func ptrIntToNullInt64(i *int) sql.NullInt64 {
+	if i == nil {
+		return sql.NullInt64{Valid: false}
+	}
+	return sql.NullInt64{Int64: int64(*i), Valid: true}
+}
+
+func ptrInt32ToNullInt64(i *int32) sql.NullInt64 {
+	if i == nil {
+		return sql.NullInt64{Valid: false}
+	}
+	return sql.NullInt64{Int64: int64(*i), Valid: true}
+}
+
+func quadrantToNullString(q *client.Quadrant) sql.NullString {
+	if q == nil {
+		return sql.NullString{Valid: false}
+	}
+	return sql.NullString{String: string(*q), Valid: true}
+}
+
+func dateToNullTime(d *openapi_types.Date) sql.NullTime {
+	if d == nil {
+		return sql.NullTime{Valid: false}
+	}
+	return sql.NullTime{Time: d.Time, Valid: true}
+}
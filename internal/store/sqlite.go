@@ -0,0 +1,207 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/Johanneslueke/dip-client/internal/database/gen/sqlite"
+	"github.com/pressly/goose/v3"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteStore stores entities in a SQLite database
+type sqliteStore struct {
+	db *sql.DB
+	q  *db.Queries
+}
+
+func openSQLite(path string, o options) (Store, error) {
+	// A busy timeout lets concurrent syncs wait for each other's writes
+	// instead of failing immediately with "database is locked"
+	sqlDB, err := sql.Open("sqlite", withBusyTimeout(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(24)
+	sqlDB.SetMaxIdleConns(24)
+	sqlDB.SetConnMaxLifetime(time.Hour)
+
+	if err := migrate(sqlDB, goose.DialectSQLite3, "migrations/sqlite", o.logger); err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+
+	return &sqliteStore{db: sqlDB, q: db.New(sqlDB)}, nil
+}
+
+// withBusyTimeout adds a busy_timeout pragma to a SQLite DSN unless it already sets pragmas.
+// Transactions take the write lock when they begin (_txlock=immediate), so
+// concurrent upserts wait for the busy timeout instead of failing on lock upgrade.
+func withBusyTimeout(dsn string) string {
+	if strings.Contains(dsn, "_pragma=") {
+		return dsn
+	}
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+	dsn += separator + "_pragma=busy_timeout(10000)"
+	if !strings.Contains(dsn, "_txlock=") {
+		dsn += "&_txlock=immediate"
+	}
+	return dsn
+}
+
+func (s *sqliteStore) Driver() string { return SQLite }
+func (s *sqliteStore) DB() *sql.DB    { return s.db }
+func (s *sqliteStore) Close() error   { return s.db.Close() }
+
+func (s *sqliteStore) EarliestDatum(ctx context.Context, table Table) (time.Time, bool, error) {
+	var (
+		datum interface{}
+		err   error
+	)
+	switch table {
+	case PersonTable:
+		datum, err = s.q.GetLatestPersonDatum(ctx)
+	case VorgangTable:
+		datum, err = s.q.GetLatestVorgangDatum(ctx)
+	case VorgangspositionTable:
+		datum, err = s.q.GetLatestVorgangspositionDatum(ctx)
+	case AktivitaetTable:
+		datum, err = s.q.GetLatestAktivitaetDatum(ctx)
+	case DrucksacheTable:
+		datum, err = s.q.GetLatestDrucksacheDatum(ctx)
+	case PlenarprotokollTable:
+		datum, err = s.q.GetLatestPlenarprotokollDatum(ctx)
+	default:
+		return time.Time{}, false, checkTable(table)
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return parseDatum(datum)
+}
+
+func (s *sqliteStore) DanglingIDs(ctx context.Context, link Link) ([]string, error) {
+	switch link {
+	case LinkVerlinkungVorgang:
+		return s.q.ListDanglingVerlinkungVorgang(ctx)
+	case LinkVorgangspositionVorgang:
+		return s.q.ListDanglingVorgangspositionVorgang(ctx)
+	case LinkMitberatenVorgang:
+		return s.q.ListDanglingMitberatenVorgang(ctx)
+	case LinkVorgangspositionFundstelleDrucksache:
+		return s.q.ListDanglingVorgangspositionFundstelleDrucksache(ctx)
+	case LinkVorgangspositionFundstellePlenarprotokoll:
+		return s.q.ListDanglingVorgangspositionFundstellePlenarprotokoll(ctx)
+	case LinkDrucksacheVorgangsbezug:
+		return s.q.ListDanglingVorgangsbezugDrucksache(ctx)
+	case LinkAutorAnzeigePerson:
+		return s.q.ListDanglingAutorAnzeigePerson(ctx)
+	case LinkPlenarprotokollVorgangsbezug:
+		return s.q.ListDanglingVorgangsbezugPlenarprotokoll(ctx)
+	case LinkAktivitaetVorgangsbezug:
+		return s.q.ListDanglingVorgangsbezugAktivitaet(ctx)
+	case LinkAktivitaetFundstelleDrucksache:
+		return s.q.ListDanglingAktivitaetFundstelleDrucksache(ctx)
+	case LinkAktivitaetFundstellePlenarprotokoll:
+		return s.q.ListDanglingAktivitaetFundstellePlenarprotokoll(ctx)
+	}
+	return nil, errUnknownLink(link)
+}
+
+func (s *sqliteStore) IDsByWahlperiode(ctx context.Context, table Table, wp int) ([]string, error) {
+	switch table {
+	case PersonTable:
+		return s.q.ListPersonIDsByWahlperiode(ctx, int64(wp))
+	case VorgangTable:
+		return s.q.ListVorgangIDsByWahlperiode(ctx, int64(wp))
+	case VorgangspositionTable:
+		return s.q.ListVorgangspositionIDsByWahlperiode(ctx, int64(wp))
+	case AktivitaetTable:
+		return s.q.ListAktivitaetIDsByWahlperiode(ctx, int64(wp))
+	case DrucksacheTable:
+		return s.q.ListDrucksacheIDsByWahlperiode(ctx, sql.NullInt64{Int64: int64(wp), Valid: true})
+	case PlenarprotokollTable:
+		return s.q.ListPlenarprotokollIDsByWahlperiode(ctx, sql.NullInt64{Int64: int64(wp), Valid: true})
+	}
+	return nil, checkTable(table)
+}
+
+func (s *sqliteStore) MarkDeleted(ctx context.Context, table Table, id string, at time.Time) error {
+	deletedAt := sql.NullString{String: at.UTC().Format(time.RFC3339), Valid: true}
+	switch table {
+	case PersonTable:
+		return s.q.MarkPersonDeleted(ctx, db.MarkPersonDeletedParams{ID: id, DeletedAt: deletedAt})
+	case VorgangTable:
+		return s.q.MarkVorgangDeleted(ctx, db.MarkVorgangDeletedParams{ID: id, DeletedAt: deletedAt})
+	case VorgangspositionTable:
+		return s.q.MarkVorgangspositionDeleted(ctx, db.MarkVorgangspositionDeletedParams{ID: id, DeletedAt: deletedAt})
+	case AktivitaetTable:
+		return s.q.MarkAktivitaetDeleted(ctx, db.MarkAktivitaetDeletedParams{ID: id, DeletedAt: deletedAt})
+	case DrucksacheTable:
+		return s.q.MarkDrucksacheDeleted(ctx, db.MarkDrucksacheDeletedParams{ID: id, DeletedAt: deletedAt})
+	case PlenarprotokollTable:
+		return s.q.MarkPlenarprotokollDeleted(ctx, db.MarkPlenarprotokollDeletedParams{ID: id, DeletedAt: deletedAt})
+	}
+	return checkTable(table)
+}
+
+func (s *sqliteStore) LoadRow(ctx context.Context, table Table, id string) (map[string]interface{}, error) {
+	if err := checkTable(table); err != nil {
+		return nil, err
+	}
+	return loadRow(ctx, conn(ctx, s.db), "SELECT * FROM "+string(table)+" WHERE id = ?", id)
+}
+
+func (s *sqliteStore) CreateSyncRun(ctx context.Context, id, resourceName string) error {
+	return s.q.CreateSyncRun(ctx, db.CreateSyncRunParams{ID: id, ResourceName: resourceName})
+}
+
+func (s *sqliteStore) CreateHistory(ctx context.Context, table Table, e HistoryEntry) error {
+	aktualisiert := e.Aktualisiert.Format(time.RFC3339)
+	switch table {
+	case PersonTable:
+		return s.queries(ctx).CreatePersonHistory(ctx, db.CreatePersonHistoryParams{PersonID: e.ID, Aktualisiert: aktualisiert, SyncRunID: e.SyncRunID, Snapshot: e.Snapshot, Diff: e.Diff})
+	case VorgangTable:
+		return s.queries(ctx).CreateVorgangHistory(ctx, db.CreateVorgangHistoryParams{VorgangID: e.ID, Aktualisiert: aktualisiert, SyncRunID: e.SyncRunID, Snapshot: e.Snapshot, Diff: e.Diff})
+	case VorgangspositionTable:
+		return s.queries(ctx).CreateVorgangspositionHistory(ctx, db.CreateVorgangspositionHistoryParams{VorgangspositionID: e.ID, Aktualisiert: aktualisiert, SyncRunID: e.SyncRunID, Snapshot: e.Snapshot, Diff: e.Diff})
+	case AktivitaetTable:
+		return s.queries(ctx).CreateAktivitaetHistory(ctx, db.CreateAktivitaetHistoryParams{AktivitaetID: e.ID, Aktualisiert: aktualisiert, SyncRunID: e.SyncRunID, Snapshot: e.Snapshot, Diff: e.Diff})
+	case DrucksacheTable:
+		return s.queries(ctx).CreateDrucksacheHistory(ctx, db.CreateDrucksacheHistoryParams{DrucksacheID: e.ID, Aktualisiert: aktualisiert, SyncRunID: e.SyncRunID, Snapshot: e.Snapshot, Diff: e.Diff})
+	case PlenarprotokollTable:
+		return s.queries(ctx).CreatePlenarprotokollHistory(ctx, db.CreatePlenarprotokollHistoryParams{PlenarprotokollID: e.ID, Aktualisiert: aktualisiert, SyncRunID: e.SyncRunID, Snapshot: e.Snapshot, Diff: e.Diff})
+	}
+	return checkTable(table)
+}
+
+func (s *sqliteStore) HistoryAsOf(ctx context.Context, table Table, id string, at time.Time) (string, error) {
+	asOf := at.Format(time.RFC3339)
+	switch table {
+	case PersonTable:
+		h, err := s.q.GetPersonHistoryAsOf(ctx, db.GetPersonHistoryAsOfParams{PersonID: id, AsOf: asOf})
+		return h.Snapshot, err
+	case VorgangTable:
+		h, err := s.q.GetVorgangHistoryAsOf(ctx, db.GetVorgangHistoryAsOfParams{VorgangID: id, AsOf: asOf})
+		return h.Snapshot, err
+	case VorgangspositionTable:
+		h, err := s.q.GetVorgangspositionHistoryAsOf(ctx, db.GetVorgangspositionHistoryAsOfParams{VorgangspositionID: id, AsOf: asOf})
+		return h.Snapshot, err
+	case AktivitaetTable:
+		h, err := s.q.GetAktivitaetHistoryAsOf(ctx, db.GetAktivitaetHistoryAsOfParams{AktivitaetID: id, AsOf: asOf})
+		return h.Snapshot, err
+	case DrucksacheTable:
+		h, err := s.q.GetDrucksacheHistoryAsOf(ctx, db.GetDrucksacheHistoryAsOfParams{DrucksacheID: id, AsOf: asOf})
+		return h.Snapshot, err
+	case PlenarprotokollTable:
+		h, err := s.q.GetPlenarprotokollHistoryAsOf(ctx, db.GetPlenarprotokollHistoryAsOfParams{PlenarprotokollID: id, AsOf: asOf})
+		return h.Snapshot, err
+	}
+	return "", checkTable(table)
+}
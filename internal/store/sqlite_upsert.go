@@ -0,0 +1,682 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	db "github.com/Johanneslueke/dip-client/internal/database/gen/sqlite"
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+)
+
+// SQLite stores dates as YYYY-MM-DD, timestamps as RFC 3339 and booleans as integers
+
+func sqliteDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+func sqliteNullDate(t sql.NullTime) sql.NullString {
+	if !t.Valid {
+		return sql.NullString{Valid: false}
+	}
+	return sql.NullString{String: sqliteDate(t.Time), Valid: true}
+}
+
+func sqliteTimestamp(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func nullBoolToNullInt64(b sql.NullBool) sql.NullInt64 {
+	if !b.Valid {
+		return sql.NullInt64{Valid: false}
+	}
+	return sql.NullInt64{Int64: boolToInt64(b.Bool), Valid: true}
+}
+
+func (s *sqliteStore) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return runInTx(ctx, s.db, fn)
+}
+
+// inTx runs fn with the queries of a transaction; see InTx
+func (s *sqliteStore) inTx(ctx context.Context, fn func(q *db.Queries) error) error {
+	return s.InTx(ctx, func(ctx context.Context) error {
+		return fn(s.queries(ctx))
+	})
+}
+
+// queries returns the queries bound to the transaction of ctx, if there is one
+func (s *sqliteStore) queries(ctx context.Context) *db.Queries {
+	if tx := txFrom(ctx); tx != nil {
+		return s.q.WithTx(tx)
+	}
+	return s.q
+}
+
+func (s *sqliteStore) UpsertPerson(ctx context.Context, person PersonWithArrayWahlperiode) error {
+	row := mapPerson(person)
+	return s.inTx(ctx, func(q *db.Queries) error {
+		for _, wp := range row.Wahlperioden {
+			if _, err := q.GetOrCreateWahlperiode(ctx, int64(wp)); err != nil {
+				return fmt.Errorf("GetOrCreateWahlperiode: %w", err)
+			}
+		}
+
+		if err := q.UpsertPerson(ctx, db.UpsertPersonParams{
+			ID:           row.ID,
+			Vorname:      row.Vorname,
+			Nachname:     row.Nachname,
+			Namenszusatz: row.Namenszusatz,
+			Titel:        row.Titel,
+			Typ:          row.Typ,
+			Aktualisiert: sqliteTimestamp(row.Aktualisiert),
+			Basisdatum:   sqliteNullDate(row.Basisdatum),
+			Datum:        sqliteNullDate(row.Datum),
+		}); err != nil {
+			return fmt.Errorf("UpsertPerson: %w", err)
+		}
+		if err := deleteChildren(ctx, row.ID,
+			q.DeletePersonWahlperioden,
+			q.DeletePersonRoleWahlperioden,
+			q.DeletePersonRoles,
+		); err != nil {
+			return err
+		}
+
+		for _, wp := range row.Wahlperioden {
+			if err := q.CreatePersonWahlperiode(ctx, db.CreatePersonWahlperiodeParams{
+				PersonID:          row.ID,
+				WahlperiodeNummer: int64(wp),
+			}); err != nil {
+				return fmt.Errorf("CreatePersonWahlperiode %d: %w", wp, err)
+			}
+		}
+
+		for _, role := range row.Roles {
+			if role.Bundesland.Valid {
+				if _, err := q.GetOrCreateBundesland(ctx, role.Bundesland.String); err != nil {
+					return fmt.Errorf("GetOrCreateBundesland: %w", err)
+				}
+			}
+
+			personRole, err := q.CreatePersonRole(ctx, db.CreatePersonRoleParams{
+				PersonID:        row.ID,
+				Funktion:        role.Funktion,
+				Funktionszusatz: role.Funktionszusatz,
+				Vorname:         role.Vorname,
+				Nachname:        role.Nachname,
+				Namenszusatz:    role.Namenszusatz,
+				Fraktion:        role.Fraktion,
+				Bundesland:      role.Bundesland,
+				RessortTitel:    role.RessortTitel,
+				Wahlkreiszusatz: role.Wahlkreiszusatz,
+			})
+			if err != nil {
+				return fmt.Errorf("CreatePersonRole: %w", err)
+			}
+
+			for _, wp := range role.Wahlperioden {
+				if err := q.CreatePersonRoleWahlperiode(ctx, db.CreatePersonRoleWahlperiodeParams{
+					PersonRoleID:      personRole.ID,
+					WahlperiodeNummer: int64(wp),
+				}); err != nil {
+					return fmt.Errorf("CreatePersonRoleWahlperiode %d: %w", wp, err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *sqliteStore) UpsertVorgang(ctx context.Context, vorgang client.Vorgang) error {
+	row := mapVorgang(vorgang)
+	return s.inTx(ctx, func(q *db.Queries) error {
+		if err := q.UpsertVorgang(ctx, db.UpsertVorgangParams{
+			ID:             row.ID,
+			Titel:          row.Titel,
+			Vorgangstyp:    row.Vorgangstyp,
+			Typ:            row.Typ,
+			Abstract:       row.Abstract,
+			Aktualisiert:   sqliteTimestamp(row.Aktualisiert),
+			Archiv:         row.Archiv,
+			Beratungsstand: row.Beratungsstand,
+			Datum:          sqliteNullDate(row.Datum),
+			Gesta:          row.Gesta,
+			Kom:            row.Kom,
+			Mitteilung:     row.Mitteilung,
+			Ratsdok:        row.Ratsdok,
+			Sek:            row.Sek,
+			Wahlperiode:    int64(row.Wahlperiode),
+		}); err != nil {
+			return fmt.Errorf("UpsertVorgang: %w", err)
+		}
+		if err := deleteChildren(ctx, row.ID,
+			q.DeleteVorgangInitiativen,
+			q.DeleteVorgangSachgebiete,
+			q.DeleteVorgangZustimmungsbeduerftigkeiten,
+			q.DeleteVorgangDeskriptoren,
+			q.DeleteVerkuendungen,
+			q.DeleteInkrafttreten,
+			q.DeleteVorgangVerlinkungen,
+		); err != nil {
+			return err
+		}
+
+		for _, initiative := range row.Initiativen {
+			if err := q.CreateVorgangInitiative(ctx, db.CreateVorgangInitiativeParams{VorgangID: row.ID, Initiative: initiative}); err != nil {
+				return fmt.Errorf("CreateVorgangInitiative: %w", err)
+			}
+		}
+
+		for _, sachgebiet := range row.Sachgebiete {
+			if err := q.CreateVorgangSachgebiet(ctx, db.CreateVorgangSachgebietParams{VorgangID: row.ID, Sachgebiet: sachgebiet}); err != nil {
+				return fmt.Errorf("CreateVorgangSachgebiet: %w", err)
+			}
+		}
+
+		for _, desk := range row.Deskriptoren {
+			if _, err := q.CreateVorgangDeskriptor(ctx, db.CreateVorgangDeskriptorParams{
+				VorgangID:  row.ID,
+				Name:       desk.Name,
+				Typ:        desk.Typ,
+				Fundstelle: boolToInt64(desk.Fundstelle),
+			}); err != nil {
+				return fmt.Errorf("CreateVorgangDeskriptor: %w", err)
+			}
+		}
+
+		for _, verk := range row.Verkuendungen {
+			if _, err := q.CreateVerkuendung(ctx, db.CreateVerkuendungParams{
+				VorgangID:                    row.ID,
+				Ausfertigungsdatum:           sqliteDate(verk.Ausfertigungsdatum),
+				Verkuendungsdatum:            sqliteDate(verk.Verkuendungsdatum),
+				Einleitungstext:              verk.Einleitungstext,
+				Fundstelle:                   verk.Fundstelle,
+				Jahrgang:                     verk.Jahrgang,
+				Seite:                        verk.Seite,
+				Heftnummer:                   verk.Heftnummer,
+				PdfUrl:                       verk.PdfUrl,
+				RubrikNr:                     verk.RubrikNr,
+				Titel:                        verk.Titel,
+				VerkuendungsblattBezeichnung: verk.VerkuendungsblattBezeichnung,
+				VerkuendungsblattKuerzel:     verk.VerkuendungsblattKuerzel,
+			}); err != nil {
+				return fmt.Errorf("CreateVerkuendung: %w", err)
+			}
+		}
+
+		for _, ink := range row.Inkrafttreten {
+			if _, err := q.CreateInkrafttreten(ctx, db.CreateInkrafttretenParams{
+				VorgangID:    row.ID,
+				Datum:        sqliteDate(ink.Datum),
+				Erlaeuterung: ink.Erlaeuterung,
+			}); err != nil {
+				return fmt.Errorf("CreateInkrafttreten: %w", err)
+			}
+		}
+
+		for _, zust := range row.Zustimmungsbeduerftigkeit {
+			if err := q.CreateVorgangZustimmungsbeduerftigkeit(ctx, db.CreateVorgangZustimmungsbeduerftigkeitParams{
+				VorgangID:                 row.ID,
+				Zustimmungsbeduerftigkeit: zust,
+			}); err != nil {
+				return fmt.Errorf("CreateVorgangZustimmungsbeduerftigkeit: %w", err)
+			}
+		}
+
+		for _, verlinkung := range row.Verlinkungen {
+			if _, err := q.CreateVorgangVerlinkung(ctx, db.CreateVorgangVerlinkungParams{
+				SourceVorgangID: row.ID,
+				TargetVorgangID: verlinkung.TargetVorgangID,
+				Titel:           verlinkung.Titel,
+				Verweisung:      verlinkung.Verweisung,
+				Gesta:           verlinkung.Gesta,
+				Wahlperiode:     int64(verlinkung.Wahlperiode),
+			}); err != nil {
+				return fmt.Errorf("CreateVorgangVerlinkung: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *sqliteStore) createRessortLinks(ctx context.Context, q *db.Queries, ressorts []ressortRow, link func(ressortID int64, federfuehrend int64) error) error {
+	for _, ressort := range ressorts {
+		record, err := q.GetOrCreateRessort(ctx, ressort.Titel)
+		if err != nil {
+			return fmt.Errorf("GetOrCreateRessort: %w", err)
+		}
+		if err := link(record.ID, boolToInt64(ressort.Federfuehrend)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *sqliteStore) createUrheberLinks(ctx context.Context, q *db.Queries, urheber []urheberRow, link func(urheberID int64, u urheberRow) error) error {
+	for _, u := range urheber {
+		record, err := q.GetOrCreateUrheber(ctx, db.GetOrCreateUrheberParams{Bezeichnung: u.Bezeichnung, Titel: u.Titel})
+		if err != nil {
+			return fmt.Errorf("GetOrCreateUrheber: %w", err)
+		}
+		if err := link(record.ID, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *sqliteStore) UpsertVorgangsposition(ctx context.Context, vorgangsposition client.Vorgangsposition) error {
+	row := mapVorgangsposition(vorgangsposition)
+	fs := row.Fundstelle
+	return s.inTx(ctx, func(q *db.Queries) error {
+		if err := q.UpsertVorgangsposition(ctx, db.UpsertVorgangspositionParams{
+			ID:                        row.ID,
+			VorgangID:                 row.VorgangID,
+			Titel:                     row.Titel,
+			Vorgangsposition:          row.Vorgangsposition,
+			Vorgangstyp:               row.Vorgangstyp,
+			Typ:                       row.Typ,
+			Dokumentart:               row.Dokumentart,
+			Datum:                     sqliteDate(row.Datum),
+			Aktualisiert:              sqliteTimestamp(row.Aktualisiert),
+			Abstract:                  row.Abstract,
+			Fortsetzung:               boolToInt64(row.Fortsetzung),
+			Gang:                      boolToInt64(row.Gang),
+			Nachtrag:                  boolToInt64(row.Nachtrag),
+			AktivitaetAnzahl:          row.AktivitaetAnzahl,
+			Kom:                       row.Kom,
+			Ratsdok:                   row.Ratsdok,
+			Sek:                       row.Sek,
+			Zuordnung:                 row.Zuordnung,
+			FundstelleDokumentnummer:  fs.Dokumentnummer,
+			FundstelleDatum:           sqliteDate(fs.Datum),
+			FundstelleDokumentart:     fs.Dokumentart,
+			FundstelleHerausgeber:     fs.Herausgeber,
+			FundstelleID:              fs.ID,
+			FundstelleDrucksachetyp:   fs.Drucksachetyp,
+			FundstelleAnlagen:         fs.Anlagen,
+			FundstelleAnfangsseite:    fs.Anfangsseite,
+			FundstelleEndseite:        fs.Endseite,
+			FundstelleAnfangsquadrant: fs.Anfangsquadrant,
+			FundstelleEndquadrant:     fs.Endquadrant,
+			FundstelleSeite:           fs.Seite,
+			FundstellePdfUrl:          fs.PdfUrl,
+			FundstelleXmlUrl:          fs.XmlUrl,
+			FundstelleTop:             fs.Top,
+			FundstelleTopZusatz:       fs.TopZusatz,
+			FundstelleFrageNummer:     fs.FrageNummer,
+			FundstelleVerteildatum:    sqliteNullDate(fs.Verteildatum),
+		}); err != nil {
+			return fmt.Errorf("UpsertVorgangsposition: %w", err)
+		}
+		if err := deleteChildren(ctx, row.ID,
+			q.DeleteAktivitaetAnzeigen,
+			q.DeleteBeschlussfassungen,
+			q.DeleteVorgangspositionRessorts,
+			q.DeleteVorgangspositionUrheber,
+			q.DeleteUeberweisungen,
+			q.DeleteVorgangspositionMitberaten,
+		); err != nil {
+			return err
+		}
+
+		for idx, aktivitaet := range row.AktivitaetAnzeige {
+			if _, err := q.CreateAktivitaetAnzeige(ctx, db.CreateAktivitaetAnzeigeParams{
+				VorgangspositionID: row.ID,
+				Aktivitaetsart:     aktivitaet.Aktivitaetsart,
+				Titel:              aktivitaet.Titel,
+				Seite:              aktivitaet.Seite,
+				PdfUrl:             aktivitaet.PdfUrl,
+				DisplayOrder:       int64(idx),
+			}); err != nil {
+				return fmt.Errorf("CreateAktivitaetAnzeige: %w", err)
+			}
+		}
+
+		for _, beschluss := range row.Beschlussfassung {
+			if _, err := q.CreateBeschlussfassung(ctx, db.CreateBeschlussfassungParams{
+				VorgangspositionID:       row.ID,
+				Beschlusstenor:           beschluss.Beschlusstenor,
+				Abstimmungsart:           beschluss.Abstimmungsart,
+				Mehrheit:                 beschluss.Mehrheit,
+				AbstimmErgebnisBemerkung: beschluss.AbstimmErgebnisBemerkung,
+				Dokumentnummer:           beschluss.Dokumentnummer,
+				Grundlage:                beschluss.Grundlage,
+				Seite:                    beschluss.Seite,
+			}); err != nil {
+				return fmt.Errorf("CreateBeschlussfassung: %w", err)
+			}
+		}
+
+		if err := s.createRessortLinks(ctx, q, row.Ressorts, func(ressortID, federfuehrend int64) error {
+			if err := q.CreateVorgangspositionRessort(ctx, db.CreateVorgangspositionRessortParams{
+				VorgangspositionID: row.ID,
+				RessortID:          ressortID,
+				Federfuehrend:      federfuehrend,
+			}); err != nil {
+				return fmt.Errorf("CreateVorgangspositionRessort: %w", err)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if err := s.createUrheberLinks(ctx, q, row.Urheber, func(urheberID int64, u urheberRow) error {
+			if err := q.CreateVorgangspositionUrheber(ctx, db.CreateVorgangspositionUrheberParams{
+				VorgangspositionID: row.ID,
+				UrheberID:          urheberID,
+				Rolle:              u.Rolle,
+				Einbringer:         nullBoolToNullInt64(u.Einbringer),
+			}); err != nil {
+				return fmt.Errorf("CreateVorgangspositionUrheber: %w", err)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, ueberweisung := range row.Ueberweisungen {
+			if _, err := q.CreateUeberweisung(ctx, db.CreateUeberweisungParams{
+				VorgangspositionID: row.ID,
+				Ausschuss:          ueberweisung.Ausschuss,
+				AusschussKuerzel:   ueberweisung.AusschussKuerzel,
+				Federfuehrung:      boolToInt64(ueberweisung.Federfuehrung),
+				Ueberweisungsart:   ueberweisung.Ueberweisungsart,
+			}); err != nil {
+				return fmt.Errorf("CreateUeberweisung: %w", err)
+			}
+		}
+
+		for _, mitberaten := range row.Mitberaten {
+			if err := q.CreateVorgangspositionMitberaten(ctx, db.CreateVorgangspositionMitberatenParams{
+				VorgangspositionID:         row.ID,
+				MitberatenVorgangID:        mitberaten.VorgangID,
+				MitberatenTitel:            mitberaten.Titel,
+				MitberatenVorgangsposition: mitberaten.Vorgangsposition,
+				MitberatenVorgangstyp:      mitberaten.Vorgangstyp,
+			}); err != nil {
+				return fmt.Errorf("CreateVorgangspositionMitberaten: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *sqliteStore) UpsertAktivitaet(ctx context.Context, aktivitaet client.Aktivitaet) error {
+	row := mapAktivitaet(aktivitaet)
+	fs := row.Fundstelle
+	return s.inTx(ctx, func(q *db.Queries) error {
+		if err := q.UpsertAktivitaet(ctx, db.UpsertAktivitaetParams{
+			ID:                        row.ID,
+			Titel:                     row.Titel,
+			Aktivitaetsart:            row.Aktivitaetsart,
+			Typ:                       row.Typ,
+			Dokumentart:               row.Dokumentart,
+			Datum:                     sqliteDate(row.Datum),
+			Aktualisiert:              sqliteTimestamp(row.Aktualisiert),
+			Abstract:                  row.Abstract,
+			VorgangsbezugAnzahl:       row.VorgangsbezugAnzahl,
+			Wahlperiode:               int64(row.Wahlperiode),
+			FundstelleDokumentnummer:  fs.Dokumentnummer,
+			FundstelleDatum:           sqliteDate(fs.Datum),
+			FundstelleDokumentart:     fs.Dokumentart,
+			FundstelleHerausgeber:     fs.Herausgeber,
+			FundstelleID:              fs.ID,
+			FundstelleDrucksachetyp:   fs.Drucksachetyp,
+			FundstelleAnlagen:         fs.Anlagen,
+			FundstelleAnfangsseite:    fs.Anfangsseite,
+			FundstelleEndseite:        fs.Endseite,
+			FundstelleAnfangsquadrant: fs.Anfangsquadrant,
+			FundstelleEndquadrant:     fs.Endquadrant,
+			FundstelleSeite:           fs.Seite,
+			FundstellePdfUrl:          fs.PdfUrl,
+			FundstelleXmlUrl:          fs.XmlUrl,
+			FundstelleTop:             fs.Top,
+			FundstelleTopZusatz:       fs.TopZusatz,
+			FundstelleFrageNummer:     fs.FrageNummer,
+			FundstelleVerteildatum:    sqliteNullDate(fs.Verteildatum),
+		}); err != nil {
+			return fmt.Errorf("UpsertAktivitaet: %w", err)
+		}
+		if err := deleteChildren(ctx, row.ID,
+			q.DeleteAktivitaetDeskriptoren,
+			q.DeleteAktivitaetVorgangsbezuege,
+		); err != nil {
+			return err
+		}
+
+		for _, desk := range row.Deskriptoren {
+			// DO NOTHING on conflict returns no row
+			if _, err := q.CreateAktivitaetDeskriptor(ctx, db.CreateAktivitaetDeskriptorParams{
+				AktivitaetID: row.ID,
+				Name:         desk.Name,
+				Typ:          desk.Typ,
+			}); err != nil && err != sql.ErrNoRows {
+				return fmt.Errorf("CreateAktivitaetDeskriptor: %w", err)
+			}
+		}
+
+		for idx, bezug := range row.Vorgangsbezuege {
+			if err := q.CreateAktivitaetVorgangsbezug(ctx, db.CreateAktivitaetVorgangsbezugParams{
+				AktivitaetID:     row.ID,
+				VorgangID:        bezug.VorgangID,
+				Titel:            bezug.Titel,
+				Vorgangsposition: bezug.Vorgangsposition,
+				Vorgangstyp:      bezug.Vorgangstyp,
+				DisplayOrder:     int64(idx),
+			}); err != nil {
+				return fmt.Errorf("CreateAktivitaetVorgangsbezug: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *sqliteStore) UpsertDrucksache(ctx context.Context, drucksache client.Drucksache) error {
+	row := mapDrucksache(drucksache)
+	fs := row.Fundstelle
+	return s.inTx(ctx, func(q *db.Queries) error {
+		if err := q.UpsertDrucksache(ctx, db.UpsertDrucksacheParams{
+			ID:                        row.ID,
+			Titel:                     row.Titel,
+			Dokumentnummer:            row.Dokumentnummer,
+			Dokumentart:               row.Dokumentart,
+			Typ:                       row.Typ,
+			Drucksachetyp:             row.Drucksachetyp,
+			Herausgeber:               row.Herausgeber,
+			Datum:                     sqliteDate(row.Datum),
+			Aktualisiert:              sqliteTimestamp(row.Aktualisiert),
+			Anlagen:                   row.Anlagen,
+			AutorenAnzahl:             row.AutorenAnzahl,
+			VorgangsbezugAnzahl:       row.VorgangsbezugAnzahl,
+			PdfHash:                   row.PdfHash,
+			Wahlperiode:               row.Wahlperiode,
+			FundstelleDokumentnummer:  fs.Dokumentnummer,
+			FundstelleDatum:           sqliteDate(fs.Datum),
+			FundstelleDokumentart:     fs.Dokumentart,
+			FundstelleHerausgeber:     fs.Herausgeber,
+			FundstelleID:              fs.ID,
+			FundstelleDrucksachetyp:   fs.Drucksachetyp,
+			FundstelleAnlagen:         fs.Anlagen,
+			FundstelleAnfangsseite:    fs.Anfangsseite,
+			FundstelleEndseite:        fs.Endseite,
+			FundstelleAnfangsquadrant: fs.Anfangsquadrant,
+			FundstelleEndquadrant:     fs.Endquadrant,
+			FundstelleSeite:           fs.Seite,
+			FundstellePdfUrl:          fs.PdfUrl,
+			FundstelleXmlUrl:          fs.XmlUrl,
+			FundstelleTop:             fs.Top,
+			FundstelleTopZusatz:       fs.TopZusatz,
+			FundstelleFrageNummer:     fs.FrageNummer,
+			FundstelleVerteildatum:    sqliteNullDate(fs.Verteildatum),
+		}); err != nil {
+			return fmt.Errorf("UpsertDrucksache: %w", err)
+		}
+		if err := deleteChildren(ctx, row.ID,
+			q.DeleteDrucksacheAutorAnzeigen,
+			q.DeleteDrucksacheRessorts,
+			q.DeleteDrucksacheUrheber,
+			q.DeleteDrucksacheVorgangsbezuege,
+			byNullID(q.DeleteDrucksacheFundstelleUrheber),
+		); err != nil {
+			return err
+		}
+
+		for idx, autor := range row.Autoren {
+			if _, err := q.CreateDrucksacheAutorAnzeige(ctx, db.CreateDrucksacheAutorAnzeigeParams{
+				DrucksacheID: row.ID,
+				PersonID:     autor.PersonID,
+				AutorTitel:   autor.AutorTitel,
+				Title:        autor.Title,
+				DisplayOrder: int64(idx),
+			}); err != nil {
+				return fmt.Errorf("CreateDrucksacheAutorAnzeige: %w", err)
+			}
+		}
+
+		if err := s.createRessortLinks(ctx, q, row.Ressorts, func(ressortID, federfuehrend int64) error {
+			if err := q.CreateDrucksacheRessort(ctx, db.CreateDrucksacheRessortParams{
+				DrucksacheID:  row.ID,
+				RessortID:     ressortID,
+				Federfuehrend: federfuehrend,
+			}); err != nil {
+				return fmt.Errorf("CreateDrucksacheRessort: %w", err)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if err := s.createUrheberLinks(ctx, q, row.Urheber, func(urheberID int64, u urheberRow) error {
+			if err := q.CreateDrucksacheUrheber(ctx, db.CreateDrucksacheUrheberParams{
+				DrucksacheID: row.ID,
+				UrheberID:    urheberID,
+				Rolle:        u.Rolle,
+				Einbringer:   nullBoolToNullInt64(u.Einbringer),
+			}); err != nil {
+				return fmt.Errorf("CreateDrucksacheUrheber: %w", err)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for idx, bezug := range row.Vorgangsbezuege {
+			if err := q.CreateDrucksacheVorgangsbezug(ctx, db.CreateDrucksacheVorgangsbezugParams{
+				DrucksacheID: row.ID,
+				VorgangID:    bezug.VorgangID,
+				Titel:        bezug.Titel,
+				Vorgangstyp:  bezug.Vorgangstyp,
+				DisplayOrder: int64(idx),
+			}); err != nil {
+				return fmt.Errorf("CreateDrucksacheVorgangsbezug: %w", err)
+			}
+		}
+
+		for _, urheber := range fs.Urheber {
+			if _, err := q.CreateFundstelleUrheber(ctx, db.CreateFundstelleUrheberParams{
+				DrucksacheID: sql.NullString{String: row.ID, Valid: true},
+				Urheber:      urheber,
+			}); err != nil {
+				return fmt.Errorf("CreateFundstelleUrheber: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *sqliteStore) UpsertDrucksacheText(ctx context.Context, text client.DrucksacheText) error {
+	row := textRow{ID: text.Id, Text: ptrToNullString(text.Text)}
+	// The text upsert also handles the drucksache metadata via ON CONFLICT
+	if _, err := s.queries(ctx).CreateDrucksacheText(ctx, db.CreateDrucksacheTextParams{ID: row.ID, Text: row.Text}); err != nil {
+		return fmt.Errorf("CreateDrucksacheText: %w", err)
+	}
+	return nil
+}
+
+func (s *sqliteStore) UpsertPlenarprotokoll(ctx context.Context, plenarprotokoll client.Plenarprotokoll) error {
+	row := mapPlenarprotokoll(plenarprotokoll)
+	fs := row.Fundstelle
+	return s.inTx(ctx, func(q *db.Queries) error {
+		if err := q.UpsertPlenarprotokoll(ctx, db.UpsertPlenarprotokollParams{
+			ID:                        row.ID,
+			Titel:                     row.Titel,
+			Dokumentnummer:            row.Dokumentnummer,
+			Dokumentart:               row.Dokumentart,
+			Typ:                       row.Typ,
+			Herausgeber:               row.Herausgeber,
+			Datum:                     sqliteDate(row.Datum),
+			Aktualisiert:              sqliteTimestamp(row.Aktualisiert),
+			PdfHash:                   row.PdfHash,
+			Sitzungsbemerkung:         row.Sitzungsbemerkung,
+			VorgangsbezugAnzahl:       row.VorgangsbezugAnzahl,
+			Wahlperiode:               row.Wahlperiode,
+			FundstelleDokumentnummer:  fs.Dokumentnummer,
+			FundstelleDatum:           sqliteDate(fs.Datum),
+			FundstelleDokumentart:     fs.Dokumentart,
+			FundstelleHerausgeber:     fs.Herausgeber,
+			FundstelleID:              fs.ID,
+			FundstelleAnfangsseite:    fs.Anfangsseite,
+			FundstelleEndseite:        fs.Endseite,
+			FundstelleAnfangsquadrant: fs.Anfangsquadrant,
+			FundstelleEndquadrant:     fs.Endquadrant,
+			FundstelleSeite:           fs.Seite,
+			FundstellePdfUrl:          fs.PdfUrl,
+			FundstelleXmlUrl:          fs.XmlUrl,
+			FundstelleTop:             fs.Top,
+			FundstelleTopZusatz:       fs.TopZusatz,
+		}); err != nil {
+			return fmt.Errorf("UpsertPlenarprotokoll: %w", err)
+		}
+		if err := deleteChildren(ctx, row.ID,
+			q.DeletePlenarprotokollVorgangsbezuege,
+			byNullID(q.DeletePlenarprotokollFundstelleUrheber),
+		); err != nil {
+			return err
+		}
+
+		for idx, bezug := range row.Vorgangsbezuege {
+			if err := q.CreatePlenarprotokollVorgangsbezug(ctx, db.CreatePlenarprotokollVorgangsbezugParams{
+				PlenarprotokollID: row.ID,
+				VorgangID:         bezug.VorgangID,
+				Titel:             bezug.Titel,
+				Vorgangstyp:       bezug.Vorgangstyp,
+				DisplayOrder:      int64(idx),
+			}); err != nil {
+				return fmt.Errorf("CreatePlenarprotokollVorgangsbezug: %w", err)
+			}
+		}
+
+		for _, urheber := range fs.Urheber {
+			if _, err := q.CreateFundstelleUrheber(ctx, db.CreateFundstelleUrheberParams{
+				PlenarprotokollID: sql.NullString{String: row.ID, Valid: true},
+				Urheber:           urheber,
+			}); err != nil {
+				return fmt.Errorf("CreateFundstelleUrheber: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *sqliteStore) UpsertPlenarprotokollText(ctx context.Context, text client.PlenarprotokollText) error {
+	row := textRow{ID: text.Id, Text: ptrToNullString(text.Text)}
+	if _, err := s.queries(ctx).CreatePlenarprotokollText(ctx, db.CreatePlenarprotokollTextParams{ID: row.ID, Text: row.Text}); err != nil {
+		return fmt.Errorf("CreatePlenarprotokollText: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+// Package store persists DIP entities in one of the supported database backends.
+// The sync commands only talk to the Store interface, so every sync can write
+// either to SQLite (the default) or to PostgreSQL.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Johanneslueke/dip-client/internal/database"
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	dipclient "github.com/Johanneslueke/dip-client/pkg/dip-client"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/pressly/goose/v3"
+)
+
+// Supported database drivers
+const (
+	SQLite   = "sqlite"
+	Postgres = "postgres"
+)
+
+// Table is a main entity table that is written by a sync
+type Table string
+
+const (
+	PersonTable           Table = "person"
+	VorgangTable          Table = "vorgang"
+	VorgangspositionTable Table = "vorgangsposition"
+	AktivitaetTable       Table = "aktivitaet"
+	DrucksacheTable       Table = "drucksache"
+	PlenarprotokollTable  Table = "plenarprotokoll"
+)
+
+// Link is a link column that references the id of another synced entity
+type Link string
+
+const (
+	LinkVerlinkungVorgang                         Link = "vorgang_verlinkung.target_vorgang_id"
+	LinkVorgangspositionVorgang                   Link = "vorgangsposition.vorgang_id"
+	LinkMitberatenVorgang                         Link = "vorgangsposition_mitberaten.mitberaten_vorgang_id"
+	LinkVorgangspositionFundstelleDrucksache      Link = "vorgangsposition.fundstelle_id (Drucksache)"
+	LinkVorgangspositionFundstellePlenarprotokoll Link = "vorgangsposition.fundstelle_id (Plenarprotokoll)"
+	LinkDrucksacheVorgangsbezug                   Link = "drucksache_vorgangsbezug.vorgang_id"
+	LinkAutorAnzeigePerson                        Link = "drucksache_autor_anzeige.person_id"
+	LinkPlenarprotokollVorgangsbezug              Link = "plenarprotokoll_vorgangsbezug.vorgang_id"
+	LinkAktivitaetVorgangsbezug                   Link = "aktivitaet_vorgangsbezug.vorgang_id"
+	LinkAktivitaetFundstelleDrucksache            Link = "aktivitaet.fundstelle_id (Drucksache)"
+	LinkAktivitaetFundstellePlenarprotokoll       Link = "aktivitaet.fundstelle_id (Plenarprotokoll)"
+)
+
+// PersonWithArrayWahlperiode handles the API response where wahlperiode is an array
+type PersonWithArrayWahlperiode struct {
+	Id               string                  `json:"id"`
+	Nachname         string                  `json:"nachname"`
+	Vorname          string                  `json:"vorname"`
+	Namenszusatz     *string                 `json:"namenszusatz,omitempty"`
+	Typ              string                  `json:"typ"`
+	WahlperiodeArray *[]int32                `json:"wahlperiode,omitempty"`
+	Basisdatum       *types.Date             `json:"basisdatum,omitempty"`
+	Datum            *types.Date             `json:"datum,omitempty"`
+	Aktualisiert     time.Time               `json:"aktualisiert"`
+	Titel            string                  `json:"titel"`
+	PersonRoles      *[]dipclient.PersonRole `json:"person_roles,omitempty"`
+}
+
+// HistoryEntry is a previous version of a main entity row
+type HistoryEntry struct {
+	ID           string    // id of the entity
+	Aktualisiert time.Time // aktualisiert of the previous version
+	SyncRunID    string
+	Snapshot     string // previous row as JSON object
+	Diff         string // changed columns as JSON object
+}
+
+// Store writes synced entities to a database backend.
+//
+// Each Upsert method maps the API type to rows once (see rows.go) and writes
+// the entity together with its child rows in one transaction. Existing rows
+// are overwritten and their child rows replaced, so the stored entity matches
+// the latest payload. If any row fails, nothing of the entity is written.
+type Store interface {
+	// Driver returns the database driver, SQLite or Postgres
+	Driver() string
+	// DB returns the underlying connection pool
+	DB() *sql.DB
+	Close() error
+
+	UpsertPerson(ctx context.Context, person PersonWithArrayWahlperiode) error
+	UpsertVorgang(ctx context.Context, vorgang client.Vorgang) error
+	UpsertVorgangsposition(ctx context.Context, vorgangsposition client.Vorgangsposition) error
+	UpsertAktivitaet(ctx context.Context, aktivitaet client.Aktivitaet) error
+	UpsertDrucksache(ctx context.Context, drucksache client.Drucksache) error
+	UpsertDrucksacheText(ctx context.Context, text client.DrucksacheText) error
+	UpsertPlenarprotokoll(ctx context.Context, plenarprotokoll client.Plenarprotokoll) error
+	UpsertPlenarprotokollText(ctx context.Context, text client.PlenarprotokollText) error
+
+	// EarliestDatum returns the earliest datum stored in the table; ok is false if the table is empty
+	EarliestDatum(ctx context.Context, table Table) (datum time.Time, ok bool, err error)
+
+	// DanglingIDs returns the distinct IDs in a link column that have no row in the referenced table
+	DanglingIDs(ctx context.Context, link Link) ([]string, error)
+
+	// IDsByWahlperiode returns the IDs of all non-deleted rows of a Wahlperiode
+	IDsByWahlperiode(ctx context.Context, table Table, wahlperiode int) ([]string, error)
+	// MarkDeleted sets deleted_at of a row
+	MarkDeleted(ctx context.Context, table Table, id string, deletedAt time.Time) error
+
+	// InTx runs fn in one transaction. The upserts, LoadRow and CreateHistory join it
+	// when they are called with the context passed to fn, so they are committed or
+	// rolled back together.
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+
+	// LoadRow returns a row of a main table as column map, or nil if it does not exist
+	LoadRow(ctx context.Context, table Table, id string) (map[string]interface{}, error)
+	CreateSyncRun(ctx context.Context, id, resourceName string) error
+	CreateHistory(ctx context.Context, table Table, entry HistoryEntry) error
+	// HistoryAsOf returns the snapshot of the latest previous version that was current at the given time.
+	// It returns sql.ErrNoRows if there is none.
+	HistoryAsOf(ctx context.Context, table Table, id string, at time.Time) (string, error)
+}
+
+// Option configures Open
+type Option func(*options)
+
+type options struct {
+	logger *log.Logger
+}
+
+// WithLogger logs the migrations to l instead of the standard logger, e.g.
+// log.New(io.Discard, "", 0) for a scratch database
+func WithLogger(l *log.Logger) Option {
+	return func(o *options) { o.logger = l }
+}
+
+// Open connects to the database and runs all migrations of the driver
+func Open(driver, dsn string, opts ...Option) (Store, error) {
+	o := options{logger: log.Default()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	switch driver {
+	case SQLite, "":
+		return openSQLite(dsn, o)
+	case Postgres:
+		return openPostgres(dsn, o)
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q (use %q or %q)", driver, SQLite, Postgres)
+	}
+}
+
+// migrate runs the embedded goose migrations of a driver and logs the applied
+// ones to logger; unlike goose.Up, the provider leaves the global goose logger alone.
+func migrate(sqlDB *sql.DB, dialect goose.Dialect, dir string, logger *log.Logger) error {
+	migrations, err := fs.Sub(database.Migrations, dir)
+	if err != nil {
+		return err
+	}
+	provider, err := goose.NewProvider(dialect, sqlDB, migrations, goose.WithLogger(logger))
+	if err != nil {
+		return fmt.Errorf("failed to create goose provider: %w", err)
+	}
+
+	results, err := provider.Up(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+	for _, r := range results {
+		logger.Printf("OK   %s (%s)", path.Base(r.Source.Path), r.Duration.Round(10*time.Microsecond))
+	}
+
+	logger.Printf("Successfully ran all database migrations")
+	return nil
+}
+
+type txKey struct{}
+
+// txFrom returns the transaction that runInTx stored in ctx, or nil
+func txFrom(ctx context.Context) *sql.Tx {
+	tx, _ := ctx.Value(txKey{}).(*sql.Tx)
+	return tx
+}
+
+// runInTx runs fn in a transaction and commits it if fn succeeds. If ctx already
+// carries a transaction, fn joins it and the outermost caller commits.
+func runInTx(ctx context.Context, sqlDB *sql.DB, fn func(ctx context.Context) error) error {
+	if txFrom(ctx) != nil {
+		return fn(ctx)
+	}
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// querier is implemented by *sql.DB and *sql.Tx
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// conn returns the transaction of ctx if there is one, otherwise sqlDB
+func conn(ctx context.Context, sqlDB *sql.DB) querier {
+	if tx := txFrom(ctx); tx != nil {
+		return tx
+	}
+	return sqlDB
+}
+
+// loadRow scans the first row of a query into a column map. It returns nil if there is no row.
+func loadRow(ctx context.Context, q querier, query string, args ...interface{}) (map[string]interface{}, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	if err := rows.Scan(pointers...); err != nil {
+		return nil, err
+	}
+
+	row := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		if b, ok := values[i].([]byte); ok {
+			row[column] = string(b)
+		} else {
+			row[column] = values[i]
+		}
+	}
+	return row, nil
+}
+
+// checkTable guards the table names that are put into SQL statements
+func checkTable(table Table) error {
+	switch table {
+	case PersonTable, VorgangTable, VorgangspositionTable, AktivitaetTable, DrucksacheTable, PlenarprotokollTable:
+		return nil
+	}
+	return fmt.Errorf("unknown table %q", table)
+}
+
+// errUnknownLink is returned for links without a dangling query
+func errUnknownLink(link Link) error {
+	return fmt.Errorf("unknown link %q", link)
+}
+
+// deleteChildren removes the child rows of an entity before they are written
+// again, so they match the latest payload exactly
+func deleteChildren(ctx context.Context, id string, deletes ...func(context.Context, string) error) error {
+	for _, del := range deletes {
+		if err := del(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete child rows of %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// byNullID adapts a delete query on a nullable parent column to deleteChildren
+func byNullID(del func(context.Context, sql.NullString) error) func(context.Context, string) error {
+	return func(ctx context.Context, id string) error {
+		return del(ctx, sql.NullString{String: id, Valid: true})
+	}
+}
+
+// parseDatum parses the result of an EarliestDatum query, which is a date
+// string in SQLite and a time.Time in PostgreSQL
+func parseDatum(value interface{}) (time.Time, bool, error) {
+	switch v := value.(type) {
+	case nil:
+		return time.Time{}, false, nil
+	case time.Time:
+		return v, true, nil
+	case string:
+		t, err := time.Parse("2006-01-02", strings.TrimSpace(v))
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		return t, true, nil
+	case []byte:
+		return parseDatum(string(v))
+	default:
+		return time.Time{}, false, fmt.Errorf("unexpected datum type %T", value)
+	}
+}
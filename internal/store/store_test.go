@@ -0,0 +1,226 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const drucksacheJSON = `{
+	"id": "264030",
+	"titel": "Entwurf eines Gesetzes zur Stärkung der Wärmeplanung",
+	"dokumentnummer": "20/8654",
+	"dokumentart": "Drucksache",
+	"typ": "Dokument",
+	"drucksachetyp": "Gesetzentwurf",
+	"herausgeber": "BT",
+	"datum": "2023-10-02",
+	"aktualisiert": "2023-10-05T11:12:13+02:00",
+	"autoren_anzahl": 2,
+	"vorgangsbezug_anzahl": 1,
+	"wahlperiode": 20,
+	"fundstelle": {
+		"id": "264030",
+		"dokumentnummer": "20/8654",
+		"datum": "2023-10-02",
+		"dokumentart": "Drucksache",
+		"herausgeber": "BT",
+		"drucksachetyp": "Gesetzentwurf",
+		"anfangsseite": 1,
+		"verteildatum": "2023-10-03",
+		"urheber": ["Bundesregierung"]
+	},
+	"autoren_anzeige": [
+		{"id": "7001", "autor_titel": "Klara Geywitz, Bundesministerin", "title": "Klara Geywitz"},
+		{"id": "7002", "autor_titel": "Robert Habeck, Bundesminister", "title": "Robert Habeck"}
+	],
+	"ressort": [{"titel": "Bundesministerium für Wohnen", "federfuehrend": true}],
+	"urheber": [{"bezeichnung": "BRg", "titel": "Bundesregierung", "einbringer": true}],
+	"vorgangsbezug": [{"id": "303271", "titel": "Wärmeplanungsgesetz", "vorgangstyp": "Gesetzgebung"}]
+}`
+
+func testDrucksache(t *testing.T) client.Drucksache {
+	t.Helper()
+	var drucksache client.Drucksache
+	require.NoError(t, json.Unmarshal([]byte(drucksacheJSON), &drucksache))
+	return drucksache
+}
+
+func TestMapDrucksache(t *testing.T) {
+	row := mapDrucksache(testDrucksache(t))
+
+	assert.Equal(t, "264030", row.ID)
+	assert.Equal(t, "2023-10-02", sqliteDate(row.Datum))
+	assert.Equal(t, int64(20), row.Wahlperiode.Int64)
+	assert.True(t, row.Wahlperiode.Valid)
+	assert.False(t, row.PdfHash.Valid)
+
+	assert.Equal(t, int64(1), row.Fundstelle.Anfangsseite.Int64)
+	assert.False(t, row.Fundstelle.Endseite.Valid)
+	assert.Equal(t, "2023-10-03", sqliteNullDate(row.Fundstelle.Verteildatum).String)
+
+	require.Len(t, row.Autoren, 2)
+	assert.Equal(t, "7002", row.Autoren[1].PersonID)
+	assert.Equal(t, []ressortRow{{Titel: "Bundesministerium für Wohnen", Federfuehrend: true}}, row.Ressorts)
+	require.Len(t, row.Urheber, 1)
+	assert.True(t, row.Urheber[0].Einbringer.Bool)
+	assert.False(t, row.Urheber[0].Rolle.Valid)
+	assert.Equal(t, []vorgangsbezugRow{{VorgangID: "303271", Titel: "Wärmeplanungsgesetz", Vorgangstyp: "Gesetzgebung"}}, row.Vorgangsbezuege)
+}
+
+func TestMapDrucksache_OptionalChildren(t *testing.T) {
+	drucksache := testDrucksache(t)
+	drucksache.AutorenAnzeige = nil
+	drucksache.Ressort = nil
+	drucksache.Urheber = nil
+	drucksache.Vorgangsbezug = nil
+	drucksache.Wahlperiode = nil
+
+	row := mapDrucksache(drucksache)
+
+	assert.Empty(t, row.Autoren)
+	assert.Empty(t, row.Ressorts)
+	assert.Empty(t, row.Urheber)
+	assert.Empty(t, row.Vorgangsbezuege)
+	assert.False(t, row.Wahlperiode.Valid)
+}
+
+func openTestSQLite(t *testing.T) Store {
+	t.Helper()
+	s, err := Open(SQLite, filepath.Join(t.TempDir(), "dip.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+// openTestPostgres opens the PostgreSQL database of $DIP_TEST_DSN. The test is
+// skipped if it is not set; the rows written by the test are kept.
+func openTestPostgres(t *testing.T) Store {
+	t.Helper()
+	dsn := os.Getenv("DIP_TEST_DSN")
+	if dsn == "" {
+		t.Skip("DIP_TEST_DSN not set")
+	}
+	s, err := Open(Postgres, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func count(t *testing.T, s Store, query string, args ...interface{}) int {
+	t.Helper()
+	var n int
+	require.NoError(t, s.DB().QueryRow(query, args...).Scan(&n))
+	return n
+}
+
+func TestSQLiteUpsertDrucksache(t *testing.T) {
+	ctx := context.Background()
+	s := openTestSQLite(t)
+	drucksache := testDrucksache(t)
+
+	require.NoError(t, s.UpsertDrucksache(ctx, drucksache))
+
+	row, err := s.LoadRow(ctx, DrucksacheTable, drucksache.Id)
+	require.NoError(t, err)
+	assert.Equal(t, drucksache.Titel, row["titel"])
+	assert.Equal(t, "2023-10-02", row["datum"])
+	assert.Equal(t, "2023-10-03", row["fundstelle_verteildatum"])
+	assert.Equal(t, 2, count(t, s, "SELECT COUNT(*) FROM drucksache_autor_anzeige WHERE drucksache_id = ?", drucksache.Id))
+	assert.Equal(t, 1, count(t, s, "SELECT COUNT(*) FROM drucksache_ressort WHERE drucksache_id = ?", drucksache.Id))
+	assert.Equal(t, 1, count(t, s, "SELECT COUNT(*) FROM drucksache_urheber WHERE drucksache_id = ? AND einbringer = 1", drucksache.Id))
+	assert.Equal(t, 1, count(t, s, "SELECT COUNT(*) FROM drucksache_vorgangsbezug WHERE drucksache_id = ?", drucksache.Id))
+
+	// A second upsert updates the row without duplicating children
+	drucksache.Titel = "Entwurf eines Gesetzes für die Wärmeplanung"
+	require.NoError(t, s.UpsertDrucksache(ctx, drucksache))
+
+	row, err = s.LoadRow(ctx, DrucksacheTable, drucksache.Id)
+	require.NoError(t, err)
+	assert.Equal(t, drucksache.Titel, row["titel"])
+	assert.Equal(t, 2, count(t, s, "SELECT COUNT(*) FROM drucksache_autor_anzeige WHERE drucksache_id = ?", drucksache.Id))
+	assert.Equal(t, 1, count(t, s, "SELECT COUNT(*) FROM drucksache_urheber WHERE drucksache_id = ?", drucksache.Id))
+}
+
+func TestPostgresUpsertDrucksache(t *testing.T) {
+	ctx := context.Background()
+	s := openTestPostgres(t)
+	drucksache := testDrucksache(t)
+
+	require.NoError(t, s.UpsertDrucksache(ctx, drucksache))
+	row, err := s.LoadRow(ctx, DrucksacheTable, drucksache.Id)
+	require.NoError(t, err)
+	assert.Equal(t, drucksache.Titel, row["titel"])
+	assert.Equal(t, 2, count(t, s, "SELECT COUNT(*) FROM drucksache_autor_anzeige WHERE drucksache_id = $1", drucksache.Id))
+	assert.Equal(t, 1, count(t, s, "SELECT COUNT(*) FROM drucksache_urheber WHERE drucksache_id = $1 AND einbringer", drucksache.Id))
+
+	// A second upsert updates the row and replaces the children
+	drucksache.Titel = "Entwurf eines Gesetzes für die Wärmeplanung"
+	autoren := (*drucksache.AutorenAnzeige)[:1]
+	drucksache.AutorenAnzeige = &autoren
+	require.NoError(t, s.UpsertDrucksache(ctx, drucksache))
+
+	row, err = s.LoadRow(ctx, DrucksacheTable, drucksache.Id)
+	require.NoError(t, err)
+	assert.Equal(t, drucksache.Titel, row["titel"])
+	assert.Equal(t, 1, count(t, s, "SELECT COUNT(*) FROM drucksache_autor_anzeige WHERE drucksache_id = $1", drucksache.Id))
+	assert.Equal(t, 1, count(t, s, "SELECT COUNT(*) FROM drucksache_urheber WHERE drucksache_id = $1", drucksache.Id))
+	assert.Equal(t, 1, count(t, s, "SELECT COUNT(*) FROM drucksache_vorgangsbezug WHERE drucksache_id = $1", drucksache.Id))
+}
+
+func TestSQLiteUpsertReplacesChildren(t *testing.T) {
+	ctx := context.Background()
+	s := openTestSQLite(t)
+	drucksache := testDrucksache(t)
+	require.NoError(t, s.UpsertDrucksache(ctx, drucksache))
+
+	// The next payload drops the Urheber, swaps the authors and moves the Fundstelle
+	autoren := *drucksache.AutorenAnzeige
+	autoren[0], autoren[1] = autoren[1], autoren[0]
+	drucksache.Urheber = nil
+	endseite := 4
+	drucksache.Fundstelle.Endseite = &endseite
+	drucksache.Fundstelle.Urheber = []string{"Bundesregierung", "Bundesrat"}
+	require.NoError(t, s.UpsertDrucksache(ctx, drucksache))
+
+	assert.Equal(t, 0, count(t, s, "SELECT COUNT(*) FROM drucksache_urheber WHERE drucksache_id = ?", drucksache.Id))
+	assert.Equal(t, 0, count(t, s, "SELECT display_order FROM drucksache_autor_anzeige WHERE drucksache_id = ? AND person_id = '7002'", drucksache.Id))
+	assert.Equal(t, 1, count(t, s, "SELECT display_order FROM drucksache_autor_anzeige WHERE drucksache_id = ? AND person_id = '7001'", drucksache.Id))
+	assert.Equal(t, 2, count(t, s, "SELECT COUNT(*) FROM fundstelle_urheber WHERE drucksache_id = ?", drucksache.Id))
+
+	row, err := s.LoadRow(ctx, DrucksacheTable, drucksache.Id)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, row["fundstelle_endseite"])
+}
+
+func TestSQLiteUpsertRollsBackOnError(t *testing.T) {
+	ctx := context.Background()
+	s := openTestSQLite(t)
+	drucksache := testDrucksache(t)
+
+	// Let the last child insert fail, after the drucksache row was written
+	_, err := s.DB().Exec(`CREATE TRIGGER fail_vorgangsbezug BEFORE INSERT ON drucksache_vorgangsbezug
+		BEGIN SELECT RAISE(ABORT, 'vorgangsbezug rejected'); END`)
+	require.NoError(t, err)
+
+	err = s.UpsertDrucksache(ctx, drucksache)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "CreateDrucksacheVorgangsbezug")
+
+	row, err := s.LoadRow(ctx, DrucksacheTable, drucksache.Id)
+	require.NoError(t, err)
+	assert.Nil(t, row)
+	assert.Equal(t, 0, count(t, s, "SELECT COUNT(*) FROM drucksache_urheber WHERE drucksache_id = ?", drucksache.Id))
+}
+
+func TestWithBusyTimeout(t *testing.T) {
+	assert.Equal(t, "dip.db?_pragma=busy_timeout(10000)&_txlock=immediate", withBusyTimeout("dip.db"))
+	assert.Equal(t, "dip.db?mode=ro&_pragma=busy_timeout(10000)&_txlock=immediate", withBusyTimeout("dip.db?mode=ro"))
+	assert.Equal(t, "dip.db?_pragma=foreign_keys(1)", withBusyTimeout("dip.db?_pragma=foreign_keys(1)"))
+}
@@ -0,0 +1,90 @@
+// Package storetest provides a temporary SQLite store and entity fixtures for
+// the tests of the packages reading the synced database.
+//
+// The fixtures in testdata/ are one Gesetz of the 20th Wahlperiode, the
+// Wärmeplanungsgesetz, as returned by the API. Tests apply their deltas as
+// JSON overlays instead of copying the fixtures.
+package storetest
+
+import (
+	"embed"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/*.json
+var fixtures embed.FS
+
+// Open opens a store on a temporary SQLite database, closed at the end of the test
+func Open(t testing.TB) store.Store {
+	t.Helper()
+	s, err := store.Open(store.SQLite, filepath.Join(t.TempDir(), "dip.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+// Fixture returns testdata/<name>.json with the overlays unmarshaled on top,
+// e.g. `{"id": "1000", "wahlperiode": 19}`. Lists in an overlay replace the
+// lists of the fixture, objects are merged field by field.
+func Fixture[T any](t testing.TB, name string, overlays ...string) T {
+	t.Helper()
+	data, err := fixtures.ReadFile("testdata/" + name + ".json")
+	require.NoError(t, err)
+	var v T
+	require.NoError(t, json.Unmarshal(data, &v), name)
+	for _, overlay := range overlays {
+		require.NoError(t, json.Unmarshal([]byte(overlay), &v), overlay)
+	}
+	return v
+}
+
+// Vorgang returns the Vorgang 303271, a Gesetz verkündet on 2023-12-22
+func Vorgang(t testing.TB, overlays ...string) client.Vorgang {
+	t.Helper()
+	return Fixture[client.Vorgang](t, "vorgang", overlays...)
+}
+
+// Drucksache returns the Gesetzentwurf 20/8654 of Vorgang 303271
+func Drucksache(t testing.TB, overlays ...string) client.Drucksache {
+	t.Helper()
+	return Fixture[client.Drucksache](t, "drucksache", overlays...)
+}
+
+// Vorgangsposition returns the Vorgangsposition 555001, the Gesetzentwurf of
+// Vorgang 303271 citing Drucksache 20/8654
+func Vorgangsposition(t testing.TB, overlays ...string) client.Vorgangsposition {
+	t.Helper()
+	return Fixture[client.Vorgangsposition](t, "vorgangsposition", overlays...)
+}
+
+// Beratung returns the Vorgangsposition 555002, the 1. Beratung of Vorgang
+// 303271 in Plenarprotokoll 20/128
+func Beratung(t testing.TB, overlays ...string) client.Vorgangsposition {
+	t.Helper()
+	return Fixture[client.Vorgangsposition](t, "beratung", overlays...)
+}
+
+// Aktivitaet returns the Aktivität 1400001, the speech of person 7001 in the
+// 1. Beratung
+func Aktivitaet(t testing.TB, overlays ...string) client.Aktivitaet {
+	t.Helper()
+	return Fixture[client.Aktivitaet](t, "aktivitaet", overlays...)
+}
+
+// Person returns the person 7001, an author of Drucksache 20/8654
+func Person(t testing.TB, overlays ...string) store.PersonWithArrayWahlperiode {
+	t.Helper()
+	return Fixture[store.PersonWithArrayWahlperiode](t, "person", overlays...)
+}
+
+// Plenarprotokoll returns the Plenarprotokoll 20/128 of the 1. Beratung
+func Plenarprotokoll(t testing.TB, overlays ...string) client.Plenarprotokoll {
+	t.Helper()
+	return Fixture[client.Plenarprotokoll](t, "plenarprotokoll", overlays...)
+}
@@ -0,0 +1,144 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/store/storetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The tests of this file upsert a fixture, then a changed payload of the same
+// entity, and check that the child rows of the first upsert were replaced.
+
+func countRows(t *testing.T, s store.Store, query string, args ...interface{}) int {
+	t.Helper()
+	var n int
+	require.NoError(t, s.DB().QueryRow(query, args...).Scan(&n))
+	return n
+}
+
+func TestSQLiteUpsertVorgangReplacesChildren(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.Open(t)
+	vorgang := storetest.Vorgang(t)
+	require.NoError(t, s.UpsertVorgang(ctx, vorgang))
+	assert.Equal(t, 2, countRows(t, s, "SELECT COUNT(*) FROM vorgang_deskriptor WHERE vorgang_id = ?", vorgang.Id))
+
+	// The next payload drops a Deskriptor, a Sachgebiet and the Verlinkung
+	require.NoError(t, s.UpsertVorgang(ctx, storetest.Vorgang(t, `{
+		"deskriptor": [{"name": "Klimaschutz", "typ": "Sachbegriffe", "fundstelle": true}],
+		"sachgebiet": ["Energie"],
+		"initiative": ["Bundesregierung", "Bundesrat"],
+		"vorgang_verlinkung": []
+	}`)))
+
+	assert.Equal(t, 1, countRows(t, s, "SELECT COUNT(*) FROM vorgang_deskriptor WHERE vorgang_id = ?", vorgang.Id))
+	assert.Equal(t, 1, countRows(t, s, "SELECT COUNT(*) FROM vorgang_deskriptor WHERE vorgang_id = ? AND name = 'Klimaschutz' AND fundstelle", vorgang.Id))
+	assert.Equal(t, 1, countRows(t, s, "SELECT COUNT(*) FROM vorgang_sachgebiet WHERE vorgang_id = ?", vorgang.Id))
+	assert.Equal(t, 2, countRows(t, s, "SELECT COUNT(*) FROM vorgang_initiative WHERE vorgang_id = ?", vorgang.Id))
+	assert.Equal(t, 0, countRows(t, s, "SELECT COUNT(*) FROM vorgang_verlinkung WHERE source_vorgang_id = ?", vorgang.Id))
+	assert.Equal(t, 1, countRows(t, s, "SELECT COUNT(*) FROM verkuendung WHERE vorgang_id = ?", vorgang.Id))
+}
+
+func TestSQLiteUpsertVorgangspositionReplacesChildren(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.Open(t)
+	vorgangsposition := storetest.Vorgangsposition(t, `{"aktivitaet_anzeige": [
+		{"aktivitaetsart": "Rede", "titel": "Klara Geywitz, Bundesministerin", "seite": "12"},
+		{"aktivitaetsart": "Rede", "titel": "Andreas Jung, MdB, CDU/CSU", "seite": "14"}
+	]}`)
+	require.NoError(t, s.UpsertVorgangsposition(ctx, vorgangsposition))
+	assert.Equal(t, 1, countRows(t, s, "SELECT COUNT(*) FROM vorgangsposition_urheber WHERE vorgangsposition_id = ?", vorgangsposition.Id))
+
+	// The next payload swaps the Aktivitäten, drops the Urheber and changes the Überweisung
+	require.NoError(t, s.UpsertVorgangsposition(ctx, storetest.Vorgangsposition(t, `{
+		"aktivitaet_anzeige": [
+			{"aktivitaetsart": "Rede", "titel": "Andreas Jung, MdB, CDU/CSU", "seite": "14"},
+			{"aktivitaetsart": "Rede", "titel": "Klara Geywitz, Bundesministerin", "seite": "12"}
+		],
+		"urheber": [],
+		"ueberweisung": [
+			{"ausschuss": "Ausschuss für Wohnen, Stadtentwicklung, Bauwesen und Kommunen", "ausschuss_kuerzel": "AfWSBK", "federfuehrung": true},
+			{"ausschuss": "Ausschuss für Klimaschutz und Energie", "ausschuss_kuerzel": "AfKE", "federfuehrung": false}
+		]
+	}`)))
+
+	assert.Equal(t, 2, countRows(t, s, "SELECT COUNT(*) FROM aktivitaet_anzeige WHERE vorgangsposition_id = ?", vorgangsposition.Id))
+	assert.Equal(t, 0, countRows(t, s, "SELECT display_order FROM aktivitaet_anzeige WHERE vorgangsposition_id = ? AND seite = '14'", vorgangsposition.Id))
+	assert.Equal(t, 1, countRows(t, s, "SELECT display_order FROM aktivitaet_anzeige WHERE vorgangsposition_id = ? AND seite = '12'", vorgangsposition.Id))
+	assert.Equal(t, 0, countRows(t, s, "SELECT COUNT(*) FROM vorgangsposition_urheber WHERE vorgangsposition_id = ?", vorgangsposition.Id))
+	assert.Equal(t, 2, countRows(t, s, "SELECT COUNT(*) FROM ueberweisung WHERE vorgangsposition_id = ?", vorgangsposition.Id))
+	assert.Equal(t, 1, countRows(t, s, "SELECT COUNT(*) FROM ueberweisung WHERE vorgangsposition_id = ? AND ausschuss_kuerzel = 'AfWSBK' AND federfuehrung", vorgangsposition.Id))
+}
+
+func TestSQLiteUpsertAktivitaetReplacesChildren(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.Open(t)
+	aktivitaet := storetest.Aktivitaet(t)
+	require.NoError(t, s.UpsertAktivitaet(ctx, aktivitaet))
+	assert.Equal(t, 2, countRows(t, s, "SELECT COUNT(*) FROM aktivitaet_deskriptor WHERE aktivitaet_id = ?", aktivitaet.Id))
+
+	// The next payload drops a Deskriptor and adds a Vorgangsbezug before the existing one
+	require.NoError(t, s.UpsertAktivitaet(ctx, storetest.Aktivitaet(t, `{
+		"deskriptor": [{"name": "Wärmeplanung", "typ": "Sachbegriffe"}],
+		"vorgangsbezug": [
+			{"id": "300001", "titel": "Gebäudeenergiegesetz", "vorgangsposition": "1. Beratung", "vorgangstyp": "Gesetzgebung"},
+			{"id": "303271", "titel": "Wärmeplanungsgesetz", "vorgangsposition": "1. Beratung", "vorgangstyp": "Gesetzgebung"}
+		]
+	}`)))
+
+	assert.Equal(t, 1, countRows(t, s, "SELECT COUNT(*) FROM aktivitaet_deskriptor WHERE aktivitaet_id = ?", aktivitaet.Id))
+	assert.Equal(t, 2, countRows(t, s, "SELECT COUNT(*) FROM aktivitaet_vorgangsbezug WHERE aktivitaet_id = ?", aktivitaet.Id))
+	assert.Equal(t, 0, countRows(t, s, "SELECT display_order FROM aktivitaet_vorgangsbezug WHERE aktivitaet_id = ? AND vorgang_id = '300001'", aktivitaet.Id))
+	assert.Equal(t, 1, countRows(t, s, "SELECT display_order FROM aktivitaet_vorgangsbezug WHERE aktivitaet_id = ? AND vorgang_id = '303271'", aktivitaet.Id))
+}
+
+func TestSQLiteUpsertPlenarprotokollReplacesChildren(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.Open(t)
+	plenarprotokoll := storetest.Plenarprotokoll(t, `{"fundstelle": {"urheber": ["Bundestag"]}}`)
+	require.NoError(t, s.UpsertPlenarprotokoll(ctx, plenarprotokoll))
+	assert.Equal(t, 1, countRows(t, s, "SELECT COUNT(*) FROM fundstelle_urheber WHERE plenarprotokoll_id = ?", plenarprotokoll.Id))
+
+	// The next payload drops the Fundstelle Urheber and reorders the Vorgangsbezüge
+	require.NoError(t, s.UpsertPlenarprotokoll(ctx, storetest.Plenarprotokoll(t, `{
+		"fundstelle": {"urheber": []},
+		"vorgangsbezug_anzahl": 2,
+		"vorgangsbezug": [
+			{"id": "300001", "titel": "Gebäudeenergiegesetz", "vorgangstyp": "Gesetzgebung"},
+			{"id": "303271", "titel": "Wärmeplanungsgesetz", "vorgangstyp": "Gesetzgebung"}
+		]
+	}`)))
+
+	assert.Equal(t, 0, countRows(t, s, "SELECT COUNT(*) FROM fundstelle_urheber WHERE plenarprotokoll_id = ?", plenarprotokoll.Id))
+	assert.Equal(t, 2, countRows(t, s, "SELECT COUNT(*) FROM plenarprotokoll_vorgangsbezug WHERE plenarprotokoll_id = ?", plenarprotokoll.Id))
+	assert.Equal(t, 0, countRows(t, s, "SELECT display_order FROM plenarprotokoll_vorgangsbezug WHERE plenarprotokoll_id = ? AND vorgang_id = '300001'", plenarprotokoll.Id))
+	assert.Equal(t, 1, countRows(t, s, "SELECT display_order FROM plenarprotokoll_vorgangsbezug WHERE plenarprotokoll_id = ? AND vorgang_id = '303271'", plenarprotokoll.Id))
+}
+
+func TestSQLiteUpsertPersonReplacesChildren(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.Open(t)
+	person := storetest.Person(t, `{"wahlperiode": [19, 20]}`)
+	require.NoError(t, s.UpsertPerson(ctx, person))
+	assert.Equal(t, 2, countRows(t, s, "SELECT COUNT(*) FROM person_wahlperiode WHERE person_id = ?", person.Id))
+
+	// The next payload drops a Wahlperiode and replaces the role
+	require.NoError(t, s.UpsertPerson(ctx, storetest.Person(t, `{
+		"wahlperiode": [20],
+		"person_roles": [{
+			"funktion": "MdB", "vorname": "Klara", "nachname": "Geywitz", "fraktion": "SPD",
+			"wahlperiode_nummer": [20]
+		}]
+	}`)))
+
+	assert.Equal(t, 1, countRows(t, s, "SELECT COUNT(*) FROM person_wahlperiode WHERE person_id = ?", person.Id))
+	assert.Equal(t, 1, countRows(t, s, "SELECT COUNT(*) FROM person_wahlperiode WHERE person_id = ? AND wahlperiode_nummer = 20", person.Id))
+	assert.Equal(t, 1, countRows(t, s, "SELECT COUNT(*) FROM person_role WHERE person_id = ?", person.Id))
+	assert.Equal(t, 1, countRows(t, s, "SELECT COUNT(*) FROM person_role WHERE person_id = ? AND funktion = 'MdB' AND fraktion = 'SPD'", person.Id))
+	assert.Equal(t, 1, countRows(t, s, `SELECT COUNT(*) FROM person_role_wahlperiode rw
+		JOIN person_role r ON r.id = rw.person_role_id WHERE r.person_id = ?`, person.Id))
+}
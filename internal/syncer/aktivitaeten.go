@@ -0,0 +1,59 @@
+package syncer
+
+import (
+	"context"
+	"log"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+	dipclient "github.com/Johanneslueke/dip-client/pkg/dip-client"
+)
+
+// fetchAktivitaetList fetches a single page of the Aktivitaet list
+func fetchAktivitaetList(ctx context.Context, c *dipclient.Client, f listFilter, cursor *string) (*utility.BatchResponse, error) {
+	resp, err := c.GetAktivitaetList(ctx, &client.GetAktivitaetListParams{
+		Cursor:       cursor,
+		FDatumEnd:    f.DatumEnd,
+		FWahlperiode: f.Wahlperiode,
+		FId:          f.IDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &utility.BatchResponse{
+		Documents:    resp.Documents,
+		Cursor:       resp.Cursor,
+		NumFound:     int(resp.NumFound),
+		DocumentsLen: len(resp.Documents),
+	}, nil
+}
+
+func extractAktivitaetList(docs interface{}) []interface{} {
+	aktivitaeten := docs.([]client.Aktivitaet)
+	items := make([]interface{}, len(aktivitaeten))
+	for i, d := range aktivitaeten {
+		items[i] = d
+	}
+	return items
+}
+
+func aktivitaetID(item interface{}) string {
+	return item.(client.Aktivitaet).Id
+}
+
+func updateAktivitaetDate(ctx context.Context, s store.Store, item interface{}, checkpointMgr *utility.CheckpointManager) {
+	aktivitaet := item.(client.Aktivitaet)
+	if !aktivitaet.Aktualisiert.IsZero() {
+		updateCheckpointDate(ctx, s, store.AktivitaetTable, aktivitaet.Aktualisiert, checkpointMgr)
+	}
+}
+
+func storeAktivitaet(ctx context.Context, s store.Store, item interface{}, failedTracker *utility.FailedRecordsTracker) {
+	aktivitaet := item.(client.Aktivitaet)
+	if err := s.UpsertAktivitaet(ctx, aktivitaet); err != nil {
+		failedTracker.RecordIfDBLocked(aktivitaet.Id, "UpsertAktivitaet", err)
+		log.Printf("Warning: Failed to store aktivitaet %s: %v", aktivitaet.Id, err)
+	}
+}
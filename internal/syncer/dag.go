@@ -0,0 +1,73 @@
+package syncer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dependencies maps each entity to the entities it references and that should
+// therefore be synced first (see the foreign keys added in migrations 0007 and 0008)
+var dependencies = map[Entity][]Entity{
+	Personen:             nil,
+	Vorgaenge:            {Personen},
+	Vorgangspositionen:   {Vorgaenge},
+	Drucksachen:          {Vorgaenge},
+	Plenarprotokolle:     {Vorgaenge},
+	Aktivitaeten:         {Vorgangspositionen, Drucksachen, Plenarprotokolle},
+	DrucksacheTexte:      {Drucksachen},
+	PlenarprotokollTexte: {Plenarprotokolle},
+}
+
+// Dependencies returns the entities the given entity depends on
+func Dependencies(entity Entity) []Entity {
+	return append([]Entity(nil), dependencies[entity]...)
+}
+
+// Stages orders the given entities into stages. All entities of a stage only
+// depend on entities of earlier stages and can therefore be synced in parallel.
+// Dependencies that are not part of entities are considered satisfied.
+// Within a stage, entities keep the default sync order.
+func Stages(entities []Entity) ([][]Entity, error) {
+	pending := make(map[Entity]bool, len(entities))
+	for _, entity := range entities {
+		if _, ok := Lookup(entity); !ok {
+			return nil, fmt.Errorf("unknown entity %q", entity)
+		}
+		pending[entity] = true
+	}
+
+	var stages [][]Entity
+	for len(pending) > 0 {
+		var stage []Entity
+		for _, def := range definitions {
+			if !pending[def.Entity] {
+				continue
+			}
+			ready := true
+			for _, dep := range dependencies[def.Entity] {
+				if pending[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				stage = append(stage, def.Entity)
+			}
+		}
+
+		if len(stage) == 0 {
+			var cyclic []string
+			for entity := range pending {
+				cyclic = append(cyclic, string(entity))
+			}
+			return nil, fmt.Errorf("dependency cycle between %s", strings.Join(cyclic, ", "))
+		}
+
+		for _, entity := range stage {
+			delete(pending, entity)
+		}
+		stages = append(stages, stage)
+	}
+
+	return stages, nil
+}
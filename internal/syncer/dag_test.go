@@ -0,0 +1,43 @@
+package syncer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStages_AllEntities(t *testing.T) {
+	var entities []Entity
+	for _, def := range Definitions() {
+		entities = append(entities, def.Entity)
+	}
+
+	stages, err := Stages(entities)
+	if err != nil {
+		t.Fatalf("Stages failed: %v", err)
+	}
+
+	assert.Equal(t, [][]Entity{
+		{Personen},
+		{Vorgaenge},
+		{Vorgangspositionen, Drucksachen, Plenarprotokolle},
+		{Aktivitaeten, DrucksacheTexte, PlenarprotokollTexte},
+	}, stages)
+}
+
+func TestStages_MissingDependenciesAreSatisfied(t *testing.T) {
+	stages, err := Stages([]Entity{PlenarprotokollTexte, Drucksachen, DrucksacheTexte})
+	if err != nil {
+		t.Fatalf("Stages failed: %v", err)
+	}
+
+	assert.Equal(t, [][]Entity{
+		{Drucksachen, PlenarprotokollTexte},
+		{DrucksacheTexte},
+	}, stages)
+}
+
+func TestStages_UnknownEntity(t *testing.T) {
+	_, err := Stages([]Entity{"unknown"})
+	assert.Error(t, err)
+}
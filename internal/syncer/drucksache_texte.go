@@ -0,0 +1,52 @@
+package syncer
+
+import (
+	"context"
+	"log"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+	dipclient "github.com/Johanneslueke/dip-client/pkg/dip-client"
+)
+
+// fetchDrucksacheTextList fetches a single page of the DrucksacheText list
+func fetchDrucksacheTextList(ctx context.Context, c *dipclient.Client, f listFilter, cursor *string) (*utility.BatchResponse, error) {
+	resp, err := c.GetDrucksacheTextList(ctx, &client.GetDrucksacheTextListParams{
+		Cursor:       cursor,
+		FDatumEnd:    f.DatumEnd,
+		FWahlperiode: f.Wahlperiode,
+		FId:          f.IDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &utility.BatchResponse{
+		Documents:    resp.Documents,
+		Cursor:       resp.Cursor,
+		NumFound:     int(resp.NumFound),
+		DocumentsLen: len(resp.Documents),
+	}, nil
+}
+
+func extractDrucksacheTextList(docs interface{}) []interface{} {
+	texts := docs.([]client.DrucksacheText)
+	items := make([]interface{}, len(texts))
+	for i, d := range texts {
+		items[i] = d
+	}
+	return items
+}
+
+func drucksacheTextID(item interface{}) string {
+	return item.(client.DrucksacheText).Id
+}
+
+func storeDrucksacheText(ctx context.Context, s store.Store, item interface{}, failedTracker *utility.FailedRecordsTracker) {
+	drucksacheText := item.(client.DrucksacheText)
+	if err := s.UpsertDrucksacheText(ctx, drucksacheText); err != nil {
+		failedTracker.RecordIfDBLocked(drucksacheText.Id, "UpsertDrucksacheText", err)
+		log.Printf("Warning: Failed to store drucksache text %s: %v", drucksacheText.Id, err)
+	}
+}
@@ -0,0 +1,59 @@
+package syncer
+
+import (
+	"context"
+	"log"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+	dipclient "github.com/Johanneslueke/dip-client/pkg/dip-client"
+)
+
+// fetchDrucksacheList fetches a single page of the Drucksache list
+func fetchDrucksacheList(ctx context.Context, c *dipclient.Client, f listFilter, cursor *string) (*utility.BatchResponse, error) {
+	resp, err := c.GetDrucksacheList(ctx, &client.GetDrucksacheListParams{
+		Cursor:       cursor,
+		FDatumEnd:    f.DatumEnd,
+		FWahlperiode: f.Wahlperiode,
+		FId:          f.IDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &utility.BatchResponse{
+		Documents:    resp.Documents,
+		Cursor:       resp.Cursor,
+		NumFound:     int(resp.NumFound),
+		DocumentsLen: len(resp.Documents),
+	}, nil
+}
+
+func extractDrucksacheList(docs interface{}) []interface{} {
+	drucksachen := docs.([]client.Drucksache)
+	items := make([]interface{}, len(drucksachen))
+	for i, d := range drucksachen {
+		items[i] = d
+	}
+	return items
+}
+
+func drucksacheID(item interface{}) string {
+	return item.(client.Drucksache).Id
+}
+
+func updateDrucksacheDate(ctx context.Context, s store.Store, item interface{}, checkpointMgr *utility.CheckpointManager) {
+	drucksache := item.(client.Drucksache)
+	if !drucksache.Datum.Time.IsZero() {
+		updateCheckpointDate(ctx, s, store.DrucksacheTable, drucksache.Datum.Time, checkpointMgr)
+	}
+}
+
+func storeDrucksache(ctx context.Context, s store.Store, item interface{}, failedTracker *utility.FailedRecordsTracker) {
+	drucksache := item.(client.Drucksache)
+	if err := s.UpsertDrucksache(ctx, drucksache); err != nil {
+		failedTracker.RecordIfDBLocked(drucksache.Id, "UpsertDrucksache", err)
+		log.Printf("Warning: Failed to store drucksache %s: %v", drucksache.Id, err)
+	}
+}
@@ -0,0 +1,218 @@
+package syncer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+)
+
+// ErrNoVersion is returned by AsOf if the entity did not exist at the given time
+var ErrNoVersion = errors.New("no version of the entity at the given time")
+
+// historyEntity is the main table of an entity with change history and the
+// upsert whose changes are recorded
+type historyEntity struct {
+	table     store.Table
+	operation string
+	upsert    func(ctx context.Context, s store.Store, item interface{}) error
+}
+
+// historyTables lists all entities that support change history
+var historyTables = map[Entity]historyEntity{
+	Personen: {store.PersonTable, "UpsertPerson", func(ctx context.Context, s store.Store, item interface{}) error {
+		return s.UpsertPerson(ctx, item.(store.PersonWithArrayWahlperiode))
+	}},
+	Vorgaenge: {store.VorgangTable, "UpsertVorgang", func(ctx context.Context, s store.Store, item interface{}) error {
+		return s.UpsertVorgang(ctx, item.(client.Vorgang))
+	}},
+	Vorgangspositionen: {store.VorgangspositionTable, "UpsertVorgangsposition", func(ctx context.Context, s store.Store, item interface{}) error {
+		return s.UpsertVorgangsposition(ctx, item.(client.Vorgangsposition))
+	}},
+	Aktivitaeten: {store.AktivitaetTable, "UpsertAktivitaet", func(ctx context.Context, s store.Store, item interface{}) error {
+		return s.UpsertAktivitaet(ctx, item.(client.Aktivitaet))
+	}},
+	Drucksachen: {store.DrucksacheTable, "UpsertDrucksache", func(ctx context.Context, s store.Store, item interface{}) error {
+		return s.UpsertDrucksache(ctx, item.(client.Drucksache))
+	}},
+	Plenarprotokolle: {store.PlenarprotokollTable, "UpsertPlenarprotokoll", func(ctx context.Context, s store.Store, item interface{}) error {
+		return s.UpsertPlenarprotokoll(ctx, item.(client.Plenarprotokoll))
+	}},
+}
+
+// historyIgnoredColumns are bookkeeping columns that do not count as a change
+var historyIgnoredColumns = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"deleted_at": true,
+}
+
+// SupportsHistory reports whether change history is recorded for the entity
+func SupportsHistory(entity Entity) bool {
+	_, ok := historyTables[entity]
+	return ok
+}
+
+// FieldChange is a single changed column in a history diff
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// withHistory replaces the store function of an entity with one that also writes
+// the previous version of a changed row to the entity's history table. The
+// upsert and the history entry share one transaction, so if the history write
+// fails the upsert is rolled back as well and a retry can still record it.
+func withHistory(sc *utility.SyncContext, def Definition, storeItem utility.StoreItemFunc) utility.StoreItemFunc {
+	h, ok := historyTables[def.Entity]
+	if !ok {
+		return storeItem
+	}
+
+	return func(ctx context.Context, s store.Store, item interface{}, failedTracker *utility.FailedRecordsTracker) {
+		id := def.itemID(item)
+		err := s.InTx(ctx, func(ctx context.Context) error {
+			before, err := s.LoadRow(ctx, h.table, id)
+			if err != nil {
+				return fmt.Errorf("failed to load previous version: %w", err)
+			}
+			if err := h.upsert(ctx, s, item); err != nil {
+				return err
+			}
+			if before == nil {
+				return nil
+			}
+
+			after, err := s.LoadRow(ctx, h.table, id)
+			if err != nil {
+				return fmt.Errorf("failed to load new version: %w", err)
+			}
+			entry, err := historyEntry(sc, h.table, id, before, after)
+			if err != nil || entry == nil {
+				return err
+			}
+			if err := s.CreateHistory(ctx, h.table, *entry); err != nil {
+				return fmt.Errorf("failed to store history: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			failedTracker.RecordIfDBLocked(id, h.operation, err)
+			log.Printf("Warning: Failed to store %s %s: %v", h.table, id, err)
+		}
+	}
+}
+
+// historyEntry returns the history entry for the previous version of a row, or
+// nil if nothing changed or the version cannot be placed in time
+func historyEntry(sc *utility.SyncContext, table store.Table, id string, before, after map[string]interface{}) (*store.HistoryEntry, error) {
+	diff := diffRows(before, after)
+	if len(diff) == 0 {
+		return nil, nil
+	}
+
+	// AsOf could not place an entry without its timestamp in time
+	aktualisiert, ok := rowTime(before["aktualisiert"])
+	if !ok {
+		log.Printf("Warning: Skipping history of %s %s: invalid aktualisiert %v", table, id, before["aktualisiert"])
+		return nil, nil
+	}
+
+	snapshot, err := json.Marshal(before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode history: %w", err)
+	}
+	diffJSON, err := json.Marshal(diff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode history: %w", err)
+	}
+	return &store.HistoryEntry{
+		ID:           id,
+		Aktualisiert: aktualisiert,
+		SyncRunID:    sc.RunID,
+		Snapshot:     string(snapshot),
+		Diff:         string(diffJSON),
+	}, nil
+}
+
+// rowTime converts a timestamp column, which is an RFC 3339 string in SQLite
+// and a time.Time in PostgreSQL
+func rowTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
+
+// diffRows returns the changed columns between two versions of a row
+func diffRows(before, after map[string]interface{}) map[string]FieldChange {
+	diff := make(map[string]FieldChange)
+	for column, old := range before {
+		if historyIgnoredColumns[column] {
+			continue
+		}
+		if updated := after[column]; !reflect.DeepEqual(old, updated) {
+			diff[column] = FieldChange{Old: old, New: updated}
+		}
+	}
+	for column, updated := range after {
+		if _, ok := before[column]; !ok && !historyIgnoredColumns[column] {
+			diff[column] = FieldChange{Old: nil, New: updated}
+		}
+	}
+	return diff
+}
+
+// AsOf reconstructs the main row of an entity as it was at the given time,
+// using the current row and the recorded history. It returns ErrNoVersion if
+// the entity did not exist yet (or no history was recorded for that time).
+//
+// Only the scalar columns of the main table are versioned; child rows such as
+// urheber, deskriptor, sachgebiet or initiative are not part of the snapshot,
+// so the returned row does not tell how they looked at that time.
+func AsOf(ctx context.Context, s store.Store, entity Entity, id string, at time.Time) (map[string]interface{}, error) {
+	h, ok := historyTables[entity]
+	if !ok {
+		return nil, fmt.Errorf("history is not supported for %q", entity)
+	}
+	table := h.table
+
+	current, err := s.LoadRow(ctx, table, id)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	// The current version is valid since its aktualisiert timestamp
+	if t, ok := rowTime(current["aktualisiert"]); ok && !t.After(at) {
+		return current, nil
+	}
+
+	snapshot, err := s.HistoryAsOf(ctx, table, id, at)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNoVersion
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var row map[string]interface{}
+	if err := json.Unmarshal([]byte(snapshot), &row); err != nil {
+		return nil, fmt.Errorf("failed to decode history of %s %s: %w", table, id, err)
+	}
+	return row, nil
+}
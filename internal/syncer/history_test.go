@@ -0,0 +1,116 @@
+package syncer
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/store/storetest"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffRows_ChangedColumns(t *testing.T) {
+	before := map[string]interface{}{
+		"id":           "1",
+		"titel":        "Alt",
+		"aktualisiert": "2024-01-01T00:00:00Z",
+		"updated_at":   "2024-01-01 00:00:00",
+	}
+	after := map[string]interface{}{
+		"id":           "1",
+		"titel":        "Neu",
+		"aktualisiert": "2024-02-01T00:00:00Z",
+		"updated_at":   "2024-02-01 00:00:00",
+	}
+
+	assert.Equal(t, map[string]FieldChange{
+		"titel":        {Old: "Alt", New: "Neu"},
+		"aktualisiert": {Old: "2024-01-01T00:00:00Z", New: "2024-02-01T00:00:00Z"},
+	}, diffRows(before, after))
+}
+
+func TestDiffRows_IgnoresBookkeepingColumns(t *testing.T) {
+	before := map[string]interface{}{"id": "1", "deleted_at": "2024-01-01T00:00:00Z", "updated_at": "a"}
+	after := map[string]interface{}{"id": "1", "deleted_at": nil, "updated_at": "b"}
+
+	assert.Empty(t, diffRows(before, after))
+}
+
+func TestAsOf(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.Open(t)
+	sc := &utility.SyncContext{RunID: "run-1"}
+	require.NoError(t, s.CreateSyncRun(ctx, sc.RunID, string(Drucksachen)))
+	def, ok := Lookup(Drucksachen)
+	require.True(t, ok)
+	storeItem := withHistory(sc, def, def.store)
+	failed := utility.NewFailedRecordsTracker(t.TempDir(), string(Drucksachen))
+
+	// aktualisiert 2023-10-05, changed on 2024-01-15; an unchanged upsert records nothing
+	first := storetest.Drucksache(t)
+	changed := storetest.Drucksache(t, `{"titel": "Entwurf eines Gesetzes für die Wärmeplanung und zur Dekarbonisierung der Wärmenetze", "aktualisiert": "2024-01-15T10:00:00+01:00"}`)
+	storeItem(ctx, s, first, failed)
+	storeItem(ctx, s, changed, failed)
+	storeItem(ctx, s, changed, failed)
+
+	row, err := AsOf(ctx, s, Drucksachen, first.Id, time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, first.Titel, row["titel"])
+
+	row, err = AsOf(ctx, s, Drucksachen, first.Id, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, changed.Titel, row["titel"])
+
+	_, err = AsOf(ctx, s, Drucksachen, first.Id, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, ErrNoVersion)
+	_, err = AsOf(ctx, s, Drucksachen, "1", time.Now())
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = AsOf(ctx, s, DrucksacheTexte, first.Id, time.Now())
+	assert.ErrorContains(t, err, "not supported")
+
+	// A version without a valid aktualisiert is not recorded
+	_, err = s.DB().ExecContext(ctx, `UPDATE drucksache SET aktualisiert = '2024-01-15' WHERE id = ?`, first.Id)
+	require.NoError(t, err)
+	storeItem(ctx, s, first, failed)
+	var n int
+	require.NoError(t, s.DB().QueryRowContext(ctx, `SELECT COUNT(*) FROM drucksache_history`).Scan(&n))
+	assert.Equal(t, 1, n)
+}
+
+func TestWithHistory_RollsBackUpsertIfHistoryFails(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.Open(t)
+	sc := &utility.SyncContext{RunID: "run-1"}
+	require.NoError(t, s.CreateSyncRun(ctx, sc.RunID, string(Drucksachen)))
+	def, ok := Lookup(Drucksachen)
+	require.True(t, ok)
+	storeItem := withHistory(sc, def, def.store)
+	failed := utility.NewFailedRecordsTracker(t.TempDir(), string(Drucksachen))
+
+	first := storetest.Drucksache(t)
+	changed := storetest.Drucksache(t, `{"titel": "Geänderter Titel", "aktualisiert": "2024-01-15T10:00:00+01:00"}`)
+	storeItem(ctx, s, first, failed)
+
+	// Without a history table the history write fails and the upsert is undone
+	_, err := s.DB().ExecContext(ctx, `ALTER TABLE drucksache_history RENAME TO drucksache_history_away`)
+	require.NoError(t, err)
+	storeItem(ctx, s, changed, failed)
+	row, err := s.LoadRow(ctx, store.DrucksacheTable, first.Id)
+	require.NoError(t, err)
+	assert.Equal(t, first.Titel, row["titel"])
+
+	// The retry records the previous version
+	_, err = s.DB().ExecContext(ctx, `ALTER TABLE drucksache_history_away RENAME TO drucksache_history`)
+	require.NoError(t, err)
+	storeItem(ctx, s, changed, failed)
+	row, err = s.LoadRow(ctx, store.DrucksacheTable, first.Id)
+	require.NoError(t, err)
+	assert.Equal(t, changed.Titel, row["titel"])
+	var n int
+	require.NoError(t, s.DB().QueryRowContext(ctx, `SELECT COUNT(*) FROM drucksache_history`).Scan(&n))
+	assert.Equal(t, 1, n)
+}
@@ -0,0 +1,118 @@
+package syncer
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/Johanneslueke/dip-client/internal/utility"
+)
+
+// Options controls how RunAll executes a sync plan
+type Options struct {
+	Sequential      bool // Run the entities of a stage one after another instead of in parallel
+	ContinueOnError bool // Start the next stage even if a sync of the current stage failed
+	Backfill        bool // Backfill dangling references after each stage
+
+	OnStageStart func(stage int, entities []Entity) // optional
+	OnResult     func(result Result)                // optional, called as soon as an entity finished (possibly concurrently)
+}
+
+// Report summarizes a complete RunAll execution
+type Report struct {
+	Stages    [][]Entity       `json:"stages"`
+	Results   []Result         `json:"results"`
+	Backfills []BackfillResult `json:"backfills,omitempty"`
+	Skipped   []Entity         `json:"skipped,omitempty"`
+}
+
+// RunAll syncs the given entities stage by stage in dependency order. Entities
+// of the same stage run in parallel and share the rate limiter of shared.
+// Cancelling ctx interrupts the running syncs and skips the remaining stages.
+func RunAll(ctx context.Context, entities []Entity, cfg *utility.SyncConfig, shared *utility.SharedResources, opts Options) (Report, error) {
+	stages, err := Stages(entities)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{Stages: stages}
+	for i, stage := range stages {
+		if ctx.Err() != nil {
+			report.Skipped = append(report.Skipped, flatten(stages[i:])...)
+			break
+		}
+
+		if opts.OnStageStart != nil {
+			opts.OnStageStart(i, stage)
+		}
+
+		results, skipped := runStage(ctx, stage, cfg, shared, opts)
+		report.Results = append(report.Results, results...)
+		report.Skipped = append(report.Skipped, skipped...)
+
+		failed := false
+		for _, result := range results {
+			if result.Err != nil {
+				failed = true
+			}
+		}
+
+		if opts.Backfill && ctx.Err() == nil {
+			backfills, err := BackfillDangling(ctx, cfg, shared, References(stage...))
+			if err != nil {
+				log.Printf("Warning: Failed to check dangling references: %v", err)
+			}
+			report.Backfills = append(report.Backfills, backfills...)
+		}
+
+		if failed && !opts.ContinueOnError {
+			report.Skipped = append(report.Skipped, flatten(stages[i+1:])...)
+			break
+		}
+	}
+
+	return report, nil
+}
+
+// runStage runs all entities of a stage and returns their results in stage order
+// together with the entities that were skipped due to an interrupt
+func runStage(ctx context.Context, stage []Entity, cfg *utility.SyncConfig, shared *utility.SharedResources, opts Options) ([]Result, []Entity) {
+	results := make([]Result, len(stage))
+
+	run := func(i int) {
+		results[i], _ = Run(ctx, stage[i], cfg, shared)
+		if opts.OnResult != nil {
+			opts.OnResult(results[i])
+		}
+	}
+
+	if opts.Sequential {
+		for i := range stage {
+			if ctx.Err() != nil {
+				return results[:i], stage[i:]
+			}
+			run(i)
+		}
+		return results, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := range stage {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			run(i)
+		}(i)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+func flatten(stages [][]Entity) []Entity {
+	var entities []Entity
+	for _, stage := range stages {
+		entities = append(entities, stage...)
+	}
+	return entities
+}
@@ -0,0 +1,70 @@
+package syncer
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+	dipclient "github.com/Johanneslueke/dip-client/pkg/dip-client"
+)
+
+// fetchPersonList fetches a single page of the Person list
+func fetchPersonList(ctx context.Context, c *dipclient.Client, f listFilter, cursor *string) (*utility.BatchResponse, error) {
+	// Use custom response handler to deal with wahlperiode array
+	respBody, err := c.GetPersonListRaw(ctx, &dipclient.GetPersonListParams{
+		Cursor:       cursor,
+		FDatumEnd:    f.DatumEnd,
+		FWahlperiode: f.Wahlperiode,
+		FId:          f.IDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Cursor    string                             `json:"cursor"`
+		Documents []store.PersonWithArrayWahlperiode `json:"documents"`
+		NumFound  int32                              `json:"numFound"`
+	}
+
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, err
+	}
+
+	return &utility.BatchResponse{
+		Documents:    result.Documents,
+		Cursor:       result.Cursor,
+		NumFound:     int(result.NumFound),
+		DocumentsLen: len(result.Documents),
+	}, nil
+}
+
+func extractPersonList(docs interface{}) []interface{} {
+	persons := docs.([]store.PersonWithArrayWahlperiode)
+	items := make([]interface{}, len(persons))
+	for i, p := range persons {
+		items[i] = p
+	}
+	return items
+}
+
+func personID(item interface{}) string {
+	return item.(store.PersonWithArrayWahlperiode).Id
+}
+
+func updatePersonDate(ctx context.Context, s store.Store, item interface{}, checkpointMgr *utility.CheckpointManager) {
+	person := item.(store.PersonWithArrayWahlperiode)
+	if !person.Aktualisiert.IsZero() {
+		checkpointMgr.UpdateDate(person.Aktualisiert)
+	}
+}
+
+func storePerson(ctx context.Context, s store.Store, item interface{}, failedTracker *utility.FailedRecordsTracker) {
+	person := item.(store.PersonWithArrayWahlperiode)
+	if err := s.UpsertPerson(ctx, person); err != nil {
+		failedTracker.RecordIfDBLocked(person.Id, "UpsertPerson", err)
+		log.Printf("Warning: Failed to store person %s: %v", person.Id, err)
+	}
+}
@@ -0,0 +1,60 @@
+package syncer
+
+import (
+	"context"
+	"log"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+	dipclient "github.com/Johanneslueke/dip-client/pkg/dip-client"
+)
+
+// fetchPlenarprotokollTextList fetches a single page of the PlenarprotokollText list
+func fetchPlenarprotokollTextList(ctx context.Context, c *dipclient.Client, f listFilter, cursor *string) (*utility.BatchResponse, error) {
+	resp, err := c.GetPlenarprotokollTextList(ctx, &client.GetPlenarprotokollTextListParams{
+		Cursor:       cursor,
+		FDatumEnd:    f.DatumEnd,
+		FWahlperiode: f.Wahlperiode,
+		FId:          f.IDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &utility.BatchResponse{
+		Documents:    resp.Documents,
+		Cursor:       resp.Cursor,
+		NumFound:     int(resp.NumFound),
+		DocumentsLen: len(resp.Documents),
+	}, nil
+}
+
+func extractPlenarprotokollTextList(docs interface{}) []interface{} {
+	texts := docs.([]client.PlenarprotokollText)
+	items := make([]interface{}, len(texts))
+	for i, d := range texts {
+		items[i] = d
+	}
+	return items
+}
+
+func plenarprotokollTextID(item interface{}) string {
+	return item.(client.PlenarprotokollText).Id
+}
+
+func updatePlenarprotokollTextDate(ctx context.Context, s store.Store, item interface{}, checkpointMgr *utility.CheckpointManager) {
+	plenarprotokollText := item.(client.PlenarprotokollText)
+	// Track the last processed date for checkpoint
+	if !plenarprotokollText.Aktualisiert.IsZero() {
+		checkpointMgr.UpdateDate(plenarprotokollText.Aktualisiert)
+	}
+}
+
+func storePlenarprotokollText(ctx context.Context, s store.Store, item interface{}, failedTracker *utility.FailedRecordsTracker) {
+	plenarprotokollText := item.(client.PlenarprotokollText)
+	if err := s.UpsertPlenarprotokollText(ctx, plenarprotokollText); err != nil {
+		failedTracker.RecordIfDBLocked(plenarprotokollText.Id, "UpsertPlenarprotokollText", err)
+		log.Printf("Warning: Failed to store plenarprotokoll text %s: %v", plenarprotokollText.Id, err)
+	}
+}
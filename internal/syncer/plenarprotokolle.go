@@ -0,0 +1,59 @@
+package syncer
+
+import (
+	"context"
+	"log"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+	dipclient "github.com/Johanneslueke/dip-client/pkg/dip-client"
+)
+
+// fetchPlenarprotokollList fetches a single page of the Plenarprotokoll list
+func fetchPlenarprotokollList(ctx context.Context, c *dipclient.Client, f listFilter, cursor *string) (*utility.BatchResponse, error) {
+	resp, err := c.GetPlenarprotokollList(ctx, &client.GetPlenarprotokollListParams{
+		Cursor:       cursor,
+		FDatumEnd:    f.DatumEnd,
+		FWahlperiode: f.Wahlperiode,
+		FId:          f.IDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &utility.BatchResponse{
+		Documents:    resp.Documents,
+		Cursor:       resp.Cursor,
+		NumFound:     int(resp.NumFound),
+		DocumentsLen: len(resp.Documents),
+	}, nil
+}
+
+func extractPlenarprotokollList(docs interface{}) []interface{} {
+	protokolle := docs.([]client.Plenarprotokoll)
+	items := make([]interface{}, len(protokolle))
+	for i, d := range protokolle {
+		items[i] = d
+	}
+	return items
+}
+
+func plenarprotokollID(item interface{}) string {
+	return item.(client.Plenarprotokoll).Id
+}
+
+func updatePlenarprotokollDate(ctx context.Context, s store.Store, item interface{}, checkpointMgr *utility.CheckpointManager) {
+	plenarprotokoll := item.(client.Plenarprotokoll)
+	if !plenarprotokoll.Datum.IsZero() {
+		updateCheckpointDate(ctx, s, store.PlenarprotokollTable, plenarprotokoll.Aktualisiert, checkpointMgr)
+	}
+}
+
+func storePlenarprotokoll(ctx context.Context, s store.Store, item interface{}, failedTracker *utility.FailedRecordsTracker) {
+	plenarprotokoll := item.(client.Plenarprotokoll)
+	if err := s.UpsertPlenarprotokoll(ctx, plenarprotokoll); err != nil {
+		failedTracker.RecordIfDBLocked(plenarprotokoll.Id, "UpsertPlenarprotokoll", err)
+		log.Printf("Warning: Failed to store plenarprotokoll %s: %v", plenarprotokoll.Id, err)
+	}
+}
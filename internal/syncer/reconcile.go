@@ -0,0 +1,127 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+)
+
+// reconcileTables lists all entities that support deletion detection and their main table
+var reconcileTables = map[Entity]store.Table{
+	Personen:           store.PersonTable,
+	Vorgaenge:          store.VorgangTable,
+	Vorgangspositionen: store.VorgangspositionTable,
+	Aktivitaeten:       store.AktivitaetTable,
+	Drucksachen:        store.DrucksacheTable,
+	Plenarprotokolle:   store.PlenarprotokollTable,
+}
+
+// CanReconcile reports whether deletion detection is supported for the entity
+func CanReconcile(entity Entity) bool {
+	_, ok := reconcileTables[entity]
+	return ok
+}
+
+// ReconcileResult summarizes the reconciliation of one entity and Wahlperiode
+type ReconcileResult struct {
+	Entity      Entity   `json:"entity"`
+	Wahlperiode int      `json:"wahlperiode"`
+	Remote      int      `json:"remote"`  // IDs returned by the API
+	Local       int      `json:"local"`   // non-deleted IDs in the database
+	Deleted     []string `json:"deleted"` // local IDs missing from the API
+	DryRun      bool     `json:"dry_run"`
+}
+
+// Reconcile scans all IDs of an entity and Wahlperiode from the API, compares
+// them with the local table and marks local records missing from the API as
+// deleted (unless dryRun is set). The scan must be complete: if it is
+// interrupted or returns fewer IDs than the API reports, nothing is marked.
+func Reconcile(ctx context.Context, entity Entity, wahlperiode int, shared *utility.SharedResources, dryRun bool) (ReconcileResult, error) {
+	result := ReconcileResult{Entity: entity, Wahlperiode: wahlperiode, DryRun: dryRun}
+
+	def, ok := Lookup(entity)
+	table, canReconcile := reconcileTables[entity]
+	if !ok || !canReconcile {
+		return result, fmt.Errorf("reconciliation is not supported for %q", entity)
+	}
+
+	remote, err := scanRemoteIDs(ctx, def, wahlperiode, shared)
+	if err != nil {
+		return result, err
+	}
+	result.Remote = len(remote)
+
+	local, err := shared.Store.IDsByWahlperiode(ctx, table, wahlperiode)
+	if err != nil {
+		return result, fmt.Errorf("failed to list local %s: %w", entity, err)
+	}
+	result.Local = len(local)
+
+	// An empty scan most likely means an API problem rather than a deleted Wahlperiode
+	if len(remote) == 0 && len(local) > 0 {
+		return result, fmt.Errorf("API returned no %s for Wahlperiode %d, refusing to mark %d local records as deleted", entity, wahlperiode, len(local))
+	}
+
+	for _, id := range local {
+		if !remote[id] {
+			result.Deleted = append(result.Deleted, id)
+		}
+	}
+
+	if dryRun || len(result.Deleted) == 0 {
+		return result, nil
+	}
+
+	deletedAt := time.Now()
+	for _, id := range result.Deleted {
+		if err := shared.Store.MarkDeleted(ctx, table, id, deletedAt); err != nil {
+			return result, fmt.Errorf("failed to mark %s %s as deleted: %w", entity, id, err)
+		}
+	}
+
+	return result, nil
+}
+
+// scanRemoteIDs pages through the API list of an entity for a Wahlperiode and collects all IDs
+func scanRemoteIDs(ctx context.Context, def Definition, wahlperiode int, shared *utility.SharedResources) (map[string]bool, error) {
+	ids := make(map[string]bool)
+	filter := listFilter{Wahlperiode: &[]int{wahlperiode}}
+
+	log.Printf("Scanning %s IDs of Wahlperiode %d from API...", def.Entity, wahlperiode)
+
+	var cursor *string
+	numFound := 0
+	for {
+		if err := shared.Limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("scan of %s interrupted: %w", def.Entity, err)
+		}
+
+		resp, err := def.fetch(ctx, shared.Client, filter, cursor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", def.Entity, err)
+		}
+		numFound = resp.NumFound
+
+		if resp.DocumentsLen == 0 {
+			break
+		}
+		for _, item := range def.extract(resp.Documents) {
+			ids[def.itemID(item)] = true
+		}
+
+		if resp.Cursor == "" || (cursor != nil && resp.Cursor == *cursor) {
+			break
+		}
+		cursor = &resp.Cursor
+	}
+
+	if len(ids) < numFound {
+		return nil, fmt.Errorf("incomplete scan of %s: got %d of %d IDs", def.Entity, len(ids), numFound)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,269 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+)
+
+// backfillBatchSize is the number of IDs requested per f.id filter
+const backfillBatchSize = 50
+
+// Reference describes a link column that points to the id of another entity
+type Reference struct {
+	Name   string // link table and column, e.g. "drucksache_vorgangsbezug.vorgang_id"
+	Source Entity // entity whose sync writes the link rows
+	Target Entity // entity the column references
+
+	link store.Link
+}
+
+// references lists all link columns that are checked for dangling IDs
+var references = []Reference{
+	{"vorgang_verlinkung.target_vorgang_id", Vorgaenge, Vorgaenge, store.LinkVerlinkungVorgang},
+	{"vorgangsposition.vorgang_id", Vorgangspositionen, Vorgaenge, store.LinkVorgangspositionVorgang},
+	{"vorgangsposition_mitberaten.mitberaten_vorgang_id", Vorgangspositionen, Vorgaenge, store.LinkMitberatenVorgang},
+	{"vorgangsposition.fundstelle_id (Drucksache)", Vorgangspositionen, Drucksachen, store.LinkVorgangspositionFundstelleDrucksache},
+	{"vorgangsposition.fundstelle_id (Plenarprotokoll)", Vorgangspositionen, Plenarprotokolle, store.LinkVorgangspositionFundstellePlenarprotokoll},
+	{"drucksache_vorgangsbezug.vorgang_id", Drucksachen, Vorgaenge, store.LinkDrucksacheVorgangsbezug},
+	{"drucksache_autor_anzeige.person_id", Drucksachen, Personen, store.LinkAutorAnzeigePerson},
+	{"plenarprotokoll_vorgangsbezug.vorgang_id", Plenarprotokolle, Vorgaenge, store.LinkPlenarprotokollVorgangsbezug},
+	{"aktivitaet_vorgangsbezug.vorgang_id", Aktivitaeten, Vorgaenge, store.LinkAktivitaetVorgangsbezug},
+	{"aktivitaet.fundstelle_id (Drucksache)", Aktivitaeten, Drucksachen, store.LinkAktivitaetFundstelleDrucksache},
+	{"aktivitaet.fundstelle_id (Plenarprotokoll)", Aktivitaeten, Plenarprotokolle, store.LinkAktivitaetFundstellePlenarprotokoll},
+}
+
+// References returns all checked link columns whose rows are written by one of the given entities.
+// Without entities, all references are returned.
+func References(sources ...Entity) []Reference {
+	if len(sources) == 0 {
+		return append([]Reference(nil), references...)
+	}
+
+	var refs []Reference
+	for _, ref := range references {
+		for _, source := range sources {
+			if ref.Source == source {
+				refs = append(refs, ref)
+				break
+			}
+		}
+	}
+	return refs
+}
+
+// BackfillResult summarizes the backfill of a single entity
+type BackfillResult struct {
+	Target  Entity   `json:"target"`
+	Sources []string `json:"sources"` // reference names the missing IDs were found in
+	Missing int      `json:"missing"`
+	Stored  int      `json:"stored"`
+	Err     error    `json:"-"`
+}
+
+// FindDangling returns the missing IDs per target entity for the given references,
+// together with the names of the references they were found in
+func FindDangling(ctx context.Context, s store.Store, refs []Reference) (map[Entity][]string, map[Entity][]string, error) {
+	missing := make(map[Entity][]string)
+	sources := make(map[Entity][]string)
+	seen := make(map[Entity]map[string]bool)
+
+	for _, ref := range refs {
+		ids, err := s.DanglingIDs(ctx, ref.link)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check %s: %w", ref.Name, err)
+		}
+		if len(ids) == 0 {
+			continue
+		}
+
+		sources[ref.Target] = append(sources[ref.Target], ref.Name)
+		if seen[ref.Target] == nil {
+			seen[ref.Target] = make(map[string]bool)
+		}
+		for _, id := range ids {
+			if !seen[ref.Target][id] {
+				seen[ref.Target][id] = true
+				missing[ref.Target] = append(missing[ref.Target], id)
+			}
+		}
+	}
+
+	return missing, sources, nil
+}
+
+// BackfillDangling checks the given references for dangling IDs and fetches the
+// referenced entities by ID
+func BackfillDangling(ctx context.Context, cfg *utility.SyncConfig, shared *utility.SharedResources, refs []Reference) ([]BackfillResult, error) {
+	return backfillDangling(ctx, cfg, shared, refs, nil)
+}
+
+// backfillDangling is BackfillDangling skipping the IDs in exclude, e.g. IDs the API did not return before
+func backfillDangling(ctx context.Context, cfg *utility.SyncConfig, shared *utility.SharedResources, refs []Reference, exclude map[Entity]map[string]bool) ([]BackfillResult, error) {
+	missing, sources, err := FindDangling(ctx, shared.Store, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []BackfillResult
+	for _, def := range definitions {
+		var ids []string
+		for _, id := range missing[def.Entity] {
+			if !exclude[def.Entity][id] {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+
+		log.Printf("Backfilling %d missing %s referenced by %v", len(ids), def.Entity, sources[def.Entity])
+		stored, err := Backfill(ctx, def.Entity, ids, cfg, shared)
+		results = append(results, BackfillResult{
+			Target:  def.Entity,
+			Sources: sources[def.Entity],
+			Missing: len(ids),
+			Stored:  stored,
+			Err:     err,
+		})
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+// Backfill fetches the given entity IDs in batches and stores them.
+// It returns the number of stored items.
+func Backfill(ctx context.Context, entity Entity, ids []string, cfg *utility.SyncConfig, shared *utility.SharedResources) (int, error) {
+	def, ok := Lookup(entity)
+	if !ok {
+		return 0, fmt.Errorf("unknown entity %q", entity)
+	}
+
+	numericIDs := make([]int, 0, len(ids))
+	for _, id := range ids {
+		n, err := strconv.Atoi(id)
+		if err != nil {
+			log.Printf("Warning: Skipping non-numeric %s id %q", entity, id)
+			continue
+		}
+		numericIDs = append(numericIDs, n)
+	}
+
+	backfillCfg := *cfg
+	backfillCfg.ResourceName = string(entity) + "-backfill"
+	backfillCfg.Limit = 0
+
+	sc, err := utility.NewSharedSyncContext(ctx, &backfillCfg, shared)
+	if err != nil {
+		return 0, err
+	}
+	defer sc.Close()
+
+	for start := 0; start < len(numericIDs); start += backfillBatchSize {
+		end := min(start+backfillBatchSize, len(numericIDs))
+		batch := numericIDs[start:end]
+		filter := listFilter{IDs: &batch}
+
+		if err := sc.SyncLoop(
+			func(ctx context.Context, cursor *string) (*utility.BatchResponse, error) {
+				return def.fetch(ctx, sc.Client, filter, cursor)
+			},
+			def.store,
+			nil,
+			def.extract,
+		); err != nil {
+			return sc.Progress.Total, err
+		}
+		if sc.IsInterrupted() {
+			break
+		}
+	}
+
+	sc.Finalize()
+	return sc.Progress.Total, nil
+}
+
+// ClosureResult summarizes a CloseReferences run
+type ClosureResult struct {
+	Rounds     []ClosureRound      `json:"rounds"`
+	Closed     bool                `json:"closed"`     // no dangling IDs left apart from unresolved ones
+	Unresolved map[Entity][]string `json:"unresolved"` // IDs the API did not return
+}
+
+// ClosureRound holds the backfills of a single CloseReferences round
+type ClosureRound struct {
+	Depth     int              `json:"depth"`
+	Backfills []BackfillResult `json:"backfills"`
+}
+
+// CloseReferences repeatedly backfills dangling references until no backfillable
+// IDs are left or maxDepth rounds were run (maxDepth <= 0 means no limit).
+// Entities fetched in one round may reference further missing entities, which
+// are picked up in the next round.
+func CloseReferences(ctx context.Context, cfg *utility.SyncConfig, shared *utility.SharedResources, refs []Reference, maxDepth int) (ClosureResult, error) {
+	result := ClosureResult{Unresolved: make(map[Entity][]string)}
+	attempted := make(map[Entity]map[string]bool)
+
+	for depth := 1; maxDepth <= 0 || depth <= maxDepth; depth++ {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		// Remember what is requested in this round to detect IDs the API does not know
+		missing, _, err := FindDangling(ctx, shared.Store, refs)
+		if err != nil {
+			return result, err
+		}
+
+		backfills, err := backfillDangling(ctx, cfg, shared, refs, attempted)
+		if err != nil {
+			return result, err
+		}
+		if len(backfills) == 0 {
+			result.Closed = true
+			break
+		}
+		result.Rounds = append(result.Rounds, ClosureRound{Depth: depth, Backfills: backfills})
+
+		for _, b := range backfills {
+			if b.Err != nil {
+				return result, fmt.Errorf("backfill of %s failed: %w", b.Target, b.Err)
+			}
+		}
+
+		for entity, ids := range missing {
+			if attempted[entity] == nil {
+				attempted[entity] = make(map[string]bool)
+			}
+			for _, id := range ids {
+				attempted[entity][id] = true
+			}
+		}
+	}
+
+	// IDs that are still dangling after being requested could not be resolved
+	missing, _, err := FindDangling(ctx, shared.Store, refs)
+	if err != nil {
+		return result, err
+	}
+	stillMissing := false
+	for _, def := range definitions {
+		for _, id := range missing[def.Entity] {
+			if attempted[def.Entity][id] {
+				result.Unresolved[def.Entity] = append(result.Unresolved[def.Entity], id)
+			} else {
+				stillMissing = true
+			}
+		}
+	}
+	result.Closed = !stillMissing
+
+	return result, nil
+}
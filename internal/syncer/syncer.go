@@ -0,0 +1,247 @@
+// Package syncer contains the sync implementations for all DIP resources so that
+// they can be run either as standalone commands or in-process by sync-all.
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+	dipclient "github.com/Johanneslueke/dip-client/pkg/dip-client"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Entity identifies a syncable DIP resource. The value doubles as the
+// resource name used for checkpoint and failed-record files.
+type Entity string
+
+const (
+	Personen             Entity = "personen"
+	Vorgaenge            Entity = "vorgaenge"
+	Vorgangspositionen   Entity = "vorgangspositionen"
+	Aktivitaeten         Entity = "aktivitaeten"
+	Drucksachen          Entity = "drucksachen"
+	DrucksacheTexte      Entity = "drucksache-texte"
+	Plenarprotokolle     Entity = "plenarprotokolle"
+	PlenarprotokollTexte Entity = "plenarprotokoll-texte"
+)
+
+// Definition describes how a single entity is synced
+type Definition struct {
+	Entity            Entity
+	Description       string
+	RequestsPerMinute int // Rate used when the entity is synced standalone
+
+	fetch      fetchFunc
+	store      utility.StoreItemFunc
+	updateDate utility.UpdateDateFunc // nil if the entity has no checkpoint date
+	extract    func(interface{}) []interface{}
+	itemID     func(interface{}) string
+}
+
+// listFilter holds the list filters shared by all DIP list endpoints
+type listFilter struct {
+	DatumEnd    *openapi_types.Date
+	Wahlperiode *[]int
+	IDs         *[]int
+}
+
+// fetchFunc fetches a single page of an entity list
+type fetchFunc func(ctx context.Context, c *dipclient.Client, f listFilter, cursor *string) (*utility.BatchResponse, error)
+
+// definitions lists all entities in their default sync order
+var definitions = []Definition{
+	{Personen, "Sync persons (Personen)", 240, fetchPersonList, storePerson, updatePersonDate, extractPersonList, personID},
+	{Vorgaenge, "Sync procedures (Vorgänge)", 240, fetchVorgangList, storeVorgang, updateVorgangDate, extractVorgangList, vorgangID},
+	{Vorgangspositionen, "Sync procedure positions (Vorgangspositionen)", 240, fetchVorgangspositionList, storeVorgangsposition, updateVorgangspositionDate, extractVorgangspositionList, vorgangspositionID},
+	{Aktivitaeten, "Sync activities (Aktivitäten)", 480, fetchAktivitaetList, storeAktivitaet, updateAktivitaetDate, extractAktivitaetList, aktivitaetID},
+	{Drucksachen, "Sync printed documents (Drucksachen)", 720, fetchDrucksacheList, storeDrucksache, updateDrucksacheDate, extractDrucksacheList, drucksacheID},
+	{DrucksacheTexte, "Sync printed document texts (Drucksache-Texte)", 240, fetchDrucksacheTextList, storeDrucksacheText, nil, extractDrucksacheTextList, drucksacheTextID},
+	{Plenarprotokolle, "Sync plenary protocols (Plenarprotokolle)", 240, fetchPlenarprotokollList, storePlenarprotokoll, updatePlenarprotokollDate, extractPlenarprotokollList, plenarprotokollID},
+	{PlenarprotokollTexte, "Sync plenary protocol texts (Plenarprotokoll-Texte)", 23, fetchPlenarprotokollTextList, storePlenarprotokollText, updatePlenarprotokollTextDate, extractPlenarprotokollTextList, plenarprotokollTextID},
+}
+
+// Definitions returns all known entities in their default sync order
+func Definitions() []Definition {
+	defs := make([]Definition, len(definitions))
+	copy(defs, definitions)
+	return defs
+}
+
+// Lookup returns the definition for the given entity
+func Lookup(entity Entity) (Definition, bool) {
+	for _, def := range definitions {
+		if def.Entity == entity {
+			return def, true
+		}
+	}
+	return Definition{}, false
+}
+
+// Result summarizes a single entity sync run
+type Result struct {
+	Entity      Entity        `json:"entity"`
+	Stored      int           `json:"stored"`
+	Failed      int           `json:"failed"`
+	Duration    time.Duration `json:"duration"`
+	Interrupted bool          `json:"interrupted"`
+	Err         error         `json:"-"`
+}
+
+// Run syncs a single entity using already initialized shared resources.
+// The config's ResourceName is set to the entity; cancelling ctx interrupts the sync.
+func Run(ctx context.Context, entity Entity, cfg *utility.SyncConfig, shared *utility.SharedResources) (Result, error) {
+	result := Result{Entity: entity}
+
+	def, ok := Lookup(entity)
+	if !ok {
+		result.Err = fmt.Errorf("unknown entity %q", entity)
+		return result, result.Err
+	}
+
+	entityCfg := *cfg
+	entityCfg.ResourceName = string(entity)
+
+	sc, err := utility.NewSharedSyncContext(ctx, &entityCfg, shared)
+	if err != nil {
+		result.Err = err
+		return result, err
+	}
+	defer sc.Close()
+
+	start := time.Now()
+	if err = syncEntity(sc, def); err == nil {
+		sc.Finalize()
+	}
+
+	result.Stored = sc.Progress.Total
+	result.Failed = sc.FailedTracker.Count()
+	result.Duration = time.Since(start)
+	result.Interrupted = sc.IsInterrupted()
+	result.Err = err
+
+	return result, err
+}
+
+// RunStandalone syncs a single entity with its own database connection, client,
+// rate limiter and signal handling. It is used by the individual sync-* commands.
+func RunStandalone(entity Entity, cfg *utility.SyncConfig) error {
+	def, ok := Lookup(entity)
+	if !ok {
+		return fmt.Errorf("unknown entity %q", entity)
+	}
+
+	sc, err := utility.NewSyncContext(cfg, def.RequestsPerMinute)
+	if err != nil {
+		return err
+	}
+	defer sc.Close()
+
+	if err := syncEntity(sc, def); err != nil {
+		return err
+	}
+
+	sc.Finalize()
+	return nil
+}
+
+// syncEntity fetches all pages of an entity and stores them in the database
+func syncEntity(sc *utility.SyncContext, def Definition) error {
+	datumEnd, err := endDate(sc)
+	if err != nil {
+		return err
+	}
+
+	wahlperioden, err := wahlperiodeFilter(sc.Config.Wahlperiode)
+	if err != nil {
+		return err
+	}
+
+	filter := listFilter{
+		DatumEnd:    datumEnd,
+		Wahlperiode: wahlperioden,
+		IDs:         idFilter(sc.Config),
+	}
+
+	storeItem := def.store
+	if sc.Config.History && SupportsHistory(def.Entity) {
+		if err := sc.Store.CreateSyncRun(sc.Context(), sc.RunID, sc.Config.ResourceName); err != nil {
+			return fmt.Errorf("failed to record sync run: %w", err)
+		}
+		log.Printf("Recording history of changed %s (sync run %s)", def.Entity, sc.RunID)
+		storeItem = withHistory(sc, def, storeItem)
+	}
+
+	return sc.SyncLoop(
+		func(ctx context.Context, cursor *string) (*utility.BatchResponse, error) {
+			return def.fetch(ctx, sc.Client, filter, cursor)
+		},
+		storeItem,
+		def.updateDate,
+		def.extract,
+	)
+}
+
+// endDate determines the upper date bound for a sync, either from the
+// checkpoint (when resuming) or from the -end flag which takes precedence
+func endDate(sc *utility.SyncContext) (*openapi_types.Date, error) {
+	// Load checkpoint if resuming
+	datumEnd, _ := sc.CheckpointMgr.LoadIfResume()
+
+	// Parse end date if provided
+	if sc.Config.End != "" {
+		endTime, err := time.Parse("2006-01-02", sc.Config.End)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end date format: %w", err)
+		}
+		date := openapi_types.Date{Time: endTime}
+		datumEnd = &date
+	}
+
+	return datumEnd, nil
+}
+
+// wahlperiodeFilter parses a comma separated list of Wahlperioden
+func wahlperiodeFilter(value string) (*[]int, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	wpStrings := strings.Split(value, ",")
+	wpFilters := make([]int, 0, len(wpStrings))
+	for _, wpStr := range wpStrings {
+		wpInt, err := strconv.Atoi(strings.TrimSpace(wpStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid wahlperiode value: %w", err)
+		}
+		wpFilters = append(wpFilters, wpInt)
+	}
+	return &wpFilters, nil
+}
+
+// idFilter returns the -vorgang-id flag as an id filter
+func idFilter(cfg *utility.SyncConfig) *[]int {
+	if cfg.VorgangID <= 0 {
+		return nil
+	}
+	return &[]int{cfg.VorgangID}
+}
+
+// updateCheckpointDate moves the checkpoint to the earliest datum stored in the
+// table, or to the item date if the table has none yet
+func updateCheckpointDate(ctx context.Context, s store.Store, table store.Table, itemDate time.Time, checkpointMgr *utility.CheckpointManager) {
+	datum, ok, err := s.EarliestDatum(ctx, table)
+	if err != nil {
+		log.Printf("Warning: Failed to get latest %s datum: %v", table, err)
+	}
+	if err != nil || !ok {
+		checkpointMgr.UpdateDate(itemDate)
+		return
+	}
+	checkpointMgr.UpdateDate(datum)
+}
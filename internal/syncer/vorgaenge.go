@@ -0,0 +1,59 @@
+package syncer
+
+import (
+	"context"
+	"log"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+	dipclient "github.com/Johanneslueke/dip-client/pkg/dip-client"
+)
+
+// fetchVorgangList fetches a single page of the Vorgang list
+func fetchVorgangList(ctx context.Context, c *dipclient.Client, f listFilter, cursor *string) (*utility.BatchResponse, error) {
+	resp, err := c.GetVorgangList(ctx, &client.GetVorgangListParams{
+		Cursor:       cursor,
+		FDatumEnd:    f.DatumEnd,
+		FWahlperiode: f.Wahlperiode,
+		FId:          f.IDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &utility.BatchResponse{
+		Documents:    resp.Documents,
+		Cursor:       resp.Cursor,
+		NumFound:     int(resp.NumFound),
+		DocumentsLen: len(resp.Documents),
+	}, nil
+}
+
+func extractVorgangList(docs interface{}) []interface{} {
+	vorgaenge := docs.([]client.Vorgang)
+	items := make([]interface{}, len(vorgaenge))
+	for i, d := range vorgaenge {
+		items[i] = d
+	}
+	return items
+}
+
+func vorgangID(item interface{}) string {
+	return item.(client.Vorgang).Id
+}
+
+func updateVorgangDate(ctx context.Context, s store.Store, item interface{}, checkpointMgr *utility.CheckpointManager) {
+	vorgang := item.(client.Vorgang)
+	if vorgang.Datum != nil && !vorgang.Datum.Time.IsZero() {
+		updateCheckpointDate(ctx, s, store.VorgangTable, vorgang.Datum.Time, checkpointMgr)
+	}
+}
+
+func storeVorgang(ctx context.Context, s store.Store, item interface{}, failedTracker *utility.FailedRecordsTracker) {
+	vorgang := item.(client.Vorgang)
+	if err := s.UpsertVorgang(ctx, vorgang); err != nil {
+		failedTracker.RecordIfDBLocked(vorgang.Id, "UpsertVorgang", err)
+		log.Printf("Warning: Failed to store vorgang %s: %v", vorgang.Id, err)
+	}
+}
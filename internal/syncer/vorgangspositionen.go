@@ -0,0 +1,59 @@
+package syncer
+
+import (
+	"context"
+	"log"
+
+	client "github.com/Johanneslueke/dip-client/internal/gen/v1.4"
+	"github.com/Johanneslueke/dip-client/internal/store"
+	"github.com/Johanneslueke/dip-client/internal/utility"
+	dipclient "github.com/Johanneslueke/dip-client/pkg/dip-client"
+)
+
+// fetchVorgangspositionList fetches a single page of the Vorgangsposition list
+func fetchVorgangspositionList(ctx context.Context, c *dipclient.Client, f listFilter, cursor *string) (*utility.BatchResponse, error) {
+	resp, err := c.GetVorgangspositionList(ctx, &client.GetVorgangspositionListParams{
+		Cursor:       cursor,
+		FDatumEnd:    f.DatumEnd,
+		FWahlperiode: f.Wahlperiode,
+		FId:          f.IDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &utility.BatchResponse{
+		Documents:    resp.Documents,
+		Cursor:       resp.Cursor,
+		NumFound:     int(resp.NumFound),
+		DocumentsLen: len(resp.Documents),
+	}, nil
+}
+
+func extractVorgangspositionList(docs interface{}) []interface{} {
+	vorgangspositionen := docs.([]client.Vorgangsposition)
+	items := make([]interface{}, len(vorgangspositionen))
+	for i, d := range vorgangspositionen {
+		items[i] = d
+	}
+	return items
+}
+
+func vorgangspositionID(item interface{}) string {
+	return item.(client.Vorgangsposition).Id
+}
+
+func updateVorgangspositionDate(ctx context.Context, s store.Store, item interface{}, checkpointMgr *utility.CheckpointManager) {
+	vorgangsposition := item.(client.Vorgangsposition)
+	if !vorgangsposition.Datum.Time.IsZero() {
+		updateCheckpointDate(ctx, s, store.VorgangspositionTable, vorgangsposition.Datum.Time, checkpointMgr)
+	}
+}
+
+func storeVorgangsposition(ctx context.Context, s store.Store, item interface{}, failedTracker *utility.FailedRecordsTracker) {
+	vorgangsposition := item.(client.Vorgangsposition)
+	if err := s.UpsertVorgangsposition(ctx, vorgangsposition); err != nil {
+		failedTracker.RecordIfDBLocked(vorgangsposition.Id, "UpsertVorgangsposition", err)
+		log.Printf("Warning: Failed to store vorgangsposition %s: %v", vorgangsposition.Id, err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 type ProgressTracker struct {
 	startTime time.Time
 	Total     int
+	Label     string // Optional prefix to tell apart concurrently running syncs
 	limit     int
 }
 
@@ -47,7 +48,13 @@ func (p *ProgressTracker) PrintProgress(current, totalAvailable int) {
 		}
 	}
 
-	fmt.Printf("\rFetched %d items (%.1f/sec, %.1f%% of %d total, %s%s)    ",
+	var prefix string
+	if p.Label != "" {
+		prefix = "[" + p.Label + "] "
+	}
+
+	fmt.Printf("\r%sFetched %d items (%.1f/sec, %.1f%% of %d total, %s%s)    ",
+		prefix,
 		current,
 		rate,
 		float64(current)/float64(totalAvailable)*100,
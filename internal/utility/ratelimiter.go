@@ -2,7 +2,6 @@ package utility
 
 import (
 	"context"
-	"fmt"
 	"sync"
 	"time"
 )
@@ -48,7 +47,6 @@ func (rl *RateLimiter) Wait(ctx context.Context) error {
 		if rl.tokens >= 1.0 {
 			rl.tokens -= 1.0
 			rl.mu.Unlock()
-			fmt.Printf("Token after Unlock %v \n", rl.tokens)
 			return nil
 		}
 
@@ -57,7 +55,6 @@ func (rl *RateLimiter) Wait(ctx context.Context) error {
 		waitTime := time.Duration(tokensNeeded / rl.refillRate)
 		
 		rl.mu.Unlock()
-		fmt.Printf("Token before wait %v , need to wait %v \n", rl.tokens, waitTime)
 		
 		timer := time.NewTimer(waitTime)
 		select {
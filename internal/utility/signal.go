@@ -73,6 +73,10 @@ func (sh *SignalHandler) handleSignals() {
 
 	// Wait for second signal to force quit
 	sig = <-sh.sigChan
+	if sig == nil {
+		// Handler was stopped after a graceful shutdown
+		return
+	}
 	sh.mu.Lock()
 	currentCmd = sh.currentCmd
 	sh.mu.Unlock()
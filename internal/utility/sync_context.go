@@ -2,29 +2,71 @@ package utility
 
 import (
 	"context"
-	"database/sql"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"time"
 
-	db "github.com/Johanneslueke/dip-client/internal/database/gen/sqlite"
+	"github.com/Johanneslueke/dip-client/internal/store"
 	dipclient "github.com/Johanneslueke/dip-client/pkg/dip-client"
-	_ "modernc.org/sqlite"
 )
 
+// SharedResources holds the components that several sync operations can share
+// when they run in the same process (database store, API client, rate limiter)
+type SharedResources struct {
+	Store   store.Store
+	Client  *dipclient.Client
+	Limiter *RateLimiter
+}
+
+// NewSharedResources opens the database, runs migrations and creates the API client and rate limiter
+func NewSharedResources(config *SyncConfig, requestsPerMinute int) (*SharedResources, error) {
+	// Setup database
+	s, err := store.Open(config.DBDriver, config.DataSource())
+	if err != nil {
+		return nil, err
+	}
+
+	// Setup API client
+	dipClient, err := dipclient.New(dipclient.Config{
+		BaseURL: config.BaseURL,
+		APIKey:  config.APIKey,
+	})
+	if err != nil {
+		s.Close()
+		return nil, fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	return &SharedResources{
+		Store:   s,
+		Client:  dipClient,
+		Limiter: NewRateLimiter(requestsPerMinute, time.Minute),
+	}, nil
+}
+
+// Close closes the shared database connection
+func (r *SharedResources) Close() error {
+	if r.Store != nil {
+		return r.Store.Close()
+	}
+	return nil
+}
+
 // SyncContext holds all the components needed for a sync operation
 type SyncContext struct {
 	Config        *SyncConfig
-	DB            *sql.DB
-	Queries       *db.Queries
+	Store         store.Store
 	Client        *dipclient.Client
 	Limiter       *RateLimiter
 	Progress      *ProgressTracker
 	FailedTracker *FailedRecordsTracker
 	CheckpointMgr *CheckpointManager
 	SignalHandler *SignalHandler
+	RunID         string // Unique ID of this sync run, e.g. for history records
 	ctx           context.Context
 	interrupted   bool
+	ownsResources bool
 }
 
 // NewSyncContext creates and initializes a complete sync context
@@ -33,52 +75,13 @@ func NewSyncContext(config *SyncConfig, requestsPerMinute int) (*SyncContext, er
 		return nil, err
 	}
 
-	sc := &SyncContext{
-		Config:      config,
-		ctx:         context.Background(),
-		interrupted: false,
-	}
-
-	// Setup database
-	sqlDB, err := sql.Open("sqlite", config.DBPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
-	}
-
-	sqlDB.SetMaxOpenConns(24)
-	sqlDB.SetMaxIdleConns(24)
-	sqlDB.SetConnMaxLifetime(time.Hour) 
-
-	if err := RunMigrations(sqlDB); err != nil {
-		sqlDB.Close()
-		return nil, fmt.Errorf("failed to run migrations: %w", err)
-	}
-
-	sc.DB = sqlDB
-	sc.Queries = db.New(sqlDB)
-
-	// Setup API client
-	dipClient, err := dipclient.New(dipclient.Config{
-		BaseURL: config.BaseURL,
-		APIKey:  config.APIKey,
-	})
+	shared, err := NewSharedResources(config, requestsPerMinute)
 	if err != nil {
-		sqlDB.Close()
-		return nil, fmt.Errorf("failed to create API client: %w", err)
+		return nil, err
 	}
-	sc.Client = dipClient
-
-	// Setup rate limiter
-	sc.Limiter = NewRateLimiter(requestsPerMinute, time.Minute)
-
-	// Setup progress tracker
-	sc.Progress = NewProgressTracker(config.Limit)
 
-	// Setup failed records tracker
-	sc.FailedTracker = NewFailedRecordsTracker(config.FailedDir, config.ResourceName)
-
-	// Setup checkpoint manager
-	sc.CheckpointMgr = NewCheckpointManager(config.CheckpointDir, config.ResourceName, config.Resume)
+	sc := newSyncContext(context.Background(), config, shared)
+	sc.ownsResources = true
 
 	// Setup signal handler
 	sc.SignalHandler = NewSignalHandler(
@@ -92,6 +95,38 @@ func NewSyncContext(config *SyncConfig, requestsPerMinute int) (*SyncContext, er
 	return sc, nil
 }
 
+// NewSharedSyncContext creates a sync context that reuses already initialized resources.
+// It does not install its own signal handler; cancelling ctx interrupts the sync instead.
+func NewSharedSyncContext(ctx context.Context, config *SyncConfig, shared *SharedResources) (*SyncContext, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	sc := newSyncContext(ctx, config, shared)
+	sc.Progress.Label = config.ResourceName
+	return sc, nil
+}
+
+func newSyncContext(ctx context.Context, config *SyncConfig, shared *SharedResources) *SyncContext {
+	return &SyncContext{
+		Config:        config,
+		Store:         shared.Store,
+		Client:        shared.Client,
+		Limiter:       shared.Limiter,
+		Progress:      NewProgressTracker(config.Limit),
+		FailedTracker: NewFailedRecordsTracker(config.FailedDir, config.ResourceName),
+		CheckpointMgr: NewCheckpointManager(config.CheckpointDir, config.ResourceName, config.Resume),
+		RunID:         newRunID(config.ResourceName),
+		ctx:           ctx,
+	}
+}
+
+// newRunID creates a unique, sortable ID for a sync run
+func newRunID(resourceName string) string {
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	return fmt.Sprintf("%s-%s-%s", time.Now().UTC().Format("20060102T150405Z"), resourceName, hex.EncodeToString(suffix))
+}
+
 // Context returns the context for this sync operation
 func (sc *SyncContext) Context() context.Context {
 	return sc.ctx
@@ -99,7 +134,10 @@ func (sc *SyncContext) Context() context.Context {
 
 // IsInterrupted checks if the sync has been interrupted
 func (sc *SyncContext) IsInterrupted() bool {
-	return sc.SignalHandler.IsInterrupted() || sc.interrupted
+	if sc.SignalHandler != nil && sc.SignalHandler.IsInterrupted() {
+		return true
+	}
+	return sc.interrupted || sc.ctx.Err() != nil
 }
 
 // ShouldStop checks if sync should stop (interrupted or limit reached)
@@ -117,14 +155,19 @@ func (sc *SyncContext) ShouldStop() bool {
 func (sc *SyncContext) Finalize() {
 	fmt.Println() // New line after progress updates
 
-	if sc.IsInterrupted() {
+	interrupted := sc.IsInterrupted()
+	if interrupted {
 		log.Printf("Interrupted after processing %d items", sc.Progress.Total)
+		// Without a signal handler nobody saved the checkpoint yet
+		if sc.SignalHandler == nil {
+			sc.CheckpointMgr.SaveCheckpoint()
+		}
 	} else if sc.Config.Limit > 0 && sc.Progress.Total >= sc.Config.Limit {
 		log.Printf("Reached limit of %d items", sc.Config.Limit)
 	} else {
 		elapsed, rate := sc.Progress.GetStats()
 		log.Printf("Successfully stored %d items in database %s (%.1f/sec, took %s)",
-			sc.Progress.Total, sc.Config.DBPath, rate, elapsed.Round(time.Second))
+			sc.Progress.Total, sc.Config.DatabaseName(), rate, elapsed.Round(time.Second))
 	}
 
 	// Save failed records if any
@@ -138,16 +181,16 @@ func (sc *SyncContext) Finalize() {
 	}
 
 	// Delete checkpoint on successful completion
-	sc.CheckpointMgr.DeleteOnSuccess(sc.interrupted)
+	sc.CheckpointMgr.DeleteOnSuccess(interrupted)
 }
 
-// Close closes the database connection and stops the signal handler
+// Close stops the signal handler and closes the database connection if this context owns it
 func (sc *SyncContext) Close() error {
 	if sc.SignalHandler != nil {
 		sc.SignalHandler.Stop()
 	}
-	if sc.DB != nil {
-		return sc.DB.Close()
+	if sc.ownsResources && sc.Store != nil {
+		return sc.Store.Close()
 	}
 	return nil
 }
@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"log"
 
-	db "github.com/Johanneslueke/dip-client/internal/database/gen/sqlite"
+	"github.com/Johanneslueke/dip-client/internal/store"
 )
 
 // BatchResponse represents a batch of fetched items
@@ -20,10 +20,10 @@ type BatchResponse struct {
 type FetchBatchFunc func(ctx context.Context, cursor *string) (*BatchResponse, error)
 
 // StoreItemFunc is a function that stores a single item
-type StoreItemFunc func(ctx context.Context, q *db.Queries, item interface{}, failedTracker *FailedRecordsTracker)
+type StoreItemFunc func(ctx context.Context, s store.Store, item interface{}, failedTracker *FailedRecordsTracker)
 
 // UpdateDateFunc is a function that extracts and updates the checkpoint date from an item
-type UpdateDateFunc func(ctx context.Context, q *db.Queries, item interface{}, checkpointMgr *CheckpointManager)
+type UpdateDateFunc func(ctx context.Context, s store.Store, item interface{}, checkpointMgr *CheckpointManager)
 
 // SyncLoop executes the main sync loop with batch fetching and item storage
 func (sc *SyncContext) SyncLoop(
@@ -43,13 +43,19 @@ func (sc *SyncContext) SyncLoop(
 		}
 
 		// Rate limiting
-		// if err := sc.Limiter.Wait(sc.ctx); err != nil {
-		// 	return fmt.Errorf("rate limiter error: %w", err)
-		// }
+		if err := sc.Limiter.Wait(sc.ctx); err != nil {
+			if sc.IsInterrupted() {
+				return nil
+			}
+			return fmt.Errorf("rate limiter error: %w", err)
+		}
 
 		// Fetch batch
 		resp, err := fetchBatch(sc.ctx, cursor)
 		if err != nil {
+			if sc.IsInterrupted() {
+				return nil
+			}
 			return fmt.Errorf("failed to fetch batch: %w", err)
 		}
 
@@ -59,18 +65,17 @@ func (sc *SyncContext) SyncLoop(
 		}
 
 		// Update progress
-		sc.Progress.Total += resp.DocumentsLen
-		sc.Progress.PrintProgress(sc.Progress.Total, resp.NumFound)
+		sc.Progress.PrintProgress(sc.Progress.Total+resp.DocumentsLen, resp.NumFound)
 
 		// Extract and process items
 		items := extractItems(resp.Documents)
 		for _, item := range items {
 			// Store the item
-			storeItem(sc.ctx, sc.Queries, item, sc.FailedTracker)
+			storeItem(sc.ctx, sc.Store, item, sc.FailedTracker)
 
 			// Update checkpoint date if function provided
 			if updateDate != nil {
-				updateDate(sc.ctx, sc.Queries, item, sc.CheckpointMgr)
+				updateDate(sc.ctx, sc.Store, item, sc.CheckpointMgr)
 			}
 
 			// Increment progress
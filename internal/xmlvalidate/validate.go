@@ -0,0 +1,157 @@
+// Package xmlvalidate validates XML documents against their DTD with libxml2.
+// It is used by validate-xml-dtd and sync-plenarprotokoll-xml.
+package xmlvalidate
+
+/*
+#cgo pkg-config: libxml-2.0
+#include <stdarg.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <libxml/parser.h>
+#include <libxml/valid.h>
+#include <libxml/xmlerror.h>
+
+typedef struct {
+	char *buf;
+	size_t len;
+	size_t cap;
+} dipMessages;
+
+static void dip_collect(void *ctx, const char *msg, ...) {
+	dipMessages *m = ctx;
+	va_list ap;
+	int n;
+
+	if (m->len + 1 >= m->cap) {
+		return;
+	}
+	va_start(ap, msg);
+	n = vsnprintf(m->buf + m->len, m->cap - m->len, msg, ap);
+	va_end(ap);
+	if (n > 0) {
+		m->len += (size_t)n;
+		if (m->len >= m->cap) {
+			m->len = m->cap - 1;
+		}
+	}
+}
+
+// dip_validate returns 1 if the document is valid, 0 if it is not valid,
+// -1 if it is not well-formed and -2 if the DTD cannot be loaded.
+// Messages are written to buf.
+static int dip_validate(const char *data, int size, const char *url, const char *dtdPath, char *buf, size_t cap) {
+	dipMessages messages = { buf, 0, cap };
+	int options = XML_PARSE_NONET | XML_PARSE_NOERROR | XML_PARSE_NOWARNING;
+	xmlDocPtr doc;
+	xmlDtdPtr dtd = NULL;
+	xmlValidCtxtPtr vctxt;
+	int valid;
+
+	buf[0] = 0;
+	if (dtdPath == NULL) {
+		options |= XML_PARSE_DTDLOAD;
+	}
+	xmlResetLastError();
+	doc = xmlReadMemory(data, size, url, NULL, options);
+	if (doc == NULL) {
+		const xmlError *err = xmlGetLastError();
+		if (err != NULL && err->message != NULL) {
+			snprintf(buf, cap, "line %d: %s", err->line, err->message);
+		}
+		return -1;
+	}
+
+	if (dtdPath != NULL) {
+		dtd = xmlParseDTD(NULL, (const xmlChar *)dtdPath);
+		if (dtd == NULL) {
+			xmlFreeDoc(doc);
+			return -2;
+		}
+	}
+
+	vctxt = xmlNewValidCtxt();
+	vctxt->userData = &messages;
+	vctxt->error = dip_collect;
+	vctxt->warning = dip_collect;
+	valid = dtd != NULL ? xmlValidateDtd(vctxt, doc, dtd) : xmlValidateDocument(vctxt, doc);
+
+	xmlFreeValidCtxt(vctxt);
+	if (dtd != NULL) {
+		xmlFreeDtd(dtd);
+	}
+	xmlFreeDoc(doc);
+	return valid;
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unsafe"
+)
+
+// maxMessageBytes limits the validation messages kept from libxml2
+const maxMessageBytes = 16 * 1024
+
+// ErrNotWellFormed is returned for documents that cannot be parsed as XML
+var ErrNotWellFormed = errors.New("XML is not well-formed")
+
+// ErrInvalid is returned for documents that do not follow their DTD
+var ErrInvalid = errors.New("XML is not valid according to DTD")
+
+// Validate validates an XML document. If dtdPath is empty, the DTD of the
+// DOCTYPE declaration is loaded, relative to baseURL (e.g. the path of the XML
+// file). Otherwise the document is validated against the DTD at dtdPath,
+// whatever its DOCTYPE declares.
+//
+// Errors wrap ErrNotWellFormed or ErrInvalid, with the messages of libxml2.
+func Validate(data []byte, baseURL, dtdPath string) error {
+	if len(data) == 0 {
+		return fmt.Errorf("%w: empty document", ErrNotWellFormed)
+	}
+
+	cData := C.CBytes(data)
+	defer C.free(cData)
+	var cURL, cDTD *C.char
+	if baseURL != "" {
+		cURL = C.CString(baseURL)
+		defer C.free(unsafe.Pointer(cURL))
+	}
+	if dtdPath != "" {
+		cDTD = C.CString(dtdPath)
+		defer C.free(unsafe.Pointer(cDTD))
+	}
+	buf := (*C.char)(C.malloc(maxMessageBytes))
+	defer C.free(unsafe.Pointer(buf))
+
+	result := C.dip_validate((*C.char)(cData), C.int(len(data)), cURL, cDTD, buf, maxMessageBytes)
+	messages := strings.Join(strings.Fields(strings.ReplaceAll(strings.TrimSpace(C.GoString(buf)), "\n", " | ")), " ")
+	switch result {
+	case 1:
+		return nil
+	case -1:
+		return fmt.Errorf("%w: %s", ErrNotWellFormed, messages)
+	case -2:
+		return fmt.Errorf("failed to load DTD %s", dtdPath)
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalid, messages)
+	}
+}
+
+// ValidateFile validates an XML file, see Validate. A DTD declared by the
+// DOCTYPE is resolved relative to the XML file.
+func ValidateFile(xmlPath, dtdPath string) error {
+	data, err := os.ReadFile(xmlPath)
+	if err != nil {
+		return fmt.Errorf("failed to read XML file: %w", err)
+	}
+	baseURL, err := filepath.Abs(xmlPath)
+	if err != nil {
+		return err
+	}
+	return Validate(data, baseURL, dtdPath)
+}
@@ -0,0 +1,32 @@
+package xmlvalidate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFile(t *testing.T) {
+	// DTD from the DOCTYPE declaration, relative to the XML file
+	require.NoError(t, ValidateFile("testdata/valid.xml", ""))
+
+	err := ValidateFile("testdata/invalid.xml", "")
+	require.ErrorIs(t, err, ErrInvalid)
+	assert.Contains(t, err.Error(), "rede")
+	assert.Contains(t, err.Error(), "wahlperiode")
+
+	// Explicit DTD
+	require.NoError(t, ValidateFile("testdata/valid.xml", "testdata/protokoll.dtd"))
+	assert.ErrorIs(t, ValidateFile("testdata/invalid.xml", "testdata/protokoll.dtd"), ErrInvalid)
+	assert.Error(t, ValidateFile("testdata/valid.xml", "testdata/missing.dtd"))
+}
+
+func TestValidate_NotWellFormed(t *testing.T) {
+	err := Validate([]byte("<protokoll><sitzung></protokoll>"), "", "testdata/protokoll.dtd")
+	require.ErrorIs(t, err, ErrNotWellFormed)
+	assert.Contains(t, err.Error(), "line 1")
+
+	// Without DOCTYPE there is no DTD to validate against
+	assert.ErrorIs(t, Validate([]byte("<protokoll/>"), "", ""), ErrInvalid)
+}
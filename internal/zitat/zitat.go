@@ -0,0 +1,141 @@
+// Package zitat extracts references to other documents from the texts of
+// Drucksachen and Plenarprotokolle, e.g. "Drucksache 20/1234", "BT-Drs. 19/567",
+// "Drucksachen 20/1, 20/2 und 20/3" or "Plenarprotokoll 20/45".
+package zitat
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Johanneslueke/dip-client/internal/protokoll"
+)
+
+// ParserVersion is increased whenever the extraction changes, so that texts are
+// processed again
+const ParserVersion = 1
+
+// Kinds of cited documents
+const (
+	Drucksache      = "Drucksache"
+	Plenarprotokoll = "Plenarprotokoll"
+)
+
+// Publishers of cited documents
+const (
+	Bundestag = "BT"
+	Bundesrat = "BR"
+)
+
+// Zitat is a reference to a document
+type Zitat struct {
+	Dokumentart    string // Drucksache or Plenarprotokoll
+	Herausgeber    string // BT or BR
+	Dokumentnummer string // as in DIP, e.g. "20/1234" (BT) or "123/21" (BR)
+	Offset         int    // offset of the number in the text, in characters
+	Raw            string // the reference as written, e.g. "BT-Drs. 19/567"
+}
+
+var (
+	// Words introducing references; the first group that matches decides the
+	// kind. A plain "Drucksache" in texts of the Bundestag refers to a
+	// Drucksache of the Bundestag.
+	prefixes = []struct {
+		dokumentart string
+		herausgeber string
+		pattern     string
+	}{
+		{Drucksache, Bundesrat, `BR-Drs\.|BR-Drucksachen?|Bundesrats-?[Dd]rucksachen?`},
+		{Drucksache, Bundestag, `BT-Drs\.|BT-Drucksachen?|Bundestags-?[Dd]rucksachen?|Drucksachen?|Drs\.`},
+		{Plenarprotokoll, Bundestag, `BT-PlPr\.|Plenarprotokoll(?:s|en)?|PlPr\.?`},
+	}
+	prefixPattern = compilePrefixPattern()
+
+	// A document number directly after the prefix or a separator, e.g. "20/1234", "Nr. 20/1234"
+	numberPattern = regexp.MustCompile(`^\s*(?:Nr\.\s*)?(\d{1,4})\s*/\s*(\d{1,6})\b`)
+	// Separators between the numbers of a list of references
+	listSeparatorPattern = regexp.MustCompile(`^\s*(?:,|und\b|sowie\b|bzw\.|oder\b)\s*`)
+)
+
+func compilePrefixPattern() *regexp.Regexp {
+	groups := make([]string, len(prefixes))
+	for i, p := range prefixes {
+		groups[i] = "(" + p.pattern + ")"
+	}
+	return regexp.MustCompile(`\b(?:` + strings.Join(groups, "|") + `)`)
+}
+
+// Parse returns the references in text in order of occurrence. A document
+// cited several times yields several Zitate.
+func Parse(text string) []Zitat {
+	var (
+		zitate  []Zitat
+		offsets = protokoll.NewRuneOffsets(text)
+	)
+	for _, loc := range prefixPattern.FindAllStringSubmatchIndex(text, -1) {
+		prefix := prefixes[prefixIndex(loc)]
+		start, pos := loc[0], loc[1]
+		for {
+			m := numberPattern.FindStringSubmatchIndex(text[pos:])
+			if m == nil {
+				break
+			}
+			if nummer, ok := dokumentnummer(prefix.herausgeber, text[pos+m[2]:pos+m[3]], text[pos+m[4]:pos+m[5]]); ok {
+				zitate = append(zitate, Zitat{
+					Dokumentart:    prefix.dokumentart,
+					Herausgeber:    prefix.herausgeber,
+					Dokumentnummer: nummer,
+					Offset:         offsets.At(pos + m[2]),
+					Raw:            strings.Join(strings.Fields(text[start:pos+m[1]]), " "),
+				})
+			}
+			pos += m[1]
+			sep := listSeparatorPattern.FindStringIndex(text[pos:])
+			if sep == nil {
+				break
+			}
+			// Further numbers of a list are recorded as written
+			pos += sep[1]
+			start = pos
+		}
+	}
+	return zitate
+}
+
+// prefixIndex returns the index of the prefix of a match of prefixPattern
+func prefixIndex(loc []int) int {
+	for i := range prefixes {
+		if loc[2+2*i] >= 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+// dokumentnummer normalizes a document number and checks that it is plausible
+// for the publisher: Wahlperiode/number for the Bundestag, number/year for the
+// Bundesrat
+func dokumentnummer(herausgeber, first, second string) (string, bool) {
+	a, err := strconv.Atoi(first)
+	if err != nil {
+		return "", false
+	}
+	b, err := strconv.Atoi(second)
+	if err != nil || a == 0 || b == 0 {
+		return "", false
+	}
+	switch herausgeber {
+	case Bundestag:
+		if a > 30 {
+			return "", false
+		}
+		return fmt.Sprintf("%d/%d", a, b), true
+	case Bundesrat:
+		if len(second) != 2 {
+			return "", false
+		}
+		return fmt.Sprintf("%d/%s", a, second), true
+	}
+	return "", false
+}
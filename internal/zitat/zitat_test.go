@@ -0,0 +1,49 @@
+package zitat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	text := `Beschlussempfehlung und Bericht zu dem Gesetzentwurf der Bundesregierung – Drucksachen 20/1234, 20/1235 und 20/1240 –
+Der Bundesrat hat in seiner Stellungnahme (BR-Drs. 123/23) Änderungen gefordert.
+Vgl. BT-Drs. 19/567 sowie Bundestagsdrucksache 18/09 und die Beratung im Plenarprotokoll 20/45, S. 5123.
+Die Ausschussdrucksache 20(9)123 und die Drucksache 20/1234 (neu) bleiben unberührt.`
+
+	type zitat struct {
+		art, herausgeber, nummer, raw string
+	}
+	var got []zitat
+	for _, z := range Parse(text) {
+		got = append(got, zitat{z.Dokumentart, z.Herausgeber, z.Dokumentnummer, z.Raw})
+	}
+	assert.Equal(t, []zitat{
+		{Drucksache, Bundestag, "20/1234", "Drucksachen 20/1234"},
+		{Drucksache, Bundestag, "20/1235", "20/1235"},
+		{Drucksache, Bundestag, "20/1240", "20/1240"},
+		{Drucksache, Bundesrat, "123/23", "BR-Drs. 123/23"},
+		{Drucksache, Bundestag, "19/567", "BT-Drs. 19/567"},
+		{Drucksache, Bundestag, "18/9", "Bundestagsdrucksache 18/09"},
+		{Plenarprotokoll, Bundestag, "20/45", "Plenarprotokoll 20/45"},
+		{Drucksache, Bundestag, "20/1234", "Drucksache 20/1234"},
+	}, got)
+}
+
+func TestParse_Offsets(t *testing.T) {
+	text := "Änderungsantrag zur Drucksache\n20/4711"
+	zitate := Parse(text)
+	require.Len(t, zitate, 1)
+	assert.Equal(t, "Drucksache 20/4711", zitate[0].Raw)
+	assert.Equal(t, "20/4711", string([]rune(text)[zitate[0].Offset:zitate[0].Offset+7]))
+}
+
+func TestParse_Implausible(t *testing.T) {
+	// Bundestag numbers start with the Wahlperiode, Bundesrat numbers end with a two-digit year
+	assert.Empty(t, Parse("Drucksache 123/21"))
+	assert.Empty(t, Parse("BR-Drucksache 123/2021"))
+	assert.Empty(t, Parse("Drucksache 0/12"))
+	assert.Empty(t, Parse("Druckerzeugnisse 20/1"))
+}